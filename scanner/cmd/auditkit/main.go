@@ -1,19 +1,26 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
+	_ "time/tzdata" // -timezone works on systems without a zoneinfo database
 
 	gcpScanner "github.com/guardian-nexus/auditkit/scanner/pkg/gcp"
 	awsScanner "github.com/guardian-nexus/auditkit/scanner/pkg/aws"
+	awsChecks "github.com/guardian-nexus/auditkit/scanner/pkg/aws/checks"
 	azureScanner "github.com/guardian-nexus/auditkit/scanner/pkg/azure"
 	"github.com/guardian-nexus/auditkit/scanner/pkg/cli"
+	"github.com/guardian-nexus/auditkit/scanner/pkg/core"
+	"github.com/guardian-nexus/auditkit/scanner/pkg/evidence"
 	"github.com/guardian-nexus/auditkit/scanner/pkg/integrations"
 	"github.com/guardian-nexus/auditkit/scanner/pkg/integrations/prowler"
 	"github.com/guardian-nexus/auditkit/scanner/pkg/integrations/scubagear"
@@ -38,20 +45,30 @@ type ComplianceResult struct {
 	FailedControls  int             `json:"failed_controls"`
 	Controls        []ControlResult `json:"controls"`
 	Recommendations []string        `json:"recommendations"`
+	NotAssessed     []string        `json:"not_assessed,omitempty"`
+	SkippedChecks   []string        `json:"skipped_checks,omitempty"`
+	Metadata        *core.ScanMetadata `json:"metadata,omitempty"`
 }
 
 type ControlResult struct {
 	ID                string            `json:"id"`
 	Name              string            `json:"name"`
 	Category          string            `json:"category"`
+	Region            string            `json:"region,omitempty"`            // region scanned (AWS)
 	Severity          string            `json:"severity,omitempty"`
+	OriginalSeverity  string            `json:"original_severity,omitempty"` // Default severity when overridden
+	Environment       string            `json:"environment,omitempty"`       // prod, staging or dev, from resource tags or the environment policy
+	FindingID         string            `json:"finding_id,omitempty"`        // stable across scans, for ticketing and diffing (AWS)
 	Status            string            `json:"status"`
 	Evidence          string            `json:"evidence"`
+	AffectedResources []string          `json:"affected_resources,omitempty"` // every failing resource; Evidence may list only some (AWS)
 	Remediation       string            `json:"remediation,omitempty"`
 	RemediationDetail string            `json:"remediation_detail,omitempty"`
+	RequiresRecreate  bool              `json:"requires_recreate,omitempty"` // Fix means rebuilding the resource
 	Priority          string            `json:"priority,omitempty"`
 	Impact            string            `json:"impact,omitempty"`
-	ScreenshotGuide   string            `json:"screenshot_guide,omitempty"`
+	ScreenshotGuide   string              `json:"screenshot_guide,omitempty"`
+	EvidenceSteps     *evidence.Checklist `json:"evidence_steps,omitempty"`
 	ConsoleURL        string            `json:"console_url,omitempty"`
 	Frameworks        map[string]string `json:"frameworks,omitempty"`
 }
@@ -76,8 +93,8 @@ func main() {
 		provider  = flag.String("provider", "aws", "Cloud provider: aws, azure, gcp")
 		profile   = flag.String("profile", "default", "AWS profile, Azure subscription, or GCP project ID")
 		framework = flag.String("framework", "all", "Compliance framework: soc2, pci, cmmc, hipaa, gdpr, nist-csf, all")
-		format    = flag.String("format", "text", "Output format (text, json, html, pdf, csv)")
-		output    = flag.String("output", "", "Output file (default: stdout)")
+		format    = flag.String("format", "text", "Output format (text, json, ndjson, html, pdf, csv)")
+		output    = flag.String("output", "", "Output file (default: stdout); {provider}, {account}, {framework} and {timestamp} expand from the scan")
 		verbose   = flag.Bool("verbose", false, "Verbose output")
 		full      = flag.Bool("full", false, "Show all controls in text output (default: truncated for readability)")
 		services  = flag.String("services", "all", "Comma-separated services to scan")
@@ -85,6 +102,27 @@ func main() {
 		file      = flag.String("file", "", "Integration file to parse")
 		offlineMode = flag.Bool("offline", false, "Use cached scan results (no cloud API calls)")
 		cacheFile   = flag.String("cache-file", "", "Load scan from specific cache file")
+		maxCacheAge = flag.Duration("max-cache-age", offline.DefaultMaxCacheAge, "Refuse offline scans older than this (0 disables)")
+		includePassing = flag.Bool("include-passing", false, "Include PASS controls in output (default: JSON/CSV/HTML yes, terminal no)")
+		customChecks   = flag.String("custom-checks", "", "YAML file of custom checks (AWS: redshift_cluster, s3_bucket)")
+		severityOverrides = flag.String("severity-overrides", "", "YAML file overriding check severities by control (AWS)")
+		baseline       = flag.String("baseline", "", "JSON scan (from -format json or the offline cache) to compare against; exit 1 only on new findings")
+		estimate       = flag.Bool("estimate", false, "Dry run: estimate AWS API calls per service without scanning")
+		timezone       = flag.String("timezone", "UTC", "Timezone for report timestamps (IANA name, e.g. America/New_York)")
+		width          = flag.Int("width", 0, "Terminal output width for boxes and tables (0 detects the terminal)")
+		assumeRole     = flag.String("assume-role", "", "IAM role ARN to assume for the scan, using -profile's credentials (AWS)")
+		checksConfig   = flag.String("checks-config", "", "YAML file enabling/disabling individual checks by ID (AWS)")
+		resource       = flag.String("resource", "", "Spot-check named resources only, e.g. redshift:analytics,opensearch:logs (AWS)")
+		theme          = flag.String("theme", "", "Terminal color theme: dark, light, high-contrast, mono (default $AUDITKIT_THEME or dark)")
+		scoreThresholds = flag.String("score-thresholds", "", "Score color breakpoints excellent,good,fair (default 90,80,60)")
+		scoreGradient  = flag.Bool("score-gradient", false, "Color scores along a red-to-green gradient on 256-color terminals instead of the theme's bands")
+		environmentPolicy = flag.String("environment-policy", "", "YAML file classifying resources by environment tag and down-weighting non-prod findings (AWS)")
+		baselineBudget = flag.String("baseline-budget", "", "New findings allowed per severity with -baseline, e.g. low=5,medium=1 (default none)")
+		evidenceLimit  = flag.Int("evidence-limit", 0, "Resource IDs listed per finding's evidence; -1 lists all (default 10 on the terminal, all in file reports) (AWS)")
+		maxRPS         = flag.Float64("max-rps", 0, "Cap on API calls per second across all services and regions (0 is unlimited) (AWS)")
+		regions        = flag.String("regions", "", "Comma-separated regions to scan, or all for every enabled region (default: the profile's region) (AWS)")
+		failFast       = flag.Bool("fail-fast", false, "Stop the scan at the first CRITICAL finding (AWS)")
+		fallbackToCache = flag.Bool("fallback-to-cache", false, "Report the latest cached scan, marked stale, if the live scan fails entirely (AWS)")
 	)
 
 	if len(os.Args) < 2 {
@@ -94,10 +132,46 @@ func main() {
 
 	command := os.Args[1]
 	flag.CommandLine.Parse(os.Args[2:])
+	cli.SetWidth(*width)
+	if *theme != "" {
+		if err := cli.SetTheme(*theme); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	if *scoreThresholds != "" {
+		thresholds, err := cli.ParseScoreThresholds(*scoreThresholds)
+		if err == nil {
+			err = cli.SetScoreThresholds(thresholds)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	cli.SetScoreGradient(*scoreGradient)
+
+	// Per-format default unless -include-passing was given explicitly
+	reportOpts := report.DefaultOptions(*format)
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "include-passing" {
+			reportOpts.IncludePassing = *includePassing
+		}
+	})
+	location, err := time.LoadLocation(*timezone)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid -timezone %q: %v\n", *timezone, err)
+		os.Exit(1)
+	}
+	reportOpts.Location = location
 
 	switch command {
 	case "scan":
-		runScan(*provider, *profile, *framework, *format, *output, *verbose, *full, *services, *offlineMode, *cacheFile)
+		if *estimate {
+			runEstimate(*provider, *profile, *framework)
+			return
+		}
+		runScan(*provider, *profile, *framework, *format, *output, *verbose, *full, *services, *offlineMode, *cacheFile, *maxCacheAge, reportOpts, *customChecks, *severityOverrides, *baseline, *assumeRole, *checksConfig, *resource, *environmentPolicy, *evidenceLimit, *baselineBudget, *maxRPS, *regions, *failFast, *fallbackToCache)
 	case "integrate":
 		runIntegration(*source, *file, *format, *output, *verbose)
 	case "evidence":
@@ -109,7 +183,17 @@ func main() {
 	case "compare":
 		compareScan(*provider, *profile)
 	case "cache":
-		runCacheCommand(*provider, *profile, *framework, *verbose)
+		runCacheCommand(*format)
+	case "schema":
+		outputSchema(*output)
+	case "manifest":
+		outputManifest(*output)
+	case "doctor":
+		runDoctor(*verbose)
+	case "badge":
+		outputBadge(*provider, *profile, *framework, *cacheFile, *output)
+	case "browse":
+		browseScan(*provider, *profile, *framework, *cacheFile)
 	case "update":
 		updater.CheckForUpdates()
 	case "version":
@@ -131,7 +215,12 @@ Usage:
   auditkit fix [options]         Generate remediation script
   auditkit progress              Show compliance improvement over time
   auditkit compare               Compare last two scans
-  auditkit cache [options]       Manage offline scan cache
+  auditkit cache [-format json]  Manage offline scan cache
+  auditkit schema [-output f]    Print the JSON Schema for -format json reports
+  auditkit manifest [-output f]  Print every AWS check as JSON, without scanning
+  auditkit doctor [-verbose]     Smoke-test every AWS check module against stub clients
+  auditkit badge [-output f]     Write an SVG score badge from the latest cached scan
+  auditkit browse                Browse the latest cached scan's findings interactively
   auditkit update                Check for updates
   auditkit version               Show version
 
@@ -139,8 +228,10 @@ Options:
   -provider string   Cloud provider: aws, azure, gcp (default "aws")
   -profile string    AWS profile, Azure subscription, or GCP project (default "default")
   -framework string  Compliance framework: soc2, pci, cmmc, hipaa, gdpr, nist-csf, 800-53, all (default "all")
-  -format string     Output format (text, json, html, pdf, csv) (default "text")
-  -output string     Output file (default: stdout)
+  -format string     Output format (text, json, ndjson, html, pdf, csv) (default "text")
+  -output string     Output file (default: stdout); {provider}, {account}, {framework}
+                     and {timestamp} expand from the scan, creating directories,
+                     e.g. reports/{provider}-{account}-{framework}-{timestamp}.json
   -services string   Services to scan (default "all")
   -source string     Integration source: scubagear, prowler
   -file string       File to parse for integration
@@ -148,6 +239,27 @@ Options:
   -full             Show all controls in text output (default: truncated)
   -offline          Use cached scan results (no cloud API calls)
   -cache-file       Load scan from specific cache file
+  -max-cache-age    Refuse offline scans older than this, e.g. 48h (default 24h, 0 disables)
+  -include-passing  Include PASS controls (default: true for json/csv/html, false for terminal)
+  -custom-checks    YAML file of org-specific checks (AWS Redshift clusters and S3 buckets)
+  -severity-overrides YAML file re-rating check severities by control/check name (AWS)
+  -baseline         Previous JSON scan; report only new findings and exit 1 if any exceed -baseline-budget (for CI)
+  -baseline-budget  New findings -baseline tolerates per severity, e.g. low=5,medium=1; others allow none
+  -estimate         Dry run: print expected AWS API calls per service, then exit
+  -timezone         Timezone for JSON/CSV/HTML timestamps, ISO 8601 (default UTC)
+  -width            Output width for summary boxes and tables (default: terminal width, 80 when piped)
+  -assume-role      IAM role ARN to scan as, e.g. a cross-account audit role (AWS)
+  -checks-config    YAML file turning individual checks off, e.g. redshift.cluster_enhanced_vpc_routing: false (AWS)
+  -resource         Check only these resources, e.g. redshift:analytics,opensearch:logs; not saved to the cache (AWS)
+  -theme            Terminal colors: dark, light, high-contrast, mono (default $AUDITKIT_THEME or dark)
+  -score-thresholds Score color breakpoints excellent,good,fair, e.g. 95,85,70 (default 90,80,60)
+  -score-gradient   Color scores red-to-green on 256-color terminals, ignored with -score-thresholds
+  -environment-policy YAML file mapping environment tags (prod/staging/dev) to severity downgrades, e.g. dev CRITICAL → MEDIUM (AWS)
+  -evidence-limit   Resource IDs listed per finding, -1 for all (default 10 on the terminal, all in -output/-format files) (AWS)
+  -max-rps          Shared cap on API calls per second across services, regions and concurrent checks, e.g. 20 (AWS)
+  -regions          Regions to scan, e.g. us-east-1,eu-west-1, or all for every enabled region (default: the profile's region) (AWS)
+  -fail-fast        Stop at the first CRITICAL finding; the partial scan is reported but not cached (AWS)
+  -fallback-to-cache Report the latest cached scan, marked stale, when the live scan fails entirely (AWS)
 
 Frameworks:
   soc2      SOC2 Type II Common Criteria (full coverage)
@@ -189,10 +301,17 @@ Examples:
 
   # Generate PDF report
   auditkit scan -format pdf -output report.pdf
+
+  # One JSON report per account and framework in a directory tree
+  auditkit scan -profile prod -format json -output 'reports/{provider}/{account}/{framework}-{timestamp}.json'
   
   # Show all controls (not truncated)
   auditkit scan -provider aws -framework cmmc --full
 
+  # Encrypt cached scans at rest
+  export AUDITKIT_CACHE_PASSPHRASE='correct horse battery staple'
+  auditkit scan -provider aws -framework soc2
+
 For more information: https://github.com/guardian-nexus/auditkit`)
 }
 
@@ -504,7 +623,18 @@ func printIntegrationSummary(result ComplianceResult) {
 	fmt.Printf("\n")
 }
 
-func runOfflineScan(provider, profile, framework, format, output string, verbose, full bool, cacheFile string) {
+// offlineAccountID returns the account whose cached scans offline commands
+// read: $AWS_ACCOUNT_ID for the default AWS profile, else the profile
+func offlineAccountID(provider, profile string) string {
+	if provider == "aws" && profile == "default" {
+		if awsAccount := os.Getenv("AWS_ACCOUNT_ID"); awsAccount != "" {
+			return awsAccount
+		}
+	}
+	return profile
+}
+
+func runOfflineScan(provider, profile, framework, format, output string, verbose, full bool, cacheFile string, maxCacheAge time.Duration, opts report.Options) {
 	cache, err := offline.NewCache()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error initializing cache: %v\n", err)
@@ -521,14 +651,7 @@ func runOfflineScan(provider, profile, framework, format, output string, verbose
 			os.Exit(1)
 		}
 	} else {
-		// Try to get account ID from environment or use profile
-		accountID := profile
-		if provider == "aws" && profile == "default" {
-			// Try to get AWS account ID from environment
-			if awsAccount := os.Getenv("AWS_ACCOUNT_ID"); awsAccount != "" {
-				accountID = awsAccount
-			}
-		}
+		accountID := offlineAccountID(provider, profile)
 
 		// Load latest cached scan
 		cachedScan, err = cache.LoadLatest(provider, accountID, framework)
@@ -542,16 +665,21 @@ func runOfflineScan(provider, profile, framework, format, output string, verbose
 		}
 	}
 
+	if err := offline.CheckFreshness(cachedScan, maxCacheAge); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		fmt.Fprintf(os.Stderr, "\nRun a fresh scan to refresh the cache:\n")
+		fmt.Fprintf(os.Stderr, "  auditkit scan -provider %s -framework %s\n\n", provider, framework)
+		fmt.Fprintf(os.Stderr, "Or accept older data with -max-cache-age (e.g. -max-cache-age 72h, or 0 to disable)\n")
+		os.Exit(1)
+	}
+
 	if verbose {
 		fmt.Printf("Loading cached scan from %s\n", cachedScan.Timestamp.Format(time.RFC3339))
-		age := time.Since(cachedScan.Timestamp)
-		if age > 24*time.Hour {
-			fmt.Printf("Warning: Cached scan is %.0f hours old\n", age.Hours())
-		}
 	}
 
 	// Convert cached scan to ComplianceResult
 	result := convertCachedToComplianceResult(cachedScan)
+	output = expandOutputPath(output, *cachedScan)
 
 	// Display offline mode indicator
 	fmt.Printf("\n%s[OFFLINE MODE]%s Loading cached scan from %s\n",
@@ -562,7 +690,7 @@ func runOfflineScan(provider, profile, framework, format, output string, verbose
 	switch format {
 	case "text":
 		if output == "" {
-			printTextSummary(result, full)
+			printTextSummary(result, full, opts)
 		} else {
 			outputTextToFile(result, output)
 		}
@@ -578,6 +706,7 @@ func runOfflineScan(provider, profile, framework, format, output string, verbose
 			Controls:        convertControlsForPDF(result.Controls),
 			Recommendations: result.Recommendations,
 			Framework:       result.Framework,
+			Metadata:        result.Metadata,
 		}
 
 		if output == "" {
@@ -594,11 +723,11 @@ func runOfflineScan(provider, profile, framework, format, output string, verbose
 		}
 		fmt.Printf("PDF report saved to %s (from cached scan)\n", output)
 	case "json":
-		outputJSON(result, output)
+		outputJSON(result, output, opts)
 	case "html":
-		outputHTML(result, output)
+		outputHTML(result, output, opts)
 	case "csv":
-		outputCSV(result, output)
+		outputCSV(result, output, opts)
 	default:
 		fmt.Fprintf(os.Stderr, "Unknown format: %s\n", format)
 		os.Exit(1)
@@ -612,14 +741,21 @@ func convertCachedToComplianceResult(cached *offline.CachedScan) ComplianceResul
 			ID:                c.ID,
 			Name:              c.Name,
 			Category:          c.Category,
+			Region:            c.Region,
 			Severity:          c.Severity,
+			OriginalSeverity:  c.OriginalSeverity,
+			Environment:       c.Environment,
+			FindingID:         c.FindingID,
 			Status:            c.Status,
 			Evidence:          c.Evidence,
+			AffectedResources: c.AffectedResources,
 			Remediation:       c.Remediation,
 			RemediationDetail: c.RemediationDetail,
+			RequiresRecreate:  c.RequiresRecreate,
 			Priority:          c.Priority,
 			Impact:            c.Impact,
 			ScreenshotGuide:   c.ScreenshotGuide,
+			EvidenceSteps:     evidence.ParseGuide(c.ScreenshotGuide, c.ConsoleURL),
 			ConsoleURL:        c.ConsoleURL,
 			Frameworks:        c.Frameworks,
 		})
@@ -636,6 +772,8 @@ func convertCachedToComplianceResult(cached *offline.CachedScan) ComplianceResul
 		FailedControls:  cached.FailedControls,
 		Controls:        controls,
 		Recommendations: cached.Recommendations,
+		SkippedChecks:   cached.SkippedChecks,
+		Metadata:        cached.Metadata,
 	}
 }
 
@@ -645,17 +783,43 @@ func saveScanToCache(result ComplianceResult, version string) error {
 		return err
 	}
 
+	return cache.Save(toCachedScan(result, version))
+}
+
+// loadPreviousScan returns the latest cached scan of the same account and
+// framework as current, or nil if there is none
+func loadPreviousScan(current offline.CachedScan) *offline.CachedScan {
+	cache, err := offline.NewCache()
+	if err != nil {
+		return nil
+	}
+
+	previous, err := cache.LoadLatest(current.Provider, current.AccountID, current.Framework)
+	if err != nil {
+		return nil
+	}
+	return previous
+}
+
+// toCachedScan converts a scan result to the offline cache format
+func toCachedScan(result ComplianceResult, version string) offline.CachedScan {
 	cachedControls := []offline.CachedControl{}
 	for _, c := range result.Controls {
 		cachedControls = append(cachedControls, offline.CachedControl{
 			ID:                c.ID,
 			Name:              c.Name,
 			Category:          c.Category,
+			Region:            c.Region,
 			Severity:          c.Severity,
+			OriginalSeverity:  c.OriginalSeverity,
+			Environment:       c.Environment,
+			FindingID:         c.FindingID,
 			Status:            c.Status,
 			Evidence:          c.Evidence,
+			AffectedResources: c.AffectedResources,
 			Remediation:       c.Remediation,
 			RemediationDetail: c.RemediationDetail,
+			RequiresRecreate:  c.RequiresRecreate,
 			Priority:          c.Priority,
 			Impact:            c.Impact,
 			ScreenshotGuide:   c.ScreenshotGuide,
@@ -676,41 +840,49 @@ func saveScanToCache(result ComplianceResult, version string) error {
 		Controls:        cachedControls,
 		Recommendations: result.Recommendations,
 		Version:         version,
+		SkippedChecks:   result.SkippedChecks,
+		Metadata:        result.Metadata,
+	}
+	if result.Metadata != nil && result.Metadata.Identity != nil {
+		cachedScan.SetIdentity(*result.Metadata.Identity)
 	}
 
-	return cache.Save(cachedScan)
+	return cachedScan
 }
 
-func runCacheCommand(provider, profile, framework string, verbose bool) {
+func runCacheCommand(format string) {
 	cache, err := offline.NewCache()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error initializing cache: %v\n", err)
 		os.Exit(1)
 	}
 
-	info, err := cache.GetCacheInfo()
+	info, err := cache.Info()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error reading cache: %v\n", err)
 		os.Exit(1)
 	}
 
+	if format == "json" {
+		if err := info.WriteJSON(os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing cache info: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	fmt.Println("\nAuditKit Offline Cache")
 	fmt.Println("======================")
-	fmt.Printf("Cache location: %s\n", info["cache_path"])
-	fmt.Printf("Total cached files: %v\n", info["total_files"])
+	fmt.Printf("Cache location: %s\n", info.Path)
+	fmt.Printf("Total cached files: %d\n", info.TotalFiles)
 
-	scans, ok := info["scans"].([]map[string]interface{})
-	if ok && len(scans) > 0 {
+	if len(info.Scans) > 0 {
 		fmt.Println("\nCached Scans:")
-		for _, scan := range scans {
-			ts, ok := scan["timestamp"].(time.Time)
-			age := ""
-			if ok {
-				age = time.Since(ts).Round(time.Minute).String() + " ago"
-			}
+		for _, scan := range info.Scans {
+			age := time.Since(scan.Timestamp).Round(time.Minute).String() + " ago"
 			fmt.Printf("  - %s | %s | %s | Score: %.1f%% | %s\n",
-				scan["provider"], scan["framework"], scan["account"],
-				scan["score"], age)
+				scan.Provider, scan.Framework, scan.AccountID,
+				scan.Score, age)
 		}
 	} else {
 		fmt.Println("\nNo cached scans found.")
@@ -723,10 +895,104 @@ func runCacheCommand(provider, profile, framework string, verbose bool) {
 	fmt.Println()
 }
 
-func runScan(provider, profile, framework, format, output string, verbose bool, full bool, services string, offlineMode bool, cacheFile string) {
+// runEstimate prints the expected API call count per check module so users can
+// gauge throttling risk before scanning a production account
+func runEstimate(provider, profile, framework string) {
+	if provider != "aws" {
+		fmt.Fprintf(os.Stderr, "Error: -estimate is only supported for AWS\n")
+		os.Exit(1)
+	}
+
+	scanner, err := awsScanner.NewScanner(profile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error initializing AWS scanner: %v\n", err)
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+	estimates := scanner.EstimateCalls(ctx, framework)
+
+	cli.Header(fmt.Sprintf("API Call Estimate: %s (%s)", strings.ToUpper(framework), scanner.GetAccountID(ctx)))
+	fmt.Printf("  %-40s %10s %10s\n", "Service", "Resources", "Calls")
+	for _, e := range estimates {
+		if !e.Known {
+			fmt.Printf("  %-40s %10s %10s\n", e.Service, "-", "-")
+			continue
+		}
+		fmt.Printf("  %-40s %10d %10d\n", e.Service, e.Resources, e.Calls)
+	}
+
+	calls, unknown := awsChecks.TotalCalls(estimates)
+	fmt.Printf("\nEstimated API calls: %d", calls)
+	if unknown > 0 {
+		fmt.Printf(" (plus %d services that do not report an estimate)", unknown)
+	}
+	fmt.Println()
+}
+
+// enforceBaseline reports findings that are new since the baseline scan and
+// exits 1 when they exceed budget, so CI fails only on introduced
+// misconfigurations. The empty budget allows no new findings at all.
+func enforceBaseline(result ComplianceResult, baselineFile string, budget awsChecks.DeltaBudget) {
+	data, err := os.ReadFile(baselineFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading baseline: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Scan JSON output and cached scans share the controls layout
+	var baseline ComplianceResult
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing baseline %s: %v\n", baselineFile, err)
+		os.Exit(1)
+	}
+
+	newFindings := awsChecks.NewFindings(controlsToCheckResults(result.Controls), controlsToCheckResults(baseline.Controls))
+	if len(newFindings) == 0 {
+		fmt.Fprintf(os.Stderr, "\nNo new findings since baseline (%s)\n", baseline.Timestamp.Format("2006-01-02 15:04"))
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "\n%d new findings since baseline (%s):\n", len(newFindings), baseline.Timestamp.Format("2006-01-02 15:04"))
+	for _, finding := range newFindings {
+		fmt.Fprintf(os.Stderr, "  [%s] %s: %s\n", finding.Severity, finding.Control, finding.Evidence)
+	}
+
+	verdict := awsChecks.EvaluateDeltaBudget(newFindings, budget)
+	fmt.Fprintln(os.Stderr, "\nNew-finding budget:")
+	for _, line := range verdict.Explanation {
+		fmt.Fprintf(os.Stderr, "  %s\n", line)
+	}
+	if verdict.Passed {
+		fmt.Fprintln(os.Stderr, "New findings are within budget")
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Budget exceeded for %s\n", strings.Join(verdict.Exceeded, ", "))
+	os.Exit(1)
+}
+
+// controlsToCheckResults adapts report controls for the checks package's
+// finding comparisons
+func controlsToCheckResults(controls []ControlResult) []awsChecks.CheckResult {
+	results := make([]awsChecks.CheckResult, 0, len(controls))
+	for _, control := range controls {
+		results = append(results, awsChecks.CheckResult{
+			Control:           control.ID,
+			Name:              control.Name,
+			Region:            control.Region,
+			Status:            control.Status,
+			Evidence:          control.Evidence,
+			AffectedResources: control.AffectedResources,
+			Severity:          control.Severity,
+		})
+	}
+	return results
+}
+
+func runScan(provider, profile, framework, format, output string, verbose bool, full bool, services string, offlineMode bool, cacheFile string, maxCacheAge time.Duration, opts report.Options, customChecksFile string, overridesFile string, baselineFile string, assumeRoleARN string, checksConfigFile string, resourceSpec string, environmentPolicyFile string, evidenceLimit int, budgetSpec string, maxRPS float64, regionsSpec string, failFast bool, fallbackToCache bool) {
 	// Handle offline mode
 	if offlineMode || cacheFile != "" {
-		runOfflineScan(provider, profile, framework, format, output, verbose, full, cacheFile)
+		runOfflineScan(provider, profile, framework, format, output, verbose, full, cacheFile, maxCacheAge, opts)
 		return
 	}
 
@@ -762,22 +1028,68 @@ func runScan(provider, profile, framework, format, output string, verbose bool,
 		os.Exit(1)
 	}
 
+	// Check the budget before scanning rather than after
+	budget, err := awsChecks.ParseDeltaBudget(budgetSpec)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid -baseline-budget: %v\n", err)
+		os.Exit(1)
+	}
+	if maxRPS < 0 {
+		fmt.Fprintf(os.Stderr, "Error: -max-rps must be 0 (unlimited) or a positive rate, got %g\n", maxRPS)
+		os.Exit(1)
+	}
+
 	if verbose {
 		fmt.Fprintf(os.Stderr, "Starting %s compliance scan for %s...\n", 
 			strings.ToUpper(framework), provider)
 	}
 
-	result := performScan(provider, profile, framework, verbose, services)
+	// Terminal output keeps Evidence lists short; file reports list every
+	// resource unless -evidence-limit says otherwise
+	if evidenceLimit == 0 && (format != "text" || output != "") {
+		evidenceLimit = awsChecks.NoEvidenceListLimit
+	}
+	awsChecks.SetEvidenceListLimit(evidenceLimit)
 
-	saveProgress(result.AccountID, result.Score, result.Controls, framework)
+	// NDJSON streams raw check results as modules finish instead of building
+	// the scored report
+	if format == "ndjson" {
+		if offline.IsPathTemplate(output) {
+			fmt.Fprintf(os.Stderr, "Error: -output templates are not supported with -format ndjson, which writes before the scan finishes\n")
+			os.Exit(1)
+		}
+		if regionsSpec != "" || failFast || fallbackToCache {
+			fmt.Fprintf(os.Stderr, "Error: -regions, -fail-fast and -fallback-to-cache are not supported with -format ndjson\n")
+			os.Exit(1)
+		}
+		streamNDJSONScan(provider, profile, framework, output, assumeRoleARN, customChecksFile, overridesFile, checksConfigFile, resourceSpec, environmentPolicyFile, maxRPS)
+		return
+	}
 
-	// Save to offline cache for later offline use
-	if err := saveScanToCache(result, CurrentVersion); err != nil {
-		if verbose {
-			fmt.Printf("Note: Could not save to offline cache: %v\n", err)
+	result := performScan(provider, profile, framework, verbose, services, customChecksFile, overridesFile, assumeRoleARN, checksConfigFile, resourceSpec, environmentPolicyFile, maxRPS, regionsSpec, failFast, fallbackToCache, evidenceLimit)
+
+	// Read the previous scan before this one replaces it as latest
+	currentScan := toCachedScan(result, CurrentVersion)
+	output = expandOutputPath(output, currentScan)
+	var previousScan *offline.CachedScan
+
+	// A spot check covers a few resources, not the account, so it must not
+	// replace the account's score history or latest cached scan. Neither
+	// must a scan stopped early or one served stale from the cache.
+	partial := result.Metadata != nil && (result.Metadata.Aborted || result.Metadata.Stale)
+	if resourceSpec == "" && !partial {
+		saveProgress(result.AccountID, result.Score, result.Controls, framework)
+
+		previousScan = loadPreviousScan(currentScan)
+
+		// Save to offline cache for later offline use
+		if err := saveScanToCache(result, CurrentVersion); err != nil {
+			if verbose {
+				fmt.Printf("Note: Could not save to offline cache: %v\n", err)
+			}
+		} else if verbose {
+			fmt.Println("Scan saved to offline cache")
 		}
-	} else if verbose {
-		fmt.Println("Scan saved to offline cache")
 	}
 
 	automatedChecks := result.PassedControls + result.FailedControls
@@ -819,7 +1131,10 @@ func runScan(provider, profile, framework, format, output string, verbose bool,
 	switch format {
 	case "text":
 		if output == "" {
-			printTextSummary(result, full)
+			printTextSummary(result, full, opts)
+			if delta := cli.ScanDelta(&currentScan, previousScan); delta != "" {
+				fmt.Printf("\n%s", delta)
+			}
 		} else {
 			outputTextToFile(result, output)
 		}
@@ -835,6 +1150,7 @@ func runScan(provider, profile, framework, format, output string, verbose bool,
 			Controls:        convertControlsForPDF(result.Controls),
 			Recommendations: result.Recommendations,
 			Framework:       result.Framework,
+			Metadata:        result.Metadata,
 		}
 
 		if output == "" {
@@ -852,22 +1168,129 @@ func runScan(provider, profile, framework, format, output string, verbose bool,
 		fmt.Printf("PDF report saved to %s\n", output)
 		fmt.Printf("Review failed controls for screenshot requirements\n")
 	case "json":
-		outputJSON(result, output)
+		outputJSON(result, output, opts)
 	case "html":
-		outputHTML(result, output)
+		outputHTML(result, output, opts)
 	case "csv":
-		outputCSV(result, output)
+		outputCSV(result, output, opts)
 	default:
 		fmt.Fprintf(os.Stderr, "Unknown format: %s\n", format)
 		os.Exit(1)
 	}
+
+	if baselineFile != "" {
+		enforceBaseline(result, baselineFile, budget)
+	}
+}
+
+// expandOutputPath expands an -output template from scan (see
+// offline.ExpandReportPath), exiting on an unknown token; plain paths are
+// returned unchanged
+func expandOutputPath(output string, scan offline.CachedScan) string {
+	if !offline.IsPathTemplate(output) {
+		return output
+	}
+	path, err := offline.ExpandReportPath(output, scan)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid -output: %v\n", err)
+		os.Exit(1)
+	}
+	return path
 }
 
-func performScan(provider, profile, framework string, verbose bool, services string) ComplianceResult {
+// streamNDJSONScan runs an AWS scan through RunStream and writes each check
+// result as one JSON line as soon as its module finishes, for log pipelines
+func streamNDJSONScan(provider, profile, framework, output, assumeRoleARN, customChecksFile, overridesFile, checksConfigFile, resourceSpec, environmentPolicyFile string, maxRPS float64) {
+	if provider != "aws" {
+		fmt.Fprintf(os.Stderr, "Error: -format ndjson is only supported for AWS\n")
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+	clients, err := awsScanner.NewClientSet(ctx, awsScanner.ClientOptions{Profile: profile, AssumeRoleARN: assumeRoleARN, MaxCallsPerSecond: maxRPS})
+	if err == nil {
+		err = awsScanner.ValidateCredentials(ctx, clients)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error initializing AWS scanner: %v\n", err)
+		os.Exit(1)
+	}
+	scanner := awsScanner.NewScannerWithClients(clients)
+
+	if customChecksFile != "" {
+		defs, err := awsChecks.LoadCustomChecks(customChecksFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading custom checks: %v\n", err)
+			os.Exit(1)
+		}
+		scanner.SetCustomChecks(defs)
+	}
+	if overridesFile != "" {
+		overrides, err := awsChecks.LoadSeverityOverrides(overridesFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading severity overrides: %v\n", err)
+			os.Exit(1)
+		}
+		scanner.SetSeverityOverrides(overrides)
+	}
+	if environmentPolicyFile != "" {
+		policy, err := awsChecks.LoadEnvironmentPolicy(environmentPolicyFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading environment policy: %v\n", err)
+			os.Exit(1)
+		}
+		scanner.SetEnvironmentPolicy(policy)
+	}
+	if checksConfigFile != "" {
+		disabled, err := awsChecks.LoadCheckConfig(checksConfigFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading checks config: %v\n", err)
+			os.Exit(1)
+		}
+		scanner.SetDisabledChecks(disabled)
+	}
+	if resourceSpec != "" {
+		filter, err := awsChecks.ParseResourceFilter(resourceSpec)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid -resource: %v\n", err)
+			os.Exit(1)
+		}
+		scanner.SetResourceFilter(filter)
+	}
+
+	var w io.Writer = os.Stdout
+	if output != "" {
+		file, err := os.Create(output)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating output file: %v\n", err)
+			os.Exit(1)
+		}
+		defer file.Close()
+		w = file
+	}
+
+	stream, errs := scanner.RunStream(ctx, framework)
+	if err := report.StreamNDJSON(w, stream); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing NDJSON: %v\n", err)
+		os.Exit(1)
+	}
+	for err := range errs {
+		fmt.Fprintf(os.Stderr, "Warning during scan: %v\n", err)
+	}
+	if output != "" {
+		fmt.Fprintf(os.Stderr, "NDJSON results saved to %s\n", output)
+	}
+}
+
+func performScan(provider, profile, framework string, verbose bool, services string, customChecksFile string, overridesFile string, assumeRoleARN string, checksConfigFile string, resourceSpec string, environmentPolicyFile string, maxRPS float64, regionsSpec string, failFast bool, fallbackToCache bool, evidenceLimit int) ComplianceResult {
 	var scanResults []interface{}
 	var accountID string
+	var executedServices []string
+	var skippedChecks []string
 
 	ctx := context.Background()
+	metadata := core.NewScanMetadata(CurrentVersion)
+	timestamp := time.Now()
 
 	// Start spinner for visual feedback
 	var spinner *cli.Spinner
@@ -896,33 +1319,166 @@ func performScan(provider, profile, framework string, verbose bool, services str
 	
 	switch provider {
 	case "aws":
-		scanner, err := awsScanner.NewScanner(profile)
+		options := awsScanner.RunnerOptions{
+			Framework:         framework,
+			Regions:           awsScanner.ParseRegions(regionsSpec),
+			ToolVersion:       CurrentVersion,
+			Profile:           profile,
+			AssumeRoleARN:     assumeRoleARN,
+			FailFast:          failFast,
+			FallbackToCache:   fallbackToCache,
+			AccountID:         offlineAccountID(provider, profile),
+			EvidenceListLimit: evidenceLimit,
+			MaxCallsPerSecond: maxRPS,
+		}
+		
+		if customChecksFile != "" {
+			defs, err := awsChecks.LoadCustomChecks(customChecksFile)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error loading custom checks: %v\n", err)
+				os.Exit(1)
+			}
+			options.CustomChecks = defs
+		}
+		
+		if checksConfigFile != "" {
+			disabled, err := awsChecks.LoadCheckConfig(checksConfigFile)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error loading checks config: %v\n", err)
+				os.Exit(1)
+			}
+			options.DisabledChecks = disabled
+		}
+		
+		if resourceSpec != "" {
+			filter, err := awsChecks.ParseResourceFilter(resourceSpec)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: invalid -resource: %v\n", err)
+				os.Exit(1)
+			}
+			options.Resources = filter
+		}
+		
+		// NewRunnerFromOptions fails fast on bad credentials instead of
+		// inside the first check, unless -fallback-to-cache serves the
+		// latest cached scan instead
+		runner, err := awsScanner.NewRunnerFromOptions(ctx, options)
 		if err != nil {
+			if spinner != nil {
+				spinner.Stop()
+			}
 			fmt.Fprintf(os.Stderr, "Error initializing AWS scanner: %v\n", err)
 			fmt.Fprintf(os.Stderr, "\nMake sure you have AWS credentials configured:\n")
 			fmt.Fprintf(os.Stderr, "  aws configure --profile %s\n", profile)
 			os.Exit(1)
 		}
 		
-		accountID = scanner.GetAccountID(ctx)
+		if overridesFile != "" {
+			overrides, err := awsChecks.LoadSeverityOverrides(overridesFile)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error loading severity overrides: %v\n", err)
+				os.Exit(1)
+			}
+			runner.SetSeverityOverrides(overrides)
+		}
+		
+		if environmentPolicyFile != "" {
+			policy, err := awsChecks.LoadEnvironmentPolicy(environmentPolicyFile)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error loading environment policy: %v\n", err)
+				os.Exit(1)
+			}
+			runner.SetEnvironmentPolicy(policy)
+		}
 		
 		if verbose {
-			fmt.Fprintf(os.Stderr, "Scanning AWS Account: %s\n", accountID)
 			fmt.Fprintf(os.Stderr, "Framework: %s\n", strings.ToUpper(framework))
+			if len(options.Regions) > 0 {
+				fmt.Fprintf(os.Stderr, "Regions: %s\n", strings.Join(options.Regions, ", "))
+			}
 		}
 		
-		serviceList := strings.Split(services, ",")
-		if services == "all" {
-			serviceList = []string{"s3", "iam", "ec2", "cloudtrail", "rds"}
+		// On a terminal, swap the spinner for a progress bar driven by the
+		// runner's module hook. Non-TTY/CI output keeps the single spinner
+		// line. A multi-region scan starts a new bar for each region.
+		var bar *cli.ProgressBar
+		if spinner != nil && cli.IsColorEnabled() {
+			spinner.Stop()
+			var mu sync.Mutex
+			total, done := len(runner.Checks()), 0
+			runner.AddHook(awsScanner.RunnerHook{
+				After: func(ctx context.Context, module awsChecks.Check, results []awsChecks.CheckResult, err error) {
+					mu.Lock()
+					defer mu.Unlock()
+					if bar == nil || done == total {
+						if bar != nil {
+							bar.Finish()
+						}
+						bar, done = cli.NewProgressBar(total, ""), 0
+					}
+					done++
+					bar.SetMessage(fmt.Sprintf("%-32.32s", module.Name()))
+					bar.Set(done)
+				},
+			})
+		} else if verbose {
+			runner.AddHook(awsScanner.RunnerHook{
+				After: func(ctx context.Context, module awsChecks.Check, results []awsChecks.CheckResult, err error) {
+					fmt.Fprintf(os.Stderr, "  Ran %s\n", module.Name())
+					for _, issue := range awsChecks.ValidateResults(results) {
+						fmt.Fprintf(os.Stderr, "    Warning: malformed result: %v\n", issue)
+					}
+				},
+			})
 		}
 		
-		awsResults, err := scanner.ScanServices(ctx, serviceList, verbose, framework)
+		scan, err := runner.Run(ctx)
+		if bar != nil {
+			bar.Finish()
+		}
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Warning during scan: %v\n", err)
 		}
 		
-		for _, r := range awsResults {
-			scanResults = append(scanResults, r)
+		// The runner detects the account from the credentials, so results
+		// and cache files always name the account actually scanned
+		accountID = scan.AccountID
+		timestamp = scan.Timestamp
+		skippedChecks = scan.SkippedChecks
+		if scan.Metadata != nil {
+			metadata = scan.Metadata
+			executedServices = scan.Metadata.Services
+		}
+		if verbose {
+			fmt.Fprintf(os.Stderr, "Scanned AWS Account: %s\n", accountID)
+		}
+		
+		multiRegion := len(options.Regions) > 1 || (len(options.Regions) == 1 && options.Regions[0] == awsScanner.AllRegions)
+		for _, cc := range scan.Controls {
+			evidenceText := cc.Evidence
+			// Findings from several regions would otherwise be
+			// indistinguishable in the report
+			if region := awsChecks.ResultRegion(awsChecks.CheckResult{Service: cc.Category, Region: cc.Region}); multiRegion && region != "" {
+				evidenceText = fmt.Sprintf("[%s] %s", region, evidenceText)
+			}
+			scanResults = append(scanResults, awsScanner.ScanResult{
+				Control:           cc.ID,
+				Region:            cc.Region,
+				Status:            cc.Status,
+				Evidence:          evidenceText,
+				AffectedResources: cc.AffectedResources,
+				Remediation:       cc.Remediation,
+				RemediationDetail: cc.RemediationDetail,
+				RequiresRecreate:  cc.RequiresRecreate,
+				Severity:          cc.Severity,
+				OriginalSeverity:  cc.OriginalSeverity,
+				Environment:       cc.Environment,
+				FindingID:         cc.FindingID,
+				ScreenshotGuide:   cc.ScreenshotGuide,
+				EvidenceSteps:     evidence.ParseGuide(cc.ScreenshotGuide, cc.ConsoleURL),
+				ConsoleURL:        cc.ConsoleURL,
+				Frameworks:        cc.Frameworks,
+			})
 		}
 		
 	case "azure":
@@ -960,6 +1516,7 @@ func performScan(provider, profile, framework string, verbose bool, services str
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Warning during scan: %v\n", err)
 		}
+		executedServices = serviceList
 		
 		for _, r := range azureResults {
 			scanResults = append(scanResults, r)
@@ -1003,6 +1560,7 @@ func performScan(provider, profile, framework string, verbose bool, services str
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Warning during GCP scan: %v\n", err)
 		}
+		executedServices = serviceList
 
 		for _, r := range gcpResults {
 			scanResults = append(scanResults, r)
@@ -1061,14 +1619,21 @@ func performScan(provider, profile, framework string, verbose bool, services str
 					ID:                awsResult.Control,
 					Name:              getControlName(awsResult.Control),
 					Category:          getControlCategory(awsResult.Control),
+					Region:            awsResult.Region,
 					Severity:          awsResult.Severity,
+					OriginalSeverity:  awsResult.OriginalSeverity,
+					Environment:       awsResult.Environment,
+					FindingID:         awsResult.FindingID,
 					Status:            awsResult.Status,
 					Evidence:          awsResult.Evidence,
+					AffectedResources: awsResult.AffectedResources,
 					Remediation:       awsResult.Remediation,
 					RemediationDetail: awsResult.RemediationDetail,
+					RequiresRecreate:  awsResult.RequiresRecreate,
 					Priority:          priority,
 					Impact:            impact,
 					ScreenshotGuide:   awsResult.ScreenshotGuide,
+					EvidenceSteps:     awsResult.EvidenceSteps,
 					ConsoleURL:        awsResult.ConsoleURL,
 					Frameworks:        awsResult.Frameworks,
 			}
@@ -1109,6 +1674,11 @@ func performScan(provider, profile, framework string, verbose bool, services str
 					Frameworks:        gcpResult.Frameworks,
 				}
 			}
+		// Structured evidence checklist for providers/checks that only set the guide string
+		if control.EvidenceSteps == nil {
+			control.EvidenceSteps = evidence.ParseGuide(control.ScreenshotGuide, control.ConsoleURL)
+		}
+
 		// Filter by framework if not "all"
 		if framework != "all" {
 			hasRequestedFramework := false
@@ -1259,8 +1829,37 @@ func performScan(provider, profile, framework string, verbose bool, services str
 		score = float64(passed) / float64(automatedChecks) * 100
 	}
 	
+	// Gap analysis: framework controls no check covers
+	var notAssessed []string
+	if mappings.SupportsGapAnalysis(framework) {
+		if crosswalk == nil {
+			crosswalk, crosswalkErr = mappings.GetCrosswalk()
+		}
+		if crosswalk != nil {
+			controlIDs := []string{}
+			controlFrameworks := []map[string]string{}
+			for _, control := range controls {
+				controlIDs = append(controlIDs, control.ID)
+				controlFrameworks = append(controlFrameworks, control.Frameworks)
+			}
+			notAssessed = crosswalk.NotAssessed(framework, controlIDs, controlFrameworks)
+		} else if verbose {
+			fmt.Fprintf(os.Stderr, "Warning: Could not load crosswalk for gap analysis: %v\n", crosswalkErr)
+		}
+	}
+	
+	// The AWS runner finishes its own metadata, with the checks, timings
+	// and errors of the scan
+	if provider == "aws" {
+		if verbose && metadata.DescribeCache != nil {
+			fmt.Fprintf(os.Stderr, "Describe cache: %d hits, %d misses\n", metadata.DescribeCache.Hits, metadata.DescribeCache.Misses)
+		}
+	} else {
+		metadata.Finish(executedServices)
+	}
+	
 	return ComplianceResult{
-		Timestamp:       time.Now(),
+		Timestamp:       timestamp,
 		Provider:        provider,
 		Framework:       framework,
 		AccountID:       accountID,
@@ -1270,6 +1869,9 @@ func performScan(provider, profile, framework string, verbose bool, services str
 		FailedControls:  failed,
 		Controls:        controls,
 		Recommendations: generatePrioritizedRecommendations(controls, critical, high, framework),
+		NotAssessed:     notAssessed,
+		SkippedChecks:   skippedChecks,
+		Metadata:        metadata,
 	}
 }
 
@@ -1716,7 +2318,7 @@ func getPriorityAndImpact(controlID, severity, status, framework string) (string
 	}
 }
 
-func printTextSummary(result ComplianceResult, full bool) {
+func printTextSummary(result ComplianceResult, full bool, opts report.Options) {
 	frameworkLabel := "Multi-Framework"
 	if result.Framework != "" && result.Framework != "all" {
 		frameworkLabel = strings.ToUpper(result.Framework)
@@ -1733,7 +2335,30 @@ func printTextSummary(result ComplianceResult, full bool) {
 		result.TotalControls,
 	))
 	fmt.Printf("Scan Time: %s\n", result.Timestamp.Format("2006-01-02 15:04:05"))
-	
+	if result.Metadata != nil {
+		fmt.Printf("%s%s%s\n", cli.Dim, result.Metadata.Summary(), cli.Reset)
+		if result.Metadata.Aborted {
+			fmt.Println()
+			cli.Warning("INCOMPLETE SCAN: %s. Results are partial, not a full assessment.", result.Metadata.AbortReason)
+			if len(result.Metadata.CompletedServices) > 0 {
+				fmt.Printf("   Completed: %s\n", strings.Join(result.Metadata.CompletedServices, ", "))
+			}
+		}
+		if result.Metadata.Stale {
+			fmt.Println()
+			cli.Warning("STALE/OFFLINE: live scan failed (%s). Showing cached results %s old.",
+				result.Metadata.StaleReason, result.Metadata.CacheAge().Round(time.Minute))
+		}
+	}
+	if note := report.EmptyScanNote(toCachedScan(result, CurrentVersion)); note != "" {
+		fmt.Println()
+		cli.Warning("NOTHING FOUND: %s", note)
+	}
+	if result.FailedControls > 0 {
+		fmt.Println()
+		fmt.Print(cli.SeverityHistogram(controlsToCheckResults(result.Controls)))
+	}
+
 	criticalCount := 0
 	highCount := 0
 	mediumCount := 0
@@ -1787,6 +2412,9 @@ func printTextSummary(result ComplianceResult, full bool) {
 				if control.Remediation != "" {
 					fmt.Printf("  %sFix:%s %s\n", cli.Green, cli.Reset, control.Remediation)
 				}
+				if control.RequiresRecreate {
+					fmt.Printf("  %sEffort:%s requires recreating the resource\n", cli.Yellow, cli.Reset)
+				}
 
 				if control.ScreenshotGuide != "" {
 					fmt.Printf("  %sEvidence:%s %s\n", cli.Cyan, cli.Reset, control.ScreenshotGuide)
@@ -1825,6 +2453,9 @@ func printTextSummary(result ComplianceResult, full bool) {
 				if control.Remediation != "" {
 					fmt.Printf("  %sFix:%s %s\n", cli.Green, cli.Reset, control.Remediation)
 				}
+				if control.RequiresRecreate {
+					fmt.Printf("  %sEffort:%s requires recreating the resource\n", cli.Yellow, cli.Reset)
+				}
 
 				if control.ScreenshotGuide != "" {
 					fmt.Printf("  %sEvidence:%s %s\n", cli.Cyan, cli.Reset, control.ScreenshotGuide)
@@ -1868,6 +2499,9 @@ func printTextSummary(result ComplianceResult, full bool) {
 				if control.Remediation != "" {
 					fmt.Printf("  %sFix:%s %s\n", cli.Green, cli.Reset, control.Remediation)
 				}
+				if control.RequiresRecreate {
+					fmt.Printf("  %sEffort:%s requires recreating the resource\n", cli.Yellow, cli.Reset)
+				}
 				fmt.Println()
 				otherShown++
 			}
@@ -1911,10 +2545,20 @@ func printTextSummary(result ComplianceResult, full bool) {
 
 	// Passed controls section
 	cli.SubHeader("Passed Controls")
+	if !opts.IncludePassing && result.PassedControls > 0 {
+		fmt.Printf("  %s%d passing controls hidden (use -include-passing to list them)%s\n",
+			cli.Dim, result.PassedControls, cli.Reset)
+	}
 	passCount := 0
 	for _, control := range result.Controls {
-		if control.Status == "PASS" {
+		if control.Status == "PASS" && opts.IncludePassing {
 			fmt.Printf("  %s %s - %s\n", cli.Pass(), control.ID, control.Name)
+			if control.Evidence != "" {
+				fmt.Printf("    %sVerified:%s %s\n", cli.Dim, cli.Reset, control.Evidence)
+			}
+			if full && control.ConsoleURL != "" {
+				fmt.Printf("    %sConsole:%s %s\n", cli.Blue, cli.Reset, control.ConsoleURL)
+			}
 			passCount++
 			if !full && passCount >= 15 {
 				remaining := result.PassedControls - 15
@@ -1927,6 +2571,28 @@ func printTextSummary(result ComplianceResult, full bool) {
 		}
 	}
 
+	// Framework controls with no automated check
+	if len(result.NotAssessed) > 0 {
+		cli.SubHeader(fmt.Sprintf("Not Assessed (%d)", len(result.NotAssessed)))
+		fmt.Printf("  %sNo automated check covers these controls - assess them manually%s\n", cli.Dim, cli.Reset)
+		shown := result.NotAssessed
+		if !full && len(shown) > 20 {
+			shown = shown[:20]
+		}
+		fmt.Printf("  %s\n", strings.Join(shown, ", "))
+		if len(result.NotAssessed) > len(shown) {
+			fmt.Printf("  %s... and %d more (use --full to see all)%s\n",
+				cli.Dim, len(result.NotAssessed)-len(shown), cli.Reset)
+		}
+	}
+
+	// Checks turned off in -checks-config
+	if len(result.SkippedChecks) > 0 {
+		cli.SubHeader(fmt.Sprintf("Skipped Checks (%d)", len(result.SkippedChecks)))
+		fmt.Printf("  %sDisabled in the checks config - not evaluated%s\n", cli.Dim, cli.Reset)
+		fmt.Printf("  %s\n", strings.Join(result.SkippedChecks, ", "))
+	}
+
 	// Recommendations
 	if len(result.Recommendations) > 0 {
 		cli.SubHeader("Priority Action Items")
@@ -2069,16 +2735,18 @@ func convertControlsForPDF(controls []ControlResult) []report.ControlResult {
 	pdfControls := []report.ControlResult{}
 	for _, c := range controls {
 		pdfControls = append(pdfControls, report.ControlResult{
-			ID:              c.ID,
-			Name:            c.Name,
-			Category:        c.Category,
-			Severity:        c.Severity,
-			Status:          c.Status,
-			Evidence:        c.Evidence,
-			Remediation:     c.Remediation,
-			ScreenshotGuide: c.ScreenshotGuide,
-			ConsoleURL:      c.ConsoleURL,
-			Frameworks:      c.Frameworks,
+			ID:               c.ID,
+			Name:             c.Name,
+			Category:         c.Category,
+			Severity:         c.Severity,
+			Status:           c.Status,
+			Evidence:         c.Evidence,
+			Remediation:      c.Remediation,
+			RequiresRecreate: c.RequiresRecreate,
+			ScreenshotGuide:  c.ScreenshotGuide,
+			EvidenceSteps:    c.EvidenceSteps,
+			ConsoleURL:       c.ConsoleURL,
+			Frameworks:       c.Frameworks,
 		})
 	}
 	return pdfControls
@@ -2210,8 +2878,8 @@ func outputTextToFile(result ComplianceResult, output string) {
 	fmt.Printf("Report saved to %s\n", output)
 }
 
-func outputJSON(result ComplianceResult, output string) {
-	data, err := json.MarshalIndent(result, "", "  ")
+func outputJSON(result ComplianceResult, output string, opts report.Options) {
+	data, err := json.MarshalIndent(applyReportOptions(result, opts), "", "  ")
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error formatting JSON: %v\n", err)
 		os.Exit(1)
@@ -2229,7 +2897,150 @@ func outputJSON(result ComplianceResult, output string) {
 	}
 }
 
-func outputHTML(result ComplianceResult, output string) {
+// outputSchema writes the JSON report schema to output, or stdout
+func outputSchema(output string) {
+	schema := report.JSONSchema()
+	if output == "" {
+		fmt.Print(string(schema))
+		return
+	}
+
+	if err := os.WriteFile(output, schema, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing to file: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("JSON schema saved to %s\n", output)
+}
+
+// runDoctor smoke-tests every AWS check module against stub clients (see
+// awsScanner.SmokeTest), printing one line per module with the outcome of its
+// happy, empty and error paths, and exits 1 if any module failed a path
+func runDoctor(verbose bool) {
+	results := awsScanner.SmokeTest(context.Background())
+
+	byService := map[string][]awsScanner.SmokeResult{}
+	services := []string{}
+	for _, result := range results {
+		if byService[result.Service] == nil {
+			services = append(services, result.Service)
+		}
+		byService[result.Service] = append(byService[result.Service], result)
+	}
+
+	failed := 0
+	fmt.Printf("       %-40s %-6s %-6s %-6s\n", "CHECK MODULE", "HAPPY", "EMPTY", "ERROR")
+	for _, service := range services {
+		outcomes := map[string]string{}
+		notes := []string{}
+		passed := true
+		for _, result := range byService[service] {
+			outcomes[result.Path] = "ok"
+			if !result.Passed() {
+				outcomes[result.Path] = "FAIL"
+				passed = false
+				for _, problem := range result.Problems {
+					notes = append(notes, fmt.Sprintf("%s: %s", result.Path, problem))
+				}
+			} else if verbose {
+				notes = append(notes, fmt.Sprintf("%s: %d results", result.Path, result.Results))
+			}
+		}
+
+		icon := cli.Pass()
+		if !passed {
+			icon = cli.Fail()
+			failed++
+		}
+		fmt.Printf("%s %-40s %-6s %-6s %-6s\n", icon, service,
+			outcomes[awsScanner.SmokeHappy], outcomes[awsScanner.SmokeEmpty], outcomes[awsScanner.SmokeError])
+		for _, note := range notes {
+			fmt.Printf("      %s\n", note)
+		}
+	}
+
+	if failed > 0 {
+		fmt.Printf("\n%d of %d check modules failed the smoke test\n", failed, len(services))
+		os.Exit(1)
+	}
+	fmt.Printf("\nAll %d check modules passed the smoke test\n", len(services))
+}
+
+func outputManifest(output string) {
+	manifest, err := json.MarshalIndent(awsChecks.Manifest(), "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error encoding check manifest: %v\n", err)
+		os.Exit(1)
+	}
+	manifest = append(manifest, '\n')
+
+	if output == "" {
+		fmt.Print(string(manifest))
+		return
+	}
+
+	if err := os.WriteFile(output, manifest, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing to file: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Check manifest saved to %s\n", output)
+}
+
+// loadCachedScan loads cacheFile, or else the latest cached scan for the
+// provider, profile and framework, exiting with a hint to run a scan first
+// when there is none
+func loadCachedScan(provider, profile, framework, cacheFile string) *offline.CachedScan {
+	cache, err := offline.NewCache()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error initializing cache: %v\n", err)
+		os.Exit(1)
+	}
+
+	var scan *offline.CachedScan
+	if cacheFile != "" {
+		scan, err = cache.LoadFromFile(cacheFile)
+	} else {
+		scan, err = cache.LoadLatest(provider, offlineAccountID(provider, profile), framework)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading cached scan: %v\n", err)
+		fmt.Fprintf(os.Stderr, "\nRun a scan first: auditkit scan -provider %s -framework %s\n", provider, framework)
+		os.Exit(1)
+	}
+	return scan
+}
+
+func outputBadge(provider, profile, framework, cacheFile, output string) {
+	scan := loadCachedScan(provider, profile, framework, cacheFile)
+
+	var badge bytes.Buffer
+	if err := report.WriteBadge(&badge, *scan); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if output == "" {
+		fmt.Print(badge.String())
+		return
+	}
+
+	if err := os.WriteFile(output, badge.Bytes(), 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing to file: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Score badge saved to %s\n", output)
+}
+
+// browseScan opens the interactive finding browser over the latest cached
+// scan (or -cache-file)
+func browseScan(provider, profile, framework, cacheFile string) {
+	scan := loadCachedScan(provider, profile, framework, cacheFile)
+	if err := cli.Browse(scan); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func outputHTML(result ComplianceResult, output string, opts report.Options) {
 	htmlResult := report.ComplianceResult{
 		Timestamp:       result.Timestamp,
 		Provider:        result.Provider,
@@ -2241,9 +3052,12 @@ func outputHTML(result ComplianceResult, output string) {
 		Controls:        convertControlsForPDF(result.Controls),
 		Recommendations: result.Recommendations,
 		Framework:       result.Framework,
+		NotAssessed:     result.NotAssessed,
+		SkippedChecks:   result.SkippedChecks,
+		Metadata:        result.Metadata,
 	}
 	
-	html := report.GenerateHTML(htmlResult)
+	html := report.GenerateHTMLWithOptions(htmlResult, opts)
 
 	if output == "" {
 		output = fmt.Sprintf("auditkit-%s-%s-report-%s.html", 
@@ -2261,11 +3075,13 @@ func outputHTML(result ComplianceResult, output string) {
 	fmt.Printf("Open in browser: file://%s/%s\n", getCurrentDir(), output)
 }
 
-func outputCSV(result ComplianceResult, output string) {
+func outputCSV(result ComplianceResult, output string, opts report.Options) {
 	var csvData strings.Builder
+	result = applyReportOptions(result, opts)
 
 	// CSV Header
-	csvData.WriteString("Control ID,Control Name,Category,Status,Severity,Priority,Evidence,Remediation,Console URL\n")
+	csvData.WriteString("Control ID,Control Name,Category,Status,Severity,Priority,Evidence,Affected Resources,Remediation,Console URL,Scan Time\n")
+	scanTime := opts.FormatTime(result.Timestamp)
 
 	// CSV Rows
 	for _, control := range result.Controls {
@@ -2277,12 +3093,13 @@ func outputCSV(result ComplianceResult, output string) {
 		severity := escapeCSVField(control.Severity)
 		priority := escapeCSVField(control.Priority)
 		evidence := escapeCSVField(control.Evidence)
+		affected := escapeCSVField(strings.Join(control.AffectedResources, "; "))
 		remediation := escapeCSVField(control.Remediation)
 		consoleURL := escapeCSVField(control.ConsoleURL)
 
-		csvData.WriteString(fmt.Sprintf("%s,%s,%s,%s,%s,%s,%s,%s,%s\n",
+		csvData.WriteString(fmt.Sprintf("%s,%s,%s,%s,%s,%s,%s,%s,%s,%s,%s\n",
 			controlID, controlName, category, status, severity, priority,
-			evidence, remediation, consoleURL))
+			evidence, affected, remediation, consoleURL, scanTime))
 	}
 
 	if output == "" {
@@ -2301,6 +3118,32 @@ func outputCSV(result ComplianceResult, output string) {
 	fmt.Printf("Import into Excel, Google Sheets, or other spreadsheet tools\n")
 }
 
+// applyReportOptions returns a copy of result with controls filtered per opts
+// and timestamps moved to the report timezone.
+// Score and control totals are left as scanned.
+func applyReportOptions(result ComplianceResult, opts report.Options) ComplianceResult {
+	result.Timestamp = opts.In(result.Timestamp)
+	if result.Metadata != nil {
+		metadata := *result.Metadata
+		metadata.StartTime = opts.In(metadata.StartTime)
+		metadata.EndTime = opts.In(metadata.EndTime)
+		result.Metadata = &metadata
+	}
+
+	if opts.IncludePassing {
+		return result
+	}
+
+	filtered := []ControlResult{}
+	for _, control := range result.Controls {
+		if control.Status != "PASS" {
+			filtered = append(filtered, control)
+		}
+	}
+	result.Controls = filtered
+	return result
+}
+
 // escapeCSVField properly escapes CSV fields containing commas, quotes, or newlines
 func escapeCSVField(field string) string {
 	// Replace newlines with spaces
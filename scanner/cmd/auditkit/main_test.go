@@ -0,0 +1,262 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/guardian-nexus/auditkit/scanner/pkg/core"
+	"github.com/guardian-nexus/auditkit/scanner/pkg/evidence"
+	"github.com/guardian-nexus/auditkit/scanner/pkg/report"
+)
+
+// fullComplianceResult sets every field the JSON report can carry, so a
+// field added or renamed without updating schema.json fails the schema test
+func fullComplianceResult() ComplianceResult {
+	start := time.Date(2025, time.March, 4, 15, 30, 0, 0, time.UTC)
+	return ComplianceResult{
+		Timestamp:      start,
+		Provider:       "aws",
+		Framework:      "soc2",
+		AccountID:      "123456789012",
+		Score:          50,
+		TotalControls:  2,
+		PassedControls: 1,
+		FailedControls: 1,
+		Controls: []ControlResult{
+			{
+				ID:                "CC6.1",
+				Name:              "S3 Block Public Access",
+				Category:          "Security",
+				Severity:          "HIGH",
+				OriginalSeverity:  "CRITICAL",
+				Environment:       "prod",
+				FindingID:         "f-0123456789abcdef",
+				Status:            "FAIL",
+				Evidence:          "1 bucket allows public access: [logs]",
+				AffectedResources: []string{"logs"},
+				Remediation:       "Enable Block Public Access",
+				RemediationDetail: "aws s3api put-public-access-block --bucket logs",
+				RequiresRecreate:  true,
+				Priority:          "HIGH",
+				Impact:            "Data exposure",
+				ScreenshotGuide:   "S3 Console → Buckets → Permissions",
+				EvidenceSteps:     &evidence.Checklist{Steps: []string{"Open S3"}, Expected: "Block all public access: On", ConsoleURL: "https://console.aws.amazon.com/s3/"},
+				ConsoleURL:        "https://console.aws.amazon.com/s3/",
+				Frameworks:        map[string]string{"SOC2": "CC6.1"},
+			},
+			{ID: "CC6.3", Name: "EBS Encryption", Category: "Security", Status: "PASS", Evidence: "All 3 volumes are encrypted"},
+		},
+		Recommendations: []string{"Enable Block Public Access"},
+		NotAssessed:     []string{"CC1.1"},
+		SkippedChecks:   []string{"s3.versioning"},
+		Metadata: &core.ScanMetadata{
+			ToolVersion:       CurrentVersion,
+			ScanProfile:       "deep",
+			StartTime:         start,
+			EndTime:           start.Add(90 * time.Second),
+			DurationSeconds:   90,
+			Services:          []string{"S3 Security"},
+			Checks:            []core.CheckRef{{ID: "s3.public_access", Name: "S3 Block Public Access"}},
+			CallerIdentity:    "arn:aws:iam::123456789012:user/auditor",
+			Identity:          &core.Identity{Provider: "aws", AccountID: "123456789012", Principal: "arn:aws:iam::123456789012:user/auditor", Partition: "aws"},
+			Aborted:           true,
+			AbortReason:       "credentials expired",
+			CompletedServices: []string{"S3 Security"},
+			Stale:             true,
+			StaleReason:       "live scan failed",
+			CacheAgeSeconds:   3600,
+			ServiceTimings:    []core.Timing{{Name: "S3 Security", Seconds: 1.5}},
+			SlowestChecks:     []core.Timing{{Name: "s3.public_access", Seconds: 0.5}},
+			DescribeCache:     &core.CacheStats{Hits: 3, Misses: 1},
+			CheckErrors:       map[string]int{"access_denied": 1},
+		},
+	}
+}
+
+func TestJSONReportMatchesSchema(t *testing.T) {
+	var schema map[string]any
+	if err := json.Unmarshal(report.JSONSchema(), &schema); err != nil {
+		t.Fatalf("schema.json is not valid JSON: %v", err)
+	}
+
+	output := filepath.Join(t.TempDir(), "report.json")
+	outputJSON(fullComplianceResult(), output, report.DefaultOptions("json"))
+
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("report is not valid JSON: %v", err)
+	}
+
+	for _, problem := range validateSchema(schema, schema, doc, "$") {
+		t.Error(problem)
+	}
+}
+
+func TestSchemaValidatorRejectsUnknownFields(t *testing.T) {
+	var schema map[string]any
+	if err := json.Unmarshal(report.JSONSchema(), &schema); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := json.Marshal(fullComplianceResult())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+	doc["renamed_field"] = true
+	delete(doc, "controls")
+
+	problems := validateSchema(schema, schema, doc, "$")
+	if len(problems) != 2 {
+		t.Errorf("got %d problems, want one for the unknown field and one for the missing one: %v", len(problems), problems)
+	}
+}
+
+// validateSchema checks value against the subset of JSON Schema that
+// schema.json uses: type, enum, required, properties, additionalProperties,
+// items, minimum, maximum, format date-time and local $refs. It returns one
+// message per violation, prefixed with the JSON path.
+func validateSchema(root, schema map[string]any, value any, path string) []string {
+	if ref, ok := schema["$ref"].(string); ok {
+		resolved, ok := resolveRef(root, ref)
+		if !ok {
+			return []string{fmt.Sprintf("%s: unresolvable $ref %q", path, ref)}
+		}
+		return validateSchema(root, resolved, value, path)
+	}
+
+	if types, ok := schema["type"]; ok && !matchesType(types, value) {
+		return []string{fmt.Sprintf("%s: %T does not match type %v", path, value, types)}
+	}
+
+	problems := []string{}
+	if enum, ok := schema["enum"].([]any); ok {
+		found := false
+		for _, allowed := range enum {
+			if allowed == value {
+				found = true
+			}
+		}
+		if !found {
+			problems = append(problems, fmt.Sprintf("%s: %v is not one of %v", path, value, enum))
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		required, _ := schema["required"].([]any)
+		for _, name := range required {
+			if _, ok := v[name.(string)]; !ok {
+				problems = append(problems, fmt.Sprintf("%s: missing required %q", path, name))
+			}
+		}
+		properties, _ := schema["properties"].(map[string]any)
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if property, ok := properties[name].(map[string]any); ok {
+				problems = append(problems, validateSchema(root, property, v[name], path+"."+name)...)
+				continue
+			}
+			switch additional := schema["additionalProperties"].(type) {
+			case bool:
+				if !additional {
+					problems = append(problems, fmt.Sprintf("%s: unexpected property %q", path, name))
+				}
+			case map[string]any:
+				problems = append(problems, validateSchema(root, additional, v[name], path+"."+name)...)
+			}
+		}
+	case []any:
+		if items, ok := schema["items"].(map[string]any); ok {
+			for i, item := range v {
+				problems = append(problems, validateSchema(root, items, item, fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+	case float64:
+		if minimum, ok := schema["minimum"].(float64); ok && v < minimum {
+			problems = append(problems, fmt.Sprintf("%s: %v is below the minimum %v", path, v, minimum))
+		}
+		if maximum, ok := schema["maximum"].(float64); ok && v > maximum {
+			problems = append(problems, fmt.Sprintf("%s: %v is above the maximum %v", path, v, maximum))
+		}
+	case string:
+		if schema["format"] == "date-time" {
+			if _, err := time.Parse(time.RFC3339Nano, v); err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %q is not a date-time", path, v))
+			}
+		}
+	}
+	return problems
+}
+
+// resolveRef looks up a "#/$defs/name" reference in the root schema
+func resolveRef(root map[string]any, ref string) (map[string]any, bool) {
+	name, ok := strings.CutPrefix(ref, "#/$defs/")
+	if !ok {
+		return nil, false
+	}
+	defs, _ := root["$defs"].(map[string]any)
+	def, ok := defs[name].(map[string]any)
+	return def, ok
+}
+
+// matchesType reports whether value is one of the JSON types named by a
+// schema "type", either a single name or a list of names
+func matchesType(types any, value any) bool {
+	names := []any{types}
+	if list, ok := types.([]any); ok {
+		names = list
+	}
+
+	for _, name := range names {
+		switch name {
+		case "object":
+			if _, ok := value.(map[string]any); ok {
+				return true
+			}
+		case "array":
+			if _, ok := value.([]any); ok {
+				return true
+			}
+		case "string":
+			if _, ok := value.(string); ok {
+				return true
+			}
+		case "number":
+			if _, ok := value.(float64); ok {
+				return true
+			}
+		case "integer":
+			if n, ok := value.(float64); ok && n == math.Trunc(n) {
+				return true
+			}
+		case "boolean":
+			if _, ok := value.(bool); ok {
+				return true
+			}
+		case "null":
+			if value == nil {
+				return true
+			}
+		}
+	}
+	return false
+}
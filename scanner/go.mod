@@ -15,6 +15,7 @@ require (
 	// AWS SDK v2
 	github.com/aws/aws-sdk-go-v2 v1.41.1
 	github.com/aws/aws-sdk-go-v2/config v1.31.8
+	github.com/aws/aws-sdk-go-v2/credentials v1.18.12
 	github.com/aws/aws-sdk-go-v2/service/autoscaling v1.59.1
 	github.com/aws/aws-sdk-go-v2/service/backup v1.47.4
 	github.com/aws/aws-sdk-go-v2/service/cloudtrail v1.53.4
@@ -33,9 +34,16 @@ require (
 	github.com/aws/aws-sdk-go-v2/service/sns v1.38.3
 	github.com/aws/aws-sdk-go-v2/service/ssm v1.64.4
 	github.com/aws/aws-sdk-go-v2/service/sts v1.38.4
+	github.com/aws/smithy-go v1.24.0
 
 	// Report generation
 	github.com/jung-kurt/gofpdf v1.16.2
+
+	// Cache encryption
+	golang.org/x/crypto v0.42.0
+
+	// Terminal size detection
+	golang.org/x/sys v0.36.0
 )
 
 require (
@@ -44,7 +52,6 @@ require (
 	github.com/AzureAD/microsoft-authentication-library-for-go v1.5.0 // indirect
 	// AWS indirect dependencies
 	github.com/aws/aws-sdk-go-v2/aws/protocol/eventstream v1.7.1 // indirect
-	github.com/aws/aws-sdk-go-v2/credentials v1.18.12 // indirect
 	github.com/aws/aws-sdk-go-v2/feature/ec2/imds v1.18.7 // indirect
 	github.com/aws/aws-sdk-go-v2/internal/configsources v1.4.17 // indirect
 	github.com/aws/aws-sdk-go-v2/internal/endpoints/v2 v2.7.17 // indirect
@@ -56,14 +63,11 @@ require (
 	github.com/aws/aws-sdk-go-v2/service/internal/s3shared v1.19.7 // indirect
 	github.com/aws/aws-sdk-go-v2/service/sso v1.29.3 // indirect
 	github.com/aws/aws-sdk-go-v2/service/ssooidc v1.34.4 // indirect
-	github.com/aws/smithy-go v1.24.0 // indirect
 	github.com/golang-jwt/jwt/v5 v5.3.0 // indirect
 	github.com/google/uuid v1.6.0 // indirect
 	github.com/kylelemons/godebug v1.1.0 // indirect
 	github.com/pkg/browser v0.0.0-20240102092130-5ac0b6a4141c // indirect
-	golang.org/x/crypto v0.42.0 // indirect
 	golang.org/x/net v0.44.0 // indirect
-	golang.org/x/sys v0.36.0 // indirect
 	golang.org/x/text v0.29.0 // indirect
 )
 
@@ -81,6 +85,7 @@ require (
 	github.com/aws/aws-sdk-go-v2/service/apigateway v1.35.8
 	github.com/aws/aws-sdk-go-v2/service/apigatewayv2 v1.32.8
 	github.com/aws/aws-sdk-go-v2/service/cloudformation v1.68.1
+	github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs v1.58.2
 	github.com/aws/aws-sdk-go-v2/service/dynamodb v1.52.2
 	github.com/aws/aws-sdk-go-v2/service/ecr v1.51.0
 	github.com/aws/aws-sdk-go-v2/service/ecs v1.65.4
@@ -91,6 +96,7 @@ require (
 	github.com/aws/aws-sdk-go-v2/service/networkfirewall v1.57.3
 	github.com/aws/aws-sdk-go-v2/service/opensearch v1.57.1
 	github.com/aws/aws-sdk-go-v2/service/redshift v1.61.5
+	github.com/aws/aws-sdk-go-v2/service/redshiftserverless v1.27.2
 	github.com/aws/aws-sdk-go-v2/service/route53 v1.58.5
 	github.com/aws/aws-sdk-go-v2/service/sagemaker v1.229.1
 	github.com/aws/aws-sdk-go-v2/service/secretsmanager v1.39.9
@@ -3,7 +3,6 @@ package checks
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/accessanalyzer"
 	"github.com/aws/aws-sdk-go-v2/service/accessanalyzer/types"
@@ -28,7 +27,7 @@ func (c *AccessAnalyzerChecks) Name() string {
 func (c *AccessAnalyzerChecks) Run(ctx context.Context) ([]CheckResult, error) {
 	results := []CheckResult{}
 
-	if result, err := c.CheckAccessAnalyzerEnabled(ctx); err == nil {
+	if result, err := runCheck(ctx, "access_analyzer.enabled", c.CheckAccessAnalyzerEnabled); err == nil {
 		results = append(results, result)
 	}
 
@@ -48,7 +47,7 @@ func (c *AccessAnalyzerChecks) CheckAccessAnalyzerEnabled(ctx context.Context) (
 			Evidence:  fmt.Sprintf("Unable to check IAM Access Analyzer: %v", err),
 			Severity:  "HIGH",
 			Priority:  PriorityHigh,
-			Timestamp: time.Now(),
+			Timestamp: nowFunc(),
 			Frameworks: GetFrameworkMappings("IAM_ACCESS_ANALYZER"),
 		}, err
 	}
@@ -68,16 +67,16 @@ aws accessanalyzer create-analyzer \
   --region %s
 
 # Alternative: Use Console
-1. Open IAM Console: https://console.aws.amazon.com/iam/
+1. Open IAM Console: %s
 2. Click 'Access analyzer' in left navigation
 3. Click 'Create analyzer'
 4. Choose:
    - Name: ConsoleAnalyzer (or custom name)
    - Zone of trust: Current account
 5. Click 'Create analyzer'
-6. Review findings regularly`, c.region),
+6. Review findings regularly`, c.region, consoleURL("iam/", consoleRegion(ctx))),
 			ScreenshotGuide: fmt.Sprintf(`IAM Access Analyzer Evidence:
-1. Open IAM Console: https://console.aws.amazon.com/iam/
+1. Open IAM Console: %s
 2. Click 'Access analyzer' in left navigation
 3. Screenshot showing:
    - At least one analyzer in 'Active' status
@@ -85,10 +84,10 @@ aws accessanalyzer create-analyzer \
    - Zone of trust: Current account
    - Region: %s
 4. Click on analyzer name
-5. Screenshot of 'Findings' tab (can be empty if no findings)`, c.region),
-			ConsoleURL:      "https://console.aws.amazon.com/iamv2/home#/access_analyzer",
+5. Screenshot of 'Findings' tab (can be empty if no findings)`, consoleURL("iam/", consoleRegion(ctx)), c.region),
+			ConsoleURL:      consoleURL("iamv2/home#/access_analyzer", consoleRegion(ctx)),
 			Priority:        PriorityHigh,
-			Timestamp:       time.Now(),
+			Timestamp:       nowFunc(),
 			Frameworks:      GetFrameworkMappings("IAM_ACCESS_ANALYZER"),
 		}, nil
 	}
@@ -121,9 +120,9 @@ aws accessanalyzer create-analyzer \
   --type ACCOUNT \
   --region %s`, c.region, c.region),
 			ScreenshotGuide:   "IAM Console → Access analyzer → Screenshot showing no active analyzers",
-			ConsoleURL:        "https://console.aws.amazon.com/iamv2/home#/access_analyzer",
+			ConsoleURL:        consoleURL("iamv2/home#/access_analyzer", consoleRegion(ctx)),
 			Priority:          PriorityHigh,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("IAM_ACCESS_ANALYZER"),
 		}, nil
 	}
@@ -134,8 +133,9 @@ aws accessanalyzer create-analyzer \
 		Status:     "PASS",
 		Evidence:   fmt.Sprintf("IAM Access Analyzer is active in region %s (%d active analyzer(s): %v) | Meets CIS AWS 1.8 (external access monitoring)", c.region, activeAnalyzers, analyzerNames),
 		Severity:   "INFO",
+		ConsoleURL: consoleURL("iamv2/home#/access_analyzer", consoleRegion(ctx)),
 		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
+		Timestamp:  nowFunc(),
 		Frameworks: GetFrameworkMappings("IAM_ACCESS_ANALYZER"),
 	}, nil
 }
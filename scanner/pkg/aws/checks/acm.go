@@ -3,7 +3,6 @@ package checks
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/acm"
 )
@@ -23,11 +22,11 @@ func (c *ACMChecks) Name() string {
 func (c *ACMChecks) Run(ctx context.Context) ([]CheckResult, error) {
 	results := []CheckResult{}
 
-	if result, err := c.CheckCertificateRenewal(ctx); err == nil {
+	if result, err := runCheck(ctx, "acm.certificate_renewal", c.CheckCertificateRenewal); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckCertificateInUse(ctx); err == nil {
+	if result, err := runCheck(ctx, "acm.certificate_in_use", c.CheckCertificateInUse); err == nil {
 		results = append(results, result)
 	}
 
@@ -43,7 +42,7 @@ func (c *ACMChecks) CheckCertificateRenewal(ctx context.Context) (CheckResult, e
 			Status:     "ERROR",
 			Evidence:   fmt.Sprintf("Failed to list certificates: %v", err),
 			Priority:   PriorityLow,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("ACM_RENEWAL"),
 		}, err
 	}
@@ -55,7 +54,7 @@ func (c *ACMChecks) CheckCertificateRenewal(ctx context.Context) (CheckResult, e
 			Status:     "INFO",
 			Evidence:   "No ACM certificates found",
 			Priority:   PriorityLow,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("ACM_RENEWAL"),
 		}, nil
 	}
@@ -63,7 +62,7 @@ func (c *ACMChecks) CheckCertificateRenewal(ctx context.Context) (CheckResult, e
 	expired := []string{}
 	expiringSoon := []string{}
 	valid := 0
-	thirtyDaysFromNow := time.Now().AddDate(0, 0, 30)
+	thirtyDaysFromNow := nowFunc().AddDate(0, 0, 30)
 
 	for _, cert := range certs.CertificateSummaryList {
 		detail, err := c.client.DescribeCertificate(ctx, &acm.DescribeCertificateInput{
@@ -74,7 +73,7 @@ func (c *ACMChecks) CheckCertificateRenewal(ctx context.Context) (CheckResult, e
 		}
 
 		if detail.Certificate.NotAfter != nil {
-			if detail.Certificate.NotAfter.Before(time.Now()) {
+			if detail.Certificate.NotAfter.Before(nowFunc()) {
 				expired = append(expired, *cert.DomainName)
 			} else if detail.Certificate.NotAfter.Before(thirtyDaysFromNow) {
 				expiringSoon = append(expiringSoon, *cert.DomainName)
@@ -86,31 +85,33 @@ func (c *ACMChecks) CheckCertificateRenewal(ctx context.Context) (CheckResult, e
 
 	if len(expired) > 0 {
 		return CheckResult{
-			Control:     "CIS-16.1",
-			Name:        "ACM Certificate Auto-Renewal",
-			Status:      "FAIL",
-			Evidence:    fmt.Sprintf("%d certificates EXPIRED: %v", len(expired), expired),
-			Remediation: "Renew or delete expired certificates immediately",
-			Severity:    "CRITICAL",
-			Priority:    PriorityCritical,
-			Timestamp:   time.Now(),
-			ConsoleURL:  "https://console.aws.amazon.com/acm/home#/certificates/list",
-			Frameworks:  GetFrameworkMappings("ACM_RENEWAL"),
+			Control:           "CIS-16.1",
+			Name:              "ACM Certificate Auto-Renewal",
+			Status:            "FAIL",
+			Evidence:          fmt.Sprintf("%d certificates EXPIRED: %s", len(expired), TruncateList(expired, evidenceListLimit(ctx))),
+			AffectedResources: expired,
+			Remediation:       "Renew or delete expired certificates immediately",
+			Severity:          "CRITICAL",
+			Priority:          PriorityCritical,
+			Timestamp:         nowFunc(),
+			ConsoleURL:        consoleURL("acm/home#/certificates/list", consoleRegion(ctx)),
+			Frameworks:        GetFrameworkMappings("ACM_RENEWAL"),
 		}, nil
 	}
 
 	if len(expiringSoon) > 0 {
 		return CheckResult{
-			Control:     "CIS-16.1",
-			Name:        "ACM Certificate Auto-Renewal",
-			Status:      "FAIL",
-			Evidence:    fmt.Sprintf("%d certificates expiring within 30 days: %v", len(expiringSoon), expiringSoon),
-			Remediation: "Renew certificates before expiration",
-			Severity:    "HIGH",
-			Priority:    PriorityHigh,
-			Timestamp:   time.Now(),
-			ConsoleURL:  "https://console.aws.amazon.com/acm/home#/certificates/list",
-			Frameworks:  GetFrameworkMappings("ACM_RENEWAL"),
+			Control:           "CIS-16.1",
+			Name:              "ACM Certificate Auto-Renewal",
+			Status:            "FAIL",
+			Evidence:          fmt.Sprintf("%d certificates expiring within 30 days: %s", len(expiringSoon), TruncateList(expiringSoon, evidenceListLimit(ctx))),
+			AffectedResources: expiringSoon,
+			Remediation:       "Renew certificates before expiration",
+			Severity:          "HIGH",
+			Priority:          PriorityHigh,
+			Timestamp:         nowFunc(),
+			ConsoleURL:        consoleURL("acm/home#/certificates/list", consoleRegion(ctx)),
+			Frameworks:        GetFrameworkMappings("ACM_RENEWAL"),
 		}, nil
 	}
 
@@ -120,8 +121,8 @@ func (c *ACMChecks) CheckCertificateRenewal(ctx context.Context) (CheckResult, e
 		Status:     "PASS",
 		Evidence:   fmt.Sprintf("All %d certificates valid and not expiring soon", valid),
 		Priority:   PriorityLow,
-		Timestamp:  time.Now(),
-		ConsoleURL: "https://console.aws.amazon.com/acm/home#/certificates/list",
+		Timestamp:  nowFunc(),
+		ConsoleURL: consoleURL("acm/home#/certificates/list", consoleRegion(ctx)),
 		Frameworks: GetFrameworkMappings("ACM_RENEWAL"),
 	}, nil
 }
@@ -135,7 +136,7 @@ func (c *ACMChecks) CheckCertificateInUse(ctx context.Context) (CheckResult, err
 			Status:     "ERROR",
 			Evidence:   "Failed to list certificates",
 			Priority:   PriorityLow,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("ACM_IN_USE"),
 		}, err
 	}
@@ -147,7 +148,7 @@ func (c *ACMChecks) CheckCertificateInUse(ctx context.Context) (CheckResult, err
 			Status:     "INFO",
 			Evidence:   "No ACM certificates found",
 			Priority:   PriorityLow,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("ACM_IN_USE"),
 		}, nil
 	}
@@ -175,12 +176,12 @@ func (c *ACMChecks) CheckCertificateInUse(ctx context.Context) (CheckResult, err
 			Control:     "CIS-16.2",
 			Name:        "ACM Certificate In Use",
 			Status:      "FAIL",
-			Evidence:    fmt.Sprintf("%d/%d certificates not in use: %v", len(unused), len(certs.CertificateSummaryList), unused),
+			Evidence:    fmt.Sprintf("%d/%d certificates not in use: %s", len(unused), len(certs.CertificateSummaryList), TruncateList(unused, evidenceListLimit(ctx))),
 			Remediation: "Delete unused certificates to reduce attack surface",
 			Severity:    "LOW",
 			Priority:    PriorityLow,
-			Timestamp:   time.Now(),
-			ConsoleURL:  "https://console.aws.amazon.com/acm/home#/certificates/list",
+			Timestamp:   nowFunc(),
+			ConsoleURL:  consoleURL("acm/home#/certificates/list", consoleRegion(ctx)),
 			Frameworks:  GetFrameworkMappings("ACM_IN_USE"),
 		}, nil
 	}
@@ -191,8 +192,8 @@ func (c *ACMChecks) CheckCertificateInUse(ctx context.Context) (CheckResult, err
 		Status:     "PASS",
 		Evidence:   fmt.Sprintf("All %d certificates are in use", inUse),
 		Priority:   PriorityLow,
-		Timestamp:  time.Now(),
-		ConsoleURL: "https://console.aws.amazon.com/acm/home#/certificates/list",
+		Timestamp:  nowFunc(),
+		ConsoleURL: consoleURL("acm/home#/certificates/list", consoleRegion(ctx)),
 		Frameworks: GetFrameworkMappings("ACM_IN_USE"),
 	}, nil
 }
@@ -3,7 +3,6 @@ package checks
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/apigateway"
 	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
@@ -29,17 +28,17 @@ func (c *APIGatewayChecks) Run(ctx context.Context) ([]CheckResult, error) {
 	results := []CheckResult{}
 
 	// CIS Section 10.7 - API Gateway Logging
-	if result, err := c.CheckAPIGatewayLogging(ctx); err == nil {
+	if result, err := runCheck(ctx, "api_gateway.logging", c.CheckAPIGatewayLogging); err == nil {
 		results = append(results, result)
 	}
 
 	// CIS Section 10.8 - API Gateway Authentication
-	if result, err := c.CheckAPIGatewayAuth(ctx); err == nil {
+	if result, err := runCheck(ctx, "api_gateway.auth", c.CheckAPIGatewayAuth); err == nil {
 		results = append(results, result)
 	}
 
 	// CIS Section 10.9 - API Gateway TLS
-	if result, err := c.CheckAPIGatewayTLS(ctx); err == nil {
+	if result, err := runCheck(ctx, "api_gateway.tls", c.CheckAPIGatewayTLS); err == nil {
 		results = append(results, result)
 	}
 
@@ -58,7 +57,7 @@ func (c *APIGatewayChecks) CheckAPIGatewayLogging(ctx context.Context) (CheckRes
 			Evidence:    fmt.Sprintf("Failed to list API Gateway REST APIs: %v", err),
 			Remediation: "Verify API Gateway access permissions",
 			Priority:    PriorityLow,
-			Timestamp:   time.Now(),
+			Timestamp:   nowFunc(),
 			Frameworks:  GetFrameworkMappings("API_GATEWAY_LOGGING"),
 		}, err
 	}
@@ -73,7 +72,7 @@ func (c *APIGatewayChecks) CheckAPIGatewayLogging(ctx context.Context) (CheckRes
 			Evidence:    fmt.Sprintf("Failed to list API Gateway HTTP APIs: %v", err),
 			Remediation: "Verify API Gateway access permissions",
 			Priority:    PriorityLow,
-			Timestamp:   time.Now(),
+			Timestamp:   nowFunc(),
 			Frameworks:  GetFrameworkMappings("API_GATEWAY_LOGGING"),
 		}, err
 	}
@@ -94,9 +93,9 @@ func (c *APIGatewayChecks) CheckAPIGatewayLogging(ctx context.Context) (CheckRes
 4. Set log level to INFO or ERROR
 5. Enable detailed CloudWatch metrics`,
 			Priority:        PriorityLow,
-			Timestamp:       time.Now(),
+			Timestamp:       nowFunc(),
 			ScreenshotGuide: "API Gateway → APIs → Screenshot showing no APIs",
-			ConsoleURL:      "https://console.aws.amazon.com/apigateway/home#/apis",
+			ConsoleURL:      consoleURL("apigateway/home#/apis", consoleRegion(ctx)),
 			Frameworks:      GetFrameworkMappings("API_GATEWAY_LOGGING"),
 		}, nil
 	}
@@ -136,11 +135,12 @@ func (c *APIGatewayChecks) CheckAPIGatewayLogging(ctx context.Context) (CheckRes
 
 	if len(stagesWithoutLogging) > 0 {
 		return CheckResult{
-			Control:     "CIS-10.7",
-			Name:        "API Gateway Logging Enabled",
-			Status:      "FAIL",
-			Evidence:    fmt.Sprintf("%d stages lack CloudWatch logging: %v", len(stagesWithoutLogging), stagesWithoutLogging),
-			Remediation: "Enable CloudWatch Logs for all API Gateway stages",
+			Control:           "CIS-10.7",
+			Name:              "API Gateway Logging Enabled",
+			Status:            "FAIL",
+			Evidence:          fmt.Sprintf("%d stages lack CloudWatch logging: %s", len(stagesWithoutLogging), TruncateList(stagesWithoutLogging, evidenceListLimit(ctx))),
+			AffectedResources: stagesWithoutLogging,
+			Remediation:       "Enable CloudWatch Logs for all API Gateway stages",
 			RemediationDetail: fmt.Sprintf(`1. Open API Gateway console
 2. For each API/stage without logging: %v
 3. Select API → Stages → Stage name
@@ -151,9 +151,9 @@ func (c *APIGatewayChecks) CheckAPIGatewayLogging(ctx context.Context) (CheckRes
 8. Save changes`, stagesWithoutLogging),
 			Severity:        "HIGH",
 			Priority:        PriorityHigh,
-			Timestamp:       time.Now(),
+			Timestamp:       nowFunc(),
 			ScreenshotGuide: "API Gateway → API → Stages → Stage → Logs/Tracing → Screenshot showing logging enabled",
-			ConsoleURL:      "https://console.aws.amazon.com/apigateway/home#/apis",
+			ConsoleURL:      consoleURL("apigateway/home#/apis", consoleRegion(ctx)),
 			Frameworks:      GetFrameworkMappings("API_GATEWAY_LOGGING"),
 		}, nil
 	}
@@ -168,9 +168,9 @@ func (c *APIGatewayChecks) CheckAPIGatewayLogging(ctx context.Context) (CheckRes
 Continue monitoring logs for security events and errors.`, stagesWithLogging),
 		Severity:        "INFO",
 		Priority:        PriorityLow,
-		Timestamp:       time.Now(),
+		Timestamp:       nowFunc(),
 		ScreenshotGuide: "API Gateway → APIs → Stages → Screenshot showing all with logging",
-		ConsoleURL:      "https://console.aws.amazon.com/apigateway/home#/apis",
+		ConsoleURL:      consoleURL("apigateway/home#/apis", consoleRegion(ctx)),
 		Frameworks:      GetFrameworkMappings("API_GATEWAY_LOGGING"),
 	}, nil
 }
@@ -186,7 +186,7 @@ func (c *APIGatewayChecks) CheckAPIGatewayAuth(ctx context.Context) (CheckResult
 			Evidence:    fmt.Sprintf("Failed to list APIs: %v", err),
 			Remediation: "Verify permissions",
 			Priority:    PriorityLow,
-			Timestamp:   time.Now(),
+			Timestamp:   nowFunc(),
 			Frameworks:  GetFrameworkMappings("API_GATEWAY_AUTH"),
 		}, err
 	}
@@ -199,7 +199,7 @@ func (c *APIGatewayChecks) CheckAPIGatewayAuth(ctx context.Context) (CheckResult
 			Evidence:    "No API Gateway APIs found",
 			Remediation: "N/A - No APIs to check",
 			Priority:    PriorityLow,
-			Timestamp:   time.Now(),
+			Timestamp:   nowFunc(),
 			Frameworks:  GetFrameworkMappings("API_GATEWAY_AUTH"),
 		}, nil
 	}
@@ -221,9 +221,9 @@ func (c *APIGatewayChecks) CheckAPIGatewayAuth(ctx context.Context) (CheckResult
 8. Screenshot showing authorizers configured`, len(restAPIs.Items)),
 		Severity:        "CRITICAL",
 		Priority:        PriorityCritical,
-		Timestamp:       time.Now(),
+		Timestamp:       nowFunc(),
 		ScreenshotGuide: "API Gateway → API → Authorizers → Screenshot showing configured authorizers",
-		ConsoleURL:      "https://console.aws.amazon.com/apigateway/home#/apis",
+		ConsoleURL:      consoleURL("apigateway/home#/apis", consoleRegion(ctx)),
 		Frameworks:      GetFrameworkMappings("API_GATEWAY_AUTH"),
 	}, nil
 }
@@ -240,7 +240,7 @@ func (c *APIGatewayChecks) CheckAPIGatewayTLS(ctx context.Context) (CheckResult,
 			Evidence:    fmt.Sprintf("Failed to list custom domains: %v", err),
 			Remediation: "Verify permissions",
 			Priority:    PriorityLow,
-			Timestamp:   time.Now(),
+			Timestamp:   nowFunc(),
 			Frameworks:  GetFrameworkMappings("API_GATEWAY_TLS"),
 		}, err
 	}
@@ -258,9 +258,9 @@ When configuring custom domains, ensure:
 2. Use ACM certificate
 3. Avoid TLS 1.0/1.1`,
 			Priority:        PriorityLow,
-			Timestamp:       time.Now(),
+			Timestamp:       nowFunc(),
 			ScreenshotGuide: "API Gateway → Custom domain names → Screenshot showing no custom domains or TLS 1.2",
-			ConsoleURL:      "https://console.aws.amazon.com/apigateway/home#/custom-domain-names",
+			ConsoleURL:      consoleURL("apigateway/home#/custom-domain-names", consoleRegion(ctx)),
 			Frameworks:      GetFrameworkMappings("API_GATEWAY_TLS"),
 		}, nil
 	}
@@ -284,11 +284,12 @@ When configuring custom domains, ensure:
 
 	if len(weakTLSDomains) > 0 {
 		return CheckResult{
-			Control:     "CIS-10.9",
-			Name:        "API Gateway TLS 1.2+",
-			Status:      "FAIL",
-			Evidence:    fmt.Sprintf("%d custom domains use weak TLS (1.0/1.1): %v", len(weakTLSDomains), weakTLSDomains),
-			Remediation: "Upgrade custom domains to TLS 1.2 security policy",
+			Control:           "CIS-10.9",
+			Name:              "API Gateway TLS 1.2+",
+			Status:            "FAIL",
+			Evidence:          fmt.Sprintf("%d custom domains use weak TLS (1.0/1.1): %s", len(weakTLSDomains), TruncateList(weakTLSDomains, evidenceListLimit(ctx))),
+			AffectedResources: weakTLSDomains,
+			Remediation:       "Upgrade custom domains to TLS 1.2 security policy",
 			RemediationDetail: fmt.Sprintf(`1. Open API Gateway console
 2. Navigate to Custom domain names
 3. For each domain with weak TLS: %v
@@ -298,9 +299,9 @@ When configuring custom domains, ensure:
 7. Test API connectivity after upgrade`, weakTLSDomains),
 			Severity:        "CRITICAL",
 			Priority:        PriorityCritical,
-			Timestamp:       time.Now(),
+			Timestamp:       nowFunc(),
 			ScreenshotGuide: "API Gateway → Custom domain names → Domain → Screenshot showing TLS 1.2 security policy",
-			ConsoleURL:      "https://console.aws.amazon.com/apigateway/home#/custom-domain-names",
+			ConsoleURL:      consoleURL("apigateway/home#/custom-domain-names", consoleRegion(ctx)),
 			Frameworks:      GetFrameworkMappings("API_GATEWAY_TLS"),
 		}, nil
 	}
@@ -315,9 +316,9 @@ When configuring custom domains, ensure:
 Continue using TLS 1.2+ for all new custom domains.`, secureDomains),
 		Severity:        "INFO",
 		Priority:        PriorityLow,
-		Timestamp:       time.Now(),
+		Timestamp:       nowFunc(),
 		ScreenshotGuide: "API Gateway → Custom domain names → Screenshot showing all TLS 1.2",
-		ConsoleURL:      "https://console.aws.amazon.com/apigateway/home#/custom-domain-names",
+		ConsoleURL:      consoleURL("apigateway/home#/custom-domain-names", consoleRegion(ctx)),
 		Frameworks:      GetFrameworkMappings("API_GATEWAY_TLS"),
 	}, nil
 }
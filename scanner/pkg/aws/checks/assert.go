@@ -0,0 +1,107 @@
+package checks
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+// debugAssertions turns mapping mistakes into panics so they surface in
+// development and CI runs instead of shipping findings no auditor can place.
+// Enabled by AUDITKIT_DEBUG or SetDebugAssertions. Checks read it from their
+// own goroutines, so it is atomic.
+var debugAssertions = envDebugAssertions()
+
+// envDebugAssertions reads AUDITKIT_DEBUG. It runs as a variable initializer,
+// not in init, so package-level tables such as checkManifest that call
+// GetFrameworkMappings are checked as well.
+func envDebugAssertions() *atomic.Bool {
+	enabled := new(atomic.Bool)
+	enabled.Store(os.Getenv("AUDITKIT_DEBUG") != "")
+	return enabled
+}
+
+// SetDebugAssertions enables or disables the framework mapping assertions
+func SetDebugAssertions(enabled bool) {
+	debugAssertions.Store(enabled)
+}
+
+// ValidateFrameworks reports a FAIL result with no framework mapping, usually
+// the sign of a mistyped GetFrameworkMappings key
+func ValidateFrameworks(result CheckResult) error {
+	if result.Status == "FAIL" && len(result.Frameworks) == 0 {
+		return fmt.Errorf("%s %q failed with no framework mappings", result.Control, result.Name)
+	}
+	return nil
+}
+
+// assertFrameworks panics on ValidateFrameworks errors when debug assertions
+// are enabled
+func assertFrameworks(result CheckResult) {
+	if !debugAssertions.Load() {
+		return
+	}
+	if err := ValidateFrameworks(result); err != nil {
+		panic(err)
+	}
+}
+
+// validStatuses are the Status values reports know how to render
+var validStatuses = map[string]bool{
+	"PASS":           true,
+	"FAIL":           true,
+	"WARN":           true,
+	"INFO":           true,
+	"MANUAL":         true,
+	"ERROR":          true,
+	"NOT_APPLICABLE": true,
+}
+
+// ValidateResults lints results before they are reported and returns one error
+// per problem: a missing Control or Name, an unknown Status, a Severity on a
+// PASS, an unknown Severity, and the ValidateFrameworks mapping check. An
+// empty slice means every result is well formed.
+func ValidateResults(results []CheckResult) []error {
+	issues := []error{}
+	for i, result := range results {
+		label := resultLabel(i, result)
+
+		if strings.TrimSpace(result.Control) == "" {
+			issues = append(issues, fmt.Errorf("%s: empty Control", label))
+		}
+		if strings.TrimSpace(result.Name) == "" {
+			issues = append(issues, fmt.Errorf("%s: empty Name", label))
+		}
+		if !validStatuses[result.Status] {
+			issues = append(issues, fmt.Errorf("%s: unknown Status %q", label, result.Status))
+		}
+		if result.Severity != "" {
+			if result.Status == "PASS" {
+				issues = append(issues, fmt.Errorf("%s: Severity %s set on a PASS", label, result.Severity))
+			} else if !validSeverities[result.Severity] {
+				issues = append(issues, fmt.Errorf("%s: unknown Severity %q", label, result.Severity))
+			}
+		}
+		if err := ValidateFrameworks(result); err != nil {
+			issues = append(issues, fmt.Errorf("%s: %v", label, err))
+		}
+	}
+	return issues
+}
+
+// resultLabel names a result in validation messages, falling back to its
+// position when it has neither a name nor a control
+func resultLabel(index int, result CheckResult) string {
+	name := result.Name
+	if name == "" {
+		name = result.Control
+	}
+	if name == "" {
+		name = fmt.Sprintf("result #%d", index+1)
+	}
+	if result.Service != "" {
+		return result.Service + ": " + name
+	}
+	return name
+}
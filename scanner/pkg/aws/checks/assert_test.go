@@ -0,0 +1,44 @@
+package checks
+
+import (
+	"testing"
+)
+
+func TestCheckManifestFrameworkKeysResolve(t *testing.T) {
+	// GetFrameworkMappings returns an empty, non-nil map for a key
+	// FrameworkMappings does not have; entries with no mapping leave
+	// Frameworks nil
+	for _, entry := range checkManifest {
+		if entry.Frameworks != nil && len(entry.Frameworks) == 0 {
+			t.Errorf("%s: framework mapping key not in FrameworkMappings", entry.ID)
+		}
+	}
+}
+
+func TestGetFrameworkMappingsPanicsUnderDebugAssertions(t *testing.T) {
+	SetDebugAssertions(true)
+	defer SetDebugAssertions(false)
+
+	defer func() {
+		if recover() == nil {
+			t.Error("unknown key did not panic")
+		}
+	}()
+	GetFrameworkMappings("NO_SUCH_MAPPING")
+}
+
+func TestAssertFrameworksOnlyWhenEnabled(t *testing.T) {
+	unmapped := CheckResult{Control: "CC6.1", Name: "Unmapped", Status: "FAIL"}
+
+	SetDebugAssertions(false)
+	assertFrameworks(unmapped)
+
+	SetDebugAssertions(true)
+	defer SetDebugAssertions(false)
+	defer func() {
+		if recover() == nil {
+			t.Error("unmapped FAIL did not panic")
+		}
+	}()
+	assertFrameworks(unmapped)
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/rds"
 )
@@ -24,7 +23,7 @@ func (c *AuroraChecks) Name() string {
 func (c *AuroraChecks) Run(ctx context.Context) ([]CheckResult, error) {
 	results := []CheckResult{}
 
-	if result, err := c.CheckBacktrackEnabled(ctx); err == nil {
+	if result, err := runCheck(ctx, "aurora.backtrack_enabled", c.CheckBacktrackEnabled); err == nil {
 		results = append(results, result)
 	}
 
@@ -40,7 +39,7 @@ func (c *AuroraChecks) CheckBacktrackEnabled(ctx context.Context) (CheckResult,
 			Status:     "ERROR",
 			Evidence:   fmt.Sprintf("Failed to list DB clusters: %v", err),
 			Priority:   PriorityLow,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("AURORA_BACKTRACK"),
 		}, err
 	}
@@ -52,7 +51,7 @@ func (c *AuroraChecks) CheckBacktrackEnabled(ctx context.Context) (CheckResult,
 			Status:     "INFO",
 			Evidence:   "No Aurora DB clusters found",
 			Priority:   PriorityLow,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("AURORA_BACKTRACK"),
 		}, nil
 	}
@@ -81,7 +80,7 @@ func (c *AuroraChecks) CheckBacktrackEnabled(ctx context.Context) (CheckResult,
 			Status:     "INFO",
 			Evidence:   "No Aurora clusters found (only RDS/other databases)",
 			Priority:   PriorityLow,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("AURORA_BACKTRACK"),
 		}, nil
 	}
@@ -91,7 +90,7 @@ func (c *AuroraChecks) CheckBacktrackEnabled(ctx context.Context) (CheckResult,
 			Control:     "CIS-18.1",
 			Name:        "Aurora Backtrack Enabled",
 			Status:      "FAIL",
-			Evidence:    fmt.Sprintf("%d/%d Aurora clusters lack backtrack: %v", len(without), len(auroraClusters), without),
+			Evidence:    fmt.Sprintf("%d/%d Aurora clusters lack backtrack: %s", len(without), len(auroraClusters), TruncateList(without, evidenceListLimit(ctx))),
 			Remediation: "Enable backtrack on Aurora clusters for point-in-time recovery",
 			RemediationDetail: fmt.Sprintf(`Aurora Backtrack allows rewinding to specific point without restoring backup.
 
@@ -104,9 +103,9 @@ func (c *AuroraChecks) CheckBacktrackEnabled(ctx context.Context) (CheckResult,
 Note: Backtrack only available for Aurora MySQL`, without),
 			Severity:        "MEDIUM",
 			Priority:        PriorityMedium,
-			Timestamp:       time.Now(),
+			Timestamp:       nowFunc(),
 			ScreenshotGuide: "RDS → Databases → Cluster → Modify → Screenshot showing backtrack enabled",
-			ConsoleURL:      "https://console.aws.amazon.com/rds/home#databases:",
+			ConsoleURL:      consoleURL("rds/home#databases:", consoleRegion(ctx)),
 			Frameworks:      GetFrameworkMappings("AURORA_BACKTRACK"),
 		}, nil
 	}
@@ -117,8 +116,8 @@ Note: Backtrack only available for Aurora MySQL`, without),
 		Status:     "PASS",
 		Evidence:   fmt.Sprintf("All %d Aurora clusters have backtrack enabled", with),
 		Priority:   PriorityLow,
-		Timestamp:  time.Now(),
-		ConsoleURL: "https://console.aws.amazon.com/rds/home#databases:",
+		Timestamp:  nowFunc(),
+		ConsoleURL: consoleURL("rds/home#databases:", consoleRegion(ctx)),
 		Frameworks: GetFrameworkMappings("AURORA_BACKTRACK"),
 	}, nil
 }
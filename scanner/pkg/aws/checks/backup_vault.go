@@ -3,7 +3,6 @@ package checks
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/backup"
 )
@@ -24,17 +23,17 @@ func (c *BackupVaultChecks) Run(ctx context.Context) ([]CheckResult, error) {
 	results := []CheckResult{}
 
 	// CIS Section 10.10 - Backup Vault Encryption
-	if result, err := c.CheckBackupVaultEncryption(ctx); err == nil {
+	if result, err := runCheck(ctx, "backup_vault.encryption", c.CheckBackupVaultEncryption); err == nil {
 		results = append(results, result)
 	}
 
 	// CIS Section 10.11 - Backup Plan Exists
-	if result, err := c.CheckBackupPlanExists(ctx); err == nil {
+	if result, err := runCheck(ctx, "backup_vault.backup_plan_exists", c.CheckBackupPlanExists); err == nil {
 		results = append(results, result)
 	}
 
 	// CIS Section 10.12 - Backup Vault Lock
-	if result, err := c.CheckBackupVaultLock(ctx); err == nil {
+	if result, err := runCheck(ctx, "backup_vault.lock", c.CheckBackupVaultLock); err == nil {
 		results = append(results, result)
 	}
 
@@ -52,7 +51,7 @@ func (c *BackupVaultChecks) CheckBackupVaultEncryption(ctx context.Context) (Che
 			Evidence:    fmt.Sprintf("Failed to list backup vaults: %v", err),
 			Remediation: "Verify AWS Backup access permissions",
 			Priority:    PriorityLow,
-			Timestamp:   time.Now(),
+			Timestamp:   nowFunc(),
 			Frameworks:  GetFrameworkMappings("BACKUP_VAULT_ENCRYPTION"),
 		}, err
 	}
@@ -70,9 +69,9 @@ func (c *BackupVaultChecks) CheckBackupVaultEncryption(ctx context.Context) (Che
 4. All backups stored in vault are automatically encrypted
 5. Screenshot showing encrypted vault creation`,
 			Priority:        PriorityLow,
-			Timestamp:       time.Now(),
+			Timestamp:       nowFunc(),
 			ScreenshotGuide: "AWS Backup → Backup vaults → Screenshot showing no vaults",
-			ConsoleURL:      "https://console.aws.amazon.com/backup/home#/backupvaults",
+			ConsoleURL:      consoleURL("backup/home#/backupvaults", consoleRegion(ctx)),
 			Frameworks:      GetFrameworkMappings("BACKUP_VAULT_ENCRYPTION"),
 		}, nil
 	}
@@ -93,7 +92,7 @@ func (c *BackupVaultChecks) CheckBackupVaultEncryption(ctx context.Context) (Che
 			Control:     "CIS-10.10",
 			Name:        "AWS Backup Vault Encryption",
 			Status:      "FAIL",
-			Evidence:    fmt.Sprintf("%d/%d backup vaults lack encryption: %v", len(unencryptedVaults), len(vaults.BackupVaultList), unencryptedVaults),
+			Evidence:    fmt.Sprintf("%d/%d backup vaults lack encryption: %s", len(unencryptedVaults), len(vaults.BackupVaultList), TruncateList(unencryptedVaults, evidenceListLimit(ctx))),
 			Remediation: "Create new encrypted backup vaults and migrate backups",
 			RemediationDetail: fmt.Sprintf(`1. Open AWS Backup console
 2. Create new backup vault with encryption
@@ -104,9 +103,9 @@ func (c *BackupVaultChecks) CheckBackupVaultEncryption(ctx context.Context) (Che
 7. Unencrypted vaults: %v`, unencryptedVaults),
 			Severity:        "CRITICAL",
 			Priority:        PriorityCritical,
-			Timestamp:       time.Now(),
+			Timestamp:       nowFunc(),
 			ScreenshotGuide: "AWS Backup → Backup vaults → Screenshot showing encrypted vaults",
-			ConsoleURL:      "https://console.aws.amazon.com/backup/home#/backupvaults",
+			ConsoleURL:      consoleURL("backup/home#/backupvaults", consoleRegion(ctx)),
 			Frameworks:      GetFrameworkMappings("BACKUP_VAULT_ENCRYPTION"),
 		}, nil
 	}
@@ -121,9 +120,9 @@ func (c *BackupVaultChecks) CheckBackupVaultEncryption(ctx context.Context) (Che
 Continue using encryption for all new backup vaults.`, encryptedVaults),
 		Severity:        "INFO",
 		Priority:        PriorityLow,
-		Timestamp:       time.Now(),
+		Timestamp:       nowFunc(),
 		ScreenshotGuide: "AWS Backup → Backup vaults → Screenshot showing all encrypted",
-		ConsoleURL:      "https://console.aws.amazon.com/backup/home#/backupvaults",
+		ConsoleURL:      consoleURL("backup/home#/backupvaults", consoleRegion(ctx)),
 		Frameworks:      GetFrameworkMappings("BACKUP_VAULT_ENCRYPTION"),
 	}, nil
 }
@@ -139,7 +138,7 @@ func (c *BackupVaultChecks) CheckBackupPlanExists(ctx context.Context) (CheckRes
 			Evidence:    fmt.Sprintf("Failed to list backup plans: %v", err),
 			Remediation: "Verify AWS Backup permissions",
 			Priority:    PriorityLow,
-			Timestamp:   time.Now(),
+			Timestamp:   nowFunc(),
 			Frameworks:  GetFrameworkMappings("BACKUP_PLAN_EXISTS"),
 		}, err
 	}
@@ -165,9 +164,9 @@ func (c *BackupVaultChecks) CheckBackupPlanExists(ctx context.Context) (CheckRes
 7. Screenshot showing configured backup plan`,
 			Severity:        "CRITICAL",
 			Priority:        PriorityCritical,
-			Timestamp:       time.Now(),
+			Timestamp:       nowFunc(),
 			ScreenshotGuide: "AWS Backup → Backup plans → Create plan → Screenshot showing plan configuration",
-			ConsoleURL:      "https://console.aws.amazon.com/backup/home#/backupplans",
+			ConsoleURL:      consoleURL("backup/home#/backupplans", consoleRegion(ctx)),
 			Frameworks:      GetFrameworkMappings("BACKUP_PLAN_EXISTS"),
 		}, nil
 	}
@@ -192,9 +191,9 @@ Regularly verify:
 3. All critical resources are covered`, activePlans),
 		Severity:        "INFO",
 		Priority:        PriorityLow,
-		Timestamp:       time.Now(),
+		Timestamp:       nowFunc(),
 		ScreenshotGuide: "AWS Backup → Backup plans → Screenshot showing active plans",
-		ConsoleURL:      "https://console.aws.amazon.com/backup/home#/backupplans",
+		ConsoleURL:      consoleURL("backup/home#/backupplans", consoleRegion(ctx)),
 		Frameworks:      GetFrameworkMappings("BACKUP_PLAN_EXISTS"),
 	}, nil
 }
@@ -210,7 +209,7 @@ func (c *BackupVaultChecks) CheckBackupVaultLock(ctx context.Context) (CheckResu
 			Evidence:    fmt.Sprintf("Failed to list vaults: %v", err),
 			Remediation: "Verify permissions",
 			Priority:    PriorityLow,
-			Timestamp:   time.Now(),
+			Timestamp:   nowFunc(),
 			Frameworks:  GetFrameworkMappings("BACKUP_VAULT_LOCK"),
 		}, err
 	}
@@ -223,7 +222,7 @@ func (c *BackupVaultChecks) CheckBackupVaultLock(ctx context.Context) (CheckResu
 			Evidence:    "No backup vaults found",
 			Remediation: "N/A - No vaults to lock",
 			Priority:    PriorityLow,
-			Timestamp:   time.Now(),
+			Timestamp:   nowFunc(),
 			Frameworks:  GetFrameworkMappings("BACKUP_VAULT_LOCK"),
 		}, nil
 	}
@@ -255,7 +254,7 @@ func (c *BackupVaultChecks) CheckBackupVaultLock(ctx context.Context) (CheckResu
 			Control:     "CIS-10.12",
 			Name:        "AWS Backup Vault Lock Enabled",
 			Status:      "FAIL",
-			Evidence:    fmt.Sprintf("%d/%d vaults lack vault lock (WORM protection): %v", len(vaultsWithoutLock), len(vaults.BackupVaultList), vaultsWithoutLock),
+			Evidence:    fmt.Sprintf("%d/%d vaults lack vault lock (WORM protection): %s", len(vaultsWithoutLock), len(vaults.BackupVaultList), TruncateList(vaultsWithoutLock, evidenceListLimit(ctx))),
 			Remediation: "Enable AWS Backup Vault Lock for immutable backups",
 			RemediationDetail: fmt.Sprintf(`1. Open AWS Backup console
 2. For each vault without lock: %v
@@ -270,9 +269,9 @@ func (c *BackupVaultChecks) CheckBackupVaultLock(ctx context.Context) (CheckResu
 Ensure retention policies are correct before enabling.`, vaultsWithoutLock),
 			Severity:        "HIGH",
 			Priority:        PriorityHigh,
-			Timestamp:       time.Now(),
+			Timestamp:       nowFunc(),
 			ScreenshotGuide: "AWS Backup → Backup vaults → Vault → Vault lock → Screenshot showing lock enabled",
-			ConsoleURL:      "https://console.aws.amazon.com/backup/home#/backupvaults",
+			ConsoleURL:      consoleURL("backup/home#/backupvaults", consoleRegion(ctx)),
 			Frameworks:      GetFrameworkMappings("BACKUP_VAULT_LOCK"),
 		}, nil
 	}
@@ -287,9 +286,9 @@ Ensure retention policies are correct before enabling.`, vaultsWithoutLock),
 This prevents accidental or malicious deletion of backups.`, vaultsWithLock),
 		Severity:        "INFO",
 		Priority:        PriorityLow,
-		Timestamp:       time.Now(),
+		Timestamp:       nowFunc(),
 		ScreenshotGuide: "AWS Backup → Backup vaults → Screenshot showing all vaults locked",
-		ConsoleURL:      "https://console.aws.amazon.com/backup/home#/backupvaults",
+		ConsoleURL:      consoleURL("backup/home#/backupvaults", consoleRegion(ctx)),
 		Frameworks:      GetFrameworkMappings("BACKUP_VAULT_LOCK"),
 	}, nil
 }
@@ -0,0 +1,114 @@
+package checks
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+)
+
+// evidenceResourceList matches the "[a b c]" lists checks print with
+// TruncateList
+var evidenceResourceList = regexp.MustCompile(`\[([^\[\]]*)\]`)
+
+// findingKey identifies a finding across scans by its structured fields: the
+// control, the check name, the check module and the region. Evidence is left
+// out: its counts, resource lists and the notes checks append after them
+// change as resources are fixed, and the finding must still match.
+func findingKey(result CheckResult) string {
+	return strings.ToLower(result.Control + "|" + result.Name + "|" + ResultService(result) + "|" + ResultRegion(result))
+}
+
+// FindingID is a stable identifier for the finding, for ticketing, diffing
+// and suppressions: a hash of the control, the check name, the check module,
+// the region and the account, the fields findingKey matches on. Evidence
+// text, the affected resources and scanning one region or several do not
+// change it, so the same misconfiguration keeps its ID on every run while
+// resources are fixed or added.
+func (r CheckResult) FindingID() string {
+	fields := []string{r.Control, r.Name, ResultService(r), ResultRegion(r), r.AccountID}
+	sum := sha256.Sum256([]byte(strings.Join(fields, "\x00")))
+	return hex.EncodeToString(sum[:8])
+}
+
+// evidenceResources returns the resources the evidence lists, from every
+// bracketed list in it, or nil when it lists none. Checks append notes with
+// lists of their own, such as resources that could not be verified, after
+// the main list.
+func evidenceResources(evidence string) []string {
+	var resources []string
+	for _, match := range evidenceResourceList.FindAllStringSubmatch(evidence, -1) {
+		resources = append(resources, strings.Fields(match[1])...)
+	}
+	return resources
+}
+
+// knownFinding is the resources a baseline failure listed, both in full from
+// AffectedResources and as printed in its Evidence list
+type knownFinding struct {
+	affected map[string]bool // nil when the baseline predates AffectedResources
+	listed   map[string]bool
+}
+
+// NewFindings returns the failing results in current that the baseline did not
+// already report: findings whose check did not fail in the baseline, and
+// findings that now list resources the baseline's failure did not. Fixed
+// resources never make a finding new, so CI can gate on introduced
+// misconfigurations without failing on existing debt. Failures are matched by
+// findingKey, and resources are compared by AffectedResources when both
+// failures carry it, otherwise by the Evidence lists, which may be truncated.
+func NewFindings(current, baseline []CheckResult) []CheckResult {
+	known := map[string]*knownFinding{}
+	for _, result := range baseline {
+		if result.Status != "FAIL" {
+			continue
+		}
+		key := findingKey(result)
+		if known[key] == nil {
+			known[key] = &knownFinding{listed: map[string]bool{}}
+		}
+		finding := known[key]
+		if result.AffectedResources != nil {
+			if finding.affected == nil {
+				finding.affected = map[string]bool{}
+			}
+			for _, resource := range result.AffectedResources {
+				finding.affected[resource] = true
+			}
+		}
+		for _, resource := range evidenceResources(result.Evidence) {
+			finding.listed[resource] = true
+		}
+	}
+
+	findings := []CheckResult{}
+	for _, result := range current {
+		if result.Status != "FAIL" {
+			continue
+		}
+
+		finding, failedBefore := known[findingKey(result)]
+		if !failedBefore {
+			// Baselines recorded before results carried a region
+			regionless := result
+			regionless.Service, regionless.Region = ResultService(result), ""
+			finding, failedBefore = known[findingKey(regionless)]
+		}
+		if !failedBefore {
+			findings = append(findings, result)
+			continue
+		}
+
+		resources, seen := evidenceResources(result.Evidence), finding.listed
+		if finding.affected != nil && result.AffectedResources != nil {
+			resources, seen = result.AffectedResources, finding.affected
+		}
+		for _, resource := range resources {
+			if !seen[resource] {
+				findings = append(findings, result)
+				break
+			}
+		}
+	}
+	return findings
+}
@@ -0,0 +1,169 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+// failingResult is a FAIL result listing resources the way checks print them,
+// followed by note
+func failingResult(control, name string, resources []string, note string) CheckResult {
+	return CheckResult{
+		Control:           control,
+		Name:              name,
+		Status:            "FAIL",
+		Evidence:          fmt.Sprintf("%d resources failing: %s", len(resources), TruncateList(resources, DefaultEvidenceListLimit)) + note,
+		AffectedResources: resources,
+	}
+}
+
+func TestNewFindingsIgnoresFixedResourcesWhateverFollowsTheList(t *testing.T) {
+	unverified := func(ids ...string) string {
+		return fmt.Sprintf(" | %d clusters could not be read and were not verified: %s", len(ids), TruncateList(ids, DefaultEvidenceListLimit))
+	}
+	memcached := memcachedNotApplicableNote(context.Background(), []string{"memcached-1"}, "Memcached does not support encryption at rest")
+
+	tests := map[string]struct {
+		baseline, current CheckResult
+	}{
+		"unverified note": {
+			baseline: failingResult("CC6.3", "Redshift IAM Role Scope", []string{"a", "b"}, unverified("c")),
+			current:  failingResult("CC6.3", "Redshift IAM Role Scope", []string{"a"}, unverified("c", "d")),
+		},
+		"memcached not applicable note": {
+			baseline: failingResult("CC6.3", "ElastiCache Encryption at Rest", []string{"redis-1", "redis-2"}, memcached),
+			current:  failingResult("CC6.3", "ElastiCache Encryption at Rest", []string{"redis-2"}, memcached),
+		},
+		"open Redis correlation note": {
+			baseline: failingResult("CC6.4", "ElastiCache Encryption in Transit", []string{"redis-1", "redis-2"}, fmt.Sprintf(" (1 of these also reported as CRITICAL under %q)", openRedisCheckName)),
+			current:  failingResult("CC6.4", "ElastiCache Encryption in Transit", []string{"redis-1"}, fmt.Sprintf(" (all also reported as CRITICAL under %q)", openRedisCheckName)),
+		},
+		"count only in evidence": {
+			baseline: CheckResult{Control: "CIS-1.3", Name: "Credentials Unused 45+ Days", Status: "FAIL", Evidence: "3 credentials unused for 45+ days | Violates CIS-1.3", AffectedResources: []string{"a", "b", "c"}},
+			current:  CheckResult{Control: "CIS-1.3", Name: "Credentials Unused 45+ Days", Status: "FAIL", Evidence: "2 credentials unused for 45+ days | Violates CIS-1.3", AffectedResources: []string{"a", "b"}},
+		},
+	}
+	for name, tt := range tests {
+		if got := NewFindings([]CheckResult{tt.current}, []CheckResult{tt.baseline}); len(got) != 0 {
+			t.Errorf("%s: fixing a resource reported %d new findings: %q", name, len(got), got[0].Evidence)
+		}
+	}
+}
+
+func TestNewFindingsReportsAddedResources(t *testing.T) {
+	note := fmt.Sprintf(" (all also reported as CRITICAL under %q)", openRedisCheckName)
+	baseline := []CheckResult{failingResult("CC6.4", "ElastiCache Encryption in Transit", []string{"redis-1"}, note)}
+	current := []CheckResult{failingResult("CC6.4", "ElastiCache Encryption in Transit", []string{"redis-1", "redis-2"}, note)}
+
+	if got := NewFindings(current, baseline); len(got) != 1 {
+		t.Errorf("adding a resource reported %d new findings, want 1", len(got))
+	}
+}
+
+func TestNewFindingsComparesEvidenceListsWithoutAffectedResources(t *testing.T) {
+	unverified := " | 1 clusters could not be read and were not verified: [c]"
+	baseline := failingResult("CC7.1", "Redshift Audit Log Destination", []string{"a", "b"}, unverified)
+	baseline.AffectedResources = nil
+
+	fixed := failingResult("CC7.1", "Redshift Audit Log Destination", []string{"a"}, unverified)
+	fixed.AffectedResources = nil
+	if got := NewFindings([]CheckResult{fixed}, []CheckResult{baseline}); len(got) != 0 {
+		t.Errorf("fixing a resource reported %d new findings", len(got))
+	}
+
+	added := failingResult("CC7.1", "Redshift Audit Log Destination", []string{"a", "d"}, unverified)
+	added.AffectedResources = nil
+	if got := NewFindings([]CheckResult{added}, []CheckResult{baseline}); len(got) != 1 {
+		t.Errorf("adding a resource reported %d new findings, want 1", len(got))
+	}
+}
+
+func TestNewFindingsKeysOnServiceAndRegion(t *testing.T) {
+	inRegion := func(service, region string) CheckResult {
+		result := failingResult("CC6.3", "Redshift Encryption", []string{"a"}, "")
+		result.Service, result.Region = service, region
+		return result
+	}
+	baseline := []CheckResult{inRegion("Redshift Security", "us-east-1")}
+
+	if got := NewFindings([]CheckResult{inRegion("Redshift Security (us-east-1)", "us-east-1")}, baseline); len(got) != 0 {
+		t.Errorf("same region under a multi-region Service label reported %d new findings", len(got))
+	}
+	if got := NewFindings([]CheckResult{inRegion("Redshift Security", "eu-west-1")}, baseline); len(got) != 1 {
+		t.Errorf("the same failure in another region reported %d new findings, want 1", len(got))
+	}
+	if got := NewFindings([]CheckResult{inRegion("Redshift Security", "us-east-1")}, []CheckResult{inRegion("Redshift Security", "")}); len(got) != 0 {
+		t.Errorf("a baseline recorded without regions reported %d new findings", len(got))
+	}
+}
+
+func TestNewFindingsIgnoresPassingResults(t *testing.T) {
+	baseline := []CheckResult{{Control: "CC6.3", Name: "Redshift Encryption", Status: "PASS"}}
+	current := []CheckResult{
+		{Control: "CC6.3", Name: "Redshift Encryption", Status: "PASS"},
+		failingResult("CC6.1", "Redshift Public Access", []string{"a"}, ""),
+	}
+
+	got := NewFindings(current, baseline)
+	if len(got) != 1 || got[0].Name != "Redshift Public Access" {
+		t.Errorf("NewFindings = %v, want only the new failure", got)
+	}
+}
+
+func findingIDTestResult() CheckResult {
+	return CheckResult{
+		Service:           "Redshift Security",
+		Region:            "us-east-1",
+		AccountID:         "123456789012",
+		Control:           "CC6.3",
+		Name:              "Redshift Encryption",
+		Status:            "FAIL",
+		Evidence:          "2 Redshift clusters unencrypted: [analytics reporting]",
+		AffectedResources: []string{"analytics", "reporting"},
+	}
+}
+
+func TestFindingIDStable(t *testing.T) {
+	want := findingIDTestResult().FindingID()
+
+	variants := map[string]func(r *CheckResult){
+		"unchanged":                  func(r *CheckResult) {},
+		"evidence reworded":          func(r *CheckResult) { r.Evidence = "Unencrypted clusters: analytics, reporting | Violates CC6.3" },
+		"resources reordered":        func(r *CheckResult) { r.AffectedResources = []string{"reporting", "analytics"} },
+		"resource fixed":             func(r *CheckResult) { r.AffectedResources = []string{"reporting"} },
+		"resource added":             func(r *CheckResult) { r.AffectedResources = append(r.AffectedResources, "staging") },
+		"multi-region Service label": func(r *CheckResult) { r.Service = "Redshift Security (us-east-1)" },
+		"severity overridden":        func(r *CheckResult) { r.Severity, r.OriginalSeverity = "LOW", "HIGH" },
+	}
+	for name, change := range variants {
+		result := findingIDTestResult()
+		change(&result)
+		if got := result.FindingID(); got != want {
+			t.Errorf("%s: FindingID %s, want %s", name, got, want)
+		}
+	}
+}
+
+func TestFindingIDUnique(t *testing.T) {
+	variants := map[string]func(r *CheckResult){
+		"control": func(r *CheckResult) { r.Control = "CC6.1" },
+		"name":    func(r *CheckResult) { r.Name = "Redshift Public Access" },
+		"service": func(r *CheckResult) { r.Service = "Custom Checks" },
+		"region":  func(r *CheckResult) { r.Region = "eu-west-1" },
+		"account": func(r *CheckResult) { r.AccountID = "210987654321" },
+		// Joining fields must not let one run into the next
+		"field boundary": func(r *CheckResult) { r.Control, r.Name = "CC6.3Redshift", " Encryption" },
+	}
+
+	seen := map[string]string{findingIDTestResult().FindingID(): "base"}
+	for name, change := range variants {
+		result := findingIDTestResult()
+		change(&result)
+		id := result.FindingID()
+		if other, ok := seen[id]; ok {
+			t.Errorf("%s: FindingID %s collides with %s", name, id, other)
+		}
+		seen[id] = name
+	}
+}
@@ -3,7 +3,6 @@ package checks
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/elasticbeanstalk"
 )
@@ -24,17 +23,17 @@ func (c *BeanstalkChecks) Run(ctx context.Context) ([]CheckResult, error) {
 	results := []CheckResult{}
 
 	// CIS Section 10.4 - Enhanced Health Reporting
-	if result, err := c.CheckEnhancedHealthReporting(ctx); err == nil {
+	if result, err := runCheck(ctx, "beanstalk.enhanced_health_reporting", c.CheckEnhancedHealthReporting); err == nil {
 		results = append(results, result)
 	}
 
 	// CIS Section 10.5 - Managed Platform Updates
-	if result, err := c.CheckManagedPlatformUpdates(ctx); err == nil {
+	if result, err := runCheck(ctx, "beanstalk.managed_platform_updates", c.CheckManagedPlatformUpdates); err == nil {
 		results = append(results, result)
 	}
 
 	// CIS Section 10.6 - Log Streaming
-	if result, err := c.CheckLogStreaming(ctx); err == nil {
+	if result, err := runCheck(ctx, "beanstalk.log_streaming", c.CheckLogStreaming); err == nil {
 		results = append(results, result)
 	}
 
@@ -52,7 +51,7 @@ func (c *BeanstalkChecks) CheckEnhancedHealthReporting(ctx context.Context) (Che
 			Evidence:    fmt.Sprintf("Failed to list Beanstalk environments: %v", err),
 			Remediation: "Verify Elastic Beanstalk access permissions",
 			Priority:    PriorityLow,
-			Timestamp:   time.Now(),
+			Timestamp:   nowFunc(),
 			Frameworks:  GetFrameworkMappings("BEANSTALK_ENHANCED_HEALTH"),
 		}, err
 	}
@@ -70,9 +69,9 @@ func (c *BeanstalkChecks) CheckEnhancedHealthReporting(ctx context.Context) (Che
 3. Configuration → Monitoring → Enhanced health reporting: Enabled
 4. This provides detailed health metrics and insights`,
 			Priority:        PriorityLow,
-			Timestamp:       time.Now(),
+			Timestamp:       nowFunc(),
 			ScreenshotGuide: "Elastic Beanstalk → Environments → Screenshot showing no environments",
-			ConsoleURL:      "https://console.aws.amazon.com/elasticbeanstalk/home#/environments",
+			ConsoleURL:      consoleURL("elasticbeanstalk/home#/environments", consoleRegion(ctx)),
 			Frameworks:      GetFrameworkMappings("BEANSTALK_ENHANCED_HEALTH"),
 		}, nil
 	}
@@ -93,7 +92,7 @@ func (c *BeanstalkChecks) CheckEnhancedHealthReporting(ctx context.Context) (Che
 			Control:     "CIS-10.4",
 			Name:        "Elastic Beanstalk Enhanced Health Reporting",
 			Status:      "FAIL",
-			Evidence:    fmt.Sprintf("%d/%d environments lack enhanced health reporting: %v", len(withoutEnhancedHealth), len(environments.Environments), withoutEnhancedHealth),
+			Evidence:    fmt.Sprintf("%d/%d environments lack enhanced health reporting: %s", len(withoutEnhancedHealth), len(environments.Environments), TruncateList(withoutEnhancedHealth, evidenceListLimit(ctx))),
 			Remediation: "Enable enhanced health reporting for Beanstalk environments",
 			RemediationDetail: fmt.Sprintf(`1. Open Elastic Beanstalk console
 2. For each environment without enhanced health: %v
@@ -103,9 +102,9 @@ func (c *BeanstalkChecks) CheckEnhancedHealthReporting(ctx context.Context) (Che
 6. Verify health status appears in console`, withoutEnhancedHealth),
 			Severity:        "MEDIUM",
 			Priority:        PriorityMedium,
-			Timestamp:       time.Now(),
+			Timestamp:       nowFunc(),
 			ScreenshotGuide: "Elastic Beanstalk → Environment → Configuration → Monitoring → Screenshot showing enhanced health enabled",
-			ConsoleURL:      "https://console.aws.amazon.com/elasticbeanstalk/home#/environments",
+			ConsoleURL:      consoleURL("elasticbeanstalk/home#/environments", consoleRegion(ctx)),
 			Frameworks:      GetFrameworkMappings("BEANSTALK_ENHANCED_HEALTH"),
 		}, nil
 	}
@@ -120,9 +119,9 @@ func (c *BeanstalkChecks) CheckEnhancedHealthReporting(ctx context.Context) (Che
 This provides detailed health insights and CloudWatch metrics.`, withEnhancedHealth),
 		Severity:        "INFO",
 		Priority:        PriorityLow,
-		Timestamp:       time.Now(),
+		Timestamp:       nowFunc(),
 		ScreenshotGuide: "Elastic Beanstalk → Environments → Screenshot showing all with enhanced health",
-		ConsoleURL:      "https://console.aws.amazon.com/elasticbeanstalk/home#/environments",
+		ConsoleURL:      consoleURL("elasticbeanstalk/home#/environments", consoleRegion(ctx)),
 		Frameworks:      GetFrameworkMappings("BEANSTALK_ENHANCED_HEALTH"),
 	}, nil
 }
@@ -138,7 +137,7 @@ func (c *BeanstalkChecks) CheckManagedPlatformUpdates(ctx context.Context) (Chec
 			Evidence:    fmt.Sprintf("Failed to list environments: %v", err),
 			Remediation: "Verify Beanstalk permissions",
 			Priority:    PriorityLow,
-			Timestamp:   time.Now(),
+			Timestamp:   nowFunc(),
 			Frameworks:  GetFrameworkMappings("BEANSTALK_MANAGED_UPDATES"),
 		}, err
 	}
@@ -151,7 +150,7 @@ func (c *BeanstalkChecks) CheckManagedPlatformUpdates(ctx context.Context) (Chec
 			Evidence:    "No Elastic Beanstalk environments found",
 			Remediation: "N/A - No environments to check",
 			Priority:    PriorityLow,
-			Timestamp:   time.Now(),
+			Timestamp:   nowFunc(),
 			Frameworks:  GetFrameworkMappings("BEANSTALK_MANAGED_UPDATES"),
 		}, nil
 	}
@@ -175,9 +174,9 @@ func (c *BeanstalkChecks) CheckManagedPlatformUpdates(ctx context.Context) (Chec
 Environments to check: %d total`, len(environments.Environments)),
 		Severity:        "MEDIUM",
 		Priority:        PriorityMedium,
-		Timestamp:       time.Now(),
+		Timestamp:       nowFunc(),
 		ScreenshotGuide: "Elastic Beanstalk → Environment → Configuration → Managed updates → Screenshot showing enabled",
-		ConsoleURL:      "https://console.aws.amazon.com/elasticbeanstalk/home#/environments",
+		ConsoleURL:      consoleURL("elasticbeanstalk/home#/environments", consoleRegion(ctx)),
 		Frameworks:      GetFrameworkMappings("BEANSTALK_MANAGED_UPDATES"),
 	}, nil
 }
@@ -193,7 +192,7 @@ func (c *BeanstalkChecks) CheckLogStreaming(ctx context.Context) (CheckResult, e
 			Evidence:    fmt.Sprintf("Failed to list environments: %v", err),
 			Remediation: "Verify Beanstalk permissions",
 			Priority:    PriorityLow,
-			Timestamp:   time.Now(),
+			Timestamp:   nowFunc(),
 			Frameworks:  GetFrameworkMappings("BEANSTALK_LOGS"),
 		}, err
 	}
@@ -206,7 +205,7 @@ func (c *BeanstalkChecks) CheckLogStreaming(ctx context.Context) (CheckResult, e
 			Evidence:    "No Elastic Beanstalk environments found",
 			Remediation: "N/A - No environments to check",
 			Priority:    PriorityLow,
-			Timestamp:   time.Now(),
+			Timestamp:   nowFunc(),
 			Frameworks:  GetFrameworkMappings("BEANSTALK_LOGS"),
 		}, nil
 	}
@@ -229,9 +228,9 @@ func (c *BeanstalkChecks) CheckLogStreaming(ctx context.Context) (CheckResult, e
 Environments to check: %d total`, len(environments.Environments)),
 		Severity:        "HIGH",
 		Priority:        PriorityHigh,
-		Timestamp:       time.Now(),
+		Timestamp:       nowFunc(),
 		ScreenshotGuide: "Elastic Beanstalk → Environment → Configuration → Software → Screenshot showing log streaming",
-		ConsoleURL:      "https://console.aws.amazon.com/elasticbeanstalk/home#/environments",
+		ConsoleURL:      consoleURL("elasticbeanstalk/home#/environments", consoleRegion(ctx)),
 		Frameworks:      GetFrameworkMappings("BEANSTALK_LOGS"),
 	}, nil
 }
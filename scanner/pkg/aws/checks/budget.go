@@ -0,0 +1,93 @@
+package checks
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DeltaBudget is how many new findings of each severity a change may
+// introduce before a baseline gate fails, e.g. {"LOW": 5, "MEDIUM": 1}.
+// Severities it does not list allow none, so the empty budget is the strict
+// "no new findings" gate.
+type DeltaBudget map[string]int
+
+// budgetSeverities orders severities for budget explanations, most severe first
+var budgetSeverities = []string{"CRITICAL", "HIGH", "MEDIUM", "LOW"}
+
+// ParseDeltaBudget reads a budget spec such as "low=5,medium=1". Severities
+// are case-insensitive; allowances must be zero or more.
+func ParseDeltaBudget(spec string) (DeltaBudget, error) {
+	budget := DeltaBudget{}
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		severity, count, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("budget entry %q: want severity=count, e.g. low=5", entry)
+		}
+		severity = strings.ToUpper(strings.TrimSpace(severity))
+		if !validSeverities[severity] {
+			return nil, fmt.Errorf("budget entry %q: unknown severity %q (want CRITICAL, HIGH, MEDIUM or LOW)", entry, severity)
+		}
+		allowed, err := strconv.Atoi(strings.TrimSpace(count))
+		if err != nil || allowed < 0 {
+			return nil, fmt.Errorf("budget entry %q: count must be a whole number of zero or more", entry)
+		}
+		budget[severity] = allowed
+	}
+	return budget, nil
+}
+
+// BudgetResult is a delta budget verdict: whether the new findings fit the
+// budget, and one explanation line per severity that had new findings or an
+// allowance
+type BudgetResult struct {
+	Passed      bool
+	New         map[string]int // new findings per severity
+	Exceeded    []string       // severities over their allowance, most severe first
+	Explanation []string
+}
+
+// EvaluateDeltaBudget judges newFindings (see NewFindings) against budget.
+// Findings without a severity count as LOW.
+func EvaluateDeltaBudget(newFindings []CheckResult, budget DeltaBudget) BudgetResult {
+	counts := map[string]int{}
+	for _, finding := range newFindings {
+		severity := strings.ToUpper(finding.Severity)
+		if severity == "" {
+			severity = "LOW"
+		}
+		counts[severity]++
+	}
+
+	// Known severities in order, then any others a custom check reported
+	severities := append([]string{}, budgetSeverities...)
+	others := []string{}
+	for severity := range counts {
+		if !validSeverities[severity] {
+			others = append(others, severity)
+		}
+	}
+	sort.Strings(others)
+	severities = append(severities, others...)
+
+	result := BudgetResult{Passed: true, New: counts}
+	for _, severity := range severities {
+		found, allowed := counts[severity], budget[severity]
+		if found == 0 && allowed == 0 {
+			continue
+		}
+		verdict := "within budget"
+		if found > allowed {
+			verdict = "OVER BUDGET"
+			result.Passed = false
+			result.Exceeded = append(result.Exceeded, severity)
+		}
+		result.Explanation = append(result.Explanation, fmt.Sprintf("%s: %d new, %d allowed (%s)", severity, found, allowed, verdict))
+	}
+	return result
+}
@@ -0,0 +1,256 @@
+package checks
+
+// checkManifest describes every check that runs through runCheck, sorted by
+// ID. IDs are the module and check name in snake_case, as passed to runCheck
+// in each module's Run; add new checks here when wiring them into Run. The
+// control, name, severity and framework mappings are those the check reports
+// when it fails, so the table can be read without running anything. Deep
+// marks checks that call AWS once per resource, which ProfileQuick skips. It
+// is the single list behind CheckIDs, checks config validation, scan
+// profiles and Manifest.
+var checkManifest = []ManifestEntry{
+	{ID: "access_analyzer.enabled", Control: "CIS-1.8", Name: "IAM Access Analyzer Enabled", Severity: "HIGH", Frameworks: GetFrameworkMappings("IAM_ACCESS_ANALYZER")},
+	{ID: "acm.certificate_in_use", Control: "CIS-16.2", Name: "ACM Certificate In Use", Severity: "LOW", Deep: true, Frameworks: GetFrameworkMappings("ACM_IN_USE")},
+	{ID: "acm.certificate_renewal", Control: "CIS-16.1", Name: "ACM Certificate Auto-Renewal", Severity: "CRITICAL", Deep: true, Frameworks: GetFrameworkMappings("ACM_RENEWAL")},
+	{ID: "api_gateway.auth", Control: "CIS-10.8", Name: "API Gateway Authorization Enabled", Severity: "CRITICAL", Frameworks: GetFrameworkMappings("API_GATEWAY_AUTH")},
+	{ID: "api_gateway.logging", Control: "CIS-10.7", Name: "API Gateway Logging Enabled", Severity: "HIGH", Deep: true, Frameworks: GetFrameworkMappings("API_GATEWAY_LOGGING")},
+	{ID: "api_gateway.tls", Control: "CIS-10.9", Name: "API Gateway TLS 1.2+", Severity: "CRITICAL", Frameworks: GetFrameworkMappings("API_GATEWAY_TLS")},
+	{ID: "aurora.backtrack_enabled", Control: "CIS-18.1", Name: "Aurora Backtrack Enabled", Severity: "MEDIUM", Frameworks: GetFrameworkMappings("AURORA_BACKTRACK")},
+	{ID: "backup_vault.backup_plan_exists", Control: "CIS-10.11", Name: "AWS Backup Plan Configured", Severity: "CRITICAL", Frameworks: GetFrameworkMappings("BACKUP_PLAN_EXISTS")},
+	{ID: "backup_vault.encryption", Control: "CIS-10.10", Name: "AWS Backup Vault Encryption", Severity: "CRITICAL", Frameworks: GetFrameworkMappings("BACKUP_VAULT_ENCRYPTION")},
+	{ID: "backup_vault.lock", Control: "CIS-10.12", Name: "AWS Backup Vault Lock Enabled", Severity: "HIGH", Deep: true, Frameworks: GetFrameworkMappings("BACKUP_VAULT_LOCK")},
+	{ID: "beanstalk.enhanced_health_reporting", Control: "CIS-10.4", Name: "Elastic Beanstalk Enhanced Health Reporting", Severity: "MEDIUM", Frameworks: GetFrameworkMappings("BEANSTALK_ENHANCED_HEALTH")},
+	{ID: "beanstalk.log_streaming", Control: "CIS-10.6", Name: "Elastic Beanstalk Log Streaming", Severity: "HIGH", Frameworks: GetFrameworkMappings("BEANSTALK_LOGS")},
+	{ID: "beanstalk.managed_platform_updates", Control: "CIS-10.5", Name: "Elastic Beanstalk Managed Platform Updates", Severity: "MEDIUM", Frameworks: GetFrameworkMappings("BEANSTALK_MANAGED_UPDATES")},
+	{ID: "cis_manual.monitoring_metric_filters", Control: "CIS-4.1", Name: "Metric Filters and Alarms (CIS 4.1-4.15)", Frameworks: map[string]string{"CIS-AWS": "4.1-4.15"}},
+	{ID: "cloudformation.drift_detection", Control: "CIS-15.2", Name: "CloudFormation Drift Detection", Frameworks: GetFrameworkMappings("CFN_DRIFT_DETECTION")},
+	{ID: "cloudformation.stack_policy", Control: "CIS-15.1", Name: "CloudFormation Stack Policy Configured", Severity: "MEDIUM", Deep: true, Frameworks: GetFrameworkMappings("CFN_STACK_POLICY")},
+	{ID: "cloudtrail.encryption", Control: "[CIS-3.7]", Name: "CloudTrail Encryption at Rest", Severity: "HIGH", Deep: true, Frameworks: GetFrameworkMappings("CLOUDTRAIL_ENCRYPTION")},
+	{ID: "cloudtrail.kms_key", Control: "[CIS-3.8]", Name: "CloudTrail KMS Key Rotation", Frameworks: GetFrameworkMappings("KMS_KEY_ROTATION")},
+	{ID: "cloudtrail.log_file_validation", Control: "CC7.1", Name: "CloudTrail Log Integrity", Severity: "MEDIUM", Deep: true, Frameworks: GetFrameworkMappings("CLOUDTRAIL_INTEGRITY")},
+	{ID: "cloudtrail.log_integration", Control: "[CIS-3.3]", Name: "CloudTrail CloudWatch Logs Integration", Severity: "MEDIUM", Deep: true, Frameworks: GetFrameworkMappings("CLOUDWATCH_LOG_ENCRYPTION")},
+	{ID: "cloudtrail.log_validation", Control: "[CIS-3.2]", Name: "CloudTrail Log File Validation", Severity: "MEDIUM", Deep: true, Frameworks: GetFrameworkMappings("CLOUDTRAIL_VALIDATION")},
+	{ID: "cloudtrail.multi_region", Control: "CIS-3.1, CC7.1", Name: "Multi-Region CloudTrail", Severity: "HIGH", Deep: true, Frameworks: GetFrameworkMappings("CLOUDTRAIL_MULTIREGION")},
+	{ID: "cloudtrail.s3_bucket_access_logging", Control: "[CIS-3.6]", Name: "CloudTrail S3 Bucket Logging", Frameworks: GetFrameworkMappings("CLOUDTRAIL_S3_LOGGING")},
+	{ID: "cloudtrail.s3_bucket_policy", Control: "[CIS-3.4]", Name: "CloudTrail S3 Bucket Policy", Frameworks: GetFrameworkMappings("S3_CLOUDTRAIL_BUCKET")},
+	{ID: "cloudtrail.s3_object_level_logging_read", Control: "[CIS-3.11]", Name: "S3 Object-Level Logging (Read)", Severity: "MEDIUM", Deep: true, Frameworks: map[string]string{"CIS-AWS": "3.11", "SOC2": "CC7.2"}},
+	{ID: "cloudtrail.s3_object_level_logging_write", Control: "[CIS-3.10]", Name: "S3 Object-Level Logging (Write)", Severity: "HIGH", Deep: true, Frameworks: map[string]string{"CIS-AWS": "3.10", "SOC2": "CC7.2"}},
+	{ID: "cloudtrail.trail_enabled", Control: "CC7.1", Name: "CloudTrail Logging Enabled", Severity: "CRITICAL", Deep: true, Frameworks: GetFrameworkMappings("CLOUDTRAIL_ENABLED")},
+	{ID: "cloudwatch_logs.log_group_retention", Control: "CC7.1", Name: "CloudWatch Logs Retention", Severity: "LOW", Frameworks: GetFrameworkMappings("CLOUDWATCH_LOG_RETENTION")},
+	{ID: "cmmc_level1.ac_l1_001", Control: "AC.L1-3.1.1", Name: "[CMMC L1] Limit System Access", Frameworks: map[string]string{"CMMC": "AC.L1-3.1.1", "NIST 800-171": "3.1.1"}},
+	{ID: "cmmc_level1.ac_l1_002", Control: "AC.L1-3.1.2", Name: "[CMMC L1] Limit System Access to Authorized Users", Frameworks: map[string]string{"CMMC": "AC.L1-3.1.2", "NIST 800-171": "3.1.2"}},
+	{ID: "cmmc_level1.ia_l1_001", Control: "IA.L1-3.5.1", Name: "[CMMC L1] Identify Users", Frameworks: map[string]string{"CMMC": "IA.L1-3.5.1", "NIST 800-171": "3.5.1"}},
+	{ID: "cmmc_level1.ia_l1_002", Control: "IA.L1-3.5.2", Name: "[CMMC L1] Authenticate Users", Frameworks: map[string]string{"CMMC": "IA.L1-3.5.2", "NIST 800-171": "3.5.2"}},
+	{ID: "cmmc_level1.mp_l1_001", Control: "MP.L1-3.8.3", Name: "[CMMC L1] Sanitize Media", Frameworks: map[string]string{"CMMC": "MP.L1-3.8.3", "NIST 800-171": "3.8.3"}},
+	{ID: "cmmc_level1.pe_l1_001", Control: "PE.L1-3.10.1", Name: "[CMMC L1] Limit Physical Access", Frameworks: map[string]string{"CMMC": "PE.L1-3.10.1", "NIST 800-171": "3.10.1"}},
+	{ID: "cmmc_level1.pe_l1_002", Control: "PE.L1-3.10.3", Name: "[CMMC L1] Escort Visitors", Frameworks: map[string]string{"CMMC": "PE.L1-3.10.3", "NIST 800-171": "3.10.3"}},
+	{ID: "cmmc_level1.pe_l1_003", Control: "PE.L1-3.10.4", Name: "[CMMC L1] Maintain Audit Logs", Frameworks: map[string]string{"CMMC": "PE.L1-3.10.4", "NIST 800-171": "3.10.4"}},
+	{ID: "cmmc_level1.pe_l1_004", Control: "PE.L1-3.10.5", Name: "[CMMC L1] Control Physical Access Devices", Frameworks: map[string]string{"CMMC": "PE.L1-3.10.5", "NIST 800-171": "3.10.5"}},
+	{ID: "cmmc_level1.pe_l1_005", Control: "PE.L1-3.10.2", Name: "[CMMC L1] Protect and Monitor Physical Facility", Frameworks: map[string]string{"CMMC": "PE.L1-3.10.2", "NIST 800-171": "3.10.2"}},
+	{ID: "cmmc_level1.pe_l1_006", Control: "PE.L1-3.10.6", Name: "[CMMC L1] Enforce Safeguarding Measures for CUI", Frameworks: map[string]string{"CMMC": "PE.L1-3.10.6", "NIST 800-171": "3.10.6"}},
+	{ID: "cmmc_level1.ps_l1_001", Control: "PS.L1-3.9.1", Name: "[CMMC L1] Screen Personnel", Frameworks: map[string]string{"CMMC": "PS.L1-3.9.1", "NIST 800-171": "3.9.1"}},
+	{ID: "cmmc_level1.ps_l1_002", Control: "PS.L1-3.9.2", Name: "[CMMC L1] Ensure CUI Access Authorization", Frameworks: map[string]string{"CMMC": "PS.L1-3.9.2", "NIST 800-171": "3.9.2"}},
+	{ID: "cmmc_level1.sc_l1_001", Control: "SC.L1-3.13.1", Name: "[CMMC L1] Monitor Communications", Frameworks: map[string]string{"CMMC": "SC.L1-3.13.1", "NIST 800-171": "3.13.1"}},
+	{ID: "cmmc_level1.sc_l1_002", Control: "SC.L1-3.13.5", Name: "[CMMC L1] Implement Subnetworks for Public Systems", Frameworks: map[string]string{"CMMC": "SC.L1-3.13.5", "NIST 800-171": "3.13.5"}},
+	{ID: "cmmc_level1.si_l1_001", Control: "SI.L1-3.14.1", Name: "[CMMC L1] Identify Flaws", Frameworks: map[string]string{"CMMC": "SI.L1-3.14.1", "NIST 800-171": "3.14.1"}},
+	{ID: "cmmc_level1.si_l1_002", Control: "SI.L1-3.14.2", Name: "[CMMC L1] Malicious Code Protection", Frameworks: map[string]string{"CMMC": "SI.L1-3.14.2", "NIST 800-171": "3.14.2"}},
+	{ID: "config.enabled", Control: "CC7.1", Name: "AWS Config Recording", Severity: "HIGH", Frameworks: GetFrameworkMappings("CONFIG_ENABLED")},
+	{ID: "config.recording", Control: "[CIS-3.5]", Name: "AWS Config Recording Status", Severity: "HIGH", Frameworks: GetFrameworkMappings("CONFIG_ENABLED")},
+	{ID: "dynamodb.auto_scaling", Control: "CIS-14.3", Name: "DynamoDB Auto Scaling Enabled", Deep: true, Frameworks: GetFrameworkMappings("DYNAMODB_AUTOSCALING")},
+	{ID: "dynamodb.encryption_at_rest", Control: "CIS-14.2", Name: "DynamoDB Encryption at Rest", Severity: "CRITICAL", Deep: true, Frameworks: GetFrameworkMappings("DYNAMODB_ENCRYPTION")},
+	{ID: "dynamodb.point_in_time_recovery", Control: "CIS-14.1", Name: "DynamoDB Point-in-Time Recovery", Severity: "HIGH", Deep: true, Frameworks: GetFrameworkMappings("DYNAMODB_PITR")},
+	{ID: "ec2.default_security_group", Control: "[CIS-5.4]", Name: "Default Security Group", Severity: "MEDIUM", Deep: true, Frameworks: GetFrameworkMappings("DEFAULT_VPC")},
+	{ID: "ec2.ebs_public_snapshots", Control: "[CIS-2.2.2]", Name: "EBS Public Snapshots", Severity: "CRITICAL", Deep: true, Frameworks: GetFrameworkMappings("EBS_PUBLIC_SNAPSHOTS")},
+	{ID: "ec2.imdsv2", Control: "[CIS-5.6]", Name: "EC2 IMDSv2", Severity: "MEDIUM", Frameworks: GetFrameworkMappings("IMDS_V2")},
+	{ID: "ec2.instance_iam_roles", Control: "[CIS-1.18]", Name: "EC2 Instance IAM Roles", Severity: "MEDIUM", Frameworks: map[string]string{"CIS-AWS": "1.18", "SOC2": "CC6.1"}},
+	{ID: "ec2.old_amis", Control: "CC7.2", Name: "AMI Age and Patching", Severity: "MEDIUM", Frameworks: GetFrameworkMappings("OLD_AMIS")},
+	{ID: "ec2.open_security_groups", Control: "CC6.1", Name: "Open Security Groups", Severity: "HIGH", Frameworks: GetFrameworkMappings("OPEN_SECURITY_GROUPS")},
+	{ID: "ec2.public_instances", Control: "CC6.1", Name: "Public EC2 Instances", Severity: "MEDIUM", Frameworks: GetFrameworkMappings("PUBLIC_INSTANCES")},
+	{ID: "ec2.security_group_rdp", Control: "[CIS-5.3]", Name: "RDP Access from Internet", Severity: "CRITICAL", Frameworks: GetFrameworkMappings("SECURITY_GROUP_UNRESTRICTED")},
+	{ID: "ec2.security_group_ssh", Control: "[CIS-5.2]", Name: "SSH Access from Internet", Severity: "CRITICAL", Frameworks: GetFrameworkMappings("SECURITY_GROUP_UNRESTRICTED")},
+	{ID: "ec2.unencrypted_volumes", Control: "CC6.3", Name: "EBS Volume Encryption", Severity: "HIGH", Frameworks: GetFrameworkMappings("EBS_ENCRYPTION")},
+	{ID: "ecr.encryption_at_rest", Control: "CIS-13.3", Name: "ECR Encryption at Rest", Frameworks: GetFrameworkMappings("ECR_ENCRYPTION")},
+	{ID: "ecr.image_scanning", Control: "CIS-13.1", Name: "ECR Image Scanning Enabled", Severity: "HIGH", Frameworks: GetFrameworkMappings("ECR_IMAGE_SCANNING")},
+	{ID: "ecr.immutable_tags", Control: "CIS-13.2", Name: "ECR Immutable Tags", Severity: "MEDIUM", Frameworks: GetFrameworkMappings("ECR_IMMUTABLE_TAGS")},
+	{ID: "ecs.container_insights", Control: "[CIS-7.3]", Name: "ECS Container Insights", Severity: "MEDIUM", Frameworks: map[string]string{"CIS-AWS": "7.3", "SOC2": "CC7.2"}},
+	{ID: "ecs.secrets_management", Control: "[CIS-7.2]", Name: "ECS Secrets Management", Severity: "CRITICAL", Deep: true, Frameworks: map[string]string{"CIS-AWS": "7.2", "SOC2": "CC6.1", "PCI-DSS": "3.4"}},
+	{ID: "ecs.task_definition_logging", Control: "[CIS-7.1]", Name: "ECS Task Definition Logging", Severity: "HIGH", Deep: true, Frameworks: map[string]string{"CIS-AWS": "7.1", "SOC2": "CC7.2", "PCI-DSS": "10.2"}},
+	{ID: "ecs.task_role_permissions", Control: "[CIS-7.4]", Name: "ECS Task Role Permissions", Severity: "HIGH", Deep: true, Frameworks: map[string]string{"CIS-AWS": "7.4", "SOC2": "CC6.3", "PCI-DSS": "7.1.2"}},
+	{ID: "eks.audit_logging", Control: "[CIS-8.8]", Name: "EKS Audit Logging", Severity: "HIGH", Deep: true, Frameworks: map[string]string{"CIS-AWS": "8.8", "SOC2": "CC7.2", "PCI-DSS": "10.2"}},
+	{ID: "eks.encryption", Control: "[CIS-8.3]", Name: "EKS Cluster Encryption", Severity: "CRITICAL", Deep: true, Frameworks: map[string]string{"CIS-AWS": "8.3", "SOC2": "CC6.7", "PCI-DSS": "3.4"}},
+	{ID: "eks.endpoint_access", Control: "[CIS-8.1]", Name: "EKS Cluster Endpoint Access", Severity: "HIGH", Deep: true, Frameworks: map[string]string{"CIS-AWS": "8.1", "SOC2": "CC6.6", "PCI-DSS": "1.2.1"}},
+	{ID: "eks.logging", Control: "[CIS-8.2]", Name: "EKS Cluster Logging", Severity: "HIGH", Deep: true, Frameworks: map[string]string{"CIS-AWS": "8.2", "SOC2": "CC7.2", "PCI-DSS": "10.2.2"}},
+	{ID: "eks.network_policy", Control: "[CIS-8.4]", Name: "EKS Network Policy", Severity: "MEDIUM", Frameworks: map[string]string{"CIS-AWS": "8.4", "SOC2": "CC6.6"}},
+	{ID: "eks.pod_security_policy", Control: "[CIS-8.5]", Name: "EKS Pod Security Policy", Severity: "HIGH", Frameworks: map[string]string{"CIS-AWS": "8.5", "SOC2": "CC8.1", "PCI-DSS": "2.2"}},
+	{ID: "eks.rbac", Control: "[CIS-8.6]", Name: "EKS RBAC Configuration", Severity: "HIGH", Frameworks: map[string]string{"CIS-AWS": "8.6", "SOC2": "CC6.3", "PCI-DSS": "7.1.2"}},
+	{ID: "eks.secrets_encryption", Control: "[CIS-8.3]", Name: "EKS Cluster Encryption", Severity: "CRITICAL", Frameworks: map[string]string{"CIS-AWS": "8.3", "SOC2": "CC6.7", "PCI-DSS": "3.4"}},
+	{ID: "elasticache.auth_token", Control: "CC6.6", Name: "ElastiCache Redis AUTH Token", Severity: "HIGH", Frameworks: GetFrameworkMappings("ELASTICACHE_AUTH")},
+	{ID: "elasticache.auto_minor_version_upgrade", Control: "CC7.5", Name: "ElastiCache Auto Minor Version Upgrade", Severity: "MEDIUM", Frameworks: GetFrameworkMappings("ELASTICACHE_PATCHING")},
+	{ID: "elasticache.backup_retention", Control: "A1.2", Name: "ElastiCache Backup Retention", Severity: "MEDIUM", Frameworks: GetFrameworkMappings("ELASTICACHE_BACKUP")},
+	{ID: "elasticache.cluster_network_exposure", Control: "CC6.1", Name: "ElastiCache Network Exposure", Severity: "HIGH", Frameworks: GetFrameworkMappings("ELASTICACHE_NETWORK")},
+	{ID: "elasticache.encryption_at_rest", Control: "CC6.3", Name: "ElastiCache Encryption at Rest", Severity: "HIGH", Frameworks: GetFrameworkMappings("ELASTICACHE_ENCRYPTION")},
+	{ID: "elasticache.encryption_in_transit", Control: "CC6.4", Name: "ElastiCache Encryption in Transit", Severity: "HIGH", Frameworks: GetFrameworkMappings("ELASTICACHE_TRANSIT")},
+	{ID: "elasticache.engine_version_supported", Control: "CC7.5", Name: "ElastiCache Engine Version", Severity: "MEDIUM", Frameworks: GetFrameworkMappings("ELASTICACHE_PATCHING")},
+	{ID: "elasticache.redis_open_access", Control: "CC6.6", Name: "ElastiCache Redis Unauthenticated and Unencrypted", Severity: "CRITICAL", Frameworks: GetFrameworkMappings("ELASTICACHE_OPEN_REDIS")},
+	{ID: "elasticache.redis_rbac", Control: "CC6.6", Name: "ElastiCache Redis RBAC", Severity: "MEDIUM", Frameworks: GetFrameworkMappings("ELASTICACHE_RBAC")},
+	{ID: "elasticache.reservation_expiry", Control: "A1.1", Name: "ElastiCache Reserved Node Expiry", Severity: "LOW", Frameworks: GetFrameworkMappings("ELASTICACHE_EXPIRY")},
+	{ID: "elasticache.subnet_group_private", Control: "CC6.1", Name: "ElastiCache Private Subnets", Severity: "HIGH", Deep: true, Frameworks: GetFrameworkMappings("ELASTICACHE_NETWORK")},
+	{ID: "iam.access_key_rotation", Control: "CIS-1.14, CC6.8", Name: "Access Key Rotation", Severity: "CRITICAL", Deep: true, Frameworks: GetFrameworkMappings("ACCESS_KEY_ROTATION")},
+	{ID: "iam.credentials_unused_45_days", Control: "CIS-1.3", Name: "Credentials Unused 45+ Days", Severity: "MEDIUM", Deep: true, Frameworks: GetFrameworkMappings("UNUSED_CREDENTIALS_45")},
+	{ID: "iam.credentials_unused_90_days", Control: "[CIS-1.12]", Name: "Credentials Unused 90 Days", Frameworks: GetFrameworkMappings("IAM_CREDENTIALS_UNUSED_90_DAYS")},
+	{ID: "iam.hardware_mfa_root", Control: "[CIS-1.6]", Name: "Root Hardware MFA", Frameworks: GetFrameworkMappings("IAM_HARDWARE_MFA_ROOT")},
+	{ID: "iam.instance_roles", Control: "[CIS-1.19]", Name: "IAM Instance Roles", Frameworks: GetFrameworkMappings("IAM_INSTANCE_ROLES")},
+	{ID: "iam.one_active_access_key", Control: "[CIS-1.13]", Name: "One Active Access Key Per User", Severity: "MEDIUM", Deep: true, Frameworks: GetFrameworkMappings("IAM_USER_UNUSED")},
+	{ID: "iam.password_expiration", Control: "[CIS-1.20]", Name: "Password Expiration Policy", Severity: "MEDIUM", Frameworks: map[string]string{"CIS-AWS": "1.20", "SOC2": "CC6.1", "PCI-DSS": "8.2.4"}},
+	{ID: "iam.password_policy", Control: "CC6.7", Name: "Password Policy", Severity: "HIGH", Frameworks: GetFrameworkMappings("PASSWORD_POLICY")},
+	{ID: "iam.password_reuse_prevention", Control: "[CIS-1.21]", Name: "Password Reuse Prevention", Severity: "MEDIUM", Frameworks: map[string]string{"CIS-AWS": "1.21", "SOC2": "CC6.1", "PCI-DSS": "8.2.5"}},
+	{ID: "iam.policies_attached", Control: "[CIS-1.15]", Name: "IAM Policies via Groups Only", Severity: "MEDIUM", Deep: true, Frameworks: GetFrameworkMappings("IAM_POLICIES_ATTACHED")},
+	{ID: "iam.policies_attached_to_users", Control: "CIS-1.16", Name: "IAM Policies on Groups/Roles Only", Severity: "MEDIUM", Deep: true, Frameworks: GetFrameworkMappings("IAM_POLICIES_GROUPS_ONLY")},
+	{ID: "iam.policies_on_groups_only", Control: "[CIS-1.22]", Name: "IAM Policies Attached to Groups Only", Severity: "HIGH", Deep: true, Frameworks: map[string]string{"CIS-AWS": "1.22", "SOC2": "CC6.3"}},
+	{ID: "iam.roles_separation", Control: "[CIS-1.18]", Name: "IAM Master and Manager Roles", Severity: "MEDIUM", Frameworks: map[string]string{"CIS-AWS": "1.18", "SOC2": "CC6.3"}},
+	{ID: "iam.root_access_keys", Control: "CIS-1.11", Name: "Root Account Access Keys", Severity: "CRITICAL", Frameworks: GetFrameworkMappings("ROOT_ACCESS_KEYS")},
+	{ID: "iam.root_mfa", Control: "CC6.6", Name: "Root Account MFA", Severity: "HIGH", Frameworks: GetFrameworkMappings("ROOT_MFA")},
+	{ID: "iam.support_role", Control: "[CIS-1.17]", Name: "IAM Support Role", Severity: "MEDIUM", Deep: true, Frameworks: GetFrameworkMappings("IAM_SUPPORT_ROLE")},
+	{ID: "iam.unused_credentials", Control: "CC6.7", Name: "Unused Credentials", Severity: "HIGH", Frameworks: GetFrameworkMappings("UNUSED_CREDENTIALS")},
+	{ID: "iam.users_mfa", Control: "[CIS-1.10]", Name: "MFA for IAM Users", Severity: "HIGH", Deep: true, Frameworks: GetFrameworkMappings("IAM_USER_MFA")},
+	{ID: "iam_advanced.excessive_permissions", Control: "CC6.5", Name: "Excessive Admin Users", Severity: "HIGH", Deep: true},
+	{ID: "iam_advanced.inactive_users", Control: "CC6.4", Name: "Zombie IAM Users", Severity: "HIGH"},
+	{ID: "iam_advanced.root_account_usage", Control: "CC6.6", Name: "Root Account Usage", Severity: "HIGH"},
+	{ID: "iam_advanced.service_account_mfa", Control: "CC6.5", Name: "Service Account Security", Severity: "MEDIUM", Deep: true},
+	{ID: "iam_extended.permission_boundaries", Control: "CIS-17.2", Name: "IAM Permission Boundaries Configured", Severity: "MEDIUM", Frameworks: GetFrameworkMappings("IAM_PERMISSION_BOUNDARIES")},
+	{ID: "iam_extended.service_linked_roles", Control: "CIS-17.1", Name: "IAM Service-Linked Roles Configured", Frameworks: GetFrameworkMappings("IAM_SERVICE_LINKED_ROLES")},
+	{ID: "lambda.environment_encryption", Control: "[CIS-6.2]", Name: "Lambda Environment Encryption", Severity: "HIGH", Frameworks: map[string]string{"CIS-AWS": "6.2", "SOC2": "CC6.7", "PCI-DSS": "3.4"}},
+	{ID: "lambda.execution_role", Control: "[CIS-6.3]", Name: "Lambda Execution Role Permissions", Severity: "HIGH", Frameworks: map[string]string{"CIS-AWS": "6.3", "SOC2": "CC6.3", "PCI-DSS": "7.1.2"}},
+	{ID: "lambda.in_vpc", Control: "[CIS-6.1]", Name: "Lambda Functions in VPC", Severity: "MEDIUM", Frameworks: map[string]string{"CIS-AWS": "6.1", "SOC2": "CC6.6"}},
+	{ID: "lambda.public_access", Control: "[CIS-6.4]", Name: "Lambda Functions Not Public", Severity: "CRITICAL", Deep: true, Frameworks: map[string]string{"CIS-AWS": "6.4", "SOC2": "CC6.1", "PCI-DSS": "1.2.1"}},
+	{ID: "lambda.tracing", Control: "[CIS-6.5]", Name: "Lambda X-Ray Tracing Enabled", Severity: "LOW", Frameworks: map[string]string{"CIS-AWS": "6.5", "SOC2": "CC7.2"}},
+	{ID: "messaging.access_policies", Control: "CIS-10.15", Name: "Messaging Access Policies", Severity: "CRITICAL", Frameworks: GetFrameworkMappings("MESSAGING_ACCESS_POLICY")},
+	{ID: "messaging.sns_encryption", Control: "CIS-10.13", Name: "SNS Topic Encryption", Severity: "HIGH", Deep: true, Frameworks: GetFrameworkMappings("SNS_ENCRYPTION")},
+	{ID: "messaging.sqs_encryption", Control: "CIS-10.14", Name: "SQS Queue Encryption", Severity: "HIGH", Deep: true, Frameworks: GetFrameworkMappings("SQS_ENCRYPTION")},
+	{ID: "monitoring.cloudwatch_alarms", Control: "CC7.3", Name: "Security Event Monitoring", Severity: "HIGH"},
+	{ID: "monitoring.security_hub_enabled", Control: "[CIS-4.16]", Name: "AWS Security Hub Enabled", Severity: "MEDIUM", Frameworks: GetFrameworkMappings("SECURITY_HUB")},
+	{ID: "monitoring.sns_topics", Control: "CC7.4", Name: "Alert Notifications", Severity: "HIGH"},
+	{ID: "network_firewall.logging", Control: "[CIS-5.17]", Name: "Network Firewall Logging", Severity: "HIGH", Deep: true, Frameworks: map[string]string{"CIS-AWS": "5.17", "SOC2": "CC7.2", "PCI-DSS": "10.2"}},
+	{ID: "network_firewall.policy_rules", Control: "[CIS-5.16]", Name: "Network Firewall Policy Rules", Severity: "HIGH", Deep: true, Frameworks: map[string]string{"CIS-AWS": "5.16", "SOC2": "CC6.1", "PCI-DSS": "1.2"}},
+	{ID: "network_firewall.subnet_placement", Control: "[CIS-5.15]", Name: "Network Firewall AZ Deployment", Severity: "MEDIUM", Deep: true, Frameworks: map[string]string{"CIS-AWS": "5.15", "SOC2": "CC6.6"}},
+	{ID: "opensearch.access_policy_public", Control: "CC6.1", Name: "OpenSearch Access Policy Not Public", Severity: "CRITICAL", Deep: true, Frameworks: GetFrameworkMappings("OPENSEARCH_POLICY")},
+	{ID: "opensearch.audit_logs", Control: "CC7.1", Name: "OpenSearch Audit Logs", Severity: "HIGH", Deep: true, Frameworks: GetFrameworkMappings("OPENSEARCH_LOGGING")},
+	{ID: "opensearch.automated_snapshots", Control: "A1.2", Name: "OpenSearch Automated Snapshots", Severity: "LOW", Deep: true, Frameworks: GetFrameworkMappings("OPENSEARCH_BACKUP")},
+	{ID: "opensearch.custom_endpoint_certificate", Control: "CC6.4", Name: "OpenSearch Custom Endpoint Certificate", Severity: "HIGH", Deep: true, Frameworks: GetFrameworkMappings("OPENSEARCH_HTTPS")},
+	{ID: "opensearch.encryption_at_rest", Control: "CC6.3", Name: "OpenSearch Encryption at Rest", Severity: "CRITICAL", Deep: true, Frameworks: GetFrameworkMappings("OPENSEARCH_ENCRYPTION")},
+	{ID: "opensearch.engine_version", Control: "CC7.5", Name: "OpenSearch Engine Version", Severity: "MEDIUM", Deep: true, Frameworks: GetFrameworkMappings("OPENSEARCH_PATCHING")},
+	{ID: "opensearch.fine_grained_access_control", Control: "CC6.6", Name: "OpenSearch Fine-Grained Access Control", Severity: "HIGH", Deep: true, Frameworks: GetFrameworkMappings("OPENSEARCH_ACCESS")},
+	{ID: "opensearch.https_required", Control: "CC6.4", Name: "OpenSearch HTTPS Required", Severity: "HIGH", Deep: true, Frameworks: GetFrameworkMappings("OPENSEARCH_HTTPS")},
+	{ID: "opensearch.node_to_node_encryption", Control: "CC6.4", Name: "OpenSearch Node-to-Node Encryption", Severity: "HIGH", Deep: true, Frameworks: GetFrameworkMappings("OPENSEARCH_TRANSIT")},
+	{ID: "opensearch.vpc_deployment", Control: "CC6.1", Name: "OpenSearch VPC Deployment", Severity: "CRITICAL", Deep: true, Frameworks: GetFrameworkMappings("OPENSEARCH_NETWORK")},
+	{ID: "organizations_advanced.multi_account_structure", Control: "CIS-11.2", Name: "Multi-Account Structure", Severity: "MEDIUM", Frameworks: GetFrameworkMappings("ORGANIZATIONS_MULTI_ACCOUNT")},
+	{ID: "organizations_advanced.organization_trail", Control: "CIS-11.3", Name: "Organization-wide CloudTrail", Severity: "CRITICAL", Frameworks: GetFrameworkMappings("ORGANIZATIONS_TRAIL")},
+	{ID: "organizations_advanced.scps_configured", Control: "CIS-11.4", Name: "Service Control Policies Configured", Severity: "HIGH", Frameworks: GetFrameworkMappings("ORGANIZATIONS_SCPS_CONFIGURED")},
+	{ID: "organizations_advanced.scps_enabled", Control: "CIS-11.1", Name: "AWS Organizations SCPs Enabled", Severity: "HIGH", Frameworks: GetFrameworkMappings("ORGANIZATIONS_SCPS_ENABLED")},
+	{ID: "pci_dss.req10_logging", Control: "PCI-10.1", Name: "[PCI-DSS] Audit Trail Implementation", Severity: "CRITICAL", Frameworks: map[string]string{"PCI-DSS": "Req 10.1, 10.2.1"}},
+	{ID: "pci_dss.req11_security_testing", Control: "PCI-11.5.1", Name: "[PCI-DSS] Change Detection Mechanisms", Severity: "HIGH", Frameworks: map[string]string{"PCI-DSS": "Req 11.5.1"}},
+	{ID: "pci_dss.req12_security_policy", Control: "PCI-12.1", Name: "[PCI-DSS] Security Policy Establishment", Frameworks: map[string]string{"PCI-DSS": "Req 12.1, 12.1.1"}},
+	{ID: "pci_dss.req1_network_segmentation", Control: "PCI-1.2.1", Name: "[PCI-DSS] Network Segmentation for CDE", Severity: "CRITICAL", Frameworks: map[string]string{"PCI-DSS": "Req 1.2.1"}},
+	{ID: "pci_dss.req2_default_passwords", Control: "PCI-2.2.2", Name: "[PCI-DSS] Disable Default Configurations", Severity: "HIGH", Frameworks: map[string]string{"PCI-DSS": "Req 2.2.2"}},
+	{ID: "pci_dss.req3_encryption", Control: "PCI-3.4", Name: "[PCI-DSS] Encryption at Rest (Mandatory)", Severity: "CRITICAL", Frameworks: map[string]string{"PCI-DSS": "Req 3.4, 3.4.1"}},
+	{ID: "pci_dss.req4_encryption_in_transit", Control: "PCI-4.1", Name: "[PCI-DSS] Encryption in Transit", Severity: "CRITICAL", Frameworks: map[string]string{"PCI-DSS": "Req 4.1, 4.1.1"}},
+	{ID: "pci_dss.req5_malware_protection", Control: "PCI-5.1", Name: "[PCI-DSS] Anti-Malware Protection", Frameworks: map[string]string{"PCI-DSS": "Req 5.1, 5.2.1"}},
+	{ID: "pci_dss.req6_secure_systems", Control: "PCI-6.2", Name: "[PCI-DSS] Security Patching", Severity: "HIGH", Frameworks: map[string]string{"PCI-DSS": "Req 6.2"}},
+	{ID: "pci_dss.req7_access_control", Control: "PCI-7.1", Name: "[PCI-DSS] Least Privilege Access", Severity: "HIGH", Frameworks: map[string]string{"PCI-DSS": "Req 7.1, 7.1.2"}},
+	{ID: "pci_dss.req8_authentication", Control: "PCI-8.2.4", Name: "[PCI-DSS] 90-Day Password Rotation", Severity: "CRITICAL", Frameworks: map[string]string{"PCI-DSS": "Req 8.2.4"}},
+	{ID: "pci_dss.req9_physical_access", Control: "PCI-9.1", Name: "[PCI-DSS] Physical Access Controls", Frameworks: map[string]string{"PCI-DSS": "Req 9.1, 9.1.1"}},
+	{ID: "rds.backups", Control: "A1.2", Name: "RDS Backup Retention", Severity: "HIGH", Frameworks: GetFrameworkMappings("RDS_BACKUP")},
+	{ID: "rds.deletion_protection", Control: "[CIS-2.3.5]", Name: "RDS Deletion Protection", Severity: "MEDIUM", Frameworks: GetFrameworkMappings("RDS_DELETION_PROTECTION")},
+	{ID: "rds.encryption", Control: "CC6.3", Name: "RDS Encryption at Rest", Severity: "CRITICAL", Frameworks: GetFrameworkMappings("RDS_ENCRYPTION")},
+	{ID: "rds.minor_version_upgrade", Control: "[CIS-2.3.2]", Name: "RDS Automatic Minor Version Upgrade", Severity: "MEDIUM", Frameworks: GetFrameworkMappings("RDS_MINOR_UPGRADE")},
+	{ID: "rds.multi_az", Control: "[CIS-2.3.4]", Name: "RDS Multi-AZ Deployment", Severity: "MEDIUM", Frameworks: GetFrameworkMappings("RDS_MULTI_AZ")},
+	{ID: "rds.public_access", Control: "CC6.1", Name: "RDS Public Access", Severity: "CRITICAL", Frameworks: GetFrameworkMappings("RDS_PUBLIC_ACCESS")},
+	{ID: "redshift.associated_iam_roles", Control: "CC6.3", Name: "Redshift IAM Role Scope", Severity: "HIGH", Frameworks: GetFrameworkMappings("REDSHIFT_IAM")},
+	{ID: "redshift.cluster_backup_retention", Control: "A1.2", Name: "Redshift Backup Retention", Severity: "MEDIUM", Frameworks: GetFrameworkMappings("REDSHIFT_BACKUP")},
+	{ID: "redshift.cluster_encryption", Control: "CC6.3", Name: "Redshift Cluster Encryption", Severity: "CRITICAL", Frameworks: GetFrameworkMappings("REDSHIFT_ENCRYPTION")},
+	{ID: "redshift.cluster_enhanced_vpc_routing", Control: "CC6.1", Name: "Redshift Enhanced VPC Routing", Severity: "MEDIUM", Frameworks: GetFrameworkMappings("REDSHIFT_NETWORK")},
+	{ID: "redshift.cluster_logging", Control: "CC7.1", Name: "Redshift Audit Logging", Severity: "HIGH", Deep: true, Frameworks: GetFrameworkMappings("REDSHIFT_LOGGING")},
+	{ID: "redshift.cluster_public_access", Control: "CC6.1", Name: "Redshift Public Access", Severity: "CRITICAL", Frameworks: GetFrameworkMappings("REDSHIFT_NETWORK")},
+	{ID: "redshift.cluster_ssl", Control: "CC6.4", Name: "Redshift SSL Required", Severity: "HIGH", Deep: true, Frameworks: GetFrameworkMappings("REDSHIFT_SSL")},
+	{ID: "redshift.cluster_version_upgrade", Control: "CC7.5", Name: "Redshift Auto Version Upgrade", Severity: "MEDIUM", Frameworks: GetFrameworkMappings("REDSHIFT_PATCHING")},
+	{ID: "redshift.cross_region_snapshot_copy", Control: "A1.2", Name: "Redshift Cross-Region Snapshot Copy", Severity: "LOW", Frameworks: GetFrameworkMappings("REDSHIFT_BACKUP")},
+	{ID: "redshift.custom_parameter_group", Control: "CC7.1", Name: "Redshift Custom Parameter Group", Severity: "LOW", Frameworks: GetFrameworkMappings("REDSHIFT_CONFIGURATION")},
+	{ID: "redshift.default_master_username", Control: "CC6.6", Name: "Redshift Default Master Username", Severity: "MEDIUM", Frameworks: GetFrameworkMappings("REDSHIFT_ACCESS")},
+	{ID: "redshift.enhanced_vpc_routing_private_subnets", Control: "CC6.1", Name: "Redshift Enhanced VPC Routing Private Subnets", Severity: "MEDIUM", Deep: true, Frameworks: GetFrameworkMappings("REDSHIFT_NETWORK")},
+	{ID: "redshift.expiring_resources", Control: "A1.1", Name: "Redshift Expiring Reservations and Snapshots", Severity: "LOW", Frameworks: GetFrameworkMappings("REDSHIFT_EXPIRY")},
+	{ID: "redshift.logging_destination_secure", Control: "CC7.1", Name: "Redshift Audit Log Destination", Severity: "HIGH", Deep: true, Frameworks: GetFrameworkMappings("REDSHIFT_LOGGING")},
+	{ID: "redshift.maintenance_window", Control: "A1.1", Name: "Redshift Maintenance Window", Severity: "LOW", Frameworks: GetFrameworkMappings("REDSHIFT_MAINTENANCE")},
+	{ID: "redshift_serverless.namespace_encryption", Control: "CC6.3", Name: "Redshift Serverless Namespace Encryption", Severity: "MEDIUM", Frameworks: GetFrameworkMappings("REDSHIFT_ENCRYPTION")},
+	{ID: "redshift_serverless.workgroup_enhanced_vpc_routing", Control: "CC6.1", Name: "Redshift Serverless Enhanced VPC Routing", Severity: "MEDIUM", Frameworks: GetFrameworkMappings("REDSHIFT_NETWORK")},
+	{ID: "redshift_serverless.workgroup_public_access", Control: "CC6.1", Name: "Redshift Serverless Public Access", Severity: "CRITICAL", Frameworks: GetFrameworkMappings("REDSHIFT_NETWORK")},
+	{ID: "route53.dnssec", Control: "CIS-5.19", Name: "Route53 DNSSEC Enabled", Severity: "MEDIUM", Deep: true, Frameworks: GetFrameworkMappings("ROUTE53_DNSSEC")},
+	{ID: "s3.account_public_access_block", Control: "[CIS-2.1.7]", Name: "S3 Account Public Access Block", Severity: "CRITICAL", Frameworks: map[string]string{"CIS-AWS": "2.1.7", "SOC2": "CC6.1", "PCI-DSS": "1.2.1"}},
+	{ID: "s3.encryption", Control: "CC6.3", Name: "S3 Encryption at Rest", Severity: "HIGH", Deep: true, Frameworks: GetFrameworkMappings("S3_ENCRYPTION")},
+	{ID: "s3.lifecycle_policy", Control: "INFO", Name: "S3 Lifecycle Policies", Frameworks: GetFrameworkMappings("S3_LIFECYCLE")},
+	{ID: "s3.logging", Control: "CC7.1", Name: "S3 Access Logging", Severity: "HIGH", Deep: true, Frameworks: GetFrameworkMappings("S3_LOGGING")},
+	{ID: "s3.mfa_delete", Control: "[CIS-2.1.2]", Name: "S3 MFA Delete", Severity: "MEDIUM", Deep: true, Frameworks: GetFrameworkMappings("S3_MFA_DELETE")},
+	{ID: "s3.object_lock", Control: "[CIS-2.1.6]", Name: "S3 Object Lock", Frameworks: GetFrameworkMappings("S3_OBJECT_LOCK")},
+	{ID: "s3.public_access", Control: "CC6.2", Name: "S3 Public Access Block", Severity: "HIGH", Deep: true, Frameworks: GetFrameworkMappings("S3_PUBLIC_ACCESS")},
+	{ID: "s3.server_access_logging", Control: "[CIS-2.1.4]", Name: "S3 Server Access Logging", Severity: "MEDIUM", Deep: true, Frameworks: GetFrameworkMappings("S3_LOGGING")},
+	{ID: "s3.versioning", Control: "A1.2", Name: "S3 Versioning for Backup", Severity: "MEDIUM", Deep: true, Frameworks: GetFrameworkMappings("S3_VERSIONING")},
+	{ID: "sagemaker.endpoint_data_capture", Control: "CC7.2", Name: "SageMaker Endpoint Data Capture", Severity: "LOW", Deep: true, Frameworks: GetFrameworkMappings("SAGEMAKER_MONITORING")},
+	{ID: "sagemaker.endpoint_encryption", Control: "CC6.3", Name: "SageMaker Endpoint Encryption", Severity: "HIGH", Deep: true, Frameworks: GetFrameworkMappings("SAGEMAKER_ENCRYPTION")},
+	{ID: "sagemaker.endpoint_instance_count", Control: "A1.2", Name: "SageMaker Endpoint Instance Count", Severity: "LOW", Deep: true, Frameworks: GetFrameworkMappings("SAGEMAKER_AVAILABILITY")},
+	{ID: "sagemaker.model_network_isolation", Control: "CC6.1", Name: "SageMaker Model Network Isolation", Severity: "LOW", Deep: true, Frameworks: GetFrameworkMappings("SAGEMAKER_NETWORK")},
+	{ID: "sagemaker.notebook_direct_internet", Control: "CC6.1", Name: "SageMaker Direct Internet Access", Severity: "MEDIUM", Deep: true, Frameworks: GetFrameworkMappings("SAGEMAKER_NETWORK")},
+	{ID: "sagemaker.notebook_encryption", Control: "CC6.3", Name: "SageMaker Notebook Encryption", Severity: "HIGH", Deep: true, Frameworks: GetFrameworkMappings("SAGEMAKER_ENCRYPTION")},
+	{ID: "sagemaker.notebook_role_privilege", Control: "CC6.6", Name: "SageMaker Notebook Role Privilege", Severity: "HIGH", Deep: true, Frameworks: GetFrameworkMappings("SAGEMAKER_ACCESS")},
+	{ID: "sagemaker.notebook_root_access", Control: "CC6.6", Name: "SageMaker Root Access", Severity: "MEDIUM", Deep: true, Frameworks: GetFrameworkMappings("SAGEMAKER_ACCESS")},
+	{ID: "sagemaker.training_job_encryption", Control: "CC6.3", Name: "SageMaker Training Job Encryption", Severity: "MEDIUM", Deep: true, Frameworks: GetFrameworkMappings("SAGEMAKER_ENCRYPTION")},
+	{ID: "secrets_manager.secret_encryption", Control: "CIS-12.2", Name: "Secrets Manager KMS Encryption", Frameworks: GetFrameworkMappings("SECRETS_ENCRYPTION")},
+	{ID: "secrets_manager.secret_rotation", Control: "CIS-12.1", Name: "Secrets Manager Rotation Enabled", Severity: "HIGH", Frameworks: GetFrameworkMappings("SECRETS_ROTATION")},
+	{ID: "secrets_manager.unused_secrets", Control: "CIS-12.3", Name: "Unused Secrets Removed", Severity: "MEDIUM", Frameworks: GetFrameworkMappings("SECRETS_UNUSED")},
+	{ID: "security_services.guardduty_enabled", Control: "[CIS-9.1]", Name: "GuardDuty Enabled", Severity: "CRITICAL", Frameworks: map[string]string{"CIS-AWS": "9.1", "SOC2": "CC7.2"}},
+	{ID: "security_services.inspector_enabled", Control: "[CIS-9.4]", Name: "Inspector Enabled", Severity: "HIGH", Frameworks: map[string]string{"CIS-AWS": "9.4", "SOC2": "CC8.1", "PCI-DSS": "6.2, 11.2.2"}},
+	{ID: "security_services.macie_enabled", Control: "[CIS-9.2]", Name: "Macie Enabled", Severity: "HIGH", Frameworks: map[string]string{"CIS-AWS": "9.2", "SOC2": "CC6.7", "PCI-DSS": "3.4"}},
+	{ID: "security_services.security_hub_enabled", Control: "[CIS-9.3]", Name: "Security Hub Enabled", Severity: "CRITICAL", Frameworks: map[string]string{"CIS-AWS": "9.3", "SOC2": "CC7.1, CC7.2", "PCI-DSS": "10.6, 11.4"}},
+	{ID: "soc2.cc1_1_integrity_and_ethics", Control: "CC1.1", Name: "Organizational Governance Structure", Severity: "HIGH", Frameworks: map[string]string{"SOC2": "CC1.1"}},
+	{ID: "soc2.cc1_2_board_oversight", Control: "CC1.2", Name: "Role-Based Access Segregation", Severity: "MEDIUM", Frameworks: map[string]string{"SOC2": "CC1.2"}},
+	{ID: "soc2.cc1_3_organizational_structure", Control: "CC1.3", Name: "Configuration Management Structure", Frameworks: map[string]string{"SOC2": "CC1.3"}},
+	{ID: "soc2.cc1_4_competence", Control: "CC1.4", Name: "Security Competence - MFA Adoption", Severity: "HIGH", Frameworks: map[string]string{"SOC2": "CC1.4"}},
+	{ID: "soc2.cc1_5_accountability", Control: "CC1.5", Name: "Accountability Controls", Frameworks: map[string]string{"SOC2": "CC1.5"}},
+	{ID: "soc2.cc2_1_information_generation", Control: "CC2.1", Name: "Configuration Information Management", Severity: "MEDIUM", Frameworks: map[string]string{"SOC2": "CC2.1"}},
+	{ID: "soc2.cc2_2_internal_communication", Control: "CC2.2", Name: "Internal Alert Communication", Severity: "HIGH", Frameworks: map[string]string{"SOC2": "CC2.2"}},
+	{ID: "soc2.cc2_3_external_communication", Control: "CC2.3", Name: "External Party Integration", Frameworks: map[string]string{"SOC2": "CC2.3"}},
+	{ID: "soc2.cc3_1_objectives", Control: "CC3.1", Name: "Security Objectives Management", Severity: "HIGH", Frameworks: map[string]string{"SOC2": "CC3.1"}},
+	{ID: "soc2.cc3_2_risk_identification", Control: "CC3.2", Name: "Threat Detection and Risk Identification", Severity: "CRITICAL", Frameworks: map[string]string{"SOC2": "CC3.2"}},
+	{ID: "soc2.cc3_3_fraud_risk", Control: "CC3.3", Name: "Fraud Risk Detection", Severity: "HIGH", Frameworks: map[string]string{"SOC2": "CC3.3"}},
+	{ID: "soc2.cc3_4_change_risk", Control: "CC3.4", Name: "Change Risk Assessment", Frameworks: map[string]string{"SOC2": "CC3.4"}},
+	{ID: "soc2.cc4_1_evaluations", Control: "CC4.1", Name: "Continuous Configuration Monitoring", Severity: "HIGH", Frameworks: map[string]string{"SOC2": "CC4.1"}},
+	{ID: "soc2.cc4_2_deficiencies", Control: "CC4.2", Name: "Compliance Deficiency Detection", Severity: "MEDIUM", Frameworks: map[string]string{"SOC2": "CC4.2"}},
+	{ID: "soc2.cc5_1_control_selection", Control: "CC5.1", Name: "Backup and Recovery Controls", Severity: "HIGH", Frameworks: map[string]string{"SOC2": "CC5.1"}},
+	{ID: "soc2.cc5_2_technology_controls", Control: "CC5.2", Name: "Encryption Key Management", Severity: "HIGH", Frameworks: map[string]string{"SOC2": "CC5.2"}},
+	{ID: "soc2.cc5_3_deployment", Control: "CC5.3", Name: "Control Deployment Through Policies", Frameworks: map[string]string{"SOC2": "CC5.3"}},
+	{ID: "soc2.cc6_1_access_controls", Control: "CC6.1", Name: "Network Access Controls - Admin Ports", Severity: "CRITICAL", Frameworks: map[string]string{"SOC2": "CC6.1"}},
+	{ID: "soc2.cc6_2_credential_issuance", Control: "CC6.2", Name: "Service Account Management", Severity: "MEDIUM", Frameworks: map[string]string{"SOC2": "CC6.2"}},
+	{ID: "soc2.cc6_3_access_points", Control: "CC6.3", Name: "Secure Access Points", Severity: "MEDIUM", Frameworks: map[string]string{"SOC2": "CC6.3"}},
+	{ID: "soc2.cc6_4_asset_access", Control: "CC6.4", Name: "Asset Access Restrictions", Severity: "HIGH", Frameworks: map[string]string{"SOC2": "CC6.4"}},
+	{ID: "soc2.cc6_5_access_removal", Control: "CC6.5", Name: "Access Removal - Inactive Users", Severity: "MEDIUM", Frameworks: map[string]string{"SOC2": "CC6.5"}},
+	{ID: "soc2.cc6_6_unauthorized_prevention", Control: "CC6.6", Name: "Unauthorized Access Prevention", Severity: "HIGH", Frameworks: map[string]string{"SOC2": "CC6.6"}},
+	{ID: "soc2.cc6_7_authentication", Control: "CC6.7", Name: "Authentication Requirements", Severity: "HIGH", Frameworks: map[string]string{"SOC2": "CC6.7"}},
+	{ID: "soc2.cc6_8_modification_prevention", Control: "CC6.8", Name: "Data Modification Prevention", Severity: "MEDIUM", Frameworks: map[string]string{"SOC2": "CC6.8"}},
+	{ID: "soc2.cc7_1_monitoring", Control: "CC7.1", Name: "System Monitoring", Severity: "CRITICAL", Frameworks: map[string]string{"SOC2": "CC7.1"}},
+	{ID: "soc2.cc7_2_anomaly_detection", Control: "CC7.2", Name: "Anomaly Detection", Frameworks: map[string]string{"SOC2": "CC7.2"}},
+	{ID: "soc2.cc7_3_security_events", Control: "CC7.3", Name: "Security Event Management - Patching", Severity: "MEDIUM", Frameworks: map[string]string{"SOC2": "CC7.3"}},
+	{ID: "soc2.cc7_4_incident_response", Control: "CC7.4", Name: "Incident Response Planning", Frameworks: map[string]string{"SOC2": "CC7.4"}},
+	{ID: "soc2.cc8_1_change_management", Control: "CC8.1", Name: "Change Management - Function Versioning", Severity: "MEDIUM", Frameworks: map[string]string{"SOC2": "CC8.1"}},
+	{ID: "soc2.cc9_1_vendor_risk", Control: "CC9.1", Name: "Data Protection - Database Encryption", Severity: "HIGH", Frameworks: map[string]string{"SOC2": "CC9.1"}},
+	{ID: "soc2.cc9_2_vendor_management", Control: "CC9.2", Name: "Vendor Data Management - S3 Encryption", Severity: "HIGH", Frameworks: map[string]string{"SOC2": "CC9.2"}},
+	{ID: "ssm.parameter_encryption", Control: "CIS-10.1", Name: "SSM Parameter Store Encryption", Severity: "HIGH", Frameworks: GetFrameworkMappings("SSM_PARAMETER_ENCRYPTION")},
+	{ID: "ssm.patch_compliance", Control: "CIS-10.3", Name: "SSM Patch Compliance", Severity: "HIGH", Frameworks: GetFrameworkMappings("SSM_PATCH_COMPLIANCE")},
+	{ID: "ssm.session_manager_logging", Control: "CIS-10.2", Name: "SSM Session Manager Logging", Severity: "HIGH", Frameworks: GetFrameworkMappings("SSM_SESSION_LOGGING")},
+	{ID: "systems.auto_scaling", Control: "A1.1", Name: "High Availability", Severity: "MEDIUM"},
+	{ID: "systems.patch_compliance", Control: "A1.1", Name: "Patch Management", Severity: "HIGH"},
+	{ID: "vpc.admin_port_security", Control: "[CIS-5.13]", Name: "Security Groups Restrict Admin Ports", Severity: "CRITICAL", Frameworks: map[string]string{"CIS-AWS": "5.13", "PCI-DSS": "1.2.1", "SOC2": "CC6.6"}},
+	{ID: "vpc.default_vpc", Control: "[CIS-5.1]", Name: "Default VPC in Use", Severity: "MEDIUM", Deep: true, Frameworks: GetFrameworkMappings("DEFAULT_VPC")},
+	{ID: "vpc.ec2_subnet_placement", Control: "[CIS-5.14]", Name: "EC2 Instances in Custom VPC", Severity: "MEDIUM", Frameworks: map[string]string{"CIS-AWS": "5.14"}},
+	{ID: "vpc.endpoints", Control: "[CIS-5.7, 5.8]", Name: "VPC Endpoints for AWS Services", Severity: "MEDIUM", Frameworks: map[string]string{"CIS-AWS": "5.7, 5.8"}},
+	{ID: "vpc.flow_logs", Control: "CIS-3.9, CC7.1", Name: "VPC Flow Logs", Severity: "HIGH", Frameworks: GetFrameworkMappings("VPC_FLOW_LOGS")},
+	{ID: "vpc.peering", Control: "[CIS-5.5]", Name: "VPC Peering Routing", Frameworks: GetFrameworkMappings("VPC_PEERING")},
+	{ID: "vpc.unused_security_groups", Control: "[CIS-5.18]", Name: "Unused Security Groups Removed", Severity: "LOW", Frameworks: map[string]string{"CIS-AWS": "5.18"}},
+}
@@ -0,0 +1,147 @@
+package checks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// errCheckDisabled is returned by runCheck for checks turned off in the
+// checks config; Run methods drop it like any other check error
+var errCheckDisabled = errors.New("check disabled by configuration")
+
+// LoadCheckConfig reads a checks config file and returns the IDs of the
+// checks it disables. Checks not listed stay enabled.
+//
+//	checks:
+//	  redshift.cluster_enhanced_vpc_routing: false
+//	  elasticache.redis_rbac: false
+//
+// Unknown check IDs are an error so a typo cannot silently leave a check on.
+func LoadCheckConfig(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checks config file: %w", err)
+	}
+
+	var file struct {
+		Checks map[string]bool `yaml:"checks"`
+	}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse checks config YAML: %w", err)
+	}
+
+	known := map[string]bool{}
+	for _, entry := range checkManifest {
+		known[entry.ID] = true
+	}
+
+	disabled := []string{}
+	for id, enabled := range file.Checks {
+		id = strings.ToLower(strings.TrimSpace(id))
+		if !known[id] {
+			return nil, fmt.Errorf("checks config: unknown check %q (see CheckIDs for valid IDs)", id)
+		}
+		if !enabled {
+			disabled = append(disabled, id)
+		}
+	}
+	sort.Strings(disabled)
+	return disabled, nil
+}
+
+// CheckIDs returns the IDs accepted in a checks config file, sorted
+func CheckIDs() []string {
+	ids := make([]string, 0, len(checkManifest))
+	for _, entry := range checkManifest {
+		ids = append(ids, entry.ID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// SetDisabledChecks turns off the given check IDs for subsequent runs without
+// a Scan (see WithScan) and clears the skipped list. Disabled checks are not
+// executed at all, so they make no API calls and produce no result.
+func SetDisabledChecks(ids []string) {
+	defaultScan.setDisabledChecks(ids)
+}
+
+func (s *Scan) setDisabledChecks(ids []string) {
+	s.configMu.Lock()
+	defer s.configMu.Unlock()
+
+	s.disabled = map[string]bool{}
+	for _, id := range ids {
+		s.disabled[id] = true
+	}
+	s.skipped = map[string]bool{}
+}
+
+// SkippedChecks returns the disabled checks skipped by runs without a Scan
+// since the last SetDisabledChecks call, sorted, for listing in reports
+func SkippedChecks() []string {
+	return defaultScan.SkippedChecks()
+}
+
+// SkippedChecks returns the disabled checks the scan skipped, sorted, for
+// listing in reports
+func (s *Scan) SkippedChecks() []string {
+	s.configMu.Lock()
+	defer s.configMu.Unlock()
+
+	skipped := make([]string, 0, len(s.skipped))
+	for id := range s.skipped {
+		skipped = append(skipped, id)
+	}
+	sort.Strings(skipped)
+	return skipped
+}
+
+// runChecks is runCheck for the framework control modules, whose checks
+// report a result per control and turn their own errors into ERROR results.
+// A skipped check reports nothing.
+func runChecks(ctx context.Context, id string, check func(context.Context) []CheckResult) []CheckResult {
+	var results []CheckResult
+	runCheck(ctx, id, func(ctx context.Context) (CheckResult, error) {
+		results = check(ctx)
+		return CheckResult{}, nil
+	})
+	return results
+}
+
+// runCheck runs check unless id is disabled in ctx's Scan, in which case it
+// records the skip and returns errCheckDisabled without calling it, or left
+// out of the scan profile, in which case it returns errCheckNotInProfile. Run
+// time is recorded for CheckTimings, and the check's error is classified with
+// ClassifyError and recorded for CheckErrorCounts.
+func runCheck(ctx context.Context, id string, check func(context.Context) (CheckResult, error)) (CheckResult, error) {
+	scan := scanFrom(ctx)
+	scan.configMu.Lock()
+	disabled := scan.disabled[id]
+	if disabled {
+		scan.skipped[id] = true
+	}
+	outOfProfile := scan.profile == ProfileQuick && deepChecks[id]
+	scan.configMu.Unlock()
+
+	if disabled {
+		return CheckResult{}, errCheckDisabled
+	}
+	if outOfProfile {
+		return CheckResult{}, errCheckNotInProfile
+	}
+
+	start := time.Now()
+	defer func() { scan.recordCheckDuration(id, time.Since(start)) }()
+	result, err := check(ctx)
+	err = ClassifyError(err)
+	scan.recordCheckError(id, err)
+	return result, err
+}
@@ -2,7 +2,6 @@ package checks
 
 import (
 	"context"
-	"time"
 )
 
 // CISManualChecks returns manual guidance for non-automatable CIS controls
@@ -17,6 +16,12 @@ func (c *CISManualChecks) Name() string {
 }
 
 func (c *CISManualChecks) Run(ctx context.Context) ([]CheckResult, error) {
+	return runChecks(ctx, "cis_manual.monitoring_metric_filters", c.CheckMonitoringMetricFilters), nil
+}
+
+// CheckMonitoringMetricFilters returns manual guidance for the CIS section 4
+// metric filters and alarms
+func (c *CISManualChecks) CheckMonitoringMetricFilters(ctx context.Context) []CheckResult {
 	var results []CheckResult
 	
 	// Section 4 - Monitoring (CloudWatch Metric Filters & Alarms)
@@ -35,9 +40,9 @@ func (c *CISManualChecks) Run(ctx context.Context) ([]CheckResult, error) {
 5. Create alarm for this metric
 6. Screenshot showing filter and alarm configured`,
 		Priority: PriorityMedium,
-		Timestamp: time.Now(),
+		Timestamp: nowFunc(),
 		ScreenshotGuide: "CloudWatch → Log groups → CloudTrail logs → Metric filters → Screenshot showing filter for unauthorized API calls",
-		ConsoleURL: "https://console.aws.amazon.com/cloudwatch/home#logsV2:log-groups",
+		ConsoleURL: consoleURL("cloudwatch/home#logsV2:log-groups", consoleRegion(ctx)),
 		Frameworks: GetFrameworkMappings("METRIC_FILTER_UNAUTHORIZED_API"),
 	})
 	
@@ -52,9 +57,9 @@ func (c *CISManualChecks) Run(ctx context.Context) ([]CheckResult, error) {
 3. Create alarm for this metric
 4. Screenshot showing filter and alarm configured`,
 		Priority: PriorityHigh,
-		Timestamp: time.Now(),
+		Timestamp: nowFunc(),
 		ScreenshotGuide: "CloudWatch → Log groups → CloudTrail logs → Metric filters → Screenshot showing filter for console login without MFA",
-		ConsoleURL: "https://console.aws.amazon.com/cloudwatch/home#logsV2:log-groups",
+		ConsoleURL: consoleURL("cloudwatch/home#logsV2:log-groups", consoleRegion(ctx)),
 		Frameworks: GetFrameworkMappings("METRIC_FILTER_CONSOLE_NO_MFA"),
 	})
 	
@@ -69,9 +74,9 @@ func (c *CISManualChecks) Run(ctx context.Context) ([]CheckResult, error) {
 3. Create alarm for this metric
 4. Screenshot showing filter and alarm configured`,
 		Priority: PriorityCritical,
-		Timestamp: time.Now(),
+		Timestamp: nowFunc(),
 		ScreenshotGuide: "CloudWatch → Log groups → CloudTrail logs → Metric filters → Screenshot showing filter for root account usage",
-		ConsoleURL: "https://console.aws.amazon.com/cloudwatch/home#logsV2:log-groups",
+		ConsoleURL: consoleURL("cloudwatch/home#logsV2:log-groups", consoleRegion(ctx)),
 		Frameworks: GetFrameworkMappings("METRIC_FILTER_ROOT_USAGE"),
 	})
 	
@@ -86,9 +91,9 @@ func (c *CISManualChecks) Run(ctx context.Context) ([]CheckResult, error) {
 3. Create alarm for this metric
 4. Screenshot showing filter and alarm configured`,
 		Priority: PriorityHigh,
-		Timestamp: time.Now(),
+		Timestamp: nowFunc(),
 		ScreenshotGuide: "CloudWatch → Log groups → CloudTrail logs → Metric filters → Screenshot showing filter for IAM changes",
-		ConsoleURL: "https://console.aws.amazon.com/cloudwatch/home#logsV2:log-groups",
+		ConsoleURL: consoleURL("cloudwatch/home#logsV2:log-groups", consoleRegion(ctx)),
 		Frameworks: GetFrameworkMappings("METRIC_FILTER_IAM_CHANGES"),
 	})
 	
@@ -103,9 +108,9 @@ func (c *CISManualChecks) Run(ctx context.Context) ([]CheckResult, error) {
 3. Create alarm for this metric
 4. Screenshot showing filter and alarm configured`,
 		Priority: PriorityHigh,
-		Timestamp: time.Now(),
+		Timestamp: nowFunc(),
 		ScreenshotGuide: "CloudWatch → Log groups → CloudTrail logs → Metric filters → Screenshot showing filter for CloudTrail changes",
-		ConsoleURL: "https://console.aws.amazon.com/cloudwatch/home#logsV2:log-groups",
+		ConsoleURL: consoleURL("cloudwatch/home#logsV2:log-groups", consoleRegion(ctx)),
 		Frameworks: GetFrameworkMappings("METRIC_FILTER_CLOUDTRAIL_CHANGES"),
 	})
 	
@@ -120,9 +125,9 @@ func (c *CISManualChecks) Run(ctx context.Context) ([]CheckResult, error) {
 3. Create alarm for this metric
 4. Screenshot showing filter and alarm configured`,
 		Priority: PriorityMedium,
-		Timestamp: time.Now(),
+		Timestamp: nowFunc(),
 		ScreenshotGuide: "CloudWatch → Log groups → CloudTrail logs → Metric filters → Screenshot showing filter for auth failures",
-		ConsoleURL: "https://console.aws.amazon.com/cloudwatch/home#logsV2:log-groups",
+		ConsoleURL: consoleURL("cloudwatch/home#logsV2:log-groups", consoleRegion(ctx)),
 		Frameworks: GetFrameworkMappings("METRIC_FILTER_CONSOLE_AUTH_FAIL"),
 	})
 	
@@ -137,9 +142,9 @@ func (c *CISManualChecks) Run(ctx context.Context) ([]CheckResult, error) {
 3. Create alarm for this metric
 4. Screenshot showing filter and alarm configured`,
 		Priority: PriorityCritical,
-		Timestamp: time.Now(),
+		Timestamp: nowFunc(),
 		ScreenshotGuide: "CloudWatch → Log groups → CloudTrail logs → Metric filters → Screenshot showing filter for KMS changes",
-		ConsoleURL: "https://console.aws.amazon.com/cloudwatch/home#logsV2:log-groups",
+		ConsoleURL: consoleURL("cloudwatch/home#logsV2:log-groups", consoleRegion(ctx)),
 		Frameworks: GetFrameworkMappings("METRIC_FILTER_CMK_DISABLE"),
 	})
 	
@@ -154,9 +159,9 @@ func (c *CISManualChecks) Run(ctx context.Context) ([]CheckResult, error) {
 3. Create alarm for this metric
 4. Screenshot showing filter and alarm configured`,
 		Priority: PriorityHigh,
-		Timestamp: time.Now(),
+		Timestamp: nowFunc(),
 		ScreenshotGuide: "CloudWatch → Log groups → CloudTrail logs → Metric filters → Screenshot showing filter for S3 changes",
-		ConsoleURL: "https://console.aws.amazon.com/cloudwatch/home#logsV2:log-groups",
+		ConsoleURL: consoleURL("cloudwatch/home#logsV2:log-groups", consoleRegion(ctx)),
 		Frameworks: GetFrameworkMappings("METRIC_FILTER_S3_POLICY_CHANGES"),
 	})
 	
@@ -171,9 +176,9 @@ func (c *CISManualChecks) Run(ctx context.Context) ([]CheckResult, error) {
 3. Create alarm for this metric
 4. Screenshot showing filter and alarm configured`,
 		Priority: PriorityMedium,
-		Timestamp: time.Now(),
+		Timestamp: nowFunc(),
 		ScreenshotGuide: "CloudWatch → Log groups → CloudTrail logs → Metric filters → Screenshot showing filter for Config changes",
-		ConsoleURL: "https://console.aws.amazon.com/cloudwatch/home#logsV2:log-groups",
+		ConsoleURL: consoleURL("cloudwatch/home#logsV2:log-groups", consoleRegion(ctx)),
 		Frameworks: GetFrameworkMappings("METRIC_FILTER_CONFIG_CHANGES"),
 	})
 	
@@ -188,9 +193,9 @@ func (c *CISManualChecks) Run(ctx context.Context) ([]CheckResult, error) {
 3. Create alarm for this metric
 4. Screenshot showing filter and alarm configured`,
 		Priority: PriorityHigh,
-		Timestamp: time.Now(),
+		Timestamp: nowFunc(),
 		ScreenshotGuide: "CloudWatch → Log groups → CloudTrail logs → Metric filters → Screenshot showing filter for SG changes",
-		ConsoleURL: "https://console.aws.amazon.com/cloudwatch/home#logsV2:log-groups",
+		ConsoleURL: consoleURL("cloudwatch/home#logsV2:log-groups", consoleRegion(ctx)),
 		Frameworks: GetFrameworkMappings("METRIC_FILTER_SECURITY_GROUP_CHANGES"),
 	})
 	
@@ -205,9 +210,9 @@ func (c *CISManualChecks) Run(ctx context.Context) ([]CheckResult, error) {
 3. Create alarm for this metric
 4. Screenshot showing filter and alarm configured`,
 		Priority: PriorityMedium,
-		Timestamp: time.Now(),
+		Timestamp: nowFunc(),
 		ScreenshotGuide: "CloudWatch → Log groups → CloudTrail logs → Metric filters → Screenshot showing filter for NACL changes",
-		ConsoleURL: "https://console.aws.amazon.com/cloudwatch/home#logsV2:log-groups",
+		ConsoleURL: consoleURL("cloudwatch/home#logsV2:log-groups", consoleRegion(ctx)),
 		Frameworks: GetFrameworkMappings("METRIC_FILTER_NACL_CHANGES"),
 	})
 	
@@ -222,9 +227,9 @@ func (c *CISManualChecks) Run(ctx context.Context) ([]CheckResult, error) {
 3. Create alarm for this metric
 4. Screenshot showing filter and alarm configured`,
 		Priority: PriorityMedium,
-		Timestamp: time.Now(),
+		Timestamp: nowFunc(),
 		ScreenshotGuide: "CloudWatch → Log groups → CloudTrail logs → Metric filters → Screenshot showing filter for gateway changes",
-		ConsoleURL: "https://console.aws.amazon.com/cloudwatch/home#logsV2:log-groups",
+		ConsoleURL: consoleURL("cloudwatch/home#logsV2:log-groups", consoleRegion(ctx)),
 		Frameworks: GetFrameworkMappings("METRIC_FILTER_GATEWAY_CHANGES"),
 	})
 	
@@ -239,9 +244,9 @@ func (c *CISManualChecks) Run(ctx context.Context) ([]CheckResult, error) {
 3. Create alarm for this metric
 4. Screenshot showing filter and alarm configured`,
 		Priority: PriorityMedium,
-		Timestamp: time.Now(),
+		Timestamp: nowFunc(),
 		ScreenshotGuide: "CloudWatch → Log groups → CloudTrail logs → Metric filters → Screenshot showing filter for route changes",
-		ConsoleURL: "https://console.aws.amazon.com/cloudwatch/home#logsV2:log-groups",
+		ConsoleURL: consoleURL("cloudwatch/home#logsV2:log-groups", consoleRegion(ctx)),
 		Frameworks: GetFrameworkMappings("METRIC_FILTER_ROUTE_TABLE_CHANGES"),
 	})
 	
@@ -256,9 +261,9 @@ func (c *CISManualChecks) Run(ctx context.Context) ([]CheckResult, error) {
 3. Create alarm for this metric
 4. Screenshot showing filter and alarm configured`,
 		Priority: PriorityMedium,
-		Timestamp: time.Now(),
+		Timestamp: nowFunc(),
 		ScreenshotGuide: "CloudWatch → Log groups → CloudTrail logs → Metric filters → Screenshot showing filter for VPC changes",
-		ConsoleURL: "https://console.aws.amazon.com/cloudwatch/home#logsV2:log-groups",
+		ConsoleURL: consoleURL("cloudwatch/home#logsV2:log-groups", consoleRegion(ctx)),
 		Frameworks: GetFrameworkMappings("METRIC_FILTER_VPC_CHANGES"),
 	})
 	
@@ -273,11 +278,11 @@ func (c *CISManualChecks) Run(ctx context.Context) ([]CheckResult, error) {
 3. Create alarm for this metric
 4. Screenshot showing filter and alarm configured`,
 		Priority: PriorityLow,
-		Timestamp: time.Now(),
+		Timestamp: nowFunc(),
 		ScreenshotGuide: "CloudWatch → Log groups → CloudTrail logs → Metric filters → Screenshot showing filter for Org changes",
-		ConsoleURL: "https://console.aws.amazon.com/cloudwatch/home#logsV2:log-groups",
+		ConsoleURL: consoleURL("cloudwatch/home#logsV2:log-groups", consoleRegion(ctx)),
 		Frameworks: GetFrameworkMappings("METRIC_FILTER_ORGANIZATIONS_CHANGES"),
 	})
 	
-	return results, nil
+	return results
 }
@@ -0,0 +1,18 @@
+package checks
+
+import "time"
+
+// nowFunc supplies CheckResult timestamps. It defaults to time.Now; tests
+// swap it via SetNowFunc to freeze time for reproducible report fixtures.
+var nowFunc = time.Now
+
+// SetNowFunc replaces the clock used for result timestamps and returns a
+// function that restores the previous one. Passing nil restores time.Now.
+func SetNowFunc(fn func() time.Time) (restore func()) {
+	previous := nowFunc
+	if fn == nil {
+		fn = time.Now
+	}
+	nowFunc = fn
+	return func() { nowFunc = previous }
+}
@@ -3,7 +3,6 @@ package checks
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
 )
@@ -23,11 +22,11 @@ func (c *CloudFormationChecks) Name() string {
 func (c *CloudFormationChecks) Run(ctx context.Context) ([]CheckResult, error) {
 	results := []CheckResult{}
 
-	if result, err := c.CheckStackPolicy(ctx); err == nil {
+	if result, err := runCheck(ctx, "cloudformation.stack_policy", c.CheckStackPolicy); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckDriftDetection(ctx); err == nil {
+	if result, err := runCheck(ctx, "cloudformation.drift_detection", c.CheckDriftDetection); err == nil {
 		results = append(results, result)
 	}
 
@@ -43,7 +42,7 @@ func (c *CloudFormationChecks) CheckStackPolicy(ctx context.Context) (CheckResul
 			Status:     "ERROR",
 			Evidence:   fmt.Sprintf("Failed to list stacks: %v", err),
 			Priority:   PriorityLow,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("CFN_STACK_POLICY"),
 		}, err
 	}
@@ -55,7 +54,7 @@ func (c *CloudFormationChecks) CheckStackPolicy(ctx context.Context) (CheckResul
 			Status:     "INFO",
 			Evidence:   "No CloudFormation stacks found",
 			Priority:   PriorityLow,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("CFN_STACK_POLICY"),
 		}, nil
 	}
@@ -79,12 +78,12 @@ func (c *CloudFormationChecks) CheckStackPolicy(ctx context.Context) (CheckResul
 			Control:     "CIS-15.1",
 			Name:        "CloudFormation Stack Policy Configured",
 			Status:      "FAIL",
-			Evidence:    fmt.Sprintf("%d/%d stacks lack stack policies: %v", len(without), len(stacks.Stacks), without),
+			Evidence:    fmt.Sprintf("%d/%d stacks lack stack policies: %s", len(without), len(stacks.Stacks), TruncateList(without, evidenceListLimit(ctx))),
 			Remediation: "Configure stack policies to protect critical resources",
 			Severity:    "MEDIUM",
 			Priority:    PriorityMedium,
-			Timestamp:   time.Now(),
-			ConsoleURL:  "https://console.aws.amazon.com/cloudformation/home#/stacks",
+			Timestamp:   nowFunc(),
+			ConsoleURL:  consoleURL("cloudformation/home#/stacks", consoleRegion(ctx)),
 			Frameworks:  GetFrameworkMappings("CFN_STACK_POLICY"),
 		}, nil
 	}
@@ -95,8 +94,8 @@ func (c *CloudFormationChecks) CheckStackPolicy(ctx context.Context) (CheckResul
 		Status:     "PASS",
 		Evidence:   fmt.Sprintf("All %d stacks have stack policies configured", with),
 		Priority:   PriorityLow,
-		Timestamp:  time.Now(),
-		ConsoleURL: "https://console.aws.amazon.com/cloudformation/home#/stacks",
+		Timestamp:  nowFunc(),
+		ConsoleURL: consoleURL("cloudformation/home#/stacks", consoleRegion(ctx)),
 		Frameworks: GetFrameworkMappings("CFN_STACK_POLICY"),
 	}, nil
 }
@@ -110,7 +109,7 @@ func (c *CloudFormationChecks) CheckDriftDetection(ctx context.Context) (CheckRe
 			Status:     "ERROR",
 			Evidence:   "Failed to list stacks",
 			Priority:   PriorityLow,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("CFN_DRIFT_DETECTION"),
 		}, err
 	}
@@ -122,7 +121,7 @@ func (c *CloudFormationChecks) CheckDriftDetection(ctx context.Context) (CheckRe
 			Status:     "INFO",
 			Evidence:   "No CloudFormation stacks found",
 			Priority:   PriorityLow,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("CFN_DRIFT_DETECTION"),
 		}, nil
 	}
@@ -134,8 +133,8 @@ func (c *CloudFormationChecks) CheckDriftDetection(ctx context.Context) (CheckRe
 		Evidence:   fmt.Sprintf("MANUAL CHECK: Run drift detection on %d stacks regularly", len(stacks.Stacks)),
 		Remediation: "Run drift detection monthly to detect manual changes",
 		Priority:   PriorityMedium,
-		Timestamp:  time.Now(),
-		ConsoleURL: "https://console.aws.amazon.com/cloudformation/home#/stacks",
+		Timestamp:  nowFunc(),
+		ConsoleURL: consoleURL("cloudformation/home#/stacks", consoleRegion(ctx)),
 		Frameworks: GetFrameworkMappings("CFN_DRIFT_DETECTION"),
 	}, nil
 }
@@ -3,7 +3,6 @@ package checks
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cloudtrail"
@@ -25,49 +24,49 @@ func (c *CloudTrailChecks) Run(ctx context.Context) ([]CheckResult, error) {
 	results := []CheckResult{}
 
 	// Existing checks
-	if result, err := c.CheckTrailEnabled(ctx); err == nil {
+	if result, err := runCheck(ctx, "cloudtrail.trail_enabled", c.CheckTrailEnabled); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckMultiRegion(ctx); err == nil {
+	if result, err := runCheck(ctx, "cloudtrail.multi_region", c.CheckMultiRegion); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckLogFileValidation(ctx); err == nil {
+	if result, err := runCheck(ctx, "cloudtrail.log_file_validation", c.CheckLogFileValidation); err == nil {
 		results = append(results, result)
 	}
 
 	// NEW CIS checks
-	if result, err := c.CheckCloudTrailEncryption(ctx); err == nil {
+	if result, err := runCheck(ctx, "cloudtrail.encryption", c.CheckCloudTrailEncryption); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckCloudTrailLogIntegration(ctx); err == nil {
+	if result, err := runCheck(ctx, "cloudtrail.log_integration", c.CheckCloudTrailLogIntegration); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckS3BucketAccessLogging(ctx); err == nil {
+	if result, err := runCheck(ctx, "cloudtrail.s3_bucket_access_logging", c.CheckS3BucketAccessLogging); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckCloudTrailLogValidation(ctx); err == nil {
+	if result, err := runCheck(ctx, "cloudtrail.log_validation", c.CheckCloudTrailLogValidation); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckCloudTrailS3BucketPolicy(ctx); err == nil {
+	if result, err := runCheck(ctx, "cloudtrail.s3_bucket_policy", c.CheckCloudTrailS3BucketPolicy); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckCloudTrailKMSKey(ctx); err == nil {
+	if result, err := runCheck(ctx, "cloudtrail.kms_key", c.CheckCloudTrailKMSKey); err == nil {
 		results = append(results, result)
 	}
 
 	// Additional CIS AWS controls
-	if result, err := c.CheckS3ObjectLevelLoggingWrite(ctx); err == nil {
+	if result, err := runCheck(ctx, "cloudtrail.s3_object_level_logging_write", c.CheckS3ObjectLevelLoggingWrite); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckS3ObjectLevelLoggingRead(ctx); err == nil {
+	if result, err := runCheck(ctx, "cloudtrail.s3_object_level_logging_read", c.CheckS3ObjectLevelLoggingRead); err == nil {
 		results = append(results, result)
 	}
 
@@ -84,7 +83,7 @@ func (c *CloudTrailChecks) CheckTrailEnabled(ctx context.Context) (CheckResult,
 			Evidence:   "Unable to check CloudTrail status",
 			Severity:   "CRITICAL",
 			Priority:   PriorityCritical,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("CLOUDTRAIL_ENABLED"),
 		}, err
 	}
@@ -98,9 +97,9 @@ func (c *CloudTrailChecks) CheckTrailEnabled(ctx context.Context) (CheckResult,
 			Evidence:        "CRITICAL: NO CloudTrail configured! Zero audit logging | Violates PCI DSS 10.1 (implement audit trails) & HIPAA 164.312(b)",
 			Remediation:     "aws cloudtrail create-trail --name audit-trail --s3-bucket-name YOUR_BUCKET && aws cloudtrail start-logging --name audit-trail",
 			ScreenshotGuide: "1. Go to CloudTrail Console\n2. Click 'Create trail'\n3. Enable for all regions\n4. Screenshot showing trail is 'Logging' status\n5. This is MANDATORY for SOC2, PCI, and HIPAA!",
-			ConsoleURL:      "https://console.aws.amazon.com/cloudtrail/home",
+			ConsoleURL:      consoleURL("cloudtrail/home", consoleRegion(ctx)),
 			Priority:        PriorityCritical,
-			Timestamp:       time.Now(),
+			Timestamp:       nowFunc(),
 			Frameworks:      GetFrameworkMappings("CLOUDTRAIL_ENABLED"),
 		}, nil
 	}
@@ -125,9 +124,9 @@ func (c *CloudTrailChecks) CheckTrailEnabled(ctx context.Context) (CheckResult,
 			Evidence:        fmt.Sprintf("CloudTrail exists but is NOT logging! (%d trails configured, 0 active) | Fails PCI DSS 10.2.1", len(trails.Trails)),
 			Remediation:     "aws cloudtrail start-logging --name YOUR_TRAIL_NAME",
 			ScreenshotGuide: "1. Go to CloudTrail → Trails\n2. Click on your trail\n3. Click 'Start logging'\n4. Screenshot showing 'Logging: ON'\n5. For PCI: Document log retention period (90+ days required)",
-			ConsoleURL:      "https://console.aws.amazon.com/cloudtrail/home#/trails",
+			ConsoleURL:      consoleURL("cloudtrail/home#/trails", consoleRegion(ctx)),
 			Priority:        PriorityCritical,
-			Timestamp:       time.Now(),
+			Timestamp:       nowFunc(),
 			Frameworks:      GetFrameworkMappings("CLOUDTRAIL_ENABLED"),
 		}, nil
 	}
@@ -139,9 +138,9 @@ func (c *CloudTrailChecks) CheckTrailEnabled(ctx context.Context) (CheckResult,
 		Evidence:        fmt.Sprintf("%d CloudTrail(s) actively logging API calls | Meets SOC2 CC7.1, PCI DSS 10.1, HIPAA 164.312(b)", activeTrails),
 		Severity:        "INFO",
 		ScreenshotGuide: "1. Go to CloudTrail → Trails\n2. Screenshot showing your trail(s) with 'Logging: ON'\n3. Click into trail and screenshot configuration\n4. For PCI: Show retention settings",
-		ConsoleURL:      "https://console.aws.amazon.com/cloudtrail/home#/trails",
+		ConsoleURL:      consoleURL("cloudtrail/home#/trails", consoleRegion(ctx)),
 		Priority:        PriorityInfo,
-		Timestamp:       time.Now(),
+		Timestamp:       nowFunc(),
 		Frameworks:      GetFrameworkMappings("CLOUDTRAIL_ENABLED"),
 	}, nil
 }
@@ -174,21 +173,23 @@ func (c *CloudTrailChecks) CheckMultiRegion(ctx context.Context) (CheckResult, e
 			Evidence:        "CloudTrail only logs current region - missing activity in other regions | Violates CIS-3.1, PCI DSS 10.2.1 requires all system activity logged",
 			Remediation:     "aws cloudtrail update-trail --name YOUR_TRAIL --is-multi-region-trail",
 			ScreenshotGuide: "1. Go to CloudTrail → Trails\n2. Click your trail\n3. Screenshot showing 'Multi-region trail: Yes'\n4. This catches attackers using other regions",
-			ConsoleURL:      "https://console.aws.amazon.com/cloudtrail/home#/trails",
+			ConsoleURL:      consoleURL("cloudtrail/home#/trails", consoleRegion(ctx)),
 			Priority:        PriorityHigh,
-			Timestamp:       time.Now(),
+			Timestamp:       nowFunc(),
 			Frameworks:      GetFrameworkMappings("CLOUDTRAIL_MULTIREGION"),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "CIS-3.1, CC7.1",
-		Name:       "Multi-Region CloudTrail",
-		Status:     "PASS",
-		Evidence:   "CloudTrail configured to log all regions | Meets CIS-3.1, PCI DSS 10.2.1 comprehensive logging",
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("CLOUDTRAIL_MULTIREGION"),
+		Control:         "CIS-3.1, CC7.1",
+		Name:            "Multi-Region CloudTrail",
+		Status:          "PASS",
+		Evidence:        "CloudTrail configured to log all regions | Meets CIS-3.1, PCI DSS 10.2.1 comprehensive logging",
+		ScreenshotGuide: "1. Go to CloudTrail → Trails\n2. Click your trail\n3. Screenshot showing 'Multi-region trail: Yes'\n4. This catches attackers using other regions",
+		ConsoleURL:      consoleURL("cloudtrail/home#/trails", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("CLOUDTRAIL_MULTIREGION"),
 	}, nil
 }
 
@@ -219,21 +220,23 @@ func (c *CloudTrailChecks) CheckLogFileValidation(ctx context.Context) (CheckRes
 			Evidence:        "Log file validation disabled - logs could be tampered with | PCI DSS 10.5.2 requires tamper protection",
 			Remediation:     "aws cloudtrail update-trail --name YOUR_TRAIL --enable-log-file-validation",
 			ScreenshotGuide: "1. Go to CloudTrail → Trails → Your Trail\n2. Screenshot showing 'Log file validation: Enabled'\n3. For HIPAA: Document integrity controls",
-			ConsoleURL:      "https://console.aws.amazon.com/cloudtrail/home#/trails",
+			ConsoleURL:      consoleURL("cloudtrail/home#/trails", consoleRegion(ctx)),
 			Priority:        PriorityMedium,
-			Timestamp:       time.Now(),
+			Timestamp:       nowFunc(),
 			Frameworks:      GetFrameworkMappings("CLOUDTRAIL_INTEGRITY"),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "CC7.1",
-		Name:       "CloudTrail Log Integrity",
-		Status:     "PASS",
-		Evidence:   "Log file validation enabled to prevent tampering | Meets PCI DSS 10.5.2 & HIPAA 164.312(c)(1)",
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("CLOUDTRAIL_INTEGRITY"),
+		Control:         "CC7.1",
+		Name:            "CloudTrail Log Integrity",
+		Status:          "PASS",
+		Evidence:        "Log file validation enabled to prevent tampering | Meets PCI DSS 10.5.2 & HIPAA 164.312(c)(1)",
+		ScreenshotGuide: "1. Go to CloudTrail → Trails → Your Trail\n2. Screenshot showing 'Log file validation: Enabled'\n3. For HIPAA: Document integrity controls",
+		ConsoleURL:      consoleURL("cloudtrail/home#/trails", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("CLOUDTRAIL_INTEGRITY"),
 	}, nil
 }
 
@@ -264,25 +267,28 @@ func (c *CloudTrailChecks) CheckCloudTrailEncryption(ctx context.Context) (Check
 			Name:              "CloudTrail Encryption at Rest",
 			Status:            "FAIL",
 			Severity:          "HIGH",
-			Evidence:          fmt.Sprintf("%d CloudTrail(s) not encrypted with KMS: %v", len(unencryptedTrails), unencryptedTrails),
+			Evidence:          fmt.Sprintf("%d CloudTrail(s) not encrypted with KMS: %s", len(unencryptedTrails), TruncateList(unencryptedTrails, evidenceListLimit(ctx))),
+			AffectedResources: unencryptedTrails,
 			Remediation:       "Enable KMS encryption for CloudTrail logs",
 			RemediationDetail: "1. Create KMS key: aws kms create-key\n2. Update trail: aws cloudtrail update-trail --name [TRAIL] --kms-key-id [KEY_ARN]",
 			ScreenshotGuide:   "CloudTrail → Trail → General details → Screenshot showing 'SSE-KMS encryption: Enabled' with KMS key ARN",
-			ConsoleURL:        "https://console.aws.amazon.com/cloudtrail/home#/trails",
+			ConsoleURL:        consoleURL("cloudtrail/home#/trails", consoleRegion(ctx)),
 			Priority:          PriorityHigh,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("CLOUDTRAIL_ENCRYPTION"),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "[CIS-3.7]",
-		Name:       "CloudTrail Encryption at Rest",
-		Status:     "PASS",
-		Evidence:   "All CloudTrail logs encrypted with KMS CMKs",
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("CLOUDTRAIL_ENCRYPTION"),
+		Control:         "[CIS-3.7]",
+		Name:            "CloudTrail Encryption at Rest",
+		Status:          "PASS",
+		Evidence:        "All CloudTrail logs encrypted with KMS CMKs",
+		ScreenshotGuide: "CloudTrail → Trail → General details → Screenshot showing 'SSE-KMS encryption: Enabled' with KMS key ARN",
+		ConsoleURL:      consoleURL("cloudtrail/home#/trails", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("CLOUDTRAIL_ENCRYPTION"),
 	}, nil
 }
 
@@ -311,25 +317,28 @@ func (c *CloudTrailChecks) CheckCloudTrailLogIntegration(ctx context.Context) (C
 			Name:              "CloudTrail CloudWatch Logs Integration",
 			Status:            "FAIL",
 			Severity:          "MEDIUM",
-			Evidence:          fmt.Sprintf("%d CloudTrail(s) not integrated with CloudWatch Logs: %v", len(trailsWithoutCWL), trailsWithoutCWL),
+			Evidence:          fmt.Sprintf("%d CloudTrail(s) not integrated with CloudWatch Logs: %s", len(trailsWithoutCWL), TruncateList(trailsWithoutCWL, evidenceListLimit(ctx))),
+			AffectedResources: trailsWithoutCWL,
 			Remediation:       "Enable CloudWatch Logs integration for real-time monitoring",
 			RemediationDetail: "1. Create CloudWatch log group\n2. Create IAM role for CloudTrail\n3. Update trail: aws cloudtrail update-trail --name [TRAIL] --cloud-watch-logs-log-group-arn [ARN] --cloud-watch-logs-role-arn [ROLE_ARN]",
 			ScreenshotGuide:   "CloudTrail → Trail → CloudWatch Logs → Screenshot showing log group ARN configured",
-			ConsoleURL:        "https://console.aws.amazon.com/cloudtrail/home#/trails",
+			ConsoleURL:        consoleURL("cloudtrail/home#/trails", consoleRegion(ctx)),
 			Priority:          PriorityMedium,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("CLOUDWATCH_LOG_ENCRYPTION"),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "[CIS-3.3]",
-		Name:       "CloudTrail CloudWatch Logs Integration",
-		Status:     "PASS",
-		Evidence:   "All CloudTrail logs integrated with CloudWatch Logs",
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("CLOUDWATCH_LOG_ENCRYPTION"),
+		Control:         "[CIS-3.3]",
+		Name:            "CloudTrail CloudWatch Logs Integration",
+		Status:          "PASS",
+		Evidence:        "All CloudTrail logs integrated with CloudWatch Logs",
+		ScreenshotGuide: "CloudTrail → Trail → CloudWatch Logs → Screenshot showing log group ARN configured",
+		ConsoleURL:      consoleURL("cloudtrail/home#/trails", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("CLOUDWATCH_LOG_ENCRYPTION"),
 	}, nil
 }
 
@@ -343,9 +352,9 @@ func (c *CloudTrailChecks) CheckS3BucketAccessLogging(ctx context.Context) (Chec
 		Remediation:       "Enable S3 server access logging on CloudTrail bucket",
 		RemediationDetail: "1. Identify CloudTrail S3 bucket\n2. Enable access logging: aws s3api put-bucket-logging --bucket [CLOUDTRAIL_BUCKET] --bucket-logging-status '{\"LoggingEnabled\":{\"TargetBucket\":\"[LOG_BUCKET]\",\"TargetPrefix\":\"cloudtrail-bucket-logs/\"}}'",
 		ScreenshotGuide:   "S3 Console → CloudTrail bucket → Properties → Server access logging → Screenshot showing 'Enabled'",
-		ConsoleURL:        "https://s3.console.aws.amazon.com/s3/buckets",
+		ConsoleURL:        consoleURL("s3/buckets", consoleRegion(ctx)),
 		Priority:          PriorityMedium,
-		Timestamp:         time.Now(),
+		Timestamp:         nowFunc(),
 		Frameworks:        GetFrameworkMappings("CLOUDTRAIL_S3_LOGGING"),
 	}, nil
 }
@@ -375,25 +384,28 @@ func (c *CloudTrailChecks) CheckCloudTrailLogValidation(ctx context.Context) (Ch
 			Name:              "CloudTrail Log File Validation",
 			Status:            "FAIL",
 			Severity:          "MEDIUM",
-			Evidence:          fmt.Sprintf("%d CloudTrail(s) without log file validation: %v", len(trailsWithoutValidation), trailsWithoutValidation),
+			Evidence:          fmt.Sprintf("%d CloudTrail(s) without log file validation: %s", len(trailsWithoutValidation), TruncateList(trailsWithoutValidation, evidenceListLimit(ctx))),
+			AffectedResources: trailsWithoutValidation,
 			Remediation:       "Enable log file validation to detect tampering",
 			RemediationDetail: "aws cloudtrail update-trail --name [TRAIL_NAME] --enable-log-file-validation",
 			ScreenshotGuide:   "CloudTrail → Trail → General details → Screenshot showing 'Log file validation: Enabled'",
-			ConsoleURL:        "https://console.aws.amazon.com/cloudtrail/home#/trails",
+			ConsoleURL:        consoleURL("cloudtrail/home#/trails", consoleRegion(ctx)),
 			Priority:          PriorityMedium,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("CLOUDTRAIL_VALIDATION"),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "[CIS-3.2]",
-		Name:       "CloudTrail Log File Validation",
-		Status:     "PASS",
-		Evidence:   "All CloudTrail logs have file validation enabled",
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("CLOUDTRAIL_VALIDATION"),
+		Control:         "[CIS-3.2]",
+		Name:            "CloudTrail Log File Validation",
+		Status:          "PASS",
+		Evidence:        "All CloudTrail logs have file validation enabled",
+		ScreenshotGuide: "CloudTrail → Trail → General details → Screenshot showing 'Log file validation: Enabled'",
+		ConsoleURL:      consoleURL("cloudtrail/home#/trails", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("CLOUDTRAIL_VALIDATION"),
 	}, nil
 }
 
@@ -407,9 +419,9 @@ func (c *CloudTrailChecks) CheckCloudTrailS3BucketPolicy(ctx context.Context) (C
 		Remediation:       "Ensure CloudTrail S3 bucket blocks all public access",
 		RemediationDetail: "1. Go to S3 Console\n2. Find CloudTrail bucket\n3. Block Public Access settings: All ON\n4. Bucket policy: Should only allow CloudTrail service access\n5. No 'Principal': '*' unless properly restricted",
 		ScreenshotGuide:   "S3 Console → CloudTrail bucket → Permissions → Screenshot showing 'Block all public access: On' and bucket policy limiting access",
-		ConsoleURL:        "https://s3.console.aws.amazon.com/s3/buckets",
+		ConsoleURL:        consoleURL("s3/buckets", consoleRegion(ctx)),
 		Priority:          PriorityHigh,
-		Timestamp:         time.Now(),
+		Timestamp:         nowFunc(),
 		Frameworks:        GetFrameworkMappings("S3_CLOUDTRAIL_BUCKET"),
 	}, nil
 }
@@ -424,9 +436,9 @@ func (c *CloudTrailChecks) CheckCloudTrailKMSKey(ctx context.Context) (CheckResu
 		Remediation:       "Enable automatic key rotation for KMS keys",
 		RemediationDetail: "1. Go to KMS Console\n2. Find key used by CloudTrail\n3. Enable automatic key rotation\n4. Verify rotation is enabled: aws kms get-key-rotation-status --key-id [KEY_ID]",
 		ScreenshotGuide:   "KMS Console → Customer managed keys → CloudTrail key → Key rotation → Screenshot showing 'Automatically rotate this KMS key every year: Enabled'",
-		ConsoleURL:        "https://console.aws.amazon.com/kms/home#/kms/keys",
+		ConsoleURL:        consoleURL("kms/home#/kms/keys", consoleRegion(ctx)),
 		Priority:          PriorityMedium,
-		Timestamp:         time.Now(),
+		Timestamp:         nowFunc(),
 		Frameworks:        GetFrameworkMappings("KMS_KEY_ROTATION"),
 	}, nil
 }
@@ -441,7 +453,7 @@ func (c *CloudTrailChecks) CheckS3ObjectLevelLoggingWrite(ctx context.Context) (
 			Status:     "FAIL",
 			Evidence:   fmt.Sprintf("Unable to check CloudTrail configuration: %v", err),
 			Priority:   PriorityHigh,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: map[string]string{"CIS-AWS": "3.10", "SOC2": "CC7.2"},
 		}, nil
 	}
@@ -474,9 +486,9 @@ aws cloudtrail put-event-selectors --trail-name [TRAIL_NAME] --event-selectors '
   }]
 }]'`,
 			ScreenshotGuide:   "CloudTrail → Trails → Data events → Screenshot showing S3 'Write' events logging enabled",
-			ConsoleURL:        "https://console.aws.amazon.com/cloudtrail/home#/trails",
+			ConsoleURL:        consoleURL("cloudtrail/home#/trails", consoleRegion(ctx)),
 			Priority:          PriorityHigh,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        map[string]string{"CIS-AWS": "3.10", "SOC2": "CC7.2", "PCI-DSS": "10.2"},
 		}, nil
 	}
@@ -526,9 +538,9 @@ aws cloudtrail put-event-selectors --trail-name [TRAIL_NAME] --event-selectors '
   }]
 }]'`,
 			ScreenshotGuide:   "CloudTrail → Trails → Data events → Screenshot showing S3 'Write' events enabled",
-			ConsoleURL:        "https://console.aws.amazon.com/cloudtrail/home#/trails",
+			ConsoleURL:        consoleURL("cloudtrail/home#/trails", consoleRegion(ctx)),
 			Priority:          PriorityHigh,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        map[string]string{"CIS-AWS": "3.10", "SOC2": "CC7.2", "PCI-DSS": "10.2"},
 		}, nil
 	}
@@ -538,8 +550,9 @@ aws cloudtrail put-event-selectors --trail-name [TRAIL_NAME] --event-selectors '
 		Name:       "S3 Object-Level Logging (Write)",
 		Status:     "PASS",
 		Evidence:   fmt.Sprintf("%d trail(s) logging S3 write events: %s | Meets CIS 3.10", len(trailsWithS3WriteLogging), trailsWithS3WriteLogging[0]),
+		ConsoleURL: consoleURL("cloudtrail/home#/trails", consoleRegion(ctx)),
 		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
+		Timestamp:  nowFunc(),
 		Frameworks: map[string]string{"CIS-AWS": "3.10", "SOC2": "CC7.2", "PCI-DSS": "10.2"},
 	}, nil
 }
@@ -554,7 +567,7 @@ func (c *CloudTrailChecks) CheckS3ObjectLevelLoggingRead(ctx context.Context) (C
 			Status:     "FAIL",
 			Evidence:   fmt.Sprintf("Unable to check CloudTrail configuration: %v", err),
 			Priority:   PriorityMedium,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: map[string]string{"CIS-AWS": "3.11", "SOC2": "CC7.2"},
 		}, nil
 	}
@@ -587,9 +600,9 @@ aws cloudtrail put-event-selectors --trail-name [TRAIL_NAME] --event-selectors '
   }]
 }]'`,
 			ScreenshotGuide:   "CloudTrail → Trails → Data events → Screenshot showing S3 'Read' events logging enabled",
-			ConsoleURL:        "https://console.aws.amazon.com/cloudtrail/home#/trails",
+			ConsoleURL:        consoleURL("cloudtrail/home#/trails", consoleRegion(ctx)),
 			Priority:          PriorityMedium,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        map[string]string{"CIS-AWS": "3.11", "SOC2": "CC7.2", "PCI-DSS": "10.3"},
 		}, nil
 	}
@@ -639,9 +652,9 @@ aws cloudtrail put-event-selectors --trail-name [TRAIL_NAME] --event-selectors '
   }]
 }]'`,
 			ScreenshotGuide:   "CloudTrail → Trails → Data events → Screenshot showing S3 'Read' events enabled",
-			ConsoleURL:        "https://console.aws.amazon.com/cloudtrail/home#/trails",
+			ConsoleURL:        consoleURL("cloudtrail/home#/trails", consoleRegion(ctx)),
 			Priority:          PriorityMedium,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        map[string]string{"CIS-AWS": "3.11", "SOC2": "CC7.2", "PCI-DSS": "10.3"},
 		}, nil
 	}
@@ -651,8 +664,9 @@ aws cloudtrail put-event-selectors --trail-name [TRAIL_NAME] --event-selectors '
 		Name:       "S3 Object-Level Logging (Read)",
 		Status:     "PASS",
 		Evidence:   fmt.Sprintf("%d trail(s) logging S3 read events: %s | Meets CIS 3.11", len(trailsWithS3ReadLogging), trailsWithS3ReadLogging[0]),
+		ConsoleURL: consoleURL("cloudtrail/home#/trails", consoleRegion(ctx)),
 		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
+		Timestamp:  nowFunc(),
 		Frameworks: map[string]string{"CIS-AWS": "3.11", "SOC2": "CC7.2", "PCI-DSS": "10.3"},
 	}, nil
 }
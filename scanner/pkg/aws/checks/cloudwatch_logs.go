@@ -0,0 +1,156 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	logstypes "github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+)
+
+type CloudWatchLogsChecks struct {
+	client *cloudwatchlogs.Client
+
+	// minRetentionDays is the shortest retention a log group may keep
+	minRetentionDays int32
+}
+
+// DefaultLogRetentionMinimumDays is the shortest log group retention
+// CheckLogGroupRetention accepts: a year keeps the logs an investigation or a
+// SOC 2 Type II observation period reaches back into
+var DefaultLogRetentionMinimumDays int32 = 365
+
+// logGroupsPerPage is the most log groups one DescribeLogGroups call returns
+const logGroupsPerPage = 50
+
+func NewCloudWatchLogsChecks(client *cloudwatchlogs.Client) *CloudWatchLogsChecks {
+	return &CloudWatchLogsChecks{
+		client:           client,
+		minRetentionDays: DefaultLogRetentionMinimumDays,
+	}
+}
+
+// SetMinimumRetentionDays sets the shortest acceptable log group retention,
+// e.g. SetMinimumRetentionDays(90) for an organization that keeps 90 days
+func (c *CloudWatchLogsChecks) SetMinimumRetentionDays(days int32) {
+	c.minRetentionDays = days
+}
+
+func (c *CloudWatchLogsChecks) Name() string {
+	return "CloudWatch Logs Retention"
+}
+
+func (c *CloudWatchLogsChecks) Run(ctx context.Context) ([]CheckResult, error) {
+	results := []CheckResult{}
+
+	if result, err := runCheck(ctx, "cloudwatch_logs.log_group_retention", c.CheckLogGroupRetention); err == nil {
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// EstimateCalls predicts one DescribeLogGroups call per page of log groups
+func (c *CloudWatchLogsChecks) EstimateCalls(ctx context.Context) (CallEstimate, error) {
+	groups, err := c.describeLogGroups(ctx)
+	if err != nil {
+		return CallEstimate{}, err
+	}
+
+	return CallEstimate{
+		Resources: len(groups),
+		Calls:     len(groups)/logGroupsPerPage + 1,
+	}, nil
+}
+
+// CheckLogGroupRetention flags log groups that never expire and log groups
+// kept for less than the minimum retention. Logs kept forever cost money and
+// hold personal data longer than data-minimization policies allow; logs kept
+// too briefly are gone before an investigation needs them.
+func (c *CloudWatchLogsChecks) CheckLogGroupRetention(ctx context.Context) (CheckResult, error) {
+	groups, err := c.describeLogGroups(ctx)
+	if err != nil {
+		return CheckResult{}, err
+	}
+
+	neverExpire := []string{}
+	tooShort := []string{}      // names with their retention, for the evidence
+	tooShortNames := []string{} // bare names, for AffectedResources
+
+	for _, group := range groups {
+		name := aws.ToString(group.LogGroupName)
+		retention := aws.ToInt32(group.RetentionInDays)
+		switch {
+		case group.RetentionInDays == nil || retention == 0:
+			neverExpire = append(neverExpire, name)
+		case retention < c.minRetentionDays:
+			tooShort = append(tooShort, fmt.Sprintf("%s (%d days)", name, retention))
+			tooShortNames = append(tooShortNames, name)
+		}
+	}
+
+	if len(neverExpire) > 0 || len(tooShort) > 0 {
+		parts := []string{}
+		if len(neverExpire) > 0 {
+			parts = append(parts, fmt.Sprintf("%d never expire: %s", len(neverExpire), TruncateList(neverExpire, evidenceListLimit(ctx))))
+		}
+		if len(tooShort) > 0 {
+			parts = append(parts, fmt.Sprintf("%d keep logs for less than %d days: %s", len(tooShort), c.minRetentionDays, TruncateList(tooShort, evidenceListLimit(ctx))))
+		}
+
+		affected := append(append([]string{}, neverExpire...), tooShortNames...)
+
+		return CheckResult{
+			Control:           "CC7.1",
+			Name:              "CloudWatch Logs Retention",
+			Status:            "FAIL",
+			Severity:          "LOW",
+			Evidence:          fmt.Sprintf("%d/%d log groups are outside the %d-day retention policy; %s", len(affected), len(groups), c.minRetentionDays, strings.Join(parts, "; ")),
+			AffectedResources: affected,
+			Remediation:       fmt.Sprintf("Set a retention policy of at least %d days on every log group", c.minRetentionDays),
+			RemediationDetail: fmt.Sprintf("aws logs put-retention-policy --log-group-name [LOG_GROUP] --retention-in-days %d\nRetention must be one of the values CloudWatch Logs accepts (e.g. 90, 180, 365, 400, 731); archive logs needed beyond it to S3", c.minRetentionDays),
+			ScreenshotGuide:   "CloudWatch Console → Logs → Log groups → Screenshot of the Retention column for every log group",
+			ConsoleURL:        consoleURL("cloudwatch/home#logsV2:log-groups", consoleRegion(ctx)),
+			Priority:          PriorityLow,
+			Timestamp:         nowFunc(),
+			Frameworks:        GetFrameworkMappings("CLOUDWATCH_LOG_RETENTION"),
+		}, nil
+	}
+
+	if len(groups) == 0 {
+		return CheckResult{
+			Control:    "CC7.1",
+			Name:       "CloudWatch Logs Retention",
+			Status:     "PASS",
+			Evidence:   "No CloudWatch Logs log groups found",
+			ConsoleURL: consoleURL("cloudwatch/home#logsV2:log-groups", consoleRegion(ctx)),
+			Priority:   PriorityInfo,
+			Timestamp:  nowFunc(),
+			Frameworks: GetFrameworkMappings("CLOUDWATCH_LOG_RETENTION"),
+		}, nil
+	}
+
+	return CheckResult{
+		Control:         "CC7.1",
+		Name:            "CloudWatch Logs Retention",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d log groups keep logs for at least %d days and expire them", len(groups), c.minRetentionDays),
+		ScreenshotGuide: "CloudWatch Console → Logs → Log groups → Screenshot of the Retention column for every log group",
+		ConsoleURL:      consoleURL("cloudwatch/home#logsV2:log-groups", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("CLOUDWATCH_LOG_RETENTION"),
+	}, nil
+}
+
+func (c *CloudWatchLogsChecks) describeLogGroups(ctx context.Context) ([]logstypes.LogGroup, error) {
+	return paginate(ctx, func(token *string) ([]logstypes.LogGroup, *string, error) {
+		out, err := c.client.DescribeLogGroups(ctx, &cloudwatchlogs.DescribeLogGroupsInput{NextToken: token})
+		if err != nil {
+			return nil, nil, err
+		}
+		return out.LogGroups, out.NextToken, nil
+	})
+}
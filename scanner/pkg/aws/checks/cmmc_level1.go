@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"strings"
-	"time"
 	
 	"github.com/aws/aws-sdk-go-v2/service/iam"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
@@ -37,39 +36,47 @@ func (c *AWSCMMCLevel1Checks) Run(ctx context.Context) ([]CheckResult, error) {
 	var results []CheckResult
 
 	// ACCESS CONTROL - 2 automated
-	results = append(results, c.CheckAC_L1_001(ctx))
-	results = append(results, c.CheckAC_L1_002(ctx))
+	results = append(results, runChecks(ctx, "cmmc_level1.ac_l1_001", cmmcPractice(c.CheckAC_L1_001))...)
+	results = append(results, runChecks(ctx, "cmmc_level1.ac_l1_002", cmmcPractice(c.CheckAC_L1_002))...)
 
 	// IDENTIFICATION AND AUTHENTICATION - 2 automated
-	results = append(results, c.CheckIA_L1_001(ctx))
-	results = append(results, c.CheckIA_L1_002(ctx))
+	results = append(results, runChecks(ctx, "cmmc_level1.ia_l1_001", cmmcPractice(c.CheckIA_L1_001))...)
+	results = append(results, runChecks(ctx, "cmmc_level1.ia_l1_002", cmmcPractice(c.CheckIA_L1_002))...)
 
 	// MEDIA PROTECTION - 1 INFO
-	results = append(results, c.CheckMP_L1_001(ctx))
+	results = append(results, runChecks(ctx, "cmmc_level1.mp_l1_001", cmmcPractice(c.CheckMP_L1_001))...)
 
 	// PHYSICAL PROTECTION - 6 INFO
-	results = append(results, c.CheckPE_L1_001(ctx))
-	results = append(results, c.CheckPE_L1_002(ctx))
-	results = append(results, c.CheckPE_L1_003(ctx))
-	results = append(results, c.CheckPE_L1_004(ctx))
-	results = append(results, c.CheckPE_L1_005(ctx))
-	results = append(results, c.CheckPE_L1_006(ctx))
+	results = append(results, runChecks(ctx, "cmmc_level1.pe_l1_001", cmmcPractice(c.CheckPE_L1_001))...)
+	results = append(results, runChecks(ctx, "cmmc_level1.pe_l1_002", cmmcPractice(c.CheckPE_L1_002))...)
+	results = append(results, runChecks(ctx, "cmmc_level1.pe_l1_003", cmmcPractice(c.CheckPE_L1_003))...)
+	results = append(results, runChecks(ctx, "cmmc_level1.pe_l1_004", cmmcPractice(c.CheckPE_L1_004))...)
+	results = append(results, runChecks(ctx, "cmmc_level1.pe_l1_005", cmmcPractice(c.CheckPE_L1_005))...)
+	results = append(results, runChecks(ctx, "cmmc_level1.pe_l1_006", cmmcPractice(c.CheckPE_L1_006))...)
 
 	// PERSONNEL SECURITY - 2 INFO
-	results = append(results, c.CheckPS_L1_001(ctx))
-	results = append(results, c.CheckPS_L1_002(ctx))
+	results = append(results, runChecks(ctx, "cmmc_level1.ps_l1_001", cmmcPractice(c.CheckPS_L1_001))...)
+	results = append(results, runChecks(ctx, "cmmc_level1.ps_l1_002", cmmcPractice(c.CheckPS_L1_002))...)
 
 	// SYSTEM AND COMMUNICATIONS PROTECTION - 2 INFO
-	results = append(results, c.CheckSC_L1_001(ctx))
-	results = append(results, c.CheckSC_L1_002(ctx))
+	results = append(results, runChecks(ctx, "cmmc_level1.sc_l1_001", cmmcPractice(c.CheckSC_L1_001))...)
+	results = append(results, runChecks(ctx, "cmmc_level1.sc_l1_002", cmmcPractice(c.CheckSC_L1_002))...)
 
 	// SYSTEM AND INFORMATION INTEGRITY - 2 automated
-	results = append(results, c.CheckSI_L1_001(ctx))
-	results = append(results, c.CheckSI_L1_002(ctx))
+	results = append(results, runChecks(ctx, "cmmc_level1.si_l1_001", cmmcPractice(c.CheckSI_L1_001))...)
+	results = append(results, runChecks(ctx, "cmmc_level1.si_l1_002", cmmcPractice(c.CheckSI_L1_002))...)
 
 	return results, nil
 }
 
+// cmmcPractice adapts a practice check, which reports exactly one result, to
+// runChecks
+func cmmcPractice(check func(context.Context) CheckResult) func(context.Context) []CheckResult {
+	return func(ctx context.Context) []CheckResult {
+		return []CheckResult{check(ctx)}
+	}
+}
+
 // AC.L1-3.1.1 - AUTOMATED
 func (c *AWSCMMCLevel1Checks) CheckAC_L1_001(ctx context.Context) CheckResult {
 	users, err := c.iamClient.ListUsers(ctx, &iam.ListUsersInput{})
@@ -81,9 +88,9 @@ func (c *AWSCMMCLevel1Checks) CheckAC_L1_001(ctx context.Context) CheckResult {
 			Evidence:    fmt.Sprintf("Unable to verify IAM users: %v", err),
 			Remediation: "Enable IAM and create user accounts for authorized personnel",
 			Priority:    PriorityCritical,
-			Timestamp:   time.Now(),
+			Timestamp:   nowFunc(),
 			ScreenshotGuide: "AWS Console → IAM → Users → Screenshot user list",
-			ConsoleURL: "https://console.aws.amazon.com/iam/home#/users",
+			ConsoleURL: consoleURL("iam/home#/users", consoleRegion(ctx)),
 			Frameworks: map[string]string{"CMMC": "AC.L1-3.1.1", "NIST 800-171": "3.1.1"},
 		}
 	}
@@ -96,9 +103,9 @@ func (c *AWSCMMCLevel1Checks) CheckAC_L1_001(ctx context.Context) CheckResult {
 			Evidence:    "No IAM users found - using root account only",
 			Remediation: "Create IAM users for authorized personnel",
 			Priority:    PriorityCritical,
-			Timestamp:   time.Now(),
+			Timestamp:   nowFunc(),
 			ScreenshotGuide: "AWS Console → IAM → Users → Create users → Screenshot user creation",
-			ConsoleURL: "https://console.aws.amazon.com/iam/home#/users",
+			ConsoleURL: consoleURL("iam/home#/users", consoleRegion(ctx)),
 			Frameworks: map[string]string{"CMMC": "AC.L1-3.1.1", "NIST 800-171": "3.1.1"},
 		}
 	}
@@ -110,9 +117,9 @@ func (c *AWSCMMCLevel1Checks) CheckAC_L1_001(ctx context.Context) CheckResult {
 		Evidence:    fmt.Sprintf("IAM access control configured with %d users", len(users.Users)),
 		Remediation: "Continue reviewing IAM user permissions regularly",
 		Priority:    PriorityCritical,
-		Timestamp:   time.Now(),
+		Timestamp:   nowFunc(),
 		ScreenshotGuide: "AWS Console → IAM → Users → Screenshot user list showing authorized access",
-		ConsoleURL: "https://console.aws.amazon.com/iam/home#/users",
+		ConsoleURL: consoleURL("iam/home#/users", consoleRegion(ctx)),
 		Frameworks: map[string]string{"CMMC": "AC.L1-3.1.1", "NIST 800-171": "3.1.1"},
 	}
 }
@@ -128,9 +135,9 @@ func (c *AWSCMMCLevel1Checks) CheckAC_L1_002(ctx context.Context) CheckResult {
 			Evidence:    fmt.Sprintf("Unable to verify IAM policies: %v", err),
 			Remediation: "Configure IAM policies to limit access to authorized users",
 			Priority:    PriorityCritical,
-			Timestamp:   time.Now(),
+			Timestamp:   nowFunc(),
 			ScreenshotGuide: "AWS Console → IAM → Policies → Screenshot policy list",
-			ConsoleURL: "https://console.aws.amazon.com/iam/home#/policies",
+			ConsoleURL: consoleURL("iam/home#/policies", consoleRegion(ctx)),
 			Frameworks: map[string]string{"CMMC": "AC.L1-3.1.2", "NIST 800-171": "3.1.2"},
 		}
 	}
@@ -143,9 +150,9 @@ func (c *AWSCMMCLevel1Checks) CheckAC_L1_002(ctx context.Context) CheckResult {
 			Evidence:    "No custom IAM policies - relying on AWS managed policies only",
 			Remediation: "Create custom IAM policies to restrict access appropriately",
 			Priority:    PriorityHigh,
-			Timestamp:   time.Now(),
+			Timestamp:   nowFunc(),
 			ScreenshotGuide: "AWS Console → IAM → Policies → Create policy → Screenshot custom policies",
-			ConsoleURL: "https://console.aws.amazon.com/iam/home#/policies",
+			ConsoleURL: consoleURL("iam/home#/policies", consoleRegion(ctx)),
 			Frameworks: map[string]string{"CMMC": "AC.L1-3.1.2", "NIST 800-171": "3.1.2"},
 		}
 	}
@@ -157,9 +164,9 @@ func (c *AWSCMMCLevel1Checks) CheckAC_L1_002(ctx context.Context) CheckResult {
 		Evidence:    fmt.Sprintf("IAM policies configured (%d custom policies)", len(policies.Policies)),
 		Remediation: "Review policies quarterly for least privilege",
 		Priority:    PriorityCritical,
-		Timestamp:   time.Now(),
+		Timestamp:   nowFunc(),
 		ScreenshotGuide: "AWS Console → IAM → Policies → Screenshot showing custom access policies",
-		ConsoleURL: "https://console.aws.amazon.com/iam/home#/policies",
+		ConsoleURL: consoleURL("iam/home#/policies", consoleRegion(ctx)),
 		Frameworks: map[string]string{"CMMC": "AC.L1-3.1.2", "NIST 800-171": "3.1.2"},
 	}
 }
@@ -175,9 +182,9 @@ func (c *AWSCMMCLevel1Checks) CheckIA_L1_001(ctx context.Context) CheckResult {
 			Evidence:    fmt.Sprintf("Unable to verify user identities: %v", err),
 			Remediation: "Ensure IAM users have unique identities",
 			Priority:    PriorityCritical,
-			Timestamp:   time.Now(),
+			Timestamp:   nowFunc(),
 			ScreenshotGuide: "AWS Console → IAM → Users → Screenshot user identities",
-			ConsoleURL: "https://console.aws.amazon.com/iam/home#/users",
+			ConsoleURL: consoleURL("iam/home#/users", consoleRegion(ctx)),
 			Frameworks: map[string]string{"CMMC": "IA.L1-3.5.1", "NIST 800-171": "3.5.1"},
 		}
 	}
@@ -200,9 +207,9 @@ func (c *AWSCMMCLevel1Checks) CheckIA_L1_001(ctx context.Context) CheckResult {
 			Evidence:    fmt.Sprintf("Found %d potential shared accounts: %s", sharedAccounts, strings.Join(sharedNames, ", ")),
 			Remediation: "Replace shared accounts with individual user accounts",
 			Priority:    PriorityCritical,
-			Timestamp:   time.Now(),
+			Timestamp:   nowFunc(),
 			ScreenshotGuide: "AWS Console → IAM → Users → Screenshot showing individual (not shared) accounts",
-			ConsoleURL: "https://console.aws.amazon.com/iam/home#/users",
+			ConsoleURL: consoleURL("iam/home#/users", consoleRegion(ctx)),
 			Frameworks: map[string]string{"CMMC": "IA.L1-3.5.1", "NIST 800-171": "3.5.1"},
 		}
 	}
@@ -214,9 +221,9 @@ func (c *AWSCMMCLevel1Checks) CheckIA_L1_001(ctx context.Context) CheckResult {
 		Evidence:    fmt.Sprintf("All %d IAM users have unique identities", len(users.Users)),
 		Remediation: "Continue ensuring unique user identities",
 		Priority:    PriorityCritical,
-		Timestamp:   time.Now(),
+		Timestamp:   nowFunc(),
 		ScreenshotGuide: "AWS Console → IAM → Users → Screenshot showing unique user identities",
-		ConsoleURL: "https://console.aws.amazon.com/iam/home#/users",
+		ConsoleURL: consoleURL("iam/home#/users", consoleRegion(ctx)),
 		Frameworks: map[string]string{"CMMC": "IA.L1-3.5.1", "NIST 800-171": "3.5.1"},
 	}
 }
@@ -232,9 +239,9 @@ func (c *AWSCMMCLevel1Checks) CheckIA_L1_002(ctx context.Context) CheckResult {
 			Evidence:    fmt.Sprintf("Unable to verify authentication: %v", err),
 			Remediation: "Configure MFA for all users",
 			Priority:    PriorityCritical,
-			Timestamp:   time.Now(),
+			Timestamp:   nowFunc(),
 			ScreenshotGuide: "AWS Console → IAM → Account settings → Screenshot MFA enforcement",
-			ConsoleURL: "https://console.aws.amazon.com/iam/home#/account_settings",
+			ConsoleURL: consoleURL("iam/home#/account_settings", consoleRegion(ctx)),
 			Frameworks: map[string]string{"CMMC": "IA.L1-3.5.2", "NIST 800-171": "3.5.2"},
 		}
 	}
@@ -250,9 +257,9 @@ func (c *AWSCMMCLevel1Checks) CheckIA_L1_002(ctx context.Context) CheckResult {
 			Evidence:    fmt.Sprintf("Only %d/%d users have MFA enabled", mfaUsers, users),
 			Remediation: "Enable MFA for all IAM users",
 			Priority:    PriorityCritical,
-			Timestamp:   time.Now(),
+			Timestamp:   nowFunc(),
 			ScreenshotGuide: "AWS Console → IAM → Users → Security credentials → Screenshot MFA devices",
-			ConsoleURL: "https://console.aws.amazon.com/iam/home#/users",
+			ConsoleURL: consoleURL("iam/home#/users", consoleRegion(ctx)),
 			Frameworks: map[string]string{"CMMC": "IA.L1-3.5.2", "NIST 800-171": "3.5.2"},
 		}
 	}
@@ -264,9 +271,9 @@ func (c *AWSCMMCLevel1Checks) CheckIA_L1_002(ctx context.Context) CheckResult {
 		Evidence:    fmt.Sprintf("All %d users have MFA enabled", users),
 		Remediation: "Continue enforcing MFA for all users",
 		Priority:    PriorityCritical,
-		Timestamp:   time.Now(),
+		Timestamp:   nowFunc(),
 		ScreenshotGuide: "AWS Console → IAM → Users → Screenshot showing MFA enabled for all users",
-		ConsoleURL: "https://console.aws.amazon.com/iam/home#/users",
+		ConsoleURL: consoleURL("iam/home#/users", consoleRegion(ctx)),
 		Frameworks: map[string]string{"CMMC": "IA.L1-3.5.2", "NIST 800-171": "3.5.2"},
 	}
 }
@@ -280,9 +287,9 @@ func (c *AWSCMMCLevel1Checks) CheckMP_L1_001(ctx context.Context) CheckResult {
 		Evidence:    "MANUAL: Document media sanitization procedures for EBS volumes and S3 objects",
 		Remediation: "Implement secure deletion procedures using AWS encryption and S3 lifecycle policies",
 		Priority:    PriorityHigh,
-		Timestamp:   time.Now(),
+		Timestamp:   nowFunc(),
 		ScreenshotGuide: "Documentation → Screenshot showing media sanitization procedures | AWS Console → S3 → Lifecycle rules",
-		ConsoleURL: "https://console.aws.amazon.com/s3/home",
+		ConsoleURL: consoleURL("s3/home", consoleRegion(ctx)),
 		Frameworks: map[string]string{"CMMC": "MP.L1-3.8.3", "NIST 800-171": "3.8.3"},
 	}
 }
@@ -296,9 +303,9 @@ func (c *AWSCMMCLevel1Checks) CheckPE_L1_001(ctx context.Context) CheckResult {
 		Evidence:    "MANUAL: AWS data centers have physical controls (inherited control)",
 		Remediation: "Review AWS compliance documentation for physical security controls",
 		Priority:    PriorityMedium,
-		Timestamp:   time.Now(),
+		Timestamp:   nowFunc(),
 		ScreenshotGuide: "AWS Artifact → Screenshot SOC 2 report showing physical controls",
-		ConsoleURL: "https://console.aws.amazon.com/artifact/home",
+		ConsoleURL: consoleURL("artifact/home", consoleRegion(ctx)),
 		Frameworks: map[string]string{"CMMC": "PE.L1-3.10.1", "NIST 800-171": "3.10.1"},
 	}
 }
@@ -311,9 +318,9 @@ func (c *AWSCMMCLevel1Checks) CheckPE_L1_002(ctx context.Context) CheckResult {
 		Evidence:    "MANUAL: AWS data centers escort visitors (inherited control)",
 		Remediation: "Review AWS compliance documentation for visitor controls",
 		Priority:    PriorityMedium,
-		Timestamp:   time.Now(),
+		Timestamp:   nowFunc(),
 		ScreenshotGuide: "AWS Artifact → Screenshot showing visitor management procedures",
-		ConsoleURL: "https://console.aws.amazon.com/artifact/home",
+		ConsoleURL: consoleURL("artifact/home", consoleRegion(ctx)),
 		Frameworks: map[string]string{"CMMC": "PE.L1-3.10.3", "NIST 800-171": "3.10.3"},
 	}
 }
@@ -326,9 +333,9 @@ func (c *AWSCMMCLevel1Checks) CheckPE_L1_003(ctx context.Context) CheckResult {
 		Evidence:    "MANUAL: AWS maintains physical access logs (inherited control)",
 		Remediation: "Review AWS compliance documentation for physical audit logs",
 		Priority:    PriorityMedium,
-		Timestamp:   time.Now(),
+		Timestamp:   nowFunc(),
 		ScreenshotGuide: "AWS Artifact → Screenshot showing physical access logging",
-		ConsoleURL: "https://console.aws.amazon.com/artifact/home",
+		ConsoleURL: consoleURL("artifact/home", consoleRegion(ctx)),
 		Frameworks: map[string]string{"CMMC": "PE.L1-3.10.4", "NIST 800-171": "3.10.4"},
 	}
 }
@@ -341,9 +348,9 @@ func (c *AWSCMMCLevel1Checks) CheckPE_L1_004(ctx context.Context) CheckResult {
 		Evidence:    "MANUAL: AWS controls physical access devices (inherited control)",
 		Remediation: "Review AWS compliance documentation for access device controls",
 		Priority:    PriorityMedium,
-		Timestamp:   time.Now(),
+		Timestamp:   nowFunc(),
 		ScreenshotGuide: "AWS Artifact → Screenshot showing physical access device management",
-		ConsoleURL: "https://console.aws.amazon.com/artifact/home",
+		ConsoleURL: consoleURL("artifact/home", consoleRegion(ctx)),
 		Frameworks: map[string]string{"CMMC": "PE.L1-3.10.5", "NIST 800-171": "3.10.5"},
 	}
 }
@@ -356,9 +363,9 @@ func (c *AWSCMMCLevel1Checks) CheckPE_L1_005(ctx context.Context) CheckResult {
 		Evidence:    "MANUAL: AWS data centers have monitoring and protection (inherited control)",
 		Remediation: "Review AWS compliance documentation for physical facility monitoring",
 		Priority:    PriorityMedium,
-		Timestamp:   time.Now(),
+		Timestamp:   nowFunc(),
 		ScreenshotGuide: "AWS Artifact → Screenshot SOC 2 report showing physical monitoring controls",
-		ConsoleURL: "https://console.aws.amazon.com/artifact/home",
+		ConsoleURL: consoleURL("artifact/home", consoleRegion(ctx)),
 		Frameworks: map[string]string{"CMMC": "PE.L1-3.10.2", "NIST 800-171": "3.10.2"},
 	}
 }
@@ -371,9 +378,9 @@ func (c *AWSCMMCLevel1Checks) CheckPE_L1_006(ctx context.Context) CheckResult {
 		Evidence:    "MANUAL: AWS enforces physical safeguarding measures (inherited control)",
 		Remediation: "Review AWS compliance documentation for physical safeguarding measures",
 		Priority:    PriorityMedium,
-		Timestamp:   time.Now(),
+		Timestamp:   nowFunc(),
 		ScreenshotGuide: "AWS Artifact → Screenshot showing physical safeguarding measures",
-		ConsoleURL: "https://console.aws.amazon.com/artifact/home",
+		ConsoleURL: consoleURL("artifact/home", consoleRegion(ctx)),
 		Frameworks: map[string]string{"CMMC": "PE.L1-3.10.6", "NIST 800-171": "3.10.6"},
 	}
 }
@@ -387,9 +394,9 @@ func (c *AWSCMMCLevel1Checks) CheckPS_L1_001(ctx context.Context) CheckResult {
 		Evidence:    "MANUAL: Document personnel screening procedures for CUI access",
 		Remediation: "Implement background checks for personnel with CUI access",
 		Priority:    PriorityHigh,
-		Timestamp:   time.Now(),
+		Timestamp:   nowFunc(),
 		ScreenshotGuide: "HR Documentation → Screenshot showing personnel screening procedures and background check records",
-		ConsoleURL: "https://console.aws.amazon.com/iam/home#/users",
+		ConsoleURL: consoleURL("iam/home#/users", consoleRegion(ctx)),
 		Frameworks: map[string]string{"CMMC": "PS.L1-3.9.1", "NIST 800-171": "3.9.1"},
 	}
 }
@@ -402,9 +409,9 @@ func (c *AWSCMMCLevel1Checks) CheckPS_L1_002(ctx context.Context) CheckResult {
 		Evidence:    "MANUAL: Document authorization process for CUI access",
 		Remediation: "Implement formal authorization process before granting CUI access",
 		Priority:    PriorityHigh,
-		Timestamp:   time.Now(),
+		Timestamp:   nowFunc(),
 		ScreenshotGuide: "Documentation → Screenshot showing CUI access authorization procedures and approval records",
-		ConsoleURL: "https://console.aws.amazon.com/iam/home#/users",
+		ConsoleURL: consoleURL("iam/home#/users", consoleRegion(ctx)),
 		Frameworks: map[string]string{"CMMC": "PS.L1-3.9.2", "NIST 800-171": "3.9.2"},
 	}
 }
@@ -420,9 +427,9 @@ func (c *AWSCMMCLevel1Checks) CheckSC_L1_001(ctx context.Context) CheckResult {
 			Evidence:    fmt.Sprintf("Unable to verify security groups: %v", err),
 			Remediation: "Configure VPC security groups to monitor network traffic",
 			Priority:    PriorityCritical,
-			Timestamp:   time.Now(),
+			Timestamp:   nowFunc(),
 			ScreenshotGuide: "AWS Console → VPC → Security Groups → Screenshot",
-			ConsoleURL: "https://console.aws.amazon.com/vpc/home#SecurityGroups:",
+			ConsoleURL: consoleURL("vpc/home#SecurityGroups:", consoleRegion(ctx)),
 			Frameworks: map[string]string{"CMMC": "SC.L1-3.13.1", "NIST 800-171": "3.13.1"},
 		}
 	}
@@ -449,9 +456,9 @@ func (c *AWSCMMCLevel1Checks) CheckSC_L1_001(ctx context.Context) CheckResult {
 			Evidence:    fmt.Sprintf("%d security groups allow unrestricted access: %s", openGroups, strings.Join(openGroupNames, ", ")),
 			Remediation: "Restrict security group rules to specific IP ranges",
 			Priority:    PriorityCritical,
-			Timestamp:   time.Now(),
+			Timestamp:   nowFunc(),
 			ScreenshotGuide: "AWS Console → VPC → Security Groups → Screenshot showing restricted inbound rules",
-			ConsoleURL: "https://console.aws.amazon.com/vpc/home#SecurityGroups:",
+			ConsoleURL: consoleURL("vpc/home#SecurityGroups:", consoleRegion(ctx)),
 			Frameworks: map[string]string{"CMMC": "SC.L1-3.13.1", "NIST 800-171": "3.13.1"},
 		}
 	}
@@ -463,9 +470,9 @@ func (c *AWSCMMCLevel1Checks) CheckSC_L1_001(ctx context.Context) CheckResult {
 		Evidence:    fmt.Sprintf("All %d security groups have restricted access", len(groups.SecurityGroups)),
 		Remediation: "Continue monitoring security group rules",
 		Priority:    PriorityCritical,
-		Timestamp:   time.Now(),
+		Timestamp:   nowFunc(),
 		ScreenshotGuide: "AWS Console → VPC → Security Groups → Screenshot showing monitoring controls",
-		ConsoleURL: "https://console.aws.amazon.com/vpc/home#SecurityGroups:",
+		ConsoleURL: consoleURL("vpc/home#SecurityGroups:", consoleRegion(ctx)),
 		Frameworks: map[string]string{"CMMC": "SC.L1-3.13.1", "NIST 800-171": "3.13.1"},
 	}
 }
@@ -478,9 +485,9 @@ func (c *AWSCMMCLevel1Checks) CheckSC_L1_002(ctx context.Context) CheckResult {
 		Evidence:    "MANUAL: Verify public-facing systems are in separate subnets from internal systems",
 		Remediation: "Use VPC subnets to separate public and internal systems with appropriate security groups",
 		Priority:    PriorityHigh,
-		Timestamp:   time.Now(),
+		Timestamp:   nowFunc(),
 		ScreenshotGuide: "AWS Console → VPC → Subnets → Screenshot showing subnet separation strategy",
-		ConsoleURL: "https://console.aws.amazon.com/vpc/home#subnets:",
+		ConsoleURL: consoleURL("vpc/home#subnets:", consoleRegion(ctx)),
 		Frameworks: map[string]string{"CMMC": "SC.L1-3.13.5", "NIST 800-171": "3.13.5"},
 	}
 }
@@ -494,9 +501,9 @@ func (c *AWSCMMCLevel1Checks) CheckSI_L1_001(ctx context.Context) CheckResult {
 		Evidence:    "MANUAL: Document flaw identification and remediation processes",
 		Remediation: "Enable AWS Systems Manager Patch Manager and Inspector for automated vulnerability scanning",
 		Priority:    PriorityHigh,
-		Timestamp:   time.Now(),
+		Timestamp:   nowFunc(),
 		ScreenshotGuide: "AWS Console → Systems Manager → Patch Manager → Screenshot compliance dashboard",
-		ConsoleURL: "https://console.aws.amazon.com/systems-manager/patch-manager",
+		ConsoleURL: consoleURL("systems-manager/patch-manager", consoleRegion(ctx)),
 		Frameworks: map[string]string{"CMMC": "SI.L1-3.14.1", "NIST 800-171": "3.14.1"},
 	}
 }
@@ -509,9 +516,9 @@ func (c *AWSCMMCLevel1Checks) CheckSI_L1_002(ctx context.Context) CheckResult {
 		Evidence:    "MANUAL: Document malicious code protection mechanisms",
 		Remediation: "Enable AWS GuardDuty and deploy endpoint protection on EC2 instances",
 		Priority:    PriorityHigh,
-		Timestamp:   time.Now(),
+		Timestamp:   nowFunc(),
 		ScreenshotGuide: "AWS Console → GuardDuty → Screenshot showing malware detection enabled",
-		ConsoleURL: "https://console.aws.amazon.com/guardduty/home",
+		ConsoleURL: consoleURL("guardduty/home", consoleRegion(ctx)),
 		Frameworks: map[string]string{"CMMC": "SI.L1-3.14.2", "NIST 800-171": "3.14.2"},
 	}
 }
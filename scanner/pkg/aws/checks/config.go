@@ -3,7 +3,6 @@ package checks
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/configservice"
 	"github.com/aws/aws-sdk-go-v2/service/guardduty"
@@ -26,12 +25,12 @@ func (c *ConfigChecks) Run(ctx context.Context) ([]CheckResult, error) {
 	results := []CheckResult{}
 
 	// Existing check
-	if result, err := c.CheckConfigEnabled(ctx); err == nil {
+	if result, err := runCheck(ctx, "config.enabled", c.CheckConfigEnabled); err == nil {
 		results = append(results, result)
 	}
 
 	// NEW CIS check
-	if result, err := c.CheckConfigRecording(ctx); err == nil {
+	if result, err := runCheck(ctx, "config.recording", c.CheckConfigRecording); err == nil {
 		results = append(results, result)
 	}
 
@@ -50,9 +49,9 @@ func (c *ConfigChecks) CheckConfigEnabled(ctx context.Context) (CheckResult, err
 			Remediation:       "Enable AWS Config to track configuration changes",
 			RemediationDetail: "1. Create S3 bucket for Config\n2. Create IAM role for Config\n3. Enable Config: aws configservice put-configuration-recorder --configuration-recorder name=default,roleARN=ROLE_ARN",
 			ScreenshotGuide:   "AWS Config Console → Screenshot showing Configuration recorder: On",
-			ConsoleURL:        "https://console.aws.amazon.com/config/",
+			ConsoleURL:        consoleURL("config/", consoleRegion(ctx)),
 			Priority:          PriorityHigh,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("CONFIG_ENABLED"),
 		}, err
 	}
@@ -67,9 +66,9 @@ func (c *ConfigChecks) CheckConfigEnabled(ctx context.Context) (CheckResult, err
 			Remediation:       "Enable AWS Config to record all resource configurations",
 			RemediationDetail: "1. Go to AWS Config Console\n2. Click 'Get started'\n3. Select 'Record all resources'\n4. Create/select S3 bucket\n5. Create/select IAM role\n6. Click 'Confirm'",
 			ScreenshotGuide:   "1. Go to AWS Config Console\n2. Click 'Get started'\n3. Enable recording for all resources\n4. Screenshot showing 'Recorder is ON'",
-			ConsoleURL:        "https://console.aws.amazon.com/config/",
+			ConsoleURL:        consoleURL("config/", consoleRegion(ctx)),
 			Priority:          PriorityHigh,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("CONFIG_ENABLED"),
 		}, nil
 	}
@@ -80,9 +79,9 @@ func (c *ConfigChecks) CheckConfigEnabled(ctx context.Context) (CheckResult, err
 		Status:          "PASS",
 		Evidence:        "AWS Config is recording configuration changes",
 		ScreenshotGuide: "AWS Config Console → Screenshot showing active configuration recording",
-		ConsoleURL:      "https://console.aws.amazon.com/config/",
+		ConsoleURL:      consoleURL("config/", consoleRegion(ctx)),
 		Priority:        PriorityInfo,
-		Timestamp:       time.Now(),
+		Timestamp:       nowFunc(),
 		Frameworks:      GetFrameworkMappings("CONFIG_ENABLED"),
 	}, nil
 }
@@ -100,9 +99,9 @@ func (c *ConfigChecks) CheckConfigRecording(ctx context.Context) (CheckResult, e
 			Remediation:       "Enable AWS Config in all regions",
 			RemediationDetail: "1. Enable Config in each region\n2. Set to record all resources\n3. Configure S3 bucket for logs\n4. Enable SNS notifications (optional)",
 			ScreenshotGuide:   "AWS Config → Settings → Screenshot showing 'Recording is on' for all resource types",
-			ConsoleURL:        "https://console.aws.amazon.com/config/",
+			ConsoleURL:        consoleURL("config/", consoleRegion(ctx)),
 			Priority:          PriorityHigh,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("CONFIG_ENABLED"),
 		}, nil
 	}
@@ -126,13 +125,13 @@ func (c *ConfigChecks) CheckConfigRecording(ctx context.Context) (CheckResult, e
 			Name:              "AWS Config Recording Status",
 			Status:            "FAIL",
 			Severity:          "HIGH",
-			Evidence:          fmt.Sprintf("AWS Config recorder exists but not recording: %v", notRecording),
+			Evidence:          fmt.Sprintf("AWS Config recorder exists but not recording: %s", TruncateList(notRecording, evidenceListLimit(ctx))),
 			Remediation:       "Start AWS Config recording",
 			RemediationDetail: fmt.Sprintf("aws configservice start-configuration-recorder --configuration-recorder-name %s", notRecording[0]),
 			ScreenshotGuide:   "AWS Config → Dashboard → Screenshot showing 'Recording: On'",
-			ConsoleURL:        "https://console.aws.amazon.com/config/",
+			ConsoleURL:        consoleURL("config/", consoleRegion(ctx)),
 			Priority:          PriorityHigh,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("CONFIG_ENABLED"),
 		}, nil
 	}
@@ -142,8 +141,9 @@ func (c *ConfigChecks) CheckConfigRecording(ctx context.Context) (CheckResult, e
 		Name:       "AWS Config Recording Status",
 		Status:     "PASS",
 		Evidence:   "AWS Config is actively recording configuration changes",
+		ConsoleURL: consoleURL("config/", consoleRegion(ctx)),
 		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
+		Timestamp:  nowFunc(),
 		Frameworks: GetFrameworkMappings("CONFIG_ENABLED"),
 	}, nil
 }
@@ -174,20 +174,21 @@ func (c *GuardDutyChecks) Run(ctx context.Context) ([]CheckResult, error) {
 			Evidence:        "GuardDuty NOT enabled - missing threat detection!",
 			Remediation:     "Enable GuardDuty for automated threat detection",
 			ScreenshotGuide: "1. Go to GuardDuty Console\n2. Click 'Get Started'\n3. Enable GuardDuty\n4. Screenshot showing 'GuardDuty is ENABLED'",
-			ConsoleURL:      "https://console.aws.amazon.com/guardduty/",
+			ConsoleURL:      consoleURL("guardduty/", consoleRegion(ctx)),
 			Priority:        PriorityHigh,
-			Timestamp:       time.Now(),
+			Timestamp:       nowFunc(),
 		})
 	} else {
 		results = append(results, CheckResult{
-			Control:   "CC7.2",
-			Name:      "GuardDuty Threat Detection",
-			Status:    "PASS",
-			Evidence:  fmt.Sprintf("GuardDuty enabled with %d detector(s)", len(detectors.DetectorIds)),
-			Priority:  PriorityInfo,
-			Timestamp: time.Now(),
+			Control:    "CC7.2",
+			Name:       "GuardDuty Threat Detection",
+			Status:     "PASS",
+			Evidence:   fmt.Sprintf("GuardDuty enabled with %d detector(s)", len(detectors.DetectorIds)),
+			ConsoleURL: consoleURL("guardduty/", consoleRegion(ctx)),
+			Priority:   PriorityInfo,
+			Timestamp:  nowFunc(),
 		})
 	}
 
-	return results, nil
+	return withSOC2Mapping(results), nil
 }
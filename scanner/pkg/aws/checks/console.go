@@ -0,0 +1,43 @@
+package checks
+
+import (
+	"context"
+	"strings"
+)
+
+// consoleRegionKey is the context key carrying the scanned region, used to
+// pick the console domain for ConsoleURL deep links
+type consoleRegionKey struct{}
+
+// WithConsoleRegion returns a copy of ctx whose checks point ConsoleURL links
+// at region's partition. Each region's scan carries its own context, so
+// concurrent scans of different regions cannot clobber each other's links.
+func WithConsoleRegion(ctx context.Context, region string) context.Context {
+	return context.WithValue(ctx, consoleRegionKey{}, region)
+}
+
+// consoleRegion returns the region set by WithConsoleRegion, or "" (the
+// commercial partition) when none was set
+func consoleRegion(ctx context.Context) string {
+	region, _ := ctx.Value(consoleRegionKey{}).(string)
+	return region
+}
+
+// ConsoleDomain returns the AWS Management Console host for a region's
+// partition: GovCloud and China regions use their own console domains
+func ConsoleDomain(region string) string {
+	switch {
+	case strings.HasPrefix(region, "us-gov-"):
+		return "console.amazonaws-us-gov.com"
+	case strings.HasPrefix(region, "cn-"):
+		return "console.amazonaws.cn"
+	default:
+		return "console.aws.amazon.com"
+	}
+}
+
+// consoleURL builds a console deep link for service, a path such as
+// "iam/home#/users", in region's partition
+func consoleURL(service, region string) string {
+	return "https://" + ConsoleDomain(region) + "/" + strings.TrimPrefix(service, "/")
+}
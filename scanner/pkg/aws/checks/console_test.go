@@ -0,0 +1,54 @@
+package checks
+
+import (
+	"context"
+	"sync"
+	"testing"
+)
+
+func TestConsoleDomain(t *testing.T) {
+	cases := map[string]string{
+		"us-east-1":      "console.aws.amazon.com",
+		"eu-west-2":      "console.aws.amazon.com",
+		"":               "console.aws.amazon.com",
+		"us-gov-west-1":  "console.amazonaws-us-gov.com",
+		"us-gov-east-1":  "console.amazonaws-us-gov.com",
+		"cn-north-1":     "console.amazonaws.cn",
+		"cn-northwest-1": "console.amazonaws.cn",
+	}
+
+	for region, want := range cases {
+		if got := ConsoleDomain(region); got != want {
+			t.Errorf("ConsoleDomain(%q) = %q, want %q", region, got, want)
+		}
+	}
+}
+
+func TestConsoleRegionFollowsContext(t *testing.T) {
+	if got := consoleRegion(context.Background()); got != "" {
+		t.Errorf("consoleRegion without a region = %q, want empty", got)
+	}
+
+	regions := map[string]string{
+		"us-gov-west-1": "https://console.amazonaws-us-gov.com/iam/",
+		"cn-north-1":    "https://console.amazonaws.cn/iam/",
+		"us-east-1":     "https://console.aws.amazon.com/iam/",
+	}
+
+	// Regions scanned concurrently must each keep their own partition
+	var wg sync.WaitGroup
+	for region, want := range regions {
+		wg.Add(1)
+		go func(region, want string) {
+			defer wg.Done()
+			ctx := WithConsoleRegion(context.Background(), region)
+			for i := 0; i < 100; i++ {
+				if got := consoleURL("iam/", consoleRegion(ctx)); got != want {
+					t.Errorf("%s: consoleURL = %q, want %q", region, got, want)
+					return
+				}
+			}
+		}(region, want)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,370 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/redshift"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"gopkg.in/yaml.v3"
+)
+
+// Resource types supported by custom checks
+const (
+	CustomResourceRedshiftCluster = "redshift_cluster"
+	CustomResourceS3Bucket        = "s3_bucket"
+)
+
+// CustomCheckFile is the top-level layout of a custom checks YAML file:
+//
+//	checks:
+//	  - id: ORG-1
+//	    name: Redshift clusters have an owner tag
+//	    resource: redshift_cluster
+//	    severity: MEDIUM
+//	    condition:
+//	      field: Tags.owner
+//	      exists: true
+type CustomCheckFile struct {
+	Checks []CustomCheckDef `yaml:"checks"`
+}
+
+// CustomCheckDef is one org-specific check evaluated against every resource
+// of the given type. A resource fails when its condition does not hold.
+type CustomCheckDef struct {
+	ID          string            `yaml:"id"`
+	Name        string            `yaml:"name"`
+	Resource    string            `yaml:"resource"`
+	Severity    string            `yaml:"severity"`
+	Condition   CustomCondition   `yaml:"condition"`
+	Remediation string            `yaml:"remediation"`
+	Frameworks  map[string]string `yaml:"frameworks"`
+}
+
+// CustomCondition tests one resource attribute. Exactly one of Exists,
+// Equals or Matches should be set. Tags are addressed as "Tags.<key>".
+type CustomCondition struct {
+	Field   string  `yaml:"field"`
+	Exists  *bool   `yaml:"exists"`
+	Equals  *string `yaml:"equals"`
+	Matches string  `yaml:"matches"`
+
+	pattern *regexp.Regexp
+}
+
+// LoadCustomChecks reads and validates custom check definitions from a YAML file
+func LoadCustomChecks(path string) ([]CustomCheckDef, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read custom checks file: %w", err)
+	}
+
+	var file CustomCheckFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse custom checks YAML: %w", err)
+	}
+
+	for i := range file.Checks {
+		def := &file.Checks[i]
+		if def.ID == "" || def.Name == "" {
+			return nil, fmt.Errorf("custom check #%d: id and name are required", i+1)
+		}
+		if def.Resource != CustomResourceRedshiftCluster && def.Resource != CustomResourceS3Bucket {
+			return nil, fmt.Errorf("custom check %s: unsupported resource type %q (use %s or %s)",
+				def.ID, def.Resource, CustomResourceRedshiftCluster, CustomResourceS3Bucket)
+		}
+		if def.Condition.Field == "" {
+			return nil, fmt.Errorf("custom check %s: condition.field is required", def.ID)
+		}
+
+		set := 0
+		if def.Condition.Exists != nil {
+			set++
+		}
+		if def.Condition.Equals != nil {
+			set++
+		}
+		if def.Condition.Matches != "" {
+			set++
+			pattern, err := regexp.Compile(def.Condition.Matches)
+			if err != nil {
+				return nil, fmt.Errorf("custom check %s: invalid matches pattern: %w", def.ID, err)
+			}
+			def.Condition.pattern = pattern
+		}
+		if set != 1 {
+			return nil, fmt.Errorf("custom check %s: condition needs exactly one of exists, equals or matches", def.ID)
+		}
+
+		if def.Severity == "" {
+			def.Severity = "MEDIUM"
+		}
+		def.Severity = strings.ToUpper(def.Severity)
+	}
+
+	return file.Checks, nil
+}
+
+// Holds evaluates the condition against a resource's attributes
+func (cond CustomCondition) Holds(attrs map[string]string) bool {
+	value, present := attrs[cond.Field]
+
+	switch {
+	case cond.Exists != nil:
+		return present == *cond.Exists
+	case cond.Equals != nil:
+		return present && value == *cond.Equals
+	case cond.pattern != nil:
+		return present && cond.pattern.MatchString(value)
+	}
+	return false
+}
+
+// CustomChecks runs user-defined YAML checks against Redshift and S3
+type CustomChecks struct {
+	defs           []CustomCheckDef
+	redshiftClient *redshift.Client
+	s3Client       *s3.Client
+}
+
+func NewCustomChecks(defs []CustomCheckDef, redshiftClient *redshift.Client, s3Client *s3.Client) *CustomChecks {
+	return &CustomChecks{
+		defs:           defs,
+		redshiftClient: redshiftClient,
+		s3Client:       s3Client,
+	}
+}
+
+func (c *CustomChecks) Name() string {
+	return "Custom Checks"
+}
+
+// customResources is one resource type's listing, shared by every definition
+// on that type
+type customResources struct {
+	attrs map[string]map[string]string
+	// unverified lists resources with an attribute that could not be read,
+	// as "name (error)". They are left out of attrs rather than evaluated
+	// against a partial attribute set.
+	unverified []string
+	// err is set when the resources could not be listed at all
+	err error
+}
+
+func (c *CustomChecks) Run(ctx context.Context) ([]CheckResult, error) {
+	results := []CheckResult{}
+
+	// Resources are listed once per type and shared by every definition
+	resources := map[string]customResources{}
+
+	for _, def := range c.defs {
+		if _, loaded := resources[def.Resource]; !loaded {
+			var listed customResources
+			switch def.Resource {
+			case CustomResourceRedshiftCluster:
+				listed.attrs, listed.err = c.redshiftClusterAttributes(ctx)
+			case CustomResourceS3Bucket:
+				listed.attrs, listed.unverified, listed.err = c.s3BucketAttributes(ctx)
+			}
+			if listed.err != nil && ctx.Err() != nil {
+				return results, ctx.Err()
+			}
+			resources[def.Resource] = listed
+		}
+
+		results = append(results, c.evaluate(ctx, def, resources[def.Resource]))
+	}
+
+	return results, nil
+}
+
+func (c *CustomChecks) evaluate(ctx context.Context, def CustomCheckDef, listed customResources) CheckResult {
+	frameworks := def.Frameworks
+	if len(frameworks) == 0 {
+		frameworks = map[string]string{"CUSTOM": def.ID}
+	}
+
+	if listed.err != nil {
+		return CheckResult{
+			Control:    def.ID,
+			Name:       def.Name,
+			Status:     "ERROR",
+			Evidence:   fmt.Sprintf("Unable to list %s resources for custom check %s: %v", def.Resource, def.ID, listed.err),
+			Priority:   PriorityInfo,
+			Timestamp:  nowFunc(),
+			Frameworks: frameworks,
+		}
+	}
+
+	resources := listed.attrs
+	ids := make([]string, 0, len(resources))
+	for id := range resources {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	failing := []string{}
+	for _, id := range ids {
+		if !def.Condition.Holds(resources[id]) {
+			failing = append(failing, id)
+		}
+	}
+
+	unverifiedNote := ""
+	if len(listed.unverified) > 0 {
+		unverifiedNote = fmt.Sprintf(" | %d %s resources could not be read and were not verified: %s", len(listed.unverified), def.Resource, TruncateList(listed.unverified, evidenceListLimit(ctx)))
+	}
+
+	if len(failing) > 0 {
+		remediation := def.Remediation
+		if remediation == "" {
+			remediation = fmt.Sprintf("Update resources so that %s satisfies the custom check condition", def.Condition.Field)
+		}
+		return CheckResult{
+			Control:           def.ID,
+			Name:              def.Name,
+			Status:            "FAIL",
+			Severity:          def.Severity,
+			Evidence:          fmt.Sprintf("%d of %d %s resources fail custom check %s: %s", len(failing), len(resources), def.Resource, def.ID, TruncateList(failing, evidenceListLimit(ctx))) + unverifiedNote,
+			AffectedResources: failing,
+			Remediation:       remediation,
+			Priority:          priorityForSeverity(def.Severity),
+			Timestamp:         nowFunc(),
+			Frameworks:        frameworks,
+		}
+	}
+
+	if len(resources) == 0 && len(listed.unverified) > 0 {
+		return CheckResult{
+			Control:           def.ID,
+			Name:              def.Name,
+			Status:            "ERROR",
+			Evidence:          fmt.Sprintf("None of the %d %s resources could be read, so custom check %s verified nothing: %s", len(listed.unverified), def.Resource, def.ID, TruncateList(listed.unverified, evidenceListLimit(ctx))),
+			AffectedResources: listed.unverified,
+			Priority:          PriorityInfo,
+			Timestamp:         nowFunc(),
+			Frameworks:        frameworks,
+		}
+	}
+
+	if len(resources) == 0 {
+		return CheckResult{
+			Control:    def.ID,
+			Name:       def.Name,
+			Status:     "PASS",
+			Evidence:   fmt.Sprintf("No %s resources found", def.Resource),
+			Priority:   PriorityInfo,
+			Timestamp:  nowFunc(),
+			Frameworks: frameworks,
+		}
+	}
+
+	return CheckResult{
+		Control:    def.ID,
+		Name:       def.Name,
+		Status:     "PASS",
+		Evidence:   fmt.Sprintf("All %d %s resources satisfy custom check %s", len(resources), def.Resource, def.ID) + unverifiedNote,
+		Priority:   PriorityInfo,
+		Timestamp:  nowFunc(),
+		Frameworks: frameworks,
+	}
+}
+
+// redshiftClusterAttributes returns cluster ID -> flattened attributes
+func (c *CustomChecks) redshiftClusterAttributes(ctx context.Context) (map[string]map[string]string, error) {
+	clusters, err := c.redshiftClient.DescribeClusters(ctx, &redshift.DescribeClustersInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	resources := map[string]map[string]string{}
+	for _, cluster := range clusters.Clusters {
+		id := aws.ToString(cluster.ClusterIdentifier)
+		attrs := map[string]string{
+			"ClusterIdentifier":  id,
+			"NodeType":           aws.ToString(cluster.NodeType),
+			"ClusterVersion":     aws.ToString(cluster.ClusterVersion),
+			"MasterUsername":     aws.ToString(cluster.MasterUsername),
+			"Encrypted":          fmt.Sprintf("%t", aws.ToBool(cluster.Encrypted)),
+			"PubliclyAccessible": fmt.Sprintf("%t", aws.ToBool(cluster.PubliclyAccessible)),
+			"EnhancedVpcRouting": fmt.Sprintf("%t", aws.ToBool(cluster.EnhancedVpcRouting)),
+		}
+		if cluster.KmsKeyId != nil {
+			attrs["KmsKeyId"] = aws.ToString(cluster.KmsKeyId)
+		}
+		if cluster.VpcId != nil {
+			attrs["VpcId"] = aws.ToString(cluster.VpcId)
+		}
+		for _, tag := range cluster.Tags {
+			attrs["Tags."+aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+		}
+		resources[id] = attrs
+	}
+	return resources, nil
+}
+
+// s3BucketAttributes returns bucket name -> flattened attributes, and the
+// buckets whose attributes could not all be read, as "name (error)"
+func (c *CustomChecks) s3BucketAttributes(ctx context.Context) (map[string]map[string]string, []string, error) {
+	buckets, err := c.s3Client.ListBuckets(ctx, &s3.ListBucketsInput{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resources := map[string]map[string]string{}
+	unverified := []string{}
+	for _, bucket := range buckets.Buckets {
+		name := aws.ToString(bucket.Name)
+		attrs := map[string]string{
+			"Name": name,
+		}
+
+		versioning, err := c.s3Client.GetBucketVersioning(ctx, &s3.GetBucketVersioningInput{
+			Bucket: bucket.Name,
+		})
+		if err != nil {
+			unverified = append(unverified, fmt.Sprintf("%s (%v)", name, err))
+			continue
+		}
+		if versioning.Status != "" {
+			attrs["Versioning"] = string(versioning.Status)
+		}
+
+		// Buckets without tags return NoSuchTagSet, which simply means no
+		// Tags.* attributes
+		tagging, err := c.s3Client.GetBucketTagging(ctx, &s3.GetBucketTaggingInput{
+			Bucket: bucket.Name,
+		})
+		switch {
+		case err == nil:
+			for _, tag := range tagging.TagSet {
+				attrs["Tags."+aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+			}
+		case !isNotConfigured(err):
+			unverified = append(unverified, fmt.Sprintf("%s (%v)", name, err))
+			continue
+		}
+
+		resources[name] = attrs
+	}
+	return resources, unverified, nil
+}
+
+// priorityForSeverity maps a severity string to the matching Priority
+func priorityForSeverity(severity string) Priority {
+	switch strings.ToUpper(severity) {
+	case "CRITICAL":
+		return PriorityCritical
+	case "HIGH":
+		return PriorityHigh
+	case "LOW":
+		return PriorityLow
+	default:
+		return PriorityMedium
+	}
+}
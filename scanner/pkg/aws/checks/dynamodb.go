@@ -3,7 +3,6 @@ package checks
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 )
@@ -23,15 +22,15 @@ func (c *DynamoDBChecks) Name() string {
 func (c *DynamoDBChecks) Run(ctx context.Context) ([]CheckResult, error) {
 	results := []CheckResult{}
 
-	if result, err := c.CheckPointInTimeRecovery(ctx); err == nil {
+	if result, err := runCheck(ctx, "dynamodb.point_in_time_recovery", c.CheckPointInTimeRecovery); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckEncryptionAtRest(ctx); err == nil {
+	if result, err := runCheck(ctx, "dynamodb.encryption_at_rest", c.CheckEncryptionAtRest); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckAutoScaling(ctx); err == nil {
+	if result, err := runCheck(ctx, "dynamodb.auto_scaling", c.CheckAutoScaling); err == nil {
 		results = append(results, result)
 	}
 
@@ -47,7 +46,7 @@ func (c *DynamoDBChecks) CheckPointInTimeRecovery(ctx context.Context) (CheckRes
 			Status:     "ERROR",
 			Evidence:   fmt.Sprintf("Failed to list DynamoDB tables: %v", err),
 			Priority:   PriorityLow,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("DYNAMODB_PITR"),
 		}, err
 	}
@@ -59,7 +58,7 @@ func (c *DynamoDBChecks) CheckPointInTimeRecovery(ctx context.Context) (CheckRes
 			Status:     "INFO",
 			Evidence:   "No DynamoDB tables found",
 			Priority:   PriorityLow,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("DYNAMODB_PITR"),
 		}, nil
 	}
@@ -89,12 +88,12 @@ func (c *DynamoDBChecks) CheckPointInTimeRecovery(ctx context.Context) (CheckRes
 			Control:     "CIS-14.1",
 			Name:        "DynamoDB Point-in-Time Recovery",
 			Status:      "FAIL",
-			Evidence:    fmt.Sprintf("%d/%d tables lack PITR: %v", len(without), len(tables.TableNames), without),
+			Evidence:    fmt.Sprintf("%d/%d tables lack PITR: %s", len(without), len(tables.TableNames), TruncateList(without, evidenceListLimit(ctx))),
 			Remediation: "Enable point-in-time recovery for all DynamoDB tables",
 			Severity:    "HIGH",
 			Priority:    PriorityHigh,
-			Timestamp:   time.Now(),
-			ConsoleURL:  "https://console.aws.amazon.com/dynamodbv2/home#tables",
+			Timestamp:   nowFunc(),
+			ConsoleURL:  consoleURL("dynamodbv2/home#tables", consoleRegion(ctx)),
 			Frameworks:  GetFrameworkMappings("DYNAMODB_PITR"),
 		}, nil
 	}
@@ -105,8 +104,8 @@ func (c *DynamoDBChecks) CheckPointInTimeRecovery(ctx context.Context) (CheckRes
 		Status:     "PASS",
 		Evidence:   fmt.Sprintf("All %d tables have PITR enabled", with),
 		Priority:   PriorityLow,
-		Timestamp:  time.Now(),
-		ConsoleURL: "https://console.aws.amazon.com/dynamodbv2/home#tables",
+		Timestamp:  nowFunc(),
+		ConsoleURL: consoleURL("dynamodbv2/home#tables", consoleRegion(ctx)),
 		Frameworks: GetFrameworkMappings("DYNAMODB_PITR"),
 	}, nil
 }
@@ -120,7 +119,7 @@ func (c *DynamoDBChecks) CheckEncryptionAtRest(ctx context.Context) (CheckResult
 			Status:     "ERROR",
 			Evidence:   "Failed to list tables",
 			Priority:   PriorityLow,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("DYNAMODB_ENCRYPTION"),
 		}, err
 	}
@@ -132,7 +131,7 @@ func (c *DynamoDBChecks) CheckEncryptionAtRest(ctx context.Context) (CheckResult
 			Status:     "INFO",
 			Evidence:   "No DynamoDB tables found",
 			Priority:   PriorityLow,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("DYNAMODB_ENCRYPTION"),
 		}, nil
 	}
@@ -162,16 +161,17 @@ func (c *DynamoDBChecks) CheckEncryptionAtRest(ctx context.Context) (CheckResult
 
 	if len(unencrypted) > 0 {
 		return CheckResult{
-			Control:     "CIS-14.2",
-			Name:        "DynamoDB Encryption at Rest",
-			Status:      "FAIL",
-			Evidence:    fmt.Sprintf("%d tables not encrypted: %v", len(unencrypted), unencrypted),
-			Remediation: "Enable encryption at rest for all DynamoDB tables",
-			Severity:    "CRITICAL",
-			Priority:    PriorityCritical,
-			Timestamp:   time.Now(),
-			ConsoleURL:  "https://console.aws.amazon.com/dynamodbv2/home#tables",
-			Frameworks:  GetFrameworkMappings("DYNAMODB_ENCRYPTION"),
+			Control:           "CIS-14.2",
+			Name:              "DynamoDB Encryption at Rest",
+			Status:            "FAIL",
+			Evidence:          fmt.Sprintf("%d tables not encrypted: %s", len(unencrypted), TruncateList(unencrypted, evidenceListLimit(ctx))),
+			AffectedResources: unencrypted,
+			Remediation:       "Enable encryption at rest for all DynamoDB tables",
+			Severity:          "CRITICAL",
+			Priority:          PriorityCritical,
+			Timestamp:         nowFunc(),
+			ConsoleURL:        consoleURL("dynamodbv2/home#tables", consoleRegion(ctx)),
+			Frameworks:        GetFrameworkMappings("DYNAMODB_ENCRYPTION"),
 		}, nil
 	}
 
@@ -181,8 +181,8 @@ func (c *DynamoDBChecks) CheckEncryptionAtRest(ctx context.Context) (CheckResult
 		Status:     "PASS",
 		Evidence:   fmt.Sprintf("All tables encrypted. %d custom KMS, %d AWS managed", customKMS, awsManaged),
 		Priority:   PriorityLow,
-		Timestamp:  time.Now(),
-		ConsoleURL: "https://console.aws.amazon.com/dynamodbv2/home#tables",
+		Timestamp:  nowFunc(),
+		ConsoleURL: consoleURL("dynamodbv2/home#tables", consoleRegion(ctx)),
 		Frameworks: GetFrameworkMappings("DYNAMODB_ENCRYPTION"),
 	}, nil
 }
@@ -196,7 +196,7 @@ func (c *DynamoDBChecks) CheckAutoScaling(ctx context.Context) (CheckResult, err
 			Status:     "ERROR",
 			Evidence:   "Failed to list tables",
 			Priority:   PriorityLow,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("DYNAMODB_AUTOSCALING"),
 		}, err
 	}
@@ -208,7 +208,7 @@ func (c *DynamoDBChecks) CheckAutoScaling(ctx context.Context) (CheckResult, err
 			Status:     "INFO",
 			Evidence:   "No DynamoDB tables found",
 			Priority:   PriorityLow,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("DYNAMODB_AUTOSCALING"),
 		}, nil
 	}
@@ -238,8 +238,8 @@ func (c *DynamoDBChecks) CheckAutoScaling(ctx context.Context) (CheckResult, err
 		Status:     "PASS",
 		Evidence:   fmt.Sprintf("%d tables on-demand (auto-scales), %d provisioned", onDemand, provisioned),
 		Priority:   PriorityLow,
-		Timestamp:  time.Now(),
-		ConsoleURL: "https://console.aws.amazon.com/dynamodbv2/home#tables",
+		Timestamp:  nowFunc(),
+		ConsoleURL: consoleURL("dynamodbv2/home#tables", consoleRegion(ctx)),
 		Frameworks: GetFrameworkMappings("DYNAMODB_AUTOSCALING"),
 	}, nil
 }
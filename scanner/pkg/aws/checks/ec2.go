@@ -27,45 +27,45 @@ func (c *EC2Checks) Run(ctx context.Context) ([]CheckResult, error) {
 	results := []CheckResult{}
 
 	// Existing checks
-	if result, err := c.CheckOpenSecurityGroups(ctx); err == nil {
+	if result, err := runCheck(ctx, "ec2.open_security_groups", c.CheckOpenSecurityGroups); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckUnencryptedVolumes(ctx); err == nil {
+	if result, err := runCheck(ctx, "ec2.unencrypted_volumes", c.CheckUnencryptedVolumes); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckPublicInstances(ctx); err == nil {
+	if result, err := runCheck(ctx, "ec2.public_instances", c.CheckPublicInstances); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckOldAMIs(ctx); err == nil {
+	if result, err := runCheck(ctx, "ec2.old_amis", c.CheckOldAMIs); err == nil {
 		results = append(results, result)
 	}
 
 	// NEW CIS checks
-	if result, err := c.CheckSecurityGroupSSH(ctx); err == nil {
+	if result, err := runCheck(ctx, "ec2.security_group_ssh", c.CheckSecurityGroupSSH); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckSecurityGroupRDP(ctx); err == nil {
+	if result, err := runCheck(ctx, "ec2.security_group_rdp", c.CheckSecurityGroupRDP); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckDefaultSecurityGroup(ctx); err == nil {
+	if result, err := runCheck(ctx, "ec2.default_security_group", c.CheckDefaultSecurityGroup); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckIMDSv2(ctx); err == nil {
+	if result, err := runCheck(ctx, "ec2.imdsv2", c.CheckIMDSv2); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckEBSPublicSnapshots(ctx); err == nil {
+	if result, err := runCheck(ctx, "ec2.ebs_public_snapshots", c.CheckEBSPublicSnapshots); err == nil {
 		results = append(results, result)
 	}
 
 	// Additional CIS AWS controls
-	if result, err := c.CheckInstanceIAMRoles(ctx); err == nil {
+	if result, err := runCheck(ctx, "ec2.instance_iam_roles", c.CheckInstanceIAMRoles); err == nil {
 		results = append(results, result)
 	}
 
@@ -82,7 +82,7 @@ func (c *EC2Checks) CheckOpenSecurityGroups(ctx context.Context) (CheckResult, e
 			Evidence:   "Unable to check security groups",
 			Severity:   "HIGH",
 			Priority:   PriorityHigh,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("OPEN_SECURITY_GROUPS"),
 		}, err
 	}
@@ -144,9 +144,9 @@ func (c *EC2Checks) CheckOpenSecurityGroups(ctx context.Context) (CheckResult, e
 			Remediation:       fmt.Sprintf("Close open ports on SG: %s\nRun: aws ec2 revoke-security-group-ingress", sgID),
 			RemediationDetail: fmt.Sprintf("aws ec2 revoke-security-group-ingress --group-id %s --protocol tcp --port 22 --cidr 0.0.0.0/0", sgID),
 			ScreenshotGuide:   "1. Go to EC2 → Security Groups\n2. Click on the flagged security group\n3. Go to 'Inbound rules' tab\n4. Screenshot showing NO rules with Source '0.0.0.0/0' for ports 22, 3389, or databases\n5. Critical: SSH/RDP must never be open to internet\n6. For PCI DSS: Document business justification for any public access",
-			ConsoleURL:        "https://console.aws.amazon.com/ec2/v2/home#SecurityGroups",
+			ConsoleURL:        consoleURL("ec2/v2/home#SecurityGroups", consoleRegion(ctx)),
 			Priority:          PriorityCritical,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("OPEN_SECURITY_GROUPS"),
 		}, nil
 	}
@@ -158,9 +158,9 @@ func (c *EC2Checks) CheckOpenSecurityGroups(ctx context.Context) (CheckResult, e
 		Evidence:        fmt.Sprintf("All %d security groups properly restrict access | Meets SOC2 CC6.1, PCI DSS 1.2.1, HIPAA 164.312(e)(1)", len(sgs.SecurityGroups)),
 		Severity:        "INFO",
 		ScreenshotGuide: "1. Go to EC2 → Security Groups\n2. Screenshot the list showing your security groups\n3. Click into 2-3 groups and screenshot inbound rules",
-		ConsoleURL:      "https://console.aws.amazon.com/ec2/v2/home#SecurityGroups",
+		ConsoleURL:      consoleURL("ec2/v2/home#SecurityGroups", consoleRegion(ctx)),
 		Priority:        PriorityInfo,
-		Timestamp:       time.Now(),
+		Timestamp:       nowFunc(),
 		Frameworks:      GetFrameworkMappings("OPEN_SECURITY_GROUPS"),
 	}, nil
 }
@@ -201,23 +201,26 @@ func (c *EC2Checks) CheckUnencryptedVolumes(ctx context.Context) (CheckResult, e
 			Evidence:          fmt.Sprintf("%d/%d EBS volumes are NOT encrypted: %s | Violates PCI DSS 3.4 (encrypt stored data) & HIPAA 164.312(a)(2)(iv)", len(unencryptedVolumes), totalVolumes, volList),
 			Remediation:       "Create encrypted snapshots and migrate",
 			RemediationDetail: "1. Create snapshot: aws ec2 create-snapshot --volume-id VOL_ID\n2. Copy with encryption: aws ec2 copy-snapshot --source-snapshot-id SNAP_ID --encrypted\n3. Create new volume from encrypted snapshot",
+			RequiresRecreate:  true,
 			ScreenshotGuide:   "1. Go to EC2 → Volumes\n2. Screenshot the list showing 'Encryption' column\n3. All volumes should show 'Encrypted'\n4. For any unencrypted, document migration plan\n5. For HIPAA: Document encryption algorithm used",
-			ConsoleURL:        "https://console.aws.amazon.com/ec2/v2/home#Volumes",
+			ConsoleURL:        consoleURL("ec2/v2/home#Volumes", consoleRegion(ctx)),
 			Priority:          PriorityHigh,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("EBS_ENCRYPTION"),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "CC6.3",
-		Name:       "EBS Volume Encryption",
-		Status:     "PASS",
-		Evidence:   fmt.Sprintf("All %d EBS volumes are encrypted | Meets SOC2 CC6.3, PCI DSS 3.4, HIPAA 164.312(a)(2)(iv)", totalVolumes),
-		Severity:   "INFO",
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("EBS_ENCRYPTION"),
+		Control:         "CC6.3",
+		Name:            "EBS Volume Encryption",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d EBS volumes are encrypted | Meets SOC2 CC6.3, PCI DSS 3.4, HIPAA 164.312(a)(2)(iv)", totalVolumes),
+		Severity:        "INFO",
+		ScreenshotGuide: "1. Go to EC2 → Volumes\n2. Screenshot the list showing 'Encryption' column\n3. All volumes should show 'Encrypted'\n4. For any unencrypted, document migration plan\n5. For HIPAA: Document encryption algorithm used",
+		ConsoleURL:      consoleURL("ec2/v2/home#Volumes", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("EBS_ENCRYPTION"),
 	}, nil
 }
 
@@ -263,9 +266,9 @@ func (c *EC2Checks) CheckPublicInstances(ctx context.Context) (CheckResult, erro
 			Remediation:       "Move instances to private subnets",
 			RemediationDetail: "Move instances to private subnets and use bastion hosts or VPN for access",
 			ScreenshotGuide:   "1. Go to EC2 → Instances\n2. Screenshot showing instance list\n3. Document why each public instance needs external access\n4. For PCI DSS: Show network segmentation",
-			ConsoleURL:        "https://console.aws.amazon.com/ec2/v2/home#Instances",
+			ConsoleURL:        consoleURL("ec2/v2/home#Instances", consoleRegion(ctx)),
 			Priority:          PriorityMedium,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("PUBLIC_INSTANCES"),
 		}, nil
 	}
@@ -276,8 +279,9 @@ func (c *EC2Checks) CheckPublicInstances(ctx context.Context) (CheckResult, erro
 		Status:     "PASS",
 		Evidence:   fmt.Sprintf("%d/%d instances properly use private IPs | Meets PCI DSS 1.3.1 network segmentation", totalInstances-len(publicInstances), totalInstances),
 		Severity:   "INFO",
+		ConsoleURL: consoleURL("ec2/v2/home#Instances", consoleRegion(ctx)),
 		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
+		Timestamp:  nowFunc(),
 		Frameworks: GetFrameworkMappings("PUBLIC_INSTANCES"),
 	}, nil
 }
@@ -318,20 +322,22 @@ func (c *EC2Checks) CheckOldAMIs(ctx context.Context) (CheckResult, error) {
 			Remediation:       "Create new AMIs with latest patches",
 			RemediationDetail: "Create new AMIs with latest patches and deregister old ones using: aws ec2 deregister-image --image-id AMI_ID",
 			Priority:          PriorityMedium,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("OLD_AMIS"),
 			ScreenshotGuide:   "1. Go to EC2 → AMIs\n2. Screenshot showing AMI creation dates\n3. Document patching schedule for PCI DSS",
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "CC7.2",
-		Name:       "AMI Age and Patching",
-		Status:     "PASS",
-		Evidence:   "All AMIs are recent and likely patched | Meets PCI DSS 6.2 patch management",
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("OLD_AMIS"),
+		Control:         "CC7.2",
+		Name:            "AMI Age and Patching",
+		Status:          "PASS",
+		Evidence:        "All AMIs are recent and likely patched | Meets PCI DSS 6.2 patch management",
+		ScreenshotGuide: "1. Go to EC2 → AMIs\n2. Screenshot showing AMI creation dates\n3. Document patching schedule for PCI DSS",
+		ConsoleURL:      consoleURL("ec2/v2/home#Images:", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("OLD_AMIS"),
 	}, nil
 }
 
@@ -366,13 +372,14 @@ func (c *EC2Checks) CheckSecurityGroupSSH(ctx context.Context) (CheckResult, err
 			Name:              "SSH Access from Internet",
 			Status:            "FAIL",
 			Severity:          "CRITICAL",
-			Evidence:          fmt.Sprintf("%d security groups allow SSH (port 22) from 0.0.0.0/0: %v", len(sshOpenGroups), sshOpenGroups),
+			Evidence:          fmt.Sprintf("%d security groups allow SSH (port 22) from 0.0.0.0/0: %s", len(sshOpenGroups), TruncateList(sshOpenGroups, evidenceListLimit(ctx))),
+			AffectedResources: sshOpenGroups,
 			Remediation:       "Restrict SSH access to specific IP addresses",
 			RemediationDetail: fmt.Sprintf("aws ec2 revoke-security-group-ingress --group-id %s --protocol tcp --port 22 --cidr 0.0.0.0/0", sshOpenGroups[0]),
 			ScreenshotGuide:   "EC2 → Security Groups → Screenshot showing NO rules allowing 0.0.0.0/0 access to port 22",
-			ConsoleURL:        "https://console.aws.amazon.com/ec2/v2/home#SecurityGroups",
+			ConsoleURL:        consoleURL("ec2/v2/home#SecurityGroups", consoleRegion(ctx)),
 			Priority:          PriorityCritical,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("SECURITY_GROUP_UNRESTRICTED"),
 		}, nil
 	}
@@ -382,8 +389,9 @@ func (c *EC2Checks) CheckSecurityGroupSSH(ctx context.Context) (CheckResult, err
 		Name:       "SSH Access from Internet",
 		Status:     "PASS",
 		Evidence:   "No security groups allow unrestricted SSH access",
+		ConsoleURL: consoleURL("ec2/v2/home#SecurityGroups", consoleRegion(ctx)),
 		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
+		Timestamp:  nowFunc(),
 		Frameworks: GetFrameworkMappings("SECURITY_GROUP_UNRESTRICTED"),
 	}, nil
 }
@@ -417,13 +425,14 @@ func (c *EC2Checks) CheckSecurityGroupRDP(ctx context.Context) (CheckResult, err
 			Name:              "RDP Access from Internet",
 			Status:            "FAIL",
 			Severity:          "CRITICAL",
-			Evidence:          fmt.Sprintf("%d security groups allow RDP (port 3389) from 0.0.0.0/0: %v", len(rdpOpenGroups), rdpOpenGroups),
+			Evidence:          fmt.Sprintf("%d security groups allow RDP (port 3389) from 0.0.0.0/0: %s", len(rdpOpenGroups), TruncateList(rdpOpenGroups, evidenceListLimit(ctx))),
+			AffectedResources: rdpOpenGroups,
 			Remediation:       "Restrict RDP access to specific IP addresses",
 			RemediationDetail: fmt.Sprintf("aws ec2 revoke-security-group-ingress --group-id %s --protocol tcp --port 3389 --cidr 0.0.0.0/0", rdpOpenGroups[0]),
 			ScreenshotGuide:   "EC2 → Security Groups → Screenshot showing NO rules allowing 0.0.0.0/0 access to port 3389",
-			ConsoleURL:        "https://console.aws.amazon.com/ec2/v2/home#SecurityGroups",
+			ConsoleURL:        consoleURL("ec2/v2/home#SecurityGroups", consoleRegion(ctx)),
 			Priority:          PriorityCritical,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("SECURITY_GROUP_UNRESTRICTED"),
 		}, nil
 	}
@@ -433,8 +442,9 @@ func (c *EC2Checks) CheckSecurityGroupRDP(ctx context.Context) (CheckResult, err
 		Name:       "RDP Access from Internet",
 		Status:     "PASS",
 		Evidence:   "No security groups allow unrestricted RDP access",
+		ConsoleURL: consoleURL("ec2/v2/home#SecurityGroups", consoleRegion(ctx)),
 		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
+		Timestamp:  nowFunc(),
 		Frameworks: GetFrameworkMappings("SECURITY_GROUP_UNRESTRICTED"),
 	}, nil
 }
@@ -477,25 +487,28 @@ func (c *EC2Checks) CheckDefaultSecurityGroup(ctx context.Context) (CheckResult,
 			Name:              "Default Security Group",
 			Status:            "FAIL",
 			Severity:          "MEDIUM",
-			Evidence:          fmt.Sprintf("%d default security groups allow traffic: %v", len(openDefaultSGs), openDefaultSGs),
+			Evidence:          fmt.Sprintf("%d default security groups allow traffic: %s", len(openDefaultSGs), TruncateList(openDefaultSGs, evidenceListLimit(ctx))),
+			AffectedResources: openDefaultSGs,
 			Remediation:       "Remove all rules from default security groups",
 			RemediationDetail: "1. Don't use default security groups\n2. Remove all inbound/outbound rules from default SGs\n3. Create custom security groups for your resources",
 			ScreenshotGuide:   "EC2 → Security Groups → Default groups → Screenshot showing NO inbound/outbound rules",
-			ConsoleURL:        "https://console.aws.amazon.com/ec2/v2/home#SecurityGroups",
+			ConsoleURL:        consoleURL("ec2/v2/home#SecurityGroups", consoleRegion(ctx)),
 			Priority:          PriorityMedium,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("DEFAULT_VPC"),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "[CIS-5.4]",
-		Name:       "Default Security Group",
-		Status:     "PASS",
-		Evidence:   "All default security groups properly restrict traffic",
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("DEFAULT_VPC"),
+		Control:         "[CIS-5.4]",
+		Name:            "Default Security Group",
+		Status:          "PASS",
+		Evidence:        "All default security groups properly restrict traffic",
+		ScreenshotGuide: "EC2 → Security Groups → Default groups → Screenshot showing NO inbound/outbound rules",
+		ConsoleURL:      consoleURL("ec2/v2/home#SecurityGroups", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("DEFAULT_VPC"),
 	}, nil
 }
 
@@ -529,25 +542,28 @@ func (c *EC2Checks) CheckIMDSv2(ctx context.Context) (CheckResult, error) {
 			Name:              "EC2 IMDSv2",
 			Status:            "FAIL",
 			Severity:          "MEDIUM",
-			Evidence:          fmt.Sprintf("%d EC2 instances not using IMDSv2: %v", len(imdsV1Instances), imdsV1Instances),
+			Evidence:          fmt.Sprintf("%d EC2 instances not using IMDSv2: %s", len(imdsV1Instances), TruncateList(imdsV1Instances, evidenceListLimit(ctx))),
+			AffectedResources: imdsV1Instances,
 			Remediation:       "Require IMDSv2 on all EC2 instances",
 			RemediationDetail: fmt.Sprintf("aws ec2 modify-instance-metadata-options --instance-id %s --http-tokens required --http-endpoint enabled", imdsV1Instances[0]),
 			ScreenshotGuide:   "EC2 → Instances → Instance details → Screenshot showing 'IMDSv2: Required'",
-			ConsoleURL:        "https://console.aws.amazon.com/ec2/v2/home#Instances",
+			ConsoleURL:        consoleURL("ec2/v2/home#Instances", consoleRegion(ctx)),
 			Priority:          PriorityMedium,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("IMDS_V2"),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "[CIS-5.6]",
-		Name:       "EC2 IMDSv2",
-		Status:     "PASS",
-		Evidence:   "All EC2 instances require IMDSv2",
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("IMDS_V2"),
+		Control:         "[CIS-5.6]",
+		Name:            "EC2 IMDSv2",
+		Status:          "PASS",
+		Evidence:        "All EC2 instances require IMDSv2",
+		ScreenshotGuide: "EC2 → Instances → Instance details → Screenshot showing 'IMDSv2: Required'",
+		ConsoleURL:      consoleURL("ec2/v2/home#Instances", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("IMDS_V2"),
 	}, nil
 }
 
@@ -584,13 +600,14 @@ func (c *EC2Checks) CheckEBSPublicSnapshots(ctx context.Context) (CheckResult, e
 			Name:              "EBS Public Snapshots",
 			Status:            "FAIL",
 			Severity:          "CRITICAL",
-			Evidence:          fmt.Sprintf("%d EBS snapshots are publicly accessible: %v", len(publicSnapshots), publicSnapshots),
+			Evidence:          fmt.Sprintf("%d EBS snapshots are publicly accessible: %s", len(publicSnapshots), TruncateList(publicSnapshots, evidenceListLimit(ctx))),
+			AffectedResources: publicSnapshots,
 			Remediation:       "Make snapshots private immediately",
 			RemediationDetail: fmt.Sprintf("aws ec2 modify-snapshot-attribute --snapshot-id %s --create-volume-permission Remove=[{Group=all}]", publicSnapshots[0]),
 			ScreenshotGuide:   "EC2 → Snapshots → Permissions → Screenshot showing NO 'Public' access",
-			ConsoleURL:        "https://console.aws.amazon.com/ec2/v2/home#Snapshots",
+			ConsoleURL:        consoleURL("ec2/v2/home#Snapshots", consoleRegion(ctx)),
 			Priority:          PriorityCritical,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("EBS_PUBLIC_SNAPSHOTS"),
 		}, nil
 	}
@@ -600,8 +617,9 @@ func (c *EC2Checks) CheckEBSPublicSnapshots(ctx context.Context) (CheckResult, e
 		Name:       "EBS Public Snapshots",
 		Status:     "PASS",
 		Evidence:   "No EBS snapshots are publicly accessible",
+		ConsoleURL: consoleURL("ec2/v2/home#Snapshots", consoleRegion(ctx)),
 		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
+		Timestamp:  nowFunc(),
 		Frameworks: GetFrameworkMappings("EBS_PUBLIC_SNAPSHOTS"),
 	}, nil
 }
@@ -617,7 +635,7 @@ func (c *EC2Checks) CheckInstanceIAMRoles(ctx context.Context) (CheckResult, err
 			Evidence:   fmt.Sprintf("Unable to check EC2 instances: %v", err),
 			Severity:   "MEDIUM",
 			Priority:   PriorityMedium,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: map[string]string{"CIS-AWS": "1.18", "SOC2": "CC6.1"},
 		}, nil
 	}
@@ -679,9 +697,9 @@ aws ec2 associate-iam-instance-profile --instance-id %s --iam-instance-profile N
 
 # Remove any embedded credentials from instance after testing role works`, instancesWithoutRoles[0]),
 			ScreenshotGuide:   "EC2 → Instances → Select instance → Security tab → Screenshot showing 'IAM Role' assigned",
-			ConsoleURL:        "https://console.aws.amazon.com/ec2/v2/home#Instances",
+			ConsoleURL:        consoleURL("ec2/v2/home#Instances", consoleRegion(ctx)),
 			Priority:          PriorityMedium,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        map[string]string{"CIS-AWS": "1.18", "SOC2": "CC6.1", "PCI-DSS": "7.1"},
 		}, nil
 	}
@@ -693,19 +711,21 @@ aws ec2 associate-iam-instance-profile --instance-id %s --iam-instance-profile N
 			Status:     "INFO",
 			Evidence:   "No running EC2 instances found",
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: map[string]string{"CIS-AWS": "1.18", "SOC2": "CC6.1"},
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "[CIS-1.18]",
-		Name:       "EC2 Instance IAM Roles",
-		Status:     "PASS",
-		Evidence:   fmt.Sprintf("All %d running EC2 instances use IAM roles | Meets CIS 1.18", totalRunningInstances),
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: map[string]string{"CIS-AWS": "1.18", "SOC2": "CC6.1", "PCI-DSS": "7.1"},
+		Control:         "[CIS-1.18]",
+		Name:            "EC2 Instance IAM Roles",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d running EC2 instances use IAM roles | Meets CIS 1.18", totalRunningInstances),
+		ScreenshotGuide: "EC2 → Instances → Select instance → Security tab → Screenshot showing 'IAM Role' assigned",
+		ConsoleURL:      consoleURL("ec2/v2/home#Instances", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      map[string]string{"CIS-AWS": "1.18", "SOC2": "CC6.1", "PCI-DSS": "7.1"},
 	}, nil
 }
 
@@ -3,7 +3,6 @@ package checks
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/ecr"
 )
@@ -23,15 +22,15 @@ func (c *ECRChecks) Name() string {
 func (c *ECRChecks) Run(ctx context.Context) ([]CheckResult, error) {
 	results := []CheckResult{}
 
-	if result, err := c.CheckImageScanning(ctx); err == nil {
+	if result, err := runCheck(ctx, "ecr.image_scanning", c.CheckImageScanning); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckImmutableTags(ctx); err == nil {
+	if result, err := runCheck(ctx, "ecr.immutable_tags", c.CheckImmutableTags); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckEncryptionAtRest(ctx); err == nil {
+	if result, err := runCheck(ctx, "ecr.encryption_at_rest", c.CheckEncryptionAtRest); err == nil {
 		results = append(results, result)
 	}
 
@@ -47,7 +46,7 @@ func (c *ECRChecks) CheckImageScanning(ctx context.Context) (CheckResult, error)
 			Status:     "ERROR",
 			Evidence:   fmt.Sprintf("Failed to list ECR repositories: %v", err),
 			Priority:   PriorityLow,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("ECR_IMAGE_SCANNING"),
 		}, err
 	}
@@ -59,7 +58,7 @@ func (c *ECRChecks) CheckImageScanning(ctx context.Context) (CheckResult, error)
 			Status:     "INFO",
 			Evidence:   "No ECR repositories found",
 			Priority:   PriorityLow,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("ECR_IMAGE_SCANNING"),
 		}, nil
 	}
@@ -80,12 +79,12 @@ func (c *ECRChecks) CheckImageScanning(ctx context.Context) (CheckResult, error)
 			Control:     "CIS-13.1",
 			Name:        "ECR Image Scanning Enabled",
 			Status:      "FAIL",
-			Evidence:    fmt.Sprintf("%d/%d repositories lack image scanning: %v", len(without), len(repos.Repositories), without),
+			Evidence:    fmt.Sprintf("%d/%d repositories lack image scanning: %s", len(without), len(repos.Repositories), TruncateList(without, evidenceListLimit(ctx))),
 			Remediation: "Enable scan on push for all ECR repositories",
 			Severity:    "HIGH",
 			Priority:    PriorityHigh,
-			Timestamp:   time.Now(),
-			ConsoleURL:  "https://console.aws.amazon.com/ecr/repositories",
+			Timestamp:   nowFunc(),
+			ConsoleURL:  consoleURL("ecr/repositories", consoleRegion(ctx)),
 			Frameworks:  GetFrameworkMappings("ECR_IMAGE_SCANNING"),
 		}, nil
 	}
@@ -96,8 +95,8 @@ func (c *ECRChecks) CheckImageScanning(ctx context.Context) (CheckResult, error)
 		Status:     "PASS",
 		Evidence:   fmt.Sprintf("All %d repositories have image scanning enabled", with),
 		Priority:   PriorityLow,
-		Timestamp:  time.Now(),
-		ConsoleURL: "https://console.aws.amazon.com/ecr/repositories",
+		Timestamp:  nowFunc(),
+		ConsoleURL: consoleURL("ecr/repositories", consoleRegion(ctx)),
 		Frameworks: GetFrameworkMappings("ECR_IMAGE_SCANNING"),
 	}, nil
 }
@@ -111,7 +110,7 @@ func (c *ECRChecks) CheckImmutableTags(ctx context.Context) (CheckResult, error)
 			Status:     "ERROR",
 			Evidence:   "Failed to list repositories",
 			Priority:   PriorityLow,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("ECR_IMMUTABLE_TAGS"),
 		}, err
 	}
@@ -123,7 +122,7 @@ func (c *ECRChecks) CheckImmutableTags(ctx context.Context) (CheckResult, error)
 			Status:     "INFO",
 			Evidence:   "No ECR repositories found",
 			Priority:   PriorityLow,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("ECR_IMMUTABLE_TAGS"),
 		}, nil
 	}
@@ -144,12 +143,12 @@ func (c *ECRChecks) CheckImmutableTags(ctx context.Context) (CheckResult, error)
 			Control:     "CIS-13.2",
 			Name:        "ECR Immutable Tags",
 			Status:      "FAIL",
-			Evidence:    fmt.Sprintf("%d/%d repositories allow mutable tags: %v", len(without), len(repos.Repositories), without),
+			Evidence:    fmt.Sprintf("%d/%d repositories allow mutable tags: %s", len(without), len(repos.Repositories), TruncateList(without, evidenceListLimit(ctx))),
 			Remediation: "Enable tag immutability to prevent tag overwriting",
 			Severity:    "MEDIUM",
 			Priority:    PriorityMedium,
-			Timestamp:   time.Now(),
-			ConsoleURL:  "https://console.aws.amazon.com/ecr/repositories",
+			Timestamp:   nowFunc(),
+			ConsoleURL:  consoleURL("ecr/repositories", consoleRegion(ctx)),
 			Frameworks:  GetFrameworkMappings("ECR_IMMUTABLE_TAGS"),
 		}, nil
 	}
@@ -160,8 +159,8 @@ func (c *ECRChecks) CheckImmutableTags(ctx context.Context) (CheckResult, error)
 		Status:     "PASS",
 		Evidence:   fmt.Sprintf("All %d repositories have immutable tags enabled", with),
 		Priority:   PriorityLow,
-		Timestamp:  time.Now(),
-		ConsoleURL: "https://console.aws.amazon.com/ecr/repositories",
+		Timestamp:  nowFunc(),
+		ConsoleURL: consoleURL("ecr/repositories", consoleRegion(ctx)),
 		Frameworks: GetFrameworkMappings("ECR_IMMUTABLE_TAGS"),
 	}, nil
 }
@@ -175,7 +174,7 @@ func (c *ECRChecks) CheckEncryptionAtRest(ctx context.Context) (CheckResult, err
 			Status:     "ERROR",
 			Evidence:   "Failed to list repositories",
 			Priority:   PriorityLow,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("ECR_ENCRYPTION"),
 		}, err
 	}
@@ -187,7 +186,7 @@ func (c *ECRChecks) CheckEncryptionAtRest(ctx context.Context) (CheckResult, err
 			Status:     "INFO",
 			Evidence:   "No ECR repositories found",
 			Priority:   PriorityLow,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("ECR_ENCRYPTION"),
 		}, nil
 	}
@@ -210,8 +209,8 @@ func (c *ECRChecks) CheckEncryptionAtRest(ctx context.Context) (CheckResult, err
 		Status:     "PASS",
 		Evidence:   fmt.Sprintf("All repositories encrypted. %d with custom KMS, %d with AWS managed", customKMS, awsManaged),
 		Priority:   PriorityLow,
-		Timestamp:  time.Now(),
-		ConsoleURL: "https://console.aws.amazon.com/ecr/repositories",
+		Timestamp:  nowFunc(),
+		ConsoleURL: consoleURL("ecr/repositories", consoleRegion(ctx)),
 		Frameworks: GetFrameworkMappings("ECR_ENCRYPTION"),
 	}, nil
 }
@@ -3,7 +3,6 @@ package checks
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ecs"
@@ -22,23 +21,31 @@ func (c *ECSChecks) Name() string {
 	return "ECS Security Configuration"
 }
 
+// describeTaskDefinition returns the task definition's DescribeTaskDefinition
+// output, fetched once per scan for all the task definition checks
+func (c *ECSChecks) describeTaskDefinition(ctx context.Context, arn string) (*ecs.DescribeTaskDefinitionOutput, error) {
+	return memoize(ctx, arn, func() (*ecs.DescribeTaskDefinitionOutput, error) {
+		return c.client.DescribeTaskDefinition(ctx, &ecs.DescribeTaskDefinitionInput{TaskDefinition: &arn})
+	})
+}
+
 func (c *ECSChecks) Run(ctx context.Context) ([]CheckResult, error) {
 	results := []CheckResult{}
 
 	// CIS Section 7 - ECS controls
-	if result, err := c.CheckECSTaskDefinitionLogging(ctx); err == nil {
+	if result, err := runCheck(ctx, "ecs.task_definition_logging", c.CheckECSTaskDefinitionLogging); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckECSSecretsManagement(ctx); err == nil {
+	if result, err := runCheck(ctx, "ecs.secrets_management", c.CheckECSSecretsManagement); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckECSContainerInsights(ctx); err == nil {
+	if result, err := runCheck(ctx, "ecs.container_insights", c.CheckECSContainerInsights); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckECSTaskRolePermissions(ctx); err == nil {
+	if result, err := runCheck(ctx, "ecs.task_role_permissions", c.CheckECSTaskRolePermissions); err == nil {
 		results = append(results, result)
 	}
 
@@ -67,9 +74,7 @@ func (c *ECSChecks) CheckECSTaskDefinitionLogging(ctx context.Context) (CheckRes
 		}
 
 		taskDefArn := taskDefs.TaskDefinitionArns[0]
-		taskDef, err := c.client.DescribeTaskDefinition(ctx, &ecs.DescribeTaskDefinitionInput{
-			TaskDefinition: &taskDefArn,
-		})
+		taskDef, err := c.describeTaskDefinition(ctx, taskDefArn)
 		if err != nil {
 			continue
 		}
@@ -114,9 +119,9 @@ func (c *ECSChecks) CheckECSTaskDefinitionLogging(ctx context.Context) (CheckRes
   }
 }`,
 			ScreenshotGuide:   "ECS Console → Task Definitions → Container definition → Storage and Logging → Screenshot showing logging configured",
-			ConsoleURL:        "https://console.aws.amazon.com/ecs/home#/taskDefinitions",
+			ConsoleURL:        consoleURL("ecs/home#/taskDefinitions", consoleRegion(ctx)),
 			Priority:          PriorityHigh,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        map[string]string{"CIS-AWS": "7.1", "SOC2": "CC7.2", "PCI-DSS": "10.2"},
 		}, nil
 	}
@@ -127,20 +132,23 @@ func (c *ECSChecks) CheckECSTaskDefinitionLogging(ctx context.Context) (CheckRes
 			Name:       "ECS Task Definition Logging",
 			Status:     "PASS",
 			Evidence:   "No ECS task definitions found | CIS 7.1 N/A",
+			ConsoleURL: consoleURL("ecs/home#/taskDefinitions", consoleRegion(ctx)),
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: map[string]string{"CIS-AWS": "7.1"},
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "[CIS-7.1]",
-		Name:       "ECS Task Definition Logging",
-		Status:     "PASS",
-		Evidence:   fmt.Sprintf("All %d ECS task definitions have logging enabled | Meets CIS 7.1", totalTasks),
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: map[string]string{"CIS-AWS": "7.1"},
+		Control:         "[CIS-7.1]",
+		Name:            "ECS Task Definition Logging",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d ECS task definitions have logging enabled | Meets CIS 7.1", totalTasks),
+		ScreenshotGuide: "ECS Console → Task Definitions → Container definition → Storage and Logging → Screenshot showing logging configured",
+		ConsoleURL:      consoleURL("ecs/home#/taskDefinitions", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      map[string]string{"CIS-AWS": "7.1"},
 	}, nil
 }
 
@@ -164,9 +172,7 @@ func (c *ECSChecks) CheckECSSecretsManagement(ctx context.Context) (CheckResult,
 		}
 
 		taskDefArn := taskDefs.TaskDefinitionArns[0]
-		taskDef, err := c.client.DescribeTaskDefinition(ctx, &ecs.DescribeTaskDefinitionInput{
-			TaskDefinition: &taskDefArn,
-		})
+		taskDef, err := c.describeTaskDefinition(ctx, taskDefArn)
 		if err != nil {
 			continue
 		}
@@ -212,9 +218,9 @@ func (c *ECSChecks) CheckECSSecretsManagement(ctx context.Context) (CheckResult,
   "valueFrom": "arn:aws:secretsmanager:region:account:secret:db-password"
 }]`,
 			ScreenshotGuide:   "ECS Console → Task Definitions → Environment → Screenshot showing secrets from Secrets Manager",
-			ConsoleURL:        "https://console.aws.amazon.com/ecs/home#/taskDefinitions",
+			ConsoleURL:        consoleURL("ecs/home#/taskDefinitions", consoleRegion(ctx)),
 			Priority:          PriorityCritical,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        map[string]string{"CIS-AWS": "7.2", "SOC2": "CC6.1", "PCI-DSS": "3.4"},
 		}, nil
 	}
@@ -225,20 +231,23 @@ func (c *ECSChecks) CheckECSSecretsManagement(ctx context.Context) (CheckResult,
 			Name:       "ECS Secrets Management",
 			Status:     "PASS",
 			Evidence:   "No ECS task definitions found | CIS 7.2 N/A",
+			ConsoleURL: consoleURL("ecs/home#/taskDefinitions", consoleRegion(ctx)),
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: map[string]string{"CIS-AWS": "7.2"},
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "[CIS-7.2]",
-		Name:       "ECS Secrets Management",
-		Status:     "PASS",
-		Evidence:   "ECS tasks use Secrets Manager for sensitive data | Meets CIS 7.2",
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: map[string]string{"CIS-AWS": "7.2"},
+		Control:         "[CIS-7.2]",
+		Name:            "ECS Secrets Management",
+		Status:          "PASS",
+		Evidence:        "ECS tasks use Secrets Manager for sensitive data | Meets CIS 7.2",
+		ScreenshotGuide: "ECS Console → Task Definitions → Environment → Screenshot showing secrets from Secrets Manager",
+		ConsoleURL:      consoleURL("ecs/home#/taskDefinitions", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      map[string]string{"CIS-AWS": "7.2"},
 	}, nil
 }
 
@@ -255,8 +264,9 @@ func (c *ECSChecks) CheckECSContainerInsights(ctx context.Context) (CheckResult,
 			Name:       "ECS Container Insights",
 			Status:     "PASS",
 			Evidence:   "No ECS clusters found | CIS 7.3 N/A",
+			ConsoleURL: consoleURL("ecs/home#/clusters", consoleRegion(ctx)),
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: map[string]string{"CIS-AWS": "7.3"},
 		}, nil
 	}
@@ -293,27 +303,29 @@ func (c *ECSChecks) CheckECSContainerInsights(ctx context.Context) (CheckResult,
 			Name:              "ECS Container Insights",
 			Status:            "FAIL",
 			Severity:          "MEDIUM",
-			Evidence:          fmt.Sprintf("%d/%d ECS clusters without Container Insights: %v | CIS 7.3", len(clustersWithoutInsights), len(clustersOutput.Clusters), clustersWithoutInsights),
+			Evidence:          fmt.Sprintf("%d/%d ECS clusters without Container Insights: %s | CIS 7.3", len(clustersWithoutInsights), len(clustersOutput.Clusters), TruncateList(clustersWithoutInsights, evidenceListLimit(ctx))),
 			Remediation:       "Enable Container Insights for ECS clusters",
 			RemediationDetail: `aws ecs update-cluster-settings \
   --cluster CLUSTER_NAME \
   --settings name=containerInsights,value=enabled`,
 			ScreenshotGuide:   "ECS Console → Clusters → Update Cluster → CloudWatch Container Insights → Screenshot showing enabled",
-			ConsoleURL:        "https://console.aws.amazon.com/ecs/home#/clusters",
+			ConsoleURL:        consoleURL("ecs/home#/clusters", consoleRegion(ctx)),
 			Priority:          PriorityMedium,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        map[string]string{"CIS-AWS": "7.3", "SOC2": "CC7.2"},
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "[CIS-7.3]",
-		Name:       "ECS Container Insights",
-		Status:     "PASS",
-		Evidence:   fmt.Sprintf("All %d ECS clusters have Container Insights enabled | Meets CIS 7.3", len(clustersOutput.Clusters)),
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: map[string]string{"CIS-AWS": "7.3"},
+		Control:         "[CIS-7.3]",
+		Name:            "ECS Container Insights",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d ECS clusters have Container Insights enabled | Meets CIS 7.3", len(clustersOutput.Clusters)),
+		ScreenshotGuide: "ECS Console → Clusters → Update Cluster → CloudWatch Container Insights → Screenshot showing enabled",
+		ConsoleURL:      consoleURL("ecs/home#/clusters", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      map[string]string{"CIS-AWS": "7.3"},
 	}, nil
 }
 
@@ -337,9 +349,7 @@ func (c *ECSChecks) CheckECSTaskRolePermissions(ctx context.Context) (CheckResul
 		}
 
 		taskDefArn := taskDefs.TaskDefinitionArns[0]
-		taskDef, err := c.client.DescribeTaskDefinition(ctx, &ecs.DescribeTaskDefinitionInput{
-			TaskDefinition: &taskDefArn,
-		})
+		taskDef, err := c.describeTaskDefinition(ctx, taskDefArn)
 		if err != nil {
 			continue
 		}
@@ -372,9 +382,9 @@ func (c *ECSChecks) CheckECSTaskRolePermissions(ctx context.Context) (CheckResul
 aws iam create-role --role-name ECSTaskRole --assume-role-policy-document file://ecs-trust-policy.json
 aws iam put-role-policy --role-name ECSTaskRole --policy-name TaskPolicy --policy-document file://task-policy.json`,
 			ScreenshotGuide:   "ECS Console → Task Definitions → Task role → Screenshot showing least-privilege policy",
-			ConsoleURL:        "https://console.aws.amazon.com/ecs/home#/taskDefinitions",
+			ConsoleURL:        consoleURL("ecs/home#/taskDefinitions", consoleRegion(ctx)),
 			Priority:          PriorityHigh,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        map[string]string{"CIS-AWS": "7.4", "SOC2": "CC6.3", "PCI-DSS": "7.1.2"},
 		}, nil
 	}
@@ -385,19 +395,22 @@ aws iam put-role-policy --role-name ECSTaskRole --policy-name TaskPolicy --polic
 			Name:       "ECS Task Role Permissions",
 			Status:     "PASS",
 			Evidence:   "No ECS task definitions found | CIS 7.4 N/A",
+			ConsoleURL: consoleURL("ecs/home#/taskDefinitions", consoleRegion(ctx)),
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: map[string]string{"CIS-AWS": "7.4"},
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "[CIS-7.4]",
-		Name:       "ECS Task Role Permissions",
-		Status:     "PASS",
-		Evidence:   "ECS tasks use least-privilege roles | Meets CIS 7.4",
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: map[string]string{"CIS-AWS": "7.4"},
+		Control:         "[CIS-7.4]",
+		Name:            "ECS Task Role Permissions",
+		Status:          "PASS",
+		Evidence:        "ECS tasks use least-privilege roles | Meets CIS 7.4",
+		ScreenshotGuide: "ECS Console → Task Definitions → Task role → Screenshot showing least-privilege policy",
+		ConsoleURL:      consoleURL("ecs/home#/taskDefinitions", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      map[string]string{"CIS-AWS": "7.4"},
 	}, nil
 }
@@ -3,7 +3,6 @@ package checks
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/eks"
 )
@@ -20,39 +19,48 @@ func (c *EKSChecks) Name() string {
 	return "EKS Security Configuration"
 }
 
+// describeCluster returns the cluster's DescribeCluster output. Every EKS
+// check needs it, so it is fetched once per scan.
+func (c *EKSChecks) describeCluster(ctx context.Context, name string) (*eks.DescribeClusterOutput, error) {
+	key := fmt.Sprintf("eks:%s:cluster/%s", c.client.Options().Region, name)
+	return memoize(ctx, key, func() (*eks.DescribeClusterOutput, error) {
+		return c.client.DescribeCluster(ctx, &eks.DescribeClusterInput{Name: &name})
+	})
+}
+
 func (c *EKSChecks) Run(ctx context.Context) ([]CheckResult, error) {
 	results := []CheckResult{}
 
 	// CIS Section 8 - EKS controls
-	if result, err := c.CheckEKSEndpointAccess(ctx); err == nil {
+	if result, err := runCheck(ctx, "eks.endpoint_access", c.CheckEKSEndpointAccess); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckEKSLogging(ctx); err == nil {
+	if result, err := runCheck(ctx, "eks.logging", c.CheckEKSLogging); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckEKSEncryption(ctx); err == nil {
+	if result, err := runCheck(ctx, "eks.encryption", c.CheckEKSEncryption); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckEKSNetworkPolicy(ctx); err == nil {
+	if result, err := runCheck(ctx, "eks.network_policy", c.CheckEKSNetworkPolicy); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckEKSPodSecurityPolicy(ctx); err == nil {
+	if result, err := runCheck(ctx, "eks.pod_security_policy", c.CheckEKSPodSecurityPolicy); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckEKSRBAC(ctx); err == nil {
+	if result, err := runCheck(ctx, "eks.rbac", c.CheckEKSRBAC); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckEKSSecretsEncryption(ctx); err == nil {
+	if result, err := runCheck(ctx, "eks.secrets_encryption", c.CheckEKSSecretsEncryption); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckEKSAuditLogging(ctx); err == nil {
+	if result, err := runCheck(ctx, "eks.audit_logging", c.CheckEKSAuditLogging); err == nil {
 		results = append(results, result)
 	}
 
@@ -72,8 +80,9 @@ func (c *EKSChecks) CheckEKSEndpointAccess(ctx context.Context) (CheckResult, er
 			Name:       "EKS Cluster Endpoint Access",
 			Status:     "PASS",
 			Evidence:   "No EKS clusters found | CIS 8.1 N/A",
+			ConsoleURL: consoleURL("eks/home#/clusters", consoleRegion(ctx)),
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: map[string]string{"CIS-AWS": "8.1"},
 		}, nil
 	}
@@ -81,9 +90,7 @@ func (c *EKSChecks) CheckEKSEndpointAccess(ctx context.Context) (CheckResult, er
 	clustersWithPublicAccess := []string{}
 
 	for _, clusterName := range clusters.Clusters {
-		cluster, err := c.client.DescribeCluster(ctx, &eks.DescribeClusterInput{
-			Name: &clusterName,
-		})
+		cluster, err := c.describeCluster(ctx, clusterName)
 		if err != nil {
 			continue
 		}
@@ -124,21 +131,23 @@ aws eks update-cluster-config \
   --name CLUSTER_NAME \
   --resources-vpc-config endpointPublicAccess=true,publicAccessCidrs="10.0.0.0/8,192.168.0.0/16"`,
 			ScreenshotGuide:   "EKS Console → Clusters → Networking → Screenshot showing restricted endpoint access",
-			ConsoleURL:        "https://console.aws.amazon.com/eks/home#/clusters",
+			ConsoleURL:        consoleURL("eks/home#/clusters", consoleRegion(ctx)),
 			Priority:          PriorityHigh,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        map[string]string{"CIS-AWS": "8.1", "SOC2": "CC6.6", "PCI-DSS": "1.2.1"},
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "[CIS-8.1]",
-		Name:       "EKS Cluster Endpoint Access",
-		Status:     "PASS",
-		Evidence:   fmt.Sprintf("All %d EKS clusters have restricted endpoint access | Meets CIS 8.1", len(clusters.Clusters)),
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: map[string]string{"CIS-AWS": "8.1"},
+		Control:         "[CIS-8.1]",
+		Name:            "EKS Cluster Endpoint Access",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d EKS clusters have restricted endpoint access | Meets CIS 8.1", len(clusters.Clusters)),
+		ScreenshotGuide: "EKS Console → Clusters → Networking → Screenshot showing restricted endpoint access",
+		ConsoleURL:      consoleURL("eks/home#/clusters", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      map[string]string{"CIS-AWS": "8.1"},
 	}, nil
 }
 
@@ -155,8 +164,9 @@ func (c *EKSChecks) CheckEKSLogging(ctx context.Context) (CheckResult, error) {
 			Name:       "EKS Cluster Logging",
 			Status:     "PASS",
 			Evidence:   "No EKS clusters found | CIS 8.2 N/A",
+			ConsoleURL: consoleURL("eks/home#/clusters", consoleRegion(ctx)),
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: map[string]string{"CIS-AWS": "8.2"},
 		}, nil
 	}
@@ -165,9 +175,7 @@ func (c *EKSChecks) CheckEKSLogging(ctx context.Context) (CheckResult, error) {
 	requiredLogTypes := []string{"api", "audit", "authenticator", "controllerManager", "scheduler"}
 
 	for _, clusterName := range clusters.Clusters {
-		cluster, err := c.client.DescribeCluster(ctx, &eks.DescribeClusterInput{
-			Name: &clusterName,
-		})
+		cluster, err := c.describeCluster(ctx, clusterName)
 		if err != nil {
 			continue
 		}
@@ -215,21 +223,23 @@ func (c *EKSChecks) CheckEKSLogging(ctx context.Context) (CheckResult, error) {
   --name CLUSTER_NAME \
   --logging '{"clusterLogging":[{"types":["api","audit","authenticator","controllerManager","scheduler"],"enabled":true}]}'`,
 			ScreenshotGuide:   "EKS Console → Clusters → Logging → Screenshot showing all 5 log types enabled",
-			ConsoleURL:        "https://console.aws.amazon.com/eks/home#/clusters",
+			ConsoleURL:        consoleURL("eks/home#/clusters", consoleRegion(ctx)),
 			Priority:          PriorityHigh,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        map[string]string{"CIS-AWS": "8.2", "SOC2": "CC7.2", "PCI-DSS": "10.2.2"},
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "[CIS-8.2]",
-		Name:       "EKS Cluster Logging",
-		Status:     "PASS",
-		Evidence:   fmt.Sprintf("All %d EKS clusters have complete logging enabled | Meets CIS 8.2", len(clusters.Clusters)),
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: map[string]string{"CIS-AWS": "8.2"},
+		Control:         "[CIS-8.2]",
+		Name:            "EKS Cluster Logging",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d EKS clusters have complete logging enabled | Meets CIS 8.2", len(clusters.Clusters)),
+		ScreenshotGuide: "EKS Console → Clusters → Logging → Screenshot showing all 5 log types enabled",
+		ConsoleURL:      consoleURL("eks/home#/clusters", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      map[string]string{"CIS-AWS": "8.2"},
 	}, nil
 }
 
@@ -246,8 +256,9 @@ func (c *EKSChecks) CheckEKSEncryption(ctx context.Context) (CheckResult, error)
 			Name:       "EKS Cluster Encryption",
 			Status:     "PASS",
 			Evidence:   "No EKS clusters found | CIS 8.3 N/A",
+			ConsoleURL: consoleURL("eks/home#/clusters", consoleRegion(ctx)),
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: map[string]string{"CIS-AWS": "8.3"},
 		}, nil
 	}
@@ -255,9 +266,7 @@ func (c *EKSChecks) CheckEKSEncryption(ctx context.Context) (CheckResult, error)
 	clustersWithoutEncryption := []string{}
 
 	for _, clusterName := range clusters.Clusters {
-		cluster, err := c.client.DescribeCluster(ctx, &eks.DescribeClusterInput{
-			Name: &clusterName,
-		})
+		cluster, err := c.describeCluster(ctx, clusterName)
 		if err != nil {
 			continue
 		}
@@ -293,21 +302,23 @@ aws eks create-cluster \
 
 # For existing clusters, you must create a new cluster with encryption enabled and migrate workloads`,
 			ScreenshotGuide:   "EKS Console → Clusters → Configuration → Secrets encryption → Screenshot showing KMS key",
-			ConsoleURL:        "https://console.aws.amazon.com/eks/home#/clusters",
+			ConsoleURL:        consoleURL("eks/home#/clusters", consoleRegion(ctx)),
 			Priority:          PriorityCritical,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        map[string]string{"CIS-AWS": "8.3", "SOC2": "CC6.7", "PCI-DSS": "3.4"},
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "[CIS-8.3]",
-		Name:       "EKS Cluster Encryption",
-		Status:     "PASS",
-		Evidence:   fmt.Sprintf("All %d EKS clusters have encryption enabled | Meets CIS 8.3", len(clusters.Clusters)),
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: map[string]string{"CIS-AWS": "8.3"},
+		Control:         "[CIS-8.3]",
+		Name:            "EKS Cluster Encryption",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d EKS clusters have encryption enabled | Meets CIS 8.3", len(clusters.Clusters)),
+		ScreenshotGuide: "EKS Console → Clusters → Configuration → Secrets encryption → Screenshot showing KMS key",
+		ConsoleURL:      consoleURL("eks/home#/clusters", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      map[string]string{"CIS-AWS": "8.3"},
 	}, nil
 }
 
@@ -324,8 +335,9 @@ func (c *EKSChecks) CheckEKSNetworkPolicy(ctx context.Context) (CheckResult, err
 			Name:       "EKS Network Policy",
 			Status:     "PASS",
 			Evidence:   "No EKS clusters found | CIS 8.4 N/A",
+			ConsoleURL: consoleURL("eks/home#/clusters", consoleRegion(ctx)),
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: map[string]string{"CIS-AWS": "8.4"},
 		}, nil
 	}
@@ -345,9 +357,9 @@ kubectl apply -f https://raw.githubusercontent.com/aws/amazon-vpc-cni-k8s/master
 
 # Then create NetworkPolicy resources for your namespaces`,
 		ScreenshotGuide:   "kubectl get networkpolicies --all-namespaces → Screenshot showing network policies",
-		ConsoleURL:        "https://console.aws.amazon.com/eks/home#/clusters",
+		ConsoleURL:        consoleURL("eks/home#/clusters", consoleRegion(ctx)),
 		Priority:          PriorityMedium,
-		Timestamp:         time.Now(),
+		Timestamp:         nowFunc(),
 		Frameworks:        map[string]string{"CIS-AWS": "8.4", "SOC2": "CC6.6"},
 	}, nil
 }
@@ -365,8 +377,9 @@ func (c *EKSChecks) CheckEKSPodSecurityPolicy(ctx context.Context) (CheckResult,
 			Name:       "EKS Pod Security Policy",
 			Status:     "PASS",
 			Evidence:   "No EKS clusters found | CIS 8.5 N/A",
+			ConsoleURL: consoleURL("eks/home#/clusters", consoleRegion(ctx)),
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: map[string]string{"CIS-AWS": "8.5"},
 		}, nil
 	}
@@ -389,9 +402,9 @@ kubectl label namespace default pod-security.kubernetes.io/warn=restricted
 # Verify:
 kubectl get namespace default -o yaml | grep pod-security`,
 		ScreenshotGuide:   "kubectl get psp → Screenshot showing pod security policies OR namespace labels for PSS",
-		ConsoleURL:        "https://console.aws.amazon.com/eks/home#/clusters",
+		ConsoleURL:        consoleURL("eks/home#/clusters", consoleRegion(ctx)),
 		Priority:          PriorityHigh,
-		Timestamp:         time.Now(),
+		Timestamp:         nowFunc(),
 		Frameworks:        map[string]string{"CIS-AWS": "8.5", "SOC2": "CC8.1", "PCI-DSS": "2.2"},
 	}, nil
 }
@@ -409,8 +422,9 @@ func (c *EKSChecks) CheckEKSRBAC(ctx context.Context) (CheckResult, error) {
 			Name:       "EKS RBAC Configuration",
 			Status:     "PASS",
 			Evidence:   "No EKS clusters found | CIS 8.6 N/A",
+			ConsoleURL: consoleURL("eks/home#/clusters", consoleRegion(ctx)),
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: map[string]string{"CIS-AWS": "8.6"},
 		}, nil
 	}
@@ -434,9 +448,9 @@ kubectl get clusterrolebindings -o json | jq '.items[] | select(.roleRef.name ==
 # Remove unnecessary admin permissions:
 kubectl delete clusterrolebinding NAME`,
 		ScreenshotGuide:   "kubectl get clusterrolebindings → Screenshot showing no unnecessary admin bindings",
-		ConsoleURL:        "https://console.aws.amazon.com/eks/home#/clusters",
+		ConsoleURL:        consoleURL("eks/home#/clusters", consoleRegion(ctx)),
 		Priority:          PriorityHigh,
-		Timestamp:         time.Now(),
+		Timestamp:         nowFunc(),
 		Frameworks:        map[string]string{"CIS-AWS": "8.6", "SOC2": "CC6.3", "PCI-DSS": "7.1.2"},
 	}, nil
 }
@@ -461,8 +475,9 @@ func (c *EKSChecks) CheckEKSAuditLogging(ctx context.Context) (CheckResult, erro
 			Name:       "EKS Audit Logging",
 			Status:     "PASS",
 			Evidence:   "No EKS clusters found | CIS 8.8 N/A",
+			ConsoleURL: consoleURL("eks/home#/clusters", consoleRegion(ctx)),
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: map[string]string{"CIS-AWS": "8.8"},
 		}, nil
 	}
@@ -470,9 +485,7 @@ func (c *EKSChecks) CheckEKSAuditLogging(ctx context.Context) (CheckResult, erro
 	clustersWithoutAuditLog := []string{}
 
 	for _, clusterName := range clusters.Clusters {
-		cluster, err := c.client.DescribeCluster(ctx, &eks.DescribeClusterInput{
-			Name: &clusterName,
-		})
+		cluster, err := c.describeCluster(ctx, clusterName)
 		if err != nil {
 			continue
 		}
@@ -516,20 +529,22 @@ func (c *EKSChecks) CheckEKSAuditLogging(ctx context.Context) (CheckResult, erro
   --name CLUSTER_NAME \
   --logging '{"clusterLogging":[{"types":["audit"],"enabled":true}]}'`,
 			ScreenshotGuide:   "EKS Console → Clusters → Logging → Screenshot showing audit log type enabled",
-			ConsoleURL:        "https://console.aws.amazon.com/eks/home#/clusters",
+			ConsoleURL:        consoleURL("eks/home#/clusters", consoleRegion(ctx)),
 			Priority:          PriorityHigh,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        map[string]string{"CIS-AWS": "8.8", "SOC2": "CC7.2", "PCI-DSS": "10.2"},
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "[CIS-8.8]",
-		Name:       "EKS Audit Logging",
-		Status:     "PASS",
-		Evidence:   fmt.Sprintf("All %d EKS clusters have audit logging enabled | Meets CIS 8.8", len(clusters.Clusters)),
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: map[string]string{"CIS-AWS": "8.8"},
+		Control:         "[CIS-8.8]",
+		Name:            "EKS Audit Logging",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d EKS clusters have audit logging enabled | Meets CIS 8.8", len(clusters.Clusters)),
+		ScreenshotGuide: "EKS Console → Clusters → Logging → Screenshot showing audit log type enabled",
+		ConsoleURL:      consoleURL("eks/home#/clusters", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      map[string]string{"CIS-AWS": "8.8"},
 	}, nil
 }
@@ -3,18 +3,53 @@ package checks
 import (
 	"context"
 	"fmt"
-	"time"
+	"strconv"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/aws/aws-sdk-go-v2/service/elasticache"
+	elasticachetypes "github.com/aws/aws-sdk-go-v2/service/elasticache/types"
 )
 
 type ElastiCacheChecks struct {
-	client *elasticache.Client
+	client    *elasticache.Client
+	ec2Client *ec2.Client
+
+	// minEngineVersions maps engine name ("redis", "memcached") to the
+	// oldest supported version
+	minEngineVersions map[string]string
+
+	// transient lists clusters and replication groups the checks skipped
+	// because they are being created or deleted
+	transient transientResources
+}
+
+// DefaultElastiCacheMinEngineVersions are the oldest engine versions still in
+// AWS standard support; CheckEngineVersionSupported flags anything older.
+// Valkey has no deprecated versions yet and is not listed.
+var DefaultElastiCacheMinEngineVersions = map[string]string{
+	"redis":     "6.0",
+	"memcached": "1.6",
 }
 
-func NewElastiCacheChecks(client *elasticache.Client) *ElastiCacheChecks {
-	return &ElastiCacheChecks{client: client}
+func NewElastiCacheChecks(client *elasticache.Client, ec2Client *ec2.Client) *ElastiCacheChecks {
+	minimums := map[string]string{}
+	for engine, version := range DefaultElastiCacheMinEngineVersions {
+		minimums[engine] = version
+	}
+	return &ElastiCacheChecks{
+		client:            client,
+		ec2Client:         ec2Client,
+		minEngineVersions: minimums,
+	}
+}
+
+// SetMinimumEngineVersion sets the oldest acceptable version for an engine,
+// "redis" or "memcached", e.g. SetMinimumEngineVersion("redis", "7.0")
+func (c *ElastiCacheChecks) SetMinimumEngineVersion(engine, version string) {
+	c.minEngineVersions[strings.ToLower(engine)] = version
 }
 
 func (c *ElastiCacheChecks) Name() string {
@@ -24,58 +59,129 @@ func (c *ElastiCacheChecks) Name() string {
 func (c *ElastiCacheChecks) Run(ctx context.Context) ([]CheckResult, error) {
 	results := []CheckResult{}
 
-	if result, err := c.CheckEncryptionAtRest(ctx); err == nil {
+	if result, err := runCheck(ctx, "elasticache.encryption_at_rest", c.CheckEncryptionAtRest); err == nil {
+		results = append(results, result)
+	}
+
+	if result, err := runCheck(ctx, "elasticache.encryption_in_transit", c.CheckEncryptionInTransit); err == nil {
+		results = append(results, result)
+	}
+
+	if result, err := runCheck(ctx, "elasticache.auto_minor_version_upgrade", c.CheckAutoMinorVersionUpgrade); err == nil {
+		results = append(results, result)
+	}
+
+	if result, err := runCheck(ctx, "elasticache.engine_version_supported", c.CheckEngineVersionSupported); err == nil {
+		results = append(results, result)
+	}
+
+	if result, err := runCheck(ctx, "elasticache.auth_token", c.CheckAuthToken); err == nil {
+		results = append(results, result)
+	}
+
+	if result, err := runCheck(ctx, "elasticache.redis_rbac", c.CheckRedisRBAC); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckEncryptionInTransit(ctx); err == nil {
+	if result, err := runCheck(ctx, "elasticache.backup_retention", c.CheckBackupRetention); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckAutoMinorVersionUpgrade(ctx); err == nil {
+	if result, err := runCheck(ctx, "elasticache.cluster_network_exposure", c.CheckClusterNetworkExposure); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckAuthToken(ctx); err == nil {
+	if result, err := runCheck(ctx, "elasticache.subnet_group_private", c.CheckSubnetGroupPrivate); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckBackupRetention(ctx); err == nil {
+	if result, err := runCheck(ctx, "elasticache.reservation_expiry", c.CheckReservationExpiry); err == nil {
+		results = append(results, result)
+	}
+
+	if result, err := runCheck(ctx, "elasticache.redis_open_access", c.CheckOpenRedis); err == nil {
+		results = correlateOpenRedis(results, result)
+	}
+
+	if result, ok := c.transient.result(ctx, "ElastiCache", "ElastiCache clusters and replication groups", "CC7.1"); ok {
 		results = append(results, result)
 	}
 
 	return results, nil
 }
 
+// EstimateCalls predicts the fixed cluster and replication group listings plus
+// one DescribeSecurityGroups batch and the subnet group and route table
+// lookups; ElastiCache checks do not describe per node
+func (c *ElastiCacheChecks) EstimateCalls(ctx context.Context) (CallEstimate, error) {
+	clusters, err := c.describeCacheClusters(ctx, false)
+	if err != nil {
+		return CallEstimate{}, err
+	}
+
+	calls := 2 + 1 + 1 // DescribeCacheClusters with and without node info and DescribeReplicationGroups, memoized per scan, and DescribeReservedCacheNodes
+	if len(clusters.CacheClusters) > 0 {
+		calls++ // DescribeSecurityGroups
+		calls++ // DescribeCacheSubnetGroups
+	}
+	subnetGroups := map[string]bool{}
+	for _, cluster := range clusters.CacheClusters {
+		subnetGroups[aws.ToString(cluster.CacheSubnetGroupName)] = true
+	}
+	calls += len(subnetGroups) // DescribeRouteTables once per VPC, counted per subnet group as the worst case
+
+	return CallEstimate{
+		Resources: len(clusters.CacheClusters),
+		Calls:     calls,
+	}, nil
+}
+
+// CheckEncryptionAtRest flags Redis clusters without encryption at rest.
+// Node-based Memcached clusters cannot be encrypted at rest at all, so they
+// are listed as not applicable rather than failed.
 func (c *ElastiCacheChecks) CheckEncryptionAtRest(ctx context.Context) (CheckResult, error) {
-	clusters, err := c.client.DescribeCacheClusters(ctx, &elasticache.DescribeCacheClustersInput{})
+	clusters, err := c.describeCacheClusters(ctx, false)
 	if err != nil {
 		return CheckResult{}, err
 	}
+	clusters.CacheClusters = c.steadyCacheClusters(clusters.CacheClusters)
 
+	deployments := elastiCacheDeployments(clusters.CacheClusters)
 	unencrypted := []string{}
+	unencryptedListed := []string{}
+	memcached := []string{}
 
-	for _, cluster := range clusters.CacheClusters {
-		clusterID := aws.ToString(cluster.CacheClusterId)
+	for _, deployment := range deployments {
+		cluster, clusterID := deployment.cluster, deployment.label()
 
+		if elastiCacheIsMemcached(cluster) {
+			memcached = append(memcached, clusterID)
+			continue
+		}
 		if !aws.ToBool(cluster.AtRestEncryptionEnabled) {
-			unencrypted = append(unencrypted, clusterID)
+			unencrypted = append(unencrypted, deployment.id)
+			unencryptedListed = append(unencryptedListed, clusterID)
 		}
 	}
 
+	notApplicable := memcachedNotApplicableNote(ctx, memcached, "Memcached does not support encryption at rest")
+	evaluated := len(deployments) - len(memcached)
+
 	if len(unencrypted) > 0 {
 		return CheckResult{
 			Control:           "CC6.3",
 			Name:              "ElastiCache Encryption at Rest",
 			Status:            "FAIL",
 			Severity:          "HIGH",
-			Evidence:          fmt.Sprintf("%d ElastiCache clusters without encryption at rest: %v", len(unencrypted), unencrypted),
+			Evidence:          fmt.Sprintf("%d ElastiCache Redis clusters without encryption at rest: %s%s", len(unencrypted), TruncateList(unencryptedListed, evidenceListLimit(ctx)), notApplicable),
+			AffectedResources: unencrypted,
 			Remediation:       "Enable encryption at rest for ElastiCache clusters",
 			RemediationDetail: "Encryption at rest must be enabled when creating the cluster. Create new cluster with AtRestEncryptionEnabled=true and migrate data.",
+			RequiresRecreate:  true,
 			ScreenshotGuide:   "ElastiCache Console → Redis/Memcached → Select cluster → Description → Screenshot showing 'Encryption at rest: Enabled'",
-			ConsoleURL:        "https://console.aws.amazon.com/elasticache/",
+			ConsoleURL:        consoleURL("elasticache/", consoleRegion(ctx)),
 			Priority:          PriorityHigh,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("ELASTICACHE_ENCRYPTION"),
 		}, nil
 	}
@@ -86,52 +192,88 @@ func (c *ElastiCacheChecks) CheckEncryptionAtRest(ctx context.Context) (CheckRes
 			Name:       "ElastiCache Encryption at Rest",
 			Status:     "PASS",
 			Evidence:   "No ElastiCache clusters found",
+			ConsoleURL: consoleURL("elasticache/", consoleRegion(ctx)),
+			Priority:   PriorityInfo,
+			Timestamp:  nowFunc(),
+			Frameworks: GetFrameworkMappings("ELASTICACHE_ENCRYPTION"),
+		}, nil
+	}
+
+	if evaluated == 0 {
+		return CheckResult{
+			Control:    "CC6.3",
+			Name:       "ElastiCache Encryption at Rest",
+			Status:     "NOT_APPLICABLE",
+			Evidence:   fmt.Sprintf("All %d ElastiCache clusters run Memcached, which does not support encryption at rest: %s", len(memcached), TruncateList(memcached, evidenceListLimit(ctx))),
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("ELASTICACHE_ENCRYPTION"),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "CC6.3",
-		Name:       "ElastiCache Encryption at Rest",
-		Status:     "PASS",
-		Evidence:   fmt.Sprintf("All %d ElastiCache clusters have encryption at rest enabled", len(clusters.CacheClusters)),
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("ELASTICACHE_ENCRYPTION"),
+		Control:         "CC6.3",
+		Name:            "ElastiCache Encryption at Rest",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d ElastiCache Redis clusters have encryption at rest enabled%s", evaluated, notApplicable),
+		ScreenshotGuide: "ElastiCache Console → Redis/Memcached → Select cluster → Description → Screenshot showing 'Encryption at rest: Enabled'",
+		ConsoleURL:      consoleURL("elasticache/", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("ELASTICACHE_ENCRYPTION"),
 	}, nil
 }
 
+// CheckEncryptionInTransit flags clusters without TLS. Memcached only supports
+// in-transit encryption from engine 1.6.12, so older Memcached clusters are
+// listed as not applicable; upgrading them is the way to enable TLS.
 func (c *ElastiCacheChecks) CheckEncryptionInTransit(ctx context.Context) (CheckResult, error) {
-	clusters, err := c.client.DescribeCacheClusters(ctx, &elasticache.DescribeCacheClustersInput{})
+	clusters, err := c.describeCacheClusters(ctx, false)
 	if err != nil {
 		return CheckResult{}, err
 	}
+	clusters.CacheClusters = c.steadyCacheClusters(clusters.CacheClusters)
 
+	deployments := elastiCacheDeployments(clusters.CacheClusters)
 	noTransitEncryption := []string{}
+	noTransitEncryptionListed := []string{}
+	memcachedNoTLSSupport := []string{}
 
-	for _, cluster := range clusters.CacheClusters {
-		clusterID := aws.ToString(cluster.CacheClusterId)
+	for _, deployment := range deployments {
+		cluster, clusterID := deployment.cluster, deployment.label()
 
+		if elastiCacheIsMemcached(cluster) && !memcachedSupportsTLS(aws.ToString(cluster.EngineVersion)) {
+			memcachedNoTLSSupport = append(memcachedNoTLSSupport, clusterID)
+			continue
+		}
 		if !aws.ToBool(cluster.TransitEncryptionEnabled) {
-			noTransitEncryption = append(noTransitEncryption, clusterID)
+			fix := ""
+			if cluster.PendingModifiedValues != nil && aws.ToBool(cluster.PendingModifiedValues.TransitEncryptionEnabled) {
+				fix = "in-transit encryption enablement"
+			}
+			noTransitEncryption = append(noTransitEncryption, deployment.id)
+			noTransitEncryptionListed = append(noTransitEncryptionListed, withPendingFix(clusterID, fix))
 		}
 	}
 
+	notApplicable := memcachedNotApplicableNote(ctx, memcachedNoTLSSupport, "engine older than 1.6.12 does not support TLS; upgrade to enable it")
+	evaluated := len(deployments) - len(memcachedNoTLSSupport)
+
 	if len(noTransitEncryption) > 0 {
 		return CheckResult{
 			Control:           "CC6.4",
 			Name:              "ElastiCache Encryption in Transit",
 			Status:            "FAIL",
 			Severity:          "HIGH",
-			Evidence:          fmt.Sprintf("%d ElastiCache clusters without encryption in transit: %v", len(noTransitEncryption), noTransitEncryption),
+			Evidence:          fmt.Sprintf("%d ElastiCache clusters without encryption in transit: %s%s", len(noTransitEncryption), TruncateList(noTransitEncryptionListed, evidenceListLimit(ctx)), notApplicable),
+			AffectedResources: noTransitEncryption,
 			Remediation:       "Enable encryption in transit (TLS) for ElastiCache clusters",
-			RemediationDetail: "Transit encryption must be enabled when creating the cluster. Create new cluster with TransitEncryptionEnabled=true.",
+			RemediationDetail: "Transit encryption must be enabled when creating the cluster. Create new cluster with TransitEncryptionEnabled=true.\nMemcached requires engine version 1.6.12 or later for TLS.",
+			RequiresRecreate:  true,
 			ScreenshotGuide:   "ElastiCache Console → Select cluster → Description → Screenshot showing 'Encryption in transit: Enabled'",
-			ConsoleURL:        "https://console.aws.amazon.com/elasticache/",
+			ConsoleURL:        consoleURL("elasticache/", consoleRegion(ctx)),
 			Priority:          PriorityHigh,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("ELASTICACHE_TRANSIT"),
 		}, nil
 	}
@@ -142,36 +284,209 @@ func (c *ElastiCacheChecks) CheckEncryptionInTransit(ctx context.Context) (Check
 			Name:       "ElastiCache Encryption in Transit",
 			Status:     "PASS",
 			Evidence:   "No ElastiCache clusters found",
+			ConsoleURL: consoleURL("elasticache/", consoleRegion(ctx)),
+			Priority:   PriorityInfo,
+			Timestamp:  nowFunc(),
+			Frameworks: GetFrameworkMappings("ELASTICACHE_TRANSIT"),
+		}, nil
+	}
+
+	if evaluated == 0 {
+		return CheckResult{
+			Control:    "CC6.4",
+			Name:       "ElastiCache Encryption in Transit",
+			Status:     "NOT_APPLICABLE",
+			Evidence:   fmt.Sprintf("All %d ElastiCache clusters run Memcached older than 1.6.12, which does not support encryption in transit: %s", len(memcachedNoTLSSupport), TruncateList(memcachedNoTLSSupport, evidenceListLimit(ctx))),
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("ELASTICACHE_TRANSIT"),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "CC6.4",
-		Name:       "ElastiCache Encryption in Transit",
-		Status:     "PASS",
-		Evidence:   fmt.Sprintf("All %d ElastiCache clusters have encryption in transit enabled", len(clusters.CacheClusters)),
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("ELASTICACHE_TRANSIT"),
+		Control:         "CC6.4",
+		Name:            "ElastiCache Encryption in Transit",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d ElastiCache clusters have encryption in transit enabled%s", evaluated, notApplicable),
+		ScreenshotGuide: "ElastiCache Console → Select cluster → Description → Screenshot showing 'Encryption in transit: Enabled'",
+		ConsoleURL:      consoleURL("elasticache/", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("ELASTICACHE_TRANSIT"),
 	}, nil
 }
 
+// describeCacheClusters lists every cache cluster, across all pages, with
+// per-node details when showNodeInfo is set. Most ElastiCache checks start
+// from this listing, so it is memoized per region and account; each call
+// gets its own output to narrow without touching the shared listing.
+func (c *ElastiCacheChecks) describeCacheClusters(ctx context.Context, showNodeInfo bool) (*elasticache.DescribeCacheClustersOutput, error) {
+	key := fmt.Sprintf("elasticache:%s:%s:cache-clusters?nodes=%t", c.client.Options().Region, scanFrom(ctx).accountID, showNodeInfo)
+	clusters, err := memoize(ctx, key, func() ([]elasticachetypes.CacheCluster, error) {
+		return paginate(ctx, func(token *string) ([]elasticachetypes.CacheCluster, *string, error) {
+			out, err := c.client.DescribeCacheClusters(ctx, &elasticache.DescribeCacheClustersInput{
+				ShowCacheNodeInfo: aws.Bool(showNodeInfo),
+				Marker:            token,
+			})
+			if err != nil {
+				return nil, nil, err
+			}
+			return out.CacheClusters, out.Marker, nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &elasticache.DescribeCacheClustersOutput{CacheClusters: clusters}, nil
+}
+
+// describeReplicationGroups lists every replication group, across all pages,
+// memoized per region and account like describeCacheClusters
+func (c *ElastiCacheChecks) describeReplicationGroups(ctx context.Context) (*elasticache.DescribeReplicationGroupsOutput, error) {
+	key := fmt.Sprintf("elasticache:%s:%s:replication-groups", c.client.Options().Region, scanFrom(ctx).accountID)
+	groups, err := memoize(ctx, key, func() ([]elasticachetypes.ReplicationGroup, error) {
+		return paginate(ctx, func(token *string) ([]elasticachetypes.ReplicationGroup, *string, error) {
+			out, err := c.client.DescribeReplicationGroups(ctx, &elasticache.DescribeReplicationGroupsInput{Marker: token})
+			if err != nil {
+				return nil, nil, err
+			}
+			return out.ReplicationGroups, out.Marker, nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &elasticache.DescribeReplicationGroupsOutput{ReplicationGroups: groups}, nil
+}
+
+// steadyCacheClusters drops clusters being created or deleted, recording them
+// for the transient INFO result
+func (c *ElastiCacheChecks) steadyCacheClusters(clusters []elasticachetypes.CacheCluster) []elasticachetypes.CacheCluster {
+	steady := []elasticachetypes.CacheCluster{}
+	for _, cluster := range clusters {
+		if status := aws.ToString(cluster.CacheClusterStatus); isTransientStatus(status) {
+			c.transient.add(aws.ToString(cluster.CacheClusterId), status)
+			continue
+		}
+		steady = append(steady, cluster)
+	}
+	return steady
+}
+
+// steadyReplicationGroups drops replication groups being created or deleted,
+// recording them for the transient INFO result
+func (c *ElastiCacheChecks) steadyReplicationGroups(groups []elasticachetypes.ReplicationGroup) []elasticachetypes.ReplicationGroup {
+	steady := []elasticachetypes.ReplicationGroup{}
+	for _, group := range groups {
+		if status := aws.ToString(group.Status); isTransientStatus(status) {
+			c.transient.add(aws.ToString(group.ReplicationGroupId), status)
+			continue
+		}
+		steady = append(steady, group)
+	}
+	return steady
+}
+
+// elastiCacheDeployment is one logical cache deployment: a standalone
+// cluster, or a replication group represented by its first member cluster.
+// Encryption and upgrade settings belong to the group, so every member node
+// reports the same values and checking one stands for all of them.
+type elastiCacheDeployment struct {
+	id      string // cluster ID, or replication group ID
+	cluster elasticachetypes.CacheCluster
+	group   bool
+	members int
+}
+
+// elastiCacheDeployments collapses replication group member clusters into one
+// deployment per group, so a group's nodes yield one finding rather than one
+// per node. Standalone clusters stay as they are, in input order.
+func elastiCacheDeployments(clusters []elasticachetypes.CacheCluster) []elastiCacheDeployment {
+	deployments := []elastiCacheDeployment{}
+	groups := map[string]int{}
+
+	for _, cluster := range clusters {
+		groupID := aws.ToString(cluster.ReplicationGroupId)
+		if groupID == "" {
+			deployments = append(deployments, elastiCacheDeployment{
+				id:      aws.ToString(cluster.CacheClusterId),
+				cluster: cluster,
+				members: 1,
+			})
+			continue
+		}
+
+		if i, ok := groups[groupID]; ok {
+			deployments[i].members++
+			continue
+		}
+		groups[groupID] = len(deployments)
+		deployments = append(deployments, elastiCacheDeployment{
+			id:      groupID,
+			cluster: cluster,
+			group:   true,
+			members: 1,
+		})
+	}
+	return deployments
+}
+
+// label names the deployment in evidence, e.g. "sessions (replication group, 3 nodes)"
+func (d elastiCacheDeployment) label() string {
+	if !d.group {
+		return d.id
+	}
+	return fmt.Sprintf("%s (replication group, %d nodes)", d.id, d.members)
+}
+
+// elastiCacheIsMemcached reports whether the cluster runs the Memcached engine
+func elastiCacheIsMemcached(cluster elasticachetypes.CacheCluster) bool {
+	return strings.EqualFold(aws.ToString(cluster.Engine), "memcached")
+}
+
+// memcachedSupportsTLS reports whether a Memcached engine version (e.g.
+// "1.6.17") supports in-transit encryption, added in 1.6.12
+func memcachedSupportsTLS(version string) bool {
+	minimum := []int{1, 6, 12}
+	parts := strings.Split(version, ".")
+	for i, want := range minimum {
+		got := 0
+		if i < len(parts) {
+			got, _ = strconv.Atoi(parts[i])
+		}
+		if got != want {
+			return got > want
+		}
+	}
+	return true
+}
+
+// memcachedNotApplicableNote is the evidence suffix naming Memcached clusters a
+// check skipped, or "" when it skipped none
+func memcachedNotApplicableNote(ctx context.Context, clusters []string, reason string) string {
+	if len(clusters) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("; %d Memcached clusters not applicable (%s): %s", len(clusters), reason, TruncateList(clusters, evidenceListLimit(ctx)))
+}
+
 func (c *ElastiCacheChecks) CheckAutoMinorVersionUpgrade(ctx context.Context) (CheckResult, error) {
-	clusters, err := c.client.DescribeCacheClusters(ctx, &elasticache.DescribeCacheClustersInput{})
+	clusters, err := c.describeCacheClusters(ctx, false)
 	if err != nil {
 		return CheckResult{}, err
 	}
+	clusters.CacheClusters = c.steadyCacheClusters(clusters.CacheClusters)
 
+	deployments := elastiCacheDeployments(clusters.CacheClusters)
 	noAutoUpgrade := []string{}
+	noAutoUpgradeListed := []string{}
 
-	for _, cluster := range clusters.CacheClusters {
-		clusterID := aws.ToString(cluster.CacheClusterId)
+	for _, deployment := range deployments {
+		cluster, clusterID := deployment.cluster, deployment.label()
 
 		if !aws.ToBool(cluster.AutoMinorVersionUpgrade) {
-			noAutoUpgrade = append(noAutoUpgrade, clusterID)
+			noAutoUpgrade = append(noAutoUpgrade, deployment.id)
+			noAutoUpgradeListed = append(noAutoUpgradeListed, clusterID)
 		}
 	}
 
@@ -181,13 +496,14 @@ func (c *ElastiCacheChecks) CheckAutoMinorVersionUpgrade(ctx context.Context) (C
 			Name:              "ElastiCache Auto Minor Version Upgrade",
 			Status:            "FAIL",
 			Severity:          "MEDIUM",
-			Evidence:          fmt.Sprintf("%d ElastiCache clusters without auto minor version upgrade: %v", len(noAutoUpgrade), noAutoUpgrade),
+			Evidence:          fmt.Sprintf("%d ElastiCache clusters without auto minor version upgrade: %s", len(noAutoUpgrade), TruncateList(noAutoUpgradeListed, evidenceListLimit(ctx))),
+			AffectedResources: noAutoUpgrade,
 			Remediation:       "Enable auto minor version upgrade for ElastiCache clusters",
-			RemediationDetail: "aws elasticache modify-cache-cluster --cache-cluster-id [CLUSTER_ID] --auto-minor-version-upgrade",
+			RemediationDetail: "aws elasticache modify-cache-cluster --cache-cluster-id [CLUSTER_ID] --auto-minor-version-upgrade\nFor replication groups: aws elasticache modify-replication-group --replication-group-id [GROUP_ID] --auto-minor-version-upgrade",
 			ScreenshotGuide:   "ElastiCache Console → Select cluster → Maintenance → Screenshot showing 'Auto minor version upgrade: Yes'",
-			ConsoleURL:        "https://console.aws.amazon.com/elasticache/",
+			ConsoleURL:        consoleURL("elasticache/", consoleRegion(ctx)),
 			Priority:          PriorityMedium,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("ELASTICACHE_PATCHING"),
 		}, nil
 	}
@@ -198,29 +514,107 @@ func (c *ElastiCacheChecks) CheckAutoMinorVersionUpgrade(ctx context.Context) (C
 			Name:       "ElastiCache Auto Minor Version Upgrade",
 			Status:     "PASS",
 			Evidence:   "No ElastiCache clusters found",
+			ConsoleURL: consoleURL("elasticache/", consoleRegion(ctx)),
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("ELASTICACHE_PATCHING"),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "CC7.5",
-		Name:       "ElastiCache Auto Minor Version Upgrade",
-		Status:     "PASS",
-		Evidence:   fmt.Sprintf("All %d ElastiCache clusters have auto minor version upgrade enabled", len(clusters.CacheClusters)),
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("ELASTICACHE_PATCHING"),
+		Control:         "CC7.5",
+		Name:            "ElastiCache Auto Minor Version Upgrade",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d ElastiCache clusters have auto minor version upgrade enabled", len(deployments)),
+		ScreenshotGuide: "ElastiCache Console → Select cluster → Maintenance → Screenshot showing 'Auto minor version upgrade: Yes'",
+		ConsoleURL:      consoleURL("elasticache/", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("ELASTICACHE_PATCHING"),
+	}, nil
+}
+
+// CheckEngineVersionSupported flags Redis and Memcached clusters running an
+// engine version older than the configured minimum for that engine, which
+// AWS has deprecated and no longer patches. Engines with no minimum
+// configured are not flagged.
+func (c *ElastiCacheChecks) CheckEngineVersionSupported(ctx context.Context) (CheckResult, error) {
+	clusters, err := c.describeCacheClusters(ctx, false)
+	if err != nil {
+		return CheckResult{}, err
+	}
+	clusters.CacheClusters = c.steadyCacheClusters(clusters.CacheClusters)
+
+	deployments := elastiCacheDeployments(clusters.CacheClusters)
+	outdated := []string{}
+	outdatedListed := []string{}
+
+	for _, deployment := range deployments {
+		cluster, clusterID := deployment.cluster, deployment.label()
+
+		engine := strings.ToLower(aws.ToString(cluster.Engine))
+		version := aws.ToString(cluster.EngineVersion)
+		minimum, ok := c.minEngineVersions[engine]
+		if !ok || version == "" {
+			continue
+		}
+		if compareEngineVersions(version, minimum) < 0 {
+			outdated = append(outdated, deployment.id)
+			outdatedListed = append(outdatedListed, fmt.Sprintf("%s (%s %s, minimum %s)", clusterID, engine, version, minimum))
+		}
+	}
+
+	if len(outdated) > 0 {
+		return CheckResult{
+			Control:           "CC7.5",
+			Name:              "ElastiCache Engine Version",
+			Status:            "FAIL",
+			Severity:          "MEDIUM",
+			Evidence:          fmt.Sprintf("%d ElastiCache clusters run a deprecated engine version: %s", len(outdated), TruncateList(outdatedListed, evidenceListLimit(ctx))),
+			AffectedResources: outdated,
+			Remediation:       "Upgrade ElastiCache clusters to a supported engine version",
+			RemediationDetail: "aws elasticache describe-cache-engine-versions --engine redis\naws elasticache modify-replication-group --replication-group-id [GROUP_ID] --engine-version [VERSION] --apply-immediately\nFor standalone clusters: aws elasticache modify-cache-cluster --cache-cluster-id [CLUSTER_ID] --engine-version [VERSION]\nNote: Take a backup first; major version upgrades cannot be rolled back",
+			ScreenshotGuide:   "ElastiCache Console → Select cluster → Cluster details → Screenshot showing the engine version",
+			ConsoleURL:        consoleURL("elasticache/", consoleRegion(ctx)),
+			Priority:          PriorityMedium,
+			Timestamp:         nowFunc(),
+			Frameworks:        GetFrameworkMappings("ELASTICACHE_PATCHING"),
+		}, nil
+	}
+
+	if len(clusters.CacheClusters) == 0 {
+		return CheckResult{
+			Control:    "CC7.5",
+			Name:       "ElastiCache Engine Version",
+			Status:     "PASS",
+			Evidence:   "No ElastiCache clusters found",
+			ConsoleURL: consoleURL("elasticache/", consoleRegion(ctx)),
+			Priority:   PriorityInfo,
+			Timestamp:  nowFunc(),
+			Frameworks: GetFrameworkMappings("ELASTICACHE_PATCHING"),
+		}, nil
+	}
+
+	return CheckResult{
+		Control:         "CC7.5",
+		Name:            "ElastiCache Engine Version",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d ElastiCache clusters run a supported engine version", len(deployments)),
+		ScreenshotGuide: "ElastiCache Console → Select cluster → Cluster details → Screenshot showing the engine version",
+		ConsoleURL:      consoleURL("elasticache/", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("ELASTICACHE_PATCHING"),
 	}, nil
 }
 
 func (c *ElastiCacheChecks) CheckAuthToken(ctx context.Context) (CheckResult, error) {
 	// Check Redis replication groups for AUTH token
-	repGroups, err := c.client.DescribeReplicationGroups(ctx, &elasticache.DescribeReplicationGroupsInput{})
+	repGroups, err := c.describeReplicationGroups(ctx)
 	if err != nil {
 		return CheckResult{}, err
 	}
+	repGroups.ReplicationGroups = c.steadyReplicationGroups(repGroups.ReplicationGroups)
 
 	noAuth := []string{}
 
@@ -238,13 +632,15 @@ func (c *ElastiCacheChecks) CheckAuthToken(ctx context.Context) (CheckResult, er
 			Name:              "ElastiCache Redis AUTH Token",
 			Status:            "FAIL",
 			Severity:          "HIGH",
-			Evidence:          fmt.Sprintf("%d Redis replication groups without AUTH token: %v", len(noAuth), noAuth),
+			Evidence:          fmt.Sprintf("%d Redis replication groups without AUTH token: %s", len(noAuth), TruncateList(noAuth, evidenceListLimit(ctx))),
+			AffectedResources: noAuth,
 			Remediation:       "Enable AUTH token for Redis replication groups",
 			RemediationDetail: "AUTH token must be enabled when creating the replication group. Create new group with AuthToken parameter set.",
+			RequiresRecreate:  true,
 			ScreenshotGuide:   "ElastiCache Console → Redis → Select replication group → Description → Screenshot showing 'AUTH token: Enabled'",
-			ConsoleURL:        "https://console.aws.amazon.com/elasticache/home#redis:",
+			ConsoleURL:        consoleURL("elasticache/home#redis:", consoleRegion(ctx)),
 			Priority:          PriorityHigh,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("ELASTICACHE_AUTH"),
 		}, nil
 	}
@@ -255,36 +651,249 @@ func (c *ElastiCacheChecks) CheckAuthToken(ctx context.Context) (CheckResult, er
 			Name:       "ElastiCache Redis AUTH Token",
 			Status:     "PASS",
 			Evidence:   "No Redis replication groups found",
+			ConsoleURL: consoleURL("elasticache/home#redis:", consoleRegion(ctx)),
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("ELASTICACHE_AUTH"),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "CC6.6",
-		Name:       "ElastiCache Redis AUTH Token",
-		Status:     "PASS",
-		Evidence:   fmt.Sprintf("All %d Redis replication groups have AUTH token enabled", len(repGroups.ReplicationGroups)),
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("ELASTICACHE_AUTH"),
+		Control:         "CC6.6",
+		Name:            "ElastiCache Redis AUTH Token",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d Redis replication groups have AUTH token enabled", len(repGroups.ReplicationGroups)),
+		ScreenshotGuide: "ElastiCache Console → Redis → Select replication group → Description → Screenshot showing 'AUTH token: Enabled'",
+		ConsoleURL:      consoleURL("elasticache/home#redis:", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("ELASTICACHE_AUTH"),
 	}, nil
 }
 
+// CheckRedisRBAC prefers RBAC user groups over a single shared AUTH token.
+// Groups with neither fail at MEDIUM; groups relying on a bare AUTH token are
+// LOW guidance, since the token cannot be scoped per user or rotated without
+// coordinating every client.
+func (c *ElastiCacheChecks) CheckRedisRBAC(ctx context.Context) (CheckResult, error) {
+	repGroups, err := c.describeReplicationGroups(ctx)
+	if err != nil {
+		return CheckResult{}, err
+	}
+	repGroups.ReplicationGroups = c.steadyReplicationGroups(repGroups.ReplicationGroups)
+
+	tokenOnly := []string{}
+	noAuth := []string{}
+	rbac := 0
+
+	for _, rg := range repGroups.ReplicationGroups {
+		rgID := aws.ToString(rg.ReplicationGroupId)
+
+		switch {
+		case len(rg.UserGroupIds) > 0:
+			rbac++
+		case aws.ToBool(rg.AuthTokenEnabled):
+			tokenOnly = append(tokenOnly, rgID)
+		default:
+			noAuth = append(noAuth, rgID)
+		}
+	}
+
+	if len(noAuth) > 0 || len(tokenOnly) > 0 {
+		severity, priority := "LOW", PriorityLow
+		evidence := fmt.Sprintf("%d Redis replication groups authenticate with a shared AUTH token instead of RBAC user groups: %s", len(tokenOnly), TruncateList(tokenOnly, evidenceListLimit(ctx)))
+		if len(noAuth) > 0 {
+			severity, priority = "MEDIUM", PriorityMedium
+			evidence = fmt.Sprintf("%d Redis replication groups have neither RBAC user groups nor an AUTH token: %s", len(noAuth), TruncateList(noAuth, evidenceListLimit(ctx)))
+			if len(tokenOnly) > 0 {
+				evidence += fmt.Sprintf("; %d more use only a shared AUTH token: %s", len(tokenOnly), TruncateList(tokenOnly, evidenceListLimit(ctx)))
+			}
+		}
+
+		return CheckResult{
+			Control:           "CC6.6",
+			Name:              "ElastiCache Redis RBAC",
+			Status:            "FAIL",
+			Severity:          severity,
+			Evidence:          evidence,
+			AffectedResources: append(noAuth, tokenOnly...),
+			Remediation:       "Use RBAC user groups for Redis authentication instead of a shared AUTH token",
+			RemediationDetail: "1. aws elasticache create-user --user-id [USER_ID] --user-name [USER_NAME] --engine redis --passwords [PASSWORD] --access-string \"on ~app:* +@read +@write\"\n2. aws elasticache create-user-group --user-group-id [GROUP_ID] --engine redis --user-ids default [USER_ID]\n3. aws elasticache modify-replication-group --replication-group-id [RG_ID] --user-group-ids-to-add [GROUP_ID] --auth-token-update-strategy DELETE (for groups migrating from AUTH)\nRequires Redis 6.0 or later with encryption in transit",
+			ScreenshotGuide:   "ElastiCache Console → Redis → Select replication group → Security → Screenshot showing 'Access control: User group access control list' and the user group",
+			ConsoleURL:        consoleURL("elasticache/home#/user-groups", consoleRegion(ctx)),
+			Priority:          priority,
+			Timestamp:         nowFunc(),
+			Frameworks:        GetFrameworkMappings("ELASTICACHE_RBAC"),
+		}, nil
+	}
+
+	if len(repGroups.ReplicationGroups) == 0 {
+		return CheckResult{
+			Control:    "CC6.6",
+			Name:       "ElastiCache Redis RBAC",
+			Status:     "PASS",
+			Evidence:   "No Redis replication groups found",
+			ConsoleURL: consoleURL("elasticache/home#/user-groups", consoleRegion(ctx)),
+			Priority:   PriorityInfo,
+			Timestamp:  nowFunc(),
+			Frameworks: GetFrameworkMappings("ELASTICACHE_RBAC"),
+		}, nil
+	}
+
+	return CheckResult{
+		Control:         "CC6.6",
+		Name:            "ElastiCache Redis RBAC",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d Redis replication groups use RBAC user groups", rbac),
+		ScreenshotGuide: "ElastiCache Console → Redis → Select replication group → Security → Screenshot showing 'Access control: User group access control list' and the user group",
+		ConsoleURL:      consoleURL("elasticache/home#/user-groups", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("ELASTICACHE_RBAC"),
+	}, nil
+}
+
+// CheckOpenRedis flags Redis replication groups with neither AUTH nor
+// encryption in transit: anyone who can reach the endpoint can read and
+// write every key, and the traffic is readable on the wire. The AUTH,
+// transit encryption and RBAC checks each report these groups too;
+// correlateOpenRedis folds those findings into this one.
+func (c *ElastiCacheChecks) CheckOpenRedis(ctx context.Context) (CheckResult, error) {
+	repGroups, err := c.describeReplicationGroups(ctx)
+	if err != nil {
+		return CheckResult{}, err
+	}
+	repGroups.ReplicationGroups = c.steadyReplicationGroups(repGroups.ReplicationGroups)
+
+	open := []string{}
+
+	for _, rg := range repGroups.ReplicationGroups {
+		if !aws.ToBool(rg.AuthTokenEnabled) && !aws.ToBool(rg.TransitEncryptionEnabled) {
+			open = append(open, aws.ToString(rg.ReplicationGroupId))
+		}
+	}
+
+	if len(open) > 0 {
+		return CheckResult{
+			Control:           "CC6.6",
+			Name:              openRedisCheckName,
+			Status:            "FAIL",
+			Severity:          "CRITICAL",
+			Evidence:          fmt.Sprintf("%d Redis replication groups accept unauthenticated, unencrypted connections from anything that can reach them: %s", len(open), TruncateList(open, evidenceListLimit(ctx))),
+			AffectedResources: open,
+			Remediation:       "Recreate these replication groups with encryption in transit and AUTH or RBAC user groups, and restrict their security groups meanwhile",
+			RemediationDetail: "1. Restrict the security groups to the application subnets now\n2. Take a snapshot: aws elasticache create-snapshot --replication-group-id [RG_ID] --snapshot-name [SNAPSHOT]\n3. Restore it into a new group: aws elasticache create-replication-group --replication-group-id [NEW_RG_ID] --replication-group-description [DESC] --snapshot-name [SNAPSHOT] --transit-encryption-enabled --auth-token [TOKEN]\n4. Point clients at the new endpoint with TLS and the token, then delete the old group",
+			RequiresRecreate:  true,
+			ScreenshotGuide:   "ElastiCache Console → Redis → Select replication group → Security → Screenshot showing 'Encryption in transit: Enabled' and AUTH or user group access control",
+			ConsoleURL:        consoleURL("elasticache/home#redis:", consoleRegion(ctx)),
+			Priority:          PriorityCritical,
+			Timestamp:         nowFunc(),
+			Frameworks:        GetFrameworkMappings("ELASTICACHE_OPEN_REDIS"),
+		}, nil
+	}
+
+	if len(repGroups.ReplicationGroups) == 0 {
+		return CheckResult{
+			Control:    "CC6.6",
+			Name:       openRedisCheckName,
+			Status:     "PASS",
+			Evidence:   "No Redis replication groups found",
+			ConsoleURL: consoleURL("elasticache/home#redis:", consoleRegion(ctx)),
+			Priority:   PriorityInfo,
+			Timestamp:  nowFunc(),
+			Frameworks: GetFrameworkMappings("ELASTICACHE_OPEN_REDIS"),
+		}, nil
+	}
+
+	return CheckResult{
+		Control:         "CC6.6",
+		Name:            openRedisCheckName,
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d Redis replication groups require AUTH or encryption in transit", len(repGroups.ReplicationGroups)),
+		ScreenshotGuide: "ElastiCache Console → Redis → Select replication group → Security → Screenshot showing 'Encryption in transit: Enabled' and AUTH or user group access control",
+		ConsoleURL:      consoleURL("elasticache/home#redis:", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("ELASTICACHE_OPEN_REDIS"),
+	}, nil
+}
+
+// openRedisCheckName is the Name of CheckOpenRedis results
+const openRedisCheckName = "ElastiCache Redis Unauthenticated and Unencrypted"
+
+// openRedisCorrelated are the checks whose failures CheckOpenRedis subsumes
+// for the groups it lists
+var openRedisCorrelated = map[string]bool{
+	"ElastiCache Redis AUTH Token":      true,
+	"ElastiCache Encryption in Transit": true,
+	"ElastiCache Redis RBAC":            true,
+}
+
+// correlateOpenRedis appends the open Redis result to results and, when it
+// failed, keeps the AUTH, transit encryption and RBAC failures from counting
+// the same groups a second time. A correlated failure whose resources are all
+// open groups becomes INFO, which the score ignores, so each open group fails
+// once, under the CRITICAL finding. One that also lists other groups stays a
+// failure for those only: the open groups leave its AffectedResources. Both
+// note the overlap in their evidence. It works on results alone, so it can be
+// exercised without AWS.
+func correlateOpenRedis(results []CheckResult, open CheckResult) []CheckResult {
+	if open.Status != "FAIL" || len(open.AffectedResources) == 0 {
+		return append(results, open)
+	}
+
+	openGroups := map[string]bool{}
+	for _, id := range open.AffectedResources {
+		openGroups[id] = true
+	}
+
+	for i := range results {
+		result := &results[i]
+		if result.Status != "FAIL" || !openRedisCorrelated[result.Name] {
+			continue
+		}
+
+		remaining := []string{}
+		for _, resource := range result.AffectedResources {
+			if !openGroups[resource] {
+				remaining = append(remaining, resource)
+			}
+		}
+		overlap := len(result.AffectedResources) - len(remaining)
+		if overlap == 0 {
+			continue
+		}
+
+		if len(remaining) == 0 {
+			result.Status = "INFO"
+			result.Severity = ""
+			result.Priority = PriorityInfo
+			result.Evidence += fmt.Sprintf(" (all also reported as CRITICAL under %q)", openRedisCheckName)
+			continue
+		}
+		result.AffectedResources = remaining
+		result.Evidence += fmt.Sprintf(" (%d of these also reported as CRITICAL under %q)", overlap, openRedisCheckName)
+	}
+
+	return append(results, open)
+}
+
 func (c *ElastiCacheChecks) CheckBackupRetention(ctx context.Context) (CheckResult, error) {
-	repGroups, err := c.client.DescribeReplicationGroups(ctx, &elasticache.DescribeReplicationGroupsInput{})
+	repGroups, err := c.describeReplicationGroups(ctx)
 	if err != nil {
 		return CheckResult{}, err
 	}
+	repGroups.ReplicationGroups = c.steadyReplicationGroups(repGroups.ReplicationGroups)
 
 	lowRetention := []string{}
+	lowRetentionListed := []string{}
 
 	for _, rg := range repGroups.ReplicationGroups {
 		rgID := aws.ToString(rg.ReplicationGroupId)
 
 		if rg.SnapshotRetentionLimit != nil && *rg.SnapshotRetentionLimit < 7 {
-			lowRetention = append(lowRetention, fmt.Sprintf("%s (%d days)", rgID, *rg.SnapshotRetentionLimit))
+			lowRetention = append(lowRetention, rgID)
+			lowRetentionListed = append(lowRetentionListed, fmt.Sprintf("%s (%d days)", rgID, *rg.SnapshotRetentionLimit))
 		}
 	}
 
@@ -294,13 +903,14 @@ func (c *ElastiCacheChecks) CheckBackupRetention(ctx context.Context) (CheckResu
 			Name:              "ElastiCache Backup Retention",
 			Status:            "FAIL",
 			Severity:          "MEDIUM",
-			Evidence:          fmt.Sprintf("%d Redis groups with backup retention < 7 days: %v", len(lowRetention), lowRetention),
+			Evidence:          fmt.Sprintf("%d Redis groups with backup retention < 7 days: %s", len(lowRetention), TruncateList(lowRetentionListed, evidenceListLimit(ctx))),
+			AffectedResources: lowRetention,
 			Remediation:       "Increase snapshot retention period to at least 7 days",
 			RemediationDetail: "aws elasticache modify-replication-group --replication-group-id [GROUP_ID] --snapshot-retention-limit 7",
 			ScreenshotGuide:   "ElastiCache Console → Redis → Select group → Backup → Screenshot showing retention >= 7 days",
-			ConsoleURL:        "https://console.aws.amazon.com/elasticache/home#redis:",
+			ConsoleURL:        consoleURL("elasticache/home#redis:", consoleRegion(ctx)),
 			Priority:          PriorityMedium,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("ELASTICACHE_BACKUP"),
 		}, nil
 	}
@@ -311,19 +921,374 @@ func (c *ElastiCacheChecks) CheckBackupRetention(ctx context.Context) (CheckResu
 			Name:       "ElastiCache Backup Retention",
 			Status:     "PASS",
 			Evidence:   "No Redis replication groups found",
+			ConsoleURL: consoleURL("elasticache/home#redis:", consoleRegion(ctx)),
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("ELASTICACHE_BACKUP"),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "A1.2",
-		Name:       "ElastiCache Backup Retention",
+		Control:         "A1.2",
+		Name:            "ElastiCache Backup Retention",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d Redis replication groups have adequate backup retention", len(repGroups.ReplicationGroups)),
+		ScreenshotGuide: "ElastiCache Console → Redis → Select group → Backup → Screenshot showing retention >= 7 days",
+		ConsoleURL:      consoleURL("elasticache/home#redis:", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("ELASTICACHE_BACKUP"),
+	}, nil
+}
+
+func (c *ElastiCacheChecks) CheckClusterNetworkExposure(ctx context.Context) (CheckResult, error) {
+	clusters, err := c.describeCacheClusters(ctx, true)
+	if err != nil {
+		return CheckResult{}, err
+	}
+	clusters.CacheClusters = c.steadyCacheClusters(clusters.CacheClusters)
+
+	if len(clusters.CacheClusters) == 0 {
+		return CheckResult{
+			Control:    "CC6.1",
+			Name:       "ElastiCache Network Exposure",
+			Status:     "PASS",
+			Evidence:   "No ElastiCache clusters found",
+			ConsoleURL: consoleURL("elasticache/", consoleRegion(ctx)),
+			Priority:   PriorityInfo,
+			Timestamp:  nowFunc(),
+			Frameworks: GetFrameworkMappings("ELASTICACHE_NETWORK"),
+		}, nil
+	}
+
+	// Collect every security group referenced by a cluster so EC2 is queried once
+	groupIDs := []string{}
+	seen := map[string]bool{}
+	for _, cluster := range clusters.CacheClusters {
+		for _, sg := range cluster.SecurityGroups {
+			id := aws.ToString(sg.SecurityGroupId)
+			if id != "" && !seen[id] {
+				seen[id] = true
+				groupIDs = append(groupIDs, id)
+			}
+		}
+	}
+
+	groups := map[string]ec2types.SecurityGroup{}
+	if len(groupIDs) > 0 {
+		sgs, err := paginate(ctx, func(token *string) ([]ec2types.SecurityGroup, *string, error) {
+			out, err := c.ec2Client.DescribeSecurityGroups(ctx, &ec2.DescribeSecurityGroupsInput{
+				GroupIds:  groupIDs,
+				NextToken: token,
+			})
+			if err != nil {
+				return nil, nil, err
+			}
+			return out.SecurityGroups, out.NextToken, nil
+		})
+		if err != nil {
+			return CheckResult{}, err
+		}
+		for _, sg := range sgs {
+			groups[aws.ToString(sg.GroupId)] = sg
+		}
+	}
+
+	// Replication group members share their security groups
+	deployments := elastiCacheDeployments(clusters.CacheClusters)
+	exposed := []string{}
+	exposedListed := []string{}
+
+	for _, deployment := range deployments {
+		cluster, clusterID := deployment.cluster, deployment.label()
+		port := elastiCacheClusterPort(cluster)
+
+		for _, membership := range cluster.SecurityGroups {
+			sg, ok := groups[aws.ToString(membership.SecurityGroupId)]
+			if !ok {
+				continue
+			}
+			if securityGroupOpenOnPort(sg, port) {
+				exposed = append(exposed, deployment.id)
+				exposedListed = append(exposedListed, fmt.Sprintf("%s (%s port %d)", clusterID, aws.ToString(sg.GroupId), port))
+				break
+			}
+		}
+	}
+
+	if len(exposed) > 0 {
+		return CheckResult{
+			Control:           "CC6.1",
+			Name:              "ElastiCache Network Exposure",
+			Status:            "FAIL",
+			Severity:          "HIGH",
+			Evidence:          fmt.Sprintf("%d ElastiCache clusters allow ingress from 0.0.0.0/0 on the cache port: %s", len(exposed), TruncateList(exposedListed, evidenceListLimit(ctx))),
+			AffectedResources: exposed,
+			Remediation:       "Restrict ElastiCache security groups to application subnets or security groups",
+			RemediationDetail: "aws ec2 revoke-security-group-ingress --group-id [SG_ID] --protocol tcp --port [PORT] --cidr 0.0.0.0/0\nThen allow only the application security group: aws ec2 authorize-security-group-ingress --group-id [SG_ID] --protocol tcp --port [PORT] --source-group [APP_SG_ID]",
+			ScreenshotGuide:   "ElastiCache Console → Select cluster → Network and security → Security groups → Inbound rules → Screenshot showing no 0.0.0.0/0 source on the cache port",
+			ConsoleURL:        consoleURL("elasticache/", consoleRegion(ctx)),
+			Priority:          PriorityHigh,
+			Timestamp:         nowFunc(),
+			Frameworks:        GetFrameworkMappings("ELASTICACHE_NETWORK"),
+		}, nil
+	}
+
+	return CheckResult{
+		Control:         "CC6.1",
+		Name:            "ElastiCache Network Exposure",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d ElastiCache clusters restrict ingress on the cache port", len(deployments)),
+		ScreenshotGuide: "ElastiCache Console → Select cluster → Network and security → Security groups → Inbound rules → Screenshot showing no 0.0.0.0/0 source on the cache port",
+		ConsoleURL:      consoleURL("elasticache/", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("ELASTICACHE_NETWORK"),
+	}, nil
+}
+
+// CheckSubnetGroupPrivate flags clusters whose cache subnet group includes
+// subnets routed to an internet gateway. Encryption and AUTH do not help a
+// cache that sits in a public subnet one security group change away from the
+// internet. Clusters on the "default" subnet group, which uses the default
+// VPC's public subnets, are called out in the evidence.
+func (c *ElastiCacheChecks) CheckSubnetGroupPrivate(ctx context.Context) (CheckResult, error) {
+	clusters, err := c.describeCacheClusters(ctx, false)
+	if err != nil {
+		return CheckResult{}, err
+	}
+	clusters.CacheClusters = c.steadyCacheClusters(clusters.CacheClusters)
+
+	if len(clusters.CacheClusters) == 0 {
+		return CheckResult{
+			Control:    "CC6.1",
+			Name:       "ElastiCache Private Subnets",
+			Status:     "PASS",
+			Evidence:   "No ElastiCache clusters found",
+			ConsoleURL: consoleURL("elasticache/home#/subnet-groups", consoleRegion(ctx)),
+			Priority:   PriorityInfo,
+			Timestamp:  nowFunc(),
+			Frameworks: GetFrameworkMappings("ELASTICACHE_NETWORK"),
+		}, nil
+	}
+
+	groups, err := c.describeCacheSubnetGroups(ctx)
+	if err != nil {
+		return CheckResult{}, err
+	}
+	subnetGroups := map[string]elasticachetypes.CacheSubnetGroup{}
+	for _, group := range groups {
+		subnetGroups[aws.ToString(group.CacheSubnetGroupName)] = group
+	}
+
+	// Clusters share VPCs, so look each VPC's route tables up only once
+	routeTables := map[string][]ec2types.RouteTable{}
+
+	// Replication group members share their subnet group
+	deployments := elastiCacheDeployments(clusters.CacheClusters)
+	public := []string{}
+	publicListed := []string{}
+
+	for _, deployment := range deployments {
+		cluster, clusterID := deployment.cluster, deployment.label()
+		groupName := aws.ToString(cluster.CacheSubnetGroupName)
+
+		group, ok := subnetGroups[groupName]
+		if !ok {
+			continue
+		}
+
+		vpcID := aws.ToString(group.VpcId)
+		tables, ok := routeTables[vpcID]
+		if !ok {
+			out, err := c.ec2Client.DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{
+				Filters: []ec2types.Filter{{Name: aws.String("vpc-id"), Values: []string{vpcID}}},
+			})
+			if err != nil {
+				return CheckResult{}, err
+			}
+			tables = out.RouteTables
+			routeTables[vpcID] = tables
+		}
+
+		publicSubnets := []string{}
+		for _, subnet := range group.Subnets {
+			subnetID := aws.ToString(subnet.SubnetIdentifier)
+			if gateway := internetGatewayRoute(subnetRouteTable(tables, subnetID)); gateway != "" {
+				publicSubnets = append(publicSubnets, fmt.Sprintf("%s via %s", subnetID, gateway))
+			}
+		}
+		if len(publicSubnets) > 0 {
+			if groupName == "default" {
+				clusterID += " [default subnet group]"
+			}
+			public = append(public, deployment.id)
+			publicListed = append(publicListed, fmt.Sprintf("%s (%s)", clusterID, strings.Join(publicSubnets, ", ")))
+		}
+	}
+
+	if len(public) > 0 {
+		return CheckResult{
+			Control:           "CC6.1",
+			Name:              "ElastiCache Private Subnets",
+			Status:            "FAIL",
+			Severity:          "HIGH",
+			Evidence:          fmt.Sprintf("%d ElastiCache clusters are in subnets routed to an internet gateway, so only their security groups keep them off the internet: %s", len(public), TruncateList(publicListed, evidenceListLimit(ctx))),
+			AffectedResources: public,
+			Remediation:       "Create a cache subnet group of private subnets and move the clusters into it",
+			RemediationDetail: "1. Create private subnets whose route table has no internet gateway route\n2. aws elasticache create-cache-subnet-group --cache-subnet-group-name [GROUP] --cache-subnet-group-description \"Private cache subnets\" --subnet-ids [PRIVATE_SUBNET_IDS]\n3. Create a new cluster or replication group in the private subnet group (from a backup for Redis) and switch clients to its endpoint\nNote: A cluster's subnet group cannot be changed in place",
+			RequiresRecreate:  true,
+			ScreenshotGuide:   "ElastiCache Console → Subnet groups → Select the cluster's subnet group → Subnets, then VPC Console → Route tables → Routes for each subnet → Screenshot showing no igw- target",
+			ConsoleURL:        consoleURL("elasticache/home#/subnet-groups", consoleRegion(ctx)),
+			Priority:          PriorityHigh,
+			Timestamp:         nowFunc(),
+			Frameworks:        GetFrameworkMappings("ELASTICACHE_NETWORK"),
+		}, nil
+	}
+
+	return CheckResult{
+		Control:         "CC6.1",
+		Name:            "ElastiCache Private Subnets",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d ElastiCache clusters are in subnets without an internet gateway route", len(deployments)),
+		ScreenshotGuide: "ElastiCache Console → Subnet groups → Select the cluster's subnet group → Subnets, then VPC Console → Route tables → Routes for each subnet → Screenshot showing no igw- target",
+		ConsoleURL:      consoleURL("elasticache/home#/subnet-groups", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("ELASTICACHE_NETWORK"),
+	}, nil
+}
+
+// CheckReservationExpiry surfaces reserved cache nodes that run out within
+// reservationExpiryWarningDays. A lapsed reservation is not a
+// misconfiguration, but it silently moves the nodes to on-demand pricing, so
+// it is reported at LOW severity ahead of time.
+func (c *ElastiCacheChecks) CheckReservationExpiry(ctx context.Context) (CheckResult, error) {
+	nodes, err := paginate(ctx, func(token *string) ([]elasticachetypes.ReservedCacheNode, *string, error) {
+		out, err := c.client.DescribeReservedCacheNodes(ctx, &elasticache.DescribeReservedCacheNodesInput{Marker: token})
+		if err != nil {
+			return nil, nil, err
+		}
+		return out.ReservedCacheNodes, out.Marker, nil
+	})
+	if err != nil {
+		return CheckResult{}, err
+	}
+
+	expiring := []string{}
+	expiringListed := []string{}
+	active := 0
+	for _, node := range nodes {
+		if !strings.EqualFold(aws.ToString(node.State), "active") || node.StartTime == nil {
+			continue
+		}
+		active++
+		end := reservationEnd(*node.StartTime, int64(aws.ToInt32(node.Duration)))
+		if daysUntil(end) <= reservationExpiryWarningDays {
+			expiring = append(expiring, aws.ToString(node.ReservedCacheNodeId))
+			expiringListed = append(expiringListed, fmt.Sprintf("%s (%d x %s, %s)", aws.ToString(node.ReservedCacheNodeId), aws.ToInt32(node.CacheNodeCount), aws.ToString(node.CacheNodeType), expiryNote(end)))
+		}
+	}
+
+	if len(expiring) > 0 {
+		return CheckResult{
+			Control:           "A1.1",
+			Name:              "ElastiCache Reserved Node Expiry",
+			Status:            "FAIL",
+			Severity:          "LOW",
+			Evidence:          fmt.Sprintf("%d ElastiCache reserved node purchases expire within %d days: %s", len(expiring), reservationExpiryWarningDays, TruncateList(expiringListed, evidenceListLimit(ctx))),
+			AffectedResources: expiring,
+			Remediation:       "Renew expiring ElastiCache reserved nodes that are still needed",
+			RemediationDetail: "aws elasticache describe-reserved-cache-nodes-offerings --cache-node-type [NODE_TYPE]\naws elasticache purchase-reserved-cache-nodes-offering --reserved-cache-nodes-offering-id [OFFERING_ID] --cache-node-count [COUNT]",
+			ScreenshotGuide:   "ElastiCache Console → Reserved nodes → Screenshot showing the renewal plan for the expiring reservations",
+			ConsoleURL:        consoleURL("elasticache/home#/reserved-nodes", consoleRegion(ctx)),
+			Priority:          PriorityLow,
+			Timestamp:         nowFunc(),
+			Frameworks:        GetFrameworkMappings("ELASTICACHE_EXPIRY"),
+		}, nil
+	}
+
+	if active == 0 {
+		return CheckResult{
+			Control:    "A1.1",
+			Name:       "ElastiCache Reserved Node Expiry",
+			Status:     "PASS",
+			Evidence:   "No active ElastiCache reserved nodes found",
+			ConsoleURL: consoleURL("elasticache/home#/reserved-nodes", consoleRegion(ctx)),
+			Priority:   PriorityInfo,
+			Timestamp:  nowFunc(),
+			Frameworks: GetFrameworkMappings("ELASTICACHE_EXPIRY"),
+		}, nil
+	}
+
+	return CheckResult{
+		Control:    "A1.1",
+		Name:       "ElastiCache Reserved Node Expiry",
 		Status:     "PASS",
-		Evidence:   fmt.Sprintf("All %d Redis replication groups have adequate backup retention", len(repGroups.ReplicationGroups)),
+		Evidence:   fmt.Sprintf("None of the %d active ElastiCache reserved node purchases expire within %d days", active, reservationExpiryWarningDays),
+		ConsoleURL: consoleURL("elasticache/home#/reserved-nodes", consoleRegion(ctx)),
 		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("ELASTICACHE_BACKUP"),
+		Timestamp:  nowFunc(),
+		Frameworks: GetFrameworkMappings("ELASTICACHE_EXPIRY"),
 	}, nil
 }
+
+// describeCacheSubnetGroups lists every cache subnet group, across all pages
+func (c *ElastiCacheChecks) describeCacheSubnetGroups(ctx context.Context) ([]elasticachetypes.CacheSubnetGroup, error) {
+	return paginate(ctx, func(token *string) ([]elasticachetypes.CacheSubnetGroup, *string, error) {
+		out, err := c.client.DescribeCacheSubnetGroups(ctx, &elasticache.DescribeCacheSubnetGroupsInput{Marker: token})
+		if err != nil {
+			return nil, nil, err
+		}
+		return out.CacheSubnetGroups, out.Marker, nil
+	})
+}
+
+// elastiCacheClusterPort returns the port the cluster listens on, falling back
+// to the engine default when node endpoints are not yet available
+func elastiCacheClusterPort(cluster elasticachetypes.CacheCluster) int32 {
+	if cluster.ConfigurationEndpoint != nil && cluster.ConfigurationEndpoint.Port != nil {
+		return aws.ToInt32(cluster.ConfigurationEndpoint.Port)
+	}
+	for _, node := range cluster.CacheNodes {
+		if node.Endpoint != nil && node.Endpoint.Port != nil {
+			return aws.ToInt32(node.Endpoint.Port)
+		}
+	}
+	if aws.ToString(cluster.Engine) == "memcached" {
+		return 11211
+	}
+	return 6379
+}
+
+// securityGroupOpenOnPort reports whether the group allows TCP ingress to
+// port from anywhere on the internet (IPv4 or IPv6). Clients reach the cache
+// over TCP, so UDP or ICMP rules on the same port range do not expose it.
+func securityGroupOpenOnPort(sg ec2types.SecurityGroup, port int32) bool {
+	for _, rule := range sg.IpPermissions {
+		protocol := aws.ToString(rule.IpProtocol)
+		if protocol != "tcp" && protocol != "-1" {
+			continue
+		}
+		if protocol == "tcp" {
+			if rule.FromPort == nil || rule.ToPort == nil {
+				continue
+			}
+			if port < aws.ToInt32(rule.FromPort) || port > aws.ToInt32(rule.ToPort) {
+				continue
+			}
+		}
+
+		for _, ipRange := range rule.IpRanges {
+			if aws.ToString(ipRange.CidrIp) == "0.0.0.0/0" {
+				return true
+			}
+		}
+		for _, ipv6Range := range rule.Ipv6Ranges {
+			if aws.ToString(ipv6Range.CidrIpv6) == "::/0" {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,379 @@
+package checks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/elasticache"
+)
+
+// elastiCacheStub answers ElastiCache query-protocol calls with canned XML
+// results by Action; other actions get an empty result
+type elastiCacheStub map[string]string
+
+func (s elastiCacheStub) Do(req *http.Request) (*http.Response, error) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, err
+	}
+
+	action := form.Get("Action")
+	xml := fmt.Sprintf("<%sResponse><%sResult>%s</%sResult></%sResponse>", action, action, s[action], action, action)
+	return &http.Response{
+		StatusCode:    http.StatusOK,
+		Status:        "200 OK",
+		Header:        http.Header{"Content-Type": []string{"text/xml"}},
+		Body:          io.NopCloser(strings.NewReader(xml)),
+		ContentLength: int64(len(xml)),
+		Request:       req,
+	}, nil
+}
+
+func newStubElastiCacheChecks(responses elastiCacheStub) *ElastiCacheChecks {
+	client := elasticache.New(elasticache.Options{
+		Region:      "us-east-1",
+		Credentials: aws.AnonymousCredentials{},
+		HTTPClient:  responses,
+		Retryer:     aws.NopRetryer{},
+	})
+	return NewElastiCacheChecks(client, nil)
+}
+
+// newScanContext returns a context with a fresh Scan, so the memoized
+// listings of one stub are not answered from another test's
+func newScanContext() context.Context {
+	return WithScan(context.Background(), NewScan(ScanOptions{}))
+}
+
+// cacheClusterXML is one DescribeCacheClusters member
+func cacheClusterXML(id, engine, version string, atRest, transit bool) string {
+	return fmt.Sprintf("<CacheCluster><CacheClusterId>%s</CacheClusterId><Engine>%s</Engine><EngineVersion>%s</EngineVersion><CacheClusterStatus>available</CacheClusterStatus><AtRestEncryptionEnabled>%t</AtRestEncryptionEnabled><TransitEncryptionEnabled>%t</TransitEncryptionEnabled></CacheCluster>",
+		id, engine, version, atRest, transit)
+}
+
+func TestElastiCacheMemcachedOnlyIsNotApplicable(t *testing.T) {
+	c := newStubElastiCacheChecks(elastiCacheStub{
+		"DescribeCacheClusters": "<CacheClusters>" +
+			cacheClusterXML("sessions", "memcached", "1.6.6", false, false) +
+			cacheClusterXML("pages", "memcached", "1.5.16", false, false) +
+			"</CacheClusters>",
+	})
+
+	checks := map[string]func(context.Context) (CheckResult, error){
+		"encryption at rest":    c.CheckEncryptionAtRest,
+		"encryption in transit": c.CheckEncryptionInTransit,
+	}
+	ctx := newScanContext()
+	for name, check := range checks {
+		result, err := check(ctx)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if result.Status != "NOT_APPLICABLE" {
+			t.Errorf("%s: status %s, want NOT_APPLICABLE: %s", name, result.Status, result.Evidence)
+		}
+		if !strings.Contains(result.Evidence, "All 2 ElastiCache clusters run Memcached") {
+			t.Errorf("%s: evidence %q should name both Memcached clusters", name, result.Evidence)
+		}
+		if errs := ValidateResults([]CheckResult{result}); len(errs) > 0 {
+			t.Errorf("%s: invalid result: %v", name, errs)
+		}
+	}
+
+	// Redis-only replication group checks have nothing to fail on
+	redisChecks := map[string]func(context.Context) (CheckResult, error){
+		"auth token":       c.CheckAuthToken,
+		"rbac":             c.CheckRedisRBAC,
+		"backup retention": c.CheckBackupRetention,
+	}
+	for name, check := range redisChecks {
+		result, err := check(ctx)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if result.Status == "FAIL" {
+			t.Errorf("%s: Memcached clusters failed a Redis-only control: %s", name, result.Evidence)
+		}
+	}
+}
+
+func TestElastiCacheMemcachedExcludedFromRedisFindings(t *testing.T) {
+	c := newStubElastiCacheChecks(elastiCacheStub{
+		"DescribeCacheClusters": "<CacheClusters>" +
+			cacheClusterXML("sessions", "memcached", "1.6.6", false, false) +
+			cacheClusterXML("queue", "redis", "7.1.0", false, true) +
+			"</CacheClusters>",
+	})
+
+	result, err := c.CheckEncryptionAtRest(newScanContext())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Status != "FAIL" {
+		t.Fatalf("status %s, want FAIL: %s", result.Status, result.Evidence)
+	}
+	if want := []string{"queue"}; !reflect.DeepEqual(result.AffectedResources, want) {
+		t.Errorf("affected resources %v, want only the Redis cluster %v", result.AffectedResources, want)
+	}
+	if !strings.Contains(result.Evidence, "1 Memcached clusters not applicable") {
+		t.Errorf("evidence %q should list the Memcached cluster as not applicable", result.Evidence)
+	}
+
+	// Memcached from 1.6.12 supports TLS, so it is evaluated, not skipped
+	c = newStubElastiCacheChecks(elastiCacheStub{
+		"DescribeCacheClusters": "<CacheClusters>" +
+			cacheClusterXML("sessions", "memcached", "1.6.17", false, false) +
+			"</CacheClusters>",
+	})
+	result, err = c.CheckEncryptionInTransit(newScanContext())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Status != "FAIL" {
+		t.Errorf("Memcached 1.6.17 without TLS: status %s, want FAIL: %s", result.Status, result.Evidence)
+	}
+}
+
+func TestElastiCachePendingFixOnlyInEvidence(t *testing.T) {
+	pending := strings.Replace(cacheClusterXML("queue", "redis", "7.1.0", true, false),
+		"</CacheCluster>", "<PendingModifiedValues><TransitEncryptionEnabled>true</TransitEncryptionEnabled></PendingModifiedValues></CacheCluster>", 1)
+	c := newStubElastiCacheChecks(elastiCacheStub{
+		"DescribeCacheClusters": "<CacheClusters>" + pending + "</CacheClusters>",
+	})
+
+	result, err := c.CheckEncryptionInTransit(newScanContext())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Status != "FAIL" {
+		t.Fatalf("status %s, want FAIL until the fix is applied: %s", result.Status, result.Evidence)
+	}
+	if !strings.Contains(result.Evidence, "queue (in-transit encryption enablement pending)") {
+		t.Errorf("evidence %q should note the pending fix", result.Evidence)
+	}
+	if want := []string{"queue"}; !reflect.DeepEqual(result.AffectedResources, want) {
+		t.Errorf("affected resources %v, want the bare ID %v", result.AffectedResources, want)
+	}
+}
+
+func TestElastiCacheReplicationGroupListsBareID(t *testing.T) {
+	member := func(id string) string {
+		return strings.Replace(cacheClusterXML(id, "redis", "5.0.6", false, true),
+			"</CacheClusterId>", "</CacheClusterId><ReplicationGroupId>sessions</ReplicationGroupId>", 1)
+	}
+	c := newStubElastiCacheChecks(elastiCacheStub{
+		"DescribeCacheClusters": "<CacheClusters>" + member("sessions-001") + member("sessions-002") + "</CacheClusters>",
+	})
+
+	checks := map[string]func(context.Context) (CheckResult, error){
+		"encryption at rest": c.CheckEncryptionAtRest,
+		"auto upgrade":       c.CheckAutoMinorVersionUpgrade,
+		"engine version":     c.CheckEngineVersionSupported,
+	}
+	ctx := newScanContext()
+	for name, check := range checks {
+		result, err := check(ctx)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if result.Status != "FAIL" {
+			t.Fatalf("%s: status %s, want FAIL: %s", name, result.Status, result.Evidence)
+		}
+		if want := []string{"sessions"}; !reflect.DeepEqual(result.AffectedResources, want) {
+			t.Errorf("%s: affected resources %v, want the bare group ID %v", name, result.AffectedResources, want)
+		}
+		if !strings.Contains(result.Evidence, "sessions (replication group, 2 nodes)") {
+			t.Errorf("%s: evidence %q should describe the group", name, result.Evidence)
+		}
+	}
+}
+
+func TestSecurityGroupOpenOnPortRequiresTCP(t *testing.T) {
+	rule := func(protocol string, from, to int32) ec2types.IpPermission {
+		return ec2types.IpPermission{
+			IpProtocol: aws.String(protocol),
+			FromPort:   aws.Int32(from),
+			ToPort:     aws.Int32(to),
+			IpRanges:   []ec2types.IpRange{{CidrIp: aws.String("0.0.0.0/0")}},
+		}
+	}
+	for _, tc := range []struct {
+		name string
+		rule ec2types.IpPermission
+		open bool
+	}{
+		{"tcp on the port", rule("tcp", 6379, 6379), true},
+		{"tcp range around the port", rule("tcp", 6000, 7000), true},
+		{"tcp on another port", rule("tcp", 22, 22), false},
+		{"all traffic", rule("-1", -1, -1), true},
+		{"udp on the port", rule("udp", 6379, 6379), false},
+		{"icmp", rule("icmp", -1, -1), false},
+	} {
+		sg := ec2types.SecurityGroup{GroupId: aws.String("sg-1"), IpPermissions: []ec2types.IpPermission{tc.rule}}
+		if got := securityGroupOpenOnPort(sg, 6379); got != tc.open {
+			t.Errorf("%s: open = %t, want %t", tc.name, got, tc.open)
+		}
+	}
+}
+
+// elastiCacheCountingStub serves elastiCacheStub's responses and counts the
+// calls made for each Action
+type elastiCacheCountingStub struct {
+	responses elastiCacheStub
+
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func (s *elastiCacheCountingStub) Do(req *http.Request) (*http.Response, error) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	s.calls[form.Get("Action")]++
+	s.mu.Unlock()
+
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return s.responses.Do(req)
+}
+
+func TestElastiCacheListingsMemoizedPerScan(t *testing.T) {
+	stub := &elastiCacheCountingStub{
+		responses: elastiCacheStub{
+			"DescribeCacheClusters": "<CacheClusters>" + cacheClusterXML("queue", "redis", "7.1.0", true, true) + "</CacheClusters>",
+		},
+		calls: map[string]int{},
+	}
+	c := NewElastiCacheChecks(elasticache.New(elasticache.Options{
+		Region:      "us-east-1",
+		Credentials: aws.AnonymousCredentials{},
+		HTTPClient:  stub,
+		Retryer:     aws.NopRetryer{},
+	}), nil)
+
+	listingChecks := []func(context.Context) (CheckResult, error){
+		c.CheckEncryptionAtRest,
+		c.CheckEncryptionInTransit,
+		c.CheckAutoMinorVersionUpgrade,
+		c.CheckAuthToken,
+		c.CheckRedisRBAC,
+		c.CheckBackupRetention,
+	}
+	scan := NewScan(ScanOptions{AccountID: "123456789012"})
+	ctx := WithScan(context.Background(), scan)
+	for _, check := range listingChecks {
+		if _, err := check(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := stub.calls["DescribeCacheClusters"]; got != 1 {
+		t.Errorf("DescribeCacheClusters called %d times in one scan, want 1", got)
+	}
+	if got := stub.calls["DescribeReplicationGroups"]; got != 1 {
+		t.Errorf("DescribeReplicationGroups called %d times in one scan, want 1", got)
+	}
+	if hits, misses := scan.DescribeCacheStats(); hits != 4 || misses != 2 {
+		t.Errorf("cache stats %d hits, %d misses, want 4 hits and 2 misses", hits, misses)
+	}
+
+	// A new scan lists again rather than reusing the last scan's clusters
+	if _, err := c.CheckEncryptionAtRest(newScanContext()); err != nil {
+		t.Fatal(err)
+	}
+	if got := stub.calls["DescribeCacheClusters"]; got != 2 {
+		t.Errorf("DescribeCacheClusters called %d times across two scans, want 2", got)
+	}
+}
+
+func TestCorrelateOpenRedis(t *testing.T) {
+	soc2 := map[string]string{FrameworkSOC2: "CC6.6"}
+	open := CheckResult{Control: "CC6.6", Name: openRedisCheckName, Status: "FAIL", Severity: "CRITICAL", AffectedResources: []string{"sessions", "queue"}, Frameworks: soc2}
+	correlated := func(resources ...string) CheckResult {
+		result := failingResult("CC6.4", "ElastiCache Encryption in Transit", resources, "")
+		result.Severity = "HIGH"
+		result.Priority = PriorityHigh
+		result.Frameworks = soc2
+		return result
+	}
+
+	tests := map[string]struct {
+		result       CheckResult
+		status       string
+		severity     string
+		affected     []string
+		evidenceNote string
+		failed       int // failing results the score counts, the open Redis one included
+	}{
+		"full overlap": {
+			result:       correlated("sessions", "queue"),
+			status:       "INFO",
+			affected:     []string{"sessions", "queue"},
+			evidenceNote: fmt.Sprintf(" (all also reported as CRITICAL under %q)", openRedisCheckName),
+			failed:       1,
+		},
+		"partial overlap": {
+			result:       correlated("sessions", "cache"),
+			status:       "FAIL",
+			severity:     "HIGH",
+			affected:     []string{"cache"},
+			evidenceNote: fmt.Sprintf(" (1 of these also reported as CRITICAL under %q)", openRedisCheckName),
+			failed:       2,
+		},
+		"no overlap": {
+			result:   correlated("cache", "search"),
+			status:   "FAIL",
+			severity: "HIGH",
+			affected: []string{"cache", "search"},
+			failed:   2,
+		},
+	}
+	for name, tt := range tests {
+		evidence := tt.result.Evidence
+		got := correlateOpenRedis([]CheckResult{tt.result}, open)
+		if len(got) != 2 || got[1].Name != openRedisCheckName {
+			t.Fatalf("%s: got %d results, want the correlated result followed by the open Redis one", name, len(got))
+		}
+		result := got[0]
+		if result.Status != tt.status || result.Severity != tt.severity {
+			t.Errorf("%s: %s %q, want %s %q", name, result.Status, result.Severity, tt.status, tt.severity)
+		}
+		if want := evidence + tt.evidenceNote; result.Evidence != want {
+			t.Errorf("%s: evidence %q, want %q", name, result.Evidence, want)
+		}
+		if !reflect.DeepEqual(result.AffectedResources, tt.affected) {
+			t.Errorf("%s: affected resources %v, want %v", name, result.AffectedResources, tt.affected)
+		}
+		if errs := ValidateResults(got); len(errs) > 0 {
+			t.Errorf("%s: invalid results: %v", name, errs)
+		}
+		if score := ScoreFrameworks(got)[FrameworkSOC2]; score.Failed != tt.failed {
+			t.Errorf("%s: score counts %d failures, want %d", name, score.Failed, tt.failed)
+		}
+	}
+
+	// A passing open Redis check correlates nothing
+	unchanged := correlated("sessions")
+	got := correlateOpenRedis([]CheckResult{unchanged}, CheckResult{Name: openRedisCheckName, Status: "PASS"})
+	if !reflect.DeepEqual(got[0], unchanged) {
+		t.Errorf("passing open Redis check changed %+v", got[0])
+	}
+}
@@ -0,0 +1,212 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Environments a resource is classified into from its environment tag
+const (
+	EnvironmentProd    = "prod"
+	EnvironmentStaging = "staging"
+	EnvironmentDev     = "dev"
+)
+
+// DefaultEnvironmentTagKeys are the tag keys read, in order, to classify a
+// resource when no EnvironmentPolicy sets its own
+var DefaultEnvironmentTagKeys = []string{"Environment", "Env", "Stage"}
+
+// SetEnvironmentTagKeys sets the tag keys runs without a Scan read to
+// classify resources. Empty keys restore DefaultEnvironmentTagKeys.
+func SetEnvironmentTagKeys(keys []string) {
+	defaultScan.setEnvironmentTagKeys(keys)
+}
+
+func (s *Scan) setEnvironmentTagKeys(keys []string) {
+	if len(keys) == 0 {
+		keys = DefaultEnvironmentTagKeys
+	}
+	s.tagKeysMu.Lock()
+	defer s.tagKeysMu.Unlock()
+	s.tagKeys = keys
+}
+
+// environmentTagKeys returns the tag keys the scan classifies resources by
+func (s *Scan) environmentTagKeys() []string {
+	s.tagKeysMu.Lock()
+	defer s.tagKeysMu.Unlock()
+	return s.tagKeys
+}
+
+// environmentAliases maps common tag values to the environment they mean
+var environmentAliases = map[string]string{
+	"prod":        EnvironmentProd,
+	"prd":         EnvironmentProd,
+	"production":  EnvironmentProd,
+	"live":        EnvironmentProd,
+	"staging":     EnvironmentStaging,
+	"stage":       EnvironmentStaging,
+	"stg":         EnvironmentStaging,
+	"preprod":     EnvironmentStaging,
+	"uat":         EnvironmentStaging,
+	"qa":          EnvironmentStaging,
+	"dev":         EnvironmentDev,
+	"development": EnvironmentDev,
+	"test":        EnvironmentDev,
+	"sandbox":     EnvironmentDev,
+}
+
+// NormalizeEnvironment maps an environment tag value to prod, staging or dev,
+// e.g. "Production" to "prod". Unrecognized values are returned lowercased.
+func NormalizeEnvironment(value string) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if env, ok := environmentAliases[value]; ok {
+		return env
+	}
+	return value
+}
+
+// environmentFromTags classifies a resource by the first of ctx's environment
+// tag keys it carries (tag keys match case-insensitively). Untagged resources
+// are unclassified ("").
+func environmentFromTags(ctx context.Context, tags map[string]string) string {
+	for _, want := range scanFrom(ctx).environmentTagKeys() {
+		for key, value := range tags {
+			if strings.EqualFold(key, want) {
+				return NormalizeEnvironment(value)
+			}
+		}
+	}
+	return ""
+}
+
+// environmentRank orders environments by how much their findings matter.
+// Unrecognized environments rank with prod, so they are never down-weighted
+// by accident.
+var environmentRank = map[string]int{
+	EnvironmentDev:     1,
+	EnvironmentStaging: 2,
+}
+
+// resourceEnvironments collects the environments of a check's failing
+// resources, to classify its result. The zero value is ready to use.
+type resourceEnvironments struct {
+	envs         []string
+	unclassified bool
+}
+
+// add records the environment of one failing resource ("" if unclassified)
+func (e *resourceEnvironments) add(env string) {
+	if env == "" {
+		e.unclassified = true
+		return
+	}
+	e.envs = append(e.envs, env)
+}
+
+// environment returns the most production-like environment of the failing
+// resources, so a result is only down-weighted when nothing failing is in a
+// more important environment. Any unclassified resource leaves the result
+// unclassified.
+func (e *resourceEnvironments) environment() string {
+	if e.unclassified || len(e.envs) == 0 {
+		return ""
+	}
+	result := e.envs[0]
+	for _, env := range e.envs[1:] {
+		if rankEnvironment(env) > rankEnvironment(result) {
+			result = env
+		}
+	}
+	return result
+}
+
+func rankEnvironment(env string) int {
+	if rank, ok := environmentRank[env]; ok {
+		return rank
+	}
+	return 3
+}
+
+// EnvironmentPolicy classifies results by environment and re-rates failures
+// in less important environments. Default classifies results whose
+// resources carry no environment tag, e.g. "dev" for a dev-only account.
+//
+//	tag_keys: [Environment, env]
+//	default: prod
+//	downgrades:
+//	  dev:
+//	    CRITICAL: MEDIUM
+//	    HIGH: LOW
+//	  staging:
+//	    CRITICAL: HIGH
+type EnvironmentPolicy struct {
+	TagKeys    []string                     `yaml:"tag_keys"`
+	Default    string                       `yaml:"default"`
+	Downgrades map[string]map[string]string `yaml:"downgrades"`
+}
+
+// LoadEnvironmentPolicy reads and validates an environment policy from a
+// YAML file. Environment names are normalized (see NormalizeEnvironment).
+func LoadEnvironmentPolicy(path string) (EnvironmentPolicy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return EnvironmentPolicy{}, fmt.Errorf("failed to read environment policy file: %w", err)
+	}
+
+	var policy EnvironmentPolicy
+	if err := yaml.Unmarshal(data, &policy); err != nil {
+		return EnvironmentPolicy{}, fmt.Errorf("failed to parse environment policy YAML: %w", err)
+	}
+
+	policy.Default = NormalizeEnvironment(policy.Default)
+	downgrades := map[string]map[string]string{}
+	for env, severities := range policy.Downgrades {
+		normalized := map[string]string{}
+		for from, to := range severities {
+			from = strings.ToUpper(strings.TrimSpace(from))
+			to = strings.ToUpper(strings.TrimSpace(to))
+			if !validSeverities[from] || !validSeverities[to] {
+				return EnvironmentPolicy{}, fmt.Errorf("environment policy %s: invalid downgrade %q → %q (use CRITICAL, HIGH, MEDIUM or LOW)", env, from, to)
+			}
+			normalized[from] = to
+		}
+		downgrades[NormalizeEnvironment(env)] = normalized
+	}
+	policy.Downgrades = downgrades
+
+	return policy, nil
+}
+
+// ApplyEnvironmentPolicy classifies unclassified results as policy.Default
+// and rewrites the severity (and matching priority) of failures in an
+// environment with a downgrade, keeping the check's own value in
+// OriginalSeverity. Returns the number of results re-rated.
+func ApplyEnvironmentPolicy(results []CheckResult, policy EnvironmentPolicy) int {
+	changed := 0
+	for i := range results {
+		result := &results[i]
+		if result.Environment == "" {
+			result.Environment = policy.Default
+		}
+		if result.Status != "FAIL" || result.Severity == "" {
+			continue
+		}
+
+		severity := policy.Downgrades[result.Environment][strings.ToUpper(result.Severity)]
+		if severity == "" || strings.EqualFold(severity, result.Severity) {
+			continue
+		}
+		if result.OriginalSeverity == "" {
+			result.OriginalSeverity = result.Severity
+		}
+		result.Severity = severity
+		result.Priority = priorityForSeverity(severity)
+		changed++
+	}
+	return changed
+}
@@ -0,0 +1,156 @@
+package checks
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/smithy-go"
+	"github.com/guardian-nexus/auditkit/scanner/pkg/core"
+)
+
+// Kinds of check failure, matched with errors.Is against the errors checks,
+// RunStream and the Runner return (see ClassifyError)
+var (
+	ErrAccessDenied       = core.ErrAccessDenied
+	ErrThrottled          = core.ErrThrottled
+	ErrServiceUnavailable = core.ErrServiceUnavailable
+)
+
+// accessDeniedCodes are AWS error codes for missing permissions or rejected
+// credentials
+var accessDeniedCodes = map[string]bool{
+	"AccessDenied":                true,
+	"AccessDeniedException":       true,
+	"UnauthorizedOperation":       true,
+	"UnauthorizedAccess":          true,
+	"AuthorizationError":          true,
+	"AuthFailure":                 true,
+	"InvalidClientTokenId":        true,
+	"SignatureDoesNotMatch":       true,
+	"UnrecognizedClientException": true,
+	"ExpiredToken":                true,
+	"ExpiredTokenException":       true,
+	"RequestExpired":              true,
+}
+
+// throttledCodes are AWS error codes for rate limiting that outlasted the
+// SDK's retries
+var throttledCodes = map[string]bool{
+	"Throttling":                             true,
+	"ThrottlingException":                    true,
+	"ThrottledException":                     true,
+	"RequestThrottled":                       true,
+	"RequestThrottledException":              true,
+	"RequestLimitExceeded":                   true,
+	"TooManyRequestsException":               true,
+	"SlowDown":                               true,
+	"ProvisionedThroughputExceededException": true,
+}
+
+// serviceUnavailableCodes are AWS error codes for a service that is down or
+// not offered, as opposed to a failed request
+var serviceUnavailableCodes = map[string]bool{
+	"ServiceUnavailable":          true,
+	"ServiceUnavailableException": true,
+	"OptInRequired":               true,
+}
+
+// notConfiguredCodes are AWS error codes that mean the requested setting was
+// never configured, which is a finding, rather than that it could not be read
+var notConfiguredCodes = map[string]bool{
+	"NoSuchBucketPolicy":                             true,
+	"NoSuchPublicAccessBlockConfiguration":           true,
+	"ServerSideEncryptionConfigurationNotFoundError": true,
+	"NoSuchLifecycleConfiguration":                   true,
+	"ReplicationConfigurationNotFoundError":          true,
+	"ObjectLockConfigurationNotFoundError":           true,
+	"NoSuchTagSet":                                   true,
+}
+
+// isNotConfigured reports whether err is AWS saying the setting asked for
+// does not exist. Any other error (access denied, throttling, a redirect to
+// another region) leaves the setting unknown.
+func isNotConfigured(err error) bool {
+	var apiErr smithy.APIError
+	return errors.As(err, &apiErr) && notConfiguredCodes[apiErr.ErrorCode()]
+}
+
+// ClassifyError wraps an AWS SDK error in a *core.ScanError of the matching
+// kind, so errors.Is(err, ErrThrottled) and friends work on it while the SDK
+// error stays reachable with errors.As. Errors that are nil, already
+// classified or of no known kind are returned unchanged.
+func ClassifyError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var scanErr *core.ScanError
+	if errors.As(err, &scanErr) {
+		return err
+	}
+
+	kind := errorKind(err)
+	if kind == nil {
+		return err
+	}
+	return &core.ScanError{Kind: kind, Err: err}
+}
+
+// errorKind returns the kind of err, or nil if it is none of them
+func errorKind(err error) error {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		code := apiErr.ErrorCode()
+		switch {
+		case accessDeniedCodes[code]:
+			return ErrAccessDenied
+		case throttledCodes[code]:
+			return ErrThrottled
+		case serviceUnavailableCodes[code]:
+			return ErrServiceUnavailable
+		}
+	}
+	if isServiceUnavailableInRegion(err) {
+		return ErrServiceUnavailable
+	}
+	return nil
+}
+
+// ResetCheckErrors forgets the check errors recorded by runs without a Scan,
+// ready for a new scan
+func ResetCheckErrors() {
+	defaultScan.errorsMu.Lock()
+	defer defaultScan.errorsMu.Unlock()
+	defaultScan.checkErrors = map[string]string{}
+}
+
+// CheckErrorCounts returns how many checks run without a Scan errored since
+// the last ResetCheckErrors, by core.ErrorKindName
+func CheckErrorCounts() map[string]int {
+	return defaultScan.CheckErrorCounts()
+}
+
+// CheckErrorCounts returns how many checks errored in the scan, by
+// core.ErrorKindName. A check that errors in several regions counts once,
+// with the kind of its latest error. Run methods drop a failed check's
+// result, so this is how reports tell "nothing found" from "could not look".
+func (s *Scan) CheckErrorCounts() map[string]int {
+	s.errorsMu.Lock()
+	defer s.errorsMu.Unlock()
+
+	counts := map[string]int{}
+	for _, kind := range s.checkErrors {
+		counts[kind]++
+	}
+	return counts
+}
+
+// recordCheckError notes that check id failed with err. Cancellation is the
+// scan stopping, which the metadata already reports, not a check failure.
+func (s *Scan) recordCheckError(id string, err error) {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return
+	}
+	s.errorsMu.Lock()
+	defer s.errorsMu.Unlock()
+	s.checkErrors[id] = core.ErrorKindName(err)
+}
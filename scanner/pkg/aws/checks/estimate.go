@@ -0,0 +1,50 @@
+package checks
+
+import "context"
+
+// CallEstimate is a check module's predicted AWS API usage for one scan.
+// Resources is what the module's cheap list call found; Known is false for
+// modules that cannot estimate their usage.
+type CallEstimate struct {
+	Service   string `json:"service"`
+	Resources int    `json:"resources"`
+	Calls     int    `json:"calls"`
+	Known     bool   `json:"known"`
+}
+
+// Estimator is implemented by check modules whose API usage grows with the
+// number of resources (Describe-per-resource services). EstimateCalls may make
+// a few list calls but must not run the checks themselves.
+type Estimator interface {
+	EstimateCalls(ctx context.Context) (CallEstimate, error)
+}
+
+// EstimateModules returns a call estimate for every module, in order. Modules
+// that do not implement Estimator, or whose list call fails, are not Known.
+func EstimateModules(ctx context.Context, modules []Check) []CallEstimate {
+	estimates := make([]CallEstimate, 0, len(modules))
+	for _, module := range modules {
+		estimate := CallEstimate{Service: module.Name()}
+		if estimator, ok := module.(Estimator); ok {
+			if e, err := estimator.EstimateCalls(ctx); err == nil {
+				estimate = e
+				estimate.Service = module.Name()
+				estimate.Known = true
+			}
+		}
+		estimates = append(estimates, estimate)
+	}
+	return estimates
+}
+
+// TotalCalls sums the Known estimates and reports how many modules were unknown
+func TotalCalls(estimates []CallEstimate) (calls int, unknown int) {
+	for _, e := range estimates {
+		if e.Known {
+			calls += e.Calls
+		} else {
+			unknown++
+		}
+	}
+	return calls, unknown
+}
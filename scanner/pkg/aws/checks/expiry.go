@@ -0,0 +1,31 @@
+package checks
+
+import (
+	"fmt"
+	"time"
+)
+
+// Warning windows for the expiry checks: reservations are flagged a month
+// ahead, leaving time to budget and buy a renewal; manual snapshots a week
+// ahead, leaving time to copy or extend them
+const (
+	reservationExpiryWarningDays = 30
+	snapshotExpiryWarningDays    = 7
+)
+
+// reservationEnd returns when a reservation that started at start and runs
+// for durationSeconds (as the reserved node APIs report it) ends
+func reservationEnd(start time.Time, durationSeconds int64) time.Time {
+	return start.Add(time.Duration(durationSeconds) * time.Second)
+}
+
+// daysUntil returns the whole days from now until t, negative once t is past
+func daysUntil(t time.Time) int {
+	return int(t.Sub(nowFunc()).Hours() / 24)
+}
+
+// expiryNote describes an expiry for Evidence, e.g. "expires 2026-11-01, in
+// 16 days"
+func expiryNote(end time.Time) string {
+	return fmt.Sprintf("expires %s, in %d days", end.UTC().Format("2006-01-02"), max(0, daysUntil(end)))
+}
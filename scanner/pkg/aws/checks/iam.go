@@ -28,81 +28,81 @@ func (c *IAMChecks) Run(ctx context.Context) ([]CheckResult, error) {
 	results := []CheckResult{}
 
 	// Existing checks
-	if result, err := c.CheckRootMFA(ctx); err == nil {
+	if result, err := runCheck(ctx, "iam.root_mfa", c.CheckRootMFA); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckPasswordPolicy(ctx); err == nil {
+	if result, err := runCheck(ctx, "iam.password_policy", c.CheckPasswordPolicy); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckAccessKeyRotation(ctx); err == nil {
+	if result, err := runCheck(ctx, "iam.access_key_rotation", c.CheckAccessKeyRotation); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckUnusedCredentials(ctx); err == nil {
+	if result, err := runCheck(ctx, "iam.unused_credentials", c.CheckUnusedCredentials); err == nil {
 		results = append(results, result)
 	}
 
 	// NEW CIS checks
-	if result, err := c.CheckRootAccessKeys(ctx); err == nil {
+	if result, err := runCheck(ctx, "iam.root_access_keys", c.CheckRootAccessKeys); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckIAMUsersMFA(ctx); err == nil {
+	if result, err := runCheck(ctx, "iam.users_mfa", c.CheckIAMUsersMFA); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckCredentialsUnused90Days(ctx); err == nil {
+	if result, err := runCheck(ctx, "iam.credentials_unused_90_days", c.CheckCredentialsUnused90Days); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckOneActiveAccessKey(ctx); err == nil {
+	if result, err := runCheck(ctx, "iam.one_active_access_key", c.CheckOneActiveAccessKey); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckIAMPoliciesAttached(ctx); err == nil {
+	if result, err := runCheck(ctx, "iam.policies_attached", c.CheckIAMPoliciesAttached); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckHardwareMFARoot(ctx); err == nil {
+	if result, err := runCheck(ctx, "iam.hardware_mfa_root", c.CheckHardwareMFARoot); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckSupportRole(ctx); err == nil {
+	if result, err := runCheck(ctx, "iam.support_role", c.CheckSupportRole); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckIAMInstanceRoles(ctx); err == nil {
+	if result, err := runCheck(ctx, "iam.instance_roles", c.CheckIAMInstanceRoles); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckIAMPoliciesOnGroupsOnly(ctx); err == nil {
+	if result, err := runCheck(ctx, "iam.policies_on_groups_only", c.CheckIAMPoliciesOnGroupsOnly); err == nil {
 		results = append(results, result)
 	}
 
 	// Additional CIS AWS controls for better coverage
-	if result, err := c.CheckPasswordExpiration(ctx); err == nil {
+	if result, err := runCheck(ctx, "iam.password_expiration", c.CheckPasswordExpiration); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckPasswordReusePrevention(ctx); err == nil {
+	if result, err := runCheck(ctx, "iam.password_reuse_prevention", c.CheckPasswordReusePrevention); err == nil {
 		results = append(results, result)
 	}
 
 	// CIS AWS 1.1, 1.2, 1.18, 1.22 - Final IAM controls for 100%
 	results = append(results, c.CheckAccountContactDetails(ctx))
 	results = append(results, c.CheckSecurityContactInfo(ctx))
-	if result, err := c.CheckIAMRolesSeparation(ctx); err == nil {
+	if result, err := runCheck(ctx, "iam.roles_separation", c.CheckIAMRolesSeparation); err == nil {
 		results = append(results, result)
 	}
 	results = append(results, c.CheckIAMUserAccessReview(ctx))
 
 	// NEW CIS controls - v0.7.0 additions
-	if result, err := c.CheckCredentialsUnused45Days(ctx); err == nil {
+	if result, err := runCheck(ctx, "iam.credentials_unused_45_days", c.CheckCredentialsUnused45Days); err == nil {
 		results = append(results, result)
 	}
-	if result, err := c.CheckIAMPoliciesAttachedToUsers(ctx); err == nil {
+	if result, err := runCheck(ctx, "iam.policies_attached_to_users", c.CheckIAMPoliciesAttachedToUsers); err == nil {
 		results = append(results, result)
 	}
 
@@ -119,7 +119,7 @@ func (c *IAMChecks) CheckRootMFA(ctx context.Context) (CheckResult, error) {
 			Evidence:   "Unable to check root MFA status",
 			Severity:   "HIGH",
 			Priority:   PriorityHigh,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("ROOT_MFA"),
 		}, err
 	}
@@ -134,9 +134,9 @@ func (c *IAMChecks) CheckRootMFA(ctx context.Context) (CheckResult, error) {
 			Remediation:       "Enable MFA on root account immediately\nSee PDF for detailed steps",
 			RemediationDetail: "1. Sign in as root user\n2. Go to Security Credentials\n3. Enable MFA immediately",
 			ScreenshotGuide:   "1. Sign in to AWS as root user\n2. Click account name → 'Security credentials'\n3. Screenshot 'Multi-factor authentication (MFA)' section\n4. Must show at least one MFA device assigned\n5. For PCI DSS: Document MFA type (virtual/hardware)",
-			ConsoleURL:        "https://console.aws.amazon.com/iam/home#/security_credentials",
+			ConsoleURL:        consoleURL("iam/home#/security_credentials", consoleRegion(ctx)),
 			Priority:          PriorityCritical,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("ROOT_MFA"),
 		}, nil
 	}
@@ -148,9 +148,9 @@ func (c *IAMChecks) CheckRootMFA(ctx context.Context) (CheckResult, error) {
 		Evidence:        "Root account has MFA enabled | Meets CIS-1.5, SOC2 CC6.6, PCI DSS 8.3.1, HIPAA 164.312(a)(2)(i)",
 		Severity:        "INFO",
 		ScreenshotGuide: "1. Go to IAM → Security credentials\n2. Screenshot MFA section showing device configured",
-		ConsoleURL:      "https://console.aws.amazon.com/iam/home#/security_credentials",
+		ConsoleURL:      consoleURL("iam/home#/security_credentials", consoleRegion(ctx)),
 		Priority:        PriorityInfo,
-		Timestamp:       time.Now(),
+		Timestamp:       nowFunc(),
 		Frameworks:      GetFrameworkMappings("ROOT_MFA"),
 	}, nil
 }
@@ -167,9 +167,9 @@ func (c *IAMChecks) CheckPasswordPolicy(ctx context.Context) (CheckResult, error
 			Remediation:       "Run: aws iam update-account-password-policy\nSee PDF for required parameters",
 			RemediationDetail: "aws iam update-account-password-policy --minimum-password-length 14 --require-symbols --require-numbers --require-uppercase-characters --require-lowercase-characters --max-password-age 90 --password-reuse-prevention 24",
 			ScreenshotGuide:   "1. Go to IAM → Account settings\n2. Screenshot 'Password policy' section\n3. Must show all requirements enabled\n4. PCI DSS requires minimum 7 chars, we recommend 14+",
-			ConsoleURL:        "https://console.aws.amazon.com/iam/home#/account_settings",
+			ConsoleURL:        consoleURL("iam/home#/account_settings", consoleRegion(ctx)),
 			Priority:          PriorityHigh,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("PASSWORD_POLICY"),
 		}, nil
 	}
@@ -211,19 +211,21 @@ func (c *IAMChecks) CheckPasswordPolicy(ctx context.Context) (CheckResult, error
 			Remediation:       "Update password policy (aws iam update-account-password-policy)",
 			RemediationDetail: "aws iam update-account-password-policy --minimum-password-length 14 --require-symbols --require-numbers --require-uppercase-characters --require-lowercase-characters",
 			Priority:          PriorityMedium,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("PASSWORD_POLICY"),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "CC6.7",
-		Name:       "Password Policy",
-		Status:     "PASS",
-		Evidence:   fmt.Sprintf("Password policy meets requirements (14+ chars, complexity) | Meets SOC2 CC6.7, PCI DSS 8.2.3-8.2.5, HIPAA 164.308(a)(5)(ii)(D)"),
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("PASSWORD_POLICY"),
+		Control:         "CC6.7",
+		Name:            "Password Policy",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("Password policy meets requirements (14+ chars, complexity) | Meets SOC2 CC6.7, PCI DSS 8.2.3-8.2.5, HIPAA 164.308(a)(5)(ii)(D)"),
+		ScreenshotGuide: "1. Go to IAM → Account settings\n2. Screenshot 'Password policy' section\n3. Must show all requirements enabled\n4. PCI DSS requires minimum 7 chars, we recommend 14+",
+		ConsoleURL:      consoleURL("iam/home#/account_settings", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("PASSWORD_POLICY"),
 	}, nil
 }
 
@@ -293,9 +295,9 @@ func (c *IAMChecks) CheckAccessKeyRotation(ctx context.Context) (CheckResult, er
 			Remediation:       fmt.Sprintf("Rotate key for user: %s\nRun: aws iam create-access-key", firstUser),
 			RemediationDetail: fmt.Sprintf("aws iam create-access-key --user-name %s && aws iam delete-access-key --access-key-id OLD_KEY_ID --user-name %s", firstUser, firstUser),
 			ScreenshotGuide:   "1. Go to IAM → Users\n2. Click on each user\n3. Go to 'Security credentials' tab\n4. Screenshot 'Access keys' section showing creation dates\n5. For PCI DSS: Document rotation schedule",
-			ConsoleURL:        "https://console.aws.amazon.com/iam/home#/users",
+			ConsoleURL:        consoleURL("iam/home#/users", consoleRegion(ctx)),
 			Priority:          PriorityCritical,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("ACCESS_KEY_ROTATION"),
 		}, nil
 	}
@@ -309,19 +311,21 @@ func (c *IAMChecks) CheckAccessKeyRotation(ctx context.Context) (CheckResult, er
 			Evidence:    fmt.Sprintf("%d access keys older than 90 days | CIS-1.14 and PCI DSS 8.2.4 requires rotation", len(oldKeys)),
 			Remediation: "Rotate keys older than 90 days",
 			Priority:    PriorityHigh,
-			Timestamp:   time.Now(),
+			Timestamp:   nowFunc(),
 			Frameworks:  GetFrameworkMappings("ACCESS_KEY_ROTATION"),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "CIS-1.14, CC6.8",
-		Name:       "Access Key Rotation",
-		Status:     "PASS",
-		Evidence:   "All access keys rotated within 90 days | Meets CIS-1.14, SOC2 CC6.8, PCI DSS 8.2.4, HIPAA 164.308(a)(4)(ii)(B)",
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("ACCESS_KEY_ROTATION"),
+		Control:         "CIS-1.14, CC6.8",
+		Name:            "Access Key Rotation",
+		Status:          "PASS",
+		Evidence:        "All access keys rotated within 90 days | Meets CIS-1.14, SOC2 CC6.8, PCI DSS 8.2.4, HIPAA 164.308(a)(4)(ii)(B)",
+		ScreenshotGuide: "1. Go to IAM → Users\n2. Click on each user\n3. Go to 'Security credentials' tab\n4. Screenshot 'Access keys' section showing creation dates\n5. For PCI DSS: Document rotation schedule",
+		ConsoleURL:      consoleURL("iam/home#/users", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("ACCESS_KEY_ROTATION"),
 	}, nil
 }
 
@@ -339,7 +343,7 @@ func (c *IAMChecks) CheckUnusedCredentials(ctx context.Context) (CheckResult, er
 				Status:     "INFO",
 				Evidence:   "Unable to generate credential report | Meets PCI DSS 8.1.4 (remove inactive accounts within 90 days)",
 				Priority:   PriorityInfo,
-				Timestamp:  time.Now(),
+				Timestamp:  nowFunc(),
 				Frameworks: GetFrameworkMappings("UNUSED_CREDENTIALS"),
 			}, nil
 		}
@@ -350,7 +354,7 @@ func (c *IAMChecks) CheckUnusedCredentials(ctx context.Context) (CheckResult, er
 			Status:     "INFO",
 			Evidence:   "Credential report being generated, check again in 10 seconds",
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("UNUSED_CREDENTIALS"),
 		}, nil
 	}
@@ -365,7 +369,7 @@ func (c *IAMChecks) CheckUnusedCredentials(ctx context.Context) (CheckResult, er
 			Status:     "INFO",
 			Evidence:   fmt.Sprintf("Unable to parse credential report: %v", err),
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("UNUSED_CREDENTIALS"),
 		}, nil
 	}
@@ -376,8 +380,9 @@ func (c *IAMChecks) CheckUnusedCredentials(ctx context.Context) (CheckResult, er
 			Name:       "Unused Credentials",
 			Status:     "PASS",
 			Evidence:   "No IAM users found in credential report",
+			ConsoleURL: consoleURL("iam/home#/users", consoleRegion(ctx)),
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("UNUSED_CREDENTIALS"),
 		}, nil
 	}
@@ -392,7 +397,7 @@ func (c *IAMChecks) CheckUnusedCredentials(ctx context.Context) (CheckResult, er
 	unusedUsers := []string{}
 	unusedPasswords := []string{}
 	unusedKeys := []string{}
-	now := time.Now()
+	now := nowFunc()
 	cutoffDate := now.AddDate(0, 0, -90) // 90 days ago
 
 	// Skip header row
@@ -473,9 +478,9 @@ func (c *IAMChecks) CheckUnusedCredentials(ctx context.Context) (CheckResult, er
 			Remediation:       "Disable or delete unused IAM credentials",
 			RemediationDetail: "aws iam update-login-profile --user-name USERNAME --password-reset-required\naws iam delete-access-key --user-name USERNAME --access-key-id KEY_ID",
 			ScreenshotGuide:   "1. Go to IAM → Users\n2. Sort by 'Last activity'\n3. Screenshot users with no recent activity\n4. For PCI DSS: Document review process for inactive accounts",
-			ConsoleURL:        "https://console.aws.amazon.com/iam/home#/users",
+			ConsoleURL:        consoleURL("iam/home#/users", consoleRegion(ctx)),
 			Priority:          PriorityHigh,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("UNUSED_CREDENTIALS"),
 		}, nil
 	}
@@ -487,9 +492,9 @@ func (c *IAMChecks) CheckUnusedCredentials(ctx context.Context) (CheckResult, er
 		Evidence:        fmt.Sprintf("All credentials used within 90 days (checked %d users) | Meets PCI DSS 8.1.4, SOC2 CC6.7", len(records)-1),
 		Severity:        "INFO",
 		ScreenshotGuide: "1. Go to IAM → Credential Report\n2. Download report\n3. Screenshot showing recent activity for all users",
-		ConsoleURL:      "https://console.aws.amazon.com/iam/home#/credential_report",
+		ConsoleURL:      consoleURL("iam/home#/credential_report", consoleRegion(ctx)),
 		Priority:        PriorityInfo,
-		Timestamp:       time.Now(),
+		Timestamp:       nowFunc(),
 		Frameworks:      GetFrameworkMappings("UNUSED_CREDENTIALS"),
 	}, nil
 }
@@ -518,9 +523,9 @@ func (c *IAMChecks) CheckRootAccessKeys(ctx context.Context) (CheckResult, error
 			Remediation:       "Delete root account access keys immediately",
 			RemediationDetail: "1. Sign in as root\n2. Go to Security credentials\n3. Delete all access keys\n4. Use IAM users for programmatic access",
 			ScreenshotGuide:   "AWS Console → Root account → Security credentials → Access keys section (must be empty)",
-			ConsoleURL:        "https://console.aws.amazon.com/iam/home#/security_credentials",
+			ConsoleURL:        consoleURL("iam/home#/security_credentials", consoleRegion(ctx)),
 			Priority:          PriorityCritical,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("ROOT_ACCESS_KEYS"),
 		}, nil
 	}
@@ -530,8 +535,9 @@ func (c *IAMChecks) CheckRootAccessKeys(ctx context.Context) (CheckResult, error
 		Name:       "Root Account Access Keys",
 		Status:     "PASS",
 		Evidence:   "No root account access keys exist | Meets CIS-1.11",
+		ConsoleURL: consoleURL("iam/home#/security_credentials", consoleRegion(ctx)),
 		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
+		Timestamp:  nowFunc(),
 		Frameworks: GetFrameworkMappings("ROOT_ACCESS_KEYS"),
 	}, nil
 }
@@ -547,9 +553,9 @@ func (c *IAMChecks) CheckHardwareMFARoot(ctx context.Context) (CheckResult, erro
 		Remediation:       "Enable hardware MFA for root account for additional security",
 		RemediationDetail: "1. Sign in as root\n2. Go to Security credentials\n3. Add hardware MFA device (not virtual authenticator app)\n4. Follow device setup instructions",
 		ScreenshotGuide:   "AWS Console → Root Security credentials → MFA → Screenshot showing 'Hardware' MFA device type",
-		ConsoleURL:        "https://console.aws.amazon.com/iam/home#/security_credentials",
+		ConsoleURL:        consoleURL("iam/home#/security_credentials", consoleRegion(ctx)),
 		Priority:          PriorityMedium,
-		Timestamp:         time.Now(),
+		Timestamp:         nowFunc(),
 		Frameworks:        GetFrameworkMappings("IAM_HARDWARE_MFA_ROOT"),
 	}, nil
 }
@@ -587,25 +593,28 @@ func (c *IAMChecks) CheckIAMUsersMFA(ctx context.Context) (CheckResult, error) {
 			Name:              "MFA for IAM Users",
 			Status:            "FAIL",
 			Severity:          "HIGH",
-			Evidence:          fmt.Sprintf("%d IAM users without MFA: %v", len(usersWithoutMFA), usersWithoutMFA),
+			Evidence:          fmt.Sprintf("%d IAM users without MFA: %s", len(usersWithoutMFA), TruncateList(usersWithoutMFA, evidenceListLimit(ctx))),
+			AffectedResources: usersWithoutMFA,
 			Remediation:       "Enable MFA for all IAM users with console access",
 			RemediationDetail: "For each user: IAM Console → Users → [Username] → Security credentials → Assign MFA device",
 			ScreenshotGuide:   "IAM → Users → [each user] → Security credentials → Screenshot MFA section showing device assigned",
-			ConsoleURL:        "https://console.aws.amazon.com/iam/home#/users",
+			ConsoleURL:        consoleURL("iam/home#/users", consoleRegion(ctx)),
 			Priority:          PriorityHigh,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("IAM_USER_MFA"),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "[CIS-1.10]",
-		Name:       "MFA for IAM Users",
-		Status:     "PASS",
-		Evidence:   "All IAM users with console access have MFA enabled",
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("IAM_USER_MFA"),
+		Control:         "[CIS-1.10]",
+		Name:            "MFA for IAM Users",
+		Status:          "PASS",
+		Evidence:        "All IAM users with console access have MFA enabled",
+		ScreenshotGuide: "IAM → Users → [each user] → Security credentials → Screenshot MFA section showing device assigned",
+		ConsoleURL:      consoleURL("iam/home#/users", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("IAM_USER_MFA"),
 	}, nil
 }
 
@@ -619,9 +628,9 @@ func (c *IAMChecks) CheckCredentialsUnused90Days(ctx context.Context) (CheckResu
 		Remediation:       "Disable or remove IAM credentials not used in 90+ days",
 		RemediationDetail: "1. Generate credential report: aws iam generate-credential-report\n2. Get report: aws iam get-credential-report\n3. Review password_last_used and access_key_last_used columns\n4. Disable unused credentials",
 		ScreenshotGuide:   "IAM → Credential report → Screenshot showing users with password_last_used/access_key_last_used > 90 days",
-		ConsoleURL:        "https://console.aws.amazon.com/iam/home#/credential_report",
+		ConsoleURL:        consoleURL("iam/home#/credential_report", consoleRegion(ctx)),
 		Priority:          PriorityMedium,
-		Timestamp:         time.Now(),
+		Timestamp:         nowFunc(),
 		Frameworks:        GetFrameworkMappings("IAM_CREDENTIALS_UNUSED_90_DAYS"),
 	}, nil
 }
@@ -660,25 +669,28 @@ func (c *IAMChecks) CheckOneActiveAccessKey(ctx context.Context) (CheckResult, e
 			Name:              "One Active Access Key Per User",
 			Status:            "FAIL",
 			Severity:          "MEDIUM",
-			Evidence:          fmt.Sprintf("%d users have multiple active access keys: %v", len(usersWithMultipleKeys), usersWithMultipleKeys),
+			Evidence:          fmt.Sprintf("%d users have multiple active access keys: %s", len(usersWithMultipleKeys), TruncateList(usersWithMultipleKeys, evidenceListLimit(ctx))),
+			AffectedResources: usersWithMultipleKeys,
 			Remediation:       "Remove extra access keys, keep only one active per user",
 			RemediationDetail: "For each user: aws iam delete-access-key --user-name [USERNAME] --access-key-id [KEY_ID]",
 			ScreenshotGuide:   "IAM → Users → [user] → Security credentials → Screenshot showing single active access key",
-			ConsoleURL:        "https://console.aws.amazon.com/iam/home#/users",
+			ConsoleURL:        consoleURL("iam/home#/users", consoleRegion(ctx)),
 			Priority:          PriorityMedium,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("IAM_USER_UNUSED"),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "[CIS-1.13]",
-		Name:       "One Active Access Key Per User",
-		Status:     "PASS",
-		Evidence:   "All IAM users have at most one active access key",
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("IAM_USER_UNUSED"),
+		Control:         "[CIS-1.13]",
+		Name:            "One Active Access Key Per User",
+		Status:          "PASS",
+		Evidence:        "All IAM users have at most one active access key",
+		ScreenshotGuide: "IAM → Users → [user] → Security credentials → Screenshot showing single active access key",
+		ConsoleURL:      consoleURL("iam/home#/users", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("IAM_USER_UNUSED"),
 	}, nil
 }
 
@@ -715,25 +727,28 @@ func (c *IAMChecks) CheckIAMPoliciesAttached(ctx context.Context) (CheckResult,
 			Name:              "IAM Policies via Groups Only",
 			Status:            "FAIL",
 			Severity:          "MEDIUM",
-			Evidence:          fmt.Sprintf("%d users have policies attached directly: %v", len(usersWithDirectPolicies), usersWithDirectPolicies),
+			Evidence:          fmt.Sprintf("%d users have policies attached directly: %s", len(usersWithDirectPolicies), TruncateList(usersWithDirectPolicies, evidenceListLimit(ctx))),
+			AffectedResources: usersWithDirectPolicies,
 			Remediation:       "Attach policies to groups instead of users directly",
 			RemediationDetail: "1. Create IAM groups with appropriate policies\n2. Add users to groups\n3. Remove direct policy attachments from users",
 			ScreenshotGuide:   "IAM → Users → [user] → Permissions tab → Screenshot showing 'No permissions policies'",
-			ConsoleURL:        "https://console.aws.amazon.com/iam/home#/users",
+			ConsoleURL:        consoleURL("iam/home#/users", consoleRegion(ctx)),
 			Priority:          PriorityMedium,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("IAM_POLICIES_ATTACHED"),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "[CIS-1.15]",
-		Name:       "IAM Policies via Groups Only",
-		Status:     "PASS",
-		Evidence:   "All IAM users receive permissions through groups",
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("IAM_POLICIES_ATTACHED"),
+		Control:         "[CIS-1.15]",
+		Name:            "IAM Policies via Groups Only",
+		Status:          "PASS",
+		Evidence:        "All IAM users receive permissions through groups",
+		ScreenshotGuide: "IAM → Users → [user] → Permissions tab → Screenshot showing 'No permissions policies'",
+		ConsoleURL:      consoleURL("iam/home#/users", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("IAM_POLICIES_ATTACHED"),
 	}, nil
 }
 
@@ -773,21 +788,23 @@ func (c *IAMChecks) CheckSupportRole(ctx context.Context) (CheckResult, error) {
 			Remediation:       "Create IAM role with AWSSupportAccess for incident management",
 			RemediationDetail: "1. Create IAM role\n2. Attach AWSSupportAccess policy: arn:aws:iam::aws:policy/AWSSupportAccess\n3. Document who can assume this role",
 			ScreenshotGuide:   "IAM → Roles → Screenshot showing role with AWSSupportAccess policy attached",
-			ConsoleURL:        "https://console.aws.amazon.com/iam/home#/roles",
+			ConsoleURL:        consoleURL("iam/home#/roles", consoleRegion(ctx)),
 			Priority:          PriorityMedium,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("IAM_SUPPORT_ROLE"),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "[CIS-1.17]",
-		Name:       "IAM Support Role",
-		Status:     "PASS",
-		Evidence:   "IAM role with AWSSupportAccess policy exists",
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("IAM_SUPPORT_ROLE"),
+		Control:         "[CIS-1.17]",
+		Name:            "IAM Support Role",
+		Status:          "PASS",
+		Evidence:        "IAM role with AWSSupportAccess policy exists",
+		ScreenshotGuide: "IAM → Roles → Screenshot showing role with AWSSupportAccess policy attached",
+		ConsoleURL:      consoleURL("iam/home#/roles", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("IAM_SUPPORT_ROLE"),
 	}, nil
 }
 
@@ -801,9 +818,9 @@ func (c *IAMChecks) CheckIAMInstanceRoles(ctx context.Context) (CheckResult, err
 		Remediation:       "Attach IAM roles to EC2 instances for AWS resource access",
 		RemediationDetail: "1. Create IAM role with required permissions\n2. Attach role to EC2 instance\n3. Remove any embedded access keys from instance\n4. Update application code to use instance role credentials",
 		ScreenshotGuide:   "EC2 Console → Instances → Instance details → Screenshot showing IAM role attached",
-		ConsoleURL:        "https://console.aws.amazon.com/ec2/v2/home#Instances",
+		ConsoleURL:        consoleURL("ec2/v2/home#Instances", consoleRegion(ctx)),
 		Priority:          PriorityMedium,
-		Timestamp:         time.Now(),
+		Timestamp:         nowFunc(),
 		Frameworks:        GetFrameworkMappings("IAM_INSTANCE_ROLES"),
 	}, nil
 }
@@ -819,7 +836,7 @@ func (c *IAMChecks) CheckIAMPoliciesOnGroupsOnly(ctx context.Context) (CheckResu
 			Evidence: "Unable to check IAM user policies",
 			Severity: "HIGH",
 			Priority: PriorityHigh,
-			Timestamp: time.Now(),
+			Timestamp: nowFunc(),
 			Frameworks: map[string]string{"CIS-AWS": "1.22", "SOC2": "CC6.3"},
 		}, err
 	}
@@ -892,9 +909,9 @@ aws iam add-user-to-group --group-name Developers --user-name %s
 aws iam detach-user-policy --user-name %s --policy-arn POLICY_ARN
 aws iam delete-user-policy --user-name %s --policy-name INLINE_POLICY_NAME`, firstUser, firstUser, firstUser),
 			ScreenshotGuide: "IAM Console → Users → Click user → Permissions tab → Screenshot showing NO attached policies (policies should be via groups)",
-			ConsoleURL:      "https://console.aws.amazon.com/iam/home#/users",
+			ConsoleURL:      consoleURL("iam/home#/users", consoleRegion(ctx)),
 			Priority:        PriorityHigh,
-			Timestamp:       time.Now(),
+			Timestamp:       nowFunc(),
 			Frameworks:      map[string]string{"CIS-AWS": "1.22", "SOC2": "CC6.3", "PCI-DSS": "7.1.2"},
 		}, nil
 	}
@@ -904,8 +921,9 @@ aws iam delete-user-policy --user-name %s --policy-name INLINE_POLICY_NAME`, fir
 		Name:       "IAM Policies Attached to Groups Only",
 		Status:     "PASS",
 		Evidence:   "No IAM policies attached directly to users | Meets CIS 1.22 (centralized permissions via groups/roles)",
+		ConsoleURL: consoleURL("iam/home#/users", consoleRegion(ctx)),
 		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
+		Timestamp:  nowFunc(),
 		Frameworks: map[string]string{"CIS-AWS": "1.22", "SOC2": "CC6.3", "PCI-DSS": "7.1.2"},
 	}, nil
 }
@@ -923,9 +941,9 @@ func (c *IAMChecks) CheckPasswordExpiration(ctx context.Context) (CheckResult, e
 			Remediation:       "Configure password policy with max age of 90 days or less",
 			RemediationDetail: "aws iam update-account-password-policy --max-password-age 90 --minimum-password-length 14 --require-symbols --require-numbers --require-uppercase-characters --require-lowercase-characters --password-reuse-prevention 24",
 			ScreenshotGuide:   "IAM → Account settings → Password policy → Screenshot showing 'Password expiration period' set to 90 days or less",
-			ConsoleURL:        "https://console.aws.amazon.com/iam/home#/account_settings",
+			ConsoleURL:        consoleURL("iam/home#/account_settings", consoleRegion(ctx)),
 			Priority:          PriorityMedium,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        map[string]string{"CIS-AWS": "1.20", "SOC2": "CC6.1", "PCI-DSS": "8.2.4"},
 		}, nil
 	}
@@ -942,9 +960,9 @@ func (c *IAMChecks) CheckPasswordExpiration(ctx context.Context) (CheckResult, e
 			Remediation:       "Set password max age to 90 days or less",
 			RemediationDetail: "aws iam update-account-password-policy --max-password-age 90",
 			ScreenshotGuide:   "IAM → Account settings → Password policy → Screenshot showing 'Password expiration period' enabled and ≤ 90 days",
-			ConsoleURL:        "https://console.aws.amazon.com/iam/home#/account_settings",
+			ConsoleURL:        consoleURL("iam/home#/account_settings", consoleRegion(ctx)),
 			Priority:          PriorityMedium,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        map[string]string{"CIS-AWS": "1.20", "SOC2": "CC6.1", "PCI-DSS": "8.2.4"},
 		}, nil
 	}
@@ -959,9 +977,9 @@ func (c *IAMChecks) CheckPasswordExpiration(ctx context.Context) (CheckResult, e
 			Remediation:       "Reduce password max age to 90 days or less",
 			RemediationDetail: "aws iam update-account-password-policy --max-password-age 90",
 			ScreenshotGuide:   "IAM → Account settings → Password policy → Screenshot showing 'Password expiration period' ≤ 90 days",
-			ConsoleURL:        "https://console.aws.amazon.com/iam/home#/account_settings",
+			ConsoleURL:        consoleURL("iam/home#/account_settings", consoleRegion(ctx)),
 			Priority:          PriorityMedium,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        map[string]string{"CIS-AWS": "1.20", "SOC2": "CC6.1", "PCI-DSS": "8.2.4"},
 		}, nil
 	}
@@ -971,8 +989,9 @@ func (c *IAMChecks) CheckPasswordExpiration(ctx context.Context) (CheckResult, e
 		Name:       "Password Expiration Policy",
 		Status:     "PASS",
 		Evidence:   fmt.Sprintf("Password max age is %d days (≤ 90) | Meets CIS 1.20", maxAge),
+		ConsoleURL: consoleURL("iam/home#/account_settings", consoleRegion(ctx)),
 		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
+		Timestamp:  nowFunc(),
 		Frameworks: map[string]string{"CIS-AWS": "1.20", "SOC2": "CC6.1", "PCI-DSS": "8.2.4"},
 	}, nil
 }
@@ -990,9 +1009,9 @@ func (c *IAMChecks) CheckPasswordReusePrevention(ctx context.Context) (CheckResu
 			Remediation:       "Configure password policy to prevent reuse of last 24 passwords",
 			RemediationDetail: "aws iam update-account-password-policy --password-reuse-prevention 24 --max-password-age 90 --minimum-password-length 14 --require-symbols --require-numbers --require-uppercase-characters --require-lowercase-characters",
 			ScreenshotGuide:   "IAM → Account settings → Password policy → Screenshot showing 'Password reuse prevention' set to 24",
-			ConsoleURL:        "https://console.aws.amazon.com/iam/home#/account_settings",
+			ConsoleURL:        consoleURL("iam/home#/account_settings", consoleRegion(ctx)),
 			Priority:          PriorityMedium,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        map[string]string{"CIS-AWS": "1.21", "SOC2": "CC6.1", "PCI-DSS": "8.2.5"},
 		}, nil
 	}
@@ -1009,9 +1028,9 @@ func (c *IAMChecks) CheckPasswordReusePrevention(ctx context.Context) (CheckResu
 			Remediation:       "Enable password reuse prevention for last 24 passwords",
 			RemediationDetail: "aws iam update-account-password-policy --password-reuse-prevention 24",
 			ScreenshotGuide:   "IAM → Account settings → Password policy → Screenshot showing 'Password reuse prevention' = 24",
-			ConsoleURL:        "https://console.aws.amazon.com/iam/home#/account_settings",
+			ConsoleURL:        consoleURL("iam/home#/account_settings", consoleRegion(ctx)),
 			Priority:          PriorityMedium,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        map[string]string{"CIS-AWS": "1.21", "SOC2": "CC6.1", "PCI-DSS": "8.2.5"},
 		}, nil
 	}
@@ -1026,9 +1045,9 @@ func (c *IAMChecks) CheckPasswordReusePrevention(ctx context.Context) (CheckResu
 			Remediation:       "Increase password reuse prevention to 24 passwords",
 			RemediationDetail: "aws iam update-account-password-policy --password-reuse-prevention 24",
 			ScreenshotGuide:   "IAM → Account settings → Password policy → Screenshot showing 'Password reuse prevention' = 24",
-			ConsoleURL:        "https://console.aws.amazon.com/iam/home#/account_settings",
+			ConsoleURL:        consoleURL("iam/home#/account_settings", consoleRegion(ctx)),
 			Priority:          PriorityLow,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        map[string]string{"CIS-AWS": "1.21", "SOC2": "CC6.1", "PCI-DSS": "8.2.5"},
 		}, nil
 	}
@@ -1038,8 +1057,9 @@ func (c *IAMChecks) CheckPasswordReusePrevention(ctx context.Context) (CheckResu
 		Name:       "Password Reuse Prevention",
 		Status:     "PASS",
 		Evidence:   fmt.Sprintf("Password reuse prevention is %d (≥ 24) | Meets CIS 1.21", reusePrevent),
+		ConsoleURL: consoleURL("iam/home#/account_settings", consoleRegion(ctx)),
 		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
+		Timestamp:  nowFunc(),
 		Frameworks: map[string]string{"CIS-AWS": "1.21", "SOC2": "CC6.1", "PCI-DSS": "8.2.5"},
 	}, nil
 }
@@ -1061,9 +1081,9 @@ func (c *IAMChecks) CheckAccountContactDetails(ctx context.Context) CheckResult
    - Email (ensure it's monitored)
 4. Screenshot showing current contact details`,
 		ScreenshotGuide:   "AWS Console → Account (top right) → My Account → Contact Information → Screenshot",
-		ConsoleURL:        "https://console.aws.amazon.com/billing/home#/account",
+		ConsoleURL:        consoleURL("billing/home#/account", consoleRegion(ctx)),
 		Priority:          PriorityMedium,
-		Timestamp:         time.Now(),
+		Timestamp:         nowFunc(),
 		Frameworks:        map[string]string{"CIS-AWS": "1.1"},
 	}
 }
@@ -1085,9 +1105,9 @@ func (c *IAMChecks) CheckSecurityContactInfo(ctx context.Context) CheckResult {
    - Phone number
 5. Screenshot showing security contact configured`,
 		ScreenshotGuide:   "AWS Console → Account → My Account → Alternate Contacts → Security contact → Screenshot",
-		ConsoleURL:        "https://console.aws.amazon.com/billing/home#/account",
+		ConsoleURL:        consoleURL("billing/home#/account", consoleRegion(ctx)),
 		Priority:          PriorityHigh,
-		Timestamp:         time.Now(),
+		Timestamp:         nowFunc(),
 		Frameworks:        map[string]string{"CIS-AWS": "1.2", "SOC2": "CC6.1"},
 	}
 }
@@ -1133,21 +1153,23 @@ aws iam put-role-policy --role-name IAMManagerRole --policy-name IAMLimitedAcces
 
 # Best practice: Separate who can create/delete IAM resources vs who can assign permissions`,
 			ScreenshotGuide:   "IAM → Roles → Screenshot showing IAMMasterRole and IAMManagerRole with appropriate policies",
-			ConsoleURL:        "https://console.aws.amazon.com/iam/home#/roles",
+			ConsoleURL:        consoleURL("iam/home#/roles", consoleRegion(ctx)),
 			Priority:          PriorityMedium,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        map[string]string{"CIS-AWS": "1.18", "SOC2": "CC6.3"},
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "[CIS-1.18]",
-		Name:       "IAM Master and Manager Roles",
-		Status:     "PASS",
-		Evidence:   "IAM management roles detected | Meets CIS 1.18",
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: map[string]string{"CIS-AWS": "1.18", "SOC2": "CC6.3"},
+		Control:         "[CIS-1.18]",
+		Name:            "IAM Master and Manager Roles",
+		Status:          "PASS",
+		Evidence:        "IAM management roles detected | Meets CIS 1.18",
+		ScreenshotGuide: "IAM → Roles → Screenshot showing IAMMasterRole and IAMManagerRole with appropriate policies",
+		ConsoleURL:      consoleURL("iam/home#/roles", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      map[string]string{"CIS-AWS": "1.18", "SOC2": "CC6.3"},
 	}, nil
 }
 
@@ -1177,9 +1199,9 @@ func (c *IAMChecks) CheckIAMUserAccessReview(ctx context.Context) CheckResult {
 
 5. Screenshot showing documented review process`,
 		ScreenshotGuide:   "IAM → Credential Report → Screenshot showing recent review date + documented process",
-		ConsoleURL:        "https://console.aws.amazon.com/iam/home#/credential_report",
+		ConsoleURL:        consoleURL("iam/home#/credential_report", consoleRegion(ctx)),
 		Priority:          PriorityMedium,
-		Timestamp:         time.Now(),
+		Timestamp:         nowFunc(),
 		Frameworks:        map[string]string{"CIS-AWS": "1.22", "SOC2": "CC6.2", "PCI-DSS": "8.1.4"},
 	}
 }
@@ -1233,13 +1255,14 @@ func (c *IAMChecks) CheckCredentialsUnused45Days(ctx context.Context) (CheckResu
 			Name:              "Credentials Unused 45+ Days",
 			Status:            "FAIL",
 			Severity:          "MEDIUM",
-			Evidence:          fmt.Sprintf("%d credentials unused for 45+ days: %v | Violates CIS-1.3", len(unusedCredentials), unusedCredentials),
+			Evidence:          fmt.Sprintf("%d credentials unused for 45+ days: %s | Violates CIS-1.3", len(unusedCredentials), TruncateList(unusedCredentials, evidenceListLimit(ctx))),
+			AffectedResources: unusedCredentials,
 			Remediation:       "Disable or remove unused credentials",
 			RemediationDetail: "aws iam update-access-key --access-key-id KEY_ID --status Inactive --user-name USERNAME",
 			ScreenshotGuide:   "IAM → Users → Security credentials → Screenshot showing all credentials used within 45 days",
-			ConsoleURL:        "https://console.aws.amazon.com/iam/home#/users",
+			ConsoleURL:        consoleURL("iam/home#/users", consoleRegion(ctx)),
 			Priority:          PriorityMedium,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("UNUSED_CREDENTIALS_45"),
 		}, nil
 	}
@@ -1249,8 +1272,9 @@ func (c *IAMChecks) CheckCredentialsUnused45Days(ctx context.Context) (CheckResu
 		Name:       "Credentials Unused 45+ Days",
 		Status:     "PASS",
 		Evidence:   "No credentials unused for 45+ days | Meets CIS-1.3",
+		ConsoleURL: consoleURL("iam/home#/users", consoleRegion(ctx)),
 		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
+		Timestamp:  nowFunc(),
 		Frameworks: GetFrameworkMappings("UNUSED_CREDENTIALS_45"),
 	}, nil
 }
@@ -1288,24 +1312,27 @@ func (c *IAMChecks) CheckIAMPoliciesAttachedToUsers(ctx context.Context) (CheckR
 			Name:              "IAM Policies on Groups/Roles Only",
 			Status:            "FAIL",
 			Severity:          "MEDIUM",
-			Evidence:          fmt.Sprintf("%d users have policies attached directly (should use groups): %v | Violates CIS-1.16", len(usersWithDirectPolicies), usersWithDirectPolicies),
+			Evidence:          fmt.Sprintf("%d users have policies attached directly (should use groups): %s | Violates CIS-1.16", len(usersWithDirectPolicies), TruncateList(usersWithDirectPolicies, evidenceListLimit(ctx))),
+			AffectedResources: usersWithDirectPolicies,
 			Remediation:       "Attach policies to groups/roles, not users",
 			RemediationDetail: "1. Create IAM group\n2. Attach policies to group\n3. Add users to group\n4. Remove direct policy attachments from users",
 			ScreenshotGuide:   "IAM → Users → Permissions tab → Screenshot showing no directly attached policies (should inherit from groups)",
-			ConsoleURL:        "https://console.aws.amazon.com/iam/home#/users",
+			ConsoleURL:        consoleURL("iam/home#/users", consoleRegion(ctx)),
 			Priority:          PriorityMedium,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("IAM_POLICIES_GROUPS_ONLY"),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "CIS-1.16",
-		Name:       "IAM Policies on Groups/Roles Only",
-		Status:     "PASS",
-		Evidence:   "All IAM policies attached to groups/roles (not users) | Meets CIS-1.16",
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("IAM_POLICIES_GROUPS_ONLY"),
+		Control:         "CIS-1.16",
+		Name:            "IAM Policies on Groups/Roles Only",
+		Status:          "PASS",
+		Evidence:        "All IAM policies attached to groups/roles (not users) | Meets CIS-1.16",
+		ScreenshotGuide: "IAM → Users → Permissions tab → Screenshot showing no directly attached policies (should inherit from groups)",
+		ConsoleURL:      consoleURL("iam/home#/users", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("IAM_POLICIES_GROUPS_ONLY"),
 	}, nil
 }
@@ -24,23 +24,23 @@ func (c *IAMAdvancedChecks) Name() string {
 func (c *IAMAdvancedChecks) Run(ctx context.Context) ([]CheckResult, error) {
 	results := []CheckResult{}
 
-	if result, err := c.CheckInactiveUsers(ctx); err == nil {
+	if result, err := runCheck(ctx, "iam_advanced.inactive_users", c.CheckInactiveUsers); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckExcessivePermissions(ctx); err == nil {
+	if result, err := runCheck(ctx, "iam_advanced.excessive_permissions", c.CheckExcessivePermissions); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckServiceAccountMFA(ctx); err == nil {
+	if result, err := runCheck(ctx, "iam_advanced.service_account_mfa", c.CheckServiceAccountMFA); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckRootAccountUsage(ctx); err == nil {
+	if result, err := runCheck(ctx, "iam_advanced.root_account_usage", c.CheckRootAccountUsage); err == nil {
 		results = append(results, result)
 	}
 
-	return results, nil
+	return withSOC2Mapping(results), nil
 }
 
 func (c *IAMAdvancedChecks) CheckInactiveUsers(ctx context.Context) (CheckResult, error) {
@@ -77,9 +77,9 @@ func (c *IAMAdvancedChecks) CheckInactiveUsers(ctx context.Context) (CheckResult
 			Remediation:       fmt.Sprintf("Delete unused user: %s", zombieUsers[0]),
 			RemediationDetail: fmt.Sprintf("aws iam delete-user --user-name %s", zombieUsers[0]),
 			ScreenshotGuide:   "1. Go to IAM → Users\n2. Sort by 'Last activity'\n3. Screenshot users with 'Never' or >90 days\n4. Document why each inactive user exists",
-			ConsoleURL:        "https://console.aws.amazon.com/iam/home#/users",
+			ConsoleURL:        consoleURL("iam/home#/users", consoleRegion(ctx)),
 			Priority:          PriorityHigh,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 		}, nil
 	}
 
@@ -92,17 +92,18 @@ func (c *IAMAdvancedChecks) CheckInactiveUsers(ctx context.Context) (CheckResult
 			Evidence:    fmt.Sprintf("%d users inactive >90 days", len(inactiveUsers)),
 			Remediation: "Review and remove inactive users",
 			Priority:    PriorityMedium,
-			Timestamp:   time.Now(),
+			Timestamp:   nowFunc(),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:   "CC6.4",
-		Name:      "User Access Reviews",
-		Status:    "PASS",
-		Evidence:  "All users active within 90 days",
-		Priority:  PriorityInfo,
-		Timestamp: time.Now(),
+		Control:    "CC6.4",
+		Name:       "User Access Reviews",
+		Status:     "PASS",
+		Evidence:   "All users active within 90 days",
+		ConsoleURL: consoleURL("iam/home#/users", consoleRegion(ctx)),
+		Priority:   PriorityInfo,
+		Timestamp:  nowFunc(),
 	}, nil
 }
 
@@ -140,19 +141,20 @@ func (c *IAMAdvancedChecks) CheckExcessivePermissions(ctx context.Context) (Chec
 			Remediation:       "Apply principle of least privilege",
 			RemediationDetail: "Remove AdministratorAccess policy and grant specific permissions only",
 			ScreenshotGuide:   "1. Go to IAM → Users\n2. Click each admin user\n3. Screenshot 'Permissions' tab\n4. Document why they need admin",
-			ConsoleURL:        "https://console.aws.amazon.com/iam/home#/users",
+			ConsoleURL:        consoleURL("iam/home#/users", consoleRegion(ctx)),
 			Priority:          PriorityHigh,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:   "CC6.5",
-		Name:      "Least Privilege Access",
-		Status:    "PASS",
-		Evidence:  "Admin access appropriately restricted",
-		Priority:  PriorityInfo,
-		Timestamp: time.Now(),
+		Control:    "CC6.5",
+		Name:       "Least Privilege Access",
+		Status:     "PASS",
+		Evidence:   "Admin access appropriately restricted",
+		ConsoleURL: consoleURL("iam/home#/users", consoleRegion(ctx)),
+		Priority:   PriorityInfo,
+		Timestamp:  nowFunc(),
 	}, nil
 }
 
@@ -193,17 +195,18 @@ func (c *IAMAdvancedChecks) CheckServiceAccountMFA(ctx context.Context) (CheckRe
 			Evidence:    fmt.Sprintf("%d service accounts lack MFA protection", len(serviceAccountsNoMFA)),
 			Remediation: "Enable MFA or use IAM roles instead",
 			Priority:    PriorityMedium,
-			Timestamp:   time.Now(),
+			Timestamp:   nowFunc(),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:   "CC6.5",
-		Name:      "Service Account Security",
-		Status:    "PASS",
-		Evidence:  "Service accounts properly secured",
-		Priority:  PriorityInfo,
-		Timestamp: time.Now(),
+		Control:    "CC6.5",
+		Name:       "Service Account Security",
+		Status:     "PASS",
+		Evidence:   "Service accounts properly secured",
+		ConsoleURL: consoleURL("iam/home#/users", consoleRegion(ctx)),
+		Priority:   PriorityInfo,
+		Timestamp:  nowFunc(),
 	}, nil
 }
 
@@ -218,8 +221,8 @@ func (c *IAMAdvancedChecks) CheckRootAccountUsage(ctx context.Context) (CheckRes
 		Evidence:        "Check CloudTrail for root account usage (should be ZERO)",
 		Remediation:     "Never use root account for daily operations",
 		ScreenshotGuide: "1. Go to CloudTrail Event History\n2. Filter by 'User name' = 'root'\n3. Screenshot showing NO recent root usage\n4. If any usage, document why",
-		ConsoleURL:      "https://console.aws.amazon.com/cloudtrail/home#/events",
+		ConsoleURL:      consoleURL("cloudtrail/home#/events", consoleRegion(ctx)),
 		Priority:        PriorityHigh,
-		Timestamp:       time.Now(),
+		Timestamp:       nowFunc(),
 	}, nil
 }
@@ -3,7 +3,6 @@ package checks
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/iam"
 )
@@ -23,11 +22,11 @@ func (c *IAMExtendedChecks) Name() string {
 func (c *IAMExtendedChecks) Run(ctx context.Context) ([]CheckResult, error) {
 	results := []CheckResult{}
 
-	if result, err := c.CheckServiceLinkedRoles(ctx); err == nil {
+	if result, err := runCheck(ctx, "iam_extended.service_linked_roles", c.CheckServiceLinkedRoles); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckPermissionBoundaries(ctx); err == nil {
+	if result, err := runCheck(ctx, "iam_extended.permission_boundaries", c.CheckPermissionBoundaries); err == nil {
 		results = append(results, result)
 	}
 
@@ -43,7 +42,7 @@ func (c *IAMExtendedChecks) CheckServiceLinkedRoles(ctx context.Context) (CheckR
 			Status:     "ERROR",
 			Evidence:   "Failed to list roles",
 			Priority:   PriorityLow,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("IAM_SERVICE_LINKED_ROLES"),
 		}, err
 	}
@@ -63,8 +62,8 @@ func (c *IAMExtendedChecks) CheckServiceLinkedRoles(ctx context.Context) (CheckR
 			Evidence:    "No service-linked roles found - may not be using AWS services that require them",
 			Remediation: "Service-linked roles are automatically created by AWS services when needed",
 			Priority:    PriorityLow,
-			Timestamp:   time.Now(),
-			ConsoleURL:  "https://console.aws.amazon.com/iam/home#/roles",
+			Timestamp:   nowFunc(),
+			ConsoleURL:  consoleURL("iam/home#/roles", consoleRegion(ctx)),
 			Frameworks:  GetFrameworkMappings("IAM_SERVICE_LINKED_ROLES"),
 		}, nil
 	}
@@ -75,8 +74,8 @@ func (c *IAMExtendedChecks) CheckServiceLinkedRoles(ctx context.Context) (CheckR
 		Status:     "PASS",
 		Evidence:   fmt.Sprintf("%d service-linked roles configured for AWS services", serviceLinkedRoles),
 		Priority:   PriorityLow,
-		Timestamp:  time.Now(),
-		ConsoleURL: "https://console.aws.amazon.com/iam/home#/roles",
+		Timestamp:  nowFunc(),
+		ConsoleURL: consoleURL("iam/home#/roles", consoleRegion(ctx)),
 		Frameworks: GetFrameworkMappings("IAM_SERVICE_LINKED_ROLES"),
 	}, nil
 }
@@ -90,7 +89,7 @@ func (c *IAMExtendedChecks) CheckPermissionBoundaries(ctx context.Context) (Chec
 			Status:     "ERROR",
 			Evidence:   "Failed to list users",
 			Priority:   PriorityLow,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("IAM_PERMISSION_BOUNDARIES"),
 		}, err
 	}
@@ -103,7 +102,7 @@ func (c *IAMExtendedChecks) CheckPermissionBoundaries(ctx context.Context) (Chec
 			Status:     "ERROR",
 			Evidence:   "Failed to list roles",
 			Priority:   PriorityLow,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("IAM_PERMISSION_BOUNDARIES"),
 		}, err
 	}
@@ -137,9 +136,9 @@ func (c *IAMExtendedChecks) CheckPermissionBoundaries(ctx context.Context) (Chec
 4. Critical for multi-tenant or delegated administration`,
 			Severity:        "MEDIUM",
 			Priority:        PriorityMedium,
-			Timestamp:       time.Now(),
+			Timestamp:       nowFunc(),
 			ScreenshotGuide: "IAM → Policies → Create permission boundary policy",
-			ConsoleURL:      "https://console.aws.amazon.com/iam/home#/policies",
+			ConsoleURL:      consoleURL("iam/home#/policies", consoleRegion(ctx)),
 			Frameworks:      GetFrameworkMappings("IAM_PERMISSION_BOUNDARIES"),
 		}, nil
 	}
@@ -150,8 +149,8 @@ func (c *IAMExtendedChecks) CheckPermissionBoundaries(ctx context.Context) (Chec
 		Status:     "PASS",
 		Evidence:   fmt.Sprintf("Permission boundaries: %d users, %d roles", usersWithBoundaries, rolesWithBoundaries),
 		Priority:   PriorityLow,
-		Timestamp:  time.Now(),
-		ConsoleURL: "https://console.aws.amazon.com/iam/home#/roles",
+		Timestamp:  nowFunc(),
+		ConsoleURL: consoleURL("iam/home#/roles", consoleRegion(ctx)),
 		Frameworks: GetFrameworkMappings("IAM_PERMISSION_BOUNDARIES"),
 	}, nil
 }
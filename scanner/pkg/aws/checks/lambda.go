@@ -3,7 +3,6 @@ package checks
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/lambda"
 )
@@ -24,23 +23,23 @@ func (c *LambdaChecks) Run(ctx context.Context) ([]CheckResult, error) {
 	results := []CheckResult{}
 
 	// CIS Section 6 - Lambda controls
-	if result, err := c.CheckLambdaInVPC(ctx); err == nil {
+	if result, err := runCheck(ctx, "lambda.in_vpc", c.CheckLambdaInVPC); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckLambdaEnvironmentEncryption(ctx); err == nil {
+	if result, err := runCheck(ctx, "lambda.environment_encryption", c.CheckLambdaEnvironmentEncryption); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckLambdaExecutionRole(ctx); err == nil {
+	if result, err := runCheck(ctx, "lambda.execution_role", c.CheckLambdaExecutionRole); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckLambdaPublicAccess(ctx); err == nil {
+	if result, err := runCheck(ctx, "lambda.public_access", c.CheckLambdaPublicAccess); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckLambdaTracing(ctx); err == nil {
+	if result, err := runCheck(ctx, "lambda.tracing", c.CheckLambdaTracing); err == nil {
 		results = append(results, result)
 	}
 
@@ -80,21 +79,23 @@ func (c *LambdaChecks) CheckLambdaInVPC(ctx context.Context) (CheckResult, error
   --function-name FUNCTION_NAME \
   --vpc-config SubnetIds=subnet-xxx,SecurityGroupIds=sg-xxx`,
 			ScreenshotGuide:   "Lambda Console → Functions → Configuration → VPC → Screenshot showing VPC configuration",
-			ConsoleURL:        "https://console.aws.amazon.com/lambda/home#/functions",
+			ConsoleURL:        consoleURL("lambda/home#/functions", consoleRegion(ctx)),
 			Priority:          PriorityMedium,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        map[string]string{"CIS-AWS": "6.1", "SOC2": "CC6.6"},
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "[CIS-6.1]",
-		Name:       "Lambda Functions in VPC",
-		Status:     "PASS",
-		Evidence:   fmt.Sprintf("All %d Lambda functions are in VPC | Meets CIS 6.1", totalFunctions),
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: map[string]string{"CIS-AWS": "6.1"},
+		Control:         "[CIS-6.1]",
+		Name:            "Lambda Functions in VPC",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d Lambda functions are in VPC | Meets CIS 6.1", totalFunctions),
+		ScreenshotGuide: "Lambda Console → Functions → Configuration → VPC → Screenshot showing VPC configuration",
+		ConsoleURL:      consoleURL("lambda/home#/functions", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      map[string]string{"CIS-AWS": "6.1"},
 	}, nil
 }
 
@@ -138,9 +139,9 @@ aws lambda update-function-configuration \
   --function-name FUNCTION_NAME \
   --kms-key-arn arn:aws:kms:region:account:key/KEY_ID`,
 			ScreenshotGuide:   "Lambda Console → Functions → Configuration → Environment variables → Encryption → Screenshot showing KMS key",
-			ConsoleURL:        "https://console.aws.amazon.com/lambda/home#/functions",
+			ConsoleURL:        consoleURL("lambda/home#/functions", consoleRegion(ctx)),
 			Priority:          PriorityHigh,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        map[string]string{"CIS-AWS": "6.2", "SOC2": "CC6.7", "PCI-DSS": "3.4"},
 		}, nil
 	}
@@ -151,20 +152,23 @@ aws lambda update-function-configuration \
 			Name:       "Lambda Environment Encryption",
 			Status:     "PASS",
 			Evidence:   "No Lambda functions with environment variables | CIS 6.2 N/A",
+			ConsoleURL: consoleURL("lambda/home#/functions", consoleRegion(ctx)),
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: map[string]string{"CIS-AWS": "6.2"},
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "[CIS-6.2]",
-		Name:       "Lambda Environment Encryption",
-		Status:     "PASS",
-		Evidence:   fmt.Sprintf("All %d functions with environment variables use KMS encryption | Meets CIS 6.2", totalWithEnvVars),
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: map[string]string{"CIS-AWS": "6.2"},
+		Control:         "[CIS-6.2]",
+		Name:            "Lambda Environment Encryption",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d functions with environment variables use KMS encryption | Meets CIS 6.2", totalWithEnvVars),
+		ScreenshotGuide: "Lambda Console → Functions → Configuration → Environment variables → Encryption → Screenshot showing KMS key",
+		ConsoleURL:      consoleURL("lambda/home#/functions", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      map[string]string{"CIS-AWS": "6.2"},
 	}, nil
 }
 
@@ -209,21 +213,23 @@ aws lambda update-function-configuration \
   --function-name FUNCTION_NAME \
   --role arn:aws:iam::ACCOUNT:role/LambdaExecutionRole`,
 			ScreenshotGuide:   "Lambda Console → Functions → Configuration → Permissions → Screenshot showing least-privilege role",
-			ConsoleURL:        "https://console.aws.amazon.com/lambda/home#/functions",
+			ConsoleURL:        consoleURL("lambda/home#/functions", consoleRegion(ctx)),
 			Priority:          PriorityHigh,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        map[string]string{"CIS-AWS": "6.3", "SOC2": "CC6.3", "PCI-DSS": "7.1.2"},
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "[CIS-6.3]",
-		Name:       "Lambda Execution Role Permissions",
-		Status:     "PASS",
-		Evidence:   "Lambda functions use least-privilege execution roles | Meets CIS 6.3",
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: map[string]string{"CIS-AWS": "6.3"},
+		Control:         "[CIS-6.3]",
+		Name:            "Lambda Execution Role Permissions",
+		Status:          "PASS",
+		Evidence:        "Lambda functions use least-privilege execution roles | Meets CIS 6.3",
+		ScreenshotGuide: "Lambda Console → Functions → Configuration → Permissions → Screenshot showing least-privilege role",
+		ConsoleURL:      consoleURL("lambda/home#/functions", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      map[string]string{"CIS-AWS": "6.3"},
 	}, nil
 }
 
@@ -272,9 +278,9 @@ func (c *LambdaChecks) CheckLambdaPublicAccess(ctx context.Context) (CheckResult
   --function-name FUNCTION_NAME \
   --statement-id AllowPublicInvoke`,
 			ScreenshotGuide:   "Lambda Console → Functions → Configuration → Permissions → Resource-based policy → Screenshot showing no public access",
-			ConsoleURL:        "https://console.aws.amazon.com/lambda/home#/functions",
+			ConsoleURL:        consoleURL("lambda/home#/functions", consoleRegion(ctx)),
 			Priority:          PriorityCritical,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        map[string]string{"CIS-AWS": "6.4", "SOC2": "CC6.1", "PCI-DSS": "1.2.1"},
 		}, nil
 	}
@@ -284,8 +290,9 @@ func (c *LambdaChecks) CheckLambdaPublicAccess(ctx context.Context) (CheckResult
 		Name:       "Lambda Functions Not Public",
 		Status:     "PASS",
 		Evidence:   "No Lambda functions are publicly accessible | Meets CIS 6.4",
+		ConsoleURL: consoleURL("lambda/home#/functions", consoleRegion(ctx)),
 		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
+		Timestamp:  nowFunc(),
 		Frameworks: map[string]string{"CIS-AWS": "6.4"},
 	}, nil
 }
@@ -323,20 +330,22 @@ func (c *LambdaChecks) CheckLambdaTracing(ctx context.Context) (CheckResult, err
   --function-name FUNCTION_NAME \
   --tracing-config Mode=Active`,
 			ScreenshotGuide:   "Lambda Console → Functions → Configuration → Monitoring → Screenshot showing X-Ray tracing enabled",
-			ConsoleURL:        "https://console.aws.amazon.com/lambda/home#/functions",
+			ConsoleURL:        consoleURL("lambda/home#/functions", consoleRegion(ctx)),
 			Priority:          PriorityLow,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        map[string]string{"CIS-AWS": "6.5", "SOC2": "CC7.2"},
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "[CIS-6.5]",
-		Name:       "Lambda X-Ray Tracing Enabled",
-		Status:     "PASS",
-		Evidence:   fmt.Sprintf("All %d Lambda functions have X-Ray tracing enabled | Meets CIS 6.5", totalFunctions),
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: map[string]string{"CIS-AWS": "6.5"},
+		Control:         "[CIS-6.5]",
+		Name:            "Lambda X-Ray Tracing Enabled",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d Lambda functions have X-Ray tracing enabled | Meets CIS 6.5", totalFunctions),
+		ScreenshotGuide: "Lambda Console → Functions → Configuration → Monitoring → Screenshot showing X-Ray tracing enabled",
+		ConsoleURL:      consoleURL("lambda/home#/functions", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      map[string]string{"CIS-AWS": "6.5"},
 	}, nil
 }
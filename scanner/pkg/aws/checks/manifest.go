@@ -0,0 +1,70 @@
+package checks
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/guardian-nexus/auditkit/scanner/pkg/core"
+)
+
+// ManifestEntry describes one check without running it: the module it
+// belongs to and what it reports when it fails
+type ManifestEntry struct {
+	ID         string            `json:"id"`                 // checks config ID, e.g. redshift.cluster_encryption
+	Service    string            `json:"service"`            // check module, the ID's prefix
+	Control    string            `json:"control"`            // control reported, e.g. CC6.3 or [CIS-3.8]
+	Name       string            `json:"name"`               // result name
+	Severity   string            `json:"severity,omitempty"` // default severity of a failure; empty for informational checks
+	Deep       bool              `json:"deep,omitempty"`     // inspects resources one Describe/Get call at a time; skipped by ProfileQuick
+	Frameworks map[string]string `json:"frameworks,omitempty"`
+}
+
+// Manifest lists every check that can be enabled or disabled by ID, sorted by
+// ID, for documentation and gap analysis. Severities are the checks' own,
+// before any severity overrides.
+func Manifest() []ManifestEntry {
+	entries := make([]ManifestEntry, 0, len(checkManifest))
+	for _, entry := range checkManifest {
+		entry.Service, _, _ = strings.Cut(entry.ID, ".")
+
+		frameworks := make(map[string]string, len(entry.Frameworks))
+		for framework, control := range entry.Frameworks {
+			frameworks[framework] = control
+		}
+		entry.Frameworks = frameworks
+
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].ID < entries[j].ID
+	})
+	return entries
+}
+
+// EnabledChecks lists the checks subsequent runs without a Scan execute:
+// those the scan profile includes and SetDisabledChecks has not turned off,
+// sorted by ID
+func EnabledChecks() []core.CheckRef {
+	return defaultScan.EnabledChecks()
+}
+
+// EnabledChecks lists the checks the scan executes: those its profile
+// includes and that are not disabled, sorted by ID. It is recorded in the
+// scan metadata as the scan's check catalog.
+func (s *Scan) EnabledChecks() []core.CheckRef {
+	s.configMu.Lock()
+	defer s.configMu.Unlock()
+
+	refs := []core.CheckRef{}
+	for _, entry := range checkManifest {
+		if s.disabled[entry.ID] || (s.profile == ProfileQuick && entry.Deep) {
+			continue
+		}
+		refs = append(refs, core.CheckRef{ID: entry.ID, Name: entry.Name})
+	}
+	sort.Slice(refs, func(i, j int) bool {
+		return refs[i].ID < refs[j].ID
+	})
+	return refs
+}
@@ -0,0 +1,76 @@
+package checks
+
+import "context"
+
+// memoEntry is one memoized call. done is closed once value and err are set,
+// so concurrent callers for the same key wait for the first call instead of
+// repeating it.
+type memoEntry struct {
+	done  chan struct{}
+	value any
+	err   error
+}
+
+// ResetDescribeCache drops the describe results memoized by runs without a
+// Scan and zeroes the hit and miss counters, ready for a new scan
+func ResetDescribeCache() {
+	defaultScan.memoMu.Lock()
+	defer defaultScan.memoMu.Unlock()
+
+	defaultScan.memoEntries = map[string]*memoEntry{}
+	defaultScan.memoHits.Store(0)
+	defaultScan.memoMisses.Store(0)
+}
+
+// DescribeCacheStats returns how many memoized describe calls in runs without
+// a Scan were answered from memory (hits) and how many went to AWS (misses)
+// since the last ResetDescribeCache
+func DescribeCacheStats() (hits, misses int64) {
+	return defaultScan.DescribeCacheStats()
+}
+
+// DescribeCacheStats returns how many of the scan's memoized describe calls
+// were answered from memory (hits) and how many went to AWS (misses)
+func (s *Scan) DescribeCacheStats() (hits, misses int64) {
+	return s.memoHits.Load(), s.memoMisses.Load()
+}
+
+// memoize returns the result of fetch for key, calling fetch only the first
+// time key is seen in ctx's Scan. Several checks describe the same resource
+// (every EKS check calls DescribeCluster per cluster), so within one scan the
+// first call goes to AWS and the rest are answered from memory. This is
+// request coalescing for a single scan, not the offline cache: nothing
+// outlives the Scan. key must identify the resource uniquely across regions:
+// an ARN, or the service, region and name. Errors are shared with callers
+// already waiting but not kept, so a later call retries.
+func memoize[T any](ctx context.Context, key string, fetch func() (T, error)) (T, error) {
+	scan := scanFrom(ctx)
+	scan.memoMu.Lock()
+	entry, ok := scan.memoEntries[key]
+	if !ok {
+		entry = &memoEntry{done: make(chan struct{})}
+		scan.memoEntries[key] = entry
+	}
+	scan.memoMu.Unlock()
+
+	if ok {
+		<-entry.done
+		scan.memoHits.Add(1)
+		value, _ := entry.value.(T)
+		return value, entry.err
+	}
+
+	scan.memoMisses.Add(1)
+	value, err := fetch()
+	entry.value, entry.err = value, err
+	close(entry.done)
+
+	if err != nil {
+		scan.memoMu.Lock()
+		if scan.memoEntries[key] == entry {
+			delete(scan.memoEntries, key)
+		}
+		scan.memoMu.Unlock()
+	}
+	return value, err
+}
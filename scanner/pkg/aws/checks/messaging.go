@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/sns"
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
@@ -31,17 +30,17 @@ func (c *MessagingChecks) Run(ctx context.Context) ([]CheckResult, error) {
 	results := []CheckResult{}
 
 	// CIS Section 10.13 - SNS Encryption
-	if result, err := c.CheckSNSEncryption(ctx); err == nil {
+	if result, err := runCheck(ctx, "messaging.sns_encryption", c.CheckSNSEncryption); err == nil {
 		results = append(results, result)
 	}
 
 	// CIS Section 10.14 - SQS Encryption
-	if result, err := c.CheckSQSEncryption(ctx); err == nil {
+	if result, err := runCheck(ctx, "messaging.sqs_encryption", c.CheckSQSEncryption); err == nil {
 		results = append(results, result)
 	}
 
 	// CIS Section 10.15 - Messaging Access Policies
-	if result, err := c.CheckMessagingAccessPolicies(ctx); err == nil {
+	if result, err := runCheck(ctx, "messaging.access_policies", c.CheckMessagingAccessPolicies); err == nil {
 		results = append(results, result)
 	}
 
@@ -59,7 +58,7 @@ func (c *MessagingChecks) CheckSNSEncryption(ctx context.Context) (CheckResult,
 			Evidence:    fmt.Sprintf("Failed to list SNS topics: %v", err),
 			Remediation: "Verify SNS access permissions",
 			Priority:    PriorityLow,
-			Timestamp:   time.Now(),
+			Timestamp:   nowFunc(),
 			Frameworks:  GetFrameworkMappings("SNS_ENCRYPTION"),
 		}, err
 	}
@@ -78,9 +77,9 @@ func (c *MessagingChecks) CheckSNSEncryption(ctx context.Context) (CheckResult,
 4. Select KMS key (default or custom)
 5. Screenshot showing encrypted topic`,
 			Priority:        PriorityLow,
-			Timestamp:       time.Now(),
+			Timestamp:       nowFunc(),
 			ScreenshotGuide: "SNS → Topics → Screenshot showing no topics",
-			ConsoleURL:      "https://console.aws.amazon.com/sns/home#/topics",
+			ConsoleURL:      consoleURL("sns/home#/topics", consoleRegion(ctx)),
 			Frameworks:      GetFrameworkMappings("SNS_ENCRYPTION"),
 		}, nil
 	}
@@ -114,7 +113,7 @@ func (c *MessagingChecks) CheckSNSEncryption(ctx context.Context) (CheckResult,
 			Control:     "CIS-10.13",
 			Name:        "SNS Topic Encryption",
 			Status:      "FAIL",
-			Evidence:    fmt.Sprintf("%d/%d SNS topics lack encryption at rest: %v", len(unencryptedTopics), len(topics.Topics), unencryptedTopics),
+			Evidence:    fmt.Sprintf("%d/%d SNS topics lack encryption at rest: %s", len(unencryptedTopics), len(topics.Topics), TruncateList(unencryptedTopics, evidenceListLimit(ctx))),
 			Remediation: "Enable encryption for unencrypted SNS topics",
 			RemediationDetail: fmt.Sprintf(`1. Open SNS console
 2. For each unencrypted topic: %v
@@ -126,9 +125,9 @@ func (c *MessagingChecks) CheckSNSEncryption(ctx context.Context) (CheckResult,
 8. Screenshot showing encryption enabled`, unencryptedTopics),
 			Severity:        "HIGH",
 			Priority:        PriorityHigh,
-			Timestamp:       time.Now(),
+			Timestamp:       nowFunc(),
 			ScreenshotGuide: "SNS → Topics → Topic → Edit → Screenshot showing encryption enabled",
-			ConsoleURL:      "https://console.aws.amazon.com/sns/home#/topics",
+			ConsoleURL:      consoleURL("sns/home#/topics", consoleRegion(ctx)),
 			Frameworks:      GetFrameworkMappings("SNS_ENCRYPTION"),
 		}, nil
 	}
@@ -143,9 +142,9 @@ func (c *MessagingChecks) CheckSNSEncryption(ctx context.Context) (CheckResult,
 Continue using encryption for all new topics.`, encryptedTopics),
 		Severity:        "INFO",
 		Priority:        PriorityLow,
-		Timestamp:       time.Now(),
+		Timestamp:       nowFunc(),
 		ScreenshotGuide: "SNS → Topics → Screenshot showing all topics encrypted",
-		ConsoleURL:      "https://console.aws.amazon.com/sns/home#/topics",
+		ConsoleURL:      consoleURL("sns/home#/topics", consoleRegion(ctx)),
 		Frameworks:      GetFrameworkMappings("SNS_ENCRYPTION"),
 	}, nil
 }
@@ -161,7 +160,7 @@ func (c *MessagingChecks) CheckSQSEncryption(ctx context.Context) (CheckResult,
 			Evidence:    fmt.Sprintf("Failed to list SQS queues: %v", err),
 			Remediation: "Verify SQS access permissions",
 			Priority:    PriorityLow,
-			Timestamp:   time.Now(),
+			Timestamp:   nowFunc(),
 			Frameworks:  GetFrameworkMappings("SQS_ENCRYPTION"),
 		}, err
 	}
@@ -180,9 +179,9 @@ func (c *MessagingChecks) CheckSQSEncryption(ctx context.Context) (CheckResult,
 4. Select KMS key (default or custom)
 5. Screenshot showing encrypted queue`,
 			Priority:        PriorityLow,
-			Timestamp:       time.Now(),
+			Timestamp:       nowFunc(),
 			ScreenshotGuide: "SQS → Queues → Screenshot showing no queues",
-			ConsoleURL:      "https://console.aws.amazon.com/sqs/home#/queues",
+			ConsoleURL:      consoleURL("sqs/home#/queues", consoleRegion(ctx)),
 			Frameworks:      GetFrameworkMappings("SQS_ENCRYPTION"),
 		}, nil
 	}
@@ -217,7 +216,7 @@ func (c *MessagingChecks) CheckSQSEncryption(ctx context.Context) (CheckResult,
 			Control:     "CIS-10.14",
 			Name:        "SQS Queue Encryption",
 			Status:      "FAIL",
-			Evidence:    fmt.Sprintf("%d/%d SQS queues lack encryption at rest: %v", len(unencryptedQueues), len(queues.QueueUrls), unencryptedQueues),
+			Evidence:    fmt.Sprintf("%d/%d SQS queues lack encryption at rest: %s", len(unencryptedQueues), len(queues.QueueUrls), TruncateList(unencryptedQueues, evidenceListLimit(ctx))),
 			Remediation: "Enable encryption for unencrypted SQS queues",
 			RemediationDetail: fmt.Sprintf(`1. Open SQS console
 2. For each unencrypted queue: %v
@@ -229,9 +228,9 @@ func (c *MessagingChecks) CheckSQSEncryption(ctx context.Context) (CheckResult,
 8. Screenshot showing encryption enabled`, unencryptedQueues),
 			Severity:        "HIGH",
 			Priority:        PriorityHigh,
-			Timestamp:       time.Now(),
+			Timestamp:       nowFunc(),
 			ScreenshotGuide: "SQS → Queues → Queue → Edit → Screenshot showing SSE enabled",
-			ConsoleURL:      "https://console.aws.amazon.com/sqs/home#/queues",
+			ConsoleURL:      consoleURL("sqs/home#/queues", consoleRegion(ctx)),
 			Frameworks:      GetFrameworkMappings("SQS_ENCRYPTION"),
 		}, nil
 	}
@@ -246,9 +245,9 @@ func (c *MessagingChecks) CheckSQSEncryption(ctx context.Context) (CheckResult,
 Continue using encryption for all new queues.`, encryptedQueues),
 		Severity:        "INFO",
 		Priority:        PriorityLow,
-		Timestamp:       time.Now(),
+		Timestamp:       nowFunc(),
 		ScreenshotGuide: "SQS → Queues → Screenshot showing all queues encrypted",
-		ConsoleURL:      "https://console.aws.amazon.com/sqs/home#/queues",
+		ConsoleURL:      consoleURL("sqs/home#/queues", consoleRegion(ctx)),
 		Frameworks:      GetFrameworkMappings("SQS_ENCRYPTION"),
 	}, nil
 }
@@ -265,7 +264,7 @@ func (c *MessagingChecks) CheckMessagingAccessPolicies(ctx context.Context) (Che
 			Evidence:    fmt.Sprintf("Failed to list topics: %v", err),
 			Remediation: "Verify permissions",
 			Priority:    PriorityLow,
-			Timestamp:   time.Now(),
+			Timestamp:   nowFunc(),
 			Frameworks:  GetFrameworkMappings("MESSAGING_ACCESS_POLICY"),
 		}, err
 	}
@@ -280,7 +279,7 @@ func (c *MessagingChecks) CheckMessagingAccessPolicies(ctx context.Context) (Che
 			Evidence:    fmt.Sprintf("Failed to list queues: %v", err),
 			Remediation: "Verify permissions",
 			Priority:    PriorityLow,
-			Timestamp:   time.Now(),
+			Timestamp:   nowFunc(),
 			Frameworks:  GetFrameworkMappings("MESSAGING_ACCESS_POLICY"),
 		}, err
 	}
@@ -295,7 +294,7 @@ func (c *MessagingChecks) CheckMessagingAccessPolicies(ctx context.Context) (Che
 			Evidence:    "No SNS topics or SQS queues found",
 			Remediation: "N/A - No messaging resources to check",
 			Priority:    PriorityLow,
-			Timestamp:   time.Now(),
+			Timestamp:   nowFunc(),
 			Frameworks:  GetFrameworkMappings("MESSAGING_ACCESS_POLICY"),
 		}, nil
 	}
@@ -326,9 +325,9 @@ func (c *MessagingChecks) CheckMessagingAccessPolicies(ctx context.Context) (Che
    - Use Condition elements to restrict access`, len(topics.Topics), len(queues.QueueUrls)),
 		Severity:        "CRITICAL",
 		Priority:        PriorityCritical,
-		Timestamp:       time.Now(),
+		Timestamp:       nowFunc(),
 		ScreenshotGuide: "SNS/SQS → Resource → Access policy → Screenshot showing restrictive policies",
-		ConsoleURL:      "https://console.aws.amazon.com/sns/home#/topics",
+		ConsoleURL:      consoleURL("sns/home#/topics", consoleRegion(ctx)),
 		Frameworks:      GetFrameworkMappings("MESSAGING_ACCESS_POLICY"),
 	}, nil
 }
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
 	"github.com/aws/aws-sdk-go-v2/service/securityhub"
@@ -32,19 +31,19 @@ func (c *MonitoringChecks) Name() string {
 func (c *MonitoringChecks) Run(ctx context.Context) ([]CheckResult, error) {
 	results := []CheckResult{}
 
-	if result, err := c.CheckCloudWatchAlarms(ctx); err == nil {
+	if result, err := runCheck(ctx, "monitoring.cloudwatch_alarms", c.CheckCloudWatchAlarms); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckSNSTopics(ctx); err == nil {
+	if result, err := runCheck(ctx, "monitoring.sns_topics", c.CheckSNSTopics); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckSecurityHubEnabled(ctx); err == nil {
+	if result, err := runCheck(ctx, "monitoring.security_hub_enabled", c.CheckSecurityHubEnabled); err == nil {
 		results = append(results, result)
 	}
 
-	return results, nil
+	return withSOC2Mapping(results), nil
 }
 
 func (c *MonitoringChecks) CheckCloudWatchAlarms(ctx context.Context) (CheckResult, error) {
@@ -88,19 +87,21 @@ func (c *MonitoringChecks) CheckCloudWatchAlarms(ctx context.Context) (CheckResu
 			Remediation:       "Create CloudWatch alarms for security events",
 			RemediationDetail: "Create alarms for: root usage, unauthorized API calls, IAM changes, etc.",
 			ScreenshotGuide:   "1. Go to CloudWatch → Alarms\n2. Screenshot list of security alarms\n3. Each alarm should notify SNS topic\n4. Show alarm history (triggered events)",
-			ConsoleURL:        "https://console.aws.amazon.com/cloudwatch/home#alarmsV2",
+			ConsoleURL:        consoleURL("cloudwatch/home#alarmsV2", consoleRegion(ctx)),
 			Priority:          PriorityHigh,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:   "CC7.3",
-		Name:      "Security Event Monitoring",
-		Status:    "PASS",
-		Evidence:  fmt.Sprintf("All critical security alarms configured (%d total)", len(alarms.MetricAlarms)),
-		Priority:  PriorityInfo,
-		Timestamp: time.Now(),
+		Control:         "CC7.3",
+		Name:            "Security Event Monitoring",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All critical security alarms configured (%d total)", len(alarms.MetricAlarms)),
+		ScreenshotGuide: "1. Go to CloudWatch → Alarms\n2. Screenshot list of security alarms\n3. Each alarm should notify SNS topic\n4. Show alarm history (triggered events)",
+		ConsoleURL:      consoleURL("cloudwatch/home#alarmsV2", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
 	}, nil
 }
 
@@ -120,19 +121,21 @@ func (c *MonitoringChecks) CheckSNSTopics(ctx context.Context) (CheckResult, err
 			Remediation:       "Create SNS topic for security alerts",
 			RemediationDetail: "aws sns create-topic --name security-alerts && aws sns subscribe --topic-arn ARN --protocol email --notification-endpoint security@company.com",
 			ScreenshotGuide:   "1. Go to SNS → Topics\n2. Screenshot security alert topic\n3. Show subscriptions (email/Slack)",
-			ConsoleURL:        "https://console.aws.amazon.com/sns/v3/home#/topics",
+			ConsoleURL:        consoleURL("sns/v3/home#/topics", consoleRegion(ctx)),
 			Priority:          PriorityHigh,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:   "CC7.4",
-		Name:      "Alert Notifications",
-		Status:    "PASS",
-		Evidence:  fmt.Sprintf("%d SNS topics configured", len(topics.Topics)),
-		Priority:  PriorityInfo,
-		Timestamp: time.Now(),
+		Control:         "CC7.4",
+		Name:            "Alert Notifications",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("%d SNS topics configured", len(topics.Topics)),
+		ScreenshotGuide: "1. Go to SNS → Topics\n2. Screenshot security alert topic\n3. Show subscriptions (email/Slack)",
+		ConsoleURL:      consoleURL("sns/v3/home#/topics", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
 	}, nil
 }
 
@@ -186,9 +189,9 @@ Also consider enabling:
 3. Screenshot of enabled security standards (CIS, AWS Foundational)
 4. Show findings summary and security score
 5. For multi-region: Screenshot showing Security Hub enabled in all active regions`,
-				ConsoleURL: "https://console.aws.amazon.com/securityhub/home",
+				ConsoleURL: consoleURL("securityhub/home", consoleRegion(ctx)),
 				Priority:   PriorityMedium,
-				Timestamp:  time.Now(),
+				Timestamp:  nowFunc(),
 				Frameworks: GetFrameworkMappings("SECURITY_HUB"),
 			}, nil
 		}
@@ -203,7 +206,7 @@ Also consider enabling:
 			Remediation:       "Verify IAM permissions to check Security Hub status",
 			RemediationDetail: "Ensure the IAM role has securityhub:DescribeHub permission",
 			Priority:          PriorityMedium,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("SECURITY_HUB"),
 		}, nil
 	}
@@ -218,7 +221,7 @@ Also consider enabling:
 			Evidence:   "Security Hub is enabled but Hub ARN is missing",
 			Remediation: "Verify Security Hub configuration",
 			Priority:   PriorityMedium,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("SECURITY_HUB"),
 		}, nil
 	}
@@ -234,8 +237,9 @@ Also consider enabling:
 		Name:       "AWS Security Hub Enabled",
 		Status:     "PASS",
 		Evidence:   fmt.Sprintf("AWS Security Hub is enabled | Hub ARN: %s | Subscribed: %s", *hub.HubArn, subscriptionDate),
+		ConsoleURL: consoleURL("securityhub/home", consoleRegion(ctx)),
 		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
+		Timestamp:  nowFunc(),
 		Frameworks: GetFrameworkMappings("SECURITY_HUB"),
 	}, nil
 }
@@ -3,7 +3,6 @@ package checks
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
@@ -30,15 +29,15 @@ func (c *NetworkFirewallChecks) Run(ctx context.Context) ([]CheckResult, error)
 	results := []CheckResult{}
 
 	// CIS Section 5 - Network Firewall controls (5.15-5.17)
-	if result, err := c.CheckNetworkFirewallSubnetPlacement(ctx); err == nil {
+	if result, err := runCheck(ctx, "network_firewall.subnet_placement", c.CheckNetworkFirewallSubnetPlacement); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckNetworkFirewallPolicyRules(ctx); err == nil {
+	if result, err := runCheck(ctx, "network_firewall.policy_rules", c.CheckNetworkFirewallPolicyRules); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckNetworkFirewallLogging(ctx); err == nil {
+	if result, err := runCheck(ctx, "network_firewall.logging", c.CheckNetworkFirewallLogging); err == nil {
 		results = append(results, result)
 	}
 
@@ -55,8 +54,9 @@ func (c *NetworkFirewallChecks) CheckNetworkFirewallSubnetPlacement(ctx context.
 			Name:       "Network Firewall AZ Deployment",
 			Status:     "PASS",
 			Evidence:   "No Network Firewalls deployed | CIS 5.15 N/A",
+			ConsoleURL: consoleURL("vpc/home#NetworkFirewalls", consoleRegion(ctx)),
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: map[string]string{"CIS-AWS": "5.15"},
 		}, nil
 	}
@@ -68,7 +68,7 @@ func (c *NetworkFirewallChecks) CheckNetworkFirewallSubnetPlacement(ctx context.
 			Status:     "INFO",
 			Evidence:   "No Network Firewalls deployed | Consider deploying for enhanced network security",
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: map[string]string{"CIS-AWS": "5.15"},
 		}, nil
 	}
@@ -138,7 +138,7 @@ func (c *NetworkFirewallChecks) CheckNetworkFirewallSubnetPlacement(ctx context.
 			Name:              "Network Firewall AZ Deployment",
 			Status:            "FAIL",
 			Severity:          "MEDIUM",
-			Evidence:          fmt.Sprintf("%d/%d firewalls not deployed in all AZs: %v | CIS 5.15", len(firewallsWithMissingAZs), len(firewalls.Firewalls), firewallsWithMissingAZs),
+			Evidence:          fmt.Sprintf("%d/%d firewalls not deployed in all AZs: %s | CIS 5.15", len(firewallsWithMissingAZs), len(firewalls.Firewalls), TruncateList(firewallsWithMissingAZs, evidenceListLimit(ctx))),
 			Remediation:       "Deploy Network Firewall in all availability zones",
 			RemediationDetail: `# Update firewall subnet mappings to include all AZs:
 aws network-firewall update-subnet-change-protection \
@@ -149,21 +149,23 @@ aws network-firewall associate-subnets \
   --firewall-name FIREWALL_NAME \
   --subnet-mappings SubnetId=subnet-xxx`,
 			ScreenshotGuide:   "Network Firewall Console → Firewalls → Subnets → Screenshot showing subnet in each AZ",
-			ConsoleURL:        "https://console.aws.amazon.com/vpc/home#NetworkFirewalls",
+			ConsoleURL:        consoleURL("vpc/home#NetworkFirewalls", consoleRegion(ctx)),
 			Priority:          PriorityMedium,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        map[string]string{"CIS-AWS": "5.15", "SOC2": "CC6.6"},
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "[CIS-5.15]",
-		Name:       "Network Firewall AZ Deployment",
-		Status:     "PASS",
-		Evidence:   fmt.Sprintf("All %d Network Firewalls are deployed across all AZs | Meets CIS 5.15", len(firewalls.Firewalls)),
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: map[string]string{"CIS-AWS": "5.15"},
+		Control:         "[CIS-5.15]",
+		Name:            "Network Firewall AZ Deployment",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d Network Firewalls are deployed across all AZs | Meets CIS 5.15", len(firewalls.Firewalls)),
+		ScreenshotGuide: "Network Firewall Console → Firewalls → Subnets → Screenshot showing subnet in each AZ",
+		ConsoleURL:      consoleURL("vpc/home#NetworkFirewalls", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      map[string]string{"CIS-AWS": "5.15"},
 	}, nil
 }
 
@@ -177,8 +179,9 @@ func (c *NetworkFirewallChecks) CheckNetworkFirewallPolicyRules(ctx context.Cont
 			Name:       "Network Firewall Policy Rules",
 			Status:     "PASS",
 			Evidence:   "No Network Firewall policies found | CIS 5.16 N/A",
+			ConsoleURL: consoleURL("vpc/home#FirewallPolicies", consoleRegion(ctx)),
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: map[string]string{"CIS-AWS": "5.16"},
 		}, nil
 	}
@@ -190,7 +193,7 @@ func (c *NetworkFirewallChecks) CheckNetworkFirewallPolicyRules(ctx context.Cont
 			Status:     "INFO",
 			Evidence:   "No Network Firewall policies found | Consider creating firewall policies",
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: map[string]string{"CIS-AWS": "5.16"},
 		}, nil
 	}
@@ -246,21 +249,23 @@ aws network-firewall update-firewall-policy \
   --firewall-policy-name POLICY_NAME \
   --firewall-policy StatefulRuleGroupReferences='[{"ResourceArn":"arn:aws:network-firewall:..."}]'`,
 			ScreenshotGuide:   "Network Firewall Console → Firewall policies → Rule groups → Screenshot showing stateful rules",
-			ConsoleURL:        "https://console.aws.amazon.com/vpc/home#FirewallPolicies",
+			ConsoleURL:        consoleURL("vpc/home#FirewallPolicies", consoleRegion(ctx)),
 			Priority:          PriorityHigh,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        map[string]string{"CIS-AWS": "5.16", "SOC2": "CC6.1", "PCI-DSS": "1.2"},
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "[CIS-5.16]",
-		Name:       "Network Firewall Policy Rules",
-		Status:     "PASS",
-		Evidence:   fmt.Sprintf("All %d Network Firewall policies have stateful rule groups | Meets CIS 5.16", len(policies.FirewallPolicies)),
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: map[string]string{"CIS-AWS": "5.16"},
+		Control:         "[CIS-5.16]",
+		Name:            "Network Firewall Policy Rules",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d Network Firewall policies have stateful rule groups | Meets CIS 5.16", len(policies.FirewallPolicies)),
+		ScreenshotGuide: "Network Firewall Console → Firewall policies → Rule groups → Screenshot showing stateful rules",
+		ConsoleURL:      consoleURL("vpc/home#FirewallPolicies", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      map[string]string{"CIS-AWS": "5.16"},
 	}, nil
 }
 
@@ -274,8 +279,9 @@ func (c *NetworkFirewallChecks) CheckNetworkFirewallLogging(ctx context.Context)
 			Name:       "Network Firewall Logging",
 			Status:     "PASS",
 			Evidence:   "No Network Firewalls found | CIS 5.17 N/A",
+			ConsoleURL: consoleURL("vpc/home#NetworkFirewalls", consoleRegion(ctx)),
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: map[string]string{"CIS-AWS": "5.17"},
 		}, nil
 	}
@@ -287,7 +293,7 @@ func (c *NetworkFirewallChecks) CheckNetworkFirewallLogging(ctx context.Context)
 			Status:     "INFO",
 			Evidence:   "No Network Firewalls deployed | CIS 5.17 N/A",
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: map[string]string{"CIS-AWS": "5.17"},
 		}, nil
 	}
@@ -346,21 +352,23 @@ aws network-firewall update-logging-configuration \
     }]
   }'`,
 			ScreenshotGuide:   "Network Firewall Console → Firewalls → Logging → Screenshot showing logging enabled",
-			ConsoleURL:        "https://console.aws.amazon.com/vpc/home#NetworkFirewalls",
+			ConsoleURL:        consoleURL("vpc/home#NetworkFirewalls", consoleRegion(ctx)),
 			Priority:          PriorityHigh,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        map[string]string{"CIS-AWS": "5.17", "SOC2": "CC7.2", "PCI-DSS": "10.2"},
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "[CIS-5.17]",
-		Name:       "Network Firewall Logging",
-		Status:     "PASS",
-		Evidence:   fmt.Sprintf("All %d Network Firewalls have logging enabled | Meets CIS 5.17", len(firewalls.Firewalls)),
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: map[string]string{"CIS-AWS": "5.17"},
+		Control:         "[CIS-5.17]",
+		Name:            "Network Firewall Logging",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d Network Firewalls have logging enabled | Meets CIS 5.17", len(firewalls.Firewalls)),
+		ScreenshotGuide: "Network Firewall Console → Firewalls → Logging → Screenshot showing logging enabled",
+		ConsoleURL:      consoleURL("vpc/home#NetworkFirewalls", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      map[string]string{"CIS-AWS": "5.17"},
 	}, nil
 }
 
@@ -2,19 +2,56 @@ package checks
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
-	"time"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/acm"
+	acmtypes "github.com/aws/aws-sdk-go-v2/service/acm/types"
 	"github.com/aws/aws-sdk-go-v2/service/opensearch"
+	opensearchtypes "github.com/aws/aws-sdk-go-v2/service/opensearch/types"
 )
 
 type OpenSearchChecks struct {
-	client *opensearch.Client
+	client    *opensearch.Client
+	acmClient *acm.Client
+
+	// minEngineVersions maps engine name to the oldest supported version
+	minEngineVersions map[string]string
+
+	// domainNames limits the checks to these domains when set
+	domainNames []string
+}
+
+// DefaultOpenSearchMinEngineVersions are the oldest engine versions still in
+// AWS standard support; CheckEngineVersion flags anything older
+var DefaultOpenSearchMinEngineVersions = map[string]string{
+	"OpenSearch":    "1.3",
+	"Elasticsearch": "7.10",
+}
+
+func NewOpenSearchChecks(client *opensearch.Client, acmClient *acm.Client) *OpenSearchChecks {
+	minimums := map[string]string{}
+	for engine, version := range DefaultOpenSearchMinEngineVersions {
+		minimums[engine] = version
+	}
+	return &OpenSearchChecks{client: client, acmClient: acmClient, minEngineVersions: minimums}
 }
 
-func NewOpenSearchChecks(client *opensearch.Client) *OpenSearchChecks {
-	return &OpenSearchChecks{client: client}
+// SetMinimumEngineVersion sets the oldest acceptable version for an engine,
+// "OpenSearch" or "Elasticsearch", e.g. SetMinimumEngineVersion("OpenSearch", "2.11")
+func (c *OpenSearchChecks) SetMinimumEngineVersion(engine, version string) {
+	c.minEngineVersions[engine] = version
+}
+
+// SetResourceFilter limits the checks to filter.OpenSearchDomains. Only those
+// domains are described and evaluated; an empty list checks every domain.
+func (c *OpenSearchChecks) SetResourceFilter(filter ResourceFilter) {
+	c.domainNames = filter.OpenSearchDomains
 }
 
 func (c *OpenSearchChecks) Name() string {
@@ -24,48 +61,259 @@ func (c *OpenSearchChecks) Name() string {
 func (c *OpenSearchChecks) Run(ctx context.Context) ([]CheckResult, error) {
 	results := []CheckResult{}
 
-	if result, err := c.CheckEncryptionAtRest(ctx); err == nil {
+	// Every check needs a DescribeDomain call per domain, which the quick
+	// profile leaves out
+	if scanFrom(ctx).Profile() == ProfileQuick {
+		return results, nil
+	}
+
+	// Every check reads the same DescribeDomain output, so fetch it once
+	domains, err := c.DescribeDomains(ctx)
+	if isServiceUnavailableInRegion(err) {
+		return []CheckResult{serviceUnavailableResult(ctx, "OpenSearch", "CC6.3")}, nil
+	}
+	if err != nil {
+		// A denied or throttled listing, or a named domain that does not
+		// exist, is an error, not an empty account
+		return nil, err
+	}
+
+	// Domains being created or deleted are reported once as INFO instead of
+	// failing checks on settings that are not in effect yet
+	var transient transientResources
+	for _, domainName := range domains.Names() {
+		if status := openSearchLifecycleStatus(domains[domainName]); status != "" {
+			transient.add(domainName, status)
+			delete(domains, domainName)
+		}
+	}
+
+	// Domains that could not be described are reported once as unverified;
+	// the checks below skip them and count only the described ones
+	if result, ok := openSearchUndescribedResult(ctx, domains); ok {
+		results = append(results, result)
+		if domains.Described() == 0 {
+			if result, ok := transient.result(ctx, "OpenSearch", "OpenSearch domains", "CC7.1"); ok {
+				results = append(results, result)
+			}
+			return results, nil
+		}
+	}
+
+	// The checks share the described domains; withDomains adapts each one to
+	// the signature runCheck takes
+	withDomains := func(check func(context.Context, OpenSearchDomains) (CheckResult, error)) func(context.Context) (CheckResult, error) {
+		return func(ctx context.Context) (CheckResult, error) {
+			return check(ctx, domains)
+		}
+	}
+
+	if result, err := runCheck(ctx, "opensearch.encryption_at_rest", withDomains(c.CheckEncryptionAtRest)); err == nil {
+		results = append(results, result)
+	}
+
+	if result, err := runCheck(ctx, "opensearch.node_to_node_encryption", withDomains(c.CheckNodeToNodeEncryption)); err == nil {
+		results = append(results, result)
+	}
+
+	if result, err := runCheck(ctx, "opensearch.https_required", withDomains(c.CheckHTTPS)); err == nil {
+		results = append(results, result)
+	}
+
+	if result, err := runCheck(ctx, "opensearch.vpc_deployment", withDomains(c.CheckVPCDeployment)); err == nil {
+		results = append(results, result)
+	}
+
+	if result, err := runCheck(ctx, "opensearch.audit_logs", withDomains(c.CheckAuditLogs)); err == nil {
+		results = append(results, result)
+	}
+
+	if result, err := runCheck(ctx, "opensearch.fine_grained_access_control", withDomains(c.CheckFineGrainedAccessControl)); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckNodeToNodeEncryption(ctx); err == nil {
+	if result, err := runCheck(ctx, "opensearch.access_policy_public", withDomains(c.CheckAccessPolicyPublic)); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckHTTPS(ctx); err == nil {
+	if result, err := runCheck(ctx, "opensearch.engine_version", withDomains(c.CheckEngineVersion)); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckVPCDeployment(ctx); err == nil {
+	if result, err := runCheck(ctx, "opensearch.automated_snapshots", withDomains(c.CheckAutomatedSnapshots)); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckAuditLogs(ctx); err == nil {
+	if result, err := runCheck(ctx, "opensearch.custom_endpoint_certificate", withDomains(c.CheckCustomEndpointCertificate)); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckFineGrainedAccessControl(ctx); err == nil {
+	if result, ok := transient.result(ctx, "OpenSearch", "OpenSearch domains", "CC7.1"); ok {
 		results = append(results, result)
 	}
 
 	return results, nil
 }
 
-func (c *OpenSearchChecks) CheckEncryptionAtRest(ctx context.Context) (CheckResult, error) {
+// EstimateCalls predicts one ListDomainNames plus one DescribeDomain per
+// domain. ACM DescribeCertificate calls for custom endpoints are not counted,
+// since custom endpoints are only known after DescribeDomain.
+func (c *OpenSearchChecks) EstimateCalls(ctx context.Context) (CallEstimate, error) {
+	if len(c.domainNames) > 0 {
+		return CallEstimate{
+			Resources: len(c.domainNames),
+			Calls:     len(c.domainNames),
+		}, nil
+	}
+
 	domains, err := c.client.ListDomainNames(ctx, &opensearch.ListDomainNamesInput{})
 	if err != nil {
-		return CheckResult{}, err
+		return CallEstimate{}, err
 	}
 
-	unencrypted := []string{}
+	return CallEstimate{
+		Resources: len(domains.DomainNames),
+		Calls:     1 + len(domains.DomainNames),
+	}, nil
+}
 
-	for _, domain := range domains.DomainNames {
-		domainName := aws.ToString(domain.DomainName)
+// openSearchDescribeConcurrency bounds in-flight DescribeDomain calls
+const openSearchDescribeConcurrency = 5
 
-		detail, err := c.client.DescribeDomain(ctx, &opensearch.DescribeDomainInput{
-			DomainName: domain.DomainName,
-		})
+// OpenSearchDomains maps domain name to its DescribeDomain output. A nil value
+// means the domain was listed but could not be described.
+type OpenSearchDomains map[string]*opensearch.DescribeDomainOutput
+
+// openSearchLifecycleStatus returns "creating" or "deleting" for a domain in
+// one of those states, or "" once it is created and not being deleted
+func openSearchLifecycleStatus(detail *opensearch.DescribeDomainOutput) string {
+	if detail == nil || detail.DomainStatus == nil {
+		return ""
+	}
+	switch {
+	case aws.ToBool(detail.DomainStatus.Deleted):
+		return "deleting"
+	case !aws.ToBool(detail.DomainStatus.Created):
+		return "creating"
+	}
+	return ""
+}
+
+// Names returns the domain names, sorted
+func (d OpenSearchDomains) Names() []string {
+	names := make([]string, 0, len(d))
+	for name := range d {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Described returns how many domains were described. PASS evidence counts
+// these, not every listed domain: a domain that could not be read is not
+// known to be compliant.
+func (d OpenSearchDomains) Described() int {
+	described := 0
+	for _, detail := range d {
+		if detail != nil {
+			described++
+		}
+	}
+	return described
+}
+
+// Undescribed returns the listed domains whose DescribeDomain call failed,
+// sorted
+func (d OpenSearchDomains) Undescribed() []string {
+	names := []string{}
+	for _, name := range d.Names() {
+		if d[name] == nil {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// openSearchUndescribedResult reports the domains the checks skipped because
+// DescribeDomain failed, so they show up as unverified instead of silently
+// dropping out of every check
+func openSearchUndescribedResult(ctx context.Context, domains OpenSearchDomains) (CheckResult, bool) {
+	undescribed := domains.Undescribed()
+	if len(undescribed) == 0 {
+		return CheckResult{}, false
+	}
+
+	return CheckResult{
+		Control:           "CC6.1",
+		Name:              "OpenSearch Domains Not Verified",
+		Status:            "ERROR",
+		Evidence:          fmt.Sprintf("%d/%d OpenSearch domains could not be described and were not checked: %s. Grant es:DescribeDomain and re-run the scan.", len(undescribed), len(domains), TruncateList(undescribed, evidenceListLimit(ctx))),
+		AffectedResources: undescribed,
+		Priority:          PriorityInfo,
+		Timestamp:         nowFunc(),
+	}, true
+}
+
+// DescribeDomains lists the account's domains, or takes the filtered domain
+// names as given, and describes each one exactly once, with at most
+// openSearchDescribeConcurrency calls in flight. A filtered domain that cannot
+// be described is an error.
+func (c *OpenSearchChecks) DescribeDomains(ctx context.Context) (OpenSearchDomains, error) {
+	names := c.domainNames
+	if len(names) == 0 {
+		list, err := c.client.ListDomainNames(ctx, &opensearch.ListDomainNamesInput{})
 		if err != nil {
+			return nil, err
+		}
+		for _, domain := range list.DomainNames {
+			names = append(names, aws.ToString(domain.DomainName))
+		}
+	}
+
+	domains := OpenSearchDomains{}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var firstErr error
+	sem := make(chan struct{}, openSearchDescribeConcurrency)
+
+	for _, domainName := range names {
+		domains[domainName] = nil
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			detail, err := c.client.DescribeDomain(ctx, &opensearch.DescribeDomainInput{
+				DomainName: aws.String(domainName),
+			})
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("opensearch domain %s: %w", domainName, err)
+				}
+				return
+			}
+			domains[domainName] = detail
+		}()
+	}
+	wg.Wait()
+
+	if len(c.domainNames) > 0 && firstErr != nil {
+		return nil, firstErr
+	}
+	return domains, nil
+}
+
+func (c *OpenSearchChecks) CheckEncryptionAtRest(ctx context.Context, domains OpenSearchDomains) (CheckResult, error) {
+	unencrypted := []string{}
+
+	for _, domainName := range domains.Names() {
+		detail := domains[domainName]
+		if detail == nil {
 			continue
 		}
 
@@ -81,55 +329,50 @@ func (c *OpenSearchChecks) CheckEncryptionAtRest(ctx context.Context) (CheckResu
 			Name:              "OpenSearch Encryption at Rest",
 			Status:            "FAIL",
 			Severity:          "CRITICAL",
-			Evidence:          fmt.Sprintf("%d OpenSearch domains without encryption at rest: %v", len(unencrypted), unencrypted),
+			Evidence:          fmt.Sprintf("%d OpenSearch domains without encryption at rest: %s", len(unencrypted), TruncateList(unencrypted, evidenceListLimit(ctx))),
+			AffectedResources: unencrypted,
 			Remediation:       "Enable encryption at rest for OpenSearch domains",
 			RemediationDetail: "Update domain configuration to enable encryption at rest with KMS key",
 			ScreenshotGuide:   "OpenSearch Console → Domains → Select domain → Security → Screenshot showing 'Encryption at rest: Enabled'",
-			ConsoleURL:        "https://console.aws.amazon.com/aos/home#opensearch/domains",
+			ConsoleURL:        consoleURL("aos/home#opensearch/domains", consoleRegion(ctx)),
 			Priority:          PriorityCritical,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("OPENSEARCH_ENCRYPTION"),
 		}, nil
 	}
 
-	if len(domains.DomainNames) == 0 {
+	if len(domains) == 0 {
 		return CheckResult{
 			Control:    "CC6.3",
 			Name:       "OpenSearch Encryption at Rest",
 			Status:     "PASS",
 			Evidence:   "No OpenSearch domains found",
+			ConsoleURL: consoleURL("aos/home#opensearch/domains", consoleRegion(ctx)),
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("OPENSEARCH_ENCRYPTION"),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "CC6.3",
-		Name:       "OpenSearch Encryption at Rest",
-		Status:     "PASS",
-		Evidence:   fmt.Sprintf("All %d OpenSearch domains have encryption at rest enabled", len(domains.DomainNames)),
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("OPENSEARCH_ENCRYPTION"),
+		Control:         "CC6.3",
+		Name:            "OpenSearch Encryption at Rest",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d OpenSearch domains have encryption at rest enabled", domains.Described()),
+		ScreenshotGuide: "OpenSearch Console → Domains → Select domain → Security → Screenshot showing 'Encryption at rest: Enabled'",
+		ConsoleURL:      consoleURL("aos/home#opensearch/domains", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("OPENSEARCH_ENCRYPTION"),
 	}, nil
 }
 
-func (c *OpenSearchChecks) CheckNodeToNodeEncryption(ctx context.Context) (CheckResult, error) {
-	domains, err := c.client.ListDomainNames(ctx, &opensearch.ListDomainNamesInput{})
-	if err != nil {
-		return CheckResult{}, err
-	}
-
+func (c *OpenSearchChecks) CheckNodeToNodeEncryption(ctx context.Context, domains OpenSearchDomains) (CheckResult, error) {
 	noNodeEncryption := []string{}
 
-	for _, domain := range domains.DomainNames {
-		domainName := aws.ToString(domain.DomainName)
-
-		detail, err := c.client.DescribeDomain(ctx, &opensearch.DescribeDomainInput{
-			DomainName: domain.DomainName,
-		})
-		if err != nil {
+	for _, domainName := range domains.Names() {
+		detail := domains[domainName]
+		if detail == nil {
 			continue
 		}
 
@@ -145,55 +388,50 @@ func (c *OpenSearchChecks) CheckNodeToNodeEncryption(ctx context.Context) (Check
 			Name:              "OpenSearch Node-to-Node Encryption",
 			Status:            "FAIL",
 			Severity:          "HIGH",
-			Evidence:          fmt.Sprintf("%d OpenSearch domains without node-to-node encryption: %v", len(noNodeEncryption), noNodeEncryption),
+			Evidence:          fmt.Sprintf("%d OpenSearch domains without node-to-node encryption: %s", len(noNodeEncryption), TruncateList(noNodeEncryption, evidenceListLimit(ctx))),
+			AffectedResources: noNodeEncryption,
 			Remediation:       "Enable node-to-node encryption for OpenSearch domains",
 			RemediationDetail: "Update domain configuration to enable node-to-node encryption (may require blue/green deployment)",
 			ScreenshotGuide:   "OpenSearch Console → Domains → Select domain → Security → Screenshot showing 'Node-to-node encryption: Enabled'",
-			ConsoleURL:        "https://console.aws.amazon.com/aos/home#opensearch/domains",
+			ConsoleURL:        consoleURL("aos/home#opensearch/domains", consoleRegion(ctx)),
 			Priority:          PriorityHigh,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("OPENSEARCH_TRANSIT"),
 		}, nil
 	}
 
-	if len(domains.DomainNames) == 0 {
+	if len(domains) == 0 {
 		return CheckResult{
 			Control:    "CC6.4",
 			Name:       "OpenSearch Node-to-Node Encryption",
 			Status:     "PASS",
 			Evidence:   "No OpenSearch domains found",
+			ConsoleURL: consoleURL("aos/home#opensearch/domains", consoleRegion(ctx)),
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("OPENSEARCH_TRANSIT"),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "CC6.4",
-		Name:       "OpenSearch Node-to-Node Encryption",
-		Status:     "PASS",
-		Evidence:   fmt.Sprintf("All %d OpenSearch domains have node-to-node encryption enabled", len(domains.DomainNames)),
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("OPENSEARCH_TRANSIT"),
+		Control:         "CC6.4",
+		Name:            "OpenSearch Node-to-Node Encryption",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d OpenSearch domains have node-to-node encryption enabled", domains.Described()),
+		ScreenshotGuide: "OpenSearch Console → Domains → Select domain → Security → Screenshot showing 'Node-to-node encryption: Enabled'",
+		ConsoleURL:      consoleURL("aos/home#opensearch/domains", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("OPENSEARCH_TRANSIT"),
 	}, nil
 }
 
-func (c *OpenSearchChecks) CheckHTTPS(ctx context.Context) (CheckResult, error) {
-	domains, err := c.client.ListDomainNames(ctx, &opensearch.ListDomainNamesInput{})
-	if err != nil {
-		return CheckResult{}, err
-	}
-
+func (c *OpenSearchChecks) CheckHTTPS(ctx context.Context, domains OpenSearchDomains) (CheckResult, error) {
 	noHTTPS := []string{}
 
-	for _, domain := range domains.DomainNames {
-		domainName := aws.ToString(domain.DomainName)
-
-		detail, err := c.client.DescribeDomain(ctx, &opensearch.DescribeDomainInput{
-			DomainName: domain.DomainName,
-		})
-		if err != nil {
+	for _, domainName := range domains.Names() {
+		detail := domains[domainName]
+		if detail == nil {
 			continue
 		}
 
@@ -209,60 +447,55 @@ func (c *OpenSearchChecks) CheckHTTPS(ctx context.Context) (CheckResult, error)
 			Name:              "OpenSearch HTTPS Required",
 			Status:            "FAIL",
 			Severity:          "HIGH",
-			Evidence:          fmt.Sprintf("%d OpenSearch domains not enforcing HTTPS: %v", len(noHTTPS), noHTTPS),
+			Evidence:          fmt.Sprintf("%d OpenSearch domains not enforcing HTTPS: %s", len(noHTTPS), TruncateList(noHTTPS, evidenceListLimit(ctx))),
+			AffectedResources: noHTTPS,
 			Remediation:       "Enable HTTPS enforcement for OpenSearch domains",
 			RemediationDetail: "Update domain endpoint options to enforce HTTPS and use TLS 1.2 minimum",
 			ScreenshotGuide:   "OpenSearch Console → Domains → Select domain → Security → Screenshot showing 'Require HTTPS: Yes'",
-			ConsoleURL:        "https://console.aws.amazon.com/aos/home#opensearch/domains",
+			ConsoleURL:        consoleURL("aos/home#opensearch/domains", consoleRegion(ctx)),
 			Priority:          PriorityHigh,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("OPENSEARCH_HTTPS"),
 		}, nil
 	}
 
-	if len(domains.DomainNames) == 0 {
+	if len(domains) == 0 {
 		return CheckResult{
 			Control:    "CC6.4",
 			Name:       "OpenSearch HTTPS Required",
 			Status:     "PASS",
 			Evidence:   "No OpenSearch domains found",
+			ConsoleURL: consoleURL("aos/home#opensearch/domains", consoleRegion(ctx)),
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("OPENSEARCH_HTTPS"),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "CC6.4",
-		Name:       "OpenSearch HTTPS Required",
-		Status:     "PASS",
-		Evidence:   fmt.Sprintf("All %d OpenSearch domains enforce HTTPS", len(domains.DomainNames)),
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("OPENSEARCH_HTTPS"),
+		Control:         "CC6.4",
+		Name:            "OpenSearch HTTPS Required",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d OpenSearch domains enforce HTTPS", domains.Described()),
+		ScreenshotGuide: "OpenSearch Console → Domains → Select domain → Security → Screenshot showing 'Require HTTPS: Yes'",
+		ConsoleURL:      consoleURL("aos/home#opensearch/domains", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("OPENSEARCH_HTTPS"),
 	}, nil
 }
 
-func (c *OpenSearchChecks) CheckVPCDeployment(ctx context.Context) (CheckResult, error) {
-	domains, err := c.client.ListDomainNames(ctx, &opensearch.ListDomainNamesInput{})
-	if err != nil {
-		return CheckResult{}, err
-	}
-
+func (c *OpenSearchChecks) CheckVPCDeployment(ctx context.Context, domains OpenSearchDomains) (CheckResult, error) {
 	publicDomains := []string{}
 
-	for _, domain := range domains.DomainNames {
-		domainName := aws.ToString(domain.DomainName)
-
-		detail, err := c.client.DescribeDomain(ctx, &opensearch.DescribeDomainInput{
-			DomainName: domain.DomainName,
-		})
-		if err != nil {
+	for _, domainName := range domains.Names() {
+		detail := domains[domainName]
+		if detail == nil {
 			continue
 		}
 
 		// If no VPC options, domain is public
-		if detail.DomainStatus.VPCOptions == nil || len(detail.DomainStatus.VPCOptions.SubnetIds) == 0 {
+		if !openSearchInVPC(detail.DomainStatus) {
 			publicDomains = append(publicDomains, domainName)
 		}
 	}
@@ -273,55 +506,51 @@ func (c *OpenSearchChecks) CheckVPCDeployment(ctx context.Context) (CheckResult,
 			Name:              "OpenSearch VPC Deployment",
 			Status:            "FAIL",
 			Severity:          "CRITICAL",
-			Evidence:          fmt.Sprintf("%d OpenSearch domains are publicly accessible (not in VPC): %v", len(publicDomains), publicDomains),
+			Evidence:          fmt.Sprintf("%d OpenSearch domains are publicly accessible (not in VPC): %s", len(publicDomains), TruncateList(publicDomains, evidenceListLimit(ctx))),
+			AffectedResources: publicDomains,
 			Remediation:       "Deploy OpenSearch domains within a VPC",
 			RemediationDetail: "Create new domain in VPC. Note: Moving from public to VPC requires recreating the domain.",
+			RequiresRecreate:  true,
 			ScreenshotGuide:   "OpenSearch Console → Domains → Select domain → Network → Screenshot showing VPC configuration",
-			ConsoleURL:        "https://console.aws.amazon.com/aos/home#opensearch/domains",
+			ConsoleURL:        consoleURL("aos/home#opensearch/domains", consoleRegion(ctx)),
 			Priority:          PriorityCritical,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("OPENSEARCH_NETWORK"),
 		}, nil
 	}
 
-	if len(domains.DomainNames) == 0 {
+	if len(domains) == 0 {
 		return CheckResult{
 			Control:    "CC6.1",
 			Name:       "OpenSearch VPC Deployment",
 			Status:     "PASS",
 			Evidence:   "No OpenSearch domains found",
+			ConsoleURL: consoleURL("aos/home#opensearch/domains", consoleRegion(ctx)),
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("OPENSEARCH_NETWORK"),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "CC6.1",
-		Name:       "OpenSearch VPC Deployment",
-		Status:     "PASS",
-		Evidence:   fmt.Sprintf("All %d OpenSearch domains are deployed in VPC", len(domains.DomainNames)),
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("OPENSEARCH_NETWORK"),
+		Control:         "CC6.1",
+		Name:            "OpenSearch VPC Deployment",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d OpenSearch domains are deployed in VPC", domains.Described()),
+		ScreenshotGuide: "OpenSearch Console → Domains → Select domain → Network → Screenshot showing VPC configuration",
+		ConsoleURL:      consoleURL("aos/home#opensearch/domains", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("OPENSEARCH_NETWORK"),
 	}, nil
 }
 
-func (c *OpenSearchChecks) CheckAuditLogs(ctx context.Context) (CheckResult, error) {
-	domains, err := c.client.ListDomainNames(ctx, &opensearch.ListDomainNamesInput{})
-	if err != nil {
-		return CheckResult{}, err
-	}
-
+func (c *OpenSearchChecks) CheckAuditLogs(ctx context.Context, domains OpenSearchDomains) (CheckResult, error) {
 	noAuditLogs := []string{}
 
-	for _, domain := range domains.DomainNames {
-		domainName := aws.ToString(domain.DomainName)
-
-		detail, err := c.client.DescribeDomain(ctx, &opensearch.DescribeDomainInput{
-			DomainName: domain.DomainName,
-		})
-		if err != nil {
+	for _, domainName := range domains.Names() {
+		detail := domains[domainName]
+		if detail == nil {
 			continue
 		}
 
@@ -350,55 +579,50 @@ func (c *OpenSearchChecks) CheckAuditLogs(ctx context.Context) (CheckResult, err
 			Name:              "OpenSearch Audit Logs",
 			Status:            "FAIL",
 			Severity:          "HIGH",
-			Evidence:          fmt.Sprintf("%d OpenSearch domains without audit logging: %v", len(noAuditLogs), noAuditLogs),
+			Evidence:          fmt.Sprintf("%d OpenSearch domains without audit logging: %s", len(noAuditLogs), TruncateList(noAuditLogs, evidenceListLimit(ctx))),
+			AffectedResources: noAuditLogs,
 			Remediation:       "Enable audit logging for OpenSearch domains",
 			RemediationDetail: "Configure log publishing options to enable AUDIT_LOGS to CloudWatch Logs",
 			ScreenshotGuide:   "OpenSearch Console → Domains → Select domain → Logs → Screenshot showing 'Audit logs: Enabled'",
-			ConsoleURL:        "https://console.aws.amazon.com/aos/home#opensearch/domains",
+			ConsoleURL:        consoleURL("aos/home#opensearch/domains", consoleRegion(ctx)),
 			Priority:          PriorityHigh,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("OPENSEARCH_LOGGING"),
 		}, nil
 	}
 
-	if len(domains.DomainNames) == 0 {
+	if len(domains) == 0 {
 		return CheckResult{
 			Control:    "CC7.1",
 			Name:       "OpenSearch Audit Logs",
 			Status:     "PASS",
 			Evidence:   "No OpenSearch domains found",
+			ConsoleURL: consoleURL("aos/home#opensearch/domains", consoleRegion(ctx)),
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("OPENSEARCH_LOGGING"),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "CC7.1",
-		Name:       "OpenSearch Audit Logs",
-		Status:     "PASS",
-		Evidence:   fmt.Sprintf("All %d OpenSearch domains have audit logging enabled", len(domains.DomainNames)),
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("OPENSEARCH_LOGGING"),
+		Control:         "CC7.1",
+		Name:            "OpenSearch Audit Logs",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d OpenSearch domains have audit logging enabled", domains.Described()),
+		ScreenshotGuide: "OpenSearch Console → Domains → Select domain → Logs → Screenshot showing 'Audit logs: Enabled'",
+		ConsoleURL:      consoleURL("aos/home#opensearch/domains", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("OPENSEARCH_LOGGING"),
 	}, nil
 }
 
-func (c *OpenSearchChecks) CheckFineGrainedAccessControl(ctx context.Context) (CheckResult, error) {
-	domains, err := c.client.ListDomainNames(ctx, &opensearch.ListDomainNamesInput{})
-	if err != nil {
-		return CheckResult{}, err
-	}
-
+func (c *OpenSearchChecks) CheckFineGrainedAccessControl(ctx context.Context, domains OpenSearchDomains) (CheckResult, error) {
 	noFGAC := []string{}
 
-	for _, domain := range domains.DomainNames {
-		domainName := aws.ToString(domain.DomainName)
-
-		detail, err := c.client.DescribeDomain(ctx, &opensearch.DescribeDomainInput{
-			DomainName: domain.DomainName,
-		})
-		if err != nil {
+	for _, domainName := range domains.Names() {
+		detail := domains[domainName]
+		if detail == nil {
 			continue
 		}
 
@@ -414,36 +638,577 @@ func (c *OpenSearchChecks) CheckFineGrainedAccessControl(ctx context.Context) (C
 			Name:              "OpenSearch Fine-Grained Access Control",
 			Status:            "FAIL",
 			Severity:          "HIGH",
-			Evidence:          fmt.Sprintf("%d OpenSearch domains without fine-grained access control: %v", len(noFGAC), noFGAC),
+			Evidence:          fmt.Sprintf("%d OpenSearch domains without fine-grained access control: %s", len(noFGAC), TruncateList(noFGAC, evidenceListLimit(ctx))),
+			AffectedResources: noFGAC,
 			Remediation:       "Enable fine-grained access control for OpenSearch domains",
 			RemediationDetail: "Enable Advanced Security Options with fine-grained access control. Requires encryption at rest and node-to-node encryption.",
 			ScreenshotGuide:   "OpenSearch Console → Domains → Select domain → Security → Screenshot showing 'Fine-grained access control: Enabled'",
-			ConsoleURL:        "https://console.aws.amazon.com/aos/home#opensearch/domains",
+			ConsoleURL:        consoleURL("aos/home#opensearch/domains", consoleRegion(ctx)),
 			Priority:          PriorityHigh,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("OPENSEARCH_ACCESS"),
 		}, nil
 	}
 
-	if len(domains.DomainNames) == 0 {
+	if len(domains) == 0 {
 		return CheckResult{
 			Control:    "CC6.6",
 			Name:       "OpenSearch Fine-Grained Access Control",
 			Status:     "PASS",
 			Evidence:   "No OpenSearch domains found",
+			ConsoleURL: consoleURL("aos/home#opensearch/domains", consoleRegion(ctx)),
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("OPENSEARCH_ACCESS"),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "CC6.6",
-		Name:       "OpenSearch Fine-Grained Access Control",
-		Status:     "PASS",
-		Evidence:   fmt.Sprintf("All %d OpenSearch domains have fine-grained access control enabled", len(domains.DomainNames)),
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("OPENSEARCH_ACCESS"),
+		Control:         "CC6.6",
+		Name:            "OpenSearch Fine-Grained Access Control",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d OpenSearch domains have fine-grained access control enabled", domains.Described()),
+		ScreenshotGuide: "OpenSearch Console → Domains → Select domain → Security → Screenshot showing 'Fine-grained access control: Enabled'",
+		ConsoleURL:      consoleURL("aos/home#opensearch/domains", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("OPENSEARCH_ACCESS"),
 	}, nil
 }
+
+// CheckAccessPolicyPublic flags internet-facing domains whose resource-based
+// access policy allows any principal without an IP or VPC condition. FGAC does
+// not make such a policy safe: the domain endpoint still accepts requests from
+// anyone. VPC domains are left to security groups and CheckVPCDeployment. A
+// policy that cannot be parsed is reported, never assumed closed.
+func (c *OpenSearchChecks) CheckAccessPolicyPublic(ctx context.Context, domains OpenSearchDomains) (CheckResult, error) {
+	openPolicies := []string{}
+	unparsed := []string{}
+
+	for _, domainName := range domains.Names() {
+		detail := domains[domainName]
+		if detail == nil {
+			continue
+		}
+
+		public, err := openSearchPolicyIsPublic(detail.DomainStatus)
+		if err != nil {
+			unparsed = append(unparsed, domainName)
+		} else if public {
+			openPolicies = append(openPolicies, domainName)
+		}
+	}
+
+	unparsedNote := ""
+	if len(unparsed) > 0 {
+		unparsedNote = fmt.Sprintf(" | %d domains have access policies that could not be parsed and were not verified: %s", len(unparsed), TruncateList(unparsed, evidenceListLimit(ctx)))
+	}
+
+	if len(openPolicies) > 0 {
+		return CheckResult{
+			Control:           "CC6.1",
+			Name:              "OpenSearch Access Policy Not Public",
+			Status:            "FAIL",
+			Severity:          "CRITICAL",
+			Evidence:          fmt.Sprintf("%d internet-facing OpenSearch domains have an access policy allowing Principal \"*\" without an IP or VPC condition: %s", len(openPolicies), TruncateList(openPolicies, evidenceListLimit(ctx))) + unparsedNote,
+			AffectedResources: openPolicies,
+			Remediation:       "Restrict OpenSearch domain access policies to specific principals or source IPs",
+			RemediationDetail: "Replace Principal \"*\" with specific IAM role/user ARNs, or add an aws:SourceIp / aws:SourceVpc condition. Fine-grained access control alone does not close an open resource policy.",
+			ScreenshotGuide:   "OpenSearch Console → Domains → Select domain → Security configuration → Access policy → Screenshot showing no statement with 'Principal: *' lacking a condition",
+			ConsoleURL:        consoleURL("aos/home#opensearch/domains", consoleRegion(ctx)),
+			Priority:          PriorityCritical,
+			Timestamp:         nowFunc(),
+			Frameworks:        GetFrameworkMappings("OPENSEARCH_POLICY"),
+		}, nil
+	}
+
+	if len(unparsed) > 0 {
+		return CheckResult{
+			Control:           "CC6.1",
+			Name:              "OpenSearch Access Policy Not Public",
+			Status:            "ERROR",
+			Evidence:          fmt.Sprintf("%d OpenSearch domains have access policies that could not be parsed, so they were not verified: %s", len(unparsed), TruncateList(unparsed, evidenceListLimit(ctx))),
+			AffectedResources: unparsed,
+			ScreenshotGuide:   "OpenSearch Console → Domains → Select domain → Security configuration → Access policy → Screenshot showing no statement with 'Principal: *' lacking a condition",
+			ConsoleURL:        consoleURL("aos/home#opensearch/domains", consoleRegion(ctx)),
+			Priority:          PriorityInfo,
+			Timestamp:         nowFunc(),
+			Frameworks:        GetFrameworkMappings("OPENSEARCH_POLICY"),
+		}, nil
+	}
+
+	if len(domains) == 0 {
+		return CheckResult{
+			Control:    "CC6.1",
+			Name:       "OpenSearch Access Policy Not Public",
+			Status:     "PASS",
+			Evidence:   "No OpenSearch domains found",
+			ConsoleURL: consoleURL("aos/home#opensearch/domains", consoleRegion(ctx)),
+			Priority:   PriorityInfo,
+			Timestamp:  nowFunc(),
+			Frameworks: GetFrameworkMappings("OPENSEARCH_POLICY"),
+		}, nil
+	}
+
+	return CheckResult{
+		Control:         "CC6.1",
+		Name:            "OpenSearch Access Policy Not Public",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d OpenSearch domains are deployed in a VPC or have access policies restricted by principal or condition", domains.Described()),
+		ScreenshotGuide: "OpenSearch Console → Domains → Select domain → Security configuration → Access policy → Screenshot showing no statement with 'Principal: *' lacking a condition",
+		ConsoleURL:      consoleURL("aos/home#opensearch/domains", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("OPENSEARCH_POLICY"),
+	}, nil
+}
+
+// openSearchPolicyStatement is the subset of an IAM policy statement needed to
+// decide whether it opens a domain to everyone. Statement, Principal and the
+// condition values may each be a single value or a list, so they stay raw.
+type openSearchPolicyStatement struct {
+	Effect    string                                `json:"Effect"`
+	Principal json.RawMessage                       `json:"Principal"`
+	Condition map[string]map[string]json.RawMessage `json:"Condition"`
+}
+
+// CheckEngineVersion flags domains running an Elasticsearch or OpenSearch
+// version older than the configured minimum for that engine. Versions of an
+// engine with no minimum configured are not flagged.
+func (c *OpenSearchChecks) CheckEngineVersion(ctx context.Context, domains OpenSearchDomains) (CheckResult, error) {
+	outdated := []string{}
+	outdatedListed := []string{}
+
+	for _, domainName := range domains.Names() {
+		detail := domains[domainName]
+		if detail == nil {
+			continue
+		}
+
+		// EngineVersion reads "OpenSearch_2.11" or "Elasticsearch_7.10"
+		engineVersion := aws.ToString(detail.DomainStatus.EngineVersion)
+		engine, version, ok := strings.Cut(engineVersion, "_")
+		if !ok {
+			continue
+		}
+		minimum, ok := c.minEngineVersions[engine]
+		if !ok {
+			continue
+		}
+
+		if compareEngineVersions(version, minimum) < 0 {
+			outdated = append(outdated, domainName)
+			outdatedListed = append(outdatedListed, fmt.Sprintf("%s (%s %s, minimum %s)", domainName, engine, version, minimum))
+		}
+	}
+
+	if len(outdated) > 0 {
+		return CheckResult{
+			Control:           "CC7.5",
+			Name:              "OpenSearch Engine Version",
+			Status:            "FAIL",
+			Severity:          "MEDIUM",
+			Evidence:          fmt.Sprintf("%d OpenSearch domains run an outdated engine version: %s", len(outdated), TruncateList(outdatedListed, evidenceListLimit(ctx))),
+			AffectedResources: outdated,
+			Remediation:       "Upgrade OpenSearch domains to a supported engine version",
+			RemediationDetail: "aws opensearch get-compatible-versions --domain-name [DOMAIN_NAME]\naws opensearch upgrade-domain --domain-name [DOMAIN_NAME] --target-version OpenSearch_2.11\nNote: Take a manual snapshot first; Elasticsearch domains may need several upgrade steps",
+			ScreenshotGuide:   "OpenSearch Console → Domains → Select domain → General information → Screenshot showing the engine version",
+			ConsoleURL:        consoleURL("aos/home#opensearch/domains", consoleRegion(ctx)),
+			Priority:          PriorityMedium,
+			Timestamp:         nowFunc(),
+			Frameworks:        GetFrameworkMappings("OPENSEARCH_PATCHING"),
+		}, nil
+	}
+
+	if len(domains) == 0 {
+		return CheckResult{
+			Control:    "CC7.5",
+			Name:       "OpenSearch Engine Version",
+			Status:     "PASS",
+			Evidence:   "No OpenSearch domains found",
+			ConsoleURL: consoleURL("aos/home#opensearch/domains", consoleRegion(ctx)),
+			Priority:   PriorityInfo,
+			Timestamp:  nowFunc(),
+			Frameworks: GetFrameworkMappings("OPENSEARCH_PATCHING"),
+		}, nil
+	}
+
+	return CheckResult{
+		Control:         "CC7.5",
+		Name:            "OpenSearch Engine Version",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d OpenSearch domains run a supported engine version", domains.Described()),
+		ScreenshotGuide: "OpenSearch Console → Domains → Select domain → General information → Screenshot showing the engine version",
+		ConsoleURL:      consoleURL("aos/home#opensearch/domains", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("OPENSEARCH_PATCHING"),
+	}, nil
+}
+
+// openSearchHourlySnapshotsSince is the first Elasticsearch version AWS
+// snapshots hourly on its own. OpenSearch domains always are; older
+// Elasticsearch domains only take the daily snapshot at
+// SnapshotOptions.AutomatedSnapshotStartHour.
+const openSearchHourlySnapshotsSince = "5.3"
+
+// CheckAutomatedSnapshots flags domains with no automated snapshot
+// configuration, which leaves them relying on manual snapshots alone
+func (c *OpenSearchChecks) CheckAutomatedSnapshots(ctx context.Context, domains OpenSearchDomains) (CheckResult, error) {
+	manualOnly := []string{}
+	manualOnlyListed := []string{}
+
+	for _, domainName := range domains.Names() {
+		detail := domains[domainName]
+		if detail == nil {
+			continue
+		}
+
+		engine, version, _ := strings.Cut(aws.ToString(detail.DomainStatus.EngineVersion), "_")
+		if engine != "Elasticsearch" || compareEngineVersions(version, openSearchHourlySnapshotsSince) >= 0 {
+			continue
+		}
+
+		options := detail.DomainStatus.SnapshotOptions
+		if options == nil || options.AutomatedSnapshotStartHour == nil {
+			manualOnly = append(manualOnly, domainName)
+			manualOnlyListed = append(manualOnlyListed, fmt.Sprintf("%s (Elasticsearch %s, no automated snapshot hour)", domainName, version))
+		}
+	}
+
+	if len(manualOnly) > 0 {
+		return CheckResult{
+			Control:           "A1.2",
+			Name:              "OpenSearch Automated Snapshots",
+			Status:            "FAIL",
+			Severity:          "LOW",
+			Evidence:          fmt.Sprintf("%d OpenSearch domains have no automated snapshot configuration and rely on manual snapshots only: %s", len(manualOnly), TruncateList(manualOnlyListed, evidenceListLimit(ctx))),
+			AffectedResources: manualOnly,
+			Remediation:       "Configure an automated snapshot hour, or upgrade the domain to get hourly automated snapshots",
+			RemediationDetail: "aws opensearch update-domain-config --domain-name [DOMAIN_NAME] --snapshot-options AutomatedSnapshotStartHour=3\nOr upgrade to Elasticsearch 5.3+ or OpenSearch, which take automated snapshots every hour",
+			ScreenshotGuide:   "OpenSearch Console → Domains → Select domain → Cluster configuration → Screenshot showing the automated snapshot start hour",
+			ConsoleURL:        consoleURL("aos/home#opensearch/domains", consoleRegion(ctx)),
+			Priority:          PriorityLow,
+			Timestamp:         nowFunc(),
+			Frameworks:        GetFrameworkMappings("OPENSEARCH_BACKUP"),
+		}, nil
+	}
+
+	if len(domains) == 0 {
+		return CheckResult{
+			Control:    "A1.2",
+			Name:       "OpenSearch Automated Snapshots",
+			Status:     "PASS",
+			Evidence:   "No OpenSearch domains found",
+			ConsoleURL: consoleURL("aos/home#opensearch/domains", consoleRegion(ctx)),
+			Priority:   PriorityInfo,
+			Timestamp:  nowFunc(),
+			Frameworks: GetFrameworkMappings("OPENSEARCH_BACKUP"),
+		}, nil
+	}
+
+	return CheckResult{
+		Control:         "A1.2",
+		Name:            "OpenSearch Automated Snapshots",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d OpenSearch domains take automated snapshots (hourly on OpenSearch and Elasticsearch 5.3+)", domains.Described()),
+		ScreenshotGuide: "OpenSearch Console → Domains → Select domain → Cluster configuration → Screenshot showing the automated snapshot start hour",
+		ConsoleURL:      consoleURL("aos/home#opensearch/domains", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("OPENSEARCH_BACKUP"),
+	}, nil
+}
+
+// CheckCustomEndpointCertificate verifies the custom endpoints of domains that
+// enable one: HTTPS must be enforced and the endpoint must present an issued,
+// unexpired ACM certificate that covers its hostname. Otherwise clients are
+// either sent over plain HTTP or get certificate errors they learn to click
+// through. Domains on their AWS-provided endpoint are covered by CheckHTTPS;
+// with no custom endpoints at all the check is not applicable.
+func (c *OpenSearchChecks) CheckCustomEndpointCertificate(ctx context.Context, domains OpenSearchDomains) (CheckResult, error) {
+	misconfigured := []string{}
+	misconfiguredListed := []string{}
+	unverified := []string{}
+	custom := 0
+
+	for _, domainName := range domains.Names() {
+		detail := domains[domainName]
+		if detail == nil {
+			continue
+		}
+		options := detail.DomainStatus.DomainEndpointOptions
+		if options == nil || !aws.ToBool(options.CustomEndpointEnabled) {
+			continue
+		}
+		custom++
+		endpoint := aws.ToString(options.CustomEndpoint)
+
+		issues := []string{}
+		if !aws.ToBool(options.EnforceHTTPS) {
+			issues = append(issues, "HTTPS not enforced")
+		}
+
+		certARN := aws.ToString(options.CustomEndpointCertificateArn)
+		switch {
+		case certARN == "":
+			issues = append(issues, "no certificate")
+		case !strings.Contains(certARN, ":acm:"):
+			issues = append(issues, "certificate not from ACM")
+		default:
+			cert, err := c.describeCertificate(ctx, certARN)
+			if err != nil {
+				// Missing ACM permissions say nothing about the domain
+				unverified = append(unverified, fmt.Sprintf("%s (%s)", domainName, endpoint))
+				break
+			}
+			issues = append(issues, customEndpointCertificateIssues(cert, endpoint)...)
+		}
+
+		if len(issues) > 0 {
+			misconfigured = append(misconfigured, domainName)
+			misconfiguredListed = append(misconfiguredListed, fmt.Sprintf("%s (%s: %s)", domainName, endpoint, strings.Join(issues, ", ")))
+		}
+	}
+
+	unverifiedNote := ""
+	if len(unverified) > 0 {
+		unverifiedNote = fmt.Sprintf(" | %d certificates could not be read from ACM and were not verified: %s", len(unverified), TruncateList(unverified, evidenceListLimit(ctx)))
+	}
+
+	if len(misconfigured) > 0 {
+		return CheckResult{
+			Control:           "CC6.4",
+			Name:              "OpenSearch Custom Endpoint Certificate",
+			Status:            "FAIL",
+			Severity:          "HIGH",
+			Evidence:          fmt.Sprintf("%d OpenSearch custom endpoints lack enforced HTTPS or a valid ACM certificate for their hostname: %s%s", len(misconfigured), TruncateList(misconfiguredListed, evidenceListLimit(ctx)), unverifiedNote),
+			AffectedResources: misconfigured,
+			Remediation:       "Attach an issued ACM certificate covering the custom endpoint hostname and enforce HTTPS",
+			RemediationDetail: "aws acm request-certificate --domain-name [CUSTOM_ENDPOINT] --validation-method DNS\naws opensearch update-domain-config --domain-name [DOMAIN_NAME] --domain-endpoint-options EnforceHTTPS=true,TLSSecurityPolicy=Policy-Min-TLS-1-2-2019-07,CustomEndpointEnabled=true,CustomEndpoint=[CUSTOM_ENDPOINT],CustomEndpointCertificateArn=[CERT_ARN]",
+			ScreenshotGuide:   "OpenSearch Console → Domains → Select domain → Custom endpoint → Screenshot showing the hostname, its ACM certificate and 'Require HTTPS: Yes'; ACM Console → Screenshot showing the certificate status Issued",
+			ConsoleURL:        consoleURL("aos/home#opensearch/domains", consoleRegion(ctx)),
+			Priority:          PriorityHigh,
+			Timestamp:         nowFunc(),
+			Frameworks:        GetFrameworkMappings("OPENSEARCH_HTTPS"),
+		}, nil
+	}
+
+	if len(domains) == 0 {
+		return CheckResult{
+			Control:    "CC6.4",
+			Name:       "OpenSearch Custom Endpoint Certificate",
+			Status:     "PASS",
+			Evidence:   "No OpenSearch domains found",
+			ConsoleURL: consoleURL("aos/home#opensearch/domains", consoleRegion(ctx)),
+			Priority:   PriorityInfo,
+			Timestamp:  nowFunc(),
+			Frameworks: GetFrameworkMappings("OPENSEARCH_HTTPS"),
+		}, nil
+	}
+
+	if custom == 0 {
+		return CheckResult{
+			Control:    "CC6.4",
+			Name:       "OpenSearch Custom Endpoint Certificate",
+			Status:     "NOT_APPLICABLE",
+			Evidence:   fmt.Sprintf("None of the %d OpenSearch domains has a custom endpoint configured; their AWS-provided endpoints are covered by OpenSearch HTTPS Required", domains.Described()),
+			Priority:   PriorityInfo,
+			Timestamp:  nowFunc(),
+			Frameworks: GetFrameworkMappings("OPENSEARCH_HTTPS"),
+		}, nil
+	}
+
+	return CheckResult{
+		Control:         "CC6.4",
+		Name:            "OpenSearch Custom Endpoint Certificate",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d OpenSearch custom endpoints enforce HTTPS with an issued ACM certificate for their hostname%s", custom, unverifiedNote),
+		ScreenshotGuide: "OpenSearch Console → Domains → Select domain → Custom endpoint → Screenshot showing the hostname, its ACM certificate and 'Require HTTPS: Yes'; ACM Console → Screenshot showing the certificate status Issued",
+		ConsoleURL:      consoleURL("aos/home#opensearch/domains", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("OPENSEARCH_HTTPS"),
+	}, nil
+}
+
+// describeCertificate describes an ACM certificate once per scan; domains
+// often share one wildcard certificate
+func (c *OpenSearchChecks) describeCertificate(ctx context.Context, arn string) (*acmtypes.CertificateDetail, error) {
+	return memoize(ctx, "acm:"+arn, func() (*acmtypes.CertificateDetail, error) {
+		out, err := c.acmClient.DescribeCertificate(ctx, &acm.DescribeCertificateInput{
+			CertificateArn: aws.String(arn),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if out.Certificate == nil {
+			return nil, fmt.Errorf("certificate %s has no details", arn)
+		}
+		return out.Certificate, nil
+	})
+}
+
+// customEndpointCertificateIssues returns what makes cert unfit for the
+// endpoint hostname: not issued, expired, or not covering the hostname
+func customEndpointCertificateIssues(cert *acmtypes.CertificateDetail, endpoint string) []string {
+	issues := []string{}
+	if cert.Status != acmtypes.CertificateStatusIssued {
+		issues = append(issues, fmt.Sprintf("certificate %s", strings.ToLower(string(cert.Status))))
+	}
+	if cert.NotAfter != nil && cert.NotAfter.Before(nowFunc()) {
+		issues = append(issues, fmt.Sprintf("certificate expired %s", cert.NotAfter.Format("2006-01-02")))
+	}
+
+	names := append([]string{aws.ToString(cert.DomainName)}, cert.SubjectAlternativeNames...)
+	covered := false
+	for _, name := range names {
+		if certificateNameMatches(name, endpoint) {
+			covered = true
+			break
+		}
+	}
+	if !covered {
+		issues = append(issues, "certificate does not cover the hostname")
+	}
+	return issues
+}
+
+// certificateNameMatches reports whether a certificate name covers host. A
+// wildcard name ("*.example.com") covers exactly one leftmost label.
+func certificateNameMatches(name, host string) bool {
+	name, host = strings.ToLower(name), strings.ToLower(strings.TrimSuffix(host, "."))
+	if name == host {
+		return true
+	}
+	suffix, ok := strings.CutPrefix(name, "*.")
+	if !ok {
+		return false
+	}
+	label, rest, found := strings.Cut(host, ".")
+	return found && label != "" && rest == suffix
+}
+
+// compareEngineVersions compares dotted numeric versions such as "7.10" and
+// "7.9", returning -1, 0 or 1. Missing or non-numeric parts count as zero.
+func compareEngineVersions(a, b string) int {
+	aParts := strings.Split(a, ".")
+	bParts := strings.Split(b, ".")
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		var x, y int
+		if i < len(aParts) {
+			x, _ = strconv.Atoi(aParts[i])
+		}
+		if i < len(bParts) {
+			y, _ = strconv.Atoi(bParts[i])
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// openSearchRestrictingConditionKeys limit who can reach the domain to an IP
+// range or network
+var openSearchRestrictingConditionKeys = map[string]bool{
+	"aws:sourceip":   true,
+	"aws:sourcevpc":  true,
+	"aws:sourcevpce": true,
+}
+
+// openSearchInVPC reports whether the domain is deployed in VPC subnets, so
+// its endpoint is not reachable from the internet
+func openSearchInVPC(status *opensearchtypes.DomainStatus) bool {
+	return status != nil && status.VPCOptions != nil && len(status.VPCOptions.SubnetIds) > 0
+}
+
+// openSearchPolicyIsPublic reports whether the domain has a public endpoint
+// and any Allow statement in its access policy grants Principal "*" (or
+// {"AWS": "*"}) with no IP/VPC condition. An aws:SourceIp condition admitting
+// 0.0.0.0/0 or ::/0 restricts nothing. A VPC domain is never public: an open
+// policy there only admits clients its security groups let in. A policy that
+// cannot be parsed is an error rather than a guess either way.
+func openSearchPolicyIsPublic(status *opensearchtypes.DomainStatus) (bool, error) {
+	if status == nil || openSearchInVPC(status) {
+		return false, nil
+	}
+
+	policy := aws.ToString(status.AccessPolicies)
+	if strings.TrimSpace(policy) == "" {
+		return false, nil
+	}
+
+	var doc struct {
+		Statement json.RawMessage `json:"Statement"`
+	}
+	if err := json.Unmarshal([]byte(policy), &doc); err != nil {
+		return false, fmt.Errorf("parsing access policy: %w", err)
+	}
+
+	statements := []openSearchPolicyStatement{}
+	if err := json.Unmarshal(doc.Statement, &statements); err != nil {
+		var single openSearchPolicyStatement
+		if err := json.Unmarshal(doc.Statement, &single); err != nil {
+			return false, fmt.Errorf("parsing access policy statements: %w", err)
+		}
+		statements = append(statements, single)
+	}
+
+	for _, stmt := range statements {
+		if !strings.EqualFold(stmt.Effect, "Allow") || !principalIsWildcard(stmt.Principal) {
+			continue
+		}
+
+		restricted := false
+		for _, keys := range stmt.Condition {
+			for key, value := range keys {
+				key = strings.ToLower(key)
+				if !openSearchRestrictingConditionKeys[key] {
+					continue
+				}
+				if key == "aws:sourceip" && policyValueHas(value, "0.0.0.0/0", "::/0") {
+					continue
+				}
+				restricted = true
+			}
+		}
+		if !restricted {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// principalIsWildcard matches "*", {"AWS": "*"} and {"AWS": ["*"]}
+func principalIsWildcard(raw json.RawMessage) bool {
+	var str string
+	if err := json.Unmarshal(raw, &str); err == nil {
+		return str == "*"
+	}
+
+	var byType map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &byType); err != nil {
+		return false
+	}
+	for principalType, value := range byType {
+		if !strings.EqualFold(principalType, "AWS") {
+			continue
+		}
+		if err := json.Unmarshal(value, &str); err == nil && str == "*" {
+			return true
+		}
+		var list []string
+		if err := json.Unmarshal(value, &list); err == nil {
+			for _, item := range list {
+				if item == "*" {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
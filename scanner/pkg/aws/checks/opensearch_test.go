@@ -0,0 +1,298 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/opensearch"
+	opensearchtypes "github.com/aws/aws-sdk-go-v2/service/opensearch/types"
+)
+
+// describedDomain returns DescribeDomain output for a domain with every
+// setting the checks look at turned on
+func describedDomain() *opensearch.DescribeDomainOutput {
+	return &opensearch.DescribeDomainOutput{
+		DomainStatus: &opensearchtypes.DomainStatus{
+			Created:                     aws.Bool(true),
+			EngineVersion:               aws.String("OpenSearch_2.11"),
+			EncryptionAtRestOptions:     &opensearchtypes.EncryptionAtRestOptions{Enabled: aws.Bool(true)},
+			NodeToNodeEncryptionOptions: &opensearchtypes.NodeToNodeEncryptionOptions{Enabled: aws.Bool(true)},
+			DomainEndpointOptions:       &opensearchtypes.DomainEndpointOptions{EnforceHTTPS: aws.Bool(true)},
+			VPCOptions:                  &opensearchtypes.VPCDerivedInfo{SubnetIds: []string{"subnet-1"}},
+			AdvancedSecurityOptions:     &opensearchtypes.AdvancedSecurityOptions{Enabled: aws.Bool(true)},
+			LogPublishingOptions: map[string]opensearchtypes.LogPublishingOption{
+				"AUDIT_LOGS": {Enabled: aws.Bool(true)},
+			},
+		},
+	}
+}
+
+func TestOpenSearchPassCountsOnlyDescribedDomains(t *testing.T) {
+	c := NewOpenSearchChecks(nil, nil)
+	domains := OpenSearchDomains{
+		"logs":   describedDomain(),
+		"search": nil, // DescribeDomain failed
+	}
+
+	checks := map[string]func(context.Context, OpenSearchDomains) (CheckResult, error){
+		"encryption at rest": c.CheckEncryptionAtRest,
+		"node-to-node":       c.CheckNodeToNodeEncryption,
+		"https":              c.CheckHTTPS,
+		"vpc":                c.CheckVPCDeployment,
+		"audit logs":         c.CheckAuditLogs,
+		"fgac":               c.CheckFineGrainedAccessControl,
+		"access policy":      c.CheckAccessPolicyPublic,
+		"engine version":     c.CheckEngineVersion,
+		"snapshots":          c.CheckAutomatedSnapshots,
+	}
+	for name, check := range checks {
+		result, err := check(context.Background(), domains)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if result.Status != "PASS" {
+			t.Errorf("%s: status %s, want PASS: %s", name, result.Status, result.Evidence)
+		}
+		if !strings.Contains(result.Evidence, "All 1 OpenSearch domains") {
+			t.Errorf("%s: evidence %q should count only the described domain", name, result.Evidence)
+		}
+	}
+}
+
+func TestOpenSearchUndescribedResult(t *testing.T) {
+	domains := OpenSearchDomains{
+		"logs":    describedDomain(),
+		"search":  nil,
+		"archive": nil,
+	}
+
+	result, ok := openSearchUndescribedResult(context.Background(), domains)
+	if !ok {
+		t.Fatal("expected a result for undescribed domains")
+	}
+	if result.Status != "ERROR" {
+		t.Errorf("status %s, want ERROR", result.Status)
+	}
+	if want := []string{"archive", "search"}; !reflect.DeepEqual(result.AffectedResources, want) {
+		t.Errorf("affected resources %v, want %v", result.AffectedResources, want)
+	}
+	if !strings.Contains(result.Evidence, "2/3 OpenSearch domains") {
+		t.Errorf("evidence %q should count 2 of 3 domains", result.Evidence)
+	}
+	if errs := ValidateResults([]CheckResult{result}); len(errs) > 0 {
+		t.Errorf("invalid result: %v", errs)
+	}
+}
+
+func TestOpenSearchUndescribedResultAllDescribed(t *testing.T) {
+	domains := OpenSearchDomains{"logs": describedDomain()}
+	if _, ok := openSearchUndescribedResult(context.Background(), domains); ok {
+		t.Error("no result expected when every domain was described")
+	}
+	if domains.Described() != 1 {
+		t.Errorf("Described() = %d, want 1", domains.Described())
+	}
+}
+
+func TestOpenSearchAccessPolicyPublicIgnoresVPCDomains(t *testing.T) {
+	openPolicy := `{"Statement":[{"Effect":"Allow","Principal":{"AWS":"*"},"Action":"es:*"}]}`
+
+	vpcDomain := describedDomain()
+	vpcDomain.DomainStatus.AccessPolicies = aws.String(openPolicy)
+
+	publicDomain := describedDomain()
+	publicDomain.DomainStatus.AccessPolicies = aws.String(openPolicy)
+	publicDomain.DomainStatus.VPCOptions = nil
+
+	c := NewOpenSearchChecks(nil, nil)
+	result, err := c.CheckAccessPolicyPublic(context.Background(), OpenSearchDomains{
+		"internal": vpcDomain,
+		"public":   publicDomain,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Status != "FAIL" {
+		t.Fatalf("status %s, want FAIL: %s", result.Status, result.Evidence)
+	}
+	if want := []string{"public"}; !reflect.DeepEqual(result.AffectedResources, want) {
+		t.Errorf("affected resources %v, want %v", result.AffectedResources, want)
+	}
+
+	result, err = c.CheckAccessPolicyPublic(context.Background(), OpenSearchDomains{"internal": vpcDomain})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Status != "PASS" {
+		t.Errorf("VPC domain with an open policy: status %s, want PASS: %s", result.Status, result.Evidence)
+	}
+}
+
+func TestOpenSearchPolicyIsPublic(t *testing.T) {
+	tests := map[string]struct {
+		policy string
+		public bool
+	}{
+		"wildcard principal":   {`{"Statement":[{"Effect":"Allow","Principal":"*","Action":"es:*"}]}`, true},
+		"single statement":     {`{"Statement":{"Effect":"Allow","Principal":{"AWS":["*"]},"Action":"es:*"}}`, true},
+		"office IP range":      {`{"Statement":[{"Effect":"Allow","Principal":"*","Condition":{"IpAddress":{"aws:SourceIp":["203.0.113.0/24"]}}}]}`, false},
+		"any IPv4 address":     {`{"Statement":[{"Effect":"Allow","Principal":"*","Condition":{"IpAddress":{"aws:SourceIp":"0.0.0.0/0"}}}]}`, true},
+		"any IPv6 address":     {`{"Statement":[{"Effect":"Allow","Principal":"*","Condition":{"IpAddress":{"aws:SourceIp":["203.0.113.0/24","::/0"]}}}]}`, true},
+		"source VPC":           {`{"Statement":[{"Effect":"Allow","Principal":"*","Condition":{"StringEquals":{"aws:SourceVpc":"vpc-1"}}}]}`, false},
+		"specific principal":   {`{"Statement":[{"Effect":"Allow","Principal":{"AWS":"arn:aws:iam::123456789012:role/app"},"Action":"es:*"}]}`, false},
+		"deny everyone":        {`{"Statement":[{"Effect":"Deny","Principal":"*","Action":"es:*"}]}`, false},
+		"no policy configured": {``, false},
+	}
+	for name, tt := range tests {
+		status := describedDomain().DomainStatus
+		status.VPCOptions = nil
+		status.AccessPolicies = aws.String(tt.policy)
+
+		public, err := openSearchPolicyIsPublic(status)
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+		if public != tt.public {
+			t.Errorf("%s: public = %v, want %v", name, public, tt.public)
+		}
+	}
+}
+
+func TestOpenSearchAccessPolicyUnparsableIsError(t *testing.T) {
+	unparsable := describedDomain()
+	unparsable.DomainStatus.VPCOptions = nil
+	unparsable.DomainStatus.AccessPolicies = aws.String(`{"Statement":[{"Effect":"Allow","Principal":"*"`)
+
+	public := describedDomain()
+	public.DomainStatus.VPCOptions = nil
+	public.DomainStatus.AccessPolicies = aws.String(`{"Statement":[{"Effect":"Allow","Principal":"*","Action":"es:*"}]}`)
+
+	c := NewOpenSearchChecks(nil, nil)
+	result, err := c.CheckAccessPolicyPublic(context.Background(), OpenSearchDomains{"broken": unparsable, "logs": describedDomain()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Status != "ERROR" {
+		t.Fatalf("status %s, want ERROR rather than a PASS for a policy never read: %s", result.Status, result.Evidence)
+	}
+	if want := []string{"broken"}; !reflect.DeepEqual(result.AffectedResources, want) {
+		t.Errorf("affected resources %v, want %v", result.AffectedResources, want)
+	}
+	if errs := ValidateResults([]CheckResult{result}); len(errs) > 0 {
+		t.Errorf("invalid result: %v", errs)
+	}
+
+	result, err = c.CheckAccessPolicyPublic(context.Background(), OpenSearchDomains{"broken": unparsable, "public": public})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Status != "FAIL" {
+		t.Fatalf("status %s, want FAIL for the open policy: %s", result.Status, result.Evidence)
+	}
+	if want := []string{"public"}; !reflect.DeepEqual(result.AffectedResources, want) {
+		t.Errorf("affected resources %v, want %v", result.AffectedResources, want)
+	}
+	if !strings.Contains(result.Evidence, "could not be parsed and were not verified: [broken]") {
+		t.Errorf("evidence %q should note the unparsable policy", result.Evidence)
+	}
+}
+
+// openSearchCountingStub answers OpenSearch REST calls for a fixed set of
+// domains and counts the DescribeDomain calls made for each. With deniedList
+// set, ListDomainNames fails with AccessDeniedException.
+type openSearchCountingStub struct {
+	domains    []string
+	deniedList bool
+
+	mu        sync.Mutex
+	described map[string]int
+}
+
+func (s *openSearchCountingStub) Do(req *http.Request) (*http.Response, error) {
+	body := "{}"
+	status, header := http.StatusOK, http.Header{"Content-Type": []string{"application/json"}}
+	switch path := req.URL.Path; {
+	case path == "/2021-01-01/domain" && s.deniedList:
+		status = http.StatusForbidden
+		header.Set("X-Amzn-Errortype", "AccessDeniedException")
+		body = `{"message":"not authorized to perform es:ListDomainNames"}`
+	case path == "/2021-01-01/domain":
+		names := []string{}
+		for _, name := range s.domains {
+			names = append(names, fmt.Sprintf(`{"DomainName":%q,"EngineType":"OpenSearch"}`, name))
+		}
+		body = `{"DomainNames":[` + strings.Join(names, ",") + `]}`
+	case strings.HasPrefix(path, "/2021-01-01/opensearch/domain/"):
+		name := strings.TrimPrefix(path, "/2021-01-01/opensearch/domain/")
+		s.mu.Lock()
+		s.described[name]++
+		s.mu.Unlock()
+		body = fmt.Sprintf(`{"DomainStatus":{"DomainName":%q,"DomainId":"123456789012/%s","ARN":"arn:aws:es:us-east-1:123456789012:domain/%s","Created":true,"EngineVersion":"OpenSearch_2.11","ClusterConfig":{}}}`, name, name, name)
+	}
+	return &http.Response{
+		StatusCode:    status,
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+func TestOpenSearchDescribesEachDomainOnce(t *testing.T) {
+	stub := &openSearchCountingStub{
+		domains:   []string{"logs", "search", "audit", "metrics", "traces", "archive"},
+		described: map[string]int{},
+	}
+	client := opensearch.New(opensearch.Options{
+		Region:      "us-east-1",
+		Credentials: aws.AnonymousCredentials{},
+		HTTPClient:  stub,
+		Retryer:     aws.NopRetryer{},
+	})
+
+	// Every check in the module reads the domains; they must share one
+	// DescribeDomain per domain
+	results, err := NewOpenSearchChecks(client, nil).Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) == 0 {
+		t.Fatal("no results")
+	}
+	for _, name := range stub.domains {
+		if got := stub.described[name]; got != 1 {
+			t.Errorf("DescribeDomain called %d times for %s, want 1", got, name)
+		}
+	}
+	if len(stub.described) != len(stub.domains) {
+		t.Errorf("described %v, want only the listed domains", stub.described)
+	}
+}
+
+func TestOpenSearchListFailureIsAnError(t *testing.T) {
+	stub := &openSearchCountingStub{domains: []string{"logs"}, deniedList: true, described: map[string]int{}}
+	client := opensearch.New(opensearch.Options{
+		Region:      "us-east-1",
+		Credentials: aws.AnonymousCredentials{},
+		HTTPClient:  stub,
+		Retryer:     aws.NopRetryer{},
+	})
+
+	// Without a domain filter, a denied listing must not read as an account
+	// with no domains
+	results, err := NewOpenSearchChecks(client, nil).Run(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "AccessDenied") {
+		t.Fatalf("error %v, want the AccessDenied listing error", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("got %d results from a failed listing", len(results))
+	}
+}
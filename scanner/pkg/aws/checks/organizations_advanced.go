@@ -3,7 +3,6 @@ package checks
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/cloudtrail"
 	"github.com/aws/aws-sdk-go-v2/service/organizations"
@@ -29,22 +28,22 @@ func (c *OrganizationsAdvancedChecks) Run(ctx context.Context) ([]CheckResult, e
 	results := []CheckResult{}
 
 	// CIS 11.1 - SCPs enabled
-	if result, err := c.CheckSCPsEnabled(ctx); err == nil {
+	if result, err := runCheck(ctx, "organizations_advanced.scps_enabled", c.CheckSCPsEnabled); err == nil {
 		results = append(results, result)
 	}
 
 	// CIS 11.2 - Multi-account structure
-	if result, err := c.CheckMultiAccountStructure(ctx); err == nil {
+	if result, err := runCheck(ctx, "organizations_advanced.multi_account_structure", c.CheckMultiAccountStructure); err == nil {
 		results = append(results, result)
 	}
 
 	// CIS 11.3 - Organization CloudTrail
-	if result, err := c.CheckOrganizationTrail(ctx); err == nil {
+	if result, err := runCheck(ctx, "organizations_advanced.organization_trail", c.CheckOrganizationTrail); err == nil {
 		results = append(results, result)
 	}
 
 	// CIS 11.4 - Service Control Policies configured
-	if result, err := c.CheckSCPsConfigured(ctx); err == nil {
+	if result, err := runCheck(ctx, "organizations_advanced.scps_configured", c.CheckSCPsConfigured); err == nil {
 		results = append(results, result)
 	}
 
@@ -65,7 +64,7 @@ func (c *OrganizationsAdvancedChecks) CheckSCPsEnabled(ctx context.Context) (Che
 3. Enable all features (not just consolidated billing)
 4. SCPs are automatically enabled with all features`,
 			Priority:   PriorityLow,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("ORGANIZATIONS_SCPS_ENABLED"),
 		}, nil
 	}
@@ -83,8 +82,8 @@ func (c *OrganizationsAdvancedChecks) CheckSCPsEnabled(ctx context.Context) (Che
 4. SCPs will become available for governance`,
 			Severity:   "HIGH",
 			Priority:   PriorityHigh,
-			Timestamp:  time.Now(),
-			ConsoleURL: "https://console.aws.amazon.com/organizations/v2/home",
+			Timestamp:  nowFunc(),
+			ConsoleURL: consoleURL("organizations/v2/home", consoleRegion(ctx)),
 			Frameworks: GetFrameworkMappings("ORGANIZATIONS_SCPS_ENABLED"),
 		}, nil
 	}
@@ -107,7 +106,7 @@ func (c *OrganizationsAdvancedChecks) CheckSCPsEnabled(ctx context.Context) (Che
 			Remediation: "Enable Service Control Policies in Organizations",
 			Severity:   "HIGH",
 			Priority:   PriorityHigh,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("ORGANIZATIONS_SCPS_ENABLED"),
 		}, nil
 	}
@@ -119,8 +118,8 @@ func (c *OrganizationsAdvancedChecks) CheckSCPsEnabled(ctx context.Context) (Che
 		Evidence:    "AWS Organizations is enabled with Service Control Policies (SCPs) available",
 		Remediation: "N/A - SCPs enabled",
 		Priority:    PriorityLow,
-		Timestamp:   time.Now(),
-		ConsoleURL:  "https://console.aws.amazon.com/organizations/v2/home/policies/service-control-policy",
+		Timestamp:   nowFunc(),
+		ConsoleURL:  consoleURL("organizations/v2/home/policies/service-control-policy", consoleRegion(ctx)),
 		Frameworks:  GetFrameworkMappings("ORGANIZATIONS_SCPS_ENABLED"),
 	}, nil
 }
@@ -135,7 +134,7 @@ func (c *OrganizationsAdvancedChecks) CheckMultiAccountStructure(ctx context.Con
 			Evidence:    "Not using AWS Organizations or no permissions",
 			Remediation: "Consider multi-account strategy for workload isolation",
 			Priority:    PriorityLow,
-			Timestamp:   time.Now(),
+			Timestamp:   nowFunc(),
 			Frameworks:  GetFrameworkMappings("ORGANIZATIONS_MULTI_ACCOUNT"),
 		}, nil
 	}
@@ -164,9 +163,9 @@ Benefits:
 - Different security controls per environment`,
 			Severity:        "MEDIUM",
 			Priority:        PriorityMedium,
-			Timestamp:       time.Now(),
+			Timestamp:       nowFunc(),
 			ScreenshotGuide: "Organizations → Accounts → Screenshot showing multi-account structure",
-			ConsoleURL:      "https://console.aws.amazon.com/organizations/v2/home/accounts",
+			ConsoleURL:      consoleURL("organizations/v2/home/accounts", consoleRegion(ctx)),
 			Frameworks:      GetFrameworkMappings("ORGANIZATIONS_MULTI_ACCOUNT"),
 		}, nil
 	}
@@ -178,8 +177,8 @@ Benefits:
 		Evidence:    fmt.Sprintf("Using multi-account structure with %d accounts for workload isolation", accountCount),
 		Remediation: "N/A - Multi-account structure implemented",
 		Priority:    PriorityLow,
-		Timestamp:   time.Now(),
-		ConsoleURL:  "https://console.aws.amazon.com/organizations/v2/home/accounts",
+		Timestamp:   nowFunc(),
+		ConsoleURL:  consoleURL("organizations/v2/home/accounts", consoleRegion(ctx)),
 		Frameworks:  GetFrameworkMappings("ORGANIZATIONS_MULTI_ACCOUNT"),
 	}, nil
 }
@@ -194,7 +193,7 @@ func (c *OrganizationsAdvancedChecks) CheckOrganizationTrail(ctx context.Context
 			Evidence:   "Cannot check CloudTrail configuration",
 			Remediation: "Verify CloudTrail permissions",
 			Priority:   PriorityLow,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("ORGANIZATIONS_TRAIL"),
 		}, nil
 	}
@@ -222,9 +221,9 @@ func (c *OrganizationsAdvancedChecks) CheckOrganizationTrail(ctx context.Context
 6. All member accounts automatically inherit this trail`,
 			Severity:        "CRITICAL",
 			Priority:        PriorityCritical,
-			Timestamp:       time.Now(),
+			Timestamp:       nowFunc(),
 			ScreenshotGuide: "CloudTrail → Trails → Create trail → Screenshot showing organization trail enabled",
-			ConsoleURL:      "https://console.aws.amazon.com/cloudtrail/home#/trails",
+			ConsoleURL:      consoleURL("cloudtrail/home#/trails", consoleRegion(ctx)),
 			Frameworks:      GetFrameworkMappings("ORGANIZATIONS_TRAIL"),
 		}, nil
 	}
@@ -236,8 +235,8 @@ func (c *OrganizationsAdvancedChecks) CheckOrganizationTrail(ctx context.Context
 		Evidence:    "Organization-wide CloudTrail is configured - all accounts are logged",
 		Remediation: "N/A - Organization trail configured",
 		Priority:    PriorityLow,
-		Timestamp:   time.Now(),
-		ConsoleURL:  "https://console.aws.amazon.com/cloudtrail/home#/trails",
+		Timestamp:   nowFunc(),
+		ConsoleURL:  consoleURL("cloudtrail/home#/trails", consoleRegion(ctx)),
 		Frameworks:  GetFrameworkMappings("ORGANIZATIONS_TRAIL"),
 	}, nil
 }
@@ -254,7 +253,7 @@ func (c *OrganizationsAdvancedChecks) CheckSCPsConfigured(ctx context.Context) (
 			Evidence:   "Cannot list SCPs - not using Organizations or no permissions",
 			Remediation: "N/A",
 			Priority:   PriorityLow,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("ORGANIZATIONS_SCPS_CONFIGURED"),
 		}, nil
 	}
@@ -293,9 +292,9 @@ Example deny policy:
 }`,
 			Severity:        "HIGH",
 			Priority:        PriorityHigh,
-			Timestamp:       time.Now(),
+			Timestamp:       nowFunc(),
 			ScreenshotGuide: "Organizations → Policies → Service control policies → Screenshot showing custom policies",
-			ConsoleURL:      "https://console.aws.amazon.com/organizations/v2/home/policies/service-control-policy",
+			ConsoleURL:      consoleURL("organizations/v2/home/policies/service-control-policy", consoleRegion(ctx)),
 			Frameworks:      GetFrameworkMappings("ORGANIZATIONS_SCPS_CONFIGURED"),
 		}, nil
 	}
@@ -307,8 +306,8 @@ Example deny policy:
 		Evidence:    fmt.Sprintf("%d custom SCPs configured for security boundaries", customPolicies),
 		Remediation: "N/A - SCPs configured",
 		Priority:    PriorityLow,
-		Timestamp:   time.Now(),
-		ConsoleURL:  "https://console.aws.amazon.com/organizations/v2/home/policies/service-control-policy",
+		Timestamp:   nowFunc(),
+		ConsoleURL:  consoleURL("organizations/v2/home/policies/service-control-policy", consoleRegion(ctx)),
 		Frameworks:  GetFrameworkMappings("ORGANIZATIONS_SCPS_CONFIGURED"),
 	}, nil
 }
@@ -0,0 +1,104 @@
+package checks
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SeverityOverride replaces the default severity of a check. Name is optional:
+// when empty the override applies to every check reporting Control.
+//
+//	overrides:
+//	  - control: CC8.1
+//	    name: Redshift Automatic Version Upgrade
+//	    severity: HIGH
+type SeverityOverride struct {
+	Control  string `yaml:"control"`
+	Name     string `yaml:"name"`
+	Severity string `yaml:"severity"`
+}
+
+// validSeverities are the severities an override may set
+var validSeverities = map[string]bool{
+	"CRITICAL": true,
+	"HIGH":     true,
+	"MEDIUM":   true,
+	"LOW":      true,
+}
+
+// LoadSeverityOverrides reads and validates severity overrides from a YAML file
+func LoadSeverityOverrides(path string) ([]SeverityOverride, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read severity overrides file: %w", err)
+	}
+
+	var file struct {
+		Overrides []SeverityOverride `yaml:"overrides"`
+	}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse severity overrides YAML: %w", err)
+	}
+
+	for i := range file.Overrides {
+		override := &file.Overrides[i]
+		if override.Control == "" {
+			return nil, fmt.Errorf("severity override #%d: control is required", i+1)
+		}
+		override.Severity = strings.ToUpper(strings.TrimSpace(override.Severity))
+		if !validSeverities[override.Severity] {
+			return nil, fmt.Errorf("severity override %s: invalid severity %q (use CRITICAL, HIGH, MEDIUM or LOW)",
+				override.Control, override.Severity)
+		}
+	}
+
+	return file.Overrides, nil
+}
+
+// ApplySeverityOverrides rewrites the severity (and matching priority) of
+// results with an override, keeping the check's own value in
+// OriginalSeverity. Results without a severity (typically PASS) are left
+// alone. An override naming the check wins over a control-wide one. Returns
+// the number of results changed.
+func ApplySeverityOverrides(results []CheckResult, overrides []SeverityOverride) int {
+	if len(overrides) == 0 {
+		return 0
+	}
+
+	changed := 0
+	for i := range results {
+		result := &results[i]
+		if result.Severity == "" {
+			continue
+		}
+
+		severity := ""
+		for _, override := range overrides {
+			if !strings.EqualFold(override.Control, result.Control) {
+				continue
+			}
+			if override.Name == "" {
+				if severity == "" {
+					severity = override.Severity
+				}
+			} else if strings.EqualFold(override.Name, result.Name) {
+				severity = override.Severity
+				break
+			}
+		}
+
+		if severity == "" || strings.EqualFold(severity, result.Severity) {
+			continue
+		}
+		if result.OriginalSeverity == "" {
+			result.OriginalSeverity = result.Severity
+		}
+		result.Severity = severity
+		result.Priority = priorityForSeverity(severity)
+		changed++
+	}
+	return changed
+}
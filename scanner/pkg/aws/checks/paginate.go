@@ -0,0 +1,50 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// maxPages bounds a paginate loop, in case an API keeps returning a token
+const maxPages = 1000
+
+// paginate collects every item of a paginated AWS call. fetch reads the page
+// at token (nil for the first page) and returns its items and the token of
+// the next page, nil or empty on the last one:
+//
+//	clusters, err := paginate(ctx, func(token *string) ([]types.Cluster, *string, error) {
+//		out, err := client.DescribeClusters(ctx, &redshift.DescribeClustersInput{Marker: token})
+//		if err != nil {
+//			return nil, nil, err
+//		}
+//		return out.Clusters, out.Marker, nil
+//	})
+//
+// A failed page fails the whole call rather than returning a partial list a
+// check would mistake for the complete one.
+func paginate[T any](ctx context.Context, fetch func(token *string) ([]T, *string, error)) ([]T, error) {
+	items := []T{}
+	var token *string
+	for page := 0; page < maxPages; page++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		pageItems, next, err := fetch(token)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, pageItems...)
+
+		if aws.ToString(next) == "" {
+			return items, nil
+		}
+		if token != nil && *next == *token {
+			return nil, fmt.Errorf("pagination stuck: page %d returned its own token", page+1)
+		}
+		token = next
+	}
+	return nil, fmt.Errorf("pagination did not finish after %d pages", maxPages)
+}
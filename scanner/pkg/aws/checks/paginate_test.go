@@ -0,0 +1,114 @@
+package checks
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// pages serves fixed pages, each page's token being its index. last is the
+// token the final page returns.
+func pages(calls *[]string, last *string, contents ...[]string) func(token *string) ([]string, *string, error) {
+	return func(token *string) ([]string, *string, error) {
+		page := 0
+		if token != nil {
+			page, _ = strconv.Atoi(*token)
+		}
+		*calls = append(*calls, aws.ToString(token))
+		if page == len(contents)-1 {
+			return contents[page], last, nil
+		}
+		return contents[page], aws.String(strconv.Itoa(page + 1)), nil
+	}
+}
+
+func TestPaginateCollectsEveryPage(t *testing.T) {
+	for name, last := range map[string]*string{"nil token": nil, "empty token": aws.String("")} {
+		var calls []string
+		got, err := paginate(context.Background(), pages(&calls, last, []string{"a", "b"}, []string{}, []string{"c"}))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if want := []string{"a", "b", "c"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got %v, want %v", name, got, want)
+		}
+		if want := []string{"", "1", "2"}; !reflect.DeepEqual(calls, want) {
+			t.Errorf("%s: fetched tokens %q, want %q", name, calls, want)
+		}
+	}
+}
+
+func TestPaginateSinglePage(t *testing.T) {
+	var calls []string
+	got, err := paginate(context.Background(), pages(&calls, nil, []string{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got == nil || len(got) != 0 {
+		t.Errorf("got %#v, want an empty, non-nil list", got)
+	}
+	if len(calls) != 1 {
+		t.Errorf("fetched %d pages, want 1", len(calls))
+	}
+}
+
+func TestPaginateFailsOnAnErrorMidway(t *testing.T) {
+	denied := errors.New("AccessDenied")
+	calls := 0
+	got, err := paginate(context.Background(), func(token *string) ([]string, *string, error) {
+		calls++
+		if calls == 2 {
+			return nil, nil, denied
+		}
+		return []string{"item"}, aws.String(strconv.Itoa(calls)), nil
+	})
+	if !errors.Is(err, denied) {
+		t.Errorf("error %v, want the page's error", err)
+	}
+	if got != nil {
+		t.Errorf("got %v, want no partial list", got)
+	}
+	if calls != 2 {
+		t.Errorf("fetched %d pages, want to stop at the failing one", calls)
+	}
+}
+
+func TestPaginateStopsWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	got, err := paginate(ctx, func(token *string) ([]string, *string, error) {
+		calls++
+		if calls == 2 {
+			cancel()
+		}
+		return []string{"item"}, aws.String(strconv.Itoa(calls)), nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("error %v, want context.Canceled", err)
+	}
+	if got != nil {
+		t.Errorf("got %v, want no partial list", got)
+	}
+	if calls != 2 {
+		t.Errorf("fetched %d pages, want none after the cancellation", calls)
+	}
+}
+
+func TestPaginateDetectsAStuckToken(t *testing.T) {
+	calls := 0
+	_, err := paginate(context.Background(), func(token *string) ([]string, *string, error) {
+		calls++
+		return []string{"item"}, aws.String("same"), nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "stuck") {
+		t.Errorf("error %v, want a stuck pagination error", err)
+	}
+	if calls != 2 {
+		t.Errorf("fetched %d pages, want to stop once the token repeats", calls)
+	}
+}
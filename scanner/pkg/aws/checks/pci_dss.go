@@ -48,40 +48,40 @@ func (c *PCIDSSChecks) Run(ctx context.Context) ([]CheckResult, error) {
 	results := []CheckResult{}
 	
 	// Requirement 1: Network Security Requirements
-	results = append(results, c.CheckReq1_NetworkSegmentation(ctx)...)
+	results = append(results, runChecks(ctx, "pci_dss.req1_network_segmentation", c.CheckReq1_NetworkSegmentation)...)
 	
 	// Requirement 2: Default Passwords
-	results = append(results, c.CheckReq2_DefaultPasswords(ctx)...)
+	results = append(results, runChecks(ctx, "pci_dss.req2_default_passwords", c.CheckReq2_DefaultPasswords)...)
 	
 	// Requirement 3: Cardholder Data Protection
-	results = append(results, c.CheckReq3_Encryption(ctx)...)
+	results = append(results, runChecks(ctx, "pci_dss.req3_encryption", c.CheckReq3_Encryption)...)
 	
 	// Requirement 4: Encryption in Transit (CRITICAL)
-	results = append(results, c.CheckReq4_EncryptionInTransit(ctx)...)
+	results = append(results, runChecks(ctx, "pci_dss.req4_encryption_in_transit", c.CheckReq4_EncryptionInTransit)...)
 
 	// Requirement 5: Malware Protection
-	results = append(results, c.CheckReq5_MalwareProtection(ctx)...)
+	results = append(results, runChecks(ctx, "pci_dss.req5_malware_protection", c.CheckReq5_MalwareProtection)...)
 
 	// Requirement 6: Secure Systems (CRITICAL)
-	results = append(results, c.CheckReq6_SecureSystems(ctx)...)
+	results = append(results, runChecks(ctx, "pci_dss.req6_secure_systems", c.CheckReq6_SecureSystems)...)
 	
 	// Requirement 7: Access Control (CRITICAL)
-	results = append(results, c.CheckReq7_AccessControl(ctx)...)
+	results = append(results, runChecks(ctx, "pci_dss.req7_access_control", c.CheckReq7_AccessControl)...)
 	
 	// Requirement 8: User Authentication (STRICTER than SOC2)
-	results = append(results, c.CheckReq8_Authentication(ctx)...)
+	results = append(results, runChecks(ctx, "pci_dss.req8_authentication", c.CheckReq8_Authentication)...)
 	
 	// Requirement 10: Logging (12 months!)
-	results = append(results, c.CheckReq10_Logging(ctx)...)
+	results = append(results, runChecks(ctx, "pci_dss.req10_logging", c.CheckReq10_Logging)...)
 	
 	// Requirement 9: Physical Access Controls
-	results = append(results, c.CheckReq9_PhysicalAccess(ctx)...)
+	results = append(results, runChecks(ctx, "pci_dss.req9_physical_access", c.CheckReq9_PhysicalAccess)...)
 
 	// Requirement 11: Security Testing
-	results = append(results, c.CheckReq11_SecurityTesting(ctx)...)
+	results = append(results, runChecks(ctx, "pci_dss.req11_security_testing", c.CheckReq11_SecurityTesting)...)
 
 	// Requirement 12: Information Security Policy
-	results = append(results, c.CheckReq12_SecurityPolicy(ctx)...)
+	results = append(results, runChecks(ctx, "pci_dss.req12_security_policy", c.CheckReq12_SecurityPolicy)...)
 
 	return results, nil
 }
@@ -99,7 +99,7 @@ func (c *PCIDSSChecks) CheckReq1_NetworkSegmentation(ctx context.Context) []Chec
 			Status:    "ERROR",
 			Evidence:  fmt.Sprintf("Could not check VPC segmentation: %v", err),
 			Priority:  PriorityCritical,
-			Timestamp: time.Now(),
+			Timestamp: nowFunc(),
 			Frameworks: map[string]string{
 				"PCI-DSS": "Req 1.2.1",
 			},
@@ -118,8 +118,8 @@ func (c *PCIDSSChecks) CheckReq1_NetworkSegmentation(ctx context.Context) []Chec
 			RemediationDetail: "1. Create new VPC: aws ec2 create-vpc --cidr-block 10.1.0.0/16\n2. Tag as CDE: aws ec2 create-tags --resources vpc-xxx --tags Key=Environment,Value=CDE\n3. Implement strict NACLs and security groups",
 			Priority: PriorityCritical,
 			ScreenshotGuide: "VPC Console → Show all VPCs → Screenshot showing CDE VPC separated",
-			ConsoleURL: "https://console.aws.amazon.com/vpc/",
-			Timestamp: time.Now(),
+			ConsoleURL: consoleURL("vpc/", consoleRegion(ctx)),
+			Timestamp: nowFunc(),
 			Frameworks: map[string]string{
 				"PCI-DSS": "Req 1.2.1",
 			},
@@ -131,7 +131,9 @@ func (c *PCIDSSChecks) CheckReq1_NetworkSegmentation(ctx context.Context) []Chec
 			Status:    "PASS",
 			Evidence:  fmt.Sprintf("%d VPCs found - verify CDE isolation manually", len(vpcs.Vpcs)),
 			Priority:  PriorityInfo,
-			Timestamp: time.Now(),
+			ScreenshotGuide: "VPC Console → Show all VPCs → Screenshot showing CDE VPC separated",
+			ConsoleURL: consoleURL("vpc/", consoleRegion(ctx)),
+			Timestamp: nowFunc(),
 			Frameworks: map[string]string{
 				"PCI-DSS": "Req 1.2.1",
 			},
@@ -148,7 +150,7 @@ func (c *PCIDSSChecks) CheckReq1_NetworkSegmentation(ctx context.Context) []Chec
 			Status:    "ERROR",
 			Evidence:  fmt.Sprintf("Unable to check security groups: %v", err),
 			Priority:  PriorityHigh,
-			Timestamp: time.Now(),
+			Timestamp: nowFunc(),
 			Frameworks: map[string]string{
 				"PCI-DSS": "Req 1.3.1",
 			},
@@ -182,8 +184,8 @@ func (c *PCIDSSChecks) CheckReq1_NetworkSegmentation(ctx context.Context) []Chec
 			RemediationDetail: "aws ec2 revoke-security-group-ingress --group-id sg-xxx --protocol all --cidr 0.0.0.0/0",
 			Priority: PriorityCritical,
 			ScreenshotGuide: "EC2 → Security Groups → Each group → Inbound rules → No 0.0.0.0/0",
-			ConsoleURL: "https://console.aws.amazon.com/ec2/v2/home#SecurityGroups",
-			Timestamp: time.Now(),
+			ConsoleURL: consoleURL("ec2/v2/home#SecurityGroups", consoleRegion(ctx)),
+			Timestamp: nowFunc(),
 			Frameworks: map[string]string{
 				"PCI-DSS": "Req 1.3.1",
 			},
@@ -195,7 +197,9 @@ func (c *PCIDSSChecks) CheckReq1_NetworkSegmentation(ctx context.Context) []Chec
 			Status:    "PASS",
 			Evidence:  "No security groups allow 0.0.0.0/0 access",
 			Priority:  PriorityInfo,
-			Timestamp: time.Now(),
+			ScreenshotGuide: "EC2 → Security Groups → Each group → Inbound rules → No 0.0.0.0/0",
+			ConsoleURL: consoleURL("ec2/v2/home#SecurityGroups", consoleRegion(ctx)),
+			Timestamp: nowFunc(),
 			Frameworks: map[string]string{
 				"PCI-DSS": "Req 1.3.1",
 			},
@@ -221,7 +225,7 @@ func (c *PCIDSSChecks) CheckReq2_DefaultPasswords(ctx context.Context) []CheckRe
 			Evidence:  fmt.Sprintf("Unable to check security groups: %v", err),
 			Remediation: "Ensure AWS credentials have ec2:DescribeSecurityGroups permission",
 			Priority:  PriorityHigh,
-			Timestamp: time.Now(),
+			Timestamp: nowFunc(),
 			Frameworks: map[string]string{
 				"PCI-DSS": "Req 2.2.2",
 			},
@@ -246,8 +250,8 @@ func (c *PCIDSSChecks) CheckReq2_DefaultPasswords(ctx context.Context) []CheckRe
 			RemediationDetail: "for each VPC: aws ec2 revoke-security-group-ingress --group-id <default-sg-id> --protocol all --source-group <default-sg-id>",
 			Priority: PriorityHigh,
 			ScreenshotGuide: "EC2 → Security Groups → Filter by 'default' → Show empty rule sets",
-			ConsoleURL: "https://console.aws.amazon.com/ec2/v2/home#SecurityGroups",
-			Timestamp: time.Now(),
+			ConsoleURL: consoleURL("ec2/v2/home#SecurityGroups", consoleRegion(ctx)),
+			Timestamp: nowFunc(),
 			Frameworks: map[string]string{
 				"PCI-DSS": "Req 2.2.2",
 			},
@@ -259,7 +263,9 @@ func (c *PCIDSSChecks) CheckReq2_DefaultPasswords(ctx context.Context) []CheckRe
 			Status:    "PASS",
 			Evidence:  "Default security groups have no rules",
 			Priority:  PriorityInfo,
-			Timestamp: time.Now(),
+			ScreenshotGuide: "EC2 → Security Groups → Filter by 'default' → Show empty rule sets",
+			ConsoleURL: consoleURL("ec2/v2/home#SecurityGroups", consoleRegion(ctx)),
+			Timestamp: nowFunc(),
 			Frameworks: map[string]string{
 				"PCI-DSS": "Req 2.2.2",
 			},
@@ -283,7 +289,7 @@ func (c *PCIDSSChecks) CheckReq3_Encryption(ctx context.Context) []CheckResult {
 			Status:    "ERROR",
 			Evidence:  fmt.Sprintf("Unable to check S3 buckets: %v", err),
 			Priority:  PriorityCritical,
-			Timestamp: time.Now(),
+			Timestamp: nowFunc(),
 			Frameworks: map[string]string{
 				"PCI-DSS": "Req 3.4",
 			},
@@ -322,8 +328,8 @@ func (c *PCIDSSChecks) CheckReq3_Encryption(ctx context.Context) []CheckResult {
 			RemediationDetail: fmt.Sprintf("aws s3api put-bucket-encryption --bucket %s --server-side-encryption-configuration '{\"Rules\":[{\"ApplyServerSideEncryptionByDefault\":{\"SSEAlgorithm\":\"AES256\"}}]}'", unencryptedBuckets[0]),
 			Priority: PriorityCritical,
 			ScreenshotGuide: "S3 → Each bucket → Properties → Encryption → Show AES-256 or KMS enabled",
-			ConsoleURL: "https://s3.console.aws.amazon.com/s3/buckets/",
-			Timestamp: time.Now(),
+			ConsoleURL: consoleURL("s3/buckets/", consoleRegion(ctx)),
+			Timestamp: nowFunc(),
 			Frameworks: map[string]string{
 				"PCI-DSS": "Req 3.4, 3.4.1",
 			},
@@ -335,7 +341,9 @@ func (c *PCIDSSChecks) CheckReq3_Encryption(ctx context.Context) []CheckResult {
 			Status:    "PASS",
 			Evidence:  fmt.Sprintf("All %d S3 buckets encrypted", totalBuckets),
 			Priority:  PriorityInfo,
-			Timestamp: time.Now(),
+			ScreenshotGuide: "S3 → Each bucket → Properties → Encryption → Show AES-256 or KMS enabled",
+			ConsoleURL: consoleURL("s3/buckets/", consoleRegion(ctx)),
+			Timestamp: nowFunc(),
 			Frameworks: map[string]string{
 				"PCI-DSS": "Req 3.4, 3.4.1",
 			},
@@ -359,7 +367,7 @@ func (c *PCIDSSChecks) CheckReq4_EncryptionInTransit(ctx context.Context) []Chec
 			Status:    "ERROR",
 			Evidence:  fmt.Sprintf("Unable to check security groups: %v", err),
 			Priority:  PriorityCritical,
-			Timestamp: time.Now(),
+			Timestamp: nowFunc(),
 			Frameworks: map[string]string{
 				"PCI-DSS": "Req 4.1",
 			},
@@ -404,8 +412,8 @@ func (c *PCIDSSChecks) CheckReq4_EncryptionInTransit(ctx context.Context) []Chec
 			RemediationDetail: "Replace HTTP with HTTPS, FTP with SFTP, Telnet with SSH",
 			Priority: PriorityCritical,
 			ScreenshotGuide: "EC2 → Security Groups → Show no HTTP/FTP/Telnet ports open",
-			ConsoleURL: "https://console.aws.amazon.com/ec2/v2/home#SecurityGroups",
-			Timestamp: time.Now(),
+			ConsoleURL: consoleURL("ec2/v2/home#SecurityGroups", consoleRegion(ctx)),
+			Timestamp: nowFunc(),
 			Frameworks: map[string]string{
 				"PCI-DSS": "Req 4.1, 4.1.1",
 			},
@@ -417,7 +425,9 @@ func (c *PCIDSSChecks) CheckReq4_EncryptionInTransit(ctx context.Context) []Chec
 			Status:    "PASS",
 			Evidence:  "No unencrypted protocols exposed to internet",
 			Priority:  PriorityInfo,
-			Timestamp: time.Now(),
+			ScreenshotGuide: "EC2 → Security Groups → Show no HTTP/FTP/Telnet ports open",
+			ConsoleURL: consoleURL("ec2/v2/home#SecurityGroups", consoleRegion(ctx)),
+			Timestamp: nowFunc(),
 			Frameworks: map[string]string{
 				"PCI-DSS": "Req 4.1",
 			},
@@ -458,7 +468,7 @@ func (c *PCIDSSChecks) CheckReq4_EncryptionInTransit(ctx context.Context) []Chec
 			Remediation: "Add bucket policy requiring SecureTransport",
 			RemediationDetail: "Add condition: {\"Bool\": {\"aws:SecureTransport\": \"true\"}}",
 			Priority: PriorityHigh,
-			Timestamp: time.Now(),
+			Timestamp: nowFunc(),
 			Frameworks: map[string]string{
 				"PCI-DSS": "Req 4.1",
 			},
@@ -482,7 +492,7 @@ func (c *PCIDSSChecks) CheckReq6_SecureSystems(ctx context.Context) []CheckResul
 			Status:    "ERROR",
 			Evidence:  fmt.Sprintf("Unable to check EC2 instances: %v", err),
 			Priority:  PriorityHigh,
-			Timestamp: time.Now(),
+			Timestamp: nowFunc(),
 			Frameworks: map[string]string{
 				"PCI-DSS": "Req 6.2",
 			},
@@ -522,8 +532,8 @@ func (c *PCIDSSChecks) CheckReq6_SecureSystems(ctx context.Context) []CheckResul
 			RemediationDetail: "Install SSM agent and enable Patch Manager",
 			Priority: PriorityHigh,
 			ScreenshotGuide: "Systems Manager → Managed Instances → Show all instances managed",
-			ConsoleURL: "https://console.aws.amazon.com/systems-manager/",
-			Timestamp: time.Now(),
+			ConsoleURL: consoleURL("systems-manager/", consoleRegion(ctx)),
+			Timestamp: nowFunc(),
 			Frameworks: map[string]string{
 				"PCI-DSS": "Req 6.2",
 			},
@@ -564,7 +574,7 @@ func (c *PCIDSSChecks) CheckReq6_SecureSystems(ctx context.Context) []CheckResul
 			Remediation: "Deploy AWS WAF for web applications",
 			RemediationDetail: "PCI requires WAF or regular code reviews for public-facing web apps",
 			Priority: PriorityMedium,
-			Timestamp: time.Now(),
+			Timestamp: nowFunc(),
 			Frameworks: map[string]string{
 				"PCI-DSS": "Req 6.4.7",
 			},
@@ -588,7 +598,7 @@ func (c *PCIDSSChecks) CheckReq7_AccessControl(ctx context.Context) []CheckResul
 			Status:    "ERROR",
 			Evidence:  fmt.Sprintf("Unable to check IAM users: %v", err),
 			Priority:  PriorityHigh,
-			Timestamp: time.Now(),
+			Timestamp: nowFunc(),
 			Frameworks: map[string]string{
 				"PCI-DSS": "Req 7.1",
 			},
@@ -623,8 +633,8 @@ func (c *PCIDSSChecks) CheckReq7_AccessControl(ctx context.Context) []CheckResul
 			RemediationDetail: "Review each user and apply minimal required permissions",
 			Priority: PriorityHigh,
 			ScreenshotGuide: "IAM → Users → Permissions → Show restricted policies only",
-			ConsoleURL: "https://console.aws.amazon.com/iam/home#/users",
-			Timestamp: time.Now(),
+			ConsoleURL: consoleURL("iam/home#/users", consoleRegion(ctx)),
+			Timestamp: nowFunc(),
 			Frameworks: map[string]string{
 				"PCI-DSS": "Req 7.1, 7.1.2",
 			},
@@ -640,7 +650,7 @@ func (c *PCIDSSChecks) CheckReq7_AccessControl(ctx context.Context) []CheckResul
 		Remediation: "Implement role-based access control",
 		RemediationDetail: "Separate Dev, Ops, and Security IAM groups with distinct permissions",
 		Priority: PriorityMedium,
-		Timestamp: time.Now(),
+		Timestamp: nowFunc(),
 		Frameworks: map[string]string{
 			"PCI-DSS": "Req 7.1.2",
 		},
@@ -667,8 +677,8 @@ func (c *PCIDSSChecks) CheckReq8_Authentication(ctx context.Context) []CheckResu
 			RemediationDetail: "aws iam update-account-password-policy --max-password-age 90",
 			Priority: PriorityCritical,
 			ScreenshotGuide: "IAM → Account settings → Password policy → Must show 90 days or less",
-			ConsoleURL: "https://console.aws.amazon.com/iam/home#/account_settings",
-			Timestamp: time.Now(),
+			ConsoleURL: consoleURL("iam/home#/account_settings", consoleRegion(ctx)),
+			Timestamp: nowFunc(),
 			Frameworks: map[string]string{
 				"PCI-DSS": "Req 8.2.4",
 			},
@@ -691,8 +701,8 @@ func (c *PCIDSSChecks) CheckReq8_Authentication(ctx context.Context) []CheckResu
 				RemediationDetail: "aws iam update-account-password-policy --max-password-age 90",
 				Priority: PriorityCritical,
 				ScreenshotGuide: "IAM → Account settings → Password policy → Show 90 days max",
-				ConsoleURL: "https://console.aws.amazon.com/iam/home#/account_settings",
-				Timestamp: time.Now(),
+				ConsoleURL: consoleURL("iam/home#/account_settings", consoleRegion(ctx)),
+				Timestamp: nowFunc(),
 				Frameworks: map[string]string{
 					"PCI-DSS": "Req 8.2.4",
 				},
@@ -704,7 +714,9 @@ func (c *PCIDSSChecks) CheckReq8_Authentication(ctx context.Context) []CheckResu
 				Status:    "PASS",
 				Evidence:  fmt.Sprintf("Password expiry set to %d days (PCI compliant)", maxAge),
 				Priority:  PriorityInfo,
-				Timestamp: time.Now(),
+				ScreenshotGuide: "IAM → Account settings → Password policy → Show 90 days max",
+				ConsoleURL: consoleURL("iam/home#/account_settings", consoleRegion(ctx)),
+				Timestamp: nowFunc(),
 				Frameworks: map[string]string{
 					"PCI-DSS": "Req 8.2.4",
 				},
@@ -723,7 +735,7 @@ func (c *PCIDSSChecks) CheckReq8_Authentication(ctx context.Context) []CheckResu
 				Remediation: "Set to 7+ characters",
 				RemediationDetail: "aws iam update-account-password-policy --minimum-password-length 7",
 				Priority: PriorityHigh,
-				Timestamp: time.Now(),
+				Timestamp: nowFunc(),
 				Frameworks: map[string]string{
 					"PCI-DSS": "Req 8.2.3",
 				},
@@ -735,7 +747,9 @@ func (c *PCIDSSChecks) CheckReq8_Authentication(ctx context.Context) []CheckResu
 				Status:    "PASS",
 				Evidence:  fmt.Sprintf("Password length %d chars meets PCI requirement (7+)", minLength),
 				Priority:  PriorityInfo,
-				Timestamp: time.Now(),
+				ScreenshotGuide: "IAM → Account settings → Password policy → Show minimum length 7+",
+				ConsoleURL: consoleURL("iam/home#/account_settings", consoleRegion(ctx)),
+				Timestamp: nowFunc(),
 				Frameworks: map[string]string{
 					"PCI-DSS": "Req 8.2.3",
 				},
@@ -754,7 +768,7 @@ func (c *PCIDSSChecks) CheckReq8_Authentication(ctx context.Context) []CheckResu
 				Remediation: "Set password history to 4+",
 				RemediationDetail: "aws iam update-account-password-policy --password-reuse-prevention 4",
 				Priority: PriorityHigh,
-				Timestamp: time.Now(),
+				Timestamp: nowFunc(),
 				Frameworks: map[string]string{
 					"PCI-DSS": "Req 8.2.5",
 				},
@@ -771,7 +785,7 @@ func (c *PCIDSSChecks) CheckReq8_Authentication(ctx context.Context) []CheckResu
 			Remediation: "Implement account lockout after 6 failed attempts",
 			RemediationDetail: "Use CloudWatch Events + Lambda to track failed logins and disable accounts",
 			Priority: PriorityMedium,
-			Timestamp: time.Now(),
+			Timestamp: nowFunc(),
 			Frameworks: map[string]string{
 				"PCI-DSS": "Req 8.1.6",
 			},
@@ -788,7 +802,7 @@ func (c *PCIDSSChecks) CheckReq8_Authentication(ctx context.Context) []CheckResu
 			Status:    "ERROR",
 			Evidence:  fmt.Sprintf("Unable to check IAM users: %v", err),
 			Priority:  PriorityCritical,
-			Timestamp: time.Now(),
+			Timestamp: nowFunc(),
 			Frameworks: map[string]string{
 				"PCI-DSS": "Req 8.3.1",
 			},
@@ -832,8 +846,8 @@ func (c *PCIDSSChecks) CheckReq8_Authentication(ctx context.Context) []CheckResu
 			RemediationDetail: "Every user with console access MUST have MFA - no exceptions for PCI",
 			Priority: PriorityCritical,
 			ScreenshotGuide: "IAM → Users → Show MFA enabled for ALL users with console access",
-			ConsoleURL: "https://console.aws.amazon.com/iam/home#/users",
-			Timestamp: time.Now(),
+			ConsoleURL: consoleURL("iam/home#/users", consoleRegion(ctx)),
+			Timestamp: nowFunc(),
 			Frameworks: map[string]string{
 				"PCI-DSS": "Req 8.3.1",
 			},
@@ -845,7 +859,9 @@ func (c *PCIDSSChecks) CheckReq8_Authentication(ctx context.Context) []CheckResu
 			Status:    "PASS",
 			Evidence:  "All users with console access have MFA enabled",
 			Priority:  PriorityInfo,
-			Timestamp: time.Now(),
+			ScreenshotGuide: "IAM → Users → Show MFA enabled for ALL users with console access",
+			ConsoleURL: consoleURL("iam/home#/users", consoleRegion(ctx)),
+			Timestamp: nowFunc(),
 			Frameworks: map[string]string{
 				"PCI-DSS": "Req 8.3.1",
 			},
@@ -887,8 +903,8 @@ func (c *PCIDSSChecks) CheckReq8_Authentication(ctx context.Context) []CheckResu
 			RemediationDetail: "aws iam create-access-key --user-name <user> && aws iam delete-access-key --access-key-id <old-key>",
 			Priority: PriorityCritical,
 			ScreenshotGuide: "IAM → Users → Security credentials → Show all keys < 90 days old",
-			ConsoleURL: "https://console.aws.amazon.com/iam/home#/users",
-			Timestamp: time.Now(),
+			ConsoleURL: consoleURL("iam/home#/users", consoleRegion(ctx)),
+			Timestamp: nowFunc(),
 			Frameworks: map[string]string{
 				"PCI-DSS": "Req 8.2.4",
 			},
@@ -900,7 +916,9 @@ func (c *PCIDSSChecks) CheckReq8_Authentication(ctx context.Context) []CheckResu
 			Status:    "PASS",
 			Evidence:  "All access keys rotated within 90 days",
 			Priority:  PriorityInfo,
-			Timestamp: time.Now(),
+			ScreenshotGuide: "IAM → Users → Security credentials → Show all keys < 90 days old",
+			ConsoleURL: consoleURL("iam/home#/users", consoleRegion(ctx)),
+			Timestamp: nowFunc(),
 			Frameworks: map[string]string{
 				"PCI-DSS": "Req 8.2.4",
 			},
@@ -917,7 +935,7 @@ func (c *PCIDSSChecks) CheckReq8_Authentication(ctx context.Context) []CheckResu
 		RemediationDetail: "IAM → Account settings → Console session timeout → 15 minutes",
 		Priority: PriorityMedium,
 		ScreenshotGuide: "IAM → Account settings → Show 15-minute session timeout configured",
-		Timestamp: time.Now(),
+		Timestamp: nowFunc(),
 		Frameworks: map[string]string{
 			"PCI-DSS": "Req 8.1.8",
 		},
@@ -940,7 +958,7 @@ func (c *PCIDSSChecks) CheckReq10_Logging(ctx context.Context) []CheckResult {
 			Status:    "ERROR",
 			Evidence:  fmt.Sprintf("Unable to check CloudTrail: %v", err),
 			Priority:  PriorityCritical,
-			Timestamp: time.Now(),
+			Timestamp: nowFunc(),
 			Frameworks: map[string]string{
 				"PCI-DSS": "Req 10.1",
 			},
@@ -958,8 +976,8 @@ func (c *PCIDSSChecks) CheckReq10_Logging(ctx context.Context) []CheckResult {
 			RemediationDetail: "aws cloudtrail create-trail --name pci-audit-trail --s3-bucket-name <bucket> --is-multi-region-trail",
 			Priority: PriorityCritical,
 			ScreenshotGuide: "CloudTrail → Dashboard → Show trail enabled for all regions",
-			ConsoleURL: "https://console.aws.amazon.com/cloudtrail/",
-			Timestamp: time.Now(),
+			ConsoleURL: consoleURL("cloudtrail/", consoleRegion(ctx)),
+			Timestamp: nowFunc(),
 			Frameworks: map[string]string{
 				"PCI-DSS": "Req 10.1, 10.2.1",
 			},
@@ -979,7 +997,9 @@ func (c *PCIDSSChecks) CheckReq10_Logging(ctx context.Context) []CheckResult {
 			Status:    "PASS",
 			Evidence:  fmt.Sprintf("%d CloudTrail(s) configured", len(trails.Trails)),
 			Priority:  PriorityInfo,
-			Timestamp: time.Now(),
+			ScreenshotGuide: "CloudTrail → Dashboard → Show trail enabled for all regions",
+			ConsoleURL: consoleURL("cloudtrail/", consoleRegion(ctx)),
+			Timestamp: nowFunc(),
 			Frameworks: map[string]string{
 				"PCI-DSS": "Req 10.1, 10.2.1",
 			},
@@ -995,8 +1015,8 @@ func (c *PCIDSSChecks) CheckReq10_Logging(ctx context.Context) []CheckResult {
 			RemediationDetail: "1. Go to S3 bucket with CloudTrail logs\n2. Create lifecycle policy\n3. Transition to Glacier after 90 days\n4. Delete after 365+ days",
 			Priority: PriorityHigh,
 			ScreenshotGuide: "S3 → CloudTrail bucket → Management → Lifecycle rules → Show 365+ day retention",
-			ConsoleURL: "https://s3.console.aws.amazon.com/s3/",
-			Timestamp: time.Now(),
+			ConsoleURL: consoleURL("s3/", consoleRegion(ctx)),
+			Timestamp: nowFunc(),
 			Frameworks: map[string]string{
 				"PCI-DSS": "Req 10.5.3",
 			},
@@ -1011,7 +1031,7 @@ func (c *PCIDSSChecks) CheckReq10_Logging(ctx context.Context) []CheckResult {
 			Remediation: "Enable log file validation",
 			RemediationDetail: "aws cloudtrail update-trail --name <trail-name> --enable-log-file-validation",
 			Priority: PriorityMedium,
-			Timestamp: time.Now(),
+			Timestamp: nowFunc(),
 			Frameworks: map[string]string{
 				"PCI-DSS": "Req 10.5.2, 10.5.5",
 			},
@@ -1026,7 +1046,7 @@ func (c *PCIDSSChecks) CheckReq10_Logging(ctx context.Context) []CheckResult {
 			Remediation: "Ensure all EC2 instances use NTP",
 			RemediationDetail: "Configure chrony or ntpd on all instances",
 			Priority: PriorityMedium,
-			Timestamp: time.Now(),
+			Timestamp: nowFunc(),
 			Frameworks: map[string]string{
 				"PCI-DSS": "Req 10.4",
 			},
@@ -1050,7 +1070,7 @@ func (c *PCIDSSChecks) CheckReq11_SecurityTesting(ctx context.Context) []CheckRe
 			Status:    "ERROR",
 			Evidence:  fmt.Sprintf("Unable to check AWS Config: %v", err),
 			Priority:  PriorityHigh,
-			Timestamp: time.Now(),
+			Timestamp: nowFunc(),
 			Frameworks: map[string]string{
 				"PCI-DSS": "Req 11.5.1",
 			},
@@ -1068,8 +1088,8 @@ func (c *PCIDSSChecks) CheckReq11_SecurityTesting(ctx context.Context) []CheckRe
 			RemediationDetail: "aws configservice put-configuration-recorder --configuration-recorder name=default,roleArn=<role-arn>",
 			Priority: PriorityHigh,
 			ScreenshotGuide: "AWS Config → Settings → Show recorder enabled",
-			ConsoleURL: "https://console.aws.amazon.com/config/",
-			Timestamp: time.Now(),
+			ConsoleURL: consoleURL("config/", consoleRegion(ctx)),
+			Timestamp: nowFunc(),
 			Frameworks: map[string]string{
 				"PCI-DSS": "Req 11.5.1",
 			},
@@ -1081,7 +1101,9 @@ func (c *PCIDSSChecks) CheckReq11_SecurityTesting(ctx context.Context) []CheckRe
 			Status:    "PASS",
 			Evidence:  "AWS Config enabled for change detection",
 			Priority:  PriorityInfo,
-			Timestamp: time.Now(),
+			ScreenshotGuide: "AWS Config → Settings → Show recorder enabled",
+			ConsoleURL: consoleURL("config/", consoleRegion(ctx)),
+			Timestamp: nowFunc(),
 			Frameworks: map[string]string{
 				"PCI-DSS": "Req 11.5.1",
 			},
@@ -1098,7 +1120,7 @@ func (c *PCIDSSChecks) CheckReq11_SecurityTesting(ctx context.Context) []CheckRe
 		RemediationDetail: "1. Engage PCI-approved ASV\n2. Schedule quarterly external scans\n3. Internal scans can use AWS Inspector",
 		Priority: PriorityMedium,
 		ScreenshotGuide: "Document ASV scan reports dated within last 90 days",
-		Timestamp: time.Now(),
+		Timestamp: nowFunc(),
 		Frameworks: map[string]string{
 			"PCI-DSS": "Req 11.2.2",
 		},
@@ -1113,7 +1135,7 @@ func (c *PCIDSSChecks) CheckReq11_SecurityTesting(ctx context.Context) []CheckRe
 		Remediation: "Schedule annual pentest",
 		RemediationDetail: "Annual external and internal penetration testing required",
 		Priority: PriorityMedium,
-		Timestamp: time.Now(),
+		Timestamp: nowFunc(),
 		Frameworks: map[string]string{
 			"PCI-DSS": "Req 11.3.1",
 		},
@@ -1128,7 +1150,7 @@ func (c *PCIDSSChecks) CheckReq11_SecurityTesting(ctx context.Context) []CheckRe
 		Remediation: "Implement FIM solution",
 		RemediationDetail: "Use AWS Systems Manager or third-party FIM tools",
 		Priority: PriorityMedium,
-		Timestamp: time.Now(),
+		Timestamp: nowFunc(),
 		Frameworks: map[string]string{
 			"PCI-DSS": "Req 11.5",
 		},
@@ -1151,8 +1173,8 @@ func (c *PCIDSSChecks) CheckReq5_MalwareProtection(ctx context.Context) []CheckR
 		RemediationDetail: "1. Deploy endpoint protection (Amazon GuardDuty for runtime, third-party for OS-level)\n2. Ensure anti-malware is active and up-to-date\n3. Configure automatic updates and periodic scans\n4. Document anti-malware solution and update schedule",
 		Priority: PriorityHigh,
 		ScreenshotGuide: "Security Console → Show anti-malware deployed on all systems with current definitions",
-		ConsoleURL: "https://console.aws.amazon.com/guardduty/",
-		Timestamp: time.Now(),
+		ConsoleURL: consoleURL("guardduty/", consoleRegion(ctx)),
+		Timestamp: nowFunc(),
 		Frameworks: map[string]string{
 			"PCI-DSS": "Req 5.1, 5.2.1",
 		},
@@ -1167,7 +1189,7 @@ func (c *PCIDSSChecks) CheckReq5_MalwareProtection(ctx context.Context) []CheckR
 		RemediationDetail: "Configure automatic signature updates and verify audit logs show active scanning",
 		Priority: PriorityMedium,
 		ScreenshotGuide: "Anti-malware console → Show automatic updates enabled and recent scan logs",
-		Timestamp: time.Now(),
+		Timestamp: nowFunc(),
 		Frameworks: map[string]string{
 			"PCI-DSS": "Req 5.2.3, 5.3.1",
 		},
@@ -1182,7 +1204,7 @@ func (c *PCIDSSChecks) CheckReq5_MalwareProtection(ctx context.Context) []CheckR
 		RemediationDetail: "1. Enable logging for all anti-malware events\n2. Configure log retention (minimum per Req 10)\n3. Establish periodic review process\n4. Document review findings",
 		Priority: PriorityMedium,
 		ScreenshotGuide: "Show anti-malware logs with retention policy and review documentation",
-		Timestamp: time.Now(),
+		Timestamp: nowFunc(),
 		Frameworks: map[string]string{
 			"PCI-DSS": "Req 5.3.2, 5.3.4",
 		},
@@ -1205,8 +1227,8 @@ func (c *PCIDSSChecks) CheckReq9_PhysicalAccess(ctx context.Context) []CheckResu
 		RemediationDetail: "1. Review AWS PCI-DSS Attestation of Compliance (AOC)\n2. Download AWS PCI-DSS Responsibility Matrix from AWS Artifact\n3. Document inherited physical controls in your compliance documentation\n4. Focus on your organizational physical security for offices/facilities with cardholder data access",
 		Priority: PriorityMedium,
 		ScreenshotGuide: "AWS Artifact → Download PCI-DSS AOC showing AWS physical security controls",
-		ConsoleURL: "https://console.aws.amazon.com/artifact/home",
-		Timestamp: time.Now(),
+		ConsoleURL: consoleURL("artifact/home", consoleRegion(ctx)),
+		Timestamp: nowFunc(),
 		Frameworks: map[string]string{
 			"PCI-DSS": "Req 9.1, 9.1.1",
 		},
@@ -1221,7 +1243,7 @@ func (c *PCIDSSChecks) CheckReq9_PhysicalAccess(ctx context.Context) []CheckResu
 		RemediationDetail: "1. Implement badge/access card system for facility entry\n2. Establish visitor log procedures\n3. Differentiate badges for employees vs visitors\n4. Require escort for visitors in sensitive areas\n5. Document all procedures",
 		Priority: PriorityMedium,
 		ScreenshotGuide: "Document physical access control procedures, visitor logs, and badge system",
-		Timestamp: time.Now(),
+		Timestamp: nowFunc(),
 		Frameworks: map[string]string{
 			"PCI-DSS": "Req 9.2, 9.3",
 		},
@@ -1236,7 +1258,7 @@ func (c *PCIDSSChecks) CheckReq9_PhysicalAccess(ctx context.Context) []CheckResu
 		RemediationDetail: "1. Store backup media in secure, locked location\n2. Maintain inventory of all media with cardholder data\n3. Review media inventory at least annually\n4. Securely destroy media when no longer needed (Req 9.8)",
 		Priority: PriorityMedium,
 		ScreenshotGuide: "Show backup media inventory, secure storage documentation, and destruction procedures",
-		Timestamp: time.Now(),
+		Timestamp: nowFunc(),
 		Frameworks: map[string]string{
 			"PCI-DSS": "Req 9.4, 9.5, 9.8",
 		},
@@ -1251,7 +1273,7 @@ func (c *PCIDSSChecks) CheckReq9_PhysicalAccess(ctx context.Context) []CheckResu
 		RemediationDetail: "1. Maintain inventory of POI devices\n2. Inspect devices regularly for tampering\n3. Train personnel to be aware of suspicious behavior\n4. Document inspection procedures and findings",
 		Priority: PriorityMedium,
 		ScreenshotGuide: "Document POI device inventory, inspection schedules, and training records",
-		Timestamp: time.Now(),
+		Timestamp: nowFunc(),
 		Frameworks: map[string]string{
 			"PCI-DSS": "Req 9.9, 9.9.1",
 		},
@@ -1274,7 +1296,7 @@ func (c *PCIDSSChecks) CheckReq12_SecurityPolicy(ctx context.Context) []CheckRes
 		RemediationDetail: "1. Establish security policy addressing PCI-DSS requirements\n2. Review policy at least annually\n3. Update when environment changes\n4. Communicate to all relevant personnel\n5. Document policy review and approval",
 		Priority: PriorityHigh,
 		ScreenshotGuide: "Document current security policy, annual review dates, and communication records",
-		Timestamp: time.Now(),
+		Timestamp: nowFunc(),
 		Frameworks: map[string]string{
 			"PCI-DSS": "Req 12.1, 12.1.1",
 		},
@@ -1289,7 +1311,7 @@ func (c *PCIDSSChecks) CheckReq12_SecurityPolicy(ctx context.Context) []CheckRes
 		RemediationDetail: "1. Perform formal risk assessment at least annually\n2. Identify critical assets and threats\n3. Assess likelihood and impact\n4. Document risk assessment results\n5. Update after significant infrastructure changes",
 		Priority: PriorityHigh,
 		ScreenshotGuide: "Document risk assessments with dates, findings, and mitigation plans",
-		Timestamp: time.Now(),
+		Timestamp: nowFunc(),
 		Frameworks: map[string]string{
 			"PCI-DSS": "Req 12.2",
 		},
@@ -1304,7 +1326,7 @@ func (c *PCIDSSChecks) CheckReq12_SecurityPolicy(ctx context.Context) []CheckRes
 		RemediationDetail: "1. Define acceptable use for all critical technologies\n2. Require management approval for use of technologies\n3. Require authentication for use of technology\n4. Maintain list of authorized devices and personnel\n5. Document acceptable use policies",
 		Priority: PriorityMedium,
 		ScreenshotGuide: "Document acceptable use policies, approval records, and technology inventory",
-		Timestamp: time.Now(),
+		Timestamp: nowFunc(),
 		Frameworks: map[string]string{
 			"PCI-DSS": "Req 12.3",
 		},
@@ -1319,7 +1341,7 @@ func (c *PCIDSSChecks) CheckReq12_SecurityPolicy(ctx context.Context) []CheckRes
 		RemediationDetail: "1. Formally assign information security responsibilities\n2. Define roles and responsibilities for PCI-DSS compliance\n3. Document organizational structure for security\n4. Ensure adequate resources allocated",
 		Priority: PriorityHigh,
 		ScreenshotGuide: "Document organizational chart showing security responsibilities and role assignments",
-		Timestamp: time.Now(),
+		Timestamp: nowFunc(),
 		Frameworks: map[string]string{
 			"PCI-DSS": "Req 12.5, 12.5.1",
 		},
@@ -1334,7 +1356,7 @@ func (c *PCIDSSChecks) CheckReq12_SecurityPolicy(ctx context.Context) []CheckRes
 		RemediationDetail: "1. Provide security awareness training upon hire and at least annually\n2. Train personnel on their responsibilities for protecting cardholder data\n3. Require personnel acknowledge understanding\n4. Document training completion and acknowledgments",
 		Priority: PriorityHigh,
 		ScreenshotGuide: "Document training program, completion records, and acknowledgment forms",
-		Timestamp: time.Now(),
+		Timestamp: nowFunc(),
 		Frameworks: map[string]string{
 			"PCI-DSS": "Req 12.6, 12.6.1, 12.6.2",
 		},
@@ -1349,7 +1371,7 @@ func (c *PCIDSSChecks) CheckReq12_SecurityPolicy(ctx context.Context) []CheckRes
 		RemediationDetail: "1. Maintain list of service providers\n2. Establish written agreement including PCI-DSS responsibilities\n3. Ensure service providers acknowledge responsibility\n4. Monitor service provider PCI-DSS compliance status at least annually",
 		Priority: PriorityHigh,
 		ScreenshotGuide: "Document service provider list, contracts, and annual compliance verification",
-		Timestamp: time.Now(),
+		Timestamp: nowFunc(),
 		Frameworks: map[string]string{
 			"PCI-DSS": "Req 12.8, 12.8.1, 12.8.2",
 		},
@@ -1364,7 +1386,7 @@ func (c *PCIDSSChecks) CheckReq12_SecurityPolicy(ctx context.Context) []CheckRes
 		RemediationDetail: "1. Create incident response plan\n2. Assign roles and responsibilities\n3. Include specific incident response procedures\n4. Test plan at least annually\n5. Update plan based on test results and industry developments",
 		Priority: PriorityHigh,
 		ScreenshotGuide: "Document incident response plan, test results, and update history",
-		Timestamp: time.Now(),
+		Timestamp: nowFunc(),
 		Frameworks: map[string]string{
 			"PCI-DSS": "Req 12.10, 12.10.1",
 		},
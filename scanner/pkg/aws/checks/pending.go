@@ -0,0 +1,25 @@
+package checks
+
+import "fmt"
+
+// withPendingFix annotates a failing resource in an Evidence list with the
+// modification AWS has queued to fix it, e.g. "analytics (encryption
+// enablement pending)", so operators can see the fix is in flight. The
+// resource keeps failing until the modification is applied, and
+// AffectedResources keeps the bare ID so queuing a fix does not change the
+// finding. An empty fix returns id unchanged.
+func withPendingFix(id, fix string) string {
+	if fix == "" {
+		return id
+	}
+	return fmt.Sprintf("%s (%s pending)", id, fix)
+}
+
+// pendingRetentionFix describes a queued backup retention change that meets
+// minDays, or returns "" if none is queued or it still falls short
+func pendingRetentionFix(pending *int32, minDays int32) string {
+	if pending == nil || *pending < minDays {
+		return ""
+	}
+	return fmt.Sprintf("retention change to %d days", *pending)
+}
@@ -0,0 +1,85 @@
+package checks
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Scan profiles trade coverage for speed. ProfileQuick runs only the checks
+// answered by list-level calls, so its API usage barely grows with the
+// account; it is a first posture estimate for very large accounts, not a
+// complete assessment.
+const (
+	ProfileDeep  = "deep"  // every check, including per-resource inspection (the default)
+	ProfileQuick = "quick" // list-level checks only; checks marked Deep in the manifest are skipped
+)
+
+// errCheckNotInProfile is returned by runCheck for checks the scan profile
+// leaves out; Run methods drop it like any other check error
+var errCheckNotInProfile = errors.New("check not in scan profile")
+
+// ParseScanProfile validates a profile name; empty is ProfileDeep
+func ParseScanProfile(name string) (string, error) {
+	switch profile := strings.ToLower(strings.TrimSpace(name)); profile {
+	case "", ProfileDeep:
+		return ProfileDeep, nil
+	case ProfileQuick:
+		return ProfileQuick, nil
+	default:
+		return "", fmt.Errorf("unknown scan profile %q (want %s or %s)", name, ProfileQuick, ProfileDeep)
+	}
+}
+
+// SetScanProfile selects the checks subsequent runs without a Scan execute.
+// Unknown names run every check, as ProfileDeep does.
+func SetScanProfile(profile string) {
+	defaultScan.setProfile(profile)
+}
+
+func (s *Scan) setProfile(profile string) {
+	s.configMu.Lock()
+	defer s.configMu.Unlock()
+
+	s.profile = ProfileDeep
+	if profile == ProfileQuick {
+		s.profile = ProfileQuick
+	}
+}
+
+// ScanProfile returns the profile set by SetScanProfile
+func ScanProfile() string {
+	return defaultScan.Profile()
+}
+
+// Profile returns the scan's profile, ProfileQuick or ProfileDeep
+func (s *Scan) Profile() string {
+	s.configMu.Lock()
+	defer s.configMu.Unlock()
+	return s.profile
+}
+
+// ProfileCheckIDs returns the IDs of the checks profile runs, sorted
+func ProfileCheckIDs(profile string) []string {
+	ids := []string{}
+	for _, entry := range checkManifest {
+		if profile == ProfileQuick && entry.Deep {
+			continue
+		}
+		ids = append(ids, entry.ID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// deepChecks are the manifest IDs marked Deep
+var deepChecks = func() map[string]bool {
+	deep := map[string]bool{}
+	for _, entry := range checkManifest {
+		if entry.Deep {
+			deep[entry.ID] = true
+		}
+	}
+	return deep
+}()
@@ -0,0 +1,189 @@
+package checks
+
+import (
+	"sort"
+	"strings"
+)
+
+// ResultQuery is a composable filter over check results, built with Query:
+//
+//	critical := checks.Query(results).
+//		WithSeverity("CRITICAL").
+//		WithRegion("us-east-1").
+//		WithService("redshift").
+//		Results()
+//
+// Each With method returns a new query, so a base query can be narrowed in
+// several directions without the branches affecting each other. Values within
+// one With call are alternatives; separate calls must all match.
+type ResultQuery struct {
+	results    []CheckResult
+	predicates []func(CheckResult) bool
+	bySeverity bool
+}
+
+// Query starts a query over results
+func Query(results []CheckResult) ResultQuery {
+	return ResultQuery{results: results}
+}
+
+// Where keeps results for which keep returns true
+func (q ResultQuery) Where(keep func(CheckResult) bool) ResultQuery {
+	predicates := make([]func(CheckResult) bool, 0, len(q.predicates)+1)
+	predicates = append(predicates, q.predicates...)
+	q.predicates = append(predicates, keep)
+	return q
+}
+
+// WithSeverity keeps results with one of severities (case-insensitive)
+func (q ResultQuery) WithSeverity(severities ...string) ResultQuery {
+	return q.Where(func(r CheckResult) bool {
+		return matchesAny(r.Severity, severities, strings.EqualFold)
+	})
+}
+
+// WithStatus keeps results with one of statuses (case-insensitive)
+func (q ResultQuery) WithStatus(statuses ...string) ResultQuery {
+	return q.Where(func(r CheckResult) bool {
+		return matchesAny(r.Status, statuses, strings.EqualFold)
+	})
+}
+
+// WithRegion keeps results scanned in one of regions (see ResultRegion);
+// results without a region never match.
+func (q ResultQuery) WithRegion(regions ...string) ResultQuery {
+	return q.Where(func(r CheckResult) bool {
+		return matchesAny(ResultRegion(r), regions, strings.EqualFold)
+	})
+}
+
+// WithService keeps results whose check module name contains one of services,
+// ignoring case, so "redshift" matches "Redshift Security (us-east-1)"
+func (q ResultQuery) WithService(services ...string) ResultQuery {
+	return q.Where(func(r CheckResult) bool {
+		return matchesAny(r.Service, services, func(service, want string) bool {
+			return strings.Contains(strings.ToLower(service), strings.ToLower(want))
+		})
+	})
+}
+
+// WithControl keeps results for one of controls, e.g. "CC6.1"
+func (q ResultQuery) WithControl(controls ...string) ResultQuery {
+	return q.Where(func(r CheckResult) bool {
+		return matchesAny(r.Control, controls, strings.EqualFold)
+	})
+}
+
+// WithEnvironment keeps results classified into one of environments, e.g.
+// "prod" (see NormalizeEnvironment). Unclassified results never match.
+func (q ResultQuery) WithEnvironment(environments ...string) ResultQuery {
+	return q.Where(func(r CheckResult) bool {
+		return matchesAny(r.Environment, environments, func(env, want string) bool {
+			return env != "" && env == NormalizeEnvironment(want)
+		})
+	})
+}
+
+// OrderBySeverity sorts the results most severe first, keeping SortResults
+// order within a severity. Results with no severity come last.
+func (q ResultQuery) OrderBySeverity() ResultQuery {
+	q.bySeverity = true
+	return q
+}
+
+// Results runs the query and returns the matching results in input order, or
+// by severity after OrderBySeverity. The input slice is not modified.
+func (q ResultQuery) Results() []CheckResult {
+	matched := []CheckResult{}
+	for _, r := range q.results {
+		if q.matches(r) {
+			matched = append(matched, r)
+		}
+	}
+
+	if q.bySeverity {
+		SortResults(matched)
+		sort.SliceStable(matched, func(i, j int) bool {
+			return querySeverityRank(matched[i].Severity) > querySeverityRank(matched[j].Severity)
+		})
+	}
+	return matched
+}
+
+// Count returns how many results match
+func (q ResultQuery) Count() int {
+	count := 0
+	for _, r := range q.results {
+		if q.matches(r) {
+			count++
+		}
+	}
+	return count
+}
+
+func (q ResultQuery) matches(r CheckResult) bool {
+	for _, keep := range q.predicates {
+		if !keep(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// ResultRegion returns the region a result was scanned in: its Region, or for
+// results recorded before Region was, the region a multi-region Runner put in
+// its Service, "us-east-1" for "Redshift Security (us-east-1)". It is "" if
+// neither names one.
+func ResultRegion(r CheckResult) string {
+	if r.Region != "" {
+		return r.Region
+	}
+	if !strings.HasSuffix(r.Service, ")") {
+		return ""
+	}
+	open := strings.LastIndex(r.Service, " (")
+	if open < 0 {
+		return ""
+	}
+	return r.Service[open+2 : len(r.Service)-1]
+}
+
+// ResultService returns the check module that produced a result, its Service
+// without the region a multi-region Runner appends: "Redshift Security" for
+// "Redshift Security (us-east-1)"
+func ResultService(r CheckResult) string {
+	region := ResultRegion(r)
+	if region == "" {
+		return r.Service
+	}
+	return strings.TrimSuffix(r.Service, " ("+region+")")
+}
+
+// matchesAny reports whether value matches one of wanted under match. An
+// empty wanted list matches everything, like FilterByStatus.
+func matchesAny(value string, wanted []string, match func(value, want string) bool) bool {
+	if len(wanted) == 0 {
+		return true
+	}
+	for _, want := range wanted {
+		if match(value, want) {
+			return true
+		}
+	}
+	return false
+}
+
+// querySeverityRank orders severities for OrderBySeverity
+func querySeverityRank(severity string) int {
+	switch strings.ToUpper(severity) {
+	case "CRITICAL":
+		return 4
+	case "HIGH":
+		return 3
+	case "MEDIUM":
+		return 2
+	case "LOW":
+		return 1
+	}
+	return 0
+}
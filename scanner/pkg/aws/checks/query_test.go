@@ -0,0 +1,97 @@
+package checks
+
+import (
+	"reflect"
+	"testing"
+)
+
+func queryTestResults() []CheckResult {
+	return []CheckResult{
+		{Control: "CC6.1", Name: "S3 Block Public Access", Service: "S3 Security (us-east-1)", Status: "FAIL", Severity: "HIGH"},
+		{Control: "CC6.1", Name: "Redshift Public Access", Service: "Redshift Security (us-east-1)", Status: "FAIL", Severity: "CRITICAL"},
+		{Control: "CC6.3", Name: "Redshift Encryption", Service: "Redshift Security (eu-west-1)", Status: "FAIL", Severity: "LOW"},
+		{Control: "CC7.2", Name: "CloudTrail Enabled", Service: "CloudTrail", Status: "PASS"},
+		{Control: "CC6.7", Name: "Redshift TLS", Service: "Redshift Security (us-east-1)", Status: "FAIL", Severity: "medium"},
+	}
+}
+
+func resultNames(results []CheckResult) []string {
+	names := []string{}
+	for _, r := range results {
+		names = append(names, r.Name)
+	}
+	return names
+}
+
+func TestResultQueryBranchesAreIndependent(t *testing.T) {
+	// Two predicates first, so a shared backing array would have spare
+	// capacity for the branches to overwrite each other's last predicate
+	base := Query(queryTestResults()).WithStatus("FAIL").WithService("redshift")
+
+	critical := base.WithSeverity("CRITICAL")
+	europe := base.WithRegion("eu-west-1")
+
+	if got, want := resultNames(critical.Results()), []string{"Redshift Public Access"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("critical branch: got %v, want %v", got, want)
+	}
+	if got, want := resultNames(europe.Results()), []string{"Redshift Encryption"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("europe branch: got %v, want %v", got, want)
+	}
+	if got := base.Count(); got != 3 {
+		t.Errorf("base query matches %d results after branching, want 3", got)
+	}
+	base.OrderBySeverity()
+	if base.bySeverity {
+		t.Error("OrderBySeverity changed the base query")
+	}
+}
+
+func TestResultRegion(t *testing.T) {
+	tests := map[string]string{
+		"Redshift Security (us-east-1)":      "us-east-1",
+		"S3 Security (ap-southeast-2)":       "ap-southeast-2",
+		"Network (VPC) Security (eu-west-1)": "eu-west-1",
+		"CloudTrail":                         "",
+		"Redshift Security(us-east-1)":       "",
+		"Redshift Security (us-east-1":       "",
+		"":                                   "",
+	}
+	for service, want := range tests {
+		if got := ResultRegion(CheckResult{Service: service}); got != want {
+			t.Errorf("ResultRegion(%q) = %q, want %q", service, got, want)
+		}
+	}
+}
+
+func TestResultQueryOrderBySeverity(t *testing.T) {
+	results := queryTestResults()
+	input := append([]CheckResult(nil), results...)
+
+	got := resultNames(Query(results).OrderBySeverity().Results())
+	want := []string{
+		"Redshift Public Access",
+		"S3 Block Public Access",
+		"Redshift TLS",
+		"Redshift Encryption",
+		"CloudTrail Enabled",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if !reflect.DeepEqual(results, input) {
+		t.Error("OrderBySeverity reordered the input slice")
+	}
+}
+
+func TestResultQueryOrderBySeverityKeepsSortOrderWithinSeverity(t *testing.T) {
+	results := []CheckResult{
+		{Control: "CC6.3", Name: "B", Service: "S3 Security", Severity: "HIGH"},
+		{Control: "CC6.1", Name: "A", Service: "S3 Security", Severity: "HIGH"},
+		{Control: "CC6.1", Name: "C", Service: "EC2 Security", Severity: "HIGH"},
+	}
+
+	got := resultNames(Query(results).OrderBySeverity().Results())
+	if want := []string{"C", "A", "B"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
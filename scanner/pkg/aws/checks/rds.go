@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/rds"
-	"time"
 )
 
 type RDSChecks struct {
@@ -24,28 +23,28 @@ func (c *RDSChecks) Run(ctx context.Context) ([]CheckResult, error) {
 	results := []CheckResult{}
 
 	// Existing checks
-	if result, err := c.CheckRDSEncryption(ctx); err == nil {
+	if result, err := runCheck(ctx, "rds.encryption", c.CheckRDSEncryption); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckRDSPublicAccess(ctx); err == nil {
+	if result, err := runCheck(ctx, "rds.public_access", c.CheckRDSPublicAccess); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckRDSBackups(ctx); err == nil {
+	if result, err := runCheck(ctx, "rds.backups", c.CheckRDSBackups); err == nil {
 		results = append(results, result)
 	}
 
 	// NEW CIS checks
-	if result, err := c.CheckRDSMinorVersionUpgrade(ctx); err == nil {
+	if result, err := runCheck(ctx, "rds.minor_version_upgrade", c.CheckRDSMinorVersionUpgrade); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckRDSMultiAZ(ctx); err == nil {
+	if result, err := runCheck(ctx, "rds.multi_az", c.CheckRDSMultiAZ); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckRDSDeletionProtection(ctx); err == nil {
+	if result, err := runCheck(ctx, "rds.deletion_protection", c.CheckRDSDeletionProtection); err == nil {
 		results = append(results, result)
 	}
 
@@ -74,13 +73,15 @@ func (c *RDSChecks) CheckRDSEncryption(ctx context.Context) (CheckResult, error)
 			Name:              "RDS Encryption at Rest",
 			Status:            "FAIL",
 			Severity:          "CRITICAL",
-			Evidence:          fmt.Sprintf("%d RDS instances NOT encrypted: %v", len(unencrypted), unencrypted),
+			Evidence:          fmt.Sprintf("%d RDS instances NOT encrypted: %s", len(unencrypted), TruncateList(unencrypted, evidenceListLimit(ctx))),
+			AffectedResources: unencrypted,
 			Remediation:       "Enable RDS encryption (requires snapshot & restore)",
 			RemediationDetail: "1. Create snapshot: aws rds create-db-snapshot --db-instance-identifier [DB_ID] --db-snapshot-identifier [SNAP_ID]\n2. Copy with encryption: aws rds copy-db-snapshot --source-db-snapshot-identifier [SNAP_ID] --target-db-snapshot-identifier [ENCRYPTED_SNAP] --kms-key-id [KEY_ID]\n3. Restore from encrypted snapshot",
+			RequiresRecreate:  true,
 			ScreenshotGuide:   "RDS Console → Instance → Configuration → Screenshot showing 'Encryption: Enabled'",
-			ConsoleURL:        "https://console.aws.amazon.com/rds/",
+			ConsoleURL:        consoleURL("rds/", consoleRegion(ctx)),
 			Priority:          PriorityCritical,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("RDS_ENCRYPTION"),
 		}, nil
 	}
@@ -91,20 +92,23 @@ func (c *RDSChecks) CheckRDSEncryption(ctx context.Context) (CheckResult, error)
 			Name:       "RDS Encryption at Rest",
 			Status:     "PASS",
 			Evidence:   "No RDS instances found",
+			ConsoleURL: consoleURL("rds/", consoleRegion(ctx)),
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("RDS_ENCRYPTION"),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "CC6.3",
-		Name:       "RDS Encryption at Rest",
-		Status:     "PASS",
-		Evidence:   fmt.Sprintf("All %d RDS instances are encrypted", len(instances.DBInstances)),
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("RDS_ENCRYPTION"),
+		Control:         "CC6.3",
+		Name:            "RDS Encryption at Rest",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d RDS instances are encrypted", len(instances.DBInstances)),
+		ScreenshotGuide: "RDS Console → Instance → Configuration → Screenshot showing 'Encryption: Enabled'",
+		ConsoleURL:      consoleURL("rds/", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("RDS_ENCRYPTION"),
 	}, nil
 }
 
@@ -128,13 +132,14 @@ func (c *RDSChecks) CheckRDSPublicAccess(ctx context.Context) (CheckResult, erro
 			Name:              "RDS Public Access",
 			Status:            "FAIL",
 			Severity:          "CRITICAL",
-			Evidence:          fmt.Sprintf("%d RDS instances are publicly accessible: %v", len(publiclyAccessible), publiclyAccessible),
+			Evidence:          fmt.Sprintf("%d RDS instances are publicly accessible: %s", len(publiclyAccessible), TruncateList(publiclyAccessible, evidenceListLimit(ctx))),
+			AffectedResources: publiclyAccessible,
 			Remediation:       "Disable public access on RDS instances",
 			RemediationDetail: fmt.Sprintf("aws rds modify-db-instance --db-instance-identifier %s --no-publicly-accessible --apply-immediately", publiclyAccessible[0]),
 			ScreenshotGuide:   "RDS Console → Instance → Connectivity & security → Screenshot showing 'Publicly accessible: No'",
-			ConsoleURL:        "https://console.aws.amazon.com/rds/",
+			ConsoleURL:        consoleURL("rds/", consoleRegion(ctx)),
 			Priority:          PriorityCritical,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("RDS_PUBLIC_ACCESS"),
 		}, nil
 	}
@@ -144,8 +149,9 @@ func (c *RDSChecks) CheckRDSPublicAccess(ctx context.Context) (CheckResult, erro
 		Name:       "RDS Public Access",
 		Status:     "PASS",
 		Evidence:   "No RDS instances are publicly accessible",
+		ConsoleURL: consoleURL("rds/", consoleRegion(ctx)),
 		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
+		Timestamp:  nowFunc(),
 		Frameworks: GetFrameworkMappings("RDS_PUBLIC_ACCESS"),
 	}, nil
 }
@@ -157,12 +163,18 @@ func (c *RDSChecks) CheckRDSBackups(ctx context.Context) (CheckResult, error) {
 	}
 
 	noBackups := []string{}
+	noBackupsListed := []string{}
 
 	for _, instance := range instances.DBInstances {
 		dbName := aws.ToString(instance.DBInstanceIdentifier)
 		// Check backup retention (PCI DSS requires 7+ days)
 		if aws.ToInt32(instance.BackupRetentionPeriod) < 7 {
-			noBackups = append(noBackups, fmt.Sprintf("%s (%d days)", dbName, aws.ToInt32(instance.BackupRetentionPeriod)))
+			fix := ""
+			if instance.PendingModifiedValues != nil {
+				fix = pendingRetentionFix(instance.PendingModifiedValues.BackupRetentionPeriod, 7)
+			}
+			noBackups = append(noBackups, dbName)
+			noBackupsListed = append(noBackupsListed, withPendingFix(fmt.Sprintf("%s (%d days)", dbName, aws.ToInt32(instance.BackupRetentionPeriod)), fix))
 		}
 	}
 
@@ -172,25 +184,28 @@ func (c *RDSChecks) CheckRDSBackups(ctx context.Context) (CheckResult, error) {
 			Name:              "RDS Backup Retention",
 			Status:            "FAIL",
 			Severity:          "HIGH",
-			Evidence:          fmt.Sprintf("%d RDS instances have <7 day backup retention: %v", len(noBackups), noBackups),
+			Evidence:          fmt.Sprintf("%d RDS instances have <7 day backup retention: %s", len(noBackups), TruncateList(noBackupsListed, evidenceListLimit(ctx))),
+			AffectedResources: noBackups,
 			Remediation:       "Set backup retention to 7+ days (30 recommended)",
 			RemediationDetail: fmt.Sprintf("aws rds modify-db-instance --db-instance-identifier [DB_ID] --backup-retention-period 30 --apply-immediately"),
 			ScreenshotGuide:   "RDS Console → Instance → Maintenance & backups → Screenshot showing 'Backup retention period: 7 days or more'",
-			ConsoleURL:        "https://console.aws.amazon.com/rds/",
+			ConsoleURL:        consoleURL("rds/", consoleRegion(ctx)),
 			Priority:          PriorityHigh,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("RDS_BACKUP"),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "A1.2",
-		Name:       "RDS Backup Retention",
-		Status:     "PASS",
-		Evidence:   "All RDS instances have adequate backup retention",
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("RDS_BACKUP"),
+		Control:         "A1.2",
+		Name:            "RDS Backup Retention",
+		Status:          "PASS",
+		Evidence:        "All RDS instances have adequate backup retention",
+		ScreenshotGuide: "RDS Console → Instance → Maintenance & backups → Screenshot showing 'Backup retention period: 7 days or more'",
+		ConsoleURL:      consoleURL("rds/", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("RDS_BACKUP"),
 	}, nil
 }
 
@@ -217,25 +232,28 @@ func (c *RDSChecks) CheckRDSMinorVersionUpgrade(ctx context.Context) (CheckResul
 			Name:              "RDS Automatic Minor Version Upgrade",
 			Status:            "FAIL",
 			Severity:          "MEDIUM",
-			Evidence:          fmt.Sprintf("%d RDS instances don't have auto minor version upgrade: %v", len(noAutoUpgrade), noAutoUpgrade),
+			Evidence:          fmt.Sprintf("%d RDS instances don't have auto minor version upgrade: %s", len(noAutoUpgrade), TruncateList(noAutoUpgrade, evidenceListLimit(ctx))),
+			AffectedResources: noAutoUpgrade,
 			Remediation:       "Enable automatic minor version upgrades for security patches",
 			RemediationDetail: fmt.Sprintf("aws rds modify-db-instance --db-instance-identifier %s --auto-minor-version-upgrade --apply-immediately", noAutoUpgrade[0]),
 			ScreenshotGuide:   "RDS Console → Instance → Maintenance & backups → Screenshot showing 'Auto minor version upgrade: Yes'",
-			ConsoleURL:        "https://console.aws.amazon.com/rds/",
+			ConsoleURL:        consoleURL("rds/", consoleRegion(ctx)),
 			Priority:          PriorityMedium,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("RDS_MINOR_UPGRADE"),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "[CIS-2.3.2]",
-		Name:       "RDS Automatic Minor Version Upgrade",
-		Status:     "PASS",
-		Evidence:   "All RDS instances have automatic minor version upgrade enabled",
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("RDS_MINOR_UPGRADE"),
+		Control:         "[CIS-2.3.2]",
+		Name:            "RDS Automatic Minor Version Upgrade",
+		Status:          "PASS",
+		Evidence:        "All RDS instances have automatic minor version upgrade enabled",
+		ScreenshotGuide: "RDS Console → Instance → Maintenance & backups → Screenshot showing 'Auto minor version upgrade: Yes'",
+		ConsoleURL:      consoleURL("rds/", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("RDS_MINOR_UPGRADE"),
 	}, nil
 }
 
@@ -247,10 +265,16 @@ func (c *RDSChecks) CheckRDSMultiAZ(ctx context.Context) (CheckResult, error) {
 	}
 
 	noMultiAZ := []string{}
+	noMultiAZListed := []string{}
 
 	for _, instance := range instances.DBInstances {
 		if !aws.ToBool(instance.MultiAZ) {
+			fix := ""
+			if instance.PendingModifiedValues != nil && aws.ToBool(instance.PendingModifiedValues.MultiAZ) {
+				fix = "Multi-AZ conversion"
+			}
 			noMultiAZ = append(noMultiAZ, aws.ToString(instance.DBInstanceIdentifier))
+			noMultiAZListed = append(noMultiAZListed, withPendingFix(aws.ToString(instance.DBInstanceIdentifier), fix))
 		}
 	}
 
@@ -260,25 +284,28 @@ func (c *RDSChecks) CheckRDSMultiAZ(ctx context.Context) (CheckResult, error) {
 			Name:              "RDS Multi-AZ Deployment",
 			Status:            "FAIL",
 			Severity:          "MEDIUM",
-			Evidence:          fmt.Sprintf("%d RDS instances not using Multi-AZ: %v", len(noMultiAZ), noMultiAZ),
+			Evidence:          fmt.Sprintf("%d RDS instances not using Multi-AZ: %s", len(noMultiAZ), TruncateList(noMultiAZListed, evidenceListLimit(ctx))),
+			AffectedResources: noMultiAZ,
 			Remediation:       "Enable Multi-AZ for high availability",
 			RemediationDetail: fmt.Sprintf("aws rds modify-db-instance --db-instance-identifier %s --multi-az --apply-immediately", noMultiAZ[0]),
 			ScreenshotGuide:   "RDS Console → Instance → Configuration → Screenshot showing 'Multi-AZ: Yes'",
-			ConsoleURL:        "https://console.aws.amazon.com/rds/",
+			ConsoleURL:        consoleURL("rds/", consoleRegion(ctx)),
 			Priority:          PriorityMedium,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("RDS_MULTI_AZ"),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "[CIS-2.3.4]",
-		Name:       "RDS Multi-AZ Deployment",
-		Status:     "PASS",
-		Evidence:   "All RDS instances use Multi-AZ deployment",
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("RDS_MULTI_AZ"),
+		Control:         "[CIS-2.3.4]",
+		Name:            "RDS Multi-AZ Deployment",
+		Status:          "PASS",
+		Evidence:        "All RDS instances use Multi-AZ deployment",
+		ScreenshotGuide: "RDS Console → Instance → Configuration → Screenshot showing 'Multi-AZ: Yes'",
+		ConsoleURL:      consoleURL("rds/", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("RDS_MULTI_AZ"),
 	}, nil
 }
 
@@ -303,24 +330,27 @@ func (c *RDSChecks) CheckRDSDeletionProtection(ctx context.Context) (CheckResult
 			Name:              "RDS Deletion Protection",
 			Status:            "FAIL",
 			Severity:          "MEDIUM",
-			Evidence:          fmt.Sprintf("%d RDS instances lack deletion protection: %v", len(noDeletionProtection), noDeletionProtection),
+			Evidence:          fmt.Sprintf("%d RDS instances lack deletion protection: %s", len(noDeletionProtection), TruncateList(noDeletionProtection, evidenceListLimit(ctx))),
+			AffectedResources: noDeletionProtection,
 			Remediation:       "Enable deletion protection to prevent accidental deletion",
 			RemediationDetail: fmt.Sprintf("aws rds modify-db-instance --db-instance-identifier %s --deletion-protection --apply-immediately", noDeletionProtection[0]),
 			ScreenshotGuide:   "RDS Console → Instance → Configuration → Screenshot showing 'Deletion protection: Enabled'",
-			ConsoleURL:        "https://console.aws.amazon.com/rds/",
+			ConsoleURL:        consoleURL("rds/", consoleRegion(ctx)),
 			Priority:          PriorityMedium,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("RDS_DELETION_PROTECTION"),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "[CIS-2.3.5]",
-		Name:       "RDS Deletion Protection",
-		Status:     "PASS",
-		Evidence:   "All RDS instances have deletion protection enabled",
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("RDS_DELETION_PROTECTION"),
+		Control:         "[CIS-2.3.5]",
+		Name:            "RDS Deletion Protection",
+		Status:          "PASS",
+		Evidence:        "All RDS instances have deletion protection enabled",
+		ScreenshotGuide: "RDS Console → Instance → Configuration → Screenshot showing 'Deletion protection: Enabled'",
+		ConsoleURL:      consoleURL("rds/", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("RDS_DELETION_PROTECTION"),
 	}, nil
 }
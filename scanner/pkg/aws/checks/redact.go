@@ -0,0 +1,256 @@
+package checks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// RedactOptions selects the identifiers Redact masks. Redaction is opt-in:
+// nothing is masked unless a caller asks for it, typically before sharing a
+// report with an external auditor.
+type RedactOptions struct {
+	AccountIDs    bool // 12-digit AWS account IDs
+	ARNs          bool // whole ARNs, including the account and resource they name
+	ResourceNames bool // resource names listed in Evidence, wherever they appear in the result
+	Regions       bool // region codes such as us-east-1
+
+	// HashKey, when set, makes each token a keyed hash of the identifier, so
+	// the same resource gets the same token in every report redacted with the
+	// same key. Otherwise tokens are numbered by first appearance and are only
+	// consistent within one report.
+	HashKey string
+}
+
+// DefaultRedactOptions masks account IDs, ARNs and resource names but keeps
+// regions, which rarely identify an account and which reviewers need to read
+// multi-region findings
+var DefaultRedactOptions = RedactOptions{AccountIDs: true, ARNs: true, ResourceNames: true}
+
+var (
+	redactARN       = regexp.MustCompile(`arn:aws[a-z-]*:[^\s\[\](),"']+`)
+	redactAccountID = regexp.MustCompile(`\b\d{12}\b`)
+	redactRegion    = regexp.MustCompile(`\b[a-z]{2}(?:-gov|-iso[a-z]*)?-(?:north|south|east|west|central|northeast|northwest|southeast|southwest)-\d\b`)
+
+	// redactWord matches a resource-name-shaped word: names are compared and
+	// replaced whole, never inside a longer name
+	redactWord = regexp.MustCompile(`[A-Za-z0-9](?:[A-Za-z0-9_.\-/]*[A-Za-z0-9_])?`)
+
+	// redactDetailName matches the identifiers checks name in a list item's
+	// detail, after its leading resource name: the bucket of an "s3://" URI,
+	// the IAM role in "(role name: ...)", and EC2 and VPC resource IDs such
+	// as security groups, subnets and instances
+	redactDetailName = regexp.MustCompile(`s3://([a-z0-9][a-z0-9.\-]*[a-z0-9])|\brole ([A-Za-z0-9](?:[A-Za-z0-9_.\-]*[A-Za-z0-9_])?)|\b((?:sg|vpc|subnet|i|vol|snap|ami|eni|igw|nat|rtb|acl|pcx|vpce|tgw)-[0-9a-f]{8,17})\b`)
+)
+
+// Redactor masks identifiers consistently across every string it is given,
+// so one resource maps to one token throughout a report
+type Redactor struct {
+	options RedactOptions
+	tokens  map[string]string // identifier to token
+	counts  map[string]int    // tokens issued per kind
+	names   map[string]bool   // resource names collected from Evidence lists
+}
+
+// NewRedactor creates a Redactor masking what options select
+func NewRedactor(options RedactOptions) *Redactor {
+	return &Redactor{
+		options: options,
+		tokens:  map[string]string{},
+		counts:  map[string]int{},
+		names:   map[string]bool{},
+	}
+}
+
+// Redact returns a copy of results with the identifiers options selects
+// masked in Service, Evidence, AffectedResources, RemediationDetail and
+// ConsoleURL. Statuses, severities, counts and the wording of the evidence
+// are left alone, so a redacted finding still reads as the same finding.
+func Redact(results []CheckResult, options RedactOptions) []CheckResult {
+	return NewRedactor(options).Results(results)
+}
+
+// Results redacts results with the tokens issued so far, adding tokens for
+// new identifiers. Resource names are collected from every result's Evidence
+// first, so a name is masked even where it appears before its listing.
+func (r *Redactor) Results(results []CheckResult) []CheckResult {
+	if r.options.ResourceNames {
+		for _, result := range results {
+			r.collectNames(result.Evidence)
+			for _, resource := range result.AffectedResources {
+				r.collectName(resource)
+			}
+		}
+	}
+
+	redacted := make([]CheckResult, len(results))
+	for i, result := range results {
+		result.Service = r.String(result.Service)
+		result.Region = r.String(result.Region)
+		result.AccountID = r.String(result.AccountID)
+		result.Evidence = r.String(result.Evidence)
+		if result.AffectedResources != nil {
+			affected := make([]string, len(result.AffectedResources))
+			for j, resource := range result.AffectedResources {
+				affected[j] = r.String(resource)
+			}
+			result.AffectedResources = affected
+		}
+		result.RemediationDetail = r.String(result.RemediationDetail)
+		result.ConsoleURL = r.String(result.ConsoleURL)
+		redacted[i] = result
+	}
+	return redacted
+}
+
+// String redacts s: ARNs first, since they contain the other identifiers,
+// then collected resource names, which may embed an account ID or region,
+// then account IDs and regions
+func (r *Redactor) String(s string) string {
+	if s == "" {
+		return s
+	}
+	if r.options.ARNs {
+		s = redactARN.ReplaceAllStringFunc(s, func(arn string) string { return r.token("arn", arn) })
+	}
+	if r.options.ResourceNames && len(r.names) > 0 {
+		s = redactWord.ReplaceAllStringFunc(s, func(word string) string {
+			if !r.names[word] {
+				return word
+			}
+			return r.token("resource", word)
+		})
+	}
+	if r.options.AccountIDs {
+		s = redactAccountID.ReplaceAllStringFunc(s, func(id string) string { return r.token("account", id) })
+	}
+	if r.options.Regions {
+		s = redactRegion.ReplaceAllStringFunc(s, func(region string) string { return r.token("region", region) })
+	}
+	return s
+}
+
+// token returns the token for identifier, issuing one of kind if it has none
+func (r *Redactor) token(kind, identifier string) string {
+	key := kind + "|" + identifier
+	if token, ok := r.tokens[key]; ok {
+		return token
+	}
+
+	var token string
+	if r.options.HashKey != "" {
+		mac := hmac.New(sha256.New, []byte(r.options.HashKey))
+		mac.Write([]byte(key))
+		token = fmt.Sprintf("<%s-%s>", kind, hex.EncodeToString(mac.Sum(nil))[:8])
+	} else {
+		r.counts[kind]++
+		token = fmt.Sprintf("<%s-%d>", kind, r.counts[kind])
+	}
+	r.tokens[key] = token
+	return token
+}
+
+// collectNames records the resource names in evidence's TruncateList lists,
+// the "[a b c]" that follows a colon. Each item's first word is its name; the
+// rest, like "(redis 5.0.6)" or "→ s3://logs (no versioning)", is detail that
+// may name further resources (see collectName). ARNs and bare account IDs are
+// left to their own patterns.
+func (r *Redactor) collectNames(evidence string) {
+	for _, list := range evidenceLists(evidence) {
+		for _, item := range splitListItems(list) {
+			r.collectName(item)
+		}
+	}
+}
+
+// collectName records the resource name that starts a list item or an
+// AffectedResources entry, and the buckets, IAM roles and resource IDs its
+// detail names, e.g. "analytics", "audit-logs" and "sg-0a1b2c3d" from
+// "analytics → s3://audit-logs (shared with sg-0a1b2c3d)"
+func (r *Redactor) collectName(item string) {
+	if loc := redactARN.FindStringIndex(item); loc == nil || loc[0] != 0 {
+		if name := redactWord.FindString(item); name != "" && strings.HasPrefix(item, name) {
+			r.addName(name)
+		}
+	}
+	for _, match := range redactDetailName.FindAllStringSubmatch(item, -1) {
+		for _, name := range match[1:] {
+			r.addName(name)
+		}
+	}
+}
+
+// addName records name as a resource name to mask, unless it is empty or a
+// bare account ID, which the account ID pattern masks
+func (r *Redactor) addName(name string) {
+	if name == "" || redactAccountID.FindString(name) == name {
+		return
+	}
+	r.names[name] = true
+}
+
+// evidenceLists returns the contents of the top-level bracketed lists in
+// evidence that follow a colon, as TruncateList lists do. Brackets used as
+// labels, like "[HIGH] 3 buckets", are not lists.
+func evidenceLists(evidence string) []string {
+	lists := []string{}
+	depth, start := 0, -1
+	for i, ch := range evidence {
+		switch ch {
+		case '[':
+			if depth == 0 && strings.HasSuffix(strings.TrimRight(evidence[:i], " "), ":") {
+				start = i + 1
+			}
+			depth++
+		case ']':
+			if depth == 0 {
+				continue
+			}
+			depth--
+			if depth == 0 && start >= 0 {
+				lists = append(lists, evidence[start:i])
+				start = -1
+			}
+		}
+	}
+	return lists
+}
+
+// splitListItems splits a TruncateList list into items at the spaces that
+// are not inside parentheses or brackets. Words that open with "(" or "[",
+// and an arrow with the word after it, as in "cluster → s3://bucket", belong
+// to the item before them.
+func splitListItems(list string) []string {
+	items := []string{}
+	depth, start := 0, 0
+	add := func(word string) {
+		if word == "" {
+			return
+		}
+		if len(items) > 0 && (word[0] == '(' || word[0] == '[' || word == "→" || strings.HasSuffix(items[len(items)-1], " →")) {
+			items[len(items)-1] += " " + word
+			return
+		}
+		items = append(items, word)
+	}
+	for i, ch := range list {
+		switch ch {
+		case '(', '[':
+			depth++
+		case ')', ']':
+			if depth > 0 {
+				depth--
+			}
+		case ' ':
+			if depth == 0 {
+				add(list[start:i])
+				start = i + 1
+			}
+		}
+	}
+	add(list[start:])
+	return items
+}
@@ -0,0 +1,107 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestRedactMasksEveryIdentifierInListItems(t *testing.T) {
+	ctx := context.Background()
+	results := []CheckResult{
+		{
+			Control: "CC7.2",
+			Name:    "Redshift Audit Log Destination",
+			Status:  "FAIL",
+			Evidence: fmt.Sprintf("%d Redshift clusters write audit logs to an insecure S3 bucket: %s", 2, TruncateList([]string{
+				"analytics → s3://acme-audit-logs (public access not blocked, unencrypted)",
+				"reporting → s3://acme.reporting.logs (unencrypted)",
+			}, evidenceListLimit(ctx))),
+			AffectedResources: []string{"analytics", "reporting"},
+		},
+		{
+			Control: "CC6.3",
+			Name:    "Redshift IAM Role Scope",
+			Status:  "FAIL",
+			Evidence: fmt.Sprintf("%d Redshift cluster roles have overly permissive policies attached: %s", 1, TruncateList([]string{
+				"warehouse (role acme-redshift-etl: AmazonS3FullAccess)",
+			}, evidenceListLimit(ctx))),
+			AffectedResources: []string{"warehouse"},
+		},
+		{
+			Control: "CC6.1",
+			Name:    "SageMaker Notebook Root Access",
+			Status:  "FAIL",
+			Evidence: fmt.Sprintf("%d notebooks run with a role allowing every action on every resource: %s", 1, TruncateList([]string{
+				"research-nb (role acme-sagemaker-admin: AdministratorAccess)",
+			}, evidenceListLimit(ctx))),
+			AffectedResources: []string{"research-nb"},
+		},
+		{
+			Control: "CC6.6",
+			Name:    "ElastiCache Security Group Ingress",
+			Status:  "FAIL",
+			Evidence: fmt.Sprintf("%d ElastiCache clusters allow ingress from 0.0.0.0/0 on the cache port: %s", 1, TruncateList([]string{
+				"sessions (sg-0a1b2c3d4e5f60718 port 6379)",
+			}, evidenceListLimit(ctx))),
+			AffectedResources: []string{"sessions"},
+		},
+		{
+			Control: "CIS-5.4",
+			Name:    "Default Security Group",
+			Status:  "FAIL",
+			Evidence: fmt.Sprintf("%d default security groups allow traffic: %s", 1, TruncateList([]string{
+				"sg-11223344 (VPC: vpc-55667788)",
+			}, evidenceListLimit(ctx))),
+			AffectedResources: []string{"sg-11223344 (VPC: vpc-55667788)"},
+		},
+	}
+
+	identifiers := []string{
+		"analytics", "reporting", "acme-audit-logs", "acme.reporting.logs",
+		"warehouse", "acme-redshift-etl", "research-nb", "acme-sagemaker-admin",
+		"sessions", "sg-0a1b2c3d4e5f60718", "sg-11223344", "vpc-55667788",
+	}
+	redacted := Redact(results, DefaultRedactOptions)
+	for i, result := range redacted {
+		fields := append([]string{result.Evidence}, result.AffectedResources...)
+		for _, field := range fields {
+			for _, id := range identifiers {
+				if strings.Contains(field, id) {
+					t.Errorf("%s: %q still names %q", result.Name, field, id)
+				}
+			}
+		}
+		if result.Status != results[i].Status || !strings.HasPrefix(result.Evidence, strings.SplitN(results[i].Evidence, ":", 2)[0]) {
+			t.Errorf("%s: redaction changed the finding: %q", result.Name, result.Evidence)
+		}
+	}
+
+	// Managed policy names and ports are detail, not identifiers
+	for _, kept := range []string{"AmazonS3FullAccess", "AdministratorAccess", "port 6379", "s3://"} {
+		found := false
+		for _, result := range redacted {
+			found = found || strings.Contains(result.Evidence, kept)
+		}
+		if !found {
+			t.Errorf("redaction removed %q, which identifies nothing", kept)
+		}
+	}
+}
+
+func TestRedactMasksAResourceTheSameEverywhere(t *testing.T) {
+	results := []CheckResult{
+		{Name: "Redshift Audit Log Destination", Evidence: "1 Redshift clusters write audit logs to an insecure S3 bucket: [analytics → s3://acme-audit-logs (unencrypted)]"},
+		{Name: "S3 Encryption", Evidence: "1 buckets unencrypted: [acme-audit-logs]", AffectedResources: []string{"acme-audit-logs"}},
+	}
+
+	redacted := NewRedactor(DefaultRedactOptions).Results(results)
+	token := redacted[1].AffectedResources[0]
+	if token == "acme-audit-logs" {
+		t.Fatal("bucket not masked")
+	}
+	if !strings.Contains(redacted[0].Evidence, "s3://"+token) {
+		t.Errorf("audit log evidence %q should mask the bucket as %s, like the S3 finding", redacted[0].Evidence, token)
+	}
+}
@@ -3,18 +3,105 @@ package checks
 import (
 	"context"
 	"fmt"
-	"time"
+	"slices"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
 	"github.com/aws/aws-sdk-go-v2/service/redshift"
+	redshifttypes "github.com/aws/aws-sdk-go-v2/service/redshift/types"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
 )
 
 type RedshiftChecks struct {
-	client *redshift.Client
+	client    *redshift.Client
+	iamClient *iam.Client
+	s3Client  *s3.Client
+	ec2Client *ec2.Client
+
+	// clusters is the DescribeClusters output, fetched once and shared by
+	// every cluster check
+	clusters *redshift.DescribeClustersOutput
+
+	// loggingStatus caches DescribeLoggingStatus by cluster ID, shared by the
+	// logging checks
+	loggingStatus map[string]*redshift.DescribeLoggingStatusOutput
+
+	// transient lists clusters left out of clusters because they are being
+	// created or deleted
+	transient transientResources
+
+	// clusterIDs limits the checks to these clusters when set
+	clusterIDs []string
 }
 
-func NewRedshiftChecks(client *redshift.Client) *RedshiftChecks {
-	return &RedshiftChecks{client: client}
+func NewRedshiftChecks(client *redshift.Client, iamClient *iam.Client, s3Client *s3.Client, ec2Client *ec2.Client) *RedshiftChecks {
+	return &RedshiftChecks{
+		client:        client,
+		iamClient:     iamClient,
+		s3Client:      s3Client,
+		ec2Client:     ec2Client,
+		loggingStatus: map[string]*redshift.DescribeLoggingStatusOutput{},
+	}
+}
+
+// redshiftDefaultMasterUsername is the master user name the console suggests
+// when creating a cluster
+const redshiftDefaultMasterUsername = "awsuser"
+
+// redshiftBusinessHours are the UTC weekday hours a maintenance window should
+// not start in: patching then restarts the cluster while people are using it
+const (
+	redshiftBusinessHoursStart = 8
+	redshiftBusinessHoursEnd   = 18
+)
+
+// redshiftDefaultMaintenanceBlocks are the 8-hour UTC blocks, as minutes past
+// midnight, from which AWS picks a random 30-minute maintenance window when a
+// cluster is created without one
+var redshiftDefaultMaintenanceBlocks = map[string]int{
+	"us-east-1":      3 * 60,
+	"us-east-2":      3 * 60,
+	"us-west-1":      6 * 60,
+	"us-west-2":      6 * 60,
+	"ca-central-1":   3 * 60,
+	"sa-east-1":      0,
+	"eu-west-1":      22 * 60,
+	"eu-west-2":      22 * 60,
+	"eu-west-3":      23 * 60,
+	"eu-north-1":     23 * 60,
+	"eu-central-1":   20 * 60,
+	"ap-south-1":     16*60 + 30,
+	"ap-northeast-1": 13 * 60,
+	"ap-northeast-2": 13 * 60,
+	"ap-southeast-1": 14 * 60,
+	"ap-southeast-2": 12 * 60,
+	"ap-east-1":      13 * 60,
+	"us-gov-west-1":  6 * 60,
+}
+
+const (
+	redshiftDefaultBlockMinutes  = 8 * 60
+	redshiftDefaultWindowMinutes = 30
+)
+
+// redshiftOverlyPermissivePolicies are AWS managed policies that grant far more
+// than a Redshift cluster role needs for COPY/UNLOAD and Spectrum
+var redshiftOverlyPermissivePolicies = []string{
+	"AdministratorAccess",
+	"PowerUserAccess",
+	"IAMFullAccess",
+	"AmazonS3FullAccess",
+}
+
+// SetResourceFilter limits the checks to filter.RedshiftClusters. Only those
+// clusters are fetched and evaluated; an empty list checks every cluster.
+func (c *RedshiftChecks) SetResourceFilter(filter ResourceFilter) {
+	c.clusterIDs = filter.RedshiftClusters
+	c.clusters = nil
 }
 
 func (c *RedshiftChecks) Name() string {
@@ -24,50 +111,133 @@ func (c *RedshiftChecks) Name() string {
 func (c *RedshiftChecks) Run(ctx context.Context) ([]CheckResult, error) {
 	results := []CheckResult{}
 
-	if result, err := c.CheckClusterEncryption(ctx); err == nil {
+	// A named cluster that does not exist is an error, not an empty account
+	if len(c.clusterIDs) > 0 {
+		if _, err := c.describeClusters(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if result, err := runCheck(ctx, "redshift.cluster_encryption", c.CheckClusterEncryption); err == nil {
+		results = append(results, result)
+	}
+
+	if result, err := runCheck(ctx, "redshift.cluster_public_access", c.CheckClusterPublicAccess); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckClusterPublicAccess(ctx); err == nil {
+	if result, err := runCheck(ctx, "redshift.cluster_logging", c.CheckClusterLogging); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckClusterLogging(ctx); err == nil {
+	if result, err := runCheck(ctx, "redshift.logging_destination_secure", c.CheckLoggingDestinationSecure); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckClusterSSL(ctx); err == nil {
+	if result, err := runCheck(ctx, "redshift.cluster_ssl", c.CheckClusterSSL); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckClusterVersionUpgrade(ctx); err == nil {
+	if result, err := runCheck(ctx, "redshift.cluster_version_upgrade", c.CheckClusterVersionUpgrade); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckClusterBackupRetention(ctx); err == nil {
+	if result, err := runCheck(ctx, "redshift.cluster_backup_retention", c.CheckClusterBackupRetention); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckClusterEnhancedVPCRouting(ctx); err == nil {
+	if result, err := runCheck(ctx, "redshift.cluster_enhanced_vpc_routing", c.CheckClusterEnhancedVPCRouting); err == nil {
+		results = append(results, result)
+	}
+
+	if result, err := runCheck(ctx, "redshift.enhanced_vpc_routing_private_subnets", c.CheckEnhancedVPCRoutingPrivateSubnets); err == nil {
+		results = append(results, result)
+	}
+
+	if result, err := runCheck(ctx, "redshift.default_master_username", c.CheckDefaultMasterUsername); err == nil {
+		results = append(results, result)
+	}
+
+	if result, err := runCheck(ctx, "redshift.associated_iam_roles", c.CheckAssociatedIAMRoles); err == nil {
+		results = append(results, result)
+	}
+
+	if result, err := runCheck(ctx, "redshift.maintenance_window", c.CheckMaintenanceWindow); err == nil {
+		results = append(results, result)
+	}
+
+	if result, err := runCheck(ctx, "redshift.custom_parameter_group", c.CheckCustomParameterGroup); err == nil {
+		results = append(results, result)
+	}
+
+	if result, err := runCheck(ctx, "redshift.cross_region_snapshot_copy", c.CheckCrossRegionSnapshotCopy); err == nil {
+		results = append(results, result)
+	}
+
+	if result, err := runCheck(ctx, "redshift.expiring_resources", c.CheckExpiringResources); err == nil {
+		results = append(results, result)
+	}
+
+	if result, ok := c.transient.result(ctx, "Redshift", "Redshift clusters", "CC7.1"); ok {
 		results = append(results, result)
 	}
 
 	return results, nil
 }
 
+// EstimateCalls predicts one shared DescribeClusters plus the per-cluster
+// logging, parameter group and IAM role lookups. Logging buckets are counted
+// as one per cluster, the worst case, since they are only known after
+// DescribeLoggingStatus.
+func (c *RedshiftChecks) EstimateCalls(ctx context.Context) (CallEstimate, error) {
+	clusters, err := c.fetchClusters(ctx)
+	if err != nil {
+		return CallEstimate{}, err
+	}
+
+	calls := max(1, len(c.clusterIDs)) // DescribeClusters (once per filtered cluster), shared by every check
+	roles := map[string]bool{}
+	for _, cluster := range clusters.Clusters {
+		calls++    // DescribeLoggingStatus
+		calls += 2 // GetPublicAccessBlock and GetBucketEncryption on the logging bucket
+		if aws.ToBool(cluster.EnhancedVpcRouting) {
+			calls += 2 // DescribeClusterSubnetGroups and DescribeRouteTables, at most once each
+		}
+		calls += len(cluster.ClusterParameterGroups)
+		for _, role := range cluster.IamRoles {
+			roles[aws.ToString(role.IamRoleArn)] = true
+		}
+	}
+	calls += len(roles) // ListAttachedRolePolicies, once per distinct role
+	calls += 2          // DescribeReservedNodes and DescribeClusterSnapshots, first page
+
+	return CallEstimate{
+		Resources: len(clusters.Clusters),
+		Calls:     calls,
+	}, nil
+}
+
 func (c *RedshiftChecks) CheckClusterEncryption(ctx context.Context) (CheckResult, error) {
-	clusters, err := c.client.DescribeClusters(ctx, &redshift.DescribeClustersInput{})
+	clusters, err := c.describeClusters(ctx)
 	if err != nil {
 		return CheckResult{}, err
 	}
 
 	unencrypted := []string{}
+	unencryptedListed := []string{}
+	var envs resourceEnvironments
 
 	for _, cluster := range clusters.Clusters {
 		clusterID := aws.ToString(cluster.ClusterIdentifier)
 
 		if !aws.ToBool(cluster.Encrypted) {
+			fix := ""
+			if pending := redshiftPending(cluster); pending.EncryptionType != nil && !strings.EqualFold(*pending.EncryptionType, "NONE") {
+				fix = "encryption enablement"
+			}
 			unencrypted = append(unencrypted, clusterID)
+			unencryptedListed = append(unencryptedListed, withPendingFix(clusterID, fix))
+			envs.add(redshiftEnvironment(ctx, cluster))
 		}
 	}
 
@@ -77,13 +247,16 @@ func (c *RedshiftChecks) CheckClusterEncryption(ctx context.Context) (CheckResul
 			Name:              "Redshift Cluster Encryption",
 			Status:            "FAIL",
 			Severity:          "CRITICAL",
-			Evidence:          fmt.Sprintf("%d Redshift clusters NOT encrypted: %v", len(unencrypted), unencrypted),
+			Environment:       envs.environment(),
+			Evidence:          fmt.Sprintf("%d Redshift clusters NOT encrypted: %s", len(unencrypted), TruncateList(unencryptedListed, evidenceListLimit(ctx))),
+			AffectedResources: unencrypted,
 			Remediation:       "Enable encryption for Redshift clusters",
 			RemediationDetail: "1. Create snapshot of unencrypted cluster\n2. Restore snapshot with encryption enabled\n3. Update applications to use new endpoint\n4. Delete unencrypted cluster",
+			RequiresRecreate:  true,
 			ScreenshotGuide:   "Redshift Console → Clusters → Select cluster → Properties → Screenshot showing 'Encrypted: Yes'",
-			ConsoleURL:        "https://console.aws.amazon.com/redshiftv2/home#clusters",
+			ConsoleURL:        consoleURL("redshiftv2/home#clusters", consoleRegion(ctx)),
 			Priority:          PriorityCritical,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("REDSHIFT_ENCRYPTION"),
 		}, nil
 	}
@@ -94,36 +267,47 @@ func (c *RedshiftChecks) CheckClusterEncryption(ctx context.Context) (CheckResul
 			Name:       "Redshift Cluster Encryption",
 			Status:     "PASS",
 			Evidence:   "No Redshift clusters found",
+			ConsoleURL: consoleURL("redshiftv2/home#clusters", consoleRegion(ctx)),
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("REDSHIFT_ENCRYPTION"),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "CC6.3",
-		Name:       "Redshift Cluster Encryption",
-		Status:     "PASS",
-		Evidence:   fmt.Sprintf("All %d Redshift clusters are encrypted", len(clusters.Clusters)),
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("REDSHIFT_ENCRYPTION"),
+		Control:         "CC6.3",
+		Name:            "Redshift Cluster Encryption",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d Redshift clusters are encrypted", len(clusters.Clusters)),
+		ScreenshotGuide: "Redshift Console → Clusters → Select cluster → Properties → Screenshot showing 'Encrypted: Yes'",
+		ConsoleURL:      consoleURL("redshiftv2/home#clusters", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("REDSHIFT_ENCRYPTION"),
 	}, nil
 }
 
 func (c *RedshiftChecks) CheckClusterPublicAccess(ctx context.Context) (CheckResult, error) {
-	clusters, err := c.client.DescribeClusters(ctx, &redshift.DescribeClustersInput{})
+	clusters, err := c.describeClusters(ctx)
 	if err != nil {
 		return CheckResult{}, err
 	}
 
 	publicClusters := []string{}
+	publicListed := []string{}
+	var envs resourceEnvironments
 
 	for _, cluster := range clusters.Clusters {
 		clusterID := aws.ToString(cluster.ClusterIdentifier)
 
 		if aws.ToBool(cluster.PubliclyAccessible) {
+			fix := ""
+			if pending := redshiftPending(cluster); pending.PubliclyAccessible != nil && !*pending.PubliclyAccessible {
+				fix = "public access removal"
+			}
 			publicClusters = append(publicClusters, clusterID)
+			publicListed = append(publicListed, withPendingFix(clusterID, fix))
+			envs.add(redshiftEnvironment(ctx, cluster))
 		}
 	}
 
@@ -133,13 +317,15 @@ func (c *RedshiftChecks) CheckClusterPublicAccess(ctx context.Context) (CheckRes
 			Name:              "Redshift Public Access",
 			Status:            "FAIL",
 			Severity:          "CRITICAL",
-			Evidence:          fmt.Sprintf("%d Redshift clusters are publicly accessible: %v", len(publicClusters), publicClusters),
+			Environment:       envs.environment(),
+			Evidence:          fmt.Sprintf("%d Redshift clusters are publicly accessible: %s", len(publicClusters), TruncateList(publicListed, evidenceListLimit(ctx))),
+			AffectedResources: publicClusters,
 			Remediation:       "Disable public accessibility for Redshift clusters",
 			RemediationDetail: "aws redshift modify-cluster --cluster-identifier [CLUSTER_ID] --no-publicly-accessible",
 			ScreenshotGuide:   "Redshift Console → Clusters → Select cluster → Properties → Screenshot showing 'Publicly accessible: No'",
-			ConsoleURL:        "https://console.aws.amazon.com/redshiftv2/home#clusters",
+			ConsoleURL:        consoleURL("redshiftv2/home#clusters", consoleRegion(ctx)),
 			Priority:          PriorityCritical,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("REDSHIFT_NETWORK"),
 		}, nil
 	}
@@ -150,40 +336,43 @@ func (c *RedshiftChecks) CheckClusterPublicAccess(ctx context.Context) (CheckRes
 			Name:       "Redshift Public Access",
 			Status:     "PASS",
 			Evidence:   "No Redshift clusters found",
+			ConsoleURL: consoleURL("redshiftv2/home#clusters", consoleRegion(ctx)),
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("REDSHIFT_NETWORK"),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "CC6.1",
-		Name:       "Redshift Public Access",
-		Status:     "PASS",
-		Evidence:   fmt.Sprintf("All %d Redshift clusters are private (not publicly accessible)", len(clusters.Clusters)),
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("REDSHIFT_NETWORK"),
+		Control:         "CC6.1",
+		Name:            "Redshift Public Access",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d Redshift clusters are private (not publicly accessible)", len(clusters.Clusters)),
+		ScreenshotGuide: "Redshift Console → Clusters → Select cluster → Properties → Screenshot showing 'Publicly accessible: No'",
+		ConsoleURL:      consoleURL("redshiftv2/home#clusters", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("REDSHIFT_NETWORK"),
 	}, nil
 }
 
 func (c *RedshiftChecks) CheckClusterLogging(ctx context.Context) (CheckResult, error) {
-	clusters, err := c.client.DescribeClusters(ctx, &redshift.DescribeClustersInput{})
+	clusters, err := c.describeClusters(ctx)
 	if err != nil {
 		return CheckResult{}, err
 	}
 
 	noLogging := []string{}
+	var envs resourceEnvironments
 
 	for _, cluster := range clusters.Clusters {
 		clusterID := aws.ToString(cluster.ClusterIdentifier)
 
 		// Get logging status
-		logging, err := c.client.DescribeLoggingStatus(ctx, &redshift.DescribeLoggingStatusInput{
-			ClusterIdentifier: cluster.ClusterIdentifier,
-		})
+		logging, err := c.describeLoggingStatus(ctx, clusterID)
 		if err != nil || !aws.ToBool(logging.LoggingEnabled) {
 			noLogging = append(noLogging, clusterID)
+			envs.add(redshiftEnvironment(ctx, cluster))
 		}
 	}
 
@@ -193,13 +382,15 @@ func (c *RedshiftChecks) CheckClusterLogging(ctx context.Context) (CheckResult,
 			Name:              "Redshift Audit Logging",
 			Status:            "FAIL",
 			Severity:          "HIGH",
-			Evidence:          fmt.Sprintf("%d Redshift clusters without audit logging: %v", len(noLogging), noLogging),
+			Environment:       envs.environment(),
+			Evidence:          fmt.Sprintf("%d Redshift clusters without audit logging: %s", len(noLogging), TruncateList(noLogging, evidenceListLimit(ctx))),
+			AffectedResources: noLogging,
 			Remediation:       "Enable audit logging for Redshift clusters",
 			RemediationDetail: "aws redshift enable-logging --cluster-identifier [CLUSTER_ID] --bucket-name [S3_BUCKET] --s3-key-prefix 'redshift-logs/'",
 			ScreenshotGuide:   "Redshift Console → Clusters → Select cluster → Properties → Audit logging → Screenshot showing 'Enabled'",
-			ConsoleURL:        "https://console.aws.amazon.com/redshiftv2/home#clusters",
+			ConsoleURL:        consoleURL("redshiftv2/home#clusters", consoleRegion(ctx)),
 			Priority:          PriorityHigh,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("REDSHIFT_LOGGING"),
 		}, nil
 	}
@@ -210,30 +401,34 @@ func (c *RedshiftChecks) CheckClusterLogging(ctx context.Context) (CheckResult,
 			Name:       "Redshift Audit Logging",
 			Status:     "PASS",
 			Evidence:   "No Redshift clusters found",
+			ConsoleURL: consoleURL("redshiftv2/home#clusters", consoleRegion(ctx)),
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("REDSHIFT_LOGGING"),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "CC7.1",
-		Name:       "Redshift Audit Logging",
-		Status:     "PASS",
-		Evidence:   fmt.Sprintf("All %d Redshift clusters have audit logging enabled", len(clusters.Clusters)),
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("REDSHIFT_LOGGING"),
+		Control:         "CC7.1",
+		Name:            "Redshift Audit Logging",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d Redshift clusters have audit logging enabled", len(clusters.Clusters)),
+		ScreenshotGuide: "Redshift Console → Clusters → Select cluster → Properties → Audit logging → Screenshot showing 'Enabled'",
+		ConsoleURL:      consoleURL("redshiftv2/home#clusters", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("REDSHIFT_LOGGING"),
 	}, nil
 }
 
 func (c *RedshiftChecks) CheckClusterSSL(ctx context.Context) (CheckResult, error) {
-	clusters, err := c.client.DescribeClusters(ctx, &redshift.DescribeClustersInput{})
+	clusters, err := c.describeClusters(ctx)
 	if err != nil {
 		return CheckResult{}, err
 	}
 
 	noSSL := []string{}
+	var envs resourceEnvironments
 
 	for _, cluster := range clusters.Clusters {
 		clusterID := aws.ToString(cluster.ClusterIdentifier)
@@ -243,15 +438,13 @@ func (c *RedshiftChecks) CheckClusterSSL(ctx context.Context) (CheckResult, erro
 			for _, pg := range cluster.ClusterParameterGroups {
 				pgName := aws.ToString(pg.ParameterGroupName)
 
-				params, err := c.client.DescribeClusterParameters(ctx, &redshift.DescribeClusterParametersInput{
-					ParameterGroupName: &pgName,
-				})
+				params, err := c.describeClusterParameters(ctx, pgName)
 				if err != nil {
 					continue
 				}
 
 				sslRequired := false
-				for _, param := range params.Parameters {
+				for _, param := range params {
 					if aws.ToString(param.ParameterName) == "require_ssl" && aws.ToString(param.ParameterValue) == "true" {
 						sslRequired = true
 						break
@@ -260,6 +453,7 @@ func (c *RedshiftChecks) CheckClusterSSL(ctx context.Context) (CheckResult, erro
 
 				if !sslRequired {
 					noSSL = append(noSSL, clusterID)
+					envs.add(redshiftEnvironment(ctx, cluster))
 				}
 			}
 		}
@@ -271,13 +465,15 @@ func (c *RedshiftChecks) CheckClusterSSL(ctx context.Context) (CheckResult, erro
 			Name:              "Redshift SSL Required",
 			Status:            "FAIL",
 			Severity:          "HIGH",
-			Evidence:          fmt.Sprintf("%d Redshift clusters do not require SSL: %v", len(noSSL), noSSL),
+			Environment:       envs.environment(),
+			Evidence:          fmt.Sprintf("%d Redshift clusters do not require SSL: %s", len(noSSL), TruncateList(noSSL, evidenceListLimit(ctx))),
+			AffectedResources: noSSL,
 			Remediation:       "Enable require_ssl parameter for Redshift clusters",
 			RemediationDetail: "1. Create/modify parameter group with require_ssl=true\n2. Associate parameter group with cluster\n3. Reboot cluster to apply changes",
 			ScreenshotGuide:   "Redshift Console → Parameter groups → Select group → Parameters → Screenshot showing 'require_ssl: true'",
-			ConsoleURL:        "https://console.aws.amazon.com/redshiftv2/home#parameter-groups",
+			ConsoleURL:        consoleURL("redshiftv2/home#parameter-groups", consoleRegion(ctx)),
 			Priority:          PriorityHigh,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("REDSHIFT_SSL"),
 		}, nil
 	}
@@ -288,36 +484,41 @@ func (c *RedshiftChecks) CheckClusterSSL(ctx context.Context) (CheckResult, erro
 			Name:       "Redshift SSL Required",
 			Status:     "PASS",
 			Evidence:   "No Redshift clusters found",
+			ConsoleURL: consoleURL("redshiftv2/home#parameter-groups", consoleRegion(ctx)),
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("REDSHIFT_SSL"),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "CC6.4",
-		Name:       "Redshift SSL Required",
-		Status:     "PASS",
-		Evidence:   fmt.Sprintf("All %d Redshift clusters require SSL connections", len(clusters.Clusters)),
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("REDSHIFT_SSL"),
+		Control:         "CC6.4",
+		Name:            "Redshift SSL Required",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d Redshift clusters require SSL connections", len(clusters.Clusters)),
+		ScreenshotGuide: "Redshift Console → Parameter groups → Select group → Parameters → Screenshot showing 'require_ssl: true'",
+		ConsoleURL:      consoleURL("redshiftv2/home#parameter-groups", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("REDSHIFT_SSL"),
 	}, nil
 }
 
 func (c *RedshiftChecks) CheckClusterVersionUpgrade(ctx context.Context) (CheckResult, error) {
-	clusters, err := c.client.DescribeClusters(ctx, &redshift.DescribeClustersInput{})
+	clusters, err := c.describeClusters(ctx)
 	if err != nil {
 		return CheckResult{}, err
 	}
 
 	noAutoUpgrade := []string{}
+	var envs resourceEnvironments
 
 	for _, cluster := range clusters.Clusters {
 		clusterID := aws.ToString(cluster.ClusterIdentifier)
 
 		if !aws.ToBool(cluster.AllowVersionUpgrade) {
 			noAutoUpgrade = append(noAutoUpgrade, clusterID)
+			envs.add(redshiftEnvironment(ctx, cluster))
 		}
 	}
 
@@ -327,13 +528,15 @@ func (c *RedshiftChecks) CheckClusterVersionUpgrade(ctx context.Context) (CheckR
 			Name:              "Redshift Auto Version Upgrade",
 			Status:            "FAIL",
 			Severity:          "MEDIUM",
-			Evidence:          fmt.Sprintf("%d Redshift clusters have auto version upgrade disabled: %v", len(noAutoUpgrade), noAutoUpgrade),
+			Environment:       envs.environment(),
+			Evidence:          fmt.Sprintf("%d Redshift clusters have auto version upgrade disabled: %s", len(noAutoUpgrade), TruncateList(noAutoUpgrade, evidenceListLimit(ctx))),
+			AffectedResources: noAutoUpgrade,
 			Remediation:       "Enable automatic version upgrades for Redshift clusters",
 			RemediationDetail: "aws redshift modify-cluster --cluster-identifier [CLUSTER_ID] --allow-version-upgrade",
 			ScreenshotGuide:   "Redshift Console → Clusters → Select cluster → Maintenance → Screenshot showing 'Allow version upgrade: Yes'",
-			ConsoleURL:        "https://console.aws.amazon.com/redshiftv2/home#clusters",
+			ConsoleURL:        consoleURL("redshiftv2/home#clusters", consoleRegion(ctx)),
 			Priority:          PriorityMedium,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("REDSHIFT_PATCHING"),
 		}, nil
 	}
@@ -344,37 +547,45 @@ func (c *RedshiftChecks) CheckClusterVersionUpgrade(ctx context.Context) (CheckR
 			Name:       "Redshift Auto Version Upgrade",
 			Status:     "PASS",
 			Evidence:   "No Redshift clusters found",
+			ConsoleURL: consoleURL("redshiftv2/home#clusters", consoleRegion(ctx)),
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("REDSHIFT_PATCHING"),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "CC7.5",
-		Name:       "Redshift Auto Version Upgrade",
-		Status:     "PASS",
-		Evidence:   fmt.Sprintf("All %d Redshift clusters have auto version upgrade enabled", len(clusters.Clusters)),
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("REDSHIFT_PATCHING"),
+		Control:         "CC7.5",
+		Name:            "Redshift Auto Version Upgrade",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d Redshift clusters have auto version upgrade enabled", len(clusters.Clusters)),
+		ScreenshotGuide: "Redshift Console → Clusters → Select cluster → Maintenance → Screenshot showing 'Allow version upgrade: Yes'",
+		ConsoleURL:      consoleURL("redshiftv2/home#clusters", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("REDSHIFT_PATCHING"),
 	}, nil
 }
 
 func (c *RedshiftChecks) CheckClusterBackupRetention(ctx context.Context) (CheckResult, error) {
-	clusters, err := c.client.DescribeClusters(ctx, &redshift.DescribeClustersInput{})
+	clusters, err := c.describeClusters(ctx)
 	if err != nil {
 		return CheckResult{}, err
 	}
 
 	lowRetention := []string{}
+	lowRetentionListed := []string{}
+	var envs resourceEnvironments
 
 	for _, cluster := range clusters.Clusters {
 		clusterID := aws.ToString(cluster.ClusterIdentifier)
 
 		// Check if backup retention is less than 7 days
 		if cluster.AutomatedSnapshotRetentionPeriod != nil && *cluster.AutomatedSnapshotRetentionPeriod < 7 {
-			lowRetention = append(lowRetention, fmt.Sprintf("%s (%d days)", clusterID, *cluster.AutomatedSnapshotRetentionPeriod))
+			fix := pendingRetentionFix(redshiftPending(cluster).AutomatedSnapshotRetentionPeriod, 7)
+			lowRetention = append(lowRetention, clusterID)
+			lowRetentionListed = append(lowRetentionListed, withPendingFix(fmt.Sprintf("%s (%d days)", clusterID, *cluster.AutomatedSnapshotRetentionPeriod), fix))
+			envs.add(redshiftEnvironment(ctx, cluster))
 		}
 	}
 
@@ -384,13 +595,15 @@ func (c *RedshiftChecks) CheckClusterBackupRetention(ctx context.Context) (Check
 			Name:              "Redshift Backup Retention",
 			Status:            "FAIL",
 			Severity:          "MEDIUM",
-			Evidence:          fmt.Sprintf("%d Redshift clusters with backup retention < 7 days: %v", len(lowRetention), lowRetention),
+			Environment:       envs.environment(),
+			Evidence:          fmt.Sprintf("%d Redshift clusters with backup retention < 7 days: %s", len(lowRetention), TruncateList(lowRetentionListed, evidenceListLimit(ctx))),
+			AffectedResources: lowRetention,
 			Remediation:       "Increase automated snapshot retention period to at least 7 days",
 			RemediationDetail: "aws redshift modify-cluster --cluster-identifier [CLUSTER_ID] --automated-snapshot-retention-period 7",
 			ScreenshotGuide:   "Redshift Console → Clusters → Select cluster → Backup → Screenshot showing retention period >= 7 days",
-			ConsoleURL:        "https://console.aws.amazon.com/redshiftv2/home#clusters",
+			ConsoleURL:        consoleURL("redshiftv2/home#clusters", consoleRegion(ctx)),
 			Priority:          PriorityMedium,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("REDSHIFT_BACKUP"),
 		}, nil
 	}
@@ -401,36 +614,47 @@ func (c *RedshiftChecks) CheckClusterBackupRetention(ctx context.Context) (Check
 			Name:       "Redshift Backup Retention",
 			Status:     "PASS",
 			Evidence:   "No Redshift clusters found",
+			ConsoleURL: consoleURL("redshiftv2/home#clusters", consoleRegion(ctx)),
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("REDSHIFT_BACKUP"),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "A1.2",
-		Name:       "Redshift Backup Retention",
-		Status:     "PASS",
-		Evidence:   fmt.Sprintf("All %d Redshift clusters have adequate backup retention (>= 7 days)", len(clusters.Clusters)),
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("REDSHIFT_BACKUP"),
+		Control:         "A1.2",
+		Name:            "Redshift Backup Retention",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d Redshift clusters have adequate backup retention (>= 7 days)", len(clusters.Clusters)),
+		ScreenshotGuide: "Redshift Console → Clusters → Select cluster → Backup → Screenshot showing retention period >= 7 days",
+		ConsoleURL:      consoleURL("redshiftv2/home#clusters", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("REDSHIFT_BACKUP"),
 	}, nil
 }
 
 func (c *RedshiftChecks) CheckClusterEnhancedVPCRouting(ctx context.Context) (CheckResult, error) {
-	clusters, err := c.client.DescribeClusters(ctx, &redshift.DescribeClustersInput{})
+	clusters, err := c.describeClusters(ctx)
 	if err != nil {
 		return CheckResult{}, err
 	}
 
 	noEnhancedRouting := []string{}
+	noEnhancedRoutingListed := []string{}
+	var envs resourceEnvironments
 
 	for _, cluster := range clusters.Clusters {
 		clusterID := aws.ToString(cluster.ClusterIdentifier)
 
 		if !aws.ToBool(cluster.EnhancedVpcRouting) {
+			fix := ""
+			if aws.ToBool(redshiftPending(cluster).EnhancedVpcRouting) {
+				fix = "enhanced VPC routing enablement"
+			}
 			noEnhancedRouting = append(noEnhancedRouting, clusterID)
+			noEnhancedRoutingListed = append(noEnhancedRoutingListed, withPendingFix(clusterID, fix))
+			envs.add(redshiftEnvironment(ctx, cluster))
 		}
 	}
 
@@ -440,13 +664,15 @@ func (c *RedshiftChecks) CheckClusterEnhancedVPCRouting(ctx context.Context) (Ch
 			Name:              "Redshift Enhanced VPC Routing",
 			Status:            "FAIL",
 			Severity:          "MEDIUM",
-			Evidence:          fmt.Sprintf("%d Redshift clusters without enhanced VPC routing: %v", len(noEnhancedRouting), noEnhancedRouting),
+			Environment:       envs.environment(),
+			Evidence:          fmt.Sprintf("%d Redshift clusters without enhanced VPC routing: %s", len(noEnhancedRouting), TruncateList(noEnhancedRoutingListed, evidenceListLimit(ctx))),
+			AffectedResources: noEnhancedRouting,
 			Remediation:       "Enable enhanced VPC routing for better network security",
 			RemediationDetail: "aws redshift modify-cluster --cluster-identifier [CLUSTER_ID] --enhanced-vpc-routing\nNote: This causes brief cluster unavailability",
 			ScreenshotGuide:   "Redshift Console → Clusters → Select cluster → Properties → Network → Screenshot showing 'Enhanced VPC routing: Enabled'",
-			ConsoleURL:        "https://console.aws.amazon.com/redshiftv2/home#clusters",
+			ConsoleURL:        consoleURL("redshiftv2/home#clusters", consoleRegion(ctx)),
 			Priority:          PriorityMedium,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("REDSHIFT_NETWORK"),
 		}, nil
 	}
@@ -457,19 +683,1055 @@ func (c *RedshiftChecks) CheckClusterEnhancedVPCRouting(ctx context.Context) (Ch
 			Name:       "Redshift Enhanced VPC Routing",
 			Status:     "PASS",
 			Evidence:   "No Redshift clusters found",
+			ConsoleURL: consoleURL("redshiftv2/home#clusters", consoleRegion(ctx)),
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("REDSHIFT_NETWORK"),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "CC6.1",
-		Name:       "Redshift Enhanced VPC Routing",
+		Control:         "CC6.1",
+		Name:            "Redshift Enhanced VPC Routing",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d Redshift clusters have enhanced VPC routing enabled", len(clusters.Clusters)),
+		ScreenshotGuide: "Redshift Console → Clusters → Select cluster → Properties → Network → Screenshot showing 'Enhanced VPC routing: Enabled'",
+		ConsoleURL:      consoleURL("redshiftv2/home#clusters", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("REDSHIFT_NETWORK"),
+	}, nil
+}
+
+// CheckEnhancedVPCRoutingPrivateSubnets confirms that clusters with enhanced
+// VPC routing sit in private subnets. The flag only forces COPY and UNLOAD
+// traffic through the VPC; when the cluster subnet group's route tables send
+// traffic to an internet gateway, that traffic still leaves for the internet
+// directly, so the flag alone gives a false sense of isolation.
+func (c *RedshiftChecks) CheckEnhancedVPCRoutingPrivateSubnets(ctx context.Context) (CheckResult, error) {
+	clusters, err := c.describeClusters(ctx)
+	if err != nil {
+		return CheckResult{}, err
+	}
+
+	// Clusters share subnet groups and VPCs, so look each up only once
+	subnetGroups := map[string]*redshifttypes.ClusterSubnetGroup{}
+	routeTables := map[string][]ec2types.RouteTable{}
+
+	routed := 0
+	public := []string{}
+	publicListed := []string{}
+	var envs resourceEnvironments
+
+	for _, cluster := range clusters.Clusters {
+		if !aws.ToBool(cluster.EnhancedVpcRouting) {
+			continue
+		}
+		routed++
+		clusterID := aws.ToString(cluster.ClusterIdentifier)
+		groupName := aws.ToString(cluster.ClusterSubnetGroupName)
+
+		group, ok := subnetGroups[groupName]
+		if !ok {
+			groups, err := c.client.DescribeClusterSubnetGroups(ctx, &redshift.DescribeClusterSubnetGroupsInput{
+				ClusterSubnetGroupName: aws.String(groupName),
+			})
+			if err != nil {
+				return CheckResult{}, err
+			}
+			if len(groups.ClusterSubnetGroups) > 0 {
+				group = &groups.ClusterSubnetGroups[0]
+			}
+			subnetGroups[groupName] = group
+		}
+		if group == nil {
+			continue
+		}
+
+		vpcID := aws.ToString(group.VpcId)
+		tables, ok := routeTables[vpcID]
+		if !ok {
+			out, err := c.ec2Client.DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{
+				Filters: []ec2types.Filter{{Name: aws.String("vpc-id"), Values: []string{vpcID}}},
+			})
+			if err != nil {
+				return CheckResult{}, err
+			}
+			tables = out.RouteTables
+			routeTables[vpcID] = tables
+		}
+
+		publicSubnets := []string{}
+		for _, subnet := range group.Subnets {
+			subnetID := aws.ToString(subnet.SubnetIdentifier)
+			if gateway := internetGatewayRoute(subnetRouteTable(tables, subnetID)); gateway != "" {
+				publicSubnets = append(publicSubnets, fmt.Sprintf("%s via %s", subnetID, gateway))
+			}
+		}
+		if len(publicSubnets) > 0 {
+			public = append(public, clusterID)
+			publicListed = append(publicListed, fmt.Sprintf("%s (%s)", clusterID, strings.Join(publicSubnets, ", ")))
+			envs.add(redshiftEnvironment(ctx, cluster))
+		}
+	}
+
+	if len(public) > 0 {
+		return CheckResult{
+			Control:           "CC6.1",
+			Name:              "Redshift Enhanced VPC Routing Private Subnets",
+			Status:            "FAIL",
+			Severity:          "MEDIUM",
+			Environment:       envs.environment(),
+			Evidence:          fmt.Sprintf("%d Redshift clusters with enhanced VPC routing are in subnets routed to an internet gateway: %s", len(public), TruncateList(publicListed, evidenceListLimit(ctx))),
+			AffectedResources: public,
+			Remediation:       "Move the cluster subnet group to private subnets and reach AWS services through VPC endpoints",
+			RemediationDetail: "1. Create private subnets whose route table has no internet gateway route (use a NAT gateway or VPC endpoints for outbound access)\n2. aws ec2 create-vpc-endpoint --vpc-id [VPC_ID] --service-name com.amazonaws.[REGION].s3 --route-table-ids [PRIVATE_RTB_ID]\n3. aws redshift modify-cluster-subnet-group --cluster-subnet-group-name [GROUP] --subnet-ids [PRIVATE_SUBNET_IDS]\nNote: Moving a cluster between subnets may require a cluster relocation or restore",
+			ScreenshotGuide:   "VPC Console → Route tables → Select the route table of each subnet in the cluster subnet group → Routes → Screenshot showing no igw- target",
+			ConsoleURL:        consoleURL("vpc/home#RouteTables:", consoleRegion(ctx)),
+			Priority:          PriorityMedium,
+			Timestamp:         nowFunc(),
+			Frameworks:        GetFrameworkMappings("REDSHIFT_NETWORK"),
+		}, nil
+	}
+
+	if routed == 0 {
+		return CheckResult{
+			Control:    "CC6.1",
+			Name:       "Redshift Enhanced VPC Routing Private Subnets",
+			Status:     "PASS",
+			Evidence:   "No Redshift clusters with enhanced VPC routing found",
+			ConsoleURL: consoleURL("vpc/home#RouteTables:", consoleRegion(ctx)),
+			Priority:   PriorityInfo,
+			Timestamp:  nowFunc(),
+			Frameworks: GetFrameworkMappings("REDSHIFT_NETWORK"),
+		}, nil
+	}
+
+	return CheckResult{
+		Control:         "CC6.1",
+		Name:            "Redshift Enhanced VPC Routing Private Subnets",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d Redshift clusters with enhanced VPC routing are in subnets without an internet gateway route", routed),
+		ScreenshotGuide: "VPC Console → Route tables → Select the route table of each subnet in the cluster subnet group → Routes → Screenshot showing no igw- target",
+		ConsoleURL:      consoleURL("vpc/home#RouteTables:", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("REDSHIFT_NETWORK"),
+	}, nil
+}
+
+// describeClusterParameters returns every parameter of a parameter group,
+// across all pages
+func (c *RedshiftChecks) describeClusterParameters(ctx context.Context, name string) ([]redshifttypes.Parameter, error) {
+	return paginate(ctx, func(token *string) ([]redshifttypes.Parameter, *string, error) {
+		out, err := c.client.DescribeClusterParameters(ctx, &redshift.DescribeClusterParametersInput{
+			ParameterGroupName: &name,
+			Marker:             token,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return out.Parameters, out.Marker, nil
+	})
+}
+
+// redshiftEnvironment classifies the cluster by its environment tag
+func redshiftEnvironment(ctx context.Context, cluster redshifttypes.Cluster) string {
+	tags := map[string]string{}
+	for _, tag := range cluster.Tags {
+		tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+	return environmentFromTags(ctx, tags)
+}
+
+// redshiftPending returns the cluster's queued modifications, empty when none
+// are pending
+func redshiftPending(cluster redshifttypes.Cluster) redshifttypes.PendingModifiedValues {
+	if cluster.PendingModifiedValues == nil {
+		return redshifttypes.PendingModifiedValues{}
+	}
+	return *cluster.PendingModifiedValues
+}
+
+// subnetRouteTable returns the route table a subnet uses: the one explicitly
+// associated with it, or else the VPC's main route table
+func subnetRouteTable(tables []ec2types.RouteTable, subnetID string) *ec2types.RouteTable {
+	var main *ec2types.RouteTable
+	for i, table := range tables {
+		for _, association := range table.Associations {
+			if aws.ToString(association.SubnetId) == subnetID {
+				return &tables[i]
+			}
+			if aws.ToBool(association.Main) {
+				main = &tables[i]
+			}
+		}
+	}
+	return main
+}
+
+// internetGatewayRoute returns the internet gateway a route table sends
+// traffic to, or "" if it has no active internet gateway route
+func internetGatewayRoute(table *ec2types.RouteTable) string {
+	if table == nil {
+		return ""
+	}
+	for _, route := range table.Routes {
+		gateway := aws.ToString(route.GatewayId)
+		if strings.HasPrefix(gateway, "igw-") && route.State != ec2types.RouteStateBlackhole {
+			return gateway
+		}
+	}
+	return ""
+}
+
+func (c *RedshiftChecks) CheckDefaultMasterUsername(ctx context.Context) (CheckResult, error) {
+	clusters, err := c.describeClusters(ctx)
+	if err != nil {
+		return CheckResult{}, err
+	}
+
+	defaultUser := []string{}
+	var envs resourceEnvironments
+
+	for _, cluster := range clusters.Clusters {
+		clusterID := aws.ToString(cluster.ClusterIdentifier)
+
+		if strings.EqualFold(aws.ToString(cluster.MasterUsername), redshiftDefaultMasterUsername) {
+			defaultUser = append(defaultUser, clusterID)
+			envs.add(redshiftEnvironment(ctx, cluster))
+		}
+	}
+
+	if len(defaultUser) > 0 {
+		return CheckResult{
+			Control:           "CC6.6",
+			Name:              "Redshift Default Master Username",
+			Status:            "FAIL",
+			Severity:          "MEDIUM",
+			Environment:       envs.environment(),
+			Evidence:          fmt.Sprintf("%d Redshift clusters use the default master username '%s': %s", len(defaultUser), redshiftDefaultMasterUsername, TruncateList(defaultUser, evidenceListLimit(ctx))),
+			AffectedResources: defaultUser,
+			Remediation:       "Use a non-default master username for Redshift clusters",
+			RemediationDetail: "The master username cannot be changed after creation. Restore a snapshot into a new cluster with --master-username [CUSTOM_NAME], migrate clients, then delete the old cluster:\naws redshift restore-from-cluster-snapshot --cluster-identifier [NEW_ID] --snapshot-identifier [SNAPSHOT_ID]",
+			RequiresRecreate:  true,
+			ScreenshotGuide:   "Redshift Console → Clusters → Select cluster → Properties → Database configurations → Screenshot showing 'Admin user name' is not 'awsuser'",
+			ConsoleURL:        consoleURL("redshiftv2/home#clusters", consoleRegion(ctx)),
+			Priority:          PriorityMedium,
+			Timestamp:         nowFunc(),
+			Frameworks:        GetFrameworkMappings("REDSHIFT_ACCESS"),
+		}, nil
+	}
+
+	if len(clusters.Clusters) == 0 {
+		return CheckResult{
+			Control:    "CC6.6",
+			Name:       "Redshift Default Master Username",
+			Status:     "PASS",
+			Evidence:   "No Redshift clusters found",
+			ConsoleURL: consoleURL("redshiftv2/home#clusters", consoleRegion(ctx)),
+			Priority:   PriorityInfo,
+			Timestamp:  nowFunc(),
+			Frameworks: GetFrameworkMappings("REDSHIFT_ACCESS"),
+		}, nil
+	}
+
+	return CheckResult{
+		Control:         "CC6.6",
+		Name:            "Redshift Default Master Username",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d Redshift clusters use a non-default master username", len(clusters.Clusters)),
+		ScreenshotGuide: "Redshift Console → Clusters → Select cluster → Properties → Database configurations → Screenshot showing 'Admin user name' is not 'awsuser'",
+		ConsoleURL:      consoleURL("redshiftv2/home#clusters", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("REDSHIFT_ACCESS"),
+	}, nil
+}
+
+func (c *RedshiftChecks) CheckAssociatedIAMRoles(ctx context.Context) (CheckResult, error) {
+	clusters, err := c.describeClusters(ctx)
+	if err != nil {
+		return CheckResult{}, err
+	}
+
+	overlyPermissive := []string{}
+	// Several clusters often share one role, so look each role up only once
+	rolePolicies := map[string][]string{}
+	roleErrors := map[string]error{}
+	// Clusters with a role whose policies could not be read are neither
+	// failed nor counted as passing
+	unverified := []string{}
+	unverifiedListed := []string{}
+	permissiveClusters := []string{}
+	var envs resourceEnvironments
+
+	for _, cluster := range clusters.Clusters {
+		clusterID := aws.ToString(cluster.ClusterIdentifier)
+		clusterUnverified, clusterPermissive := false, false
+
+		for _, role := range cluster.IamRoles {
+			roleARN := aws.ToString(role.IamRoleArn)
+			if roleARN == "" {
+				continue
+			}
+
+			policies, checked := rolePolicies[roleARN]
+			roleErr := roleErrors[roleARN]
+			if !checked && roleErr == nil {
+				policies, roleErr = c.permissivePoliciesForRole(ctx, roleARN)
+				if roleErr != nil {
+					if ctx.Err() != nil {
+						return CheckResult{}, ctx.Err()
+					}
+					roleErrors[roleARN] = roleErr
+				} else {
+					rolePolicies[roleARN] = policies
+				}
+			}
+
+			roleName := iamRoleName(roleARN)
+			if roleErr != nil {
+				if !clusterUnverified {
+					unverified = append(unverified, clusterID)
+					unverifiedListed = append(unverifiedListed, fmt.Sprintf("%s (role %s)", clusterID, roleName))
+					clusterUnverified = true
+				}
+				continue
+			}
+			if len(policies) > 0 {
+				overlyPermissive = append(overlyPermissive, fmt.Sprintf("%s (role %s: %s)", clusterID, roleName, strings.Join(policies, ", ")))
+				if !clusterPermissive {
+					permissiveClusters = append(permissiveClusters, clusterID)
+					envs.add(redshiftEnvironment(ctx, cluster))
+					clusterPermissive = true
+				}
+			}
+		}
+	}
+
+	unverifiedNote := ""
+	if len(unverified) > 0 {
+		unverifiedNote = fmt.Sprintf(" | %d clusters have IAM roles whose policies could not be read and were not verified: %s", len(unverified), TruncateList(unverifiedListed, evidenceListLimit(ctx)))
+	}
+
+	if len(overlyPermissive) > 0 {
+		return CheckResult{
+			Control:           "CC6.3",
+			Name:              "Redshift IAM Role Scope",
+			Status:            "FAIL",
+			Severity:          "HIGH",
+			Environment:       envs.environment(),
+			Evidence:          fmt.Sprintf("%d Redshift cluster roles have overly permissive policies attached: %s", len(overlyPermissive), TruncateList(overlyPermissive, evidenceListLimit(ctx))) + unverifiedNote,
+			AffectedResources: permissiveClusters,
+			Remediation:       "Replace broad managed policies on Redshift roles with scoped policies",
+			RemediationDetail: "1. Create a policy granting only the S3 buckets/prefixes, Glue catalog and KMS keys the cluster needs\n2. aws iam attach-role-policy --role-name [ROLE_NAME] --policy-arn [SCOPED_POLICY_ARN]\n3. aws iam detach-role-policy --role-name [ROLE_NAME] --policy-arn arn:aws:iam::aws:policy/[BROAD_POLICY]",
+			ScreenshotGuide:   "Redshift Console → Clusters → Select cluster → Properties → Associated IAM roles → IAM Console → Role → Permissions → Screenshot showing only scoped policies attached",
+			ConsoleURL:        consoleURL("redshiftv2/home#clusters", consoleRegion(ctx)),
+			Priority:          PriorityHigh,
+			Timestamp:         nowFunc(),
+			Frameworks:        GetFrameworkMappings("REDSHIFT_IAM"),
+		}, nil
+	}
+
+	if len(clusters.Clusters) == 0 {
+		return CheckResult{
+			Control:    "CC6.3",
+			Name:       "Redshift IAM Role Scope",
+			Status:     "PASS",
+			Evidence:   "No Redshift clusters found",
+			ConsoleURL: consoleURL("redshiftv2/home#clusters", consoleRegion(ctx)),
+			Priority:   PriorityInfo,
+			Timestamp:  nowFunc(),
+			Frameworks: GetFrameworkMappings("REDSHIFT_IAM"),
+		}, nil
+	}
+
+	verified := len(clusters.Clusters) - len(unverified)
+	if verified == 0 {
+		return CheckResult{
+			Control:           "CC6.3",
+			Name:              "Redshift IAM Role Scope",
+			Status:            "ERROR",
+			Evidence:          fmt.Sprintf("The IAM roles of all %d Redshift clusters could not be read, so none were verified: %s. Grant iam:ListAttachedRolePolicies and re-run the scan.", len(unverified), TruncateList(unverifiedListed, evidenceListLimit(ctx))),
+			AffectedResources: unverified,
+			ConsoleURL:        consoleURL("redshiftv2/home#clusters", consoleRegion(ctx)),
+			Priority:          PriorityInfo,
+			Timestamp:         nowFunc(),
+			Frameworks:        GetFrameworkMappings("REDSHIFT_IAM"),
+		}, nil
+	}
+
+	return CheckResult{
+		Control:         "CC6.3",
+		Name:            "Redshift IAM Role Scope",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d Redshift clusters use scoped IAM roles (%d roles checked)", verified, len(rolePolicies)) + unverifiedNote,
+		ScreenshotGuide: "Redshift Console → Clusters → Select cluster → Properties → Associated IAM roles → IAM Console → Role → Permissions → Screenshot showing only scoped policies attached",
+		ConsoleURL:      consoleURL("redshiftv2/home#clusters", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("REDSHIFT_IAM"),
+	}, nil
+}
+
+// permissivePoliciesForRole returns the names of overly permissive managed
+// policies attached to the role, or an error if the role cannot be read
+func (c *RedshiftChecks) permissivePoliciesForRole(ctx context.Context, roleARN string) ([]string, error) {
+	found := []string{}
+
+	attached, err := paginate(ctx, func(token *string) ([]iamtypes.AttachedPolicy, *string, error) {
+		out, err := c.iamClient.ListAttachedRolePolicies(ctx, &iam.ListAttachedRolePoliciesInput{RoleName: aws.String(iamRoleName(roleARN)), Marker: token})
+		if err != nil {
+			return nil, nil, err
+		}
+		return out.AttachedPolicies, out.Marker, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, policy := range attached {
+		policyARN := aws.ToString(policy.PolicyArn)
+		for _, broad := range redshiftOverlyPermissivePolicies {
+			// Suffix match so GovCloud and China partition ARNs are covered too
+			if strings.HasSuffix(policyARN, ":aws:policy/"+broad) {
+				found = append(found, broad)
+			}
+		}
+	}
+
+	return found, nil
+}
+
+// describeLoggingStatus returns a cluster's logging status, calling
+// DescribeLoggingStatus only on first use
+func (c *RedshiftChecks) describeLoggingStatus(ctx context.Context, clusterID string) (*redshift.DescribeLoggingStatusOutput, error) {
+	if logging, ok := c.loggingStatus[clusterID]; ok {
+		return logging, nil
+	}
+
+	logging, err := c.client.DescribeLoggingStatus(ctx, &redshift.DescribeLoggingStatusInput{
+		ClusterIdentifier: aws.String(clusterID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.loggingStatus[clusterID] = logging
+	return logging, nil
+}
+
+// CheckLoggingDestinationSecure flags clusters whose audit logs go to an S3
+// bucket that does not block public access or has no default encryption.
+// Audit logs in a public bucket leak query text and user activity, which is
+// worse than not logging. Clusters logging to CloudWatch or not logging at
+// all are left to CheckClusterLogging.
+func (c *RedshiftChecks) CheckLoggingDestinationSecure(ctx context.Context) (CheckResult, error) {
+	clusters, err := c.describeClusters(ctx)
+	if err != nil {
+		return CheckResult{}, err
+	}
+
+	insecure := []string{}
+	insecureListed := []string{}
+	bucketIssues := map[string][]string{} // each bucket is inspected once
+	bucketErrors := map[string]error{}
+	unverified := []string{}
+	unverifiedListed := []string{}
+	checked := 0
+	var envs resourceEnvironments
+
+	for _, cluster := range clusters.Clusters {
+		clusterID := aws.ToString(cluster.ClusterIdentifier)
+
+		logging, err := c.describeLoggingStatus(ctx, clusterID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return CheckResult{}, ctx.Err()
+			}
+			// Where the cluster logs to is unknown, so like an unreadable
+			// bucket it is neither failed nor counted as passing
+			unverified = append(unverified, clusterID)
+			unverifiedListed = append(unverifiedListed, fmt.Sprintf("%s (logging status unavailable)", clusterID))
+			continue
+		}
+		if !aws.ToBool(logging.LoggingEnabled) {
+			continue
+		}
+		bucket := aws.ToString(logging.BucketName)
+		if bucket == "" {
+			continue
+		}
+
+		issues, ok := bucketIssues[bucket]
+		bucketErr := bucketErrors[bucket]
+		if !ok && bucketErr == nil {
+			issues, bucketErr = c.loggingBucketIssues(ctx, bucket)
+			if bucketErr != nil {
+				if ctx.Err() != nil {
+					return CheckResult{}, ctx.Err()
+				}
+				bucketErrors[bucket] = bucketErr
+			} else {
+				bucketIssues[bucket] = issues
+			}
+		}
+		if bucketErr != nil {
+			// The bucket could not be read, so the cluster is neither
+			// failed nor counted as passing
+			unverified = append(unverified, clusterID)
+			unverifiedListed = append(unverifiedListed, fmt.Sprintf("%s → s3://%s", clusterID, bucket))
+			continue
+		}
+		checked++
+
+		if len(issues) > 0 {
+			insecure = append(insecure, clusterID)
+			insecureListed = append(insecureListed, fmt.Sprintf("%s → s3://%s (%s)", clusterID, bucket, strings.Join(issues, ", ")))
+			envs.add(redshiftEnvironment(ctx, cluster))
+		}
+	}
+
+	unverifiedNote := ""
+	if len(unverified) > 0 {
+		unverifiedNote = fmt.Sprintf(" | %d clusters whose logging status or log bucket settings could not be read were not verified: %s", len(unverified), TruncateList(unverifiedListed, evidenceListLimit(ctx)))
+	}
+
+	if len(insecure) > 0 {
+		return CheckResult{
+			Control:           "CC7.1",
+			Name:              "Redshift Audit Log Destination",
+			Status:            "FAIL",
+			Severity:          "HIGH",
+			Environment:       envs.environment(),
+			Evidence:          fmt.Sprintf("%d Redshift clusters write audit logs to an insecure S3 bucket: %s", len(insecure), TruncateList(insecureListed, evidenceListLimit(ctx))) + unverifiedNote,
+			AffectedResources: insecure,
+			Remediation:       "Block public access and enable default encryption on the Redshift audit log bucket",
+			RemediationDetail: "aws s3api put-public-access-block --bucket [LOG_BUCKET] --public-access-block-configuration BlockPublicAcls=true,IgnorePublicAcls=true,BlockPublicPolicy=true,RestrictPublicBuckets=true\naws s3api put-bucket-encryption --bucket [LOG_BUCKET] --server-side-encryption-configuration '{\"Rules\": [{\"ApplyServerSideEncryptionByDefault\": {\"SSEAlgorithm\": \"aws:kms\"}}]}'",
+			ScreenshotGuide:   "S3 Console → Buckets → Select the Redshift log bucket → Permissions → Screenshot showing all 'Block public access' settings On, then Properties → Default encryption enabled",
+			ConsoleURL:        consoleURL("s3/buckets", consoleRegion(ctx)),
+			Priority:          PriorityHigh,
+			Timestamp:         nowFunc(),
+			Frameworks:        GetFrameworkMappings("REDSHIFT_LOGGING"),
+		}, nil
+	}
+
+	if checked == 0 && len(unverified) > 0 {
+		return CheckResult{
+			Control:           "CC7.1",
+			Name:              "Redshift Audit Log Destination",
+			Status:            "ERROR",
+			Evidence:          fmt.Sprintf("The audit log destinations of %d Redshift clusters could not be read, so none were verified: %s. Grant redshift:DescribeLoggingStatus, s3:GetBucketPublicAccessBlock and s3:GetEncryptionConfiguration and re-run the scan.", len(unverified), TruncateList(unverifiedListed, evidenceListLimit(ctx))),
+			AffectedResources: unverified,
+			ConsoleURL:        consoleURL("s3/buckets", consoleRegion(ctx)),
+			Priority:          PriorityInfo,
+			Timestamp:         nowFunc(),
+			Frameworks:        GetFrameworkMappings("REDSHIFT_LOGGING"),
+		}, nil
+	}
+
+	if checked == 0 {
+		return CheckResult{
+			Control:    "CC7.1",
+			Name:       "Redshift Audit Log Destination",
+			Status:     "PASS",
+			Evidence:   "No Redshift clusters log to S3",
+			ConsoleURL: consoleURL("s3/buckets", consoleRegion(ctx)),
+			Priority:   PriorityInfo,
+			Timestamp:  nowFunc(),
+			Frameworks: GetFrameworkMappings("REDSHIFT_LOGGING"),
+		}, nil
+	}
+
+	return CheckResult{
+		Control:         "CC7.1",
+		Name:            "Redshift Audit Log Destination",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d Redshift clusters logging to S3 use a bucket that blocks public access and is encrypted", checked) + unverifiedNote,
+		ScreenshotGuide: "S3 Console → Buckets → Select the Redshift log bucket → Permissions → Screenshot showing all 'Block public access' settings On, then Properties → Default encryption enabled",
+		ConsoleURL:      consoleURL("s3/buckets", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("REDSHIFT_LOGGING"),
+	}, nil
+}
+
+// loggingBucketIssues describes what makes an audit log bucket insecure, or
+// returns nil if it blocks public access and has default encryption. A
+// missing public access block counts as public, as in the S3 checks. Errors
+// other than a setting not being configured are returned, since the bucket
+// could not be verified.
+func (c *RedshiftChecks) loggingBucketIssues(ctx context.Context, bucket string) ([]string, error) {
+	issues := []string{}
+
+	pab, err := c.s3Client.GetPublicAccessBlock(ctx, &s3.GetPublicAccessBlockInput{
+		Bucket: aws.String(bucket),
+	})
+	switch {
+	case err != nil && !isNotConfigured(err):
+		return nil, err
+	case err != nil || pab.PublicAccessBlockConfiguration == nil:
+		issues = append(issues, "public access not blocked")
+	default:
+		cfg := pab.PublicAccessBlockConfiguration
+		if !aws.ToBool(cfg.BlockPublicAcls) ||
+			!aws.ToBool(cfg.BlockPublicPolicy) ||
+			!aws.ToBool(cfg.IgnorePublicAcls) ||
+			!aws.ToBool(cfg.RestrictPublicBuckets) {
+			issues = append(issues, "public access not blocked")
+		}
+	}
+
+	if _, err := c.s3Client.GetBucketEncryption(ctx, &s3.GetBucketEncryptionInput{
+		Bucket: aws.String(bucket),
+	}); err != nil {
+		if !isNotConfigured(err) {
+			return nil, err
+		}
+		issues = append(issues, "no default encryption")
+	}
+
+	if len(issues) == 0 {
+		return nil, nil
+	}
+	return issues, nil
+}
+
+// describeClusters returns the account's clusters, calling DescribeClusters
+// only on first use. Clusters being created or deleted are left out, and
+// recorded in c.transient, so their half-configured state is not reported as
+// a failure.
+func (c *RedshiftChecks) describeClusters(ctx context.Context) (*redshift.DescribeClustersOutput, error) {
+	if c.clusters != nil {
+		return c.clusters, nil
+	}
+
+	clusters, err := c.fetchClusters(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	steady := clusters.Clusters[:0]
+	for _, cluster := range clusters.Clusters {
+		if status := aws.ToString(cluster.ClusterStatus); isTransientStatus(status) {
+			c.transient.add(aws.ToString(cluster.ClusterIdentifier), status)
+			continue
+		}
+		steady = append(steady, cluster)
+	}
+	clusters.Clusters = steady
+
+	c.clusters = clusters
+	return clusters, nil
+}
+
+// fetchClusters calls DescribeClusters for the whole account, or once per
+// cluster in clusterIDs
+func (c *RedshiftChecks) fetchClusters(ctx context.Context) (*redshift.DescribeClustersOutput, error) {
+	if len(c.clusterIDs) == 0 {
+		all, err := paginate(ctx, func(token *string) ([]redshifttypes.Cluster, *string, error) {
+			out, err := c.client.DescribeClusters(ctx, &redshift.DescribeClustersInput{Marker: token})
+			if err != nil {
+				return nil, nil, err
+			}
+			return out.Clusters, out.Marker, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &redshift.DescribeClustersOutput{Clusters: all}, nil
+	}
+
+	clusters := &redshift.DescribeClustersOutput{}
+	for _, id := range c.clusterIDs {
+		out, err := c.client.DescribeClusters(ctx, &redshift.DescribeClustersInput{
+			ClusterIdentifier: aws.String(id),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("redshift cluster %s: %w", id, err)
+		}
+		clusters.Clusters = append(clusters.Clusters, out.Clusters...)
+	}
+	return clusters, nil
+}
+
+// CheckMaintenanceWindow flags clusters whose maintenance window could cause
+// surprise downtime: none configured, the default AWS assigned at creation,
+// or one starting during weekday business hours (UTC). The API does not say
+// whether a window was chosen, so a 30-minute window inside the region's
+// default assignment block is treated as the unreviewed default.
+func (c *RedshiftChecks) CheckMaintenanceWindow(ctx context.Context) (CheckResult, error) {
+	clusters, err := c.describeClusters(ctx)
+	if err != nil {
+		return CheckResult{}, err
+	}
+
+	region := c.client.Options().Region
+	uncontrolled := []string{}
+	details := []string{}
+	var envs resourceEnvironments
+
+	for _, cluster := range clusters.Clusters {
+		clusterID := aws.ToString(cluster.ClusterIdentifier)
+		window := aws.ToString(cluster.PreferredMaintenanceWindow)
+
+		var detail string
+		switch {
+		case window == "":
+			detail = "no window"
+		case redshiftWindowIsDefault(window, region):
+			detail = fmt.Sprintf("%s, AWS default", window)
+		case redshiftWindowInBusinessHours(window):
+			detail = fmt.Sprintf("%s, business hours", window)
+		default:
+			continue
+		}
+
+		uncontrolled = append(uncontrolled, clusterID)
+		details = append(details, fmt.Sprintf("%s (%s)", clusterID, detail))
+		envs.add(redshiftEnvironment(ctx, cluster))
+	}
+
+	if len(uncontrolled) > 0 {
+		return CheckResult{
+			Control:           "A1.1",
+			Name:              "Redshift Maintenance Window",
+			Status:            "FAIL",
+			Severity:          "LOW",
+			Environment:       envs.environment(),
+			Evidence:          fmt.Sprintf("%d Redshift clusters have no maintenance window, the AWS-assigned default, or one during weekday business hours (UTC): %s", len(uncontrolled), TruncateList(details, evidenceListLimit(ctx))),
+			AffectedResources: uncontrolled,
+			Remediation:       "Set an explicit off-hours maintenance window for Redshift clusters",
+			RemediationDetail: "aws redshift modify-cluster --cluster-identifier [CLUSTER_ID] --preferred-maintenance-window sun:03:00-sun:03:30\nPick a window outside business hours for the cluster's users; times are UTC",
+			ScreenshotGuide:   "Redshift Console → Clusters → Select cluster → Maintenance → Screenshot showing the maintenance window set outside business hours",
+			ConsoleURL:        consoleURL("redshiftv2/home#clusters", consoleRegion(ctx)),
+			Priority:          PriorityLow,
+			Timestamp:         nowFunc(),
+			Frameworks:        GetFrameworkMappings("REDSHIFT_MAINTENANCE"),
+		}, nil
+	}
+
+	if len(clusters.Clusters) == 0 {
+		return CheckResult{
+			Control:    "A1.1",
+			Name:       "Redshift Maintenance Window",
+			Status:     "PASS",
+			Evidence:   "No Redshift clusters found",
+			ConsoleURL: consoleURL("redshiftv2/home#clusters", consoleRegion(ctx)),
+			Priority:   PriorityInfo,
+			Timestamp:  nowFunc(),
+			Frameworks: GetFrameworkMappings("REDSHIFT_MAINTENANCE"),
+		}, nil
+	}
+
+	return CheckResult{
+		Control:         "A1.1",
+		Name:            "Redshift Maintenance Window",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d Redshift clusters have a deliberately chosen off-hours maintenance window", len(clusters.Clusters)),
+		ScreenshotGuide: "Redshift Console → Clusters → Select cluster → Maintenance → Screenshot showing the maintenance window set outside business hours",
+		ConsoleURL:      consoleURL("redshiftv2/home#clusters", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("REDSHIFT_MAINTENANCE"),
+	}, nil
+}
+
+// CheckCustomParameterGroup flags clusters left on a default.redshift-*
+// parameter group. Default groups cannot be modified, so org-mandated settings
+// such as require_ssl, enable_user_activity_logging or statement_timeout cannot
+// be enforced on those clusters. Uses the parameter group names already
+// returned by DescribeClusters.
+func (c *RedshiftChecks) CheckCustomParameterGroup(ctx context.Context) (CheckResult, error) {
+	clusters, err := c.describeClusters(ctx)
+	if err != nil {
+		return CheckResult{}, err
+	}
+
+	onDefault := []string{}
+	onDefaultListed := []string{}
+	var envs resourceEnvironments
+
+	for _, cluster := range clusters.Clusters {
+		clusterID := aws.ToString(cluster.ClusterIdentifier)
+
+		for _, pg := range cluster.ClusterParameterGroups {
+			pgName := aws.ToString(pg.ParameterGroupName)
+			if strings.HasPrefix(pgName, "default.") {
+				onDefault = append(onDefault, clusterID)
+				onDefaultListed = append(onDefaultListed, fmt.Sprintf("%s (%s)", clusterID, pgName))
+				envs.add(redshiftEnvironment(ctx, cluster))
+				break
+			}
+		}
+	}
+
+	if len(onDefault) > 0 {
+		return CheckResult{
+			Control:           "CC7.1",
+			Name:              "Redshift Custom Parameter Group",
+			Status:            "FAIL",
+			Severity:          "LOW",
+			Environment:       envs.environment(),
+			Evidence:          fmt.Sprintf("%d Redshift clusters use a default parameter group, where security parameters (require_ssl, user activity logging, statement_timeout) cannot be set: %s", len(onDefault), TruncateList(onDefaultListed, evidenceListLimit(ctx))),
+			AffectedResources: onDefault,
+			Remediation:       "Create a custom parameter group with the organization's required settings and attach it to each cluster",
+			RemediationDetail: "aws redshift create-cluster-parameter-group --parameter-group-name [GROUP_NAME] --parameter-group-family redshift-1.0 --description \"Org baseline\"\naws redshift modify-cluster-parameter-group --parameter-group-name [GROUP_NAME] --parameters ParameterName=require_ssl,ParameterValue=true\naws redshift modify-cluster --cluster-identifier [CLUSTER_ID] --cluster-parameter-group-name [GROUP_NAME]\nNote: The new group takes effect after the cluster reboots",
+			ScreenshotGuide:   "Redshift Console → Clusters → Select cluster → Properties → Database configurations → Screenshot showing a non-default parameter group",
+			ConsoleURL:        consoleURL("redshiftv2/home#parameter-groups", consoleRegion(ctx)),
+			Priority:          PriorityLow,
+			Timestamp:         nowFunc(),
+			Frameworks:        GetFrameworkMappings("REDSHIFT_CONFIGURATION"),
+		}, nil
+	}
+
+	if len(clusters.Clusters) == 0 {
+		return CheckResult{
+			Control:    "CC7.1",
+			Name:       "Redshift Custom Parameter Group",
+			Status:     "PASS",
+			Evidence:   "No Redshift clusters found",
+			ConsoleURL: consoleURL("redshiftv2/home#parameter-groups", consoleRegion(ctx)),
+			Priority:   PriorityInfo,
+			Timestamp:  nowFunc(),
+			Frameworks: GetFrameworkMappings("REDSHIFT_CONFIGURATION"),
+		}, nil
+	}
+
+	return CheckResult{
+		Control:         "CC7.1",
+		Name:            "Redshift Custom Parameter Group",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d Redshift clusters use a custom parameter group", len(clusters.Clusters)),
+		ScreenshotGuide: "Redshift Console → Clusters → Select cluster → Properties → Database configurations → Screenshot showing a non-default parameter group",
+		ConsoleURL:      consoleURL("redshiftv2/home#parameter-groups", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("REDSHIFT_CONFIGURATION"),
+	}, nil
+}
+
+// CheckCrossRegionSnapshotCopy flags clusters whose snapshots are not copied
+// to another region. Automated and manual snapshots otherwise live only in the
+// cluster's own region, so a regional outage takes the backups down with the
+// cluster. Uses the ClusterSnapshotCopyStatus returned by DescribeClusters.
+func (c *RedshiftChecks) CheckCrossRegionSnapshotCopy(ctx context.Context) (CheckResult, error) {
+	clusters, err := c.describeClusters(ctx)
+	if err != nil {
+		return CheckResult{}, err
+	}
+
+	noCopy := []string{}
+	var envs resourceEnvironments
+
+	for _, cluster := range clusters.Clusters {
+		clusterID := aws.ToString(cluster.ClusterIdentifier)
+
+		status := cluster.ClusterSnapshotCopyStatus
+		if status == nil || aws.ToString(status.DestinationRegion) == "" {
+			noCopy = append(noCopy, clusterID)
+			envs.add(redshiftEnvironment(ctx, cluster))
+		}
+	}
+
+	if len(noCopy) > 0 {
+		return CheckResult{
+			Control:           "A1.2",
+			Name:              "Redshift Cross-Region Snapshot Copy",
+			Status:            "FAIL",
+			Severity:          "LOW",
+			Environment:       envs.environment(),
+			Evidence:          fmt.Sprintf("%d Redshift clusters keep snapshots in their own region only, so a regional outage loses the cluster and its backups together and disaster recovery cannot restore elsewhere: %s", len(noCopy), TruncateList(noCopy, evidenceListLimit(ctx))),
+			AffectedResources: noCopy,
+			Remediation:       "Enable cross-region snapshot copy to a second region for clusters that must survive a regional disaster",
+			RemediationDetail: "aws redshift enable-snapshot-copy --cluster-identifier [CLUSTER_ID] --destination-region [DR_REGION] --retention-period 7\nFor KMS-encrypted clusters, first create a snapshot copy grant in the destination region:\naws redshift create-snapshot-copy-grant --snapshot-copy-grant-name [GRANT_NAME] --region [DR_REGION]\nand pass --snapshot-copy-grant-name [GRANT_NAME] to enable-snapshot-copy",
+			ScreenshotGuide:   "Redshift Console → Clusters → Select cluster → Maintenance → Backup details → Screenshot showing cross-region snapshot copy enabled with its destination region",
+			ConsoleURL:        consoleURL("redshiftv2/home#clusters", consoleRegion(ctx)),
+			Priority:          PriorityLow,
+			Timestamp:         nowFunc(),
+			Frameworks:        GetFrameworkMappings("REDSHIFT_BACKUP"),
+		}, nil
+	}
+
+	if len(clusters.Clusters) == 0 {
+		return CheckResult{
+			Control:    "A1.2",
+			Name:       "Redshift Cross-Region Snapshot Copy",
+			Status:     "PASS",
+			Evidence:   "No Redshift clusters found",
+			ConsoleURL: consoleURL("redshiftv2/home#clusters", consoleRegion(ctx)),
+			Priority:   PriorityInfo,
+			Timestamp:  nowFunc(),
+			Frameworks: GetFrameworkMappings("REDSHIFT_BACKUP"),
+		}, nil
+	}
+
+	return CheckResult{
+		Control:         "A1.2",
+		Name:            "Redshift Cross-Region Snapshot Copy",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d Redshift clusters copy snapshots to another region", len(clusters.Clusters)),
+		ScreenshotGuide: "Redshift Console → Clusters → Select cluster → Maintenance → Backup details → Screenshot showing cross-region snapshot copy enabled with its destination region",
+		ConsoleURL:      consoleURL("redshiftv2/home#clusters", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("REDSHIFT_BACKUP"),
+	}, nil
+}
+
+// CheckExpiringResources surfaces reserved nodes that run out within
+// reservationExpiryWarningDays and manual snapshots whose retention period
+// ends within snapshotExpiryWarningDays. Neither is a misconfiguration, but a
+// lapsed reservation silently moves the cluster to on-demand pricing and an
+// expired snapshot is deleted, so both are reported at LOW severity ahead of
+// time. Reservations are account-wide and are not listed when the checks are
+// limited to named clusters.
+func (c *RedshiftChecks) CheckExpiringResources(ctx context.Context) (CheckResult, error) {
+	expiring := []string{}
+	expiringListed := []string{}
+
+	if len(c.clusterIDs) == 0 {
+		nodes, err := paginate(ctx, func(token *string) ([]redshifttypes.ReservedNode, *string, error) {
+			out, err := c.client.DescribeReservedNodes(ctx, &redshift.DescribeReservedNodesInput{Marker: token})
+			if err != nil {
+				return nil, nil, err
+			}
+			return out.ReservedNodes, out.Marker, nil
+		})
+		if err != nil {
+			return CheckResult{}, err
+		}
+		for _, node := range nodes {
+			if !strings.EqualFold(aws.ToString(node.State), "active") || node.StartTime == nil {
+				continue
+			}
+			end := reservationEnd(*node.StartTime, int64(aws.ToInt32(node.Duration)))
+			if daysUntil(end) <= reservationExpiryWarningDays {
+				expiring = append(expiring, aws.ToString(node.ReservedNodeId))
+				expiringListed = append(expiringListed, fmt.Sprintf("%s (%d x %s reserved nodes, %s)", aws.ToString(node.ReservedNodeId), aws.ToInt32(node.NodeCount), aws.ToString(node.NodeType), expiryNote(end)))
+			}
+		}
+	}
+
+	snapshots, err := paginate(ctx, func(token *string) ([]redshifttypes.Snapshot, *string, error) {
+		out, err := c.client.DescribeClusterSnapshots(ctx, &redshift.DescribeClusterSnapshotsInput{
+			SnapshotType: aws.String("manual"),
+			Marker:       token,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return out.Snapshots, out.Marker, nil
+	})
+	if err != nil {
+		return CheckResult{}, err
+	}
+	for _, snapshot := range snapshots {
+		clusterID := aws.ToString(snapshot.ClusterIdentifier)
+		if len(c.clusterIDs) > 0 && !slices.Contains(c.clusterIDs, clusterID) {
+			continue
+		}
+		// -1 keeps the snapshot until it is deleted by hand
+		remaining := aws.ToInt32(snapshot.ManualSnapshotRemainingDays)
+		if remaining < 0 || remaining > snapshotExpiryWarningDays {
+			continue
+		}
+		expiring = append(expiring, aws.ToString(snapshot.SnapshotIdentifier))
+		expiringListed = append(expiringListed, fmt.Sprintf("%s (manual snapshot of %s, deleted in %d days)", aws.ToString(snapshot.SnapshotIdentifier), clusterID, remaining))
+	}
+
+	if len(expiring) > 0 {
+		return CheckResult{
+			Control:           "A1.1",
+			Name:              "Redshift Expiring Reservations and Snapshots",
+			Status:            "FAIL",
+			Severity:          "LOW",
+			Evidence:          fmt.Sprintf("%d Redshift reserved node purchases or manual snapshots expire soon (reservations within %d days, snapshots within %d): %s", len(expiring), reservationExpiryWarningDays, snapshotExpiryWarningDays, TruncateList(expiringListed, evidenceListLimit(ctx))),
+			AffectedResources: expiring,
+			Remediation:       "Renew expiring reserved nodes and copy or extend manual snapshots that are still needed",
+			RemediationDetail: "aws redshift describe-reserved-node-offerings --node-type [NODE_TYPE]\naws redshift purchase-reserved-node-offering --reserved-node-offering-id [OFFERING_ID] --node-count [COUNT]\nTo keep a manual snapshot: aws redshift modify-cluster-snapshot --snapshot-identifier [SNAPSHOT_ID] --manual-snapshot-retention-period -1",
+			ScreenshotGuide:   "Redshift Console → Reserved nodes → Screenshot showing the renewal plan for expiring reservations; Redshift Console → Snapshots → Screenshot showing the retention period of the listed snapshots",
+			ConsoleURL:        consoleURL("redshiftv2/home#reserved-nodes", consoleRegion(ctx)),
+			Priority:          PriorityLow,
+			Timestamp:         nowFunc(),
+			Frameworks:        GetFrameworkMappings("REDSHIFT_EXPIRY"),
+		}, nil
+	}
+
+	return CheckResult{
+		Control:    "A1.1",
+		Name:       "Redshift Expiring Reservations and Snapshots",
 		Status:     "PASS",
-		Evidence:   fmt.Sprintf("All %d Redshift clusters have enhanced VPC routing enabled", len(clusters.Clusters)),
+		Evidence:   fmt.Sprintf("No Redshift reserved nodes expire within %d days and no manual snapshots within %d days", reservationExpiryWarningDays, snapshotExpiryWarningDays),
+		ConsoleURL: consoleURL("redshiftv2/home#reserved-nodes", consoleRegion(ctx)),
 		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("REDSHIFT_NETWORK"),
+		Timestamp:  nowFunc(),
+		Frameworks: GetFrameworkMappings("REDSHIFT_EXPIRY"),
 	}, nil
 }
+
+// redshiftWindowIsDefault reports whether a "ddd:hh24:mi-ddd:hh24:mi"
+// maintenance window has the shape AWS assigns by default in region: exactly
+// 30 minutes, starting inside the region's default block. Regions without a
+// known block and unparseable windows are not flagged.
+func redshiftWindowIsDefault(window, region string) bool {
+	blockStart, ok := redshiftDefaultMaintenanceBlocks[region]
+	if !ok {
+		return false
+	}
+
+	startText, endText, found := strings.Cut(window, "-")
+	if !found {
+		return false
+	}
+	start, ok := redshiftWindowMinute(startText)
+	if !ok {
+		return false
+	}
+	end, ok := redshiftWindowMinute(endText)
+	if !ok {
+		return false
+	}
+
+	const week = 7 * 24 * 60
+	if (end-start+week)%week != redshiftDefaultWindowMinutes {
+		return false
+	}
+	offset := (start%(24*60) - blockStart + 24*60) % (24 * 60)
+	return offset < redshiftDefaultBlockMinutes
+}
+
+// redshiftWindowMinute converts a "ddd:hh24:mi" window bound to minutes since
+// Monday 00:00 UTC
+func redshiftWindowMinute(bound string) (int, bool) {
+	var day string
+	var hour, minute int
+	if _, err := fmt.Sscanf(strings.ReplaceAll(bound, ":", " "), "%s %d %d", &day, &hour, &minute); err != nil {
+		return 0, false
+	}
+
+	days := map[string]int{"mon": 0, "tue": 1, "wed": 2, "thu": 3, "fri": 4, "sat": 5, "sun": 6}
+	index, ok := days[strings.ToLower(day)]
+	if !ok || hour < 0 || hour > 23 || minute < 0 || minute > 59 {
+		return 0, false
+	}
+	return index*24*60 + hour*60 + minute, true
+}
+
+// redshiftWindowInBusinessHours reports whether a "ddd:hh24:mi-ddd:hh24:mi"
+// maintenance window starts on a weekday during business hours. Unparseable
+// windows are not flagged.
+func redshiftWindowInBusinessHours(window string) bool {
+	var day string
+	var hour, minute int
+	start, _, _ := strings.Cut(window, "-")
+	if _, err := fmt.Sscanf(strings.ReplaceAll(start, ":", " "), "%s %d %d", &day, &hour, &minute); err != nil {
+		return false
+	}
+
+	switch strings.ToLower(day) {
+	case "sat", "sun":
+		return false
+	}
+	return hour >= redshiftBusinessHoursStart && hour < redshiftBusinessHoursEnd
+}
@@ -0,0 +1,265 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/redshiftserverless"
+	redshiftserverlesstypes "github.com/aws/aws-sdk-go-v2/service/redshiftserverless/types"
+)
+
+// RedshiftServerlessChecks covers Redshift Serverless namespaces and
+// workgroups. Results reuse the provisioned cluster checks' Control IDs and
+// framework mappings so reports treat both deployment models the same way.
+type RedshiftServerlessChecks struct {
+	client *redshiftserverless.Client
+}
+
+func NewRedshiftServerlessChecks(client *redshiftserverless.Client) *RedshiftServerlessChecks {
+	return &RedshiftServerlessChecks{
+		client: client,
+	}
+}
+
+// redshiftServerlessAWSOwnedKey is the KmsKeyId reported for namespaces
+// encrypted with the default AWS owned key
+const redshiftServerlessAWSOwnedKey = "AWS_OWNED_KMS_KEY"
+
+func (c *RedshiftServerlessChecks) Name() string {
+	return "Redshift Serverless Security"
+}
+
+func (c *RedshiftServerlessChecks) Run(ctx context.Context) ([]CheckResult, error) {
+	results := []CheckResult{}
+
+	result, err := runCheck(ctx, "redshift_serverless.namespace_encryption", c.CheckNamespaceEncryption)
+	if isServiceUnavailableInRegion(err) {
+		return []CheckResult{serviceUnavailableResult(ctx, "Redshift Serverless", "CC6.3")}, nil
+	}
+	if err == nil {
+		results = append(results, result)
+	}
+
+	if result, err := runCheck(ctx, "redshift_serverless.workgroup_public_access", c.CheckWorkgroupPublicAccess); err == nil {
+		results = append(results, result)
+	}
+
+	if result, err := runCheck(ctx, "redshift_serverless.workgroup_enhanced_vpc_routing", c.CheckWorkgroupEnhancedVPCRouting); err == nil {
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// EstimateCalls predicts one ListNamespaces and two ListWorkgroups calls;
+// serverless usage does not grow with the number of workgroups
+func (c *RedshiftServerlessChecks) EstimateCalls(ctx context.Context) (CallEstimate, error) {
+	workgroups, err := c.listWorkgroups(ctx)
+	if err != nil {
+		return CallEstimate{}, err
+	}
+
+	return CallEstimate{
+		Resources: len(workgroups),
+		Calls:     3,
+	}, nil
+}
+
+// CheckNamespaceEncryption flags namespaces encrypted with the AWS owned key.
+// Serverless data is always encrypted at rest, but only a customer managed KMS
+// key gives you control over key policy, rotation and revocation.
+func (c *RedshiftServerlessChecks) CheckNamespaceEncryption(ctx context.Context) (CheckResult, error) {
+	namespaces, err := c.listNamespaces(ctx)
+	if err != nil {
+		return CheckResult{}, err
+	}
+
+	awsOwnedKey := []string{}
+
+	for _, namespace := range namespaces {
+		keyID := aws.ToString(namespace.KmsKeyId)
+		if keyID == "" || keyID == redshiftServerlessAWSOwnedKey {
+			awsOwnedKey = append(awsOwnedKey, aws.ToString(namespace.NamespaceName))
+		}
+	}
+
+	if len(awsOwnedKey) > 0 {
+		return CheckResult{
+			Control:           "CC6.3",
+			Name:              "Redshift Serverless Namespace Encryption",
+			Status:            "FAIL",
+			Severity:          "MEDIUM",
+			Evidence:          fmt.Sprintf("%d Redshift Serverless namespaces use the AWS owned key instead of a customer managed KMS key: %s", len(awsOwnedKey), TruncateList(awsOwnedKey, evidenceListLimit(ctx))),
+			AffectedResources: awsOwnedKey,
+			Remediation:       "Encrypt Redshift Serverless namespaces with a customer managed KMS key",
+			RemediationDetail: "aws redshift-serverless update-namespace --namespace-name [NAMESPACE] --kms-key-id [KMS_KEY_ARN] --admin-username [ADMIN_USER] --admin-user-password [PASSWORD]\nNote: changing the key re-encrypts the namespace data",
+			ScreenshotGuide:   "Redshift Console → Serverless dashboard → Namespace configuration → Select namespace → Security and encryption → Screenshot showing a customer managed KMS key",
+			ConsoleURL:        consoleURL("redshiftv2/home#serverless-dashboard", consoleRegion(ctx)),
+			Priority:          PriorityMedium,
+			Timestamp:         nowFunc(),
+			Frameworks:        GetFrameworkMappings("REDSHIFT_ENCRYPTION"),
+		}, nil
+	}
+
+	if len(namespaces) == 0 {
+		return CheckResult{
+			Control:    "CC6.3",
+			Name:       "Redshift Serverless Namespace Encryption",
+			Status:     "PASS",
+			Evidence:   "No Redshift Serverless namespaces found",
+			ConsoleURL: consoleURL("redshiftv2/home#serverless-dashboard", consoleRegion(ctx)),
+			Priority:   PriorityInfo,
+			Timestamp:  nowFunc(),
+			Frameworks: GetFrameworkMappings("REDSHIFT_ENCRYPTION"),
+		}, nil
+	}
+
+	return CheckResult{
+		Control:         "CC6.3",
+		Name:            "Redshift Serverless Namespace Encryption",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d Redshift Serverless namespaces are encrypted with a customer managed KMS key", len(namespaces)),
+		ScreenshotGuide: "Redshift Console → Serverless dashboard → Namespace configuration → Select namespace → Security and encryption → Screenshot showing a customer managed KMS key",
+		ConsoleURL:      consoleURL("redshiftv2/home#serverless-dashboard", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("REDSHIFT_ENCRYPTION"),
+	}, nil
+}
+
+func (c *RedshiftServerlessChecks) CheckWorkgroupPublicAccess(ctx context.Context) (CheckResult, error) {
+	workgroups, err := c.listWorkgroups(ctx)
+	if err != nil {
+		return CheckResult{}, err
+	}
+
+	publicWorkgroups := []string{}
+
+	for _, workgroup := range workgroups {
+		if aws.ToBool(workgroup.PubliclyAccessible) {
+			publicWorkgroups = append(publicWorkgroups, aws.ToString(workgroup.WorkgroupName))
+		}
+	}
+
+	if len(publicWorkgroups) > 0 {
+		return CheckResult{
+			Control:           "CC6.1",
+			Name:              "Redshift Serverless Public Access",
+			Status:            "FAIL",
+			Severity:          "CRITICAL",
+			Evidence:          fmt.Sprintf("%d Redshift Serverless workgroups are publicly accessible: %s", len(publicWorkgroups), TruncateList(publicWorkgroups, evidenceListLimit(ctx))),
+			AffectedResources: publicWorkgroups,
+			Remediation:       "Disable public accessibility for Redshift Serverless workgroups",
+			RemediationDetail: "aws redshift-serverless update-workgroup --workgroup-name [WORKGROUP] --no-publicly-accessible",
+			ScreenshotGuide:   "Redshift Console → Serverless dashboard → Workgroup configuration → Select workgroup → Network and security → Screenshot showing 'Publicly accessible: Off'",
+			ConsoleURL:        consoleURL("redshiftv2/home#serverless-dashboard", consoleRegion(ctx)),
+			Priority:          PriorityCritical,
+			Timestamp:         nowFunc(),
+			Frameworks:        GetFrameworkMappings("REDSHIFT_NETWORK"),
+		}, nil
+	}
+
+	if len(workgroups) == 0 {
+		return CheckResult{
+			Control:    "CC6.1",
+			Name:       "Redshift Serverless Public Access",
+			Status:     "PASS",
+			Evidence:   "No Redshift Serverless workgroups found",
+			ConsoleURL: consoleURL("redshiftv2/home#serverless-dashboard", consoleRegion(ctx)),
+			Priority:   PriorityInfo,
+			Timestamp:  nowFunc(),
+			Frameworks: GetFrameworkMappings("REDSHIFT_NETWORK"),
+		}, nil
+	}
+
+	return CheckResult{
+		Control:         "CC6.1",
+		Name:            "Redshift Serverless Public Access",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d Redshift Serverless workgroups are private (not publicly accessible)", len(workgroups)),
+		ScreenshotGuide: "Redshift Console → Serverless dashboard → Workgroup configuration → Select workgroup → Network and security → Screenshot showing 'Publicly accessible: Off'",
+		ConsoleURL:      consoleURL("redshiftv2/home#serverless-dashboard", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("REDSHIFT_NETWORK"),
+	}, nil
+}
+
+func (c *RedshiftServerlessChecks) CheckWorkgroupEnhancedVPCRouting(ctx context.Context) (CheckResult, error) {
+	workgroups, err := c.listWorkgroups(ctx)
+	if err != nil {
+		return CheckResult{}, err
+	}
+
+	noEnhancedRouting := []string{}
+
+	for _, workgroup := range workgroups {
+		if !aws.ToBool(workgroup.EnhancedVpcRouting) {
+			noEnhancedRouting = append(noEnhancedRouting, aws.ToString(workgroup.WorkgroupName))
+		}
+	}
+
+	if len(noEnhancedRouting) > 0 {
+		return CheckResult{
+			Control:           "CC6.1",
+			Name:              "Redshift Serverless Enhanced VPC Routing",
+			Status:            "FAIL",
+			Severity:          "MEDIUM",
+			Evidence:          fmt.Sprintf("%d Redshift Serverless workgroups without enhanced VPC routing: %s", len(noEnhancedRouting), TruncateList(noEnhancedRouting, evidenceListLimit(ctx))),
+			AffectedResources: noEnhancedRouting,
+			Remediation:       "Enable enhanced VPC routing for Redshift Serverless workgroups",
+			RemediationDetail: "aws redshift-serverless update-workgroup --workgroup-name [WORKGROUP] --enhanced-vpc-routing",
+			ScreenshotGuide:   "Redshift Console → Serverless dashboard → Workgroup configuration → Select workgroup → Network and security → Screenshot showing 'Enhanced VPC routing: On'",
+			ConsoleURL:        consoleURL("redshiftv2/home#serverless-dashboard", consoleRegion(ctx)),
+			Priority:          PriorityMedium,
+			Timestamp:         nowFunc(),
+			Frameworks:        GetFrameworkMappings("REDSHIFT_NETWORK"),
+		}, nil
+	}
+
+	if len(workgroups) == 0 {
+		return CheckResult{
+			Control:    "CC6.1",
+			Name:       "Redshift Serverless Enhanced VPC Routing",
+			Status:     "PASS",
+			Evidence:   "No Redshift Serverless workgroups found",
+			ConsoleURL: consoleURL("redshiftv2/home#serverless-dashboard", consoleRegion(ctx)),
+			Priority:   PriorityInfo,
+			Timestamp:  nowFunc(),
+			Frameworks: GetFrameworkMappings("REDSHIFT_NETWORK"),
+		}, nil
+	}
+
+	return CheckResult{
+		Control:         "CC6.1",
+		Name:            "Redshift Serverless Enhanced VPC Routing",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d Redshift Serverless workgroups have enhanced VPC routing enabled", len(workgroups)),
+		ScreenshotGuide: "Redshift Console → Serverless dashboard → Workgroup configuration → Select workgroup → Network and security → Screenshot showing 'Enhanced VPC routing: On'",
+		ConsoleURL:      consoleURL("redshiftv2/home#serverless-dashboard", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("REDSHIFT_NETWORK"),
+	}, nil
+}
+
+func (c *RedshiftServerlessChecks) listNamespaces(ctx context.Context) ([]redshiftserverlesstypes.Namespace, error) {
+	return paginate(ctx, func(token *string) ([]redshiftserverlesstypes.Namespace, *string, error) {
+		out, err := c.client.ListNamespaces(ctx, &redshiftserverless.ListNamespacesInput{NextToken: token})
+		if err != nil {
+			return nil, nil, err
+		}
+		return out.Namespaces, out.NextToken, nil
+	})
+}
+
+func (c *RedshiftServerlessChecks) listWorkgroups(ctx context.Context) ([]redshiftserverlesstypes.Workgroup, error) {
+	return paginate(ctx, func(token *string) ([]redshiftserverlesstypes.Workgroup, *string, error) {
+		out, err := c.client.ListWorkgroups(ctx, &redshiftserverless.ListWorkgroupsInput{NextToken: token})
+		if err != nil {
+			return nil, nil, err
+		}
+		return out.Workgroups, out.NextToken, nil
+	})
+}
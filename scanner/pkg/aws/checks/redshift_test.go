@@ -0,0 +1,141 @@
+package checks
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/redshift"
+)
+
+func TestRedshiftWindowIsDefault(t *testing.T) {
+	cases := []struct {
+		window string
+		region string
+		want   bool
+	}{
+		{"sat:05:00-sat:05:30", "us-east-1", true},
+		{"sun:10:30-sun:11:00", "us-east-1", true},
+		{"sun:02:30-sun:03:00", "us-east-1", false},
+		{"sun:03:00-sun:04:00", "us-east-1", false},
+		{"tue:23:30-wed:00:00", "eu-west-1", true},
+		{"wed:01:00-wed:01:30", "eu-central-1", true},
+		{"sun:23:45-mon:00:15", "sa-east-1", false},
+		{"sat:05:00-sat:05:30", "unknown-region-1", false},
+		{"not-a-window", "us-east-1", false},
+	}
+
+	for _, tc := range cases {
+		if got := redshiftWindowIsDefault(tc.window, tc.region); got != tc.want {
+			t.Errorf("redshiftWindowIsDefault(%q, %q) = %v, want %v", tc.window, tc.region, got, tc.want)
+		}
+	}
+}
+
+// newStubRedshiftChecks answers Redshift's query-protocol calls from
+// responses, which elastiCacheStub serves by Action as for ElastiCache
+func newStubRedshiftChecks(responses elastiCacheStub) *RedshiftChecks {
+	client := redshift.New(redshift.Options{
+		Region:      "us-east-1",
+		Credentials: aws.AnonymousCredentials{},
+		HTTPClient:  responses,
+		Retryer:     aws.NopRetryer{},
+	})
+	return NewRedshiftChecks(client, nil, nil, nil)
+}
+
+func TestRedshiftAffectedResourcesAreBareIDs(t *testing.T) {
+	c := newStubRedshiftChecks(elastiCacheStub{
+		"DescribeClusters": "<Clusters><Cluster>" +
+			"<ClusterIdentifier>analytics</ClusterIdentifier><ClusterStatus>available</ClusterStatus>" +
+			"<MasterUsername>awsuser</MasterUsername><AutomatedSnapshotRetentionPeriod>3</AutomatedSnapshotRetentionPeriod>" +
+			"<ClusterParameterGroups><ClusterParameterGroup><ParameterGroupName>default.redshift-1.0</ParameterGroupName></ClusterParameterGroup></ClusterParameterGroups>" +
+			"</Cluster></Clusters>",
+	})
+
+	checks := map[string]func(context.Context) (CheckResult, error){
+		"default master username": c.CheckDefaultMasterUsername,
+		"backup retention":        c.CheckClusterBackupRetention,
+		"custom parameter group":  c.CheckCustomParameterGroup,
+	}
+	for name, check := range checks {
+		result, err := check(context.Background())
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if result.Status != "FAIL" {
+			t.Errorf("%s: status %s, want FAIL: %s", name, result.Status, result.Evidence)
+			continue
+		}
+		if want := []string{"analytics"}; !reflect.DeepEqual(result.AffectedResources, want) {
+			t.Errorf("%s: affected resources %v, want %v", name, result.AffectedResources, want)
+		}
+	}
+}
+
+// redshiftDeniedStub serves elastiCacheStub's responses but refuses the
+// denied Action with AccessDenied
+type redshiftDeniedStub struct {
+	responses elastiCacheStub
+	denied    string
+}
+
+func (s redshiftDeniedStub) Do(req *http.Request) (*http.Response, error) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, err
+	}
+	if form.Get("Action") != s.denied {
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		return s.responses.Do(req)
+	}
+
+	xml := "<ErrorResponse><Error><Type>Sender</Type><Code>AccessDenied</Code><Message>not authorized</Message></Error></ErrorResponse>"
+	return &http.Response{
+		StatusCode:    http.StatusForbidden,
+		Status:        "403 Forbidden",
+		Header:        http.Header{"Content-Type": []string{"text/xml"}},
+		Body:          io.NopCloser(strings.NewReader(xml)),
+		ContentLength: int64(len(xml)),
+		Request:       req,
+	}, nil
+}
+
+func TestRedshiftLoggingStatusFailureIsUnverified(t *testing.T) {
+	client := redshift.New(redshift.Options{
+		Region:      "us-east-1",
+		Credentials: aws.AnonymousCredentials{},
+		HTTPClient: redshiftDeniedStub{
+			responses: elastiCacheStub{
+				"DescribeClusters": "<Clusters><Cluster><ClusterIdentifier>analytics</ClusterIdentifier><ClusterStatus>available</ClusterStatus></Cluster></Clusters>",
+			},
+			denied: "DescribeLoggingStatus",
+		},
+		Retryer: aws.NopRetryer{},
+	})
+	c := NewRedshiftChecks(client, nil, nil, nil)
+
+	result, err := c.CheckLoggingDestinationSecure(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Status != "ERROR" {
+		t.Fatalf("status %s, want ERROR when the logging status cannot be read: %s", result.Status, result.Evidence)
+	}
+	if want := []string{"analytics"}; !reflect.DeepEqual(result.AffectedResources, want) {
+		t.Errorf("affected resources %v, want %v", result.AffectedResources, want)
+	}
+	if !strings.Contains(result.Evidence, "analytics (logging status unavailable)") {
+		t.Errorf("evidence %q should list the cluster as unverified", result.Evidence)
+	}
+}
@@ -0,0 +1,76 @@
+package checks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// regionUnavailableMessages are the fragments the SDK's endpoint resolvers
+// wrap their errors in when a service has no endpoint in the configured region
+var regionUnavailableMessages = []string{
+	"failed to resolve service endpoint",
+	"could not resolve endpoint",
+}
+
+// isServiceUnavailableInRegion reports whether err means the service does not
+// exist in the scanned region, as opposed to a permissions or API failure.
+// Newer services (OpenSearch, SageMaker, Redshift Serverless) are missing
+// from some regions: the SDK fails to resolve an endpoint there, or resolves
+// one whose host does not exist in DNS. Other network errors, including DNS
+// timeouts and lookups of non-AWS hosts such as a proxy, are not treated as
+// unavailability, since they also occur on flaky connections in supported
+// regions.
+func isServiceUnavailableInRegion(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var notFound *aws.EndpointNotFoundError
+	if errors.As(err, &notFound) {
+		return true
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound && isAWSServiceHost(dnsErr.Name) {
+		return true
+	}
+
+	message := strings.ToLower(err.Error())
+	for _, fragment := range regionUnavailableMessages {
+		if strings.Contains(message, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// isAWSServiceHost reports whether host is an AWS service endpoint, e.g.
+// "es.ap-southeast-7.amazonaws.com" or a China region's ".amazonaws.com.cn"
+func isAWSServiceHost(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	return strings.HasSuffix(host, ".amazonaws.com") || strings.HasSuffix(host, ".amazonaws.com.cn")
+}
+
+// serviceUnavailableResult is the INFO result a module returns instead of its
+// checks when the service is not offered in the scanned region, so
+// multi-region reports show the gap rather than silently omitting it
+func serviceUnavailableResult(ctx context.Context, service, control string) CheckResult {
+	region := consoleRegion(ctx)
+	if region == "" {
+		region = "the scanned region"
+	}
+
+	return CheckResult{
+		Control:   control,
+		Name:      fmt.Sprintf("%s Regional Availability", service),
+		Status:    "INFO",
+		Evidence:  fmt.Sprintf("%s is not available in region %s; its checks were skipped", service, region),
+		Priority:  PriorityInfo,
+		Timestamp: nowFunc(),
+	}
+}
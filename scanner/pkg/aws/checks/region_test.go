@@ -0,0 +1,33 @@
+package checks
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+func TestIsServiceUnavailableInRegion(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"endpoint not found", fmt.Errorf("list domains: %w", &aws.EndpointNotFoundError{Err: errors.New("no partition")}), true},
+		{"resolver failure", errors.New("operation error SageMaker: ListNotebookInstances, failed to resolve service endpoint, endpoint rule error"), true},
+		{"dns not found", fmt.Errorf("send request: %w", &net.DNSError{Err: "no such host", Name: "es.ap-southeast-7.amazonaws.com", IsNotFound: true}), true},
+		{"dns not found in china", fmt.Errorf("send request: %w", &net.DNSError{Err: "no such host", Name: "sagemaker.cn-northwest-1.amazonaws.com.cn.", IsNotFound: true}), true},
+		{"dns not found for proxy", fmt.Errorf("proxyconnect tcp: %w", &net.DNSError{Err: "no such host", Name: "proxy.corp.example", IsNotFound: true}), false},
+		{"dns timeout", fmt.Errorf("send request: %w", &net.DNSError{Err: "i/o timeout", Name: "es.us-east-1.amazonaws.com", IsTimeout: true, IsTemporary: true}), false},
+		{"access denied", errors.New("AccessDeniedException: not authorized"), false},
+	}
+
+	for _, tc := range cases {
+		if got := isServiceUnavailableInRegion(tc.err); got != tc.want {
+			t.Errorf("%s: isServiceUnavailableInRegion = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package checks
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ResourceFilter narrows a scan to named resources, for spot-checking one
+// cluster or domain after a fix instead of the whole account. Only modules
+// that support filtering honor it: Redshift (by ClusterIdentifier) and
+// OpenSearch (by DomainName). An empty list leaves that service unfiltered.
+type ResourceFilter struct {
+	RedshiftClusters  []string
+	OpenSearchDomains []string
+}
+
+// IsEmpty reports whether the filter names no resources
+func (f ResourceFilter) IsEmpty() bool {
+	return len(f.RedshiftClusters) == 0 && len(f.OpenSearchDomains) == 0
+}
+
+// ParseResourceFilter parses a comma-separated list of service:name pairs,
+// e.g. "redshift:analytics,opensearch:logs". An empty spec is an empty filter.
+func ParseResourceFilter(spec string) (ResourceFilter, error) {
+	var filter ResourceFilter
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		service, name, ok := strings.Cut(entry, ":")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return ResourceFilter{}, fmt.Errorf("invalid resource %q: expected service:name, e.g. redshift:analytics", entry)
+		}
+
+		switch strings.ToLower(strings.TrimSpace(service)) {
+		case "redshift":
+			filter.RedshiftClusters = append(filter.RedshiftClusters, name)
+		case "opensearch":
+			filter.OpenSearchDomains = append(filter.OpenSearchDomains, name)
+		default:
+			return ResourceFilter{}, fmt.Errorf("invalid resource %q: service must be redshift or opensearch", entry)
+		}
+	}
+	return filter, nil
+}
+
+// ApplyResourceFilter narrows modules to the resources filter names. Modules
+// the filter names no resources for are dropped, so a spot check runs only
+// the checks for the named resources. An empty filter returns modules
+// unchanged.
+func ApplyResourceFilter(modules []Check, filter ResourceFilter) []Check {
+	if filter.IsEmpty() {
+		return modules
+	}
+
+	scoped := []Check{}
+	for _, module := range modules {
+		switch m := module.(type) {
+		case *RedshiftChecks:
+			if len(filter.RedshiftClusters) > 0 {
+				m.SetResourceFilter(filter)
+				scoped = append(scoped, m)
+			}
+		case *OpenSearchChecks:
+			if len(filter.OpenSearchDomains) > 0 {
+				m.SetResourceFilter(filter)
+				scoped = append(scoped, m)
+			}
+		}
+	}
+	return scoped
+}
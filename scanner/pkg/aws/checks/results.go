@@ -0,0 +1,85 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SortResults orders results by service, then control, then name, then
+// region and evidence, so report output is identical across runs regardless
+// of goroutine scheduling, even when two modules share a name
+func SortResults(results []CheckResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Service != results[j].Service {
+			return results[i].Service < results[j].Service
+		}
+		if results[i].Control != results[j].Control {
+			return results[i].Control < results[j].Control
+		}
+		if results[i].Name != results[j].Name {
+			return results[i].Name < results[j].Name
+		}
+		if results[i].Region != results[j].Region {
+			return results[i].Region < results[j].Region
+		}
+		return results[i].Evidence < results[j].Evidence
+	})
+}
+
+// FilterByStatus returns the results whose Status matches one of statuses
+// (case-insensitive). With no statuses the input is returned unchanged.
+func FilterByStatus(results []CheckResult, statuses ...string) []CheckResult {
+	if len(statuses) == 0 {
+		return results
+	}
+
+	filtered := []CheckResult{}
+	for _, r := range results {
+		for _, status := range statuses {
+			if strings.EqualFold(r.Status, status) {
+				filtered = append(filtered, r)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+// DefaultEvidenceListLimit is how many resource IDs an Evidence string lists
+// before the rest are summarised as "and N more", sized for the terminal
+const DefaultEvidenceListLimit = 10
+
+// NoEvidenceListLimit lists every resource ID, for file reports where the
+// complete list is the evidence
+const NoEvidenceListLimit = -1
+
+// SetEvidenceListLimit sets how many resource IDs Evidence strings list in
+// runs without a Scan: NoEvidenceListLimit (or any negative value) lists them
+// all, 0 restores DefaultEvidenceListLimit. Set it before the scan; results
+// already produced keep the list they were built with.
+func SetEvidenceListLimit(limit int) {
+	defaultScan.evidenceLimit.Store(int64(limit))
+}
+
+// evidenceListLimit is the limit ctx's Scan uses, for TruncateList calls in
+// checks
+func evidenceListLimit(ctx context.Context) int {
+	if limit := int(scanFrom(ctx).evidenceLimit.Load()); limit != 0 {
+		return limit
+	}
+	return DefaultEvidenceListLimit
+}
+
+// TruncateList formats items the way Evidence strings print resource lists,
+// "[a b c]", keeping at most max items and appending "and N more" for the
+// rest. A list of exactly max items is printed in full. Checks set the full
+// list as the result's AffectedResources, so reports that need every
+// resource do not depend on the limit.
+func TruncateList(items []string, max int) string {
+	if max < 0 || len(items) <= max {
+		return fmt.Sprintf("%v", items)
+	}
+	return fmt.Sprintf("%v and %d more", items[:max], len(items)-max)
+}
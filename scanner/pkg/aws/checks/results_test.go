@@ -0,0 +1,79 @@
+package checks
+
+import (
+	"context"
+	"sync"
+	"testing"
+)
+
+func TestSetEvidenceListLimit(t *testing.T) {
+	defer SetEvidenceListLimit(0)
+
+	items := []string{"a", "b", "c", "d"}
+
+	SetEvidenceListLimit(2)
+	if got, want := TruncateList(items, evidenceListLimit(context.Background())), "[a b] and 2 more"; got != want {
+		t.Errorf("limit 2: got %q, want %q", got, want)
+	}
+
+	SetEvidenceListLimit(NoEvidenceListLimit)
+	if got, want := TruncateList(items, evidenceListLimit(context.Background())), "[a b c d]"; got != want {
+		t.Errorf("no limit: got %q, want %q", got, want)
+	}
+
+	SetEvidenceListLimit(0)
+	if got := evidenceListLimit(context.Background()); got != DefaultEvidenceListLimit {
+		t.Errorf("limit 0: got %d, want the default %d", got, DefaultEvidenceListLimit)
+	}
+}
+
+// Runners set the limit while checks from another scan may be formatting
+// evidence; run with -race
+func TestSetEvidenceListLimitConcurrent(t *testing.T) {
+	defer SetEvidenceListLimit(0)
+
+	items := []string{"a", "b", "c"}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func(limit int) {
+			defer wg.Done()
+			SetEvidenceListLimit(limit)
+		}(i%3 + 1)
+		go func() {
+			defer wg.Done()
+			_ = TruncateList(items, evidenceListLimit(context.Background()))
+		}()
+	}
+	wg.Wait()
+}
+
+func TestTruncateListBoundaries(t *testing.T) {
+	list := func(n int) []string {
+		items := make([]string, n)
+		for i := range items {
+			items[i] = string(rune('a' + i))
+		}
+		return items
+	}
+
+	tests := []struct {
+		name  string
+		items []string
+		max   int
+		want  string
+	}{
+		{"empty", list(0), 3, "[]"},
+		{"max-1 items", list(2), 3, "[a b]"},
+		{"exactly max items", list(3), 3, "[a b c]"},
+		{"max+1 items", list(4), 3, "[a b c] and 1 more"},
+		{"well over max", list(6), 3, "[a b c] and 3 more"},
+		{"max 0", list(2), 0, "[] and 2 more"},
+		{"no limit", list(6), NoEvidenceListLimit, "[a b c d e f]"},
+	}
+	for _, tt := range tests {
+		if got := TruncateList(tt.items, tt.max); got != tt.want {
+			t.Errorf("%s: TruncateList(%v, %d) = %q, want %q", tt.name, tt.items, tt.max, got, tt.want)
+		}
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/route53"
 )
@@ -24,7 +23,7 @@ func (c *Route53Checks) Name() string {
 func (c *Route53Checks) Run(ctx context.Context) ([]CheckResult, error) {
 	results := []CheckResult{}
 
-	if result, err := c.CheckDNSSEC(ctx); err == nil {
+	if result, err := runCheck(ctx, "route53.dnssec", c.CheckDNSSEC); err == nil {
 		results = append(results, result)
 	}
 
@@ -44,7 +43,7 @@ func (c *Route53Checks) CheckDNSSEC(ctx context.Context) (CheckResult, error) {
 			Evidence:  fmt.Sprintf("Unable to check Route53 hosted zones: %v", err),
 			Severity:  "MEDIUM",
 			Priority:  PriorityMedium,
-			Timestamp: time.Now(),
+			Timestamp: nowFunc(),
 			Frameworks: GetFrameworkMappings("ROUTE53_DNSSEC"),
 		}, err
 	}
@@ -56,8 +55,9 @@ func (c *Route53Checks) CheckDNSSEC(ctx context.Context) (CheckResult, error) {
 			Status:     "PASS",
 			Evidence:   "No Route53 hosted zones found",
 			Severity:   "INFO",
+			ConsoleURL: consoleURL("route53/v2/hostedzones", consoleRegion(ctx)),
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("ROUTE53_DNSSEC"),
 		}, nil
 	}
@@ -112,29 +112,31 @@ aws route53 enable-hosted-zone-dnssec --hosted-zone-id $(aws route53 list-hosted
 4. Click 'Enable DNSSEC signing'
 5. Follow the wizard to create KSK and enable signing`, nonDNSSECZones[0], nonDNSSECZones[0]),
 			ScreenshotGuide: fmt.Sprintf(`Route53 DNSSEC Evidence:
-1. Open Route53 Console: https://console.aws.amazon.com/route53/
+1. Open Route53 Console: %s
 2. Click 'Hosted zones' in left navigation
 3. Click on zone '%s'
 4. Click 'DNSSEC signing' tab
 5. Screenshot showing:
    - DNSSEC signing status: Enabled
    - Key-signing key (KSK) status: Active
-   - DNSSEC validation: Enabled`, nonDNSSECZones[0]),
-			ConsoleURL:      fmt.Sprintf("https://console.aws.amazon.com/route53/v2/hostedzones"),
+   - DNSSEC validation: Enabled`, consoleURL("route53/", consoleRegion(ctx)), nonDNSSECZones[0]),
+			ConsoleURL:      consoleURL("route53/v2/hostedzones", consoleRegion(ctx)),
 			Priority:        PriorityMedium,
-			Timestamp:       time.Now(),
+			Timestamp:       nowFunc(),
 			Frameworks:      GetFrameworkMappings("ROUTE53_DNSSEC"),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "CIS-5.19",
-		Name:       "Route53 DNSSEC Enabled",
-		Status:     "PASS",
-		Evidence:   fmt.Sprintf("All %d Route53 public hosted zones have DNSSEC enabled | Meets CIS AWS 5.19 (DNS integrity protection)", checkedCount),
-		Severity:   "INFO",
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("ROUTE53_DNSSEC"),
+		Control:         "CIS-5.19",
+		Name:            "Route53 DNSSEC Enabled",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d Route53 public hosted zones have DNSSEC enabled | Meets CIS AWS 5.19 (DNS integrity protection)", checkedCount),
+		Severity:        "INFO",
+		ScreenshotGuide: "Route53 Console → Hosted zones → Select zone → DNSSEC signing → Screenshot showing 'DNSSEC signing: Enabled'",
+		ConsoleURL:      consoleURL("route53/v2/hostedzones", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("ROUTE53_DNSSEC"),
 	}, nil
 }
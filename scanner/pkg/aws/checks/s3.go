@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
@@ -26,41 +25,41 @@ func (c *S3Checks) Run(ctx context.Context) ([]CheckResult, error) {
 	results := []CheckResult{}
 
 	// Existing checks
-	if result, err := c.CheckPublicAccess(ctx); err == nil {
+	if result, err := runCheck(ctx, "s3.public_access", c.CheckPublicAccess); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckEncryption(ctx); err == nil {
+	if result, err := runCheck(ctx, "s3.encryption", c.CheckEncryption); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckVersioning(ctx); err == nil {
+	if result, err := runCheck(ctx, "s3.versioning", c.CheckVersioning); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckLogging(ctx); err == nil {
+	if result, err := runCheck(ctx, "s3.logging", c.CheckLogging); err == nil {
 		results = append(results, result)
 	}
 
 	// NEW CIS checks
-	if result, err := c.CheckMFADelete(ctx); err == nil {
+	if result, err := runCheck(ctx, "s3.mfa_delete", c.CheckMFADelete); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckServerAccessLogging(ctx); err == nil {
+	if result, err := runCheck(ctx, "s3.server_access_logging", c.CheckServerAccessLogging); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckObjectLock(ctx); err == nil {
+	if result, err := runCheck(ctx, "s3.object_lock", c.CheckObjectLock); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckS3LifecyclePolicy(ctx); err == nil {
+	if result, err := runCheck(ctx, "s3.lifecycle_policy", c.CheckS3LifecyclePolicy); err == nil {
 		results = append(results, result)
 	}
 
 	// Additional CIS AWS controls
-	if result, err := c.CheckAccountPublicAccessBlock(ctx); err == nil {
+	if result, err := runCheck(ctx, "s3.account_public_access_block", c.CheckAccountPublicAccessBlock); err == nil {
 		results = append(results, result)
 	}
 
@@ -77,7 +76,7 @@ func (c *S3Checks) CheckPublicAccess(ctx context.Context) (CheckResult, error) {
 			Evidence:  fmt.Sprintf("Unable to check S3 buckets: %v", err),
 			Severity:  "HIGH",
 			Priority:  PriorityHigh,
-			Timestamp: time.Now(),
+			Timestamp: nowFunc(),
 			Frameworks: GetFrameworkMappings("S3_PUBLIC_ACCESS"),
 		}, err
 	}
@@ -89,8 +88,9 @@ func (c *S3Checks) CheckPublicAccess(ctx context.Context) (CheckResult, error) {
 			Status:     "PASS",
 			Evidence:   "No S3 buckets found",
 			Severity:   "INFO",
+			ConsoleURL: consoleURL("s3/buckets", consoleRegion(ctx)),
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("S3_PUBLIC_ACCESS"),
 		}, nil
 	}
@@ -140,9 +140,9 @@ func (c *S3Checks) CheckPublicAccess(ctx context.Context) (CheckResult, error) {
 			Remediation:       fmt.Sprintf("Block public access on bucket: %s\nRun: aws s3api put-public-access-block", publicBuckets[0]),
 			RemediationDetail: fmt.Sprintf("aws s3api put-public-access-block --bucket %s --public-access-block-configuration BlockPublicAcls=true,IgnorePublicAcls=true,BlockPublicPolicy=true,RestrictPublicBuckets=true", publicBuckets[0]),
 			ScreenshotGuide:   "1. Open S3 Console\n2. Click on bucket '" + publicBuckets[0] + "'\n3. Go to 'Permissions' tab\n4. Screenshot 'Block public access' section\n5. All 4 options must show 'On'\n6. For PCI DSS: Document that cardholder data is NOT stored here",
-			ConsoleURL:        fmt.Sprintf("https://s3.console.aws.amazon.com/s3/buckets/%s?tab=permissions", publicBuckets[0]),
+			ConsoleURL:        consoleURL(fmt.Sprintf("s3/buckets/%s?tab=permissions", publicBuckets[0]), consoleRegion(ctx)),
 			Priority:          PriorityCritical,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("S3_PUBLIC_ACCESS"),
 		}, nil
 	}
@@ -154,9 +154,9 @@ func (c *S3Checks) CheckPublicAccess(ctx context.Context) (CheckResult, error) {
 		Evidence:        fmt.Sprintf("All %d S3 buckets block public access | Meets SOC2 CC6.2, PCI DSS 1.2.1, HIPAA 164.312(a)(1)", checkedCount),
 		Severity:        "INFO",
 		ScreenshotGuide: "1. Open S3 Console\n2. Click any bucket\n3. Go to 'Permissions' tab\n4. Screenshot showing all 'Block public access' settings ON",
-		ConsoleURL:      "https://s3.console.aws.amazon.com/s3/buckets",
+		ConsoleURL:      consoleURL("s3/buckets", consoleRegion(ctx)),
 		Priority:        PriorityInfo,
-		Timestamp:       time.Now(),
+		Timestamp:       nowFunc(),
 		Frameworks:      GetFrameworkMappings("S3_PUBLIC_ACCESS"),
 	}, nil
 }
@@ -197,22 +197,24 @@ func (c *S3Checks) CheckEncryption(ctx context.Context) (CheckResult, error) {
 			Remediation:       fmt.Sprintf("Enable encryption on: %s\nRun: aws s3api put-bucket-encryption", unencryptedBuckets[0]),
 			RemediationDetail: fmt.Sprintf("aws s3api put-bucket-encryption --bucket %s --server-side-encryption-configuration '{\"Rules\": [{\"ApplyServerSideEncryptionByDefault\": {\"SSEAlgorithm\": \"AES256\"}}]}'", unencryptedBuckets[0]),
 			ScreenshotGuide:   "1. Open S3 Console\n2. Click bucket '" + unencryptedBuckets[0] + "'\n3. Go to 'Properties' tab\n4. Scroll to 'Default encryption'\n5. Screenshot showing 'Server-side encryption: Enabled'\n6. For HIPAA: Note encryption algorithm (AES-256)",
-			ConsoleURL:        fmt.Sprintf("https://s3.console.aws.amazon.com/s3/buckets/%s?tab=properties", unencryptedBuckets[0]),
+			ConsoleURL:        consoleURL(fmt.Sprintf("s3/buckets/%s?tab=properties", unencryptedBuckets[0]), consoleRegion(ctx)),
 			Priority:          PriorityHigh,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("S3_ENCRYPTION"),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "CC6.3",
-		Name:       "S3 Encryption at Rest",
-		Status:     "PASS",
-		Evidence:   fmt.Sprintf("All %d S3 buckets have encryption enabled | Meets SOC2 CC6.3, PCI DSS 3.4, HIPAA 164.312(a)(2)(iv)", checkedCount),
-		Severity:   "INFO",
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("S3_ENCRYPTION"),
+		Control:         "CC6.3",
+		Name:            "S3 Encryption at Rest",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d S3 buckets have encryption enabled | Meets SOC2 CC6.3, PCI DSS 3.4, HIPAA 164.312(a)(2)(iv)", checkedCount),
+		Severity:        "INFO",
+		ScreenshotGuide: "1. Open S3 Console\n2. Click any bucket\n3. Go to 'Properties' tab\n4. Scroll to 'Default encryption'\n5. Screenshot showing 'Server-side encryption: Enabled'",
+		ConsoleURL:      consoleURL("s3/buckets", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("S3_ENCRYPTION"),
 	}, nil
 }
 
@@ -245,21 +247,23 @@ func (c *S3Checks) CheckVersioning(ctx context.Context) (CheckResult, error) {
 			Remediation:       fmt.Sprintf("Enable versioning on: %s", firstBucket),
 			RemediationDetail: fmt.Sprintf("aws s3api put-bucket-versioning --bucket %s --versioning-configuration Status=Enabled", firstBucket),
 			Priority:          PriorityMedium,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("S3_VERSIONING"),
 			ScreenshotGuide:   "1. Open S3 Console\n2. Click bucket '" + firstBucket + "'\n3. Go to 'Properties' tab\n4. Screenshot 'Bucket Versioning' showing 'Enabled'",
-			ConsoleURL:        fmt.Sprintf("https://s3.console.aws.amazon.com/s3/buckets/%s?tab=properties", firstBucket),
+			ConsoleURL:        consoleURL(fmt.Sprintf("s3/buckets/%s?tab=properties", firstBucket), consoleRegion(ctx)),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "A1.2",
-		Name:       "S3 Versioning for Backup",
-		Status:     "PASS",
-		Evidence:   "All buckets have versioning enabled | Meets SOC2 A1.2, PCI DSS 10.5.5, HIPAA 164.312(c)(1)",
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("S3_VERSIONING"),
+		Control:         "A1.2",
+		Name:            "S3 Versioning for Backup",
+		Status:          "PASS",
+		Evidence:        "All buckets have versioning enabled | Meets SOC2 A1.2, PCI DSS 10.5.5, HIPAA 164.312(c)(1)",
+		ScreenshotGuide: "1. Open S3 Console\n2. Click any bucket\n3. Go to 'Properties' tab\n4. Screenshot 'Bucket Versioning' showing 'Enabled'",
+		ConsoleURL:      consoleURL("s3/buckets", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("S3_VERSIONING"),
 	}, nil
 }
 
@@ -273,7 +277,7 @@ func (c *S3Checks) CheckLogging(ctx context.Context) (CheckResult, error) {
 			Evidence:   fmt.Sprintf("Unable to check S3 buckets: %v", err),
 			Severity:   "HIGH",
 			Priority:   PriorityHigh,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("S3_LOGGING"),
 		}, err
 	}
@@ -284,8 +288,9 @@ func (c *S3Checks) CheckLogging(ctx context.Context) (CheckResult, error) {
 			Name:       "S3 Access Logging",
 			Status:     "PASS",
 			Evidence:   "No S3 buckets found",
+			ConsoleURL: consoleURL("s3/buckets", consoleRegion(ctx)),
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("S3_LOGGING"),
 		}, nil
 	}
@@ -320,21 +325,23 @@ func (c *S3Checks) CheckLogging(ctx context.Context) (CheckResult, error) {
 			Remediation:       fmt.Sprintf("Enable server access logging on bucket: %s", bucketsWithoutLogging[0]),
 			RemediationDetail: fmt.Sprintf("aws s3api put-bucket-logging --bucket %s --bucket-logging-status '{\"LoggingEnabled\":{\"TargetBucket\":\"my-log-bucket\",\"TargetPrefix\":\"%s/\"}}'", bucketsWithoutLogging[0], bucketsWithoutLogging[0]),
 			ScreenshotGuide:   "1. Open S3 Console\n2. Click bucket '" + bucketsWithoutLogging[0] + "'\n3. Go to 'Properties' tab\n4. Scroll to 'Server access logging'\n5. Screenshot showing 'Server access logging: Enabled'\n6. For PCI DSS: Document log retention period",
-			ConsoleURL:        fmt.Sprintf("https://s3.console.aws.amazon.com/s3/buckets/%s?tab=properties", bucketsWithoutLogging[0]),
+			ConsoleURL:        consoleURL(fmt.Sprintf("s3/buckets/%s?tab=properties", bucketsWithoutLogging[0]), consoleRegion(ctx)),
 			Priority:          PriorityMedium,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("S3_LOGGING"),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "CC7.1",
-		Name:       "S3 Access Logging",
-		Status:     "PASS",
-		Evidence:   fmt.Sprintf("All %d S3 buckets have access logging enabled | Meets SOC2 CC7.1, PCI DSS 10.2", checkedCount),
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("S3_LOGGING"),
+		Control:         "CC7.1",
+		Name:            "S3 Access Logging",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d S3 buckets have access logging enabled | Meets SOC2 CC7.1, PCI DSS 10.2", checkedCount),
+		ScreenshotGuide: "1. Open S3 Console\n2. Click any bucket\n3. Go to 'Properties' tab\n4. Scroll to 'Server access logging'\n5. Screenshot showing 'Server access logging: Enabled'",
+		ConsoleURL:      consoleURL("s3/buckets", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("S3_LOGGING"),
 	}, nil
 }
 
@@ -369,21 +376,23 @@ func (c *S3Checks) CheckMFADelete(ctx context.Context) (CheckResult, error) {
 			Remediation:       "Enable MFA Delete on critical S3 buckets",
 			RemediationDetail: "1. MFA Delete can only be enabled by root account\n2. Sign in as root with MFA\n3. Run: aws s3api put-bucket-versioning --bucket [BUCKET] --versioning-configuration Status=Enabled,MFADelete=Enabled --mfa 'arn:aws:iam::ACCOUNT:mfa/root-account-mfa-device MFACODE'",
 			ScreenshotGuide:   "S3 Console → Bucket → Properties → Bucket Versioning → Screenshot showing 'MFA delete: Enabled'",
-			ConsoleURL:        "https://s3.console.aws.amazon.com/s3/buckets",
+			ConsoleURL:        consoleURL("s3/buckets", consoleRegion(ctx)),
 			Priority:          PriorityMedium,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("S3_MFA_DELETE"),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "[CIS-2.1.2]",
-		Name:       "S3 MFA Delete",
-		Status:     "PASS",
-		Evidence:   "All S3 buckets have MFA Delete enabled",
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("S3_MFA_DELETE"),
+		Control:         "[CIS-2.1.2]",
+		Name:            "S3 MFA Delete",
+		Status:          "PASS",
+		Evidence:        "All S3 buckets have MFA Delete enabled",
+		ScreenshotGuide: "S3 Console → Bucket → Properties → Bucket Versioning → Screenshot showing 'MFA delete: Enabled'",
+		ConsoleURL:      consoleURL("s3/buckets", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("S3_MFA_DELETE"),
 	}, nil
 }
 
@@ -417,21 +426,23 @@ func (c *S3Checks) CheckServerAccessLogging(ctx context.Context) (CheckResult, e
 			Remediation:       "Enable S3 server access logging",
 			RemediationDetail: fmt.Sprintf("aws s3api put-bucket-logging --bucket %s --bucket-logging-status '{\"LoggingEnabled\":{\"TargetBucket\":\"my-log-bucket\",\"TargetPrefix\":\"%s/\"}}'", firstBucket, firstBucket),
 			ScreenshotGuide:   "S3 Console → Bucket → Properties → Server access logging → Screenshot showing 'Enabled'",
-			ConsoleURL:        fmt.Sprintf("https://s3.console.aws.amazon.com/s3/buckets/%s?tab=properties", firstBucket),
+			ConsoleURL:        consoleURL(fmt.Sprintf("s3/buckets/%s?tab=properties", firstBucket), consoleRegion(ctx)),
 			Priority:          PriorityMedium,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("S3_LOGGING"),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "[CIS-2.1.4]",
-		Name:       "S3 Server Access Logging",
-		Status:     "PASS",
-		Evidence:   "All S3 buckets have server access logging enabled",
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("S3_LOGGING"),
+		Control:         "[CIS-2.1.4]",
+		Name:            "S3 Server Access Logging",
+		Status:          "PASS",
+		Evidence:        "All S3 buckets have server access logging enabled",
+		ScreenshotGuide: "S3 Console → Bucket → Properties → Server access logging → Screenshot showing 'Enabled'",
+		ConsoleURL:      consoleURL("s3/buckets", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("S3_LOGGING"),
 	}, nil
 }
 
@@ -445,9 +456,9 @@ func (c *S3Checks) CheckObjectLock(ctx context.Context) (CheckResult, error) {
 		Remediation:       "Enable Object Lock on S3 buckets that require WORM (write-once-read-many) protection",
 		RemediationDetail: "1. Object Lock can only be enabled during bucket creation\n2. Create new bucket with: aws s3api create-bucket --bucket [NAME] --object-lock-enabled-for-bucket\n3. Configure retention: aws s3api put-object-lock-configuration --bucket [NAME] --object-lock-configuration ...",
 		ScreenshotGuide:   "S3 Console → Bucket → Properties → Object Lock → Screenshot showing 'Enabled' with retention configuration",
-		ConsoleURL:        "https://s3.console.aws.amazon.com/s3/buckets",
+		ConsoleURL:        consoleURL("s3/buckets", consoleRegion(ctx)),
 		Priority:          PriorityLow,
-		Timestamp:         time.Now(),
+		Timestamp:         nowFunc(),
 		Frameworks:        GetFrameworkMappings("S3_OBJECT_LOCK"),
 	}, nil
 }
@@ -462,9 +473,9 @@ func (c *S3Checks) CheckS3LifecyclePolicy(ctx context.Context) (CheckResult, err
 		Remediation:       "Configure lifecycle policies for data retention and cost optimization",
 		RemediationDetail: "1. Identify buckets needing lifecycle management\n2. Create lifecycle policy based on retention requirements\n3. Configure transitions to IA/Glacier for cost savings\n4. Set expiration rules for compliance",
 		ScreenshotGuide:   "S3 Console → Bucket → Management → Lifecycle rules → Screenshot showing configured lifecycle policies",
-		ConsoleURL:        "https://s3.console.aws.amazon.com/s3/buckets",
+		ConsoleURL:        consoleURL("s3/buckets", consoleRegion(ctx)),
 		Priority:          PriorityMedium,
-		Timestamp:         time.Now(),
+		Timestamp:         nowFunc(),
 		Frameworks:        GetFrameworkMappings("S3_LIFECYCLE"),
 	}, nil
 }
@@ -491,9 +502,9 @@ aws s3control put-public-access-block \
 
 # This protects all S3 buckets in the account from public access`,
 				ScreenshotGuide:   "S3 Console → Block Public Access settings for this account → Screenshot showing ALL 4 settings enabled",
-				ConsoleURL:        "https://s3.console.aws.amazon.com/s3/settings",
+				ConsoleURL:        consoleURL("s3/settings", consoleRegion(ctx)),
 				Priority:          PriorityCritical,
-				Timestamp:         time.Now(),
+				Timestamp:         nowFunc(),
 				Frameworks:        map[string]string{"CIS-AWS": "2.1.7", "SOC2": "CC6.1", "PCI-DSS": "1.2.1"},
 			}, nil
 		}
@@ -504,7 +515,7 @@ aws s3control put-public-access-block \
 			Status:     "FAIL",
 			Evidence:   fmt.Sprintf("Unable to check account-level public access block: %v", err),
 			Priority:   PriorityHigh,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: map[string]string{"CIS-AWS": "2.1.7", "SOC2": "CC6.1"},
 		}, nil
 	}
@@ -543,9 +554,9 @@ aws s3control put-public-access-block \
   --public-access-block-configuration \
     BlockPublicAcls=true,IgnorePublicAcls=true,BlockPublicPolicy=true,RestrictPublicBuckets=true`,
 			ScreenshotGuide:   "S3 Console → Block Public Access settings → Screenshot showing ALL 4 checkboxes enabled",
-			ConsoleURL:        "https://s3.console.aws.amazon.com/s3/settings",
+			ConsoleURL:        consoleURL("s3/settings", consoleRegion(ctx)),
 			Priority:          PriorityHigh,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        map[string]string{"CIS-AWS": "2.1.7", "SOC2": "CC6.1", "PCI-DSS": "1.2.1"},
 		}, nil
 	}
@@ -555,8 +566,9 @@ aws s3control put-public-access-block \
 		Name:       "S3 Account Public Access Block",
 		Status:     "PASS",
 		Evidence:   "S3 Block Public Access is enabled at account level (all 4 settings) | Meets CIS 2.1.7",
+		ConsoleURL: consoleURL("s3/settings", consoleRegion(ctx)),
 		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
+		Timestamp:  nowFunc(),
 		Frameworks: map[string]string{"CIS-AWS": "2.1.7", "SOC2": "CC6.1", "PCI-DSS": "1.2.1"},
 	}, nil
 }
@@ -2,55 +2,133 @@ package checks
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
-	"time"
+	"net/url"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
 	"github.com/aws/aws-sdk-go-v2/service/sagemaker"
 )
 
 type SageMakerChecks struct {
-	client *sagemaker.Client
+	client    *sagemaker.Client
+	iamClient *iam.Client
+
+	// endpoints is the endpoint listing shared by the endpoint checks
+	endpoints []sageMakerEndpoint
+}
+
+// sageMakerEndpoint is an endpoint with its described configuration. Config is
+// nil when the endpoint or its config could not be described.
+type sageMakerEndpoint struct {
+	Name   string
+	ARN    string
+	Status string
+	Config *sagemaker.DescribeEndpointConfigOutput
 }
 
-func NewSageMakerChecks(client *sagemaker.Client) *SageMakerChecks {
-	return &SageMakerChecks{client: client}
+func NewSageMakerChecks(client *sagemaker.Client, iamClient *iam.Client) *SageMakerChecks {
+	return &SageMakerChecks{client: client, iamClient: iamClient}
 }
 
 func (c *SageMakerChecks) Name() string {
 	return "SageMaker ML Security"
 }
 
+// describeNotebookInstance returns the notebook's DescribeNotebookInstance
+// output, fetched once per scan for all the notebook checks
+func (c *SageMakerChecks) describeNotebookInstance(ctx context.Context, name string) (*sagemaker.DescribeNotebookInstanceOutput, error) {
+	key := fmt.Sprintf("sagemaker:%s:notebook-instance/%s", c.client.Options().Region, name)
+	return memoize(ctx, key, func() (*sagemaker.DescribeNotebookInstanceOutput, error) {
+		return c.client.DescribeNotebookInstance(ctx, &sagemaker.DescribeNotebookInstanceInput{NotebookInstanceName: &name})
+	})
+}
+
 func (c *SageMakerChecks) Run(ctx context.Context) ([]CheckResult, error) {
 	results := []CheckResult{}
 
-	if result, err := c.CheckNotebookEncryption(ctx); err == nil {
+	result, err := runCheck(ctx, "sagemaker.notebook_encryption", c.CheckNotebookEncryption)
+	if isServiceUnavailableInRegion(err) {
+		return []CheckResult{serviceUnavailableResult(ctx, "SageMaker", "CC6.3")}, nil
+	}
+	if err == nil {
+		results = append(results, result)
+	}
+
+	if result, err := runCheck(ctx, "sagemaker.notebook_direct_internet", c.CheckNotebookDirectInternet); err == nil {
+		results = append(results, result)
+	}
+
+	if result, err := runCheck(ctx, "sagemaker.notebook_root_access", c.CheckNotebookRootAccess); err == nil {
+		results = append(results, result)
+	}
+
+	if result, err := runCheck(ctx, "sagemaker.notebook_role_privilege", c.CheckNotebookRolePrivilege); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckNotebookDirectInternet(ctx); err == nil {
+	if result, err := runCheck(ctx, "sagemaker.endpoint_encryption", c.CheckEndpointEncryption); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckNotebookRootAccess(ctx); err == nil {
+	if result, err := runCheck(ctx, "sagemaker.endpoint_data_capture", c.CheckEndpointDataCapture); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckEndpointEncryption(ctx); err == nil {
+	if result, err := runCheck(ctx, "sagemaker.endpoint_instance_count", c.CheckEndpointInstanceCount); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckTrainingJobEncryption(ctx); err == nil {
+	if result, err := runCheck(ctx, "sagemaker.training_job_encryption", c.CheckTrainingJobEncryption); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckModelNetworkIsolation(ctx); err == nil {
+	if result, err := runCheck(ctx, "sagemaker.model_network_isolation", c.CheckModelNetworkIsolation); err == nil {
 		results = append(results, result)
 	}
 
 	return results, nil
 }
 
+// EstimateCalls predicts the list calls plus the per-resource Describe calls
+// for notebooks (four checks, and the IAM listings for each notebook role),
+// endpoints (with their tags), training jobs and models
+func (c *SageMakerChecks) EstimateCalls(ctx context.Context) (CallEstimate, error) {
+	notebooks, err := c.client.ListNotebookInstances(ctx, &sagemaker.ListNotebookInstancesInput{})
+	if err != nil {
+		return CallEstimate{}, err
+	}
+	endpoints, err := c.client.ListEndpoints(ctx, &sagemaker.ListEndpointsInput{})
+	if err != nil {
+		return CallEstimate{}, err
+	}
+	jobs, err := c.client.ListTrainingJobs(ctx, &sagemaker.ListTrainingJobsInput{
+		MaxResults: aws.Int32(100),
+	})
+	if err != nil {
+		return CallEstimate{}, err
+	}
+	models, err := c.client.ListModels(ctx, &sagemaker.ListModelsInput{
+		MaxResults: aws.Int32(100),
+	})
+	if err != nil {
+		return CallEstimate{}, err
+	}
+
+	n := len(notebooks.NotebookInstances)
+	e := len(endpoints.Endpoints)
+	j := len(jobs.TrainingJobSummaries)
+	m := len(models.Models)
+
+	return CallEstimate{
+		Resources: n + e + j + m,
+		Calls:     4*(1+n) + 2*n + (1 + 3*e) + (1 + j) + (1 + m),
+	}, nil
+}
+
 func (c *SageMakerChecks) CheckNotebookEncryption(ctx context.Context) (CheckResult, error) {
 	notebooks, err := c.client.ListNotebookInstances(ctx, &sagemaker.ListNotebookInstancesInput{})
 	if err != nil {
@@ -63,9 +141,7 @@ func (c *SageMakerChecks) CheckNotebookEncryption(ctx context.Context) (CheckRes
 		nbName := aws.ToString(nb.NotebookInstanceName)
 
 		// Get detailed info
-		detail, err := c.client.DescribeNotebookInstance(ctx, &sagemaker.DescribeNotebookInstanceInput{
-			NotebookInstanceName: nb.NotebookInstanceName,
-		})
+		detail, err := c.describeNotebookInstance(ctx, nbName)
 		if err != nil {
 			continue
 		}
@@ -81,13 +157,15 @@ func (c *SageMakerChecks) CheckNotebookEncryption(ctx context.Context) (CheckRes
 			Name:              "SageMaker Notebook Encryption",
 			Status:            "FAIL",
 			Severity:          "HIGH",
-			Evidence:          fmt.Sprintf("%d notebooks without KMS encryption: %v", len(unencrypted), unencrypted),
+			Evidence:          fmt.Sprintf("%d notebooks without KMS encryption: %s", len(unencrypted), TruncateList(unencrypted, evidenceListLimit(ctx))),
+			AffectedResources: unencrypted,
 			Remediation:       "Enable KMS encryption for SageMaker notebooks",
 			RemediationDetail: "Create new notebook instance with KMS key or recreate existing notebooks with encryption enabled",
+			RequiresRecreate:  true,
 			ScreenshotGuide:   "SageMaker Console → Notebook instances → Select instance → Configuration → Screenshot showing KMS Key ARN",
-			ConsoleURL:        "https://console.aws.amazon.com/sagemaker/home#/notebook-instances",
+			ConsoleURL:        consoleURL("sagemaker/home#/notebook-instances", consoleRegion(ctx)),
 			Priority:          PriorityHigh,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("SAGEMAKER_ENCRYPTION"),
 		}, nil
 	}
@@ -98,20 +176,23 @@ func (c *SageMakerChecks) CheckNotebookEncryption(ctx context.Context) (CheckRes
 			Name:       "SageMaker Notebook Encryption",
 			Status:     "PASS",
 			Evidence:   "No SageMaker notebooks found",
+			ConsoleURL: consoleURL("sagemaker/home#/notebook-instances", consoleRegion(ctx)),
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("SAGEMAKER_ENCRYPTION"),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "CC6.3",
-		Name:       "SageMaker Notebook Encryption",
-		Status:     "PASS",
-		Evidence:   fmt.Sprintf("All %d notebooks are encrypted with KMS", len(notebooks.NotebookInstances)),
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("SAGEMAKER_ENCRYPTION"),
+		Control:         "CC6.3",
+		Name:            "SageMaker Notebook Encryption",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d notebooks are encrypted with KMS", len(notebooks.NotebookInstances)),
+		ScreenshotGuide: "SageMaker Console → Notebook instances → Select instance → Configuration → Screenshot showing KMS Key ARN",
+		ConsoleURL:      consoleURL("sagemaker/home#/notebook-instances", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("SAGEMAKER_ENCRYPTION"),
 	}, nil
 }
 
@@ -126,9 +207,7 @@ func (c *SageMakerChecks) CheckNotebookDirectInternet(ctx context.Context) (Chec
 	for _, nb := range notebooks.NotebookInstances {
 		nbName := aws.ToString(nb.NotebookInstanceName)
 
-		detail, err := c.client.DescribeNotebookInstance(ctx, &sagemaker.DescribeNotebookInstanceInput{
-			NotebookInstanceName: nb.NotebookInstanceName,
-		})
+		detail, err := c.describeNotebookInstance(ctx, nbName)
 		if err != nil {
 			continue
 		}
@@ -144,13 +223,15 @@ func (c *SageMakerChecks) CheckNotebookDirectInternet(ctx context.Context) (Chec
 			Name:              "SageMaker Direct Internet Access",
 			Status:            "FAIL",
 			Severity:          "MEDIUM",
-			Evidence:          fmt.Sprintf("%d notebooks have direct internet access enabled: %v", len(directInternet), directInternet),
+			Evidence:          fmt.Sprintf("%d notebooks have direct internet access enabled: %s", len(directInternet), TruncateList(directInternet, evidenceListLimit(ctx))),
+			AffectedResources: directInternet,
 			Remediation:       "Disable direct internet access and use VPC for network isolation",
 			RemediationDetail: "Recreate notebook instance in VPC with DirectInternetAccess=Disabled, use NAT gateway for outbound access",
+			RequiresRecreate:  true,
 			ScreenshotGuide:   "SageMaker Console → Notebook → Network → Screenshot showing 'Direct internet access: Disabled'",
-			ConsoleURL:        "https://console.aws.amazon.com/sagemaker/home#/notebook-instances",
+			ConsoleURL:        consoleURL("sagemaker/home#/notebook-instances", consoleRegion(ctx)),
 			Priority:          PriorityMedium,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("SAGEMAKER_NETWORK"),
 		}, nil
 	}
@@ -161,20 +242,23 @@ func (c *SageMakerChecks) CheckNotebookDirectInternet(ctx context.Context) (Chec
 			Name:       "SageMaker Direct Internet Access",
 			Status:     "PASS",
 			Evidence:   "No SageMaker notebooks found",
+			ConsoleURL: consoleURL("sagemaker/home#/notebook-instances", consoleRegion(ctx)),
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("SAGEMAKER_NETWORK"),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "CC6.1",
-		Name:       "SageMaker Direct Internet Access",
-		Status:     "PASS",
-		Evidence:   fmt.Sprintf("All %d notebooks have direct internet access disabled", len(notebooks.NotebookInstances)),
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("SAGEMAKER_NETWORK"),
+		Control:         "CC6.1",
+		Name:            "SageMaker Direct Internet Access",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d notebooks have direct internet access disabled", len(notebooks.NotebookInstances)),
+		ScreenshotGuide: "SageMaker Console → Notebook → Network → Screenshot showing 'Direct internet access: Disabled'",
+		ConsoleURL:      consoleURL("sagemaker/home#/notebook-instances", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("SAGEMAKER_NETWORK"),
 	}, nil
 }
 
@@ -189,9 +273,7 @@ func (c *SageMakerChecks) CheckNotebookRootAccess(ctx context.Context) (CheckRes
 	for _, nb := range notebooks.NotebookInstances {
 		nbName := aws.ToString(nb.NotebookInstanceName)
 
-		detail, err := c.client.DescribeNotebookInstance(ctx, &sagemaker.DescribeNotebookInstanceInput{
-			NotebookInstanceName: nb.NotebookInstanceName,
-		})
+		detail, err := c.describeNotebookInstance(ctx, nbName)
 		if err != nil {
 			continue
 		}
@@ -207,13 +289,14 @@ func (c *SageMakerChecks) CheckNotebookRootAccess(ctx context.Context) (CheckRes
 			Name:              "SageMaker Root Access",
 			Status:            "FAIL",
 			Severity:          "MEDIUM",
-			Evidence:          fmt.Sprintf("%d notebooks have root access enabled: %v", len(rootEnabled), rootEnabled),
+			Evidence:          fmt.Sprintf("%d notebooks have root access enabled: %s", len(rootEnabled), TruncateList(rootEnabled, evidenceListLimit(ctx))),
+			AffectedResources: rootEnabled,
 			Remediation:       "Disable root access for notebook instances",
 			RemediationDetail: "Update notebook instance to disable root access: aws sagemaker update-notebook-instance --notebook-instance-name NAME --root-access Disabled",
 			ScreenshotGuide:   "SageMaker Console → Notebook → Permissions → Screenshot showing 'Root access: Disabled'",
-			ConsoleURL:        "https://console.aws.amazon.com/sagemaker/home#/notebook-instances",
+			ConsoleURL:        consoleURL("sagemaker/home#/notebook-instances", consoleRegion(ctx)),
 			Priority:          PriorityMedium,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("SAGEMAKER_ACCESS"),
 		}, nil
 	}
@@ -224,91 +307,584 @@ func (c *SageMakerChecks) CheckNotebookRootAccess(ctx context.Context) (CheckRes
 			Name:       "SageMaker Root Access",
 			Status:     "PASS",
 			Evidence:   "No SageMaker notebooks found",
+			ConsoleURL: consoleURL("sagemaker/home#/notebook-instances", consoleRegion(ctx)),
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
+			Frameworks: GetFrameworkMappings("SAGEMAKER_ACCESS"),
+		}, nil
+	}
+
+	return CheckResult{
+		Control:         "CC6.6",
+		Name:            "SageMaker Root Access",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d notebooks have root access disabled", len(notebooks.NotebookInstances)),
+		ScreenshotGuide: "SageMaker Console → Notebook → Permissions → Screenshot showing 'Root access: Disabled'",
+		ConsoleURL:      consoleURL("sagemaker/home#/notebook-instances", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("SAGEMAKER_ACCESS"),
+	}, nil
+}
+
+// CheckNotebookRolePrivilege flags notebooks whose execution role can do
+// anything in the account: AdministratorAccess attached, or a customer
+// managed or inline policy allowing every action on every resource. Anyone
+// with a notebook's Jupyter session holds its role, so a notebook is an
+// administrator console. Roles that cannot be read are counted, not failed.
+func (c *SageMakerChecks) CheckNotebookRolePrivilege(ctx context.Context) (CheckResult, error) {
+	notebooks, err := c.client.ListNotebookInstances(ctx, &sagemaker.ListNotebookInstancesInput{})
+	if err != nil {
+		return CheckResult{}, err
+	}
+
+	privileged := []string{}      // notebooks with their role and offending policies, for the evidence
+	privilegedNames := []string{} // bare notebook names, for AffectedResources
+	unreadable := map[string]bool{}
+
+	for _, nb := range notebooks.NotebookInstances {
+		nbName := aws.ToString(nb.NotebookInstanceName)
+
+		detail, err := c.describeNotebookInstance(ctx, nbName)
+		if err != nil || aws.ToString(detail.RoleArn) == "" {
+			continue
+		}
+		roleARN := aws.ToString(detail.RoleArn)
+
+		policies, err := c.roleFullAccessPolicies(ctx, roleARN)
+		if err != nil {
+			unreadable[roleARN] = true
+			continue
+		}
+		if len(policies) > 0 {
+			privileged = append(privileged, fmt.Sprintf("%s (role %s: %s)", nbName, iamRoleName(roleARN), strings.Join(policies, ", ")))
+			privilegedNames = append(privilegedNames, nbName)
+		}
+	}
+
+	unreadableNote := ""
+	if len(unreadable) > 0 {
+		unreadableNote = fmt.Sprintf("; %d notebook roles could not be read", len(unreadable))
+	}
+
+	if len(privileged) > 0 {
+		return CheckResult{
+			Control:           "CC6.6",
+			Name:              "SageMaker Notebook Role Privilege",
+			Status:            "FAIL",
+			Severity:          "HIGH",
+			Evidence:          fmt.Sprintf("%d notebooks run with a role allowing every action on every resource: %s%s", len(privileged), TruncateList(privileged, evidenceListLimit(ctx)), unreadableNote),
+			AffectedResources: privilegedNames,
+			Remediation:       "Give notebook execution roles only the S3 buckets, SageMaker APIs and other services the notebook uses",
+			RemediationDetail: "1. Create a scoped role, e.g. from AmazonSageMakerFullAccess limited to the project's buckets\n2. aws sagemaker stop-notebook-instance --notebook-instance-name [NAME]\n3. aws sagemaker update-notebook-instance --notebook-instance-name [NAME] --role-arn [SCOPED_ROLE_ARN]\n4. aws sagemaker start-notebook-instance --notebook-instance-name [NAME]\nOr detach AdministratorAccess and the wildcard policies from the existing role",
+			ScreenshotGuide:   "SageMaker Console → Notebook → Permissions and encryption → IAM role → Screenshot of the role's attached policies",
+			ConsoleURL:        consoleURL("sagemaker/home#/notebook-instances", consoleRegion(ctx)),
+			Priority:          PriorityHigh,
+			Timestamp:         nowFunc(),
+			Frameworks:        GetFrameworkMappings("SAGEMAKER_ACCESS"),
+		}, nil
+	}
+
+	if len(notebooks.NotebookInstances) == 0 {
+		return CheckResult{
+			Control:    "CC6.6",
+			Name:       "SageMaker Notebook Role Privilege",
+			Status:     "PASS",
+			Evidence:   "No SageMaker notebooks found",
+			ConsoleURL: consoleURL("sagemaker/home#/notebook-instances", consoleRegion(ctx)),
+			Priority:   PriorityInfo,
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("SAGEMAKER_ACCESS"),
 		}, nil
 	}
 
 	return CheckResult{
 		Control:    "CC6.6",
-		Name:       "SageMaker Root Access",
+		Name:       "SageMaker Notebook Role Privilege",
 		Status:     "PASS",
-		Evidence:   fmt.Sprintf("All %d notebooks have root access disabled", len(notebooks.NotebookInstances)),
+		Evidence:   fmt.Sprintf("No notebook role among %d notebooks has AdministratorAccess or a wildcard policy%s", len(notebooks.NotebookInstances), unreadableNote),
+		ConsoleURL: consoleURL("sagemaker/home#/notebook-instances", consoleRegion(ctx)),
 		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
+		Timestamp:  nowFunc(),
 		Frameworks: GetFrameworkMappings("SAGEMAKER_ACCESS"),
 	}, nil
 }
 
-func (c *SageMakerChecks) CheckEndpointEncryption(ctx context.Context) (CheckResult, error) {
+// roleFullAccessPolicies names the policies on a role that allow every action
+// on every resource: AdministratorAccess, and customer managed or inline
+// ("name (inline)") policies with such a statement. Other AWS managed
+// policies are not read. IAM is global, so the answer is shared by every
+// region's notebooks.
+func (c *SageMakerChecks) roleFullAccessPolicies(ctx context.Context, roleARN string) ([]string, error) {
+	return memoize(ctx, "iam:role-full-access:"+roleARN, func() ([]string, error) {
+		roleName := iamRoleName(roleARN)
+		found := []string{}
+
+		attached, err := paginate(ctx, func(token *string) ([]iamtypes.AttachedPolicy, *string, error) {
+			out, err := c.iamClient.ListAttachedRolePolicies(ctx, &iam.ListAttachedRolePoliciesInput{RoleName: aws.String(roleName), Marker: token})
+			if err != nil {
+				return nil, nil, err
+			}
+			return out.AttachedPolicies, out.Marker, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, policy := range attached {
+			policyARN := aws.ToString(policy.PolicyArn)
+			// Suffix match so GovCloud and China partition ARNs are covered too
+			if strings.HasSuffix(policyARN, ":aws:policy/AdministratorAccess") {
+				found = append(found, "AdministratorAccess")
+				continue
+			}
+			if strings.Contains(policyARN, ":aws:policy/") {
+				continue
+			}
+
+			meta, err := c.iamClient.GetPolicy(ctx, &iam.GetPolicyInput{PolicyArn: aws.String(policyARN)})
+			if err != nil || meta.Policy == nil {
+				continue
+			}
+			version, err := c.iamClient.GetPolicyVersion(ctx, &iam.GetPolicyVersionInput{
+				PolicyArn: aws.String(policyARN),
+				VersionId: meta.Policy.DefaultVersionId,
+			})
+			if err != nil || version.PolicyVersion == nil {
+				continue
+			}
+			if policyAllowsFullAccess(aws.ToString(version.PolicyVersion.Document)) {
+				found = append(found, aws.ToString(policy.PolicyName))
+			}
+		}
+
+		inline, err := paginate(ctx, func(token *string) ([]string, *string, error) {
+			out, err := c.iamClient.ListRolePolicies(ctx, &iam.ListRolePoliciesInput{RoleName: aws.String(roleName), Marker: token})
+			if err != nil {
+				return nil, nil, err
+			}
+			return out.PolicyNames, out.Marker, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, name := range inline {
+			policy, err := c.iamClient.GetRolePolicy(ctx, &iam.GetRolePolicyInput{RoleName: aws.String(roleName), PolicyName: aws.String(name)})
+			if err != nil {
+				continue
+			}
+			if policyAllowsFullAccess(aws.ToString(policy.PolicyDocument)) {
+				found = append(found, name+" (inline)")
+			}
+		}
+
+		return found, nil
+	})
+}
+
+// iamRoleName is the role name at the end of a role ARN, without its path
+func iamRoleName(roleARN string) string {
+	return roleARN[strings.LastIndex(roleARN, "/")+1:]
+}
+
+// policyAllowsFullAccess reports whether an IAM policy document, as IAM
+// returns it (URL-encoded JSON), has an Allow statement for action "*" (or
+// "*:*") on resource "*". Conditions are not weighed.
+func policyAllowsFullAccess(document string) bool {
+	if decoded, err := url.QueryUnescape(document); err == nil {
+		document = decoded
+	}
+
+	var doc struct {
+		Statement json.RawMessage `json:"Statement"`
+	}
+	if err := json.Unmarshal([]byte(document), &doc); err != nil {
+		return false
+	}
+
+	type statement struct {
+		Effect   string          `json:"Effect"`
+		Action   json.RawMessage `json:"Action"`
+		Resource json.RawMessage `json:"Resource"`
+	}
+	statements := []statement{}
+	if err := json.Unmarshal(doc.Statement, &statements); err != nil {
+		var single statement
+		if err := json.Unmarshal(doc.Statement, &single); err != nil {
+			return false
+		}
+		statements = append(statements, single)
+	}
+
+	for _, stmt := range statements {
+		if !strings.EqualFold(stmt.Effect, "Allow") {
+			continue
+		}
+		if policyValueHas(stmt.Action, "*", "*:*") && policyValueHas(stmt.Resource, "*") {
+			return true
+		}
+	}
+	return false
+}
+
+// policyValueHas reports whether a policy element, a string or a list of
+// strings, contains any of want
+func policyValueHas(raw json.RawMessage, want ...string) bool {
+	values := []string{}
+	var single string
+	if err := json.Unmarshal(raw, &single); err == nil {
+		values = append(values, single)
+	} else if err := json.Unmarshal(raw, &values); err != nil {
+		return false
+	}
+
+	for _, value := range values {
+		for _, w := range want {
+			if value == w {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// listEndpoints lists and describes every endpoint once; the endpoint checks
+// share the result
+func (c *SageMakerChecks) listEndpoints(ctx context.Context) ([]sageMakerEndpoint, error) {
+	if c.endpoints != nil {
+		return c.endpoints, nil
+	}
+
 	endpoints, err := c.client.ListEndpoints(ctx, &sagemaker.ListEndpointsInput{})
 	if err != nil {
-		return CheckResult{}, err
+		return nil, err
 	}
 
-	unencrypted := []string{}
-
+	described := []sageMakerEndpoint{}
 	for _, ep := range endpoints.Endpoints {
-		epName := aws.ToString(ep.EndpointName)
+		endpoint := sageMakerEndpoint{
+			Name:   aws.ToString(ep.EndpointName),
+			ARN:    aws.ToString(ep.EndpointArn),
+			Status: string(ep.EndpointStatus),
+		}
 
 		detail, err := c.client.DescribeEndpoint(ctx, &sagemaker.DescribeEndpointInput{
 			EndpointName: ep.EndpointName,
 		})
-		if err != nil {
-			continue
+		if err == nil {
+			configDetail, err := c.client.DescribeEndpointConfig(ctx, &sagemaker.DescribeEndpointConfigInput{
+				EndpointConfigName: detail.EndpointConfigName,
+			})
+			if err == nil {
+				endpoint.Config = configDetail
+			}
 		}
 
-		// Check if endpoint config has KMS key
-		configDetail, err := c.client.DescribeEndpointConfig(ctx, &sagemaker.DescribeEndpointConfigInput{
-			EndpointConfigName: detail.EndpointConfigName,
-		})
-		if err != nil {
+		described = append(described, endpoint)
+	}
+
+	c.endpoints = described
+	return described, nil
+}
+
+// sageMakerInstanceStorageFamilies are instance families whose storage is NVMe
+// instance store. SageMaker rejects a KmsKeyId for them: the volumes are
+// encrypted by the instance hardware instead.
+var sageMakerInstanceStorageFamilies = map[string]bool{
+	"c5d":  true,
+	"m5d":  true,
+	"r5d":  true,
+	"g4dn": true,
+	"g5":   true,
+	"g6":   true,
+	"p3dn": true,
+	"p4d":  true,
+	"p4de": true,
+	"p5":   true,
+}
+
+// sageMakerKeyNotSupported returns why an endpoint config cannot take a
+// KmsKeyId (serverless or instance-store variants), or "" if it can
+func sageMakerKeyNotSupported(config *sagemaker.DescribeEndpointConfigOutput) string {
+	for _, variant := range config.ProductionVariants {
+		if variant.ServerlessConfig != nil {
+			return "serverless"
+		}
+		// Instance types read "ml.g5.xlarge"
+		parts := strings.Split(string(variant.InstanceType), ".")
+		if len(parts) == 3 && sageMakerInstanceStorageFamilies[parts[1]] {
+			return "instance storage"
+		}
+	}
+	return ""
+}
+
+// CheckEndpointEncryption covers the ML storage volume attached to endpoint
+// instances (the endpoint config's KmsKeyId). Captured request/response data
+// is written to S3 and is covered separately by the data capture config.
+//
+// SageMaker never leaves the volume unencrypted: without a KmsKeyId it uses
+// the AWS managed key, which the API does not report. Those endpoints fail
+// for lacking a customer managed key, and the evidence says so. Serverless and
+// instance-store endpoints cannot take a KmsKeyId and are not failed.
+func (c *SageMakerChecks) CheckEndpointEncryption(ctx context.Context) (CheckResult, error) {
+	endpoints, err := c.listEndpoints(ctx)
+	if err != nil {
+		return CheckResult{}, err
+	}
+
+	awsManaged := []string{}
+	notConfigurable := []string{}
+	customerManaged := 0
+
+	for _, ep := range endpoints {
+		if ep.Config == nil {
 			continue
 		}
 
-		if configDetail.KmsKeyId == nil || *configDetail.KmsKeyId == "" {
-			unencrypted = append(unencrypted, epName)
+		reason := sageMakerKeyNotSupported(ep.Config)
+		switch {
+		case aws.ToString(ep.Config.KmsKeyId) != "":
+			customerManaged++
+		case reason != "":
+			notConfigurable = append(notConfigurable, fmt.Sprintf("%s (%s)", ep.Name, reason))
+		default:
+			awsManaged = append(awsManaged, ep.Name)
 		}
 	}
 
-	if len(unencrypted) > 0 {
+	other := ""
+	if len(notConfigurable) > 0 {
+		other = fmt.Sprintf(". %d endpoints are encrypted by AWS and cannot use a KMS key: %s", len(notConfigurable), TruncateList(notConfigurable, evidenceListLimit(ctx)))
+	}
+
+	if len(awsManaged) > 0 {
 		return CheckResult{
 			Control:           "CC6.3",
 			Name:              "SageMaker Endpoint Encryption",
 			Status:            "FAIL",
 			Severity:          "HIGH",
-			Evidence:          fmt.Sprintf("%d endpoints without KMS encryption: %v", len(unencrypted), unencrypted),
+			Evidence:          fmt.Sprintf("%d endpoints have no customer managed KMS key; their storage volumes are encrypted only with the AWS managed key (the API does not report the key SageMaker applied when none is set): %s%s", len(awsManaged), TruncateList(awsManaged, evidenceListLimit(ctx)), other),
+			AffectedResources: awsManaged,
 			Remediation:       "Enable KMS encryption for SageMaker endpoints",
 			RemediationDetail: "Create new endpoint config with KmsKeyId specified, then update endpoint to use new config",
 			ScreenshotGuide:   "SageMaker Console → Endpoints → Select endpoint → Configuration → Screenshot showing KMS Key ARN",
-			ConsoleURL:        "https://console.aws.amazon.com/sagemaker/home#/endpoints",
+			ConsoleURL:        consoleURL("sagemaker/home#/endpoints", consoleRegion(ctx)),
 			Priority:          PriorityHigh,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("SAGEMAKER_ENCRYPTION"),
 		}, nil
 	}
 
-	if len(endpoints.Endpoints) == 0 {
+	if len(endpoints) == 0 {
 		return CheckResult{
 			Control:    "CC6.3",
 			Name:       "SageMaker Endpoint Encryption",
 			Status:     "PASS",
 			Evidence:   "No SageMaker endpoints found",
+			ConsoleURL: consoleURL("sagemaker/home#/endpoints", consoleRegion(ctx)),
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("SAGEMAKER_ENCRYPTION"),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "CC6.3",
-		Name:       "SageMaker Endpoint Encryption",
-		Status:     "PASS",
-		Evidence:   fmt.Sprintf("All %d endpoints are encrypted with KMS", len(endpoints.Endpoints)),
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("SAGEMAKER_ENCRYPTION"),
+		Control:         "CC6.3",
+		Name:            "SageMaker Endpoint Encryption",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("%d endpoints are encrypted with a customer managed KMS key%s", customerManaged, other),
+		ScreenshotGuide: "SageMaker Console → Endpoints → Select endpoint → Configuration → Screenshot showing KMS Key ARN",
+		ConsoleURL:      consoleURL("sagemaker/home#/endpoints", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("SAGEMAKER_ENCRYPTION"),
+	}, nil
+}
+
+// CheckEndpointDataCapture flags in-service endpoints without data capture.
+// Captured requests and responses are what Model Monitor uses to detect
+// drift, and they are the audit trail of what a production model returned.
+func (c *SageMakerChecks) CheckEndpointDataCapture(ctx context.Context) (CheckResult, error) {
+	endpoints, err := c.listEndpoints(ctx)
+	if err != nil {
+		return CheckResult{}, err
+	}
+
+	noCapture := []string{}
+	inService := 0
+
+	for _, ep := range endpoints {
+		if ep.Status != "InService" || ep.Config == nil {
+			continue
+		}
+		inService++
+
+		if ep.Config.DataCaptureConfig == nil || !aws.ToBool(ep.Config.DataCaptureConfig.EnableCapture) {
+			noCapture = append(noCapture, ep.Name)
+		}
+	}
+
+	if len(noCapture) > 0 {
+		return CheckResult{
+			Control:           "CC7.2",
+			Name:              "SageMaker Endpoint Data Capture",
+			Status:            "FAIL",
+			Severity:          "LOW",
+			Evidence:          fmt.Sprintf("%d in-service endpoints without data capture enabled: %s", len(noCapture), TruncateList(noCapture, evidenceListLimit(ctx))),
+			AffectedResources: noCapture,
+			Remediation:       "Enable data capture on production SageMaker endpoints",
+			RemediationDetail: "Create an endpoint config with DataCaptureConfig (EnableCapture: true, S3 destination, KmsKeyId for the captured data), then update the endpoint. Attach a Model Monitor schedule to detect drift.",
+			ScreenshotGuide:   "SageMaker Console → Endpoints → Select endpoint → Data capture → Screenshot showing 'Enable data capture: Yes'",
+			ConsoleURL:        consoleURL("sagemaker/home#/endpoints", consoleRegion(ctx)),
+			Priority:          PriorityLow,
+			Timestamp:         nowFunc(),
+			Frameworks:        GetFrameworkMappings("SAGEMAKER_MONITORING"),
+		}, nil
+	}
+
+	if inService == 0 {
+		return CheckResult{
+			Control:    "CC7.2",
+			Name:       "SageMaker Endpoint Data Capture",
+			Status:     "PASS",
+			Evidence:   "No in-service SageMaker endpoints found",
+			ConsoleURL: consoleURL("sagemaker/home#/endpoints", consoleRegion(ctx)),
+			Priority:   PriorityInfo,
+			Timestamp:  nowFunc(),
+			Frameworks: GetFrameworkMappings("SAGEMAKER_MONITORING"),
+		}, nil
+	}
+
+	return CheckResult{
+		Control:         "CC7.2",
+		Name:            "SageMaker Endpoint Data Capture",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d in-service endpoints have data capture enabled", inService),
+		ScreenshotGuide: "SageMaker Console → Endpoints → Select endpoint → Data capture → Screenshot showing 'Enable data capture: Yes'",
+		ConsoleURL:      consoleURL("sagemaker/home#/endpoints", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("SAGEMAKER_MONITORING"),
+	}, nil
+}
+
+// endpointEnvironment classifies the endpoint by its environment tag; it is
+// unclassified ("") when its tags cannot be read
+func (c *SageMakerChecks) endpointEnvironment(ctx context.Context, arn string) string {
+	if arn == "" {
+		return ""
+	}
+	tags, err := memoize(ctx, "sagemaker:tags:"+arn, func() (map[string]string, error) {
+		out, err := c.client.ListTags(ctx, &sagemaker.ListTagsInput{ResourceArn: &arn})
+		if err != nil {
+			return nil, err
+		}
+		tags := map[string]string{}
+		for _, tag := range out.Tags {
+			tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+		}
+		return tags, nil
+	})
+	if err != nil {
+		return ""
+	}
+	return environmentFromTags(ctx, tags)
+}
+
+// CheckEndpointInstanceCount flags in-service endpoints whose instance-backed
+// production variants add up to a single instance: one instance failure or
+// AZ outage takes the model offline. The count is the endpoint config's
+// InitialInstanceCount. Serverless variants scale on their own and are not
+// counted, and endpoints tagged as dev are excluded.
+func (c *SageMakerChecks) CheckEndpointInstanceCount(ctx context.Context) (CheckResult, error) {
+	endpoints, err := c.listEndpoints(ctx)
+	if err != nil {
+		return CheckResult{}, err
+	}
+
+	singleInstance := []string{}
+	var envs resourceEnvironments
+	checked, dev := 0, 0
+
+	for _, ep := range endpoints {
+		if ep.Status != "InService" || ep.Config == nil {
+			continue
+		}
+
+		instances, serverless := int32(0), false
+		for _, variant := range ep.Config.ProductionVariants {
+			if variant.ServerlessConfig != nil {
+				serverless = true
+				continue
+			}
+			instances += aws.ToInt32(variant.InitialInstanceCount)
+		}
+		if serverless && instances == 0 {
+			continue
+		}
+
+		env := c.endpointEnvironment(ctx, ep.ARN)
+		if env == EnvironmentDev {
+			dev++
+			continue
+		}
+		checked++
+
+		if instances <= 1 {
+			singleInstance = append(singleInstance, ep.Name)
+			envs.add(env)
+		}
+	}
+
+	excluded := ""
+	if dev > 0 {
+		excluded = fmt.Sprintf(" (%d dev endpoints excluded)", dev)
+	}
+
+	if len(singleInstance) > 0 {
+		return CheckResult{
+			Control:           "A1.2",
+			Name:              "SageMaker Endpoint Instance Count",
+			Status:            "FAIL",
+			Severity:          "LOW",
+			Environment:       envs.environment(),
+			Evidence:          fmt.Sprintf("%d in-service endpoints run on a single instance with no redundancy%s: %s", len(singleInstance), excluded, TruncateList(singleInstance, evidenceListLimit(ctx))),
+			AffectedResources: singleInstance,
+			Remediation:       "Run production SageMaker endpoints on at least two instances",
+			RemediationDetail: "Create an endpoint config with InitialInstanceCount of 2 or more per production variant and update the endpoint; SageMaker spreads the instances across Availability Zones. If the endpoint auto scales, register the variant with Application Auto Scaling with MinCapacity 2.",
+			ScreenshotGuide:   "SageMaker Console → Endpoints → Select endpoint → Endpoint runtime settings → Screenshot showing 'Current instance count' of 2 or more",
+			ConsoleURL:        consoleURL("sagemaker/home#/endpoints", consoleRegion(ctx)),
+			Priority:          PriorityLow,
+			Timestamp:         nowFunc(),
+			Frameworks:        GetFrameworkMappings("SAGEMAKER_AVAILABILITY"),
+		}, nil
+	}
+
+	if checked == 0 {
+		return CheckResult{
+			Control:    "A1.2",
+			Name:       "SageMaker Endpoint Instance Count",
+			Status:     "PASS",
+			Evidence:   "No in-service instance-backed production SageMaker endpoints found" + excluded,
+			ConsoleURL: consoleURL("sagemaker/home#/endpoints", consoleRegion(ctx)),
+			Priority:   PriorityInfo,
+			Timestamp:  nowFunc(),
+			Frameworks: GetFrameworkMappings("SAGEMAKER_AVAILABILITY"),
+		}, nil
+	}
+
+	return CheckResult{
+		Control:         "A1.2",
+		Name:            "SageMaker Endpoint Instance Count",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d in-service endpoints run on two or more instances%s", checked, excluded),
+		ScreenshotGuide: "SageMaker Console → Endpoints → Select endpoint → Endpoint runtime settings → Screenshot showing 'Current instance count' of 2 or more",
+		ConsoleURL:      consoleURL("sagemaker/home#/endpoints", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("SAGEMAKER_AVAILABILITY"),
 	}, nil
 }
 
@@ -344,13 +920,14 @@ func (c *SageMakerChecks) CheckTrainingJobEncryption(ctx context.Context) (Check
 			Name:              "SageMaker Training Job Encryption",
 			Status:            "FAIL",
 			Severity:          "MEDIUM",
-			Evidence:          fmt.Sprintf("%d training jobs without volume encryption: %v", len(unencrypted), truncateList(unencrypted, 5)),
+			Evidence:          fmt.Sprintf("%d training jobs without volume encryption: %s", len(unencrypted), TruncateList(unencrypted, evidenceListLimit(ctx))),
+			AffectedResources: unencrypted,
 			Remediation:       "Enable KMS encryption for training job volumes",
 			RemediationDetail: "When creating training jobs, specify VolumeKmsKeyId in ResourceConfig",
 			ScreenshotGuide:   "SageMaker Console → Training jobs → Select job → Configuration → Screenshot showing encryption settings",
-			ConsoleURL:        "https://console.aws.amazon.com/sagemaker/home#/jobs",
+			ConsoleURL:        consoleURL("sagemaker/home#/jobs", consoleRegion(ctx)),
 			Priority:          PriorityMedium,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("SAGEMAKER_ENCRYPTION"),
 		}, nil
 	}
@@ -361,20 +938,23 @@ func (c *SageMakerChecks) CheckTrainingJobEncryption(ctx context.Context) (Check
 			Name:       "SageMaker Training Job Encryption",
 			Status:     "PASS",
 			Evidence:   "No SageMaker training jobs found",
+			ConsoleURL: consoleURL("sagemaker/home#/jobs", consoleRegion(ctx)),
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("SAGEMAKER_ENCRYPTION"),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "CC6.3",
-		Name:       "SageMaker Training Job Encryption",
-		Status:     "PASS",
-		Evidence:   fmt.Sprintf("All %d recent training jobs have volume encryption enabled", len(jobs.TrainingJobSummaries)),
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("SAGEMAKER_ENCRYPTION"),
+		Control:         "CC6.3",
+		Name:            "SageMaker Training Job Encryption",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d recent training jobs have volume encryption enabled", len(jobs.TrainingJobSummaries)),
+		ScreenshotGuide: "SageMaker Console → Training jobs → Select job → Configuration → Screenshot showing encryption settings",
+		ConsoleURL:      consoleURL("sagemaker/home#/jobs", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("SAGEMAKER_ENCRYPTION"),
 	}, nil
 }
 
@@ -410,13 +990,14 @@ func (c *SageMakerChecks) CheckModelNetworkIsolation(ctx context.Context) (Check
 			Name:              "SageMaker Model Network Isolation",
 			Status:            "FAIL",
 			Severity:          "LOW",
-			Evidence:          fmt.Sprintf("%d models without network isolation: %v", len(notIsolated), truncateList(notIsolated, 5)),
+			Evidence:          fmt.Sprintf("%d models without network isolation: %s", len(notIsolated), TruncateList(notIsolated, evidenceListLimit(ctx))),
+			AffectedResources: notIsolated,
 			Remediation:       "Enable network isolation for SageMaker models",
 			RemediationDetail: "When creating models, set EnableNetworkIsolation=true to prevent network access during inference",
 			ScreenshotGuide:   "SageMaker Console → Models → Select model → Network → Screenshot showing isolation settings",
-			ConsoleURL:        "https://console.aws.amazon.com/sagemaker/home#/models",
+			ConsoleURL:        consoleURL("sagemaker/home#/models", consoleRegion(ctx)),
 			Priority:          PriorityLow,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("SAGEMAKER_NETWORK"),
 		}, nil
 	}
@@ -427,20 +1008,23 @@ func (c *SageMakerChecks) CheckModelNetworkIsolation(ctx context.Context) (Check
 			Name:       "SageMaker Model Network Isolation",
 			Status:     "PASS",
 			Evidence:   "No SageMaker models found",
+			ConsoleURL: consoleURL("sagemaker/home#/models", consoleRegion(ctx)),
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("SAGEMAKER_NETWORK"),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "CC6.1",
-		Name:       "SageMaker Model Network Isolation",
-		Status:     "PASS",
-		Evidence:   fmt.Sprintf("All %d models have network isolation enabled", len(models.Models)),
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("SAGEMAKER_NETWORK"),
+		Control:         "CC6.1",
+		Name:            "SageMaker Model Network Isolation",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d models have network isolation enabled", len(models.Models)),
+		ScreenshotGuide: "SageMaker Console → Models → Select model → Network → Screenshot showing isolation settings",
+		ConsoleURL:      consoleURL("sagemaker/home#/models", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("SAGEMAKER_NETWORK"),
 	}, nil
 }
 
@@ -0,0 +1,93 @@
+package checks
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ScanOptions configures a Scan. Zero values run every check with the
+// default environment tag keys and evidence list limit.
+type ScanOptions struct {
+	DisabledChecks     []string // check IDs not to run (see LoadCheckConfig)
+	Profile            string   // ProfileQuick or ProfileDeep; anything else runs every check
+	EnvironmentTagKeys []string // tag keys that classify resources; empty is DefaultEnvironmentTagKeys
+	EvidenceListLimit  int      // resource IDs listed per Evidence string; 0 is DefaultEvidenceListLimit, negative lists all
+	AccountID          string   // account being scanned, part of the memoized listings' keys; empty when unknown
+}
+
+// Scan is one scan's configuration and the state its checks record: the
+// disabled checks and the ones skipped, the scan profile, the environment tag
+// keys, the evidence list limit, and the timings, check errors and memoized
+// describe calls accumulated as the checks run. A runner creates one per scan
+// and carries it on the context with WithScan, so concurrent scans in one
+// process cannot clobber each other's settings or results.
+//
+// Checks run on a context without a Scan use a package default, configured by
+// SetDisabledChecks, SetScanProfile, SetEnvironmentTagKeys and
+// SetEvidenceListLimit and cleared by the Reset functions.
+type Scan struct {
+	configMu sync.Mutex
+	disabled map[string]bool // check IDs runCheck skips
+	skipped  map[string]bool // disabled checks runCheck actually skipped
+	profile  string
+
+	accountID string // set once by NewScan, so read without a lock
+
+	tagKeysMu sync.Mutex
+	tagKeys   []string
+
+	// evidenceLimit is 0 for DefaultEvidenceListLimit. Check goroutines read
+	// it while the default scan's may be being set, so it is atomic.
+	evidenceLimit atomic.Int64
+
+	timingMu         sync.Mutex
+	checkDurations   map[string]time.Duration
+	serviceDurations map[string]time.Duration
+
+	errorsMu    sync.Mutex
+	checkErrors map[string]string // kind of each errored check's latest error
+
+	memoMu      sync.Mutex
+	memoEntries map[string]*memoEntry
+	memoHits    atomic.Int64
+	memoMisses  atomic.Int64
+}
+
+// NewScan creates a Scan configured by options, with nothing recorded yet
+func NewScan(options ScanOptions) *Scan {
+	s := &Scan{
+		checkDurations:   map[string]time.Duration{},
+		serviceDurations: map[string]time.Duration{},
+		checkErrors:      map[string]string{},
+		memoEntries:      map[string]*memoEntry{},
+		accountID:        options.AccountID,
+	}
+	s.setDisabledChecks(options.DisabledChecks)
+	s.setProfile(options.Profile)
+	s.setEnvironmentTagKeys(options.EnvironmentTagKeys)
+	s.evidenceLimit.Store(int64(options.EvidenceListLimit))
+	return s
+}
+
+// defaultScan is the Scan used by checks run on a context without one
+var defaultScan = NewScan(ScanOptions{})
+
+// scanKey is the context key carrying the Scan checks record into
+type scanKey struct{}
+
+// WithScan returns a copy of ctx whose checks run under scan's configuration
+// and record their timings, errors and skips into it
+func WithScan(ctx context.Context, scan *Scan) context.Context {
+	return context.WithValue(ctx, scanKey{}, scan)
+}
+
+// scanFrom returns the Scan set by WithScan, or the package default when none
+// was set
+func scanFrom(ctx context.Context) *Scan {
+	if scan, ok := ctx.Value(scanKey{}).(*Scan); ok && scan != nil {
+		return scan
+	}
+	return defaultScan
+}
@@ -0,0 +1,68 @@
+package checks
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// Two scans running at once, as two Runners in one process do, must not see
+// each other's settings or records; run with -race
+func TestScansAreIndependent(t *testing.T) {
+	id := CheckIDs()[0]
+	quiet := NewScan(ScanOptions{DisabledChecks: []string{id}, EvidenceListLimit: 1})
+	loud := NewScan(ScanOptions{EvidenceListLimit: NoEvidenceListLimit})
+	items := []string{"a", "b", "c"}
+
+	var wg sync.WaitGroup
+	run := func(scan *Scan, want string) {
+		defer wg.Done()
+		ctx := WithScan(context.Background(), scan)
+		for i := 0; i < 50; i++ {
+			if got := TruncateList(items, evidenceListLimit(ctx)); got != want {
+				t.Errorf("evidence list %q, want %q", got, want)
+				return
+			}
+			runCheck(ctx, id, func(context.Context) (CheckResult, error) {
+				return CheckResult{}, errors.New("AccessDenied")
+			})
+			memoize(ctx, "key", func() (int, error) { return i, nil })
+		}
+	}
+	wg.Add(2)
+	go run(quiet, "[a] and 2 more")
+	go run(loud, "[a b c]")
+	wg.Wait()
+
+	if got := quiet.SkippedChecks(); !reflect.DeepEqual(got, []string{id}) {
+		t.Errorf("quiet scan skipped %v, want %v", got, []string{id})
+	}
+	if got := loud.SkippedChecks(); len(got) != 0 {
+		t.Errorf("loud scan skipped %v, want nothing: the other scan's disabled checks leaked", got)
+	}
+	if got := len(quiet.CheckErrorCounts()); got != 0 {
+		t.Errorf("quiet scan recorded errors for a check it never ran: %v", quiet.CheckErrorCounts())
+	}
+	if got := loud.CheckErrorCounts(); len(got) == 0 {
+		t.Error("loud scan recorded no check errors")
+	}
+	for name, scan := range map[string]*Scan{"quiet": quiet, "loud": loud} {
+		if hits, misses := scan.DescribeCacheStats(); hits != 49 || misses != 1 {
+			t.Errorf("%s scan: describe cache hits %d, misses %d, want 49 and 1", name, hits, misses)
+		}
+	}
+}
+
+func TestContextWithoutScanUsesDefault(t *testing.T) {
+	defer SetEvidenceListLimit(0)
+
+	SetEvidenceListLimit(2)
+	if got := evidenceListLimit(context.Background()); got != 2 {
+		t.Errorf("limit without a Scan %d, want the default scan's 2", got)
+	}
+	if got := evidenceListLimit(WithScan(context.Background(), NewScan(ScanOptions{}))); got != DefaultEvidenceListLimit {
+		t.Errorf("limit in a new Scan %d, want %d", got, DefaultEvidenceListLimit)
+	}
+}
@@ -0,0 +1,84 @@
+package checks
+
+import (
+	"sort"
+	"strings"
+)
+
+// FrameworkScore is one framework's view of a scan: the results that map to
+// it, scored like a single-framework scan, and its controls split by outcome
+type FrameworkScore struct {
+	Framework      string   `json:"framework"`
+	Score          float64  `json:"score"`           // passed / (passed + failed) * 100
+	Passed         int      `json:"passed"`          // PASS results mapping to the framework
+	Failed         int      `json:"failed"`          // FAIL results mapping to the framework
+	Other          int      `json:"other"`           // INFO, NOT_APPLICABLE and other results
+	FailedControls []string `json:"failed_controls"` // framework controls with at least one failing result
+	PassedControls []string `json:"passed_controls"` // framework controls whose results all pass
+}
+
+// ScoreFrameworks scores results against every framework their Frameworks
+// mappings name, in one pass, so one scan can be reported as SOC2, PCI-DSS and
+// HIPAA without rerunning it. Results with no mapping for a framework do not
+// count towards it. Control lists are sorted.
+func ScoreFrameworks(results []CheckResult) map[string]FrameworkScore {
+	scores := map[string]*FrameworkScore{}
+	// controls records, per framework, whether each control has failed
+	controls := map[string]map[string]bool{}
+
+	for _, result := range results {
+		for framework, mapped := range result.Frameworks {
+			if strings.TrimSpace(mapped) == "" {
+				continue
+			}
+
+			score, ok := scores[framework]
+			if !ok {
+				score = &FrameworkScore{Framework: framework}
+				scores[framework] = score
+				controls[framework] = map[string]bool{}
+			}
+
+			switch result.Status {
+			case "PASS":
+				score.Passed++
+			case "FAIL":
+				score.Failed++
+			default:
+				score.Other++
+				continue
+			}
+
+			// Mappings may name several controls, e.g. "1.2.1, 1.3.4"
+			for _, control := range strings.Split(mapped, ",") {
+				control = strings.TrimSpace(control)
+				if control == "" {
+					continue
+				}
+				controls[framework][control] = controls[framework][control] || result.Status == "FAIL"
+			}
+		}
+	}
+
+	out := make(map[string]FrameworkScore, len(scores))
+	for framework, score := range scores {
+		if score.Passed+score.Failed > 0 {
+			score.Score = float64(score.Passed) / float64(score.Passed+score.Failed) * 100
+		}
+
+		score.FailedControls = []string{}
+		score.PassedControls = []string{}
+		for control, failed := range controls[framework] {
+			if failed {
+				score.FailedControls = append(score.FailedControls, control)
+			} else {
+				score.PassedControls = append(score.PassedControls, control)
+			}
+		}
+		sort.Strings(score.FailedControls)
+		sort.Strings(score.PassedControls)
+
+		out[framework] = *score
+	}
+	return out
+}
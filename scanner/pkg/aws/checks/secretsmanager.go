@@ -3,7 +3,6 @@ package checks
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
 )
@@ -24,17 +23,17 @@ func (c *SecretsManagerChecks) Run(ctx context.Context) ([]CheckResult, error) {
 	results := []CheckResult{}
 
 	// CIS 12.1 - Secret rotation enabled
-	if result, err := c.CheckSecretRotation(ctx); err == nil {
+	if result, err := runCheck(ctx, "secrets_manager.secret_rotation", c.CheckSecretRotation); err == nil {
 		results = append(results, result)
 	}
 
 	// CIS 12.2 - Secrets encrypted with KMS
-	if result, err := c.CheckSecretEncryption(ctx); err == nil {
+	if result, err := runCheck(ctx, "secrets_manager.secret_encryption", c.CheckSecretEncryption); err == nil {
 		results = append(results, result)
 	}
 
 	// CIS 12.3 - Unused secrets removed
-	if result, err := c.CheckUnusedSecrets(ctx); err == nil {
+	if result, err := runCheck(ctx, "secrets_manager.unused_secrets", c.CheckUnusedSecrets); err == nil {
 		results = append(results, result)
 	}
 
@@ -51,7 +50,7 @@ func (c *SecretsManagerChecks) CheckSecretRotation(ctx context.Context) (CheckRe
 			Evidence:   fmt.Sprintf("Failed to list secrets: %v", err),
 			Remediation: "Verify Secrets Manager permissions",
 			Priority:   PriorityLow,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("SECRETS_ROTATION"),
 		}, err
 	}
@@ -70,9 +69,9 @@ func (c *SecretsManagerChecks) CheckSecretRotation(ctx context.Context) (CheckRe
 4. Select or create Lambda rotation function
 5. Set rotation schedule (30-90 days recommended)`,
 			Priority:        PriorityLow,
-			Timestamp:       time.Now(),
+			Timestamp:       nowFunc(),
 			ScreenshotGuide: "Secrets Manager → Secrets → Screenshot showing no secrets",
-			ConsoleURL:      "https://console.aws.amazon.com/secretsmanager/home#/secrets",
+			ConsoleURL:      consoleURL("secretsmanager/home#/secrets", consoleRegion(ctx)),
 			Frameworks:      GetFrameworkMappings("SECRETS_ROTATION"),
 		}, nil
 	}
@@ -93,7 +92,7 @@ func (c *SecretsManagerChecks) CheckSecretRotation(ctx context.Context) (CheckRe
 			Control:     "CIS-12.1",
 			Name:        "Secrets Manager Rotation Enabled",
 			Status:      "FAIL",
-			Evidence:    fmt.Sprintf("%d/%d secrets lack automatic rotation: %v", len(withoutRotation), len(secrets.SecretList), withoutRotation),
+			Evidence:    fmt.Sprintf("%d/%d secrets lack automatic rotation: %s", len(withoutRotation), len(secrets.SecretList), TruncateList(withoutRotation, evidenceListLimit(ctx))),
 			Remediation: "Enable automatic rotation for all secrets",
 			RemediationDetail: fmt.Sprintf(`1. Open Secrets Manager console
 2. For each secret without rotation: %v
@@ -105,9 +104,9 @@ func (c *SecretsManagerChecks) CheckSecretRotation(ctx context.Context) (CheckRe
 8. Screenshot showing rotation enabled`, withoutRotation),
 			Severity:        "HIGH",
 			Priority:        PriorityHigh,
-			Timestamp:       time.Now(),
+			Timestamp:       nowFunc(),
 			ScreenshotGuide: "Secrets Manager → Secret → Rotation configuration → Screenshot showing rotation enabled",
-			ConsoleURL:      "https://console.aws.amazon.com/secretsmanager/home#/secrets",
+			ConsoleURL:      consoleURL("secretsmanager/home#/secrets", consoleRegion(ctx)),
 			Frameworks:      GetFrameworkMappings("SECRETS_ROTATION"),
 		}, nil
 	}
@@ -119,8 +118,8 @@ func (c *SecretsManagerChecks) CheckSecretRotation(ctx context.Context) (CheckRe
 		Evidence:    fmt.Sprintf("All %d secrets have automatic rotation enabled", withRotation),
 		Remediation: "N/A - All secrets rotate automatically",
 		Priority:    PriorityLow,
-		Timestamp:   time.Now(),
-		ConsoleURL:  "https://console.aws.amazon.com/secretsmanager/home#/secrets",
+		Timestamp:   nowFunc(),
+		ConsoleURL:  consoleURL("secretsmanager/home#/secrets", consoleRegion(ctx)),
 		Frameworks:  GetFrameworkMappings("SECRETS_ROTATION"),
 	}, nil
 }
@@ -135,7 +134,7 @@ func (c *SecretsManagerChecks) CheckSecretEncryption(ctx context.Context) (Check
 			Evidence:   fmt.Sprintf("Failed to list secrets: %v", err),
 			Remediation: "Verify permissions",
 			Priority:   PriorityLow,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("SECRETS_ENCRYPTION"),
 		}, err
 	}
@@ -148,7 +147,7 @@ func (c *SecretsManagerChecks) CheckSecretEncryption(ctx context.Context) (Check
 			Evidence:   "No secrets found",
 			Remediation: "N/A",
 			Priority:   PriorityLow,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("SECRETS_ENCRYPTION"),
 		}, nil
 	}
@@ -182,9 +181,9 @@ To use custom KMS keys:
 4. Existing secrets: Rotate secret to re-encrypt with new key`, withoutCustomKMS),
 			Severity:        "INFO",
 			Priority:        PriorityLow,
-			Timestamp:       time.Now(),
+			Timestamp:       nowFunc(),
 			ScreenshotGuide: "Secrets Manager → Secret → Screenshot showing custom KMS key",
-			ConsoleURL:      "https://console.aws.amazon.com/secretsmanager/home#/secrets",
+			ConsoleURL:      consoleURL("secretsmanager/home#/secrets", consoleRegion(ctx)),
 			Frameworks:      GetFrameworkMappings("SECRETS_ENCRYPTION"),
 		}, nil
 	}
@@ -196,8 +195,8 @@ To use custom KMS keys:
 		Evidence:    fmt.Sprintf("All %d secrets encrypted with custom KMS keys", withCustomKMS),
 		Remediation: "N/A - All secrets properly encrypted",
 		Priority:    PriorityLow,
-		Timestamp:   time.Now(),
-		ConsoleURL:  "https://console.aws.amazon.com/secretsmanager/home#/secrets",
+		Timestamp:   nowFunc(),
+		ConsoleURL:  consoleURL("secretsmanager/home#/secrets", consoleRegion(ctx)),
 		Frameworks:  GetFrameworkMappings("SECRETS_ENCRYPTION"),
 	}, nil
 }
@@ -212,7 +211,7 @@ func (c *SecretsManagerChecks) CheckUnusedSecrets(ctx context.Context) (CheckRes
 			Evidence:   "Failed to list secrets",
 			Remediation: "Verify permissions",
 			Priority:   PriorityLow,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("SECRETS_UNUSED"),
 		}, err
 	}
@@ -225,14 +224,14 @@ func (c *SecretsManagerChecks) CheckUnusedSecrets(ctx context.Context) (CheckRes
 			Evidence:   "No secrets found",
 			Remediation: "N/A",
 			Priority:   PriorityLow,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("SECRETS_UNUSED"),
 		}, nil
 	}
 
 	unusedSecrets := []string{}
 	activeSecrets := 0
-	ninetyDaysAgo := time.Now().AddDate(0, 0, -90)
+	ninetyDaysAgo := nowFunc().AddDate(0, 0, -90)
 
 	for _, secret := range secrets.SecretList {
 		// Check last accessed time
@@ -249,7 +248,7 @@ func (c *SecretsManagerChecks) CheckUnusedSecrets(ctx context.Context) (CheckRes
 			Control:     "CIS-12.3",
 			Name:        "Unused Secrets Removed",
 			Status:      "FAIL",
-			Evidence:    fmt.Sprintf("%d/%d secrets not accessed in 90+ days: %v", len(unusedSecrets), len(secrets.SecretList), unusedSecrets),
+			Evidence:    fmt.Sprintf("%d/%d secrets not accessed in 90+ days: %s", len(unusedSecrets), len(secrets.SecretList), TruncateList(unusedSecrets, evidenceListLimit(ctx))),
 			Remediation: "Review and delete unused secrets",
 			RemediationDetail: fmt.Sprintf(`1. Open Secrets Manager console
 2. For each unused secret: %v
@@ -264,9 +263,9 @@ Unused secrets increase:
 - Management overhead`, unusedSecrets),
 			Severity:        "MEDIUM",
 			Priority:        PriorityMedium,
-			Timestamp:       time.Now(),
+			Timestamp:       nowFunc(),
 			ScreenshotGuide: "Secrets Manager → Secrets → Screenshot showing deleted unused secrets",
-			ConsoleURL:      "https://console.aws.amazon.com/secretsmanager/home#/secrets",
+			ConsoleURL:      consoleURL("secretsmanager/home#/secrets", consoleRegion(ctx)),
 			Frameworks:      GetFrameworkMappings("SECRETS_UNUSED"),
 		}, nil
 	}
@@ -278,8 +277,8 @@ Unused secrets increase:
 		Evidence:    fmt.Sprintf("All %d secrets accessed within last 90 days", activeSecrets),
 		Remediation: "N/A - No unused secrets",
 		Priority:    PriorityLow,
-		Timestamp:   time.Now(),
-		ConsoleURL:  "https://console.aws.amazon.com/secretsmanager/home#/secrets",
+		Timestamp:   nowFunc(),
+		ConsoleURL:  consoleURL("secretsmanager/home#/secrets", consoleRegion(ctx)),
 		Frameworks:  GetFrameworkMappings("SECRETS_UNUSED"),
 	}, nil
 }
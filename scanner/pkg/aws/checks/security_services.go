@@ -3,7 +3,6 @@ package checks
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/guardduty"
 	"github.com/aws/aws-sdk-go-v2/service/macie2"
@@ -35,22 +34,22 @@ func (c *SecurityServicesChecks) Run(ctx context.Context) ([]CheckResult, error)
 	results := []CheckResult{}
 
 	// GuardDuty checks
-	if result, err := c.CheckGuardDutyEnabled(ctx); err == nil {
+	if result, err := runCheck(ctx, "security_services.guardduty_enabled", c.CheckGuardDutyEnabled); err == nil {
 		results = append(results, result)
 	}
 
 	// Macie checks
-	if result, err := c.CheckMacieEnabled(ctx); err == nil {
+	if result, err := runCheck(ctx, "security_services.macie_enabled", c.CheckMacieEnabled); err == nil {
 		results = append(results, result)
 	}
 
 	// Security Hub checks
-	if result, err := c.CheckSecurityHubEnabled(ctx); err == nil {
+	if result, err := runCheck(ctx, "security_services.security_hub_enabled", c.CheckSecurityHubEnabled); err == nil {
 		results = append(results, result)
 	}
 
 	// Inspector checks
-	if result, err := c.CheckInspectorEnabled(ctx); err == nil {
+	if result, err := runCheck(ctx, "security_services.inspector_enabled", c.CheckInspectorEnabled); err == nil {
 		results = append(results, result)
 	}
 
@@ -70,9 +69,9 @@ func (c *SecurityServicesChecks) CheckGuardDutyEnabled(ctx context.Context) (Che
 			Remediation:       "Enable GuardDuty",
 			RemediationDetail: "aws guardduty create-detector --enable",
 			ScreenshotGuide:   "GuardDuty Console → Getting started → Screenshot showing detector enabled",
-			ConsoleURL:        "https://console.aws.amazon.com/guardduty/home",
+			ConsoleURL:        consoleURL("guardduty/home", consoleRegion(ctx)),
 			Priority:          PriorityCritical,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        map[string]string{"CIS-AWS": "9.1", "SOC2": "CC7.2"},
 		}, nil
 	}
@@ -87,9 +86,9 @@ func (c *SecurityServicesChecks) CheckGuardDutyEnabled(ctx context.Context) (Che
 			Remediation:       "Enable GuardDuty to detect threats",
 			RemediationDetail: `aws guardduty create-detector --enable --finding-publishing-frequency FIFTEEN_MINUTES`,
 			ScreenshotGuide:   "GuardDuty Console → Dashboard → Screenshot showing detector enabled with findings",
-			ConsoleURL:        "https://console.aws.amazon.com/guardduty/home",
+			ConsoleURL:        consoleURL("guardduty/home", consoleRegion(ctx)),
 			Priority:          PriorityCritical,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        map[string]string{"CIS-AWS": "9.1", "SOC2": "CC7.2", "PCI-DSS": "11.4"},
 		}, nil
 	}
@@ -113,9 +112,9 @@ func (c *SecurityServicesChecks) CheckGuardDutyEnabled(ctx context.Context) (Che
 			Remediation:       "Enable GuardDuty detector",
 			RemediationDetail: fmt.Sprintf("aws guardduty update-detector --detector-id %s --enable", detectorId),
 			ScreenshotGuide:   "GuardDuty Console → Settings → Screenshot showing detector enabled",
-			ConsoleURL:        "https://console.aws.amazon.com/guardduty/home",
+			ConsoleURL:        consoleURL("guardduty/home", consoleRegion(ctx)),
 			Priority:          PriorityCritical,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        map[string]string{"CIS-AWS": "9.1"},
 		}, nil
 	}
@@ -125,8 +124,9 @@ func (c *SecurityServicesChecks) CheckGuardDutyEnabled(ctx context.Context) (Che
 		Name:       "GuardDuty Enabled",
 		Status:     "PASS",
 		Evidence:   fmt.Sprintf("GuardDuty is enabled (Detector: %s) | Meets CIS 9.1", detectorId),
+		ConsoleURL: consoleURL("guardduty/home", consoleRegion(ctx)),
 		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
+		Timestamp:  nowFunc(),
 		Frameworks: map[string]string{"CIS-AWS": "9.1"},
 	}, nil
 }
@@ -144,9 +144,9 @@ func (c *SecurityServicesChecks) CheckMacieEnabled(ctx context.Context) (CheckRe
 			Remediation:       "Enable Amazon Macie",
 			RemediationDetail: `aws macie2 enable-macie`,
 			ScreenshotGuide:   "Macie Console → Dashboard → Screenshot showing Macie enabled",
-			ConsoleURL:        "https://console.aws.amazon.com/macie/home",
+			ConsoleURL:        consoleURL("macie/home", consoleRegion(ctx)),
 			Priority:          PriorityHigh,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        map[string]string{"CIS-AWS": "9.2", "SOC2": "CC6.7", "PCI-DSS": "3.4"},
 		}, nil
 	}
@@ -161,9 +161,9 @@ func (c *SecurityServicesChecks) CheckMacieEnabled(ctx context.Context) (CheckRe
 			Remediation:       "Enable Amazon Macie",
 			RemediationDetail: "aws macie2 enable-macie",
 			ScreenshotGuide:   "Macie Console → Settings → Screenshot showing Macie status enabled",
-			ConsoleURL:        "https://console.aws.amazon.com/macie/home",
+			ConsoleURL:        consoleURL("macie/home", consoleRegion(ctx)),
 			Priority:          PriorityHigh,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        map[string]string{"CIS-AWS": "9.2"},
 		}, nil
 	}
@@ -173,8 +173,9 @@ func (c *SecurityServicesChecks) CheckMacieEnabled(ctx context.Context) (CheckRe
 		Name:       "Macie Enabled",
 		Status:     "PASS",
 		Evidence:   "Amazon Macie is enabled for sensitive data discovery | Meets CIS 9.2",
+		ConsoleURL: consoleURL("macie/home", consoleRegion(ctx)),
 		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
+		Timestamp:  nowFunc(),
 		Frameworks: map[string]string{"CIS-AWS": "9.2"},
 	}, nil
 }
@@ -192,9 +193,9 @@ func (c *SecurityServicesChecks) CheckSecurityHubEnabled(ctx context.Context) (C
 			Remediation:       "Enable AWS Security Hub",
 			RemediationDetail: `aws securityhub enable-security-hub --enable-default-standards`,
 			ScreenshotGuide:   "Security Hub Console → Summary → Screenshot showing Security Hub enabled with standards",
-			ConsoleURL:        "https://console.aws.amazon.com/securityhub/home",
+			ConsoleURL:        consoleURL("securityhub/home", consoleRegion(ctx)),
 			Priority:          PriorityCritical,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        map[string]string{"CIS-AWS": "9.3", "SOC2": "CC7.1, CC7.2", "PCI-DSS": "10.6, 11.4"},
 		}, nil
 	}
@@ -209,9 +210,9 @@ func (c *SecurityServicesChecks) CheckSecurityHubEnabled(ctx context.Context) (C
 			Remediation:       "Enable Security Hub",
 			RemediationDetail: "aws securityhub enable-security-hub",
 			ScreenshotGuide:   "Security Hub Console → Screenshot showing enabled",
-			ConsoleURL:        "https://console.aws.amazon.com/securityhub/home",
+			ConsoleURL:        consoleURL("securityhub/home", consoleRegion(ctx)),
 			Priority:          PriorityCritical,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        map[string]string{"CIS-AWS": "9.3"},
 		}, nil
 	}
@@ -221,8 +222,9 @@ func (c *SecurityServicesChecks) CheckSecurityHubEnabled(ctx context.Context) (C
 		Name:       "Security Hub Enabled",
 		Status:     "PASS",
 		Evidence:   fmt.Sprintf("AWS Security Hub is enabled | Meets CIS 9.3"),
+		ConsoleURL: consoleURL("securityhub/home", consoleRegion(ctx)),
 		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
+		Timestamp:  nowFunc(),
 		Frameworks: map[string]string{"CIS-AWS": "9.3"},
 	}, nil
 }
@@ -240,9 +242,9 @@ func (c *SecurityServicesChecks) CheckInspectorEnabled(ctx context.Context) (Che
 			Remediation:       "Enable Amazon Inspector",
 			RemediationDetail: `aws inspector2 enable --resource-types EC2 ECR LAMBDA`,
 			ScreenshotGuide:   "Inspector Console → Dashboard → Screenshot showing Inspector enabled",
-			ConsoleURL:        "https://console.aws.amazon.com/inspector/v2/home",
+			ConsoleURL:        consoleURL("inspector/v2/home", consoleRegion(ctx)),
 			Priority:          PriorityHigh,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        map[string]string{"CIS-AWS": "9.4", "SOC2": "CC8.1", "PCI-DSS": "6.2, 11.2.2"},
 		}, nil
 	}
@@ -257,9 +259,9 @@ func (c *SecurityServicesChecks) CheckInspectorEnabled(ctx context.Context) (Che
 			Remediation:       "Enable Amazon Inspector for vulnerability scanning",
 			RemediationDetail: "aws inspector2 enable --resource-types EC2 ECR LAMBDA",
 			ScreenshotGuide:   "Inspector Console → Account management → Screenshot showing account enabled",
-			ConsoleURL:        "https://console.aws.amazon.com/inspector/v2/home",
+			ConsoleURL:        consoleURL("inspector/v2/home", consoleRegion(ctx)),
 			Priority:          PriorityHigh,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        map[string]string{"CIS-AWS": "9.4"},
 		}, nil
 	}
@@ -275,9 +277,9 @@ func (c *SecurityServicesChecks) CheckInspectorEnabled(ctx context.Context) (Che
 			Remediation:       "Enable Amazon Inspector",
 			RemediationDetail: "aws inspector2 enable --resource-types EC2 ECR LAMBDA",
 			ScreenshotGuide:   "Inspector Console → Settings → Screenshot showing status enabled",
-			ConsoleURL:        "https://console.aws.amazon.com/inspector/v2/home",
+			ConsoleURL:        consoleURL("inspector/v2/home", consoleRegion(ctx)),
 			Priority:          PriorityHigh,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        map[string]string{"CIS-AWS": "9.4"},
 		}, nil
 	}
@@ -287,8 +289,9 @@ func (c *SecurityServicesChecks) CheckInspectorEnabled(ctx context.Context) (Che
 		Name:       "Inspector Enabled",
 		Status:     "PASS",
 		Evidence:   "Amazon Inspector is enabled for vulnerability scanning | Meets CIS 9.4",
+		ConsoleURL: consoleURL("inspector/v2/home", consoleRegion(ctx)),
 		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
+		Timestamp:  nowFunc(),
 		Frameworks: map[string]string{"CIS-AWS": "9.4"},
 	}, nil
 }
@@ -4,7 +4,6 @@ import (
     "context"
     "fmt"
     "strings"
-    "time"
     
     "github.com/aws/aws-sdk-go-v2/aws"
     "github.com/aws/aws-sdk-go-v2/service/iam"
@@ -38,21 +37,21 @@ func (c *CC1Checks) Run(ctx context.Context) ([]CheckResult, error) {
     results := []CheckResult{}
     
     // CC1.1: Demonstrates Commitment to Integrity and Ethical Values
-    results = append(results, c.CheckCC1_1_IntegrityAndEthics(ctx)...)
+    results = append(results, runChecks(ctx, "soc2.cc1_1_integrity_and_ethics", c.CheckCC1_1_IntegrityAndEthics)...)
     
     // CC1.2: Board Exercises Oversight Responsibility
-    results = append(results, c.CheckCC1_2_BoardOversight(ctx)...)
+    results = append(results, runChecks(ctx, "soc2.cc1_2_board_oversight", c.CheckCC1_2_BoardOversight)...)
     
     // CC1.3: Management Establishes Structure, Authority, and Responsibility
-    results = append(results, c.CheckCC1_3_OrganizationalStructure(ctx)...)
+    results = append(results, runChecks(ctx, "soc2.cc1_3_organizational_structure", c.CheckCC1_3_OrganizationalStructure)...)
     
     // CC1.4: Demonstrates Commitment to Competence
-    results = append(results, c.CheckCC1_4_Competence(ctx)...)
+    results = append(results, runChecks(ctx, "soc2.cc1_4_competence", c.CheckCC1_4_Competence)...)
     
     // CC1.5: Enforces Accountability
-    results = append(results, c.CheckCC1_5_Accountability(ctx)...)
+    results = append(results, runChecks(ctx, "soc2.cc1_5_accountability", c.CheckCC1_5_Accountability)...)
     
-    return results, nil
+    return withSOC2Mapping(results), nil
 }
 
 func (c *CC1Checks) CheckCC1_1_IntegrityAndEthics(ctx context.Context) []CheckResult {
@@ -70,9 +69,9 @@ func (c *CC1Checks) CheckCC1_1_IntegrityAndEthics(ctx context.Context) []CheckRe
                 Evidence:    "AWS Organizations not enabled - no centralized governance",
                 Remediation: "Enable AWS Organizations and implement Service Control Policies",
                 ScreenshotGuide: "1. Go to AWS Organizations\n2. Screenshot the organization structure\n3. Document SCPs in place",
-                ConsoleURL:  "https://console.aws.amazon.com/organizations/",
+                ConsoleURL:  consoleURL("organizations/", consoleRegion(ctx)),
                 Priority:    PriorityHigh,
-                Timestamp:   time.Now(),
+                Timestamp:   nowFunc(),
             })
         } else {
             // Check for Service Control Policies
@@ -87,7 +86,7 @@ func (c *CC1Checks) CheckCC1_1_IntegrityAndEthics(ctx context.Context) []CheckRe
                     Status:    "PASS",
                     Evidence:  fmt.Sprintf("AWS Organizations enabled with %d SCPs for governance", len(policies.Policies)),
                     Priority:  PriorityInfo,
-                    Timestamp: time.Now(),
+                    Timestamp: nowFunc(),
                 })
             } else {
                 results = append(results, CheckResult{
@@ -98,7 +97,7 @@ func (c *CC1Checks) CheckCC1_1_IntegrityAndEthics(ctx context.Context) []CheckRe
                     Evidence:    "Organizations enabled but no Service Control Policies defined",
                     Remediation: "Implement SCPs to enforce organizational policies",
                     Priority:    PriorityMedium,
-                    Timestamp:   time.Now(),
+                    Timestamp:   nowFunc(),
                 })
             }
         }
@@ -135,7 +134,7 @@ func (c *CC1Checks) CheckCC1_2_BoardOversight(ctx context.Context) []CheckResult
             Status:    "PASS",
             Evidence:  fmt.Sprintf("Found %d admin roles and %d audit/readonly roles for oversight", adminRoles, readOnlyRoles),
             Priority:  PriorityInfo,
-            Timestamp: time.Now(),
+            Timestamp: nowFunc(),
         })
     } else {
         results = append(results, CheckResult{
@@ -146,7 +145,7 @@ func (c *CC1Checks) CheckCC1_2_BoardOversight(ctx context.Context) []CheckResult
             Evidence:    "Insufficient role segregation for proper oversight",
             Remediation: "Create separate admin, operator, and audit roles",
             Priority:    PriorityMedium,
-            Timestamp:   time.Now(),
+            Timestamp:   nowFunc(),
         })
     }
     
@@ -176,7 +175,7 @@ func (c *CC1Checks) CheckCC1_3_OrganizationalStructure(ctx context.Context) []Ch
                     Status:    "PASS",
                     Evidence:  fmt.Sprintf("%d hierarchical parameters showing organizational structure", hierarchicalParams),
                     Priority:  PriorityInfo,
-                    Timestamp: time.Now(),
+                    Timestamp: nowFunc(),
                 })
             } else {
                 results = append(results, CheckResult{
@@ -186,7 +185,7 @@ func (c *CC1Checks) CheckCC1_3_OrganizationalStructure(ctx context.Context) []Ch
                     Evidence:    "Parameters exist but no hierarchical structure detected",
                     Remediation: "Consider organizing parameters hierarchically (e.g., /prod/app/config)",
                     Priority:    PriorityLow,
-                    Timestamp:   time.Now(),
+                    Timestamp:   nowFunc(),
                 })
             }
         }
@@ -221,7 +220,7 @@ func (c *CC1Checks) CheckCC1_4_Competence(ctx context.Context) []CheckResult {
                 Status:    "PASS",
                 Evidence:  fmt.Sprintf("%.0f%% of IAM users have MFA enabled", mfaPercentage),
                 Priority:  PriorityInfo,
-                Timestamp: time.Now(),
+                Timestamp: nowFunc(),
             })
         } else {
             results = append(results, CheckResult{
@@ -232,7 +231,7 @@ func (c *CC1Checks) CheckCC1_4_Competence(ctx context.Context) []CheckResult {
                 Evidence:    fmt.Sprintf("Only %.0f%% of users have MFA enabled", mfaPercentage),
                 Remediation: "Enforce MFA for all IAM users",
                 Priority:    PriorityHigh,
-                Timestamp:   time.Now(),
+                Timestamp:   nowFunc(),
             })
         }
     }
@@ -264,7 +263,7 @@ func (c *CC1Checks) CheckCC1_5_Accountability(ctx context.Context) []CheckResult
                 Status:    "PASS",
                 Evidence:  fmt.Sprintf("%d restrictive policies enforcing accountability", restrictivePolicies),
                 Priority:  PriorityInfo,
-                Timestamp: time.Now(),
+                Timestamp: nowFunc(),
             })
         } else {
             results = append(results, CheckResult{
@@ -274,7 +273,7 @@ func (c *CC1Checks) CheckCC1_5_Accountability(ctx context.Context) []CheckResult
                 Evidence:    "No explicit deny policies found",
                 Remediation: "Consider implementing permission boundaries and explicit deny policies",
                 Priority:    PriorityLow,
-                Timestamp:   time.Now(),
+                Timestamp:   nowFunc(),
             })
         }
     }
@@ -307,15 +306,15 @@ func (c *CC2Checks) Run(ctx context.Context) ([]CheckResult, error) {
     results := []CheckResult{}
     
     // CC2.1: Obtains or Generates Relevant Information
-    results = append(results, c.CheckCC2_1_InformationGeneration(ctx)...)
+    results = append(results, runChecks(ctx, "soc2.cc2_1_information_generation", c.CheckCC2_1_InformationGeneration)...)
     
     // CC2.2: Communicates Internal Control Information
-    results = append(results, c.CheckCC2_2_InternalCommunication(ctx)...)
+    results = append(results, runChecks(ctx, "soc2.cc2_2_internal_communication", c.CheckCC2_2_InternalCommunication)...)
     
     // CC2.3: Communicates with External Parties
-    results = append(results, c.CheckCC2_3_ExternalCommunication(ctx)...)
+    results = append(results, runChecks(ctx, "soc2.cc2_3_external_communication", c.CheckCC2_3_ExternalCommunication)...)
     
-    return results, nil
+    return withSOC2Mapping(results), nil
 }
 
 func (c *CC2Checks) CheckCC2_1_InformationGeneration(ctx context.Context) []CheckResult {
@@ -341,7 +340,7 @@ func (c *CC2Checks) CheckCC2_1_InformationGeneration(ctx context.Context) []Chec
                     Status:    "PASS",
                     Evidence:  fmt.Sprintf("%d of %d parameters are encrypted", encryptedCount, len(params.Parameters)),
                     Priority:  PriorityInfo,
-                    Timestamp: time.Now(),
+                    Timestamp: nowFunc(),
                 })
             } else if len(params.Parameters) > 0 {
                 results = append(results, CheckResult{
@@ -352,7 +351,7 @@ func (c *CC2Checks) CheckCC2_1_InformationGeneration(ctx context.Context) []Chec
                     Evidence:    fmt.Sprintf("%d parameters but none encrypted - sensitive data at risk", len(params.Parameters)),
                     Remediation: "Use SecureString type for sensitive parameters",
                     Priority:    PriorityMedium,
-                    Timestamp:   time.Now(),
+                    Timestamp:   nowFunc(),
                 })
             }
         }
@@ -377,9 +376,9 @@ func (c *CC2Checks) CheckCC2_2_InternalCommunication(ctx context.Context) []Chec
                 Evidence:    "No SNS topics configured - no security alerting mechanism",
                 Remediation: "Create SNS topics for security alerts and operational notifications",
                 ScreenshotGuide: "1. Go to SNS Console\n2. Create topics for SecurityAlerts, OperationalAlerts\n3. Configure subscriptions",
-                ConsoleURL:  "https://console.aws.amazon.com/sns/",
+                ConsoleURL:  consoleURL("sns/", consoleRegion(ctx)),
                 Priority:    PriorityHigh,
-                Timestamp:   time.Now(),
+                Timestamp:   nowFunc(),
             })
         } else {
             // Check for security-related topics
@@ -400,7 +399,7 @@ func (c *CC2Checks) CheckCC2_2_InternalCommunication(ctx context.Context) []Chec
                     Status:    "PASS",
                     Evidence:  fmt.Sprintf("%d security/alert topics configured for notifications", securityTopics),
                     Priority:  PriorityInfo,
-                    Timestamp: time.Now(),
+                    Timestamp: nowFunc(),
                 })
             } else {
                 results = append(results, CheckResult{
@@ -411,7 +410,7 @@ func (c *CC2Checks) CheckCC2_2_InternalCommunication(ctx context.Context) []Chec
                     Evidence:    fmt.Sprintf("%d SNS topics but none for security alerts", len(topics.Topics)),
                     Remediation: "Create dedicated SNS topics for security notifications",
                     Priority:    PriorityMedium,
-                    Timestamp:   time.Now(),
+                    Timestamp:   nowFunc(),
                 })
             }
         }
@@ -448,7 +447,7 @@ func (c *CC2Checks) CheckCC2_3_ExternalCommunication(ctx context.Context) []Chec
             Status:    "INFO",
             Evidence:  fmt.Sprintf("%d cross-account roles configured - verify these are authorized", crossAccountRoles),
             Priority:  PriorityInfo,
-            Timestamp: time.Now(),
+            Timestamp: nowFunc(),
         })
     } else {
         results = append(results, CheckResult{
@@ -457,7 +456,7 @@ func (c *CC2Checks) CheckCC2_3_ExternalCommunication(ctx context.Context) []Chec
             Status:    "PASS",
             Evidence:  "No cross-account access detected - good isolation",
             Priority:  PriorityInfo,
-            Timestamp: time.Now(),
+            Timestamp: nowFunc(),
         })
     }
     
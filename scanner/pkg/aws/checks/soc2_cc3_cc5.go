@@ -3,7 +3,6 @@ package checks
 import (
     "context"
     "fmt"
-    "time"
     
     "github.com/aws/aws-sdk-go-v2/service/backup"
     "github.com/aws/aws-sdk-go-v2/service/cloudwatch"
@@ -39,18 +38,18 @@ func (c *CC3Checks) Run(ctx context.Context) ([]CheckResult, error) {
     results := []CheckResult{}
     
     // CC3.1: Specifies Objectives
-    results = append(results, c.CheckCC3_1_Objectives(ctx)...)
+    results = append(results, runChecks(ctx, "soc2.cc3_1_objectives", c.CheckCC3_1_Objectives)...)
     
     // CC3.2: Identifies and Assesses Risks
-    results = append(results, c.CheckCC3_2_RiskIdentification(ctx)...)
+    results = append(results, runChecks(ctx, "soc2.cc3_2_risk_identification", c.CheckCC3_2_RiskIdentification)...)
     
     // CC3.3: Considers Risk Potential for Fraud
-    results = append(results, c.CheckCC3_3_FraudRisk(ctx)...)
+    results = append(results, runChecks(ctx, "soc2.cc3_3_fraud_risk", c.CheckCC3_3_FraudRisk)...)
     
     // CC3.4: Identifies and Assesses Changes
-    results = append(results, c.CheckCC3_4_ChangeRisk(ctx)...)
+    results = append(results, runChecks(ctx, "soc2.cc3_4_change_risk", c.CheckCC3_4_ChangeRisk)...)
     
-    return results, nil
+    return withSOC2Mapping(results), nil
 }
 
 func (c *CC3Checks) CheckCC3_1_Objectives(ctx context.Context) []CheckResult {
@@ -69,9 +68,9 @@ func (c *CC3Checks) CheckCC3_1_Objectives(ctx context.Context) []CheckResult {
                 Evidence:    "AWS Security Hub not enabled - no centralized security objectives",
                 Remediation: "Enable Security Hub to centralize security standards and objectives",
                 ScreenshotGuide: "1. Go to Security Hub\n2. Enable with security standards\n3. Document compliance scores",
-                ConsoleURL:  "https://console.aws.amazon.com/securityhub/",
+                ConsoleURL:  consoleURL("securityhub/", consoleRegion(ctx)),
                 Priority:    PriorityHigh,
-                Timestamp:   time.Now(),
+                Timestamp:   nowFunc(),
             })
         } else {
             // Check for enabled standards
@@ -84,7 +83,7 @@ func (c *CC3Checks) CheckCC3_1_Objectives(ctx context.Context) []CheckResult {
                     Status:    "PASS",
                     Evidence:  fmt.Sprintf("Security Hub enabled with %d security standards", len(standards.Standards)),
                     Priority:  PriorityInfo,
-                    Timestamp: time.Now(),
+                    Timestamp: nowFunc(),
                 })
             } else {
                 results = append(results, CheckResult{
@@ -95,7 +94,7 @@ func (c *CC3Checks) CheckCC3_1_Objectives(ctx context.Context) []CheckResult {
                     Evidence:    "Security Hub enabled but no standards activated",
                     Remediation: "Enable AWS Foundational Security Best Practices and CIS standards",
                     Priority:    PriorityMedium,
-                    Timestamp:   time.Now(),
+                    Timestamp:   nowFunc(),
                 })
             }
         }
@@ -120,9 +119,9 @@ func (c *CC3Checks) CheckCC3_2_RiskIdentification(ctx context.Context) []CheckRe
                 Evidence:    "GuardDuty not enabled - no automated threat detection",
                 Remediation: "Enable GuardDuty for continuous threat monitoring",
                 ScreenshotGuide: "1. Go to GuardDuty\n2. Enable for all regions\n3. Configure threat intel feeds",
-                ConsoleURL:  "https://console.aws.amazon.com/guardduty/",
+                ConsoleURL:  consoleURL("guardduty/", consoleRegion(ctx)),
                 Priority:    PriorityCritical,
-                Timestamp:   time.Now(),
+                Timestamp:   nowFunc(),
             })
         } else {
             // Check if detector is actually enabled
@@ -138,7 +137,7 @@ func (c *CC3Checks) CheckCC3_2_RiskIdentification(ctx context.Context) []CheckRe
                         Status:    "PASS",
                         Evidence:  "GuardDuty actively monitoring for threats",
                         Priority:  PriorityInfo,
-                        Timestamp: time.Now(),
+                        Timestamp: nowFunc(),
                     })
                 } else {
                     results = append(results, CheckResult{
@@ -149,7 +148,7 @@ func (c *CC3Checks) CheckCC3_2_RiskIdentification(ctx context.Context) []CheckRe
                         Evidence:    "GuardDuty detector exists but not enabled",
                         Remediation: "Enable the GuardDuty detector",
                         Priority:    PriorityHigh,
-                        Timestamp:   time.Now(),
+                        Timestamp:   nowFunc(),
                     })
                 }
                 break // Check only first detector
@@ -169,7 +168,7 @@ func (c *CC3Checks) CheckCC3_2_RiskIdentification(ctx context.Context) []CheckRe
                 Status:    "INFO",
                 Evidence:  "Inspector v2 status checked - manual review required",
                 Priority:  PriorityInfo,
-                Timestamp: time.Now(),
+                Timestamp: nowFunc(),
             })
         } else {
             results = append(results, CheckResult{
@@ -180,7 +179,7 @@ func (c *CC3Checks) CheckCC3_2_RiskIdentification(ctx context.Context) []CheckRe
                 Evidence:    "Inspector v2 not enabled",
                 Remediation: "Enable Inspector v2 for vulnerability scanning",
                 Priority:    PriorityHigh,
-                Timestamp:   time.Now(),
+                Timestamp:   nowFunc(),
             })
         }
     }
@@ -203,7 +202,7 @@ func (c *CC3Checks) CheckCC3_3_FraudRisk(ctx context.Context) []CheckResult {
                 Status:    "PASS",
                 Evidence:  "GuardDuty monitoring for fraudulent activity patterns",
                 Priority:  PriorityInfo,
-                Timestamp: time.Now(),
+                Timestamp: nowFunc(),
             })
         } else {
             results = append(results, CheckResult{
@@ -214,7 +213,7 @@ func (c *CC3Checks) CheckCC3_3_FraudRisk(ctx context.Context) []CheckResult {
                 Evidence:    "No automated fraud detection mechanisms in place",
                 Remediation: "Enable GuardDuty with IAM finding types",
                 Priority:    PriorityHigh,
-                Timestamp:   time.Now(),
+                Timestamp:   nowFunc(),
             })
         }
     }
@@ -232,7 +231,7 @@ func (c *CC3Checks) CheckCC3_4_ChangeRisk(ctx context.Context) []CheckResult {
         Status:    "INFO",
         Evidence:  "Manual review required: Verify change management process includes risk assessment",
         Priority:  PriorityInfo,
-        Timestamp: time.Now(),
+        Timestamp: nowFunc(),
     })
     
     return results
@@ -261,12 +260,12 @@ func (c *CC4Checks) Run(ctx context.Context) ([]CheckResult, error) {
     results := []CheckResult{}
     
     // CC4.1: Selects and Develops Ongoing and Separate Evaluations
-    results = append(results, c.CheckCC4_1_Evaluations(ctx)...)
+    results = append(results, runChecks(ctx, "soc2.cc4_1_evaluations", c.CheckCC4_1_Evaluations)...)
     
     // CC4.2: Evaluates and Communicates Deficiencies
-    results = append(results, c.CheckCC4_2_Deficiencies(ctx)...)
+    results = append(results, runChecks(ctx, "soc2.cc4_2_deficiencies", c.CheckCC4_2_Deficiencies)...)
     
-    return results, nil
+    return withSOC2Mapping(results), nil
 }
 
 func (c *CC4Checks) CheckCC4_1_Evaluations(ctx context.Context) []CheckResult {
@@ -285,9 +284,9 @@ func (c *CC4Checks) CheckCC4_1_Evaluations(ctx context.Context) []CheckResult {
                 Evidence:    "AWS Config not enabled - no continuous compliance monitoring",
                 Remediation: "Enable AWS Config to track configuration changes",
                 ScreenshotGuide: "1. Go to AWS Config\n2. Set up configuration recorder\n3. Enable compliance rules",
-                ConsoleURL:  "https://console.aws.amazon.com/config/",
+                ConsoleURL:  consoleURL("config/", consoleRegion(ctx)),
                 Priority:    PriorityHigh,
-                Timestamp:   time.Now(),
+                Timestamp:   nowFunc(),
             })
         } else {
             // Check if recorder is actually running
@@ -309,7 +308,7 @@ func (c *CC4Checks) CheckCC4_1_Evaluations(ctx context.Context) []CheckResult {
                         Status:    "PASS",
                         Evidence:  "AWS Config actively recording configuration changes",
                         Priority:  PriorityInfo,
-                        Timestamp: time.Now(),
+                        Timestamp: nowFunc(),
                     })
                 } else {
                     results = append(results, CheckResult{
@@ -320,7 +319,7 @@ func (c *CC4Checks) CheckCC4_1_Evaluations(ctx context.Context) []CheckResult {
                         Evidence:    "Config recorder exists but not recording",
                         Remediation: "Start the Config recorder",
                         Priority:    PriorityHigh,
-                        Timestamp:   time.Now(),
+                        Timestamp:   nowFunc(),
                     })
                 }
             }
@@ -338,7 +337,7 @@ func (c *CC4Checks) CheckCC4_1_Evaluations(ctx context.Context) []CheckResult {
                 Status:    "PASS",
                 Evidence:  fmt.Sprintf("%d CloudWatch alarms configured for monitoring", len(alarms.MetricAlarms)),
                 Priority:  PriorityInfo,
-                Timestamp: time.Now(),
+                Timestamp: nowFunc(),
             })
         } else {
             results = append(results, CheckResult{
@@ -349,7 +348,7 @@ func (c *CC4Checks) CheckCC4_1_Evaluations(ctx context.Context) []CheckResult {
                 Evidence:    "No CloudWatch alarms configured",
                 Remediation: "Set up CloudWatch alarms for critical metrics",
                 Priority:    PriorityMedium,
-                Timestamp:   time.Now(),
+                Timestamp:   nowFunc(),
             })
         }
     }
@@ -388,7 +387,7 @@ func (c *CC4Checks) CheckCC4_2_Deficiencies(ctx context.Context) []CheckResult {
                     Evidence:    fmt.Sprintf("%d Config Rules are non-compliant", nonCompliantRules),
                     Remediation: "Review and remediate non-compliant resources",
                     Priority:    PriorityMedium,
-                    Timestamp:   time.Now(),
+                    Timestamp:   nowFunc(),
                 })
             } else {
                 results = append(results, CheckResult{
@@ -397,7 +396,7 @@ func (c *CC4Checks) CheckCC4_2_Deficiencies(ctx context.Context) []CheckResult {
                     Status:    "PASS",
                     Evidence:  fmt.Sprintf("%d Config Rules all compliant", len(rules.ConfigRules)),
                     Priority:  PriorityInfo,
-                    Timestamp: time.Now(),
+                    Timestamp: nowFunc(),
                 })
             }
         } else {
@@ -409,7 +408,7 @@ func (c *CC4Checks) CheckCC4_2_Deficiencies(ctx context.Context) []CheckResult {
                 Evidence:    "No Config Rules configured for compliance checking",
                 Remediation: "Deploy Config Rules for compliance requirements",
                 Priority:    PriorityMedium,
-                Timestamp:   time.Now(),
+                Timestamp:   nowFunc(),
             })
         }
     }
@@ -440,15 +439,15 @@ func (c *CC5Checks) Run(ctx context.Context) ([]CheckResult, error) {
     results := []CheckResult{}
     
     // CC5.1: Selects and Develops Control Activities
-    results = append(results, c.CheckCC5_1_ControlSelection(ctx)...)
+    results = append(results, runChecks(ctx, "soc2.cc5_1_control_selection", c.CheckCC5_1_ControlSelection)...)
     
     // CC5.2: Selects and Develops General Controls Over Technology
-    results = append(results, c.CheckCC5_2_TechnologyControls(ctx)...)
+    results = append(results, runChecks(ctx, "soc2.cc5_2_technology_controls", c.CheckCC5_2_TechnologyControls)...)
     
     // CC5.3: Deploys Through Policies and Procedures
-    results = append(results, c.CheckCC5_3_Deployment(ctx)...)
+    results = append(results, runChecks(ctx, "soc2.cc5_3_deployment", c.CheckCC5_3_Deployment)...)
     
-    return results, nil
+    return withSOC2Mapping(results), nil
 }
 
 func (c *CC5Checks) CheckCC5_1_ControlSelection(ctx context.Context) []CheckResult {
@@ -467,9 +466,9 @@ func (c *CC5Checks) CheckCC5_1_ControlSelection(ctx context.Context) []CheckResu
                 Evidence:    "No AWS Backup plans configured - data at risk",
                 Remediation: "Create backup plans for critical resources",
                 ScreenshotGuide: "1. Go to AWS Backup\n2. Create backup plan\n3. Assign resources",
-                ConsoleURL:  "https://console.aws.amazon.com/backup/",
+                ConsoleURL:  consoleURL("backup/", consoleRegion(ctx)),
                 Priority:    PriorityHigh,
-                Timestamp:   time.Now(),
+                Timestamp:   nowFunc(),
             })
         } else {
             // Check backup vaults
@@ -486,7 +485,7 @@ func (c *CC5Checks) CheckCC5_1_ControlSelection(ctx context.Context) []CheckResu
                 Status:    "PASS",
                 Evidence:  fmt.Sprintf("%d backup plans with %d vaults configured", len(plans.BackupPlansList), vaultCount),
                 Priority:  PriorityInfo,
-                Timestamp: time.Now(),
+                Timestamp: nowFunc(),
             })
         }
     }
@@ -510,7 +509,7 @@ func (c *CC5Checks) CheckCC5_2_TechnologyControls(ctx context.Context) []CheckRe
                 Evidence:    "No KMS keys configured - using default encryption only",
                 Remediation: "Create customer-managed KMS keys for sensitive data",
                 Priority:    PriorityHigh,
-                Timestamp:   time.Now(),
+                Timestamp:   nowFunc(),
             })
         } else {
             // Count customer managed keys
@@ -534,7 +533,7 @@ func (c *CC5Checks) CheckCC5_2_TechnologyControls(ctx context.Context) []CheckRe
                     Status:    "PASS",
                     Evidence:  fmt.Sprintf("%d customer-managed KMS keys configured", customerKeys),
                     Priority:  PriorityInfo,
-                    Timestamp: time.Now(),
+                    Timestamp: nowFunc(),
                 })
             } else {
                 results = append(results, CheckResult{
@@ -545,7 +544,7 @@ func (c *CC5Checks) CheckCC5_2_TechnologyControls(ctx context.Context) []CheckRe
                     Evidence:    "Only AWS-managed keys in use",
                     Remediation: "Create customer-managed keys for better control",
                     Priority:    PriorityMedium,
-                    Timestamp:   time.Now(),
+                    Timestamp:   nowFunc(),
                 })
             }
         }
@@ -564,7 +563,7 @@ func (c *CC5Checks) CheckCC5_3_Deployment(ctx context.Context) []CheckResult {
         Status:    "INFO",
         Evidence:  "Manual review required: Verify security policies are documented and enforced",
         Priority:  PriorityInfo,
-        Timestamp: time.Now(),
+        Timestamp: nowFunc(),
     })
     
     return results
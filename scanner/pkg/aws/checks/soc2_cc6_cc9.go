@@ -43,30 +43,30 @@ func (c *CC6Checks) Run(ctx context.Context) ([]CheckResult, error) {
     results := []CheckResult{}
     
     // CC6.1: Logical and Physical Access Controls
-    results = append(results, c.CheckCC6_1_AccessControls(ctx)...)
+    results = append(results, runChecks(ctx, "soc2.cc6_1_access_controls", c.CheckCC6_1_AccessControls)...)
     
     // CC6.2: Prior to Issuing System Credentials
-    results = append(results, c.CheckCC6_2_CredentialIssuance(ctx)...)
+    results = append(results, runChecks(ctx, "soc2.cc6_2_credential_issuance", c.CheckCC6_2_CredentialIssuance)...)
     
     // CC6.3: Manages Points of Access
-    results = append(results, c.CheckCC6_3_AccessPoints(ctx)...)
+    results = append(results, runChecks(ctx, "soc2.cc6_3_access_points", c.CheckCC6_3_AccessPoints)...)
     
     // CC6.4: Restricts Access to Information Assets
-    results = append(results, c.CheckCC6_4_AssetAccess(ctx)...)
+    results = append(results, runChecks(ctx, "soc2.cc6_4_asset_access", c.CheckCC6_4_AssetAccess)...)
     
     // CC6.5: Discontinues Logical and Physical Protections
-    results = append(results, c.CheckCC6_5_AccessRemoval(ctx)...)
+    results = append(results, runChecks(ctx, "soc2.cc6_5_access_removal", c.CheckCC6_5_AccessRemoval)...)
     
     // CC6.6: Prevents Unauthorized Access
-    results = append(results, c.CheckCC6_6_UnauthorizedPrevention(ctx)...)
+    results = append(results, runChecks(ctx, "soc2.cc6_6_unauthorized_prevention", c.CheckCC6_6_UnauthorizedPrevention)...)
     
     // CC6.7: Authenticates Users
-    results = append(results, c.CheckCC6_7_Authentication(ctx)...)
+    results = append(results, runChecks(ctx, "soc2.cc6_7_authentication", c.CheckCC6_7_Authentication)...)
     
     // CC6.8: Prevents Unauthorized Modification
-    results = append(results, c.CheckCC6_8_ModificationPrevention(ctx)...)
+    results = append(results, runChecks(ctx, "soc2.cc6_8_modification_prevention", c.CheckCC6_8_ModificationPrevention)...)
     
-    return results, nil
+    return withSOC2Mapping(results), nil
 }
 
 func (c *CC6Checks) CheckCC6_1_AccessControls(ctx context.Context) []CheckResult {
@@ -81,7 +81,7 @@ func (c *CC6Checks) CheckCC6_1_AccessControls(ctx context.Context) []CheckResult
             Status:    "ERROR",
             Evidence:  "Unable to check security groups",
             Priority:  PriorityHigh,
-            Timestamp: time.Now(),
+            Timestamp: nowFunc(),
         })
         return results
     }
@@ -116,9 +116,9 @@ func (c *CC6Checks) CheckCC6_1_AccessControls(ctx context.Context) []CheckResult
             Evidence:    fmt.Sprintf("CRITICAL: %d security groups with admin ports open to internet", adminPortsOpen),
             Remediation: "Restrict SSH/RDP/database ports to specific IPs only",
             ScreenshotGuide: "1. Go to EC2 → Security Groups\n2. Review inbound rules\n3. Remove 0.0.0.0/0 from ports 22, 3389, 3306",
-            ConsoleURL:  "https://console.aws.amazon.com/ec2/v2/home#SecurityGroups",
+            ConsoleURL:  consoleURL("ec2/v2/home#SecurityGroups", consoleRegion(ctx)),
             Priority:    PriorityCritical,
-            Timestamp:   time.Now(),
+            Timestamp:   nowFunc(),
         })
     } else if openToWorld > 5 {
         results = append(results, CheckResult{
@@ -129,7 +129,7 @@ func (c *CC6Checks) CheckCC6_1_AccessControls(ctx context.Context) []CheckResult
             Evidence:    fmt.Sprintf("%d security group rules open to 0.0.0.0/0", openToWorld),
             Remediation: "Review and restrict security group rules",
             Priority:    PriorityMedium,
-            Timestamp:   time.Now(),
+            Timestamp:   nowFunc(),
         })
     } else {
         results = append(results, CheckResult{
@@ -138,7 +138,7 @@ func (c *CC6Checks) CheckCC6_1_AccessControls(ctx context.Context) []CheckResult
             Status:    "PASS",
             Evidence:  "Security groups properly restrict network access",
             Priority:  PriorityInfo,
-            Timestamp: time.Now(),
+            Timestamp: nowFunc(),
         })
     }
     
@@ -157,7 +157,7 @@ func (c *CC6Checks) CheckCC6_2_CredentialIssuance(ctx context.Context) []CheckRe
             Status:    "ERROR",
             Evidence:  "Unable to check IAM users",
             Priority:  PriorityHigh,
-            Timestamp: time.Now(),
+            Timestamp: nowFunc(),
         })
         return results
     }
@@ -180,7 +180,7 @@ func (c *CC6Checks) CheckCC6_2_CredentialIssuance(ctx context.Context) []CheckRe
                 Status:    "INFO",
                 Evidence:  fmt.Sprintf("%d users created in last 30 days - verify approval process", recentUsers),
                 Priority:  PriorityInfo,
-                Timestamp: time.Now(),
+                Timestamp: nowFunc(),
             })
         }
         
@@ -202,7 +202,7 @@ func (c *CC6Checks) CheckCC6_2_CredentialIssuance(ctx context.Context) []CheckRe
                 Evidence:    fmt.Sprintf("%d service accounts detected - consider using IAM roles instead", serviceAccounts),
                 Remediation: "Replace service account users with IAM roles for applications",
                 Priority:    PriorityMedium,
-                Timestamp:   time.Now(),
+                Timestamp:   nowFunc(),
             })
         }
     }
@@ -228,7 +228,7 @@ func (c *CC6Checks) CheckCC6_3_AccessPoints(ctx context.Context) []CheckResult {
                 Remediation: "Create VPC endpoints for AWS services (S3, DynamoDB, etc.)",
                 ScreenshotGuide: "1. Go to VPC → Endpoints\n2. Create endpoints for S3, DynamoDB\n3. Route table associations",
                 Priority:    PriorityMedium,
-                Timestamp:   time.Now(),
+                Timestamp:   nowFunc(),
             })
         } else {
             results = append(results, CheckResult{
@@ -237,7 +237,7 @@ func (c *CC6Checks) CheckCC6_3_AccessPoints(ctx context.Context) []CheckResult {
                 Status:    "PASS",
                 Evidence:  fmt.Sprintf("%d VPC endpoints configured for secure service access", len(endpoints.VpcEndpoints)),
                 Priority:  PriorityInfo,
-                Timestamp: time.Now(),
+                Timestamp: nowFunc(),
             })
         }
     }
@@ -278,7 +278,7 @@ func (c *CC6Checks) CheckCC6_4_AssetAccess(ctx context.Context) []CheckResult {
                 Evidence:    fmt.Sprintf("[HIGH] %d S3 buckets with public access", publicBuckets),
                 Remediation: "Remove public access from S3 buckets unless absolutely necessary",
                 Priority:    PriorityHigh,
-                Timestamp:   time.Now(),
+                Timestamp:   nowFunc(),
             })
         } else {
             results = append(results, CheckResult{
@@ -287,7 +287,7 @@ func (c *CC6Checks) CheckCC6_4_AssetAccess(ctx context.Context) []CheckResult {
                 Status:    "PASS",
                 Evidence:  "All S3 buckets properly restrict public access",
                 Priority:  PriorityInfo,
-                Timestamp: time.Now(),
+                Timestamp: nowFunc(),
             })
         }
     }
@@ -307,7 +307,7 @@ func (c *CC6Checks) CheckCC6_5_AccessRemoval(ctx context.Context) []CheckResult
             Status:    "ERROR",
             Evidence:  "Unable to check IAM users for inactive accounts",
             Priority:  PriorityMedium,
-            Timestamp: time.Now(),
+            Timestamp: nowFunc(),
         })
         return results
     }
@@ -349,7 +349,7 @@ func (c *CC6Checks) CheckCC6_5_AccessRemoval(ctx context.Context) []CheckResult
             Evidence:    fmt.Sprintf("%d users with access keys older than 90 days", inactiveUsers),
             Remediation: "Review and rotate old access keys or remove inactive users",
             Priority:    PriorityMedium,
-            Timestamp:   time.Now(),
+            Timestamp:   nowFunc(),
         })
     } else {
         results = append(results, CheckResult{
@@ -358,7 +358,7 @@ func (c *CC6Checks) CheckCC6_5_AccessRemoval(ctx context.Context) []CheckResult
             Status:    "PASS",
             Evidence:  "No inactive users with old credentials detected",
             Priority:  PriorityInfo,
-            Timestamp: time.Now(),
+            Timestamp: nowFunc(),
         })
     }
     
@@ -379,7 +379,7 @@ func (c *CC6Checks) CheckCC6_6_UnauthorizedPrevention(ctx context.Context) []Che
             Evidence:    "No CloudTrail configured - cannot detect unauthorized access",
             Remediation: "Enable CloudTrail for all regions",
             Priority:    PriorityHigh,
-            Timestamp:   time.Now(),
+            Timestamp:   nowFunc(),
         })
     } else {
         multiRegionTrail := false
@@ -399,7 +399,7 @@ func (c *CC6Checks) CheckCC6_6_UnauthorizedPrevention(ctx context.Context) []Che
                 Evidence:    "CloudTrail not enabled for all regions",
                 Remediation: "Enable multi-region CloudTrail",
                 Priority:    PriorityMedium,
-                Timestamp:   time.Now(),
+                Timestamp:   nowFunc(),
             })
         } else {
             results = append(results, CheckResult{
@@ -408,7 +408,7 @@ func (c *CC6Checks) CheckCC6_6_UnauthorizedPrevention(ctx context.Context) []Che
                 Status:    "PASS",
                 Evidence:  "Multi-region CloudTrail enabled for unauthorized access detection",
                 Priority:  PriorityInfo,
-                Timestamp: time.Now(),
+                Timestamp: nowFunc(),
             })
         }
     }
@@ -430,7 +430,7 @@ func (c *CC6Checks) CheckCC6_7_Authentication(ctx context.Context) []CheckResult
             Evidence:    "No password policy configured",
             Remediation: "Configure strong IAM password policy",
             Priority:    PriorityHigh,
-            Timestamp:   time.Now(),
+            Timestamp:   nowFunc(),
         })
         return results
     }
@@ -467,7 +467,7 @@ func (c *CC6Checks) CheckCC6_7_Authentication(ctx context.Context) []CheckResult
             Evidence:    "Password policy does not meet minimum requirements",
             Remediation: fmt.Sprintf("Set minimum %d characters with complexity requirements", minLength),
             Priority:    PriorityMedium,
-            Timestamp:   time.Now(),
+            Timestamp:   nowFunc(),
         })
     } else {
         minLengthValue := int32(8)
@@ -480,7 +480,7 @@ func (c *CC6Checks) CheckCC6_7_Authentication(ctx context.Context) []CheckResult
             Status:    "PASS",
             Evidence:  fmt.Sprintf("Password policy requires %d+ characters with complexity", minLengthValue),
             Priority:  PriorityInfo,
-            Timestamp: time.Now(),
+            Timestamp: nowFunc(),
         })
     }
     
@@ -514,7 +514,7 @@ func (c *CC6Checks) CheckCC6_8_ModificationPrevention(ctx context.Context) []Che
                 Evidence:    fmt.Sprintf("Only %.0f%% of S3 buckets have versioning enabled", percentage),
                 Remediation: "Enable versioning on all S3 buckets",
                 Priority:    PriorityMedium,
-                Timestamp:   time.Now(),
+                Timestamp:   nowFunc(),
             })
         } else {
             results = append(results, CheckResult{
@@ -523,7 +523,7 @@ func (c *CC6Checks) CheckCC6_8_ModificationPrevention(ctx context.Context) []Che
                 Status:    "PASS",
                 Evidence:  fmt.Sprintf("%.0f%% of S3 buckets have versioning for modification tracking", percentage),
                 Priority:  PriorityInfo,
-                Timestamp: time.Now(),
+                Timestamp: nowFunc(),
             })
         }
     } else {
@@ -533,7 +533,7 @@ func (c *CC6Checks) CheckCC6_8_ModificationPrevention(ctx context.Context) []Che
             Status:    "INFO",
             Evidence:  "No S3 buckets to check",
             Priority:  PriorityInfo,
-            Timestamp: time.Now(),
+            Timestamp: nowFunc(),
         })
     }
     
@@ -565,18 +565,18 @@ func (c *CC7Checks) Run(ctx context.Context) ([]CheckResult, error) {
     results := []CheckResult{}
     
     // CC7.1: Monitors the System
-    results = append(results, c.CheckCC7_1_Monitoring(ctx)...)
+    results = append(results, runChecks(ctx, "soc2.cc7_1_monitoring", c.CheckCC7_1_Monitoring)...)
     
     // CC7.2: Monitors Anomalies
-    results = append(results, c.CheckCC7_2_AnomalyDetection(ctx)...)
+    results = append(results, runChecks(ctx, "soc2.cc7_2_anomaly_detection", c.CheckCC7_2_AnomalyDetection)...)
     
     // CC7.3: Evaluates Security Events
-    results = append(results, c.CheckCC7_3_SecurityEvents(ctx)...)
+    results = append(results, runChecks(ctx, "soc2.cc7_3_security_events", c.CheckCC7_3_SecurityEvents)...)
     
     // CC7.4: Responds to Anomalies and Security Events
-    results = append(results, c.CheckCC7_4_IncidentResponse(ctx)...)
+    results = append(results, runChecks(ctx, "soc2.cc7_4_incident_response", c.CheckCC7_4_IncidentResponse)...)
     
-    return results, nil
+    return withSOC2Mapping(results), nil
 }
 
 func (c *CC7Checks) CheckCC7_1_Monitoring(ctx context.Context) []CheckResult {
@@ -604,7 +604,7 @@ func (c *CC7Checks) CheckCC7_1_Monitoring(ctx context.Context) []CheckResult {
                 Evidence:    "CloudTrail exists but logging is disabled",
                 Remediation: "Enable CloudTrail logging immediately",
                 Priority:    PriorityCritical,
-                Timestamp:   time.Now(),
+                Timestamp:   nowFunc(),
             })
         } else {
             results = append(results, CheckResult{
@@ -613,7 +613,7 @@ func (c *CC7Checks) CheckCC7_1_Monitoring(ctx context.Context) []CheckResult {
                 Status:    "PASS",
                 Evidence:  fmt.Sprintf("%d CloudTrail(s) actively logging", enabledTrails),
                 Priority:  PriorityInfo,
-                Timestamp: time.Now(),
+                Timestamp: nowFunc(),
             })
         }
     } else {
@@ -625,7 +625,7 @@ func (c *CC7Checks) CheckCC7_1_Monitoring(ctx context.Context) []CheckResult {
             Evidence:    "No CloudTrail configured",
             Remediation: "Enable CloudTrail for comprehensive logging",
             Priority:    PriorityCritical,
-            Timestamp:   time.Now(),
+            Timestamp:   nowFunc(),
         })
     }
     
@@ -655,7 +655,7 @@ func (c *CC7Checks) CheckCC7_2_AnomalyDetection(ctx context.Context) []CheckResu
             Status:    "PASS",
             Evidence:  fmt.Sprintf("%d Lambda functions potentially for anomaly detection", anomalyFunctions),
             Priority:  PriorityInfo,
-            Timestamp: time.Now(),
+            Timestamp: nowFunc(),
         })
     } else {
         results = append(results, CheckResult{
@@ -665,7 +665,7 @@ func (c *CC7Checks) CheckCC7_2_AnomalyDetection(ctx context.Context) []CheckResu
             Evidence:    "No automated anomaly detection functions found",
             Remediation: "Consider implementing Lambda-based anomaly detection",
             Priority:    PriorityLow,
-            Timestamp:   time.Now(),
+            Timestamp:   nowFunc(),
         })
     }
     
@@ -686,7 +686,7 @@ func (c *CC7Checks) CheckCC7_3_SecurityEvents(ctx context.Context) []CheckResult
                 Status:    "PASS",
                 Evidence:  fmt.Sprintf("%d patch groups configured for security updates", len(patches.Mappings)),
                 Priority:  PriorityInfo,
-                Timestamp: time.Now(),
+                Timestamp: nowFunc(),
             })
         } else {
             results = append(results, CheckResult{
@@ -697,7 +697,7 @@ func (c *CC7Checks) CheckCC7_3_SecurityEvents(ctx context.Context) []CheckResult
                 Evidence:    "No patch groups configured in Systems Manager",
                 Remediation: "Configure SSM Patch Manager for automated security patching",
                 Priority:    PriorityMedium,
-                Timestamp:   time.Now(),
+                Timestamp:   nowFunc(),
             })
         }
     }
@@ -715,7 +715,7 @@ func (c *CC7Checks) CheckCC7_4_IncidentResponse(ctx context.Context) []CheckResu
         Status:    "INFO",
         Evidence:  "Manual review required: Verify incident response procedures are documented",
         Priority:  PriorityInfo,
-        Timestamp: time.Now(),
+        Timestamp: nowFunc(),
     })
     
     return results
@@ -744,9 +744,9 @@ func (c *CC8Checks) Run(ctx context.Context) ([]CheckResult, error) {
     results := []CheckResult{}
     
     // CC8.1: Authorizes, Designs, Develops, Configures, Documents, Tests, Approves, and Implements Changes
-    results = append(results, c.CheckCC8_1_ChangeManagement(ctx)...)
+    results = append(results, runChecks(ctx, "soc2.cc8_1_change_management", c.CheckCC8_1_ChangeManagement)...)
     
-    return results, nil
+    return withSOC2Mapping(results), nil
 }
 
 func (c *CC8Checks) CheckCC8_1_ChangeManagement(ctx context.Context) []CheckResult {
@@ -778,7 +778,7 @@ func (c *CC8Checks) CheckCC8_1_ChangeManagement(ctx context.Context) []CheckResu
                     Evidence:    fmt.Sprintf("Only %.0f%% of Lambda functions have version tracking", percentage),
                     Remediation: "Enable versioning for all Lambda functions",
                     Priority:    PriorityMedium,
-                    Timestamp:   time.Now(),
+                    Timestamp:   nowFunc(),
                 })
             } else {
                 results = append(results, CheckResult{
@@ -787,7 +787,7 @@ func (c *CC8Checks) CheckCC8_1_ChangeManagement(ctx context.Context) []CheckResu
                     Status:    "PASS",
                     Evidence:  fmt.Sprintf("%.0f%% of Lambda functions have proper version tracking", percentage),
                     Priority:  PriorityInfo,
-                    Timestamp: time.Now(),
+                    Timestamp: nowFunc(),
                 })
             }
         }
@@ -805,7 +805,7 @@ func (c *CC8Checks) CheckCC8_1_ChangeManagement(ctx context.Context) []CheckResu
             Status:    "PASS",
             Evidence:  fmt.Sprintf("%d AMIs maintained for change control", len(images.Images)),
             Priority:  PriorityInfo,
-            Timestamp: time.Now(),
+            Timestamp: nowFunc(),
         })
     } else {
         results = append(results, CheckResult{
@@ -815,7 +815,7 @@ func (c *CC8Checks) CheckCC8_1_ChangeManagement(ctx context.Context) []CheckResu
             Evidence:    "No custom AMIs found - consider creating golden images",
             Remediation: "Create and maintain AMIs for consistent deployments",
             Priority:    PriorityLow,
-            Timestamp:   time.Now(),
+            Timestamp:   nowFunc(),
         })
     }
     
@@ -845,12 +845,12 @@ func (c *CC9Checks) Run(ctx context.Context) ([]CheckResult, error) {
     results := []CheckResult{}
     
     // CC9.1: Identifies and Assesses Risk from Vendors and Business Partners
-    results = append(results, c.CheckCC9_1_VendorRisk(ctx)...)
+    results = append(results, runChecks(ctx, "soc2.cc9_1_vendor_risk", c.CheckCC9_1_VendorRisk)...)
     
     // CC9.2: Assesses and Manages Risk Associated with Vendors and Business Partners
-    results = append(results, c.CheckCC9_2_VendorManagement(ctx)...)
+    results = append(results, runChecks(ctx, "soc2.cc9_2_vendor_management", c.CheckCC9_2_VendorManagement)...)
     
-    return results, nil
+    return withSOC2Mapping(results), nil
 }
 
 func (c *CC9Checks) CheckCC9_1_VendorRisk(ctx context.Context) []CheckResult {
@@ -877,7 +877,7 @@ func (c *CC9Checks) CheckCC9_1_VendorRisk(ctx context.Context) []CheckResult {
                 Evidence:    fmt.Sprintf("Only %.0f%% of RDS instances are encrypted", percentage),
                 Remediation: "Enable encryption for all RDS databases",
                 Priority:    PriorityHigh,
-                Timestamp:   time.Now(),
+                Timestamp:   nowFunc(),
             })
         } else {
             results = append(results, CheckResult{
@@ -886,7 +886,7 @@ func (c *CC9Checks) CheckCC9_1_VendorRisk(ctx context.Context) []CheckResult {
                 Status:    "PASS",
                 Evidence:  "All RDS databases are encrypted at rest",
                 Priority:  PriorityInfo,
-                Timestamp: time.Now(),
+                Timestamp: nowFunc(),
             })
         }
     }
@@ -921,7 +921,7 @@ func (c *CC9Checks) CheckCC9_2_VendorManagement(ctx context.Context) []CheckResu
                 Evidence:    fmt.Sprintf("Only %.0f%% of S3 buckets have encryption enabled", percentage),
                 Remediation: "Enable default encryption for all S3 buckets",
                 Priority:    PriorityHigh,
-                Timestamp:   time.Now(),
+                Timestamp:   nowFunc(),
             })
         } else {
             results = append(results, CheckResult{
@@ -930,7 +930,7 @@ func (c *CC9Checks) CheckCC9_2_VendorManagement(ctx context.Context) []CheckResu
                 Status:    "PASS",
                 Evidence:  "All S3 buckets have encryption enabled",
                 Priority:  PriorityInfo,
-                Timestamp: time.Now(),
+                Timestamp: nowFunc(),
             })
         }
     }
@@ -3,7 +3,6 @@ package checks
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/ssm"
 	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
@@ -25,17 +24,17 @@ func (c *SSMChecks) Run(ctx context.Context) ([]CheckResult, error) {
 	results := []CheckResult{}
 
 	// CIS Section 10.1 - SSM Parameter Store Encryption
-	if result, err := c.CheckParameterEncryption(ctx); err == nil {
+	if result, err := runCheck(ctx, "ssm.parameter_encryption", c.CheckParameterEncryption); err == nil {
 		results = append(results, result)
 	}
 
 	// CIS Section 10.2 - SSM Session Manager Logging
-	if result, err := c.CheckSessionManagerLogging(ctx); err == nil {
+	if result, err := runCheck(ctx, "ssm.session_manager_logging", c.CheckSessionManagerLogging); err == nil {
 		results = append(results, result)
 	}
 
 	// CIS Section 10.3 - SSM Patch Compliance
-	if result, err := c.CheckPatchCompliance(ctx); err == nil {
+	if result, err := runCheck(ctx, "ssm.patch_compliance", c.CheckPatchCompliance); err == nil {
 		results = append(results, result)
 	}
 
@@ -55,7 +54,7 @@ func (c *SSMChecks) CheckParameterEncryption(ctx context.Context) (CheckResult,
 			Remediation:       "Ensure AWS Systems Manager Parameter Store access is enabled",
 			RemediationDetail: "Verify IAM permissions for SSM access",
 			Priority:          PriorityLow,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("SSM_PARAMETER_ENCRYPTION"),
 		}, err
 	}
@@ -72,9 +71,9 @@ func (c *SSMChecks) CheckParameterEncryption(ctx context.Context) (CheckResult,
 3. Create parameters with Type=SecureString
 4. Use default KMS key or custom KMS key for encryption`,
 			Priority:        PriorityLow,
-			Timestamp:       time.Now(),
+			Timestamp:       nowFunc(),
 			ScreenshotGuide: "Systems Manager → Parameter Store → Create parameter → Type: SecureString",
-			ConsoleURL:      "https://console.aws.amazon.com/systems-manager/parameters",
+			ConsoleURL:      consoleURL("systems-manager/parameters", consoleRegion(ctx)),
 			Frameworks:      GetFrameworkMappings("SSM_PARAMETER_ENCRYPTION"),
 		}, nil
 	}
@@ -95,7 +94,7 @@ func (c *SSMChecks) CheckParameterEncryption(ctx context.Context) (CheckResult,
 			Control:     "CIS-10.1",
 			Name:        "SSM Parameter Store Encryption",
 			Status:      "FAIL",
-			Evidence:    fmt.Sprintf("%d/%d parameters not encrypted (using String/StringList instead of SecureString): %v", len(unencryptedParams), len(params.Parameters), unencryptedParams),
+			Evidence:    fmt.Sprintf("%d/%d parameters not encrypted (using String/StringList instead of SecureString): %s", len(unencryptedParams), len(params.Parameters), TruncateList(unencryptedParams, evidenceListLimit(ctx))),
 			Remediation: "Migrate unencrypted parameters to SecureString type",
 			RemediationDetail: fmt.Sprintf(`1. Open Systems Manager console
 2. Navigate to Parameter Store
@@ -106,9 +105,9 @@ func (c *SSMChecks) CheckParameterEncryption(ctx context.Context) (CheckResult,
 4. Unencrypted parameters: %v`, unencryptedParams),
 			Severity:        "HIGH",
 			Priority:        PriorityHigh,
-			Timestamp:       time.Now(),
+			Timestamp:       nowFunc(),
 			ScreenshotGuide: "Systems Manager → Parameter Store → Screenshot showing unencrypted parameters",
-			ConsoleURL:      "https://console.aws.amazon.com/systems-manager/parameters",
+			ConsoleURL:      consoleURL("systems-manager/parameters", consoleRegion(ctx)),
 			Frameworks:      GetFrameworkMappings("SSM_PARAMETER_ENCRYPTION"),
 		}, nil
 	}
@@ -123,9 +122,9 @@ func (c *SSMChecks) CheckParameterEncryption(ctx context.Context) (CheckResult,
 Continue this practice for all new parameters.`, encryptedCount),
 		Severity:        "INFO",
 		Priority:        PriorityLow,
-		Timestamp:       time.Now(),
+		Timestamp:       nowFunc(),
 		ScreenshotGuide: "Systems Manager → Parameter Store → Screenshot showing all SecureString parameters",
-		ConsoleURL:      "https://console.aws.amazon.com/systems-manager/parameters",
+		ConsoleURL:      consoleURL("systems-manager/parameters", consoleRegion(ctx)),
 		Frameworks:      GetFrameworkMappings("SSM_PARAMETER_ENCRYPTION"),
 	}, nil
 }
@@ -153,9 +152,9 @@ func (c *SSMChecks) CheckSessionManagerLogging(ctx context.Context) (CheckResult
 5. Click Save`,
 			Severity:        "HIGH",
 			Priority:        PriorityHigh,
-			Timestamp:       time.Now(),
+			Timestamp:       nowFunc(),
 			ScreenshotGuide: "Systems Manager → Session Manager → Preferences → Screenshot showing CloudWatch logging enabled",
-			ConsoleURL:      "https://console.aws.amazon.com/systems-manager/session-manager/preferences",
+			ConsoleURL:      consoleURL("systems-manager/session-manager/preferences", consoleRegion(ctx)),
 			Frameworks:      GetFrameworkMappings("SSM_SESSION_LOGGING"),
 		}, nil
 	}
@@ -177,9 +176,9 @@ func (c *SSMChecks) CheckSessionManagerLogging(ctx context.Context) (CheckResult
 5. Screenshot showing both logging destinations configured`,
 		Severity:        "MEDIUM",
 		Priority:        PriorityMedium,
-		Timestamp:       time.Now(),
+		Timestamp:       nowFunc(),
 		ScreenshotGuide: "Systems Manager → Session Manager → Preferences → Screenshot showing logging configuration",
-		ConsoleURL:      "https://console.aws.amazon.com/systems-manager/session-manager/preferences",
+		ConsoleURL:      consoleURL("systems-manager/session-manager/preferences", consoleRegion(ctx)),
 		Frameworks:      GetFrameworkMappings("SSM_SESSION_LOGGING"),
 	}, nil
 }
@@ -200,7 +199,7 @@ Required permissions:
 - ssm:DescribeInstanceInformation
 - ssm:DescribeInstancePatchStates`,
 			Priority:   PriorityLow,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("SSM_PATCH_COMPLIANCE"),
 		}, err
 	}
@@ -218,9 +217,9 @@ Required permissions:
 4. Create Maintenance Windows for patching
 5. Monitor patch compliance in Systems Manager console`,
 			Priority:        PriorityLow,
-			Timestamp:       time.Now(),
+			Timestamp:       nowFunc(),
 			ScreenshotGuide: "Systems Manager → Fleet Manager → Screenshot showing no managed instances",
-			ConsoleURL:      "https://console.aws.amazon.com/systems-manager/managed-instances",
+			ConsoleURL:      consoleURL("systems-manager/managed-instances", consoleRegion(ctx)),
 			Frameworks:      GetFrameworkMappings("SSM_PATCH_COMPLIANCE"),
 		}, nil
 	}
@@ -238,7 +237,7 @@ Required permissions:
 			Evidence:    fmt.Sprintf("Failed to get patch compliance status: %v", err),
 			Remediation: "Verify patch compliance permissions",
 			Priority:   PriorityMedium,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: GetFrameworkMappings("SSM_PATCH_COMPLIANCE"),
 		}, nil
 	}
@@ -259,7 +258,7 @@ Required permissions:
 			Control:     "CIS-10.3",
 			Name:        "SSM Patch Compliance",
 			Status:      "FAIL",
-			Evidence:    fmt.Sprintf("%d/%d instances are not patch compliant: %v", len(nonCompliantInstances), len(patchStates.InstancePatchStates), nonCompliantInstances),
+			Evidence:    fmt.Sprintf("%d/%d instances are not patch compliant: %s", len(nonCompliantInstances), len(patchStates.InstancePatchStates), TruncateList(nonCompliantInstances, evidenceListLimit(ctx))),
 			Remediation: "Apply missing patches to non-compliant instances",
 			RemediationDetail: fmt.Sprintf(`1. Open Systems Manager console
 2. Navigate to Patch Manager
@@ -269,9 +268,9 @@ Required permissions:
 6. Verify compliance after patching`, nonCompliantInstances),
 			Severity:        "HIGH",
 			Priority:        PriorityCritical,
-			Timestamp:       time.Now(),
+			Timestamp:       nowFunc(),
 			ScreenshotGuide: "Systems Manager → Patch Manager → Compliance → Screenshot showing non-compliant instances",
-			ConsoleURL:      "https://console.aws.amazon.com/systems-manager/patch-manager/compliance",
+			ConsoleURL:      consoleURL("systems-manager/patch-manager/compliance", consoleRegion(ctx)),
 			Frameworks:      GetFrameworkMappings("SSM_PATCH_COMPLIANCE"),
 		}, nil
 	}
@@ -286,9 +285,9 @@ Required permissions:
 Continue monitoring patch compliance and apply patches regularly.`, compliantInstances),
 		Severity:        "INFO",
 		Priority:        PriorityLow,
-		Timestamp:       time.Now(),
+		Timestamp:       nowFunc(),
 		ScreenshotGuide: "Systems Manager → Patch Manager → Compliance → Screenshot showing all compliant",
-		ConsoleURL:      "https://console.aws.amazon.com/systems-manager/patch-manager/compliance",
+		ConsoleURL:      consoleURL("systems-manager/patch-manager/compliance", consoleRegion(ctx)),
 		Frameworks:      GetFrameworkMappings("SSM_PATCH_COMPLIANCE"),
 	}, nil
 }
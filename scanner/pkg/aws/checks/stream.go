@@ -0,0 +1,102 @@
+package checks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/guardian-nexus/auditkit/scanner/pkg/evidence"
+)
+
+// ModuleError is a check module failure sent on a RunStream error channel
+type ModuleError struct {
+	Service string
+	Err     error
+}
+
+func (e *ModuleError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Service, e.Err)
+}
+
+func (e *ModuleError) Unwrap() error {
+	return e.Err
+}
+
+// RunStream runs modules in order and sends each module's results as soon as
+// it returns, so large scans can be rendered incrementally. Results without a
+// Service are stamped with the module's name, EvidenceSteps is derived from
+// ScreenshotGuide when the check did not set it, and each result is checked
+// by the debug framework assertion. progress, if not nil, is called after
+// each module finishes.
+//
+// Module failures arrive on the error channel as *ModuleError, classified with
+// ClassifyError; a module that fails may still have sent partial results. If ctx is cancelled the stream
+// stops and ctx.Err() is sent. Both channels are closed when the run ends; the
+// error channel is buffered, so consumers only need to drain results.
+func RunStream(ctx context.Context, modules []Check, progress func(service string, done, total int)) (<-chan CheckResult, <-chan error) {
+	results := make(chan CheckResult, 16)
+	errs := make(chan error, len(modules)+1)
+
+	go func() {
+		defer close(errs)
+		defer close(results)
+
+		for i, module := range modules {
+			if err := ctx.Err(); err != nil {
+				errs <- err
+				return
+			}
+
+			start := time.Now()
+			moduleResults, err := module.Run(ctx)
+			scanFrom(ctx).recordServiceDuration(module.Name(), time.Since(start))
+			if err != nil {
+				errs <- &ModuleError{Service: module.Name(), Err: ClassifyError(err)}
+			}
+
+			for _, r := range moduleResults {
+				if r.Service == "" {
+					r.Service = module.Name()
+				}
+				if r.EvidenceSteps == nil {
+					r.EvidenceSteps = evidence.ParseGuide(r.ScreenshotGuide, r.ConsoleURL)
+				}
+				assertFrameworks(r)
+
+				select {
+				case results <- r:
+				case <-ctx.Done():
+					errs <- ctx.Err()
+					return
+				}
+			}
+
+			if progress != nil {
+				progress(module.Name(), i+1, len(modules))
+			}
+		}
+	}()
+
+	return results, errs
+}
+
+// RunModules runs modules and returns their results in SortResults order. It
+// is RunStream collected into a slice; module failures are joined into the
+// returned error alongside whatever results were produced.
+func RunModules(ctx context.Context, modules []Check, progress func(service string, done, total int)) ([]CheckResult, error) {
+	stream, errs := RunStream(ctx, modules, progress)
+
+	collected := []CheckResult{}
+	for r := range stream {
+		collected = append(collected, r)
+	}
+
+	var failures []error
+	for err := range errs {
+		failures = append(failures, err)
+	}
+
+	SortResults(collected)
+	return collected, errors.Join(failures...)
+}
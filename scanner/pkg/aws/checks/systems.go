@@ -3,7 +3,6 @@ package checks
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
 	"github.com/aws/aws-sdk-go-v2/service/ssm"
@@ -28,15 +27,15 @@ func (c *SystemsChecks) Name() string {
 func (c *SystemsChecks) Run(ctx context.Context) ([]CheckResult, error) {
 	results := []CheckResult{}
 
-	if result, err := c.CheckPatchCompliance(ctx); err == nil {
+	if result, err := runCheck(ctx, "systems.patch_compliance", c.CheckPatchCompliance); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckAutoScaling(ctx); err == nil {
+	if result, err := runCheck(ctx, "systems.auto_scaling", c.CheckAutoScaling); err == nil {
 		results = append(results, result)
 	}
 
-	return results, nil
+	return withSOC2Mapping(results), nil
 }
 
 func (c *SystemsChecks) CheckPatchCompliance(ctx context.Context) (CheckResult, error) {
@@ -53,19 +52,21 @@ func (c *SystemsChecks) CheckPatchCompliance(ctx context.Context) (CheckResult,
 			Remediation:       "Configure Systems Manager Patch Manager",
 			RemediationDetail: "Enable Systems Manager and create patch baselines for automated patching",
 			ScreenshotGuide:   "1. Go to Systems Manager → Patch Manager\n2. Screenshot patch baselines\n3. Show compliance dashboard\n4. Document patching schedule",
-			ConsoleURL:        "https://console.aws.amazon.com/systems-manager/patch-manager",
+			ConsoleURL:        consoleURL("systems-manager/patch-manager", consoleRegion(ctx)),
 			Priority:          PriorityHigh,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:   "A1.1",
-		Name:      "Patch Management",
-		Status:    "PASS",
-		Evidence:  fmt.Sprintf("%d patch baselines configured", len(patches.BaselineIdentities)),
-		Priority:  PriorityInfo,
-		Timestamp: time.Now(),
+		Control:         "A1.1",
+		Name:            "Patch Management",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("%d patch baselines configured", len(patches.BaselineIdentities)),
+		ScreenshotGuide: "1. Go to Systems Manager → Patch Manager\n2. Screenshot patch baselines\n3. Show compliance dashboard\n4. Document patching schedule",
+		ConsoleURL:      consoleURL("systems-manager/patch-manager", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
 	}, nil
 }
 
@@ -81,9 +82,9 @@ func (c *SystemsChecks) CheckAutoScaling(ctx context.Context) (CheckResult, erro
 			Evidence:        "No auto-scaling configured - single points of failure exist",
 			Remediation:     "Implement auto-scaling for critical services",
 			ScreenshotGuide: "1. Go to EC2 → Auto Scaling Groups\n2. Document HA architecture\n3. Show multi-AZ deployments",
-			ConsoleURL:      "https://console.aws.amazon.com/ec2/v2/home#AutoScalingGroups",
+			ConsoleURL:      consoleURL("ec2/v2/home#AutoScalingGroups", consoleRegion(ctx)),
 			Priority:        PriorityMedium,
-			Timestamp:       time.Now(),
+			Timestamp:       nowFunc(),
 		}, nil
 	}
 
@@ -93,6 +94,6 @@ func (c *SystemsChecks) CheckAutoScaling(ctx context.Context) (CheckResult, erro
 		Status:    "PASS",
 		Evidence:  fmt.Sprintf("%d auto-scaling groups configured", len(groups.AutoScalingGroups)),
 		Priority:  PriorityInfo,
-		Timestamp: time.Now(),
+		Timestamp: nowFunc(),
 	}, nil
 }
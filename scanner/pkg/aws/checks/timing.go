@@ -0,0 +1,67 @@
+package checks
+
+import (
+	"time"
+
+	"github.com/guardian-nexus/auditkit/scanner/pkg/core"
+)
+
+// ResetTimings clears the durations recorded by runs without a Scan, ready
+// for a new scan
+func ResetTimings() {
+	defaultScan.timingMu.Lock()
+	defer defaultScan.timingMu.Unlock()
+
+	defaultScan.checkDurations = map[string]time.Duration{}
+	defaultScan.serviceDurations = map[string]time.Duration{}
+}
+
+// CheckTimings returns how long each check run without a Scan ran, by check
+// ID, since the last ResetTimings
+func CheckTimings() []core.Timing {
+	return defaultScan.CheckTimings()
+}
+
+// ServiceTimings returns how long each check module run without a Scan ran,
+// by module name, since the last ResetTimings
+func ServiceTimings() []core.Timing {
+	return defaultScan.ServiceTimings()
+}
+
+// CheckTimings returns how long each check ran in the scan, by check ID. Only
+// checks run through runCheck are timed, and a check that runs more than once
+// (several regions, or -framework all) accumulates its total time.
+func (s *Scan) CheckTimings() []core.Timing {
+	s.timingMu.Lock()
+	defer s.timingMu.Unlock()
+	return toTimings(s.checkDurations)
+}
+
+// ServiceTimings returns how long each check module ran in the scan, by
+// module name
+func (s *Scan) ServiceTimings() []core.Timing {
+	s.timingMu.Lock()
+	defer s.timingMu.Unlock()
+	return toTimings(s.serviceDurations)
+}
+
+func (s *Scan) recordCheckDuration(id string, d time.Duration) {
+	s.timingMu.Lock()
+	defer s.timingMu.Unlock()
+	s.checkDurations[id] += d
+}
+
+func (s *Scan) recordServiceDuration(service string, d time.Duration) {
+	s.timingMu.Lock()
+	defer s.timingMu.Unlock()
+	s.serviceDurations[service] += d
+}
+
+func toTimings(durations map[string]time.Duration) []core.Timing {
+	timings := make([]core.Timing, 0, len(durations))
+	for name, d := range durations {
+		timings = append(timings, core.Timing{Name: name, Seconds: d.Seconds()})
+	}
+	core.SortTimings(timings)
+	return timings
+}
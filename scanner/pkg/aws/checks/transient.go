@@ -0,0 +1,62 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// transientStatuses are lifecycle states in which a resource's configuration
+// is not yet, or no longer, reported reliably: encryption and endpoints may be
+// missing while it is created, and API calls against it may fail while it is
+// deleted
+var transientStatuses = map[string]bool{
+	"creating":       true,
+	"deleting":       true,
+	"deleted":        true,
+	"final-snapshot": true, // Redshift: snapshot taken before deletion
+}
+
+// isTransientStatus reports whether a resource status is a creation or
+// deletion state whose findings would flap between scans
+func isTransientStatus(status string) bool {
+	return transientStatuses[strings.ToLower(status)]
+}
+
+// transientResources collects resources a module skipped because they were
+// in a transient state, so they can be reported once as INFO. The zero value
+// is ready to use.
+type transientResources struct {
+	seen      map[string]bool
+	resources []string
+}
+
+// add records a skipped resource; repeat calls for the same ID are ignored so
+// checks that each list the resource record it once
+func (t *transientResources) add(id, status string) {
+	if t.seen == nil {
+		t.seen = map[string]bool{}
+	}
+	if t.seen[id] {
+		return
+	}
+	t.seen[id] = true
+	t.resources = append(t.resources, fmt.Sprintf("%s (%s)", id, strings.ToLower(status)))
+}
+
+// result is the INFO result listing the skipped resources, described by kind
+// ("Redshift clusters"). ok is false when nothing was skipped.
+func (t *transientResources) result(ctx context.Context, service, kind, control string) (result CheckResult, ok bool) {
+	if len(t.resources) == 0 {
+		return CheckResult{}, false
+	}
+
+	return CheckResult{
+		Control:   control,
+		Name:      fmt.Sprintf("%s Resources in Transition", service),
+		Status:    "INFO",
+		Evidence:  fmt.Sprintf("%d %s skipped while being created or deleted: %s. Re-run the scan once they are available.", len(t.resources), kind, TruncateList(t.resources, evidenceListLimit(ctx))),
+		Priority:  PriorityInfo,
+		Timestamp: nowFunc(),
+	}, true
+}
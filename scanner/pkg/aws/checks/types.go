@@ -4,6 +4,10 @@ import (
 	"context"
 	"time"
 	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/guardian-nexus/auditkit/scanner/pkg/evidence"
 )
 
 // Framework constants
@@ -15,15 +19,23 @@ const (
 )
 
 type CheckResult struct {
+	Service           string            `json:"service,omitempty"` // Check module name, set by the collector
+	Region            string            `json:"region,omitempty"`  // Region scanned, set by the Runner
+	AccountID         string            `json:"account_id,omitempty"` // Account scanned, set by the Runner
 	Control           string            `json:"control"`
 	Name              string            `json:"name"`
 	Status            string            `json:"status"` // PASS, FAIL, NOT_APPLICABLE
 	Evidence          string            `json:"evidence"`
+	AffectedResources []string          `json:"affected_resources,omitempty"` // Every failing resource; Evidence lists at most the evidence list limit
 	Remediation       string            `json:"remediation,omitempty"`
 	RemediationDetail string            `json:"remediation_detail,omitempty"`
+	RequiresRecreate  bool              `json:"requires_recreate,omitempty"` // Fix means rebuilding the resource, not a config toggle
 	Severity          string            `json:"severity,omitempty"`
+	OriginalSeverity  string            `json:"original_severity,omitempty"` // Check's own severity when overridden
+	Environment       string            `json:"environment,omitempty"` // prod, staging or dev, from the failing resources' environment tag
 	Priority          Priority          `json:"priority"`
 	ScreenshotGuide   string            `json:"screenshot_guide,omitempty"`
+	EvidenceSteps     *evidence.Checklist `json:"evidence_steps,omitempty"` // Structured form of ScreenshotGuide
 	ConsoleURL        string            `json:"console_url,omitempty"`
 	Timestamp         time.Time         `json:"timestamp"`
 	Frameworks        map[string]string `json:"frameworks,omitempty"`
@@ -127,6 +139,12 @@ var FrameworkMappings = map[string]map[string]string{
 		FrameworkHIPAA: "164.308(a)(4)(ii)(C)",
 		FrameworkCIS:   "1.12",
 	},
+	"UNUSED_CREDENTIALS_45": {
+		FrameworkSOC2:  "CC6.7",
+		FrameworkPCI:   "8.1.4",
+		FrameworkHIPAA: "164.308(a)(4)(ii)(C)",
+		FrameworkCIS:   "1.3",
+	},
 	"IAM_USER_MFA": {
 		FrameworkSOC2:  "CC6.6",
 		FrameworkPCI:   "8.3.1",
@@ -169,6 +187,12 @@ var FrameworkMappings = map[string]map[string]string{
 		FrameworkHIPAA: "164.308(a)(3)(i)",
 		FrameworkCIS:   "1.15",
 	},
+	"IAM_POLICIES_GROUPS_ONLY": {
+		FrameworkSOC2:  "CC6.6",
+		FrameworkPCI:   "7.1",
+		FrameworkHIPAA: "164.308(a)(3)(i)",
+		FrameworkCIS:   "1.16",
+	},
 	"IAM_USER_UNUSED": {
 		FrameworkSOC2:  "CC6.7",
 		FrameworkPCI:   "8.1.4",
@@ -264,6 +288,11 @@ var FrameworkMappings = map[string]map[string]string{
 		FrameworkHIPAA: "164.312(a)(2)(iv)",
 		FrameworkCIS:   "3.3",
 	},
+	"CLOUDWATCH_LOG_RETENTION": {
+		FrameworkSOC2:  "CC7.1",
+		FrameworkPCI:   "10.5.1",
+		FrameworkHIPAA: "164.312(b)",
+	},
 	"CONFIG_ENABLED": {
 		FrameworkSOC2:  "CC7.1",
 		FrameworkPCI:   "10.1",
@@ -318,6 +347,18 @@ var FrameworkMappings = map[string]map[string]string{
 		FrameworkHIPAA: "164.312(e)(1)",
 		FrameworkCIS:   "5.5",
 	},
+	"VPC_PEERING_ROUTING": {
+		FrameworkSOC2:  "CC6.1",
+		FrameworkPCI:   "1.2.1",
+		FrameworkHIPAA: "164.312(e)(1)",
+		FrameworkCIS:   "5.8",
+	},
+	"VPC_S3_ENDPOINTS": {
+		FrameworkSOC2:  "CC6.1",
+		FrameworkPCI:   "1.3.1",
+		FrameworkHIPAA: "164.312(e)(1)",
+		FrameworkCIS:   "5.20",
+	},
 	"SECURITY_GROUP_UNRESTRICTED": {
 		FrameworkSOC2:  "CC6.1",
 		FrameworkPCI:   "1.2.1",
@@ -686,6 +727,15 @@ var FrameworkMappings = map[string]map[string]string{
 		FrameworkHIPAA: "164.312(a)(1)",
 		FrameworkCIS:   "19.3",
 	},
+	"SAGEMAKER_MONITORING": {
+		FrameworkSOC2:  "CC7.2",
+		FrameworkPCI:   "10.2",
+		FrameworkHIPAA: "164.312(b)",
+		FrameworkCIS:   "19.7",
+	},
+	"SAGEMAKER_AVAILABILITY": {
+		FrameworkSOC2: "A1.2",
+	},
 	// Redshift Security
 	"REDSHIFT_ENCRYPTION": {
 		FrameworkSOC2:  "CC6.3",
@@ -723,6 +773,33 @@ var FrameworkMappings = map[string]map[string]string{
 		FrameworkHIPAA: "164.308(a)(7)(ii)(A)",
 		FrameworkCIS:   "20.6",
 	},
+	"REDSHIFT_CONFIGURATION": {
+		FrameworkSOC2:  "CC7.1",
+		FrameworkPCI:   "2.2",
+		FrameworkHIPAA: "164.308(a)(1)(ii)(B)",
+		FrameworkCIS:   "20.7",
+	},
+	"REDSHIFT_MAINTENANCE": {
+		FrameworkSOC2:  "A1.1",
+		FrameworkPCI:   "6.3.3",
+		FrameworkHIPAA: "164.308(a)(7)(ii)(C)",
+		FrameworkCIS:   "20.10",
+	},
+	"REDSHIFT_EXPIRY": {
+		FrameworkSOC2: "A1.1",
+	},
+	"REDSHIFT_ACCESS": {
+		FrameworkSOC2:  "CC6.6",
+		FrameworkPCI:   "2.1",
+		FrameworkHIPAA: "164.312(a)(1)",
+		FrameworkCIS:   "20.8",
+	},
+	"REDSHIFT_IAM": {
+		FrameworkSOC2:  "CC6.3",
+		FrameworkPCI:   "7.1.2",
+		FrameworkHIPAA: "164.308(a)(4)",
+		FrameworkCIS:   "20.9",
+	},
 	// ElastiCache Security
 	"ELASTICACHE_ENCRYPTION": {
 		FrameworkSOC2:  "CC6.3",
@@ -742,6 +819,11 @@ var FrameworkMappings = map[string]map[string]string{
 		FrameworkHIPAA: "164.312(a)(2)(i)",
 		FrameworkCIS:   "21.3",
 	},
+	"ELASTICACHE_OPEN_REDIS": {
+		FrameworkSOC2:  "CC6.6",
+		FrameworkPCI:   "8.3.1",
+		FrameworkHIPAA: "164.312(a)(2)(i)",
+	},
 	"ELASTICACHE_PATCHING": {
 		FrameworkSOC2:  "CC7.5",
 		FrameworkPCI:   "6.2",
@@ -754,6 +836,21 @@ var FrameworkMappings = map[string]map[string]string{
 		FrameworkHIPAA: "164.308(a)(7)(ii)(A)",
 		FrameworkCIS:   "21.5",
 	},
+	"ELASTICACHE_NETWORK": {
+		FrameworkSOC2:  "CC6.1",
+		FrameworkPCI:   "1.2.1",
+		FrameworkHIPAA: "164.312(e)(1)",
+		FrameworkCIS:   "21.6",
+	},
+	"ELASTICACHE_RBAC": {
+		FrameworkSOC2:  "CC6.6",
+		FrameworkPCI:   "7.2.1",
+		FrameworkHIPAA: "164.312(a)(1)",
+		FrameworkCIS:   "21.7",
+	},
+	"ELASTICACHE_EXPIRY": {
+		FrameworkSOC2: "A1.1",
+	},
 	// OpenSearch Security
 	"OPENSEARCH_ENCRYPTION": {
 		FrameworkSOC2:  "CC6.3",
@@ -791,6 +888,24 @@ var FrameworkMappings = map[string]map[string]string{
 		FrameworkHIPAA: "164.312(a)(1)",
 		FrameworkCIS:   "22.6",
 	},
+	"OPENSEARCH_POLICY": {
+		FrameworkSOC2:  "CC6.1",
+		FrameworkPCI:   "1.3.1",
+		FrameworkHIPAA: "164.312(a)(1)",
+		FrameworkCIS:   "22.7",
+	},
+	"OPENSEARCH_PATCHING": {
+		FrameworkSOC2:  "CC7.5",
+		FrameworkPCI:   "6.3.3",
+		FrameworkHIPAA: "164.308(a)(5)(ii)(B)",
+		FrameworkCIS:   "22.8",
+	},
+	"OPENSEARCH_BACKUP": {
+		FrameworkSOC2:  "A1.2",
+		FrameworkPCI:   "9.5",
+		FrameworkHIPAA: "164.308(a)(7)(ii)(A)",
+		FrameworkCIS:   "22.9",
+	},
 }
 
 // Helper function to get framework mappings for a control
@@ -798,9 +913,48 @@ func GetFrameworkMappings(controlType string) map[string]string {
 	if mappings, exists := FrameworkMappings[controlType]; exists {
 		return mappings
 	}
+	if debugAssertions.Load() {
+		panic(fmt.Sprintf("unknown framework mapping key %q", controlType))
+	}
 	return make(map[string]string)
 }
 
+// withSOC2Mapping maps results with no Frameworks to their own Control, under
+// the framework that control belongs to. Modules whose controls are SOC2
+// criteria (the CC1-CC9 checks) use it so their findings carry a mapping like
+// every other check; a CIS control such as "[CIS-3.5]" among them is mapped
+// to CIS, never to SOC2.
+func withSOC2Mapping(results []CheckResult) []CheckResult {
+	for i := range results {
+		if len(results[i].Frameworks) > 0 {
+			continue
+		}
+		if framework, control, ok := controlFramework(results[i].Control); ok {
+			results[i].Frameworks = map[string]string{framework: control}
+		}
+	}
+	return results
+}
+
+// controlFramework returns the framework a control ID belongs to and the ID
+// within it: "[CIS-3.5]" is CIS-AWS 3.5 and "CC6.1" or "A1.2" is SOC2 CC6.1
+// or A1.2. Other IDs are not recognised.
+func controlFramework(control string) (framework, id string, ok bool) {
+	control = strings.Trim(strings.TrimSpace(control), "[]")
+	if cis, found := strings.CutPrefix(control, "CIS-"); found {
+		return FrameworkCIS, cis, cis != ""
+	}
+	if soc2CriterionPattern.MatchString(control) {
+		return FrameworkSOC2, control, true
+	}
+	return "", "", false
+}
+
+// soc2CriterionPattern matches SOC2 Trust Services Criteria IDs: the common
+// criteria (CC1.1) and the availability, confidentiality, processing
+// integrity and privacy criteria (A1.2, C1.1, PI1.3, P4.1)
+var soc2CriterionPattern = regexp.MustCompile(`^(CC|A|C|PI|P)[0-9]+\.[0-9]+$`)
+
 // Helper to format framework requirements in evidence
 func FormatFrameworkRequirements(frameworks map[string]string) string {
 	if len(frameworks) == 0 {
@@ -0,0 +1,40 @@
+package checks
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWithSOC2MappingKeysOnControlFramework(t *testing.T) {
+	results := withSOC2Mapping([]CheckResult{
+		{Control: "CC6.1"},
+		{Control: "A1.2"},
+		{Control: "[CIS-3.5]"},
+		{Control: "CIS-4.16"},
+		{Control: "CC7.1", Frameworks: map[string]string{FrameworkPCI: "10.2"}},
+		{Control: "CUSTOM-1"},
+	})
+
+	want := []map[string]string{
+		{FrameworkSOC2: "CC6.1"},
+		{FrameworkSOC2: "A1.2"},
+		{FrameworkCIS: "3.5"},
+		{FrameworkCIS: "4.16"},
+		{FrameworkPCI: "10.2"},
+		nil,
+	}
+	for i, result := range results {
+		if !reflect.DeepEqual(result.Frameworks, want[i]) {
+			t.Errorf("%s: frameworks %v, want %v", result.Control, result.Frameworks, want[i])
+		}
+	}
+}
+
+func TestWithSOC2MappingNeverMapsCISToSOC2(t *testing.T) {
+	for _, control := range []string{"[CIS-3.5]", "CIS-1.8", "[CIS-4.16]"} {
+		results := withSOC2Mapping([]CheckResult{{Control: control}})
+		if _, ok := results[0].Frameworks[FrameworkSOC2]; ok {
+			t.Errorf("%s: got a SOC2 mapping %v", control, results[0].Frameworks)
+		}
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
-	"time"
 )
 
 type VPCChecks struct {
@@ -25,21 +24,21 @@ func (c *VPCChecks) Run(ctx context.Context) ([]CheckResult, error) {
 	results := []CheckResult{}
 
 	// Existing check
-	if result, err := c.CheckVPCFlowLogs(ctx); err == nil {
+	if result, err := runCheck(ctx, "vpc.flow_logs", c.CheckVPCFlowLogs); err == nil {
 		results = append(results, result)
 	}
 
 	// NEW CIS checks
-	if result, err := c.CheckDefaultVPC(ctx); err == nil {
+	if result, err := runCheck(ctx, "vpc.default_vpc", c.CheckDefaultVPC); err == nil {
 		results = append(results, result)
 	}
 
-	if result, err := c.CheckVPCPeering(ctx); err == nil {
+	if result, err := runCheck(ctx, "vpc.peering", c.CheckVPCPeering); err == nil {
 		results = append(results, result)
 	}
 
 	// CIS 5.7-5.8: VPC Endpoints
-	if result, err := c.CheckVPCEndpoints(ctx); err == nil {
+	if result, err := runCheck(ctx, "vpc.endpoints", c.CheckVPCEndpoints); err == nil {
 		results = append(results, result)
 	}
 
@@ -47,17 +46,17 @@ func (c *VPCChecks) Run(ctx context.Context) ([]CheckResult, error) {
 	results = append(results, c.CheckNACLRestrictions(ctx)...)
 
 	// CIS 5.13: Admin port security
-	if result, err := c.CheckAdminPortSecurity(ctx); err == nil {
+	if result, err := runCheck(ctx, "vpc.admin_port_security", c.CheckAdminPortSecurity); err == nil {
 		results = append(results, result)
 	}
 
 	// CIS 5.14: EC2 subnet placement
-	if result, err := c.CheckEC2SubnetPlacement(ctx); err == nil {
+	if result, err := runCheck(ctx, "vpc.ec2_subnet_placement", c.CheckEC2SubnetPlacement); err == nil {
 		results = append(results, result)
 	}
 
 	// CIS 5.18: Unused security groups
-	if result, err := c.CheckUnusedSecurityGroups(ctx); err == nil {
+	if result, err := runCheck(ctx, "vpc.unused_security_groups", c.CheckUnusedSecurityGroups); err == nil {
 		results = append(results, result)
 	}
 
@@ -103,25 +102,28 @@ func (c *VPCChecks) CheckVPCFlowLogs(ctx context.Context) (CheckResult, error) {
 			Name:              "VPC Flow Logs",
 			Status:            "FAIL",
 			Severity:          "HIGH",
-			Evidence:          fmt.Sprintf("%d VPCs don't have Flow Logs enabled: %v | Network traffic not audited | Violates CIS-3.9", len(vpcsWithoutFlowLogs), vpcsWithoutFlowLogs),
+			Evidence:          fmt.Sprintf("%d VPCs don't have Flow Logs enabled: %s | Network traffic not audited | Violates CIS-3.9", len(vpcsWithoutFlowLogs), TruncateList(vpcsWithoutFlowLogs, evidenceListLimit(ctx))),
+			AffectedResources: vpcsWithoutFlowLogs,
 			Remediation:       "Enable VPC Flow Logs immediately",
 			RemediationDetail: fmt.Sprintf("aws ec2 create-flow-logs --resource-type VPC --resource-ids %s --traffic-type ALL --log-destination-type cloud-watch-logs --log-group-name /aws/vpc/flowlogs", vpcsWithoutFlowLogs[0]),
 			ScreenshotGuide:   "VPC Console → Select VPC → Flow logs tab → Screenshot showing 'Active' flow logs",
-			ConsoleURL:        "https://console.aws.amazon.com/vpc/",
+			ConsoleURL:        consoleURL("vpc/", consoleRegion(ctx)),
 			Priority:          PriorityHigh,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("VPC_FLOW_LOGS"),
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "CIS-3.9, CC7.1",
-		Name:       "VPC Flow Logs",
-		Status:     "PASS",
-		Evidence:   fmt.Sprintf("All %d VPCs have Flow Logs enabled | Meets CIS-3.9", len(vpcs.Vpcs)),
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: GetFrameworkMappings("VPC_FLOW_LOGS"),
+		Control:         "CIS-3.9, CC7.1",
+		Name:            "VPC Flow Logs",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d VPCs have Flow Logs enabled | Meets CIS-3.9", len(vpcs.Vpcs)),
+		ScreenshotGuide: "VPC Console → Select VPC → Flow logs tab → Screenshot showing 'Active' flow logs",
+		ConsoleURL:      consoleURL("vpc/", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      GetFrameworkMappings("VPC_FLOW_LOGS"),
 	}, nil
 }
 
@@ -173,13 +175,15 @@ func (c *VPCChecks) CheckDefaultVPC(ctx context.Context) (CheckResult, error) {
 			Name:              "Default VPC in Use",
 			Status:            "FAIL",
 			Severity:          "MEDIUM",
-			Evidence:          fmt.Sprintf("%d default VPC(s) have resources: %v | Default VPCs lack security controls", len(defaultVPCsInUse), defaultVPCsInUse),
+			Evidence:          fmt.Sprintf("%d default VPC(s) have resources: %s | Default VPCs lack security controls", len(defaultVPCsInUse), TruncateList(defaultVPCsInUse, evidenceListLimit(ctx))),
+			AffectedResources: defaultVPCsInUse,
 			Remediation:       "Move resources to custom VPCs with proper security controls",
 			RemediationDetail: "1. Create custom VPC with proper CIDR and subnets\n2. Migrate instances to custom VPC\n3. Delete default VPC after migration",
+			RequiresRecreate:  true,
 			ScreenshotGuide:   "VPC Console → Show only custom VPCs in use (no default VPC resources)",
-			ConsoleURL:        "https://console.aws.amazon.com/vpc/",
+			ConsoleURL:        consoleURL("vpc/", consoleRegion(ctx)),
 			Priority:          PriorityMedium,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        GetFrameworkMappings("DEFAULT_VPC"),
 		}, nil
 	}
@@ -189,8 +193,9 @@ func (c *VPCChecks) CheckDefaultVPC(ctx context.Context) (CheckResult, error) {
 		Name:       "Default VPC in Use",
 		Status:     "PASS",
 		Evidence:   "No resources using default VPC",
+		ConsoleURL: consoleURL("vpc/", consoleRegion(ctx)),
 		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
+		Timestamp:  nowFunc(),
 		Frameworks: GetFrameworkMappings("DEFAULT_VPC"),
 	}, nil
 }
@@ -205,9 +210,9 @@ func (c *VPCChecks) CheckVPCPeering(ctx context.Context) (CheckResult, error) {
 		Remediation:       "Ensure VPC peering route tables use specific CIDR blocks, not 0.0.0.0/0",
 		RemediationDetail: "1. Review all VPC peering connections\n2. Check route tables for peering connections\n3. Ensure routes use specific CIDR blocks\n4. Remove any overly permissive routes (0.0.0.0/0)",
 		ScreenshotGuide:   "VPC Console → Peering Connections → Route Tables → Screenshot showing specific CIDR routes (no 0.0.0.0/0)",
-		ConsoleURL:        "https://console.aws.amazon.com/vpc/home#PeeringConnections:",
+		ConsoleURL:        consoleURL("vpc/home#PeeringConnections:", consoleRegion(ctx)),
 		Priority:          PriorityMedium,
-		Timestamp:         time.Now(),
+		Timestamp:         nowFunc(),
 		Frameworks:        GetFrameworkMappings("VPC_PEERING"),
 	}, nil
 }
@@ -278,21 +283,23 @@ aws ec2 create-vpc-endpoint --vpc-id VPC_ID --service-name com.amazonaws.REGION.
 # Create DynamoDB endpoint
 aws ec2 create-vpc-endpoint --vpc-id VPC_ID --service-name com.amazonaws.REGION.dynamodb --route-table-ids RTB_ID`,
 			ScreenshotGuide:   "VPC Console → Endpoints → Screenshot showing S3 and DynamoDB endpoints for each VPC",
-			ConsoleURL:        "https://console.aws.amazon.com/vpc/home#Endpoints:",
+			ConsoleURL:        consoleURL("vpc/home#Endpoints:", consoleRegion(ctx)),
 			Priority:          PriorityMedium,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        map[string]string{"CIS-AWS": "5.7, 5.8"},
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "[CIS-5.7, 5.8]",
-		Name:       "VPC Endpoints for AWS Services",
-		Status:     "PASS",
-		Evidence:   fmt.Sprintf("All %d VPCs have appropriate endpoints | CIS 5.7-5.8", len(vpcs.Vpcs)-countDefaultVPCs(vpcs.Vpcs)),
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: map[string]string{"CIS-AWS": "5.7, 5.8"},
+		Control:         "[CIS-5.7, 5.8]",
+		Name:            "VPC Endpoints for AWS Services",
+		Status:          "PASS",
+		Evidence:        fmt.Sprintf("All %d VPCs have appropriate endpoints | CIS 5.7-5.8", len(vpcs.Vpcs)-countDefaultVPCs(vpcs.Vpcs)),
+		ScreenshotGuide: "VPC Console → Endpoints → Screenshot showing S3 and DynamoDB endpoints for each VPC",
+		ConsoleURL:      consoleURL("vpc/home#Endpoints:", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      map[string]string{"CIS-AWS": "5.7, 5.8"},
 	}, nil
 }
 
@@ -367,13 +374,14 @@ func (c *VPCChecks) CheckNACLRestrictions(ctx context.Context) []CheckResult {
 			Name:              "NACL Restricts SSH from Internet",
 			Status:            "FAIL",
 			Severity:          "CRITICAL",
-			Evidence:          fmt.Sprintf("%d NACLs allow SSH (port 22) from 0.0.0.0/0: %v | CIS 5.9", len(naclsAllowingSSH), truncateList(naclsAllowingSSH, 3)),
+			Evidence:          fmt.Sprintf("%d NACLs allow SSH (port 22) from 0.0.0.0/0: %s | CIS 5.9", len(naclsAllowingSSH), TruncateList(naclsAllowingSSH, evidenceListLimit(ctx))),
+			AffectedResources: naclsAllowingSSH,
 			Remediation:       "Remove NACL rules allowing SSH from 0.0.0.0/0",
 			RemediationDetail: `aws ec2 delete-network-acl-entry --network-acl-id NACL_ID --ingress --rule-number RULE_NUM`,
 			ScreenshotGuide:   "VPC Console → Network ACLs → Inbound Rules → Screenshot showing no rules for port 22 from 0.0.0.0/0",
-			ConsoleURL:        "https://console.aws.amazon.com/vpc/home#acls:",
+			ConsoleURL:        consoleURL("vpc/home#acls:", consoleRegion(ctx)),
 			Priority:          PriorityCritical,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        map[string]string{"CIS-AWS": "5.9", "PCI-DSS": "1.2.1", "SOC2": "CC6.6"},
 		})
 	} else {
@@ -382,8 +390,9 @@ func (c *VPCChecks) CheckNACLRestrictions(ctx context.Context) []CheckResult {
 			Name:       "NACL Restricts SSH from Internet",
 			Status:     "PASS",
 			Evidence:   "No NACLs allow SSH from internet | CIS 5.9",
+			ConsoleURL: consoleURL("vpc/home#acls:", consoleRegion(ctx)),
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: map[string]string{"CIS-AWS": "5.9"},
 		})
 	}
@@ -395,13 +404,14 @@ func (c *VPCChecks) CheckNACLRestrictions(ctx context.Context) []CheckResult {
 			Name:              "NACL Restricts RDP from Internet",
 			Status:            "FAIL",
 			Severity:          "CRITICAL",
-			Evidence:          fmt.Sprintf("%d NACLs allow RDP (port 3389) from 0.0.0.0/0: %v | CIS 5.10", len(naclsAllowingRDP), truncateList(naclsAllowingRDP, 3)),
+			Evidence:          fmt.Sprintf("%d NACLs allow RDP (port 3389) from 0.0.0.0/0: %s | CIS 5.10", len(naclsAllowingRDP), TruncateList(naclsAllowingRDP, evidenceListLimit(ctx))),
+			AffectedResources: naclsAllowingRDP,
 			Remediation:       "Remove NACL rules allowing RDP from 0.0.0.0/0",
 			RemediationDetail: `aws ec2 delete-network-acl-entry --network-acl-id NACL_ID --ingress --rule-number RULE_NUM`,
 			ScreenshotGuide:   "VPC Console → Network ACLs → Inbound Rules → Screenshot showing no rules for port 3389 from 0.0.0.0/0",
-			ConsoleURL:        "https://console.aws.amazon.com/vpc/home#acls:",
+			ConsoleURL:        consoleURL("vpc/home#acls:", consoleRegion(ctx)),
 			Priority:          PriorityCritical,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        map[string]string{"CIS-AWS": "5.10", "PCI-DSS": "1.2.1", "SOC2": "CC6.6"},
 		})
 	} else {
@@ -410,8 +420,9 @@ func (c *VPCChecks) CheckNACLRestrictions(ctx context.Context) []CheckResult {
 			Name:       "NACL Restricts RDP from Internet",
 			Status:     "PASS",
 			Evidence:   "No NACLs allow RDP from internet | CIS 5.10",
+			ConsoleURL: consoleURL("vpc/home#acls:", consoleRegion(ctx)),
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: map[string]string{"CIS-AWS": "5.10"},
 		})
 	}
@@ -423,13 +434,14 @@ func (c *VPCChecks) CheckNACLRestrictions(ctx context.Context) []CheckResult {
 			Name:              "NACL Restricts SSH from Internet (IPv6)",
 			Status:            "FAIL",
 			Severity:          "CRITICAL",
-			Evidence:          fmt.Sprintf("%d NACLs allow SSH from ::/0: %v | CIS 5.11", len(naclsAllowingSSHv6), truncateList(naclsAllowingSSHv6, 3)),
+			Evidence:          fmt.Sprintf("%d NACLs allow SSH from ::/0: %s | CIS 5.11", len(naclsAllowingSSHv6), TruncateList(naclsAllowingSSHv6, evidenceListLimit(ctx))),
+			AffectedResources: naclsAllowingSSHv6,
 			Remediation:       "Remove NACL rules allowing SSH from ::/0",
 			RemediationDetail: `aws ec2 delete-network-acl-entry --network-acl-id NACL_ID --ingress --rule-number RULE_NUM`,
 			ScreenshotGuide:   "VPC Console → Network ACLs → Inbound Rules → Screenshot showing no IPv6 rules for port 22",
-			ConsoleURL:        "https://console.aws.amazon.com/vpc/home#acls:",
+			ConsoleURL:        consoleURL("vpc/home#acls:", consoleRegion(ctx)),
 			Priority:          PriorityCritical,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        map[string]string{"CIS-AWS": "5.11"},
 		})
 	} else {
@@ -438,8 +450,9 @@ func (c *VPCChecks) CheckNACLRestrictions(ctx context.Context) []CheckResult {
 			Name:       "NACL Restricts SSH from Internet (IPv6)",
 			Status:     "PASS",
 			Evidence:   "No NACLs allow SSH from ::/0 | CIS 5.11",
+			ConsoleURL: consoleURL("vpc/home#acls:", consoleRegion(ctx)),
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: map[string]string{"CIS-AWS": "5.11"},
 		})
 	}
@@ -451,13 +464,14 @@ func (c *VPCChecks) CheckNACLRestrictions(ctx context.Context) []CheckResult {
 			Name:              "NACL Restricts RDP from Internet (IPv6)",
 			Status:            "FAIL",
 			Severity:          "CRITICAL",
-			Evidence:          fmt.Sprintf("%d NACLs allow RDP from ::/0: %v | CIS 5.12", len(naclsAllowingRDPv6), truncateList(naclsAllowingRDPv6, 3)),
+			Evidence:          fmt.Sprintf("%d NACLs allow RDP from ::/0: %s | CIS 5.12", len(naclsAllowingRDPv6), TruncateList(naclsAllowingRDPv6, evidenceListLimit(ctx))),
+			AffectedResources: naclsAllowingRDPv6,
 			Remediation:       "Remove NACL rules allowing RDP from ::/0",
 			RemediationDetail: `aws ec2 delete-network-acl-entry --network-acl-id NACL_ID --ingress --rule-number RULE_NUM`,
 			ScreenshotGuide:   "VPC Console → Network ACLs → Inbound Rules → Screenshot showing no IPv6 rules for port 3389",
-			ConsoleURL:        "https://console.aws.amazon.com/vpc/home#acls:",
+			ConsoleURL:        consoleURL("vpc/home#acls:", consoleRegion(ctx)),
 			Priority:          PriorityCritical,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        map[string]string{"CIS-AWS": "5.12"},
 		})
 	} else {
@@ -466,8 +480,9 @@ func (c *VPCChecks) CheckNACLRestrictions(ctx context.Context) []CheckResult {
 			Name:       "NACL Restricts RDP from Internet (IPv6)",
 			Status:     "PASS",
 			Evidence:   "No NACLs allow RDP from ::/0 | CIS 5.12",
+			ConsoleURL: consoleURL("vpc/home#acls:", consoleRegion(ctx)),
 			Priority:   PriorityInfo,
-			Timestamp:  time.Now(),
+			Timestamp:  nowFunc(),
 			Frameworks: map[string]string{"CIS-AWS": "5.12"},
 		})
 	}
@@ -510,26 +525,29 @@ func (c *VPCChecks) CheckAdminPortSecurity(ctx context.Context) (CheckResult, er
 			Name:              "Security Groups Restrict Admin Ports",
 			Status:            "FAIL",
 			Severity:          "CRITICAL",
-			Evidence:          fmt.Sprintf("%d security group rules allow admin ports from internet: %v | CIS 5.13", len(violatingSGs), truncateList(violatingSGs, 5)),
+			Evidence:          fmt.Sprintf("%d security group rules allow admin ports from internet: %s | CIS 5.13", len(violatingSGs), TruncateList(violatingSGs, evidenceListLimit(ctx))),
+			AffectedResources: violatingSGs,
 			Remediation:       "Restrict admin port access to specific IP ranges",
 			RemediationDetail: `aws ec2 revoke-security-group-ingress --group-id SG_ID --protocol tcp --port 22 --cidr 0.0.0.0/0
 aws ec2 authorize-security-group-ingress --group-id SG_ID --protocol tcp --port 22 --cidr YOUR_IP/32`,
 			ScreenshotGuide:   "EC2 Console → Security Groups → Inbound Rules → Screenshot showing admin ports restricted to specific IPs",
-			ConsoleURL:        "https://console.aws.amazon.com/ec2/home#SecurityGroups:",
+			ConsoleURL:        consoleURL("ec2/home#SecurityGroups:", consoleRegion(ctx)),
 			Priority:          PriorityCritical,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        map[string]string{"CIS-AWS": "5.13", "PCI-DSS": "1.2.1", "SOC2": "CC6.6"},
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "[CIS-5.13]",
-		Name:       "Security Groups Restrict Admin Ports",
-		Status:     "PASS",
-		Evidence:   "Security groups properly restrict admin port access | CIS 5.13",
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: map[string]string{"CIS-AWS": "5.13"},
+		Control:         "[CIS-5.13]",
+		Name:            "Security Groups Restrict Admin Ports",
+		Status:          "PASS",
+		Evidence:        "Security groups properly restrict admin port access | CIS 5.13",
+		ScreenshotGuide: "EC2 Console → Security Groups → Inbound Rules → Screenshot showing admin ports restricted to specific IPs",
+		ConsoleURL:      consoleURL("ec2/home#SecurityGroups:", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      map[string]string{"CIS-AWS": "5.13"},
 	}, nil
 }
 
@@ -569,25 +587,29 @@ func (c *VPCChecks) CheckEC2SubnetPlacement(ctx context.Context) (CheckResult, e
 			Name:              "EC2 Instances in Custom VPC",
 			Status:            "FAIL",
 			Severity:          "MEDIUM",
-			Evidence:          fmt.Sprintf("%d instances in default VPC: %v | CIS 5.14", len(instancesInDefault), truncateList(instancesInDefault, 3)),
+			Evidence:          fmt.Sprintf("%d instances in default VPC: %s | CIS 5.14", len(instancesInDefault), TruncateList(instancesInDefault, evidenceListLimit(ctx))),
+			AffectedResources: instancesInDefault,
 			Remediation:       "Launch instances in custom VPCs with proper network controls",
 			RemediationDetail: "1. Create custom VPC\n2. Migrate instances to custom VPC\n3. Terminate instances in default VPC",
+			RequiresRecreate:  true,
 			ScreenshotGuide:   "EC2 Console → Instances → VPC column → Screenshot showing all instances in custom VPCs",
-			ConsoleURL:        "https://console.aws.amazon.com/ec2/home#Instances:",
+			ConsoleURL:        consoleURL("ec2/home#Instances:", consoleRegion(ctx)),
 			Priority:          PriorityMedium,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        map[string]string{"CIS-AWS": "5.14"},
 		}, nil
 	}
 
 	return CheckResult{
-		Control:    "[CIS-5.14]",
-		Name:       "EC2 Instances in Custom VPC",
-		Status:     "PASS",
-		Evidence:   "All instances in custom VPCs | CIS 5.14",
-		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
-		Frameworks: map[string]string{"CIS-AWS": "5.14"},
+		Control:         "[CIS-5.14]",
+		Name:            "EC2 Instances in Custom VPC",
+		Status:          "PASS",
+		Evidence:        "All instances in custom VPCs | CIS 5.14",
+		ScreenshotGuide: "EC2 Console → Instances → VPC column → Screenshot showing all instances in custom VPCs",
+		ConsoleURL:      consoleURL("ec2/home#Instances:", consoleRegion(ctx)),
+		Priority:        PriorityInfo,
+		Timestamp:       nowFunc(),
+		Frameworks:      map[string]string{"CIS-AWS": "5.14"},
 	}, nil
 }
 
@@ -629,13 +651,14 @@ func (c *VPCChecks) CheckUnusedSecurityGroups(ctx context.Context) (CheckResult,
 			Name:              "Unused Security Groups Removed",
 			Status:            "FAIL",
 			Severity:          "LOW",
-			Evidence:          fmt.Sprintf("%d unused security groups found: %v | CIS 5.18", len(unusedSGs), truncateList(unusedSGs, 5)),
+			Evidence:          fmt.Sprintf("%d unused security groups found: %s | CIS 5.18", len(unusedSGs), TruncateList(unusedSGs, evidenceListLimit(ctx))),
+			AffectedResources: unusedSGs,
 			Remediation:       "Remove unused security groups to reduce attack surface",
 			RemediationDetail: `aws ec2 delete-security-group --group-id SG_ID`,
 			ScreenshotGuide:   "EC2 Console → Security Groups → Screenshot showing only security groups in use",
-			ConsoleURL:        "https://console.aws.amazon.com/ec2/home#SecurityGroups:",
+			ConsoleURL:        consoleURL("ec2/home#SecurityGroups:", consoleRegion(ctx)),
 			Priority:          PriorityLow,
-			Timestamp:         time.Now(),
+			Timestamp:         nowFunc(),
 			Frameworks:        map[string]string{"CIS-AWS": "5.18"},
 		}, nil
 	}
@@ -645,8 +668,9 @@ func (c *VPCChecks) CheckUnusedSecurityGroups(ctx context.Context) (CheckResult,
 		Name:       "Unused Security Groups Removed",
 		Status:     "PASS",
 		Evidence:   "No unused security groups | CIS 5.18",
+		ConsoleURL: consoleURL("ec2/home#SecurityGroups:", consoleRegion(ctx)),
 		Priority:   PriorityInfo,
-		Timestamp:  time.Now(),
+		Timestamp:  nowFunc(),
 		Frameworks: map[string]string{"CIS-AWS": "5.18"},
 	}, nil
 }
@@ -682,13 +706,6 @@ func isPortInRange(port int32, from, to *int32) bool {
 	return port >= *from && port <= *to
 }
 
-func truncateList(list []string, maxLen int) []string {
-	if len(list) <= maxLen {
-		return list
-	}
-	return list[:maxLen]
-}
-
 // CIS-5.8 - Ensure routing tables for VPC peering are "least access"
 func (c *VPCChecks) CheckVPCPeeringRouting(ctx context.Context) CheckResult {
 	return CheckResult{
@@ -713,9 +730,9 @@ func (c *VPCChecks) CheckVPCPeeringRouting(ctx context.Context) CheckResult {
    - Associated route tables
    - Specific CIDR blocks (not 0.0.0.0/0)`,
 		ScreenshotGuide:   "VPC Console → Peering Connections → Route Tables → Screenshot showing specific CIDR routes (not 0.0.0.0/0)",
-		ConsoleURL:        "https://console.aws.amazon.com/vpc/home#PeeringConnections:",
+		ConsoleURL:        consoleURL("vpc/home#PeeringConnections:", consoleRegion(ctx)),
 		Priority:          PriorityMedium,
-		Timestamp:         time.Now(),
+		Timestamp:         nowFunc(),
 		Frameworks:        GetFrameworkMappings("VPC_PEERING_ROUTING"),
 	}
 }
@@ -755,9 +772,9 @@ func (c *VPCChecks) CheckVPCEndpointsForS3(ctx context.Context) CheckResult {
    - Route tables associated with endpoints
    - S3 bucket policies enforcing VPC endpoint access`,
 		ScreenshotGuide:   "VPC Console → Endpoints → Screenshot showing active S3 endpoint(s) + associated route tables",
-		ConsoleURL:        "https://console.aws.amazon.com/vpc/home#Endpoints:",
+		ConsoleURL:        consoleURL("vpc/home#Endpoints:", consoleRegion(ctx)),
 		Priority:          PriorityLow,
-		Timestamp:         time.Now(),
+		Timestamp:         nowFunc(),
 		Frameworks:        GetFrameworkMappings("VPC_S3_ENDPOINTS"),
 	}
 }
@@ -0,0 +1,254 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/accessanalyzer"
+	"github.com/aws/aws-sdk-go-v2/service/acm"
+	"github.com/aws/aws-sdk-go-v2/service/apigateway"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	"github.com/aws/aws-sdk-go-v2/service/backup"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/aws/aws-sdk-go-v2/service/cloudtrail"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/configservice"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ecr"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/aws/aws-sdk-go-v2/service/elasticache"
+	"github.com/aws/aws-sdk-go-v2/service/elasticbeanstalk"
+	"github.com/aws/aws-sdk-go-v2/service/guardduty"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/inspector2"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/macie2"
+	"github.com/aws/aws-sdk-go-v2/service/networkfirewall"
+	"github.com/aws/aws-sdk-go-v2/service/opensearch"
+	"github.com/aws/aws-sdk-go-v2/service/organizations"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/redshift"
+	"github.com/aws/aws-sdk-go-v2/service/redshiftserverless"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/securityhub"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/smithy-go"
+	"github.com/guardian-nexus/auditkit/scanner/pkg/aws/checks"
+)
+
+// ClientOptions controls how NewClientSet loads the AWS configuration. Zero
+// values fall back to the SDK defaults (shared config, default retryer).
+type ClientOptions struct {
+	Profile       string        // shared config profile; "" uses the default chain
+	Region        string        // overrides the profile's region
+	AssumeRoleARN string        // role assumed with the profile's credentials, e.g. for cross-account scans
+	MaxAttempts   int           // total attempts per API call, including the first
+	MaxBackoff    time.Duration // cap on the delay between retries
+
+	// MaxCallsPerSecond caps API calls per second across every client in
+	// the set, every region and every concurrent check module (see
+	// RateLimiter); 0 leaves calls unlimited
+	MaxCallsPerSecond float64
+}
+
+// rateLimitBurst is how many calls a rate-limited client set may make at once
+// after a quiet spell; it is kept small so the cap holds over any one second
+const rateLimitBurst = 5
+
+// assumeRoleSessionName identifies AuditKit scans in the target account's
+// CloudTrail when AssumeRoleARN is used
+const assumeRoleSessionName = "auditkit-scan"
+
+// ClientSet holds every service client the checks use, all built from one
+// aws.Config so region, credentials and retry behaviour are consistent
+type ClientSet struct {
+	Config aws.Config
+
+	AccessAnalyzer     *accessanalyzer.Client
+	ACM                *acm.Client
+	APIGateway         *apigateway.Client
+	APIGatewayV2       *apigatewayv2.Client
+	AutoScaling        *autoscaling.Client
+	Backup             *backup.Client
+	Beanstalk          *elasticbeanstalk.Client
+	CloudFormation     *cloudformation.Client
+	CloudTrail         *cloudtrail.Client
+	CloudWatch         *cloudwatch.Client
+	CloudWatchLogs     *cloudwatchlogs.Client
+	ConfigService      *configservice.Client
+	DynamoDB           *dynamodb.Client
+	EC2                *ec2.Client
+	ECR                *ecr.Client
+	ECS                *ecs.Client
+	EKS                *eks.Client
+	ElastiCache        *elasticache.Client
+	GuardDuty          *guardduty.Client
+	IAM                *iam.Client
+	Inspector2         *inspector2.Client
+	KMS                *kms.Client
+	Lambda             *lambda.Client
+	Macie              *macie2.Client
+	NetworkFirewall    *networkfirewall.Client
+	OpenSearch         *opensearch.Client
+	Organizations      *organizations.Client
+	RDS                *rds.Client
+	Redshift           *redshift.Client
+	RedshiftServerless *redshiftserverless.Client
+	Route53            *route53.Client
+	S3                 *s3.Client
+	SageMaker          *sagemaker.Client
+	SecretsManager     *secretsmanager.Client
+	SecurityHub        *securityhub.Client
+	SNS                *sns.Client
+	SQS                *sqs.Client
+	SSM                *ssm.Client
+	STS                *sts.Client
+}
+
+// LoadConfig loads the AWS configuration described by opts
+func LoadConfig(ctx context.Context, opts ClientOptions) (aws.Config, error) {
+	loadOpts := []func(*config.LoadOptions) error{}
+	if opts.Profile != "" {
+		loadOpts = append(loadOpts, config.WithSharedConfigProfile(opts.Profile))
+	}
+	if opts.Region != "" {
+		loadOpts = append(loadOpts, config.WithRegion(opts.Region))
+	}
+	if opts.MaxAttempts > 0 || opts.MaxBackoff > 0 {
+		loadOpts = append(loadOpts, config.WithRetryer(func() aws.Retryer {
+			var retryer aws.Retryer = retry.NewStandard()
+			if opts.MaxAttempts > 0 {
+				retryer = retry.AddWithMaxAttempts(retryer, opts.MaxAttempts)
+			}
+			if opts.MaxBackoff > 0 {
+				retryer = retry.AddWithMaxBackoffDelay(retryer, opts.MaxBackoff)
+			}
+			return retryer
+		}))
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx, loadOpts...)
+	if err != nil {
+		return aws.Config{}, fmt.Errorf("failed to load AWS config: %v", err)
+	}
+
+	if opts.AssumeRoleARN != "" {
+		provider := stscreds.NewAssumeRoleProvider(sts.NewFromConfig(cfg), opts.AssumeRoleARN, func(o *stscreds.AssumeRoleOptions) {
+			o.RoleSessionName = assumeRoleSessionName
+		})
+		cfg.Credentials = aws.NewCredentialsCache(provider)
+	}
+	if opts.MaxCallsPerSecond > 0 {
+		cfg = WithRateLimit(cfg, NewRateLimiter(opts.MaxCallsPerSecond, rateLimitBurst))
+	}
+	return cfg, nil
+}
+
+// ValidateCredentials calls STS GetCallerIdentity so missing, invalid or
+// expired credentials, or a role that cannot be assumed, are reported before
+// any check runs instead of as a failure deep inside the first one
+func ValidateCredentials(ctx context.Context, clients *ClientSet) error {
+	if _, err := clients.STS.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{}); err != nil {
+		return credentialsError(err)
+	}
+	return nil
+}
+
+// credentialsError turns an STS credential failure into an actionable message,
+// keeping the original error wrapped and classified (see checks.ClassifyError)
+func credentialsError(err error) error {
+	classified := checks.ClassifyError(err)
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.ErrorCode() {
+		case "ExpiredToken", "ExpiredTokenException", "RequestExpired":
+			return fmt.Errorf("AWS credentials have expired; refresh them (e.g. aws sso login) and retry: %w", classified)
+		case "InvalidClientTokenId", "SignatureDoesNotMatch", "UnrecognizedClientException":
+			return fmt.Errorf("AWS credentials are invalid; check the access key or profile: %w", classified)
+		case "AccessDenied":
+			if strings.Contains(err.Error(), "AssumeRole") {
+				return fmt.Errorf("could not assume the role; check its trust policy allows your principal: %w", classified)
+			}
+		}
+	}
+
+	if strings.Contains(err.Error(), "failed to retrieve credentials") || strings.Contains(err.Error(), "no EC2 IMDS role found") {
+		return fmt.Errorf("no AWS credentials found; run aws configure or pass -profile: %w", classified)
+	}
+	return fmt.Errorf("failed to validate AWS credentials: %w", classified)
+}
+
+// NewClientSet loads the AWS configuration described by opts and builds every
+// service client from it
+func NewClientSet(ctx context.Context, opts ClientOptions) (*ClientSet, error) {
+	cfg, err := LoadConfig(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return NewClientSetFromConfig(cfg), nil
+}
+
+// NewClientSetFromConfig builds every service client from a pre-configured
+// aws.Config, e.g. one carrying assumed-role credentials
+func NewClientSetFromConfig(cfg aws.Config) *ClientSet {
+	return &ClientSet{
+		Config:             cfg,
+		AccessAnalyzer:     accessanalyzer.NewFromConfig(cfg),
+		ACM:                acm.NewFromConfig(cfg),
+		APIGateway:         apigateway.NewFromConfig(cfg),
+		APIGatewayV2:       apigatewayv2.NewFromConfig(cfg),
+		AutoScaling:        autoscaling.NewFromConfig(cfg),
+		Backup:             backup.NewFromConfig(cfg),
+		Beanstalk:          elasticbeanstalk.NewFromConfig(cfg),
+		CloudFormation:     cloudformation.NewFromConfig(cfg),
+		CloudTrail:         cloudtrail.NewFromConfig(cfg),
+		CloudWatch:         cloudwatch.NewFromConfig(cfg),
+		CloudWatchLogs:     cloudwatchlogs.NewFromConfig(cfg),
+		ConfigService:      configservice.NewFromConfig(cfg),
+		DynamoDB:           dynamodb.NewFromConfig(cfg),
+		EC2:                ec2.NewFromConfig(cfg),
+		ECR:                ecr.NewFromConfig(cfg),
+		ECS:                ecs.NewFromConfig(cfg),
+		EKS:                eks.NewFromConfig(cfg),
+		ElastiCache:        elasticache.NewFromConfig(cfg),
+		GuardDuty:          guardduty.NewFromConfig(cfg),
+		IAM:                iam.NewFromConfig(cfg),
+		Inspector2:         inspector2.NewFromConfig(cfg),
+		KMS:                kms.NewFromConfig(cfg),
+		Lambda:             lambda.NewFromConfig(cfg),
+		Macie:              macie2.NewFromConfig(cfg),
+		NetworkFirewall:    networkfirewall.NewFromConfig(cfg),
+		OpenSearch:         opensearch.NewFromConfig(cfg),
+		Organizations:      organizations.NewFromConfig(cfg),
+		RDS:                rds.NewFromConfig(cfg),
+		Redshift:           redshift.NewFromConfig(cfg),
+		RedshiftServerless: redshiftserverless.NewFromConfig(cfg),
+		Route53:            route53.NewFromConfig(cfg),
+		S3:                 s3.NewFromConfig(cfg),
+		SageMaker:          sagemaker.NewFromConfig(cfg),
+		SecretsManager:     secretsmanager.NewFromConfig(cfg),
+		SecurityHub:        securityhub.NewFromConfig(cfg),
+		SNS:                sns.NewFromConfig(cfg),
+		SQS:                sqs.NewFromConfig(cfg),
+		SSM:                ssm.NewFromConfig(cfg),
+		STS:                sts.NewFromConfig(cfg),
+	}
+}
@@ -0,0 +1,249 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/guardian-nexus/auditkit/scanner/pkg/aws/checks"
+)
+
+// Smoke paths: the ways SmokeTest exercises each check module
+const (
+	SmokeHappy = "happy" // calls with a SmokeFixtures entry return it, the rest return nothing
+	SmokeEmpty = "empty" // every call succeeds and finds no resources
+	SmokeError = "error" // every call is denied
+)
+
+// smokePaths is the order SmokeTest runs the paths in
+var smokePaths = []string{SmokeHappy, SmokeEmpty, SmokeError}
+
+// smokeModuleTimeout bounds one module on one path, so a pagination loop that
+// never ends is reported instead of hanging the doctor
+const smokeModuleTimeout = 30 * time.Second
+
+// SmokeFixtures are the canned responses the happy path gives, keyed by
+// "service:Operation" as stubOperation names calls, e.g.
+// "dynamodb:ListTables". Bodies use the service's wire protocol. They give
+// the per-resource Describe loops something to iterate over; add fixtures
+// for the calls a new check makes.
+var SmokeFixtures = map[string]string{
+	"dynamodb:ListTables":    `{"TableNames":["smoke-table"]}`,
+	"dynamodb:DescribeTable": `{"Table":{"TableName":"smoke-table","TableStatus":"ACTIVE","TableArn":"arn:aws:dynamodb:us-east-1:123456789012:table/smoke-table"}}`,
+
+	"iam:ListAttachedRolePolicies": `<ListAttachedRolePoliciesResponse><ListAttachedRolePoliciesResult><AttachedPolicies><member><PolicyName>AdministratorAccess</PolicyName><PolicyArn>arn:aws:iam::aws:policy/AdministratorAccess</PolicyArn></member></AttachedPolicies><IsTruncated>false</IsTruncated></ListAttachedRolePoliciesResult></ListAttachedRolePoliciesResponse>`,
+
+	"kms:ListKeys": `{"Keys":[{"KeyId":"smoke-key","KeyArn":"arn:aws:kms:us-east-1:123456789012:key/smoke-key"}]}`,
+
+	"logs:DescribeLogGroups": `{"logGroups":[{"logGroupName":"/smoke/never-expires"},{"logGroupName":"/smoke/short","retentionInDays":7}]}`,
+
+	"sagemaker:ListNotebookInstances":    `{"NotebookInstances":[{"NotebookInstanceName":"smoke-notebook","NotebookInstanceArn":"arn:aws:sagemaker:us-east-1:123456789012:notebook-instance/smoke-notebook","NotebookInstanceStatus":"InService"}]}`,
+	"sagemaker:DescribeNotebookInstance": `{"NotebookInstanceName":"smoke-notebook","DirectInternetAccess":"Enabled","RootAccess":"Enabled","RoleArn":"arn:aws:iam::123456789012:role/smoke-notebook-role"}`,
+	"sagemaker:ListEndpoints":            `{"Endpoints":[{"EndpointName":"smoke-endpoint","EndpointArn":"arn:aws:sagemaker:us-east-1:123456789012:endpoint/smoke-endpoint","EndpointStatus":"InService","CreationTime":0,"LastModifiedTime":0}]}`,
+	"sagemaker:DescribeEndpoint":         `{"EndpointName":"smoke-endpoint","EndpointConfigName":"smoke-config","EndpointStatus":"InService"}`,
+	"sagemaker:DescribeEndpointConfig":   `{"EndpointConfigName":"smoke-config","ProductionVariants":[{"VariantName":"AllTraffic","InstanceType":"ml.m5.large","InitialInstanceCount":1}]}`,
+
+	"secretsmanager:ListSecrets": `{"SecretList":[{"Name":"smoke-secret","ARN":"arn:aws:secretsmanager:us-east-1:123456789012:secret:smoke-secret"}]}`,
+}
+
+// SmokeResult is how one check module fared on one smoke path
+type SmokeResult struct {
+	Service  string
+	Path     string
+	Results  int      // results the module returned
+	Problems []string // panics, hangs, unexpected errors and malformed results
+}
+
+// Passed reports whether the module ran cleanly on the path
+func (r SmokeResult) Passed() bool {
+	return len(r.Problems) == 0
+}
+
+// SmokeTest runs every AWS check module against stub clients that never
+// leave the process, once per smoke path, and reports for each module and
+// path whether it panicked, hung, returned an error where every call
+// succeeded, or returned results checks.ValidateResults rejects. Modules are
+// rebuilt and run in a fresh checks.Scan for each path, so no path sees
+// another's memoized responses. Results are sorted by service, then path order.
+//
+// It is a floor, not a test of check logic: a module passes the error path
+// by returning an error or results, and the happy path only reaches the
+// resources SmokeFixtures describes.
+func SmokeTest(ctx context.Context) []SmokeResult {
+	results := []SmokeResult{}
+	for _, path := range smokePaths {
+		pathCtx := checks.WithScan(ctx, checks.NewScan(checks.ScanOptions{}))
+		scanner := NewScannerWithClients(NewStubClientSet(stubResponder(path)))
+
+		seen := map[string]bool{}
+		for _, module := range scanner.frameworkModules("all") {
+			if seen[module.Name()] {
+				continue
+			}
+			seen[module.Name()] = true
+			results = append(results, smokeModule(pathCtx, module, path))
+		}
+	}
+
+	order := map[string]int{}
+	for i, path := range smokePaths {
+		order[path] = i
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Service != results[j].Service {
+			return results[i].Service < results[j].Service
+		}
+		return order[results[i].Path] < order[results[j].Path]
+	})
+	return results
+}
+
+// smokeModule runs module once on path, recovering a panic into a problem
+func smokeModule(ctx context.Context, module checks.Check, path string) SmokeResult {
+	result := SmokeResult{Service: module.Name(), Path: path}
+
+	ctx, cancel := context.WithTimeout(ctx, smokeModuleTimeout)
+	defer cancel()
+
+	type outcome struct {
+		results []checks.CheckResult
+		err     error
+		panic   any
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		var out outcome
+		defer func() {
+			out.panic = recover()
+			done <- out
+		}()
+		out.results, out.err = module.Run(ctx)
+	}()
+
+	var out outcome
+	select {
+	case out = <-done:
+	case <-ctx.Done():
+		result.Problems = append(result.Problems, fmt.Sprintf("did not finish within %s", smokeModuleTimeout))
+		return result
+	}
+
+	if out.panic != nil {
+		result.Problems = append(result.Problems, fmt.Sprintf("panic: %v", out.panic))
+		return result
+	}
+	if out.err != nil && path != SmokeError {
+		result.Problems = append(result.Problems, fmt.Sprintf("error: %v", out.err))
+	}
+	for i := range out.results {
+		if out.results[i].Service == "" {
+			out.results[i].Service = module.Name()
+		}
+	}
+	result.Results = len(out.results)
+	for _, issue := range checks.ValidateResults(out.results) {
+		result.Problems = append(result.Problems, issue.Error())
+	}
+	return result
+}
+
+// NewStubClientSet builds every service client against respond instead of
+// AWS: no request leaves the process, calls are not retried, and requests
+// are unsigned. For the doctor and for exercising checks without an account.
+func NewStubClientSet(respond func(req *http.Request) *http.Response) *ClientSet {
+	return NewClientSetFromConfig(aws.Config{
+		Region:      "us-east-1",
+		Credentials: aws.AnonymousCredentials{},
+		HTTPClient:  stubHTTPClient(respond),
+		Retryer: func() aws.Retryer {
+			return aws.NopRetryer{}
+		},
+	})
+}
+
+// stubHTTPClient answers SDK requests with a function
+type stubHTTPClient func(req *http.Request) *http.Response
+
+func (f stubHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	resp := f(req)
+	resp.Request = req
+	return resp, nil
+}
+
+// stubResponder answers every call the way path calls for
+func stubResponder(path string) func(req *http.Request) *http.Response {
+	return func(req *http.Request) *http.Response {
+		operation := stubOperation(req)
+		switch path {
+		case SmokeError:
+			return stubDenied(req, operation)
+		case SmokeHappy:
+			if body, ok := SmokeFixtures[operation]; ok {
+				return stubResponse(http.StatusOK, body, nil)
+			}
+		}
+		// An empty body deserializes to the operation's zero-value output
+		return stubResponse(http.StatusOK, "", nil)
+	}
+}
+
+// stubDenied is an AccessDenied error in the request's protocol: XML for the
+// query protocols (form-encoded requests), JSON otherwise
+func stubDenied(req *http.Request, operation string) *http.Response {
+	message := "smoke test: " + operation + " denied"
+	if strings.HasPrefix(req.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		return stubResponse(http.StatusForbidden,
+			fmt.Sprintf("<ErrorResponse><Error><Type>Sender</Type><Code>AccessDenied</Code><Message>%s</Message></Error></ErrorResponse>", message), nil)
+	}
+	return stubResponse(http.StatusForbidden,
+		fmt.Sprintf(`{"__type":"AccessDeniedException","message":%q}`, message),
+		map[string]string{"X-Amzn-ErrorType": "AccessDeniedException"})
+}
+
+func stubResponse(status int, body string, headers map[string]string) *http.Response {
+	resp := &http.Response{
+		StatusCode:    status,
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		Header:        http.Header{},
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+	}
+	for key, value := range headers {
+		resp.Header.Set(key, value)
+	}
+	return resp
+}
+
+// stubOperation names the call req makes as "service:Operation". The service
+// is the endpoint label before the region ("api.sagemaker.us-east-1..." is
+// sagemaker), or the first label for global endpoints. The operation is the
+// X-Amz-Target of JSON protocols, the Action of query protocols, or the
+// method and path of REST protocols.
+func stubOperation(req *http.Request) string {
+	labels := strings.Split(req.URL.Hostname(), ".")
+	service := labels[0]
+	for i, label := range labels {
+		if i > 0 && strings.Count(label, "-") >= 2 {
+			service = labels[i-1]
+			break
+		}
+	}
+
+	if target := req.Header.Get("X-Amz-Target"); target != "" {
+		_, operation, _ := strings.Cut(target, ".")
+		return service + ":" + operation
+	}
+	if req.Body != nil && strings.HasPrefix(req.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		body, _ := io.ReadAll(req.Body)
+		if form, err := url.ParseQuery(string(body)); err == nil && form.Get("Action") != "" {
+			return service + ":" + form.Get("Action")
+		}
+	}
+	return service + ":" + req.Method + " " + req.URL.Path
+}
@@ -0,0 +1,49 @@
+package aws
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/guardian-nexus/auditkit/scanner/pkg/aws/checks"
+)
+
+// Every module must survive every smoke path without panicking, hanging or
+// returning a malformed result, so the doctor never reports a broken check
+func TestSmokeTest(t *testing.T) {
+	results := SmokeTest(context.Background())
+	if len(results) == 0 {
+		t.Fatal("SmokeTest ran no modules")
+	}
+
+	paths := map[string]map[string]bool{}
+	for _, result := range results {
+		if !result.Passed() {
+			t.Errorf("%s (%s path): %s", result.Service, result.Path, strings.Join(result.Problems, "; "))
+		}
+		if paths[result.Service] == nil {
+			paths[result.Service] = map[string]bool{}
+		}
+		paths[result.Service][result.Path] = true
+	}
+	for service, ran := range paths {
+		for _, path := range smokePaths {
+			if !ran[path] {
+				t.Errorf("%s was not run on the %s path", service, path)
+			}
+		}
+	}
+}
+
+func TestModulesPassDebugAssertions(t *testing.T) {
+	checks.SetDebugAssertions(true)
+	defer checks.SetDebugAssertions(false)
+
+	// SmokeTest recovers the panics GetFrameworkMappings and
+	// assertFrameworks raise into problems
+	for _, result := range SmokeTest(context.Background()) {
+		for _, problem := range result.Problems {
+			t.Errorf("%s (%s path): %s", result.Service, result.Path, problem)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package aws
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// RateLimiter is a token bucket shared by every client it is installed on,
+// capping AWS API calls per second across all services, regions and
+// concurrently running check modules. The per-service throttling limits are
+// generous, but a scan running many modules in several regions at once can
+// add up to more than the account's shared limits allow.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64 // tokens added per second
+	burst  float64 // most tokens the bucket holds
+	tokens float64
+	last   time.Time
+
+	now func() time.Time // clock, replaceable for deterministic timing
+}
+
+// NewRateLimiter allows perSecond calls per second on average, with bursts
+// of up to burst calls after a quiet spell. burst below 1 is 1.
+func NewRateLimiter(perSecond float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:   perSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		now:    time.Now,
+	}
+}
+
+// Wait blocks until a call may be made, or ctx is done
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	delay := l.reserve()
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// reserve takes a token and returns how long to wait until it is due. The
+// bucket may go negative: each waiting caller holds its place in the queue,
+// so concurrent callers are spaced out rather than waking together.
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if !l.last.IsZero() {
+		l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+	l.last = now
+
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// rateLimitMiddlewareID names the middleware in the SDK's operation stack
+const rateLimitMiddlewareID = "AuditKitRateLimit"
+
+// apiOption adds the limiter to an operation's middleware stack. It runs in
+// the finalize step after the retryer, so each attempt of a retried call
+// takes its own token: retries are what throttling multiplies.
+func (l *RateLimiter) apiOption(stack *middleware.Stack) error {
+	return stack.Finalize.Add(middleware.FinalizeMiddlewareFunc(rateLimitMiddlewareID,
+		func(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+			if err := l.Wait(ctx); err != nil {
+				return middleware.FinalizeOutput{}, middleware.Metadata{}, err
+			}
+			return next.HandleFinalize(ctx, in)
+		}), middleware.After)
+}
+
+// WithRateLimit returns a copy of cfg whose clients all pass their calls
+// through limiter. Regional copies of the returned config (aws.Config.Copy)
+// keep the same limiter, so a multi-region scan shares one budget.
+func WithRateLimit(cfg aws.Config, limiter *RateLimiter) aws.Config {
+	cfg = cfg.Copy()
+	cfg.APIOptions = append(cfg.APIOptions, limiter.apiOption)
+	return cfg
+}
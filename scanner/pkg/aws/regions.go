@@ -0,0 +1,73 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/guardian-nexus/auditkit/scanner/pkg/aws/checks"
+)
+
+// AllRegions in RunnerOptions.Regions scans every region enabled for the
+// account, discovered with DiscoverRegions when the scan starts
+const AllRegions = "all"
+
+// ParseRegions splits a comma-separated region list such as
+// "us-east-1,eu-west-1", or "all" for every enabled region. An empty spec
+// returns nil, which scans the client set's region.
+func ParseRegions(spec string) []string {
+	var regions []string
+	for _, region := range strings.Split(spec, ",") {
+		region = strings.ToLower(strings.TrimSpace(region))
+		if region == "" {
+			continue
+		}
+		if region == AllRegions {
+			return []string{AllRegions}
+		}
+		regions = append(regions, region)
+	}
+	return regions
+}
+
+// DiscoverRegions lists the regions enabled for the account, sorted: those
+// that need no opt-in plus those the account has opted in to. Opt-in regions
+// the account has not enabled are skipped, since every call there would fail.
+func DiscoverRegions(ctx context.Context, clients *ClientSet) ([]string, error) {
+	out, err := clients.EC2.DescribeRegions(ctx, &ec2.DescribeRegionsInput{
+		AllRegions: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover regions: %w", checks.ClassifyError(err))
+	}
+
+	regions := []string{}
+	for _, region := range out.Regions {
+		if aws.ToString(region.OptInStatus) == "not-opted-in" {
+			continue
+		}
+		regions = append(regions, aws.ToString(region.RegionName))
+	}
+	if len(regions) == 0 {
+		return nil, fmt.Errorf("failed to discover regions: no enabled regions returned")
+	}
+	sort.Strings(regions)
+	return regions, nil
+}
+
+// resolveRegions expands RunnerOptions.Regions: empty is the client set's
+// region and AllRegions is every enabled region
+func resolveRegions(ctx context.Context, clients *ClientSet, regions []string) ([]string, error) {
+	if len(regions) == 0 {
+		return []string{clients.Config.Region}, nil
+	}
+	for _, region := range regions {
+		if region == AllRegions {
+			return DiscoverRegions(ctx, clients)
+		}
+	}
+	return regions, nil
+}
@@ -0,0 +1,720 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/guardian-nexus/auditkit/scanner/pkg/aws/checks"
+	"github.com/guardian-nexus/auditkit/scanner/pkg/core"
+	"github.com/guardian-nexus/auditkit/scanner/pkg/offline"
+)
+
+// RunnerOptions controls what a Runner scans and how. Zero values scan the
+// SOC2 modules once, in the client set's region, one module at a time.
+type RunnerOptions struct {
+	Framework         string   // framework whose check modules run ("soc2", "pci", "cis-aws", "all", ...)
+	SeverityThreshold string   // hide failures below this severity from the output; they still count in the score ("" keeps all)
+	Concurrency       int      // check modules run in parallel per region; <1 means 1
+	Regions           []string // regions to scan; empty scans the client set's region, AllRegions every enabled one
+	ToolVersion       string   // recorded in the scan metadata and CachedScan.Version
+	Profile           string   // shared config profile, used by NewRunnerFromOptions
+	AssumeRoleARN     string   // role to scan as, used by NewRunnerFromOptions
+	DisabledChecks    []string // check IDs not to run (see checks.LoadCheckConfig)
+	FailFast          bool     // stop the scan at the first CRITICAL failure
+	FallbackToCache   bool     // serve the latest cached scan, marked stale, when the live scan fails entirely
+	AccountID         string   // account whose cached scan FallbackToCache loads if the live scan cannot detect it
+	EvidenceListLimit int      // resource IDs listed per Evidence string; 0 is checks.DefaultEvidenceListLimit, negative lists all
+	ScanProfile       string   // checks.ProfileQuick or checks.ProfileDeep (the default); recorded in the scan metadata
+	MaxCallsPerSecond float64  // shared API call budget across regions and modules, used by NewRunnerFromOptions; 0 is unlimited
+
+	// Resources narrows the scan to named resources (see checks.ResourceFilter)
+	Resources checks.ResourceFilter
+
+	// CustomChecks are org-specific YAML checks (see checks.LoadCustomChecks)
+	// run in every region after the framework's modules. A resource filter
+	// skips them, as it does for AWSScanner.
+	CustomChecks []checks.CustomCheckDef
+
+	// Redact, when set, masks identifiers in the results and the scan's
+	// account and identity (see checks.Redact), for sharing the scan outside
+	// the organization. A redacted scan is filed under a masked account, so
+	// do not save it to the offline cache.
+	Redact *checks.RedactOptions
+}
+
+// Runner owns a full AWS scan: the client set, the enabled check modules and
+// the scan options. Run produces an offline.CachedScan ready to be saved with
+// offline.Cache.Save or handed to the report writers, so the CLI and other
+// consumers do not each wire checks, scoring and metadata together.
+type Runner struct {
+	clients *ClientSet
+	checks  []checks.Check
+	options RunnerOptions
+
+	// severityOverrides re-rate check severities before the threshold applies
+	severityOverrides []checks.SeverityOverride
+
+	// environmentPolicy classifies results by environment and down-weights
+	// non-production failures, after the severity overrides
+	environmentPolicy checks.EnvironmentPolicy
+
+	// hooks run around every check module, in the order they were added
+	hooks []RunnerHook
+
+	// liveErr is why the client set could not be built, on a Runner created
+	// by NewRunnerFromOptions with FallbackToCache set. Run then serves the
+	// cached scan straight away.
+	liveErr error
+}
+
+// RunnerHook injects behavior around each check module the Runner runs, for
+// logging, metrics or annotating results, without changing the checks.
+// Before is called as a module starts. After is called when it returns, with
+// its results, which it may modify in place, and its error. Either may be
+// nil. With RunnerOptions.Concurrency above 1, hooks are called from several
+// goroutines at once.
+type RunnerHook struct {
+	Before func(ctx context.Context, module checks.Check)
+	After  func(ctx context.Context, module checks.Check, results []checks.CheckResult, err error)
+}
+
+// severityRank orders severities for RunnerOptions.SeverityThreshold
+var severityRank = map[string]int{
+	"CRITICAL": 4,
+	"HIGH":     3,
+	"MEDIUM":   2,
+	"LOW":      1,
+}
+
+// NewRunner creates a Runner over clients with the check modules of
+// options.Framework enabled
+func NewRunner(clients *ClientSet, options RunnerOptions) (*Runner, error) {
+	if threshold := strings.ToUpper(options.SeverityThreshold); threshold != "" {
+		if _, ok := severityRank[threshold]; !ok {
+			return nil, fmt.Errorf("unknown severity threshold %q (want CRITICAL, HIGH, MEDIUM or LOW)", options.SeverityThreshold)
+		}
+		options.SeverityThreshold = threshold
+	}
+	if options.Framework == "" {
+		options.Framework = "soc2"
+	}
+	profile, err := checks.ParseScanProfile(options.ScanProfile)
+	if err != nil {
+		return nil, err
+	}
+	options.ScanProfile = profile
+
+	return &Runner{
+		clients: clients,
+		checks:  regionModules(clients, options, true),
+		options: options,
+	}, nil
+}
+
+// regionModules returns the check modules options runs against clients: the
+// framework's, narrowed by the resource filter, then any custom checks. Global
+// services are scanned from the home region only, so in any other region the
+// modules and custom checks covering them are left out.
+func regionModules(clients *ClientSet, options RunnerOptions, home bool) []checks.Check {
+	scanner := NewScannerWithClients(clients)
+	scanner.SetResourceFilter(options.Resources)
+
+	modules := []checks.Check{}
+	for _, module := range scanner.modulesForFramework(options.Framework) {
+		if !home && globalModule(module) {
+			continue
+		}
+		modules = append(modules, module)
+	}
+	if isPCIFramework(options.Framework) {
+		modules = pciRelevant(modules)
+	}
+
+	custom := options.CustomChecks
+	if !home {
+		custom = regionalCustomChecks(custom)
+	}
+	if len(custom) > 0 && options.Resources.IsEmpty() {
+		modules = append(modules, checks.NewCustomChecks(custom, clients.Redshift, clients.S3))
+	}
+	return modules
+}
+
+// globalModule reports whether module only checks global services (IAM, S3,
+// Organizations, Route 53, CloudTrail trails) or none at all, so running it
+// in every region would repeat the same findings once per region
+func globalModule(module checks.Check) bool {
+	switch module.(type) {
+	case *checks.IAMChecks, *checks.IAMExtendedChecks, *checks.S3Checks,
+		*checks.OrganizationsAdvancedChecks, *checks.Route53Checks,
+		*checks.CloudTrailChecks, *checks.CISManualChecks:
+		return true
+	}
+	return false
+}
+
+// regionalCustomChecks returns the custom checks on regional resources, leaving
+// out S3 bucket checks, which list every bucket whatever the region
+func regionalCustomChecks(defs []checks.CustomCheckDef) []checks.CustomCheckDef {
+	regional := []checks.CustomCheckDef{}
+	for _, def := range defs {
+		if def.Resource != checks.CustomResourceS3Bucket {
+			regional = append(regional, def)
+		}
+	}
+	return regional
+}
+
+// isPCIFramework reports whether framework selects the PCI-DSS modules
+func isPCIFramework(framework string) bool {
+	switch strings.ToLower(framework) {
+	case "pci", "pci-dss":
+		return true
+	}
+	return false
+}
+
+// pciRelevant wraps the general-purpose modules a PCI-DSS scan borrows so they
+// only report results with a PCI-DSS mapping, as AWSScanner.ScanServices does.
+// The PCI-DSS module itself reports everything.
+func pciRelevant(modules []checks.Check) []checks.Check {
+	wrapped := make([]checks.Check, 0, len(modules))
+	for _, module := range modules {
+		if _, ok := module.(*checks.PCIDSSChecks); ok {
+			wrapped = append(wrapped, module)
+			continue
+		}
+		wrapped = append(wrapped, &frameworkModule{Check: module, framework: checks.FrameworkPCI})
+	}
+	return wrapped
+}
+
+// frameworkModule is a check module that drops results not mapped to framework
+type frameworkModule struct {
+	checks.Check
+	framework string
+}
+
+func (m *frameworkModule) Run(ctx context.Context) ([]checks.CheckResult, error) {
+	results, err := m.Check.Run(ctx)
+	mapped := []checks.CheckResult{}
+	for _, result := range results {
+		if result.Frameworks[m.framework] != "" {
+			mapped = append(mapped, result)
+		}
+	}
+	return mapped, err
+}
+
+// NewRunnerFromOptions loads the AWS configuration for options.Profile and
+// options.AssumeRoleARN, validates the credentials and creates a Runner over
+// the resulting clients. Bad credentials fail here with a readable error,
+// unless options.FallbackToCache is set: the Runner is then still returned,
+// and its Run serves the latest cached scan instead.
+func NewRunnerFromOptions(ctx context.Context, options RunnerOptions) (*Runner, error) {
+	clients, err := NewClientSet(ctx, ClientOptions{
+		Profile:           options.Profile,
+		AssumeRoleARN:     options.AssumeRoleARN,
+		MaxCallsPerSecond: options.MaxCallsPerSecond,
+	})
+	if err == nil {
+		err = ValidateCredentials(ctx, clients)
+	}
+	if err != nil {
+		if !options.FallbackToCache {
+			return nil, err
+		}
+		if options.Framework == "" {
+			options.Framework = "soc2"
+		}
+		return &Runner{options: options, liveErr: err}, nil
+	}
+	return NewRunner(clients, options)
+}
+
+// Checks returns the enabled check modules, in run order
+func (r *Runner) Checks() []checks.Check {
+	return append([]checks.Check{}, r.checks...)
+}
+
+// SetChecks replaces the enabled check modules for the client set's region.
+// Additional regions in RunnerOptions.Regions still run the framework's
+// regional modules, built against clients for that region.
+func (r *Runner) SetChecks(modules []checks.Check) {
+	r.checks = modules
+}
+
+// SetSeverityOverrides registers severity overrides (see
+// checks.LoadSeverityOverrides) applied to every check result
+func (r *Runner) SetSeverityOverrides(overrides []checks.SeverityOverride) {
+	r.severityOverrides = overrides
+}
+
+// SetEnvironmentPolicy registers an environment policy (see
+// checks.LoadEnvironmentPolicy) applied to every check result after the
+// severity overrides, so the severity threshold sees the down-weighted rating
+func (r *Runner) SetEnvironmentPolicy(policy checks.EnvironmentPolicy) {
+	r.environmentPolicy = policy
+}
+
+// AddHook registers a hook run around every check module in later scans
+func (r *Runner) AddHook(hook RunnerHook) {
+	r.hooks = append(r.hooks, hook)
+}
+
+// rate applies the severity overrides, then the environment policy, to results
+func (r *Runner) rate(results []checks.CheckResult) {
+	checks.ApplySeverityOverrides(results, r.severityOverrides)
+	checks.ApplyEnvironmentPolicy(results, r.environmentPolicy)
+}
+
+// Run executes the enabled checks in every configured region and returns the
+// scored scan. Module failures do not stop the scan: they are joined into the
+// returned error alongside a scan built from whatever results were produced.
+// Each result's Region is the region it was scanned in, and with more than one
+// region its Service names the region as well. Modules for global services
+// such as IAM and S3 run in the home region only.
+//
+// With FailFast set, the first CRITICAL failure cancels the rest of the scan.
+// If ctx hits its deadline or is cancelled, the checks already done are kept
+// rather than discarded. Either way the partial scan is returned with its
+// metadata marked aborted and listing the services that completed, and the
+// cancellation itself is not reported as an error.
+//
+// With FallbackToCache set, a live scan that fails entirely (no credentials,
+// no network, every module erroring) returns the latest cached scan for the
+// account and framework instead, its metadata marked stale with the cache
+// age. The live failure is only returned if no cached scan can be loaded.
+func (r *Runner) Run(ctx context.Context) (offline.CachedScan, error) {
+	if r.liveErr != nil {
+		return r.fallbackToCache(r.options.AccountID, r.liveErr)
+	}
+	metadata := core.NewScanMetadata(r.options.ToolVersion)
+	metadata.ScanProfile = r.options.ScanProfile
+
+	scanner := NewScannerWithClients(r.clients)
+	accountID := "unknown"
+	identity, identityErr := scanner.DetectIdentity(ctx)
+	if identityErr == nil {
+		accountID = identity.AccountID
+		metadata.SetIdentity(identity)
+	}
+
+	// Each Run records into its own Scan, so Runners in one process can run
+	// at once without sharing settings, timings, errors or memoized calls
+	scanState := checks.NewScan(checks.ScanOptions{
+		DisabledChecks:     r.options.DisabledChecks,
+		Profile:            r.options.ScanProfile,
+		EnvironmentTagKeys: r.environmentPolicy.TagKeys,
+		EvidenceListLimit:  r.options.EvidenceListLimit,
+		AccountID:          identity.AccountID,
+	})
+	ctx = checks.WithScan(ctx, scanState)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var firstCritical *checks.CheckResult
+	var stop func(checks.CheckResult)
+	if r.options.FailFast {
+		var once sync.Once
+		stop = func(result checks.CheckResult) {
+			// Judge the result as reported, after any severity override
+			rated := []checks.CheckResult{result}
+			r.rate(rated)
+			if rated[0].Status != "FAIL" || strings.ToUpper(rated[0].Severity) != "CRITICAL" {
+				return
+			}
+			once.Do(func() {
+				firstCritical = &rated[0]
+				cancel()
+			})
+		}
+	}
+
+	results := []checks.CheckResult{}
+	services := map[string]bool{}
+	completed := &completionTracker{}
+	var failures []error
+
+	homeRegion := r.clients.Config.Region
+	regions, err := resolveRegions(ctx, r.clients, r.options.Regions)
+	if err != nil {
+		// Still scan the home region rather than nothing
+		failures = append(failures, err)
+		regions = []string{homeRegion}
+	}
+	// Plan every region's modules up front so an aborted scan can report how
+	// many of them it completed out of the true total
+	planned := make([][]checks.Check, len(regions))
+	total := 0
+	for i, region := range regions {
+		planned[i] = r.checks
+		if region != homeRegion {
+			cfg := r.clients.Config.Copy()
+			cfg.Region = region
+			planned[i] = regionModules(NewClientSetFromConfig(cfg), r.options, false)
+		}
+		total += len(planned[i])
+	}
+
+	for i, region := range regions {
+		modules := planned[i]
+		// Console deep links follow the region being scanned
+		regionCtx := checks.WithConsoleRegion(ctx, region)
+
+		label := ""
+		if len(regions) > 1 {
+			label = region
+		}
+		regionResults, err := runConcurrently(regionCtx, completed.track(r.hooked(modules), label), r.options.Concurrency, stop)
+		if firstCritical != nil || ctx.Err() != nil {
+			// Cancellation errors are the abort itself, not module failures
+			err = nil
+		}
+		if err != nil {
+			failures = append(failures, fmt.Errorf("%s: %w", region, err))
+		}
+
+		for _, module := range modules {
+			services[module.Name()] = true
+		}
+		for i := range regionResults {
+			regionResults[i].Region = region
+			if identityErr == nil {
+				regionResults[i].AccountID = accountID
+			}
+			if len(regions) > 1 {
+				regionResults[i].Service = fmt.Sprintf("%s (%s)", regionResults[i].Service, region)
+			}
+		}
+		results = append(results, regionResults...)
+
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	r.rate(results)
+	checks.SortResults(results)
+
+	executed := make([]string, 0, len(services))
+	for service := range services {
+		executed = append(executed, service)
+	}
+	metadata.Finish(executed)
+	metadata.SetChecks(scanState.EnabledChecks())
+	metadata.SetTimings(scanState.ServiceTimings(), scanState.CheckTimings())
+	metadata.SetCheckErrors(scanState.CheckErrorCounts())
+	metadata.SetDescribeCacheStats(scanState.DescribeCacheStats())
+	switch {
+	case firstCritical != nil:
+		metadata.Abort(fmt.Sprintf("stopped at first CRITICAL finding (%s: %s) after %d of %d services completed", firstCritical.Control, firstCritical.Name, len(completed.services()), total))
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		metadata.Abort(fmt.Sprintf("scan deadline exceeded after %d of %d services completed", len(completed.services()), total))
+	case ctx.Err() != nil:
+		metadata.Abort(fmt.Sprintf("scan cancelled after %d of %d services completed", len(completed.services()), total))
+	}
+	if metadata.Aborted {
+		metadata.SetCompletedServices(completed.services())
+	}
+
+	scan := buildCachedScan(results, r.options.Framework, accountID, r.options.ToolVersion, metadata)
+	if identityErr == nil {
+		scan.SetIdentity(identity)
+	}
+	scan.SkippedChecks = scanState.SkippedChecks()
+	if r.options.Redact != nil {
+		redactScan(&scan, results, *r.options.Redact)
+	}
+	r.applyThreshold(&scan)
+	if r.options.FallbackToCache && len(results) == 0 && len(failures) > 0 {
+		liveErr := errors.Join(failures...)
+		if identityErr != nil {
+			liveErr = errors.Join(identityErr, liveErr)
+			accountID = r.options.AccountID
+		}
+		return r.fallbackToCache(accountID, liveErr)
+	}
+	return scan, errors.Join(failures...)
+}
+
+// fallbackToCache loads the latest cached scan for accountID and the
+// runner's framework and marks it stale because of liveErr. If there is no
+// cached scan to serve, liveErr is returned with the reason.
+func (r *Runner) fallbackToCache(accountID string, liveErr error) (offline.CachedScan, error) {
+	if accountID == "" || accountID == "unknown" {
+		return offline.CachedScan{}, fmt.Errorf("%w (no cached scan to fall back to: account unknown, set RunnerOptions.AccountID)", liveErr)
+	}
+	if !offline.IsOfflineModeAvailable("aws", accountID, r.options.Framework) {
+		return offline.CachedScan{}, fmt.Errorf("%w (no cached %s scan for account %s to fall back to)", liveErr, r.options.Framework, accountID)
+	}
+
+	cache, err := offline.NewCache()
+	if err != nil {
+		return offline.CachedScan{}, fmt.Errorf("%w (cache unavailable: %v)", liveErr, err)
+	}
+	cached, err := cache.LoadLatest("aws", accountID, r.options.Framework)
+	if err != nil {
+		return offline.CachedScan{}, fmt.Errorf("%w (cached scan unreadable: %v)", liveErr, err)
+	}
+	scan := *cached
+	metadata := core.NewScanMetadata(r.options.ToolVersion)
+	if scan.Metadata != nil {
+		copied := *scan.Metadata
+		metadata = &copied
+	}
+	metadata.MarkStale(liveErr.Error(), time.Since(scan.Timestamp))
+	scan.Metadata = metadata
+	return scan, nil
+}
+
+// redactScan rebuilds scan's controls from results with identifiers masked,
+// and masks its account and identity with the same tokens
+func redactScan(scan *offline.CachedScan, results []checks.CheckResult, options checks.RedactOptions) {
+	redactor := checks.NewRedactor(options)
+	redacted := buildCachedScan(redactor.Results(results), scan.Framework, redactor.String(scan.AccountID), scan.Version, scan.Metadata)
+	redacted.Provider = scan.Provider
+	redacted.SkippedChecks = scan.SkippedChecks
+
+	if metadata := redacted.Metadata; metadata != nil {
+		metadata.CallerIdentity = redactor.String(metadata.CallerIdentity)
+		if metadata.Identity != nil {
+			identity := *metadata.Identity
+			identity.AccountID = redactor.String(identity.AccountID)
+			identity.Principal = redactor.String(identity.Principal)
+			metadata.Identity = &identity
+		}
+	}
+	*scan = redacted
+}
+
+// completionTracker records which check modules ran to completion, so a scan
+// cut short by FailFast, its deadline or cancellation can report exactly which
+// services it fully covered
+type completionTracker struct {
+	mu   sync.Mutex
+	done []string
+}
+
+// track wraps modules so each one that returns before ctx is done is recorded,
+// as "Name (label)" when label is set
+func (t *completionTracker) track(modules []checks.Check, label string) []checks.Check {
+	tracked := make([]checks.Check, 0, len(modules))
+	for _, module := range modules {
+		tracked = append(tracked, &trackedModule{Check: module, tracker: t, label: label})
+	}
+	return tracked
+}
+
+// services returns the completed modules
+func (t *completionTracker) services() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]string{}, t.done...)
+}
+
+// trackedModule is a check module that reports completion to its tracker
+type trackedModule struct {
+	checks.Check
+	tracker *completionTracker
+	label   string
+}
+
+func (m *trackedModule) Run(ctx context.Context) ([]checks.CheckResult, error) {
+	results, err := m.Check.Run(ctx)
+	// A module that returns after ctx is done may have skipped checks
+	if ctx.Err() == nil {
+		name := m.Name()
+		if m.label != "" {
+			name = fmt.Sprintf("%s (%s)", name, m.label)
+		}
+		m.tracker.mu.Lock()
+		m.tracker.done = append(m.tracker.done, name)
+		m.tracker.mu.Unlock()
+	}
+	return results, err
+}
+
+// hooked wraps modules so the registered hooks run around each one
+func (r *Runner) hooked(modules []checks.Check) []checks.Check {
+	if len(r.hooks) == 0 {
+		return modules
+	}
+	wrapped := make([]checks.Check, 0, len(modules))
+	for _, module := range modules {
+		wrapped = append(wrapped, &hookedModule{Check: module, hooks: r.hooks})
+	}
+	return wrapped
+}
+
+// hookedModule is a check module that calls hooks around its run
+type hookedModule struct {
+	checks.Check
+	hooks []RunnerHook
+}
+
+func (m *hookedModule) Run(ctx context.Context) ([]checks.CheckResult, error) {
+	for _, hook := range m.hooks {
+		if hook.Before != nil {
+			hook.Before(ctx, m.Check)
+		}
+	}
+
+	results, err := m.Check.Run(ctx)
+	// Hooks see results as the collector will report them
+	for i := range results {
+		if results[i].Service == "" {
+			results[i].Service = m.Name()
+		}
+	}
+
+	for _, hook := range m.hooks {
+		if hook.After != nil {
+			hook.After(ctx, m.Check, results, err)
+		}
+	}
+	return results, err
+}
+
+// applyThreshold hides failing controls below the severity threshold from
+// scan. It runs after the scan is scored, so the score and pass/fail counts
+// still include the suppressed findings.
+func (r *Runner) applyThreshold(scan *offline.CachedScan) {
+	if r.options.SeverityThreshold == "" {
+		return
+	}
+	minimum := severityRank[r.options.SeverityThreshold]
+
+	kept := []offline.CachedControl{}
+	for _, control := range scan.Controls {
+		if control.Status == "FAIL" && severityRank[strings.ToUpper(control.Severity)] < minimum {
+			continue
+		}
+		kept = append(kept, control)
+	}
+	scan.Controls = kept
+}
+
+// runConcurrently runs modules with at most concurrency in flight and returns
+// their results sorted, with module failures joined into the error. onResult,
+// if not nil, is called with each result as soon as it arrives.
+func runConcurrently(ctx context.Context, modules []checks.Check, concurrency int, onResult func(checks.CheckResult)) ([]checks.CheckResult, error) {
+	if concurrency <= 1 {
+		return collectModules(ctx, modules, onResult)
+	}
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		results  []checks.CheckResult
+		failures []error
+	)
+	slots := make(chan struct{}, concurrency)
+
+	for _, module := range modules {
+		wg.Add(1)
+		go func(module checks.Check) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			moduleResults, err := collectModules(ctx, []checks.Check{module}, onResult)
+
+			mu.Lock()
+			defer mu.Unlock()
+			results = append(results, moduleResults...)
+			if err != nil {
+				failures = append(failures, err)
+			}
+		}(module)
+	}
+	wg.Wait()
+
+	checks.SortResults(results)
+	return results, errors.Join(failures...)
+}
+
+// collectModules is checks.RunModules, calling onResult with each result as it
+// streams in rather than once the modules have finished
+func collectModules(ctx context.Context, modules []checks.Check, onResult func(checks.CheckResult)) ([]checks.CheckResult, error) {
+	if onResult == nil {
+		return checks.RunModules(ctx, modules, nil)
+	}
+
+	stream, errs := checks.RunStream(ctx, modules, nil)
+	collected := []checks.CheckResult{}
+	for result := range stream {
+		collected = append(collected, result)
+		onResult(result)
+	}
+
+	var failures []error
+	for err := range errs {
+		failures = append(failures, err)
+	}
+
+	checks.SortResults(collected)
+	return collected, errors.Join(failures...)
+}
+
+// buildCachedScan scores results the way the CLI does, passed over passed plus
+// failed, and converts them to the cached scan format
+func buildCachedScan(results []checks.CheckResult, framework, accountID, version string, metadata *core.ScanMetadata) offline.CachedScan {
+	controls := []offline.CachedControl{}
+	passed, failed := 0, 0
+
+	for _, result := range results {
+		switch result.Status {
+		case "PASS":
+			passed++
+		case "FAIL":
+			failed++
+		}
+
+		controls = append(controls, offline.CachedControl{
+			ID:                result.Control,
+			Name:              result.Name,
+			Category:          result.Service,
+			Region:            result.Region,
+			Severity:          result.Severity,
+			OriginalSeverity:  result.OriginalSeverity,
+			Environment:       result.Environment,
+			FindingID:         result.FindingID(),
+			Status:            result.Status,
+			Evidence:          result.Evidence,
+			AffectedResources: result.AffectedResources,
+			Remediation:       result.Remediation,
+			RemediationDetail: result.RemediationDetail,
+			RequiresRecreate:  result.RequiresRecreate,
+			Priority:          result.Priority.Level,
+			Impact:            result.Priority.Impact,
+			ScreenshotGuide:   result.ScreenshotGuide,
+			ConsoleURL:        result.ConsoleURL,
+			Frameworks:        result.Frameworks,
+		})
+	}
+
+	score := 0.0
+	if passed+failed > 0 {
+		score = float64(passed) / float64(passed+failed) * 100
+	}
+
+	return offline.CachedScan{
+		Timestamp:       metadata.EndTime,
+		Provider:        "aws",
+		Framework:       framework,
+		AccountID:       accountID,
+		Score:           score,
+		TotalControls:   len(controls),
+		PassedControls:  passed,
+		FailedControls:  failed,
+		Controls:        controls,
+		Recommendations: []string{},
+		Version:         version,
+		Metadata:        metadata,
+	}
+}
@@ -0,0 +1,114 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/guardian-nexus/auditkit/scanner/pkg/aws/checks"
+)
+
+func TestRegionModulesRunGlobalModulesInHomeRegionOnly(t *testing.T) {
+	clients := NewStubClientSet(stubResponder(SmokeEmpty))
+	options := RunnerOptions{
+		Framework: "cis-aws",
+		CustomChecks: []checks.CustomCheckDef{
+			{ID: "ORG-1", Resource: checks.CustomResourceS3Bucket},
+			{ID: "ORG-2", Resource: checks.CustomResourceRedshiftCluster},
+		},
+	}
+
+	home := regionModules(clients, options, true)
+	globals := 0
+	for _, module := range home {
+		if globalModule(module) {
+			globals++
+		}
+	}
+	if globals == 0 {
+		t.Fatal("home region runs no global modules")
+	}
+
+	for _, module := range regionModules(clients, options, false) {
+		if globalModule(module) {
+			t.Errorf("%s runs outside the home region", module.Name())
+		}
+		if custom, ok := module.(*checks.CustomChecks); ok {
+			results, _ := custom.Run(context.Background())
+			if len(results) != 1 || results[0].Control != "ORG-2" {
+				t.Errorf("custom checks outside the home region = %v, want only the Redshift check", results)
+			}
+		}
+	}
+}
+
+// staticModule is a check module that returns fixed results, after delay
+type staticModule struct {
+	name    string
+	results []checks.CheckResult
+	delay   time.Duration
+}
+
+func (m staticModule) Name() string { return m.name }
+
+func (m staticModule) Run(ctx context.Context) ([]checks.CheckResult, error) {
+	time.Sleep(m.delay)
+	return append([]checks.CheckResult{}, m.results...), nil
+}
+
+// Parallel modules finish in a different order every run; the collected
+// results must not
+func TestRunConcurrentlyIsDeterministic(t *testing.T) {
+	modules := func(run int) []checks.Check {
+		names := []string{"IAM Security", "S3 Security", "IAM Security", "EC2 Security", "Redshift Security", "S3 Security"}
+		built := make([]checks.Check, len(names))
+		for i, name := range names {
+			built[i] = staticModule{
+				name:  name,
+				delay: time.Duration((i*7+run*3)%5) * time.Millisecond,
+				results: []checks.CheckResult{
+					// Modules sharing a name report the same controls
+					{Control: "CC6.1", Name: "Access Review", Status: "FAIL", Evidence: fmt.Sprintf("module %d", i)},
+					{Control: "CC6.1", Name: "Access Review", Status: "PASS", Evidence: fmt.Sprintf("module %d passed", i)},
+					{Control: "CC7.2", Name: "Logging", Status: "PASS", Evidence: fmt.Sprintf("module %d logs", i)},
+				},
+			}
+		}
+		return built
+	}
+
+	want, err := runConcurrently(context.Background(), modules(0), 4, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for run := 1; run < 20; run++ {
+		got, err := runConcurrently(context.Background(), modules(run), 4, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("run %d collected results in a different order than run 0", run)
+		}
+	}
+}
+
+func TestPCIRelevantKeepsOnlyPCIMappedResults(t *testing.T) {
+	borrowed := staticModule{name: "IAM Security", results: []checks.CheckResult{
+		{Control: "CC6.1", Name: "Root MFA", Status: "PASS", Frameworks: map[string]string{checks.FrameworkSOC2: "CC6.1", checks.FrameworkPCI: "8.4.1"}},
+		{Control: "CC6.2", Name: "Access Keys", Status: "FAIL", Frameworks: map[string]string{checks.FrameworkSOC2: "CC6.2"}},
+	}}
+
+	modules := pciRelevant([]checks.Check{borrowed})
+	results, err := modules[0].Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].Name != "Root MFA" {
+		t.Errorf("PCI scan reported %v, want only the PCI-mapped result", results)
+	}
+	if modules[0].Name() != borrowed.Name() {
+		t.Errorf("wrapped module named %q, want %q", modules[0].Name(), borrowed.Name())
+	}
+}
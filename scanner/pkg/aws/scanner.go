@@ -3,171 +3,262 @@ package aws
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
-	"github.com/aws/aws-sdk-go-v2/service/accessanalyzer"
-	"github.com/aws/aws-sdk-go-v2/service/acm"
-	"github.com/aws/aws-sdk-go-v2/service/apigateway"
-	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
-	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
-	"github.com/aws/aws-sdk-go-v2/service/backup"
-	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
-	"github.com/aws/aws-sdk-go-v2/service/cloudtrail"
-	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
-	"github.com/aws/aws-sdk-go-v2/service/configservice"
-	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
-	"github.com/aws/aws-sdk-go-v2/service/ec2"
-	"github.com/aws/aws-sdk-go-v2/service/ecr"
-	"github.com/aws/aws-sdk-go-v2/service/ecs"
-	"github.com/aws/aws-sdk-go-v2/service/eks"
-	"github.com/aws/aws-sdk-go-v2/service/elasticache"
-	"github.com/aws/aws-sdk-go-v2/service/elasticbeanstalk"
-	"github.com/aws/aws-sdk-go-v2/service/guardduty"
-	"github.com/aws/aws-sdk-go-v2/service/iam"
-	"github.com/aws/aws-sdk-go-v2/service/inspector2"
-	"github.com/aws/aws-sdk-go-v2/service/kms"
-	"github.com/aws/aws-sdk-go-v2/service/lambda"
-	"github.com/aws/aws-sdk-go-v2/service/macie2"
-	"github.com/aws/aws-sdk-go-v2/service/networkfirewall"
-	"github.com/aws/aws-sdk-go-v2/service/opensearch"
-	"github.com/aws/aws-sdk-go-v2/service/organizations"
-	"github.com/aws/aws-sdk-go-v2/service/rds"
-	"github.com/aws/aws-sdk-go-v2/service/redshift"
-	"github.com/aws/aws-sdk-go-v2/service/sagemaker"
-	"github.com/aws/aws-sdk-go-v2/service/route53"
-	"github.com/aws/aws-sdk-go-v2/service/s3"
-	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
-	"github.com/aws/aws-sdk-go-v2/service/securityhub"
-	"github.com/aws/aws-sdk-go-v2/service/sns"
-	"github.com/aws/aws-sdk-go-v2/service/sqs"
-	"github.com/aws/aws-sdk-go-v2/service/ssm"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
 	"github.com/guardian-nexus/auditkit/scanner/pkg/aws/checks"
+	"github.com/guardian-nexus/auditkit/scanner/pkg/core"
+	"github.com/guardian-nexus/auditkit/scanner/pkg/evidence"
 )
 
 type AWSScanner struct {
-	cfg                 aws.Config
-	s3Client            *s3.Client
-	iamClient           *iam.Client
-	ec2Client           *ec2.Client
-	ctClient            *cloudtrail.Client
-	stsClient           *sts.Client
-	configClient        *configservice.Client
-	gdClient            *guardduty.Client
-	shClient            *securityhub.Client
-	rdsClient           *rds.Client
-	cwClient            *cloudwatch.Client
-	snsClient           *sns.Client
-	ssmClient           *ssm.Client
-	asClient            *autoscaling.Client
-	orgClient           *organizations.Client
-	inspector2Client    *inspector2.Client
-	backupClient        *backup.Client
-	kmsClient           *kms.Client
-	lambdaClient        *lambda.Client
-	ecsClient           *ecs.Client
-	eksClient           *eks.Client
-	macieClient         *macie2.Client
-	nfwClient           *networkfirewall.Client
-	route53Client       *route53.Client
-	accessAnalyzerClient *accessanalyzer.Client
-	sqsClient            *sqs.Client
-	apigwClient          *apigateway.Client
-	apigwv2Client        *apigatewayv2.Client
-	beanstalkClient      *elasticbeanstalk.Client
-	secretsManagerClient *secretsmanager.Client
-	ecrClient            *ecr.Client
-	dynamodbClient       *dynamodb.Client
-	cloudFormationClient *cloudformation.Client
-	acmClient            *acm.Client
-	// New services (January 2026)
-	sagemakerClient   *sagemaker.Client
-	redshiftClient    *redshift.Client
-	elasticacheClient *elasticache.Client
-	opensearchClient  *opensearch.Client
+	// clients are the service clients checks run against
+	clients *ClientSet
+
+	// progress is called after each check module completes (optional)
+	progress ProgressFunc
+
+	// customChecks are org-specific YAML checks run alongside the framework
+	customChecks []checks.CustomCheckDef
+
+	// severityOverrides re-rate check severities after they run
+	severityOverrides []checks.SeverityOverride
+
+	// environmentPolicy classifies results by environment and down-weights
+	// non-production failures, after the severity overrides
+	environmentPolicy checks.EnvironmentPolicy
+
+	// identity is the detected caller identity, memoized by DetectIdentity
+	identity *core.Identity
+
+	// executed records the check modules that ran, for scan metadata
+	executed map[string]bool
+
+	// resourceFilter narrows the scan to named resources when set
+	resourceFilter checks.ResourceFilter
+
+	// disabledChecks are check IDs not to run (see checks.LoadCheckConfig)
+	disabledChecks []string
+
+	// scan holds the latest run's settings and what its checks recorded,
+	// carried to the checks on the context (see checks.WithScan)
+	scan *checks.Scan
 }
 
+// ProgressFunc receives the name of the check module that just finished and
+// how many of the current run's modules are done
+type ProgressFunc func(service string, done, total int)
+
 type ScanResult struct {
 	Control           string
+	Region            string // region scanned, when the Runner recorded it
 	Status            string
 	Evidence          string
+	AffectedResources []string // every failing resource; Evidence may list only some
 	Remediation       string
 	RemediationDetail string
+	RequiresRecreate  bool
 	Severity          string
+	OriginalSeverity  string // Check's own severity when overridden
+	Environment       string // prod, staging or dev (see checks.EnvironmentPolicy)
+	FindingID         string // stable across scans (see checks.CheckResult.FindingID)
 	ScreenshotGuide   string
+	EvidenceSteps     *evidence.Checklist
 	ConsoleURL        string
 	Frameworks        map[string]string
 }
 
+// toScanResult converts a check result to the ScanResult the framework runs
+// return, scoped to the scanner's region and account
+func (s *AWSScanner) toScanResult(cr checks.CheckResult) ScanResult {
+	cr = s.scoped(cr)
+	return ScanResult{
+		Control:           cr.Control,
+		Region:            cr.Region,
+		Status:            cr.Status,
+		Evidence:          cr.Evidence,
+		AffectedResources: cr.AffectedResources,
+		Remediation:       cr.Remediation,
+		RemediationDetail: cr.RemediationDetail,
+		RequiresRecreate:  cr.RequiresRecreate,
+		Severity:          cr.Severity,
+		OriginalSeverity:  cr.OriginalSeverity,
+		Environment:       cr.Environment,
+		FindingID:         cr.FindingID(),
+		ScreenshotGuide:   cr.ScreenshotGuide,
+		EvidenceSteps:     cr.EvidenceSteps,
+		ConsoleURL:        cr.ConsoleURL,
+		Frameworks:        cr.Frameworks,
+	}
+}
+
+// scoped fills in the region and account a result was scanned in, which the
+// Runner sets on its own results, so FindingIDs match across both paths
+func (s *AWSScanner) scoped(cr checks.CheckResult) checks.CheckResult {
+	if cr.Region == "" {
+		cr.Region = s.clients.Config.Region
+	}
+	if cr.AccountID == "" && s.identity != nil {
+		cr.AccountID = s.identity.AccountID
+	}
+	return cr
+}
+
 func NewScanner(profile string) (*AWSScanner, error) {
-	cfg, err := config.LoadDefaultConfig(context.TODO(),
-		config.WithSharedConfigProfile(profile),
-	)
+	clients, err := NewClientSet(context.TODO(), ClientOptions{Profile: profile})
 	if err != nil {
-		return nil, fmt.Errorf("failed to load AWS config: %v", err)
+		return nil, err
 	}
 
-	return NewScannerWithConfig(cfg)
+	return NewScannerWithClients(clients), nil
 }
 
 // NewScannerWithConfig creates an AWS scanner with a pre-configured aws.Config
 // This is useful for cross-account scanning with assumed role credentials
 func NewScannerWithConfig(cfg aws.Config) (*AWSScanner, error) {
-	return &AWSScanner{
-		cfg:                  cfg,
-		s3Client:             s3.NewFromConfig(cfg),
-		iamClient:            iam.NewFromConfig(cfg),
-		ec2Client:            ec2.NewFromConfig(cfg),
-		ctClient:             cloudtrail.NewFromConfig(cfg),
-		stsClient:            sts.NewFromConfig(cfg),
-		configClient:         configservice.NewFromConfig(cfg),
-		gdClient:             guardduty.NewFromConfig(cfg),
-		shClient:             securityhub.NewFromConfig(cfg),
-		rdsClient:            rds.NewFromConfig(cfg),
-		cwClient:             cloudwatch.NewFromConfig(cfg),
-		snsClient:            sns.NewFromConfig(cfg),
-		ssmClient:            ssm.NewFromConfig(cfg),
-		asClient:             autoscaling.NewFromConfig(cfg),
-		orgClient:            organizations.NewFromConfig(cfg),
-		inspector2Client:     inspector2.NewFromConfig(cfg),
-		backupClient:         backup.NewFromConfig(cfg),
-		kmsClient:            kms.NewFromConfig(cfg),
-		lambdaClient:         lambda.NewFromConfig(cfg),
-		ecsClient:            ecs.NewFromConfig(cfg),
-		eksClient:            eks.NewFromConfig(cfg),
-		macieClient:          macie2.NewFromConfig(cfg),
-		nfwClient:            networkfirewall.NewFromConfig(cfg),
-		route53Client:        route53.NewFromConfig(cfg),
-		accessAnalyzerClient: accessanalyzer.NewFromConfig(cfg),
-		sqsClient:            sqs.NewFromConfig(cfg),
-		apigwClient:          apigateway.NewFromConfig(cfg),
-		apigwv2Client:        apigatewayv2.NewFromConfig(cfg),
-		beanstalkClient:      elasticbeanstalk.NewFromConfig(cfg),
-		secretsManagerClient: secretsmanager.NewFromConfig(cfg),
-		ecrClient:            ecr.NewFromConfig(cfg),
-		dynamodbClient:       dynamodb.NewFromConfig(cfg),
-		cloudFormationClient: cloudformation.NewFromConfig(cfg),
-		acmClient:            acm.NewFromConfig(cfg),
-		// New services (January 2026)
-		sagemakerClient:   sagemaker.NewFromConfig(cfg),
-		redshiftClient:    redshift.NewFromConfig(cfg),
-		elasticacheClient: elasticache.NewFromConfig(cfg),
-		opensearchClient:  opensearch.NewFromConfig(cfg),
-	}, nil
+	return NewScannerWithClients(NewClientSetFromConfig(cfg)), nil
 }
 
-func (s *AWSScanner) GetAccountID(ctx context.Context) string {
-	identity, err := s.stsClient.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+// NewScannerWithClients creates an AWS scanner over an existing ClientSet
+func NewScannerWithClients(clients *ClientSet) *AWSScanner {
+	return &AWSScanner{clients: clients}
+}
+
+// SetProgressFunc registers a callback used to drive progress output while
+// check modules run. Pass nil to disable.
+func (s *AWSScanner) SetProgressFunc(fn ProgressFunc) {
+	s.progress = fn
+}
+
+// SetCustomChecks registers YAML-defined checks (see checks.LoadCustomChecks)
+// to run with every framework
+func (s *AWSScanner) SetCustomChecks(defs []checks.CustomCheckDef) {
+	s.customChecks = defs
+}
+
+// SetDisabledChecks turns off individual checks by ID (see
+// checks.LoadCheckConfig) in later runs. SkippedChecks lists the ones skipped.
+func (s *AWSScanner) SetDisabledChecks(ids []string) {
+	s.disabledChecks = ids
+}
+
+// SkippedChecks returns the disabled checks the latest run skipped, sorted
+func (s *AWSScanner) SkippedChecks() []string {
+	if s.scan == nil {
+		return []string{}
+	}
+	return s.scan.SkippedChecks()
+}
+
+// startScan begins a run with fresh per-scan state and returns ctx carrying
+// it, pointed at the scanner's region for console deep links
+func (s *AWSScanner) startScan(ctx context.Context) context.Context {
+	s.executed = map[string]bool{}
+	s.scan = checks.NewScan(checks.ScanOptions{
+		DisabledChecks:     s.disabledChecks,
+		EnvironmentTagKeys: s.environmentPolicy.TagKeys,
+	})
+	ctx = checks.WithScan(ctx, s.scan)
+	return checks.WithConsoleRegion(ctx, s.clients.Config.Region)
+}
+
+// SetResourceFilter narrows scans to the named resources (see
+// checks.ApplyResourceFilter). Only the modules for those resources run;
+// custom checks and modules that cannot be filtered are skipped.
+func (s *AWSScanner) SetResourceFilter(filter checks.ResourceFilter) {
+	s.resourceFilter = filter
+}
+
+// SetSeverityOverrides registers severity overrides (see
+// checks.LoadSeverityOverrides) applied to every check result
+func (s *AWSScanner) SetSeverityOverrides(overrides []checks.SeverityOverride) {
+	s.severityOverrides = overrides
+}
+
+// SetEnvironmentPolicy registers an environment policy (see
+// checks.LoadEnvironmentPolicy) applied to every check result after the
+// severity overrides
+func (s *AWSScanner) SetEnvironmentPolicy(policy checks.EnvironmentPolicy) {
+	s.environmentPolicy = policy
+}
+
+// rate applies the severity overrides, then the environment policy, to results
+func (s *AWSScanner) rate(results []checks.CheckResult) {
+	checks.ApplySeverityOverrides(results, s.severityOverrides)
+	checks.ApplyEnvironmentPolicy(results, s.environmentPolicy)
+}
+
+func (s *AWSScanner) reportProgress(service string, done, total int) {
+	s.markExecuted(service)
+	if s.progress != nil {
+		s.progress(service, done, total)
+	}
+}
+
+func (s *AWSScanner) markExecuted(service string) {
+	if s.executed == nil {
+		s.executed = map[string]bool{}
+	}
+	s.executed[service] = true
+}
+
+// ExecutedServices returns the check modules run by the last ScanServices
+// call, sorted
+func (s *AWSScanner) ExecutedServices() []string {
+	services := make([]string, 0, len(s.executed))
+	for service := range s.executed {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+	return services
+}
+
+// GetCallerIdentity returns the ARN of the principal running the scan
+func (s *AWSScanner) GetCallerIdentity(ctx context.Context) string {
+	identity, err := s.DetectIdentity(ctx)
+	if err != nil || identity.Principal == "" {
+		return "unknown"
+	}
+	return identity.Principal
+}
+
+// DetectIdentity resolves the account, caller ARN and partition from STS
+// GetCallerIdentity. The result is memoized for the scanner's lifetime.
+func (s *AWSScanner) DetectIdentity(ctx context.Context) (core.Identity, error) {
+	if s.identity != nil {
+		return *s.identity, nil
+	}
+
+	output, err := s.clients.STS.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
 	if err != nil {
+		return core.Identity{}, fmt.Errorf("failed to detect AWS identity: %w", err)
+	}
+
+	principal := aws.ToString(output.Arn)
+	identity := core.Identity{
+		Provider:  "aws",
+		AccountID: aws.ToString(output.Account),
+		Principal: principal,
+		Partition: core.PartitionFromARN(principal),
+	}
+	s.identity = &identity
+	return identity, nil
+}
+
+func (s *AWSScanner) GetAccountID(ctx context.Context) string {
+	identity, err := s.DetectIdentity(ctx)
+	if err != nil || identity.AccountID == "" {
 		return "unknown"
 	}
-	return *identity.Account
+	return identity.AccountID
 }
 
 func (s *AWSScanner) ScanServices(ctx context.Context, services []string, verbose bool, framework string) ([]ScanResult, error) {
-	_, err := s.stsClient.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	// Console deep links must point at the partition being scanned
+	ctx = s.startScan(ctx)
+	_, err := s.DetectIdentity(ctx)
 	if err != nil {
 		if verbose {
 			fmt.Println("Error: Not connected to AWS. Please configure AWS credentials.")
@@ -196,9 +287,160 @@ func (s *AWSScanner) ScanServices(ctx context.Context, services []string, verbos
 		results = append(results, s.runSOC2Checks(ctx, verbose)...)
 	}
 
+	if len(s.customChecks) > 0 && s.resourceFilter.IsEmpty() {
+		if verbose {
+			fmt.Printf("  Running %d custom checks...\n", len(s.customChecks))
+		}
+		custom := checks.NewCustomChecks(s.customChecks, s.clients.Redshift, s.clients.S3)
+		customResults, _ := custom.Run(ctx)
+		s.rate(customResults)
+		s.markExecuted(custom.Name())
+		for _, cr := range customResults {
+			results = append(results, s.toScanResult(cr))
+		}
+	}
+
 	return results, nil
 }
 
+// RunStream runs the check modules for framework, then any custom checks, and
+// sends each result as soon as its module finishes, with severity overrides
+// and the environment policy applied. Results are raw CheckResults in module order, not the
+// framework-labelled ScanResults ScanServices returns. See checks.RunStream
+// for the channel semantics; ExecutedServices is complete once both channels
+// are closed.
+func (s *AWSScanner) RunStream(ctx context.Context, framework string) (<-chan checks.CheckResult, <-chan error) {
+	ctx = s.startScan(ctx)
+	modules := s.modulesForFramework(framework)
+	if len(s.customChecks) > 0 && s.resourceFilter.IsEmpty() {
+		modules = append(modules, checks.NewCustomChecks(s.customChecks, s.clients.Redshift, s.clients.S3))
+	}
+
+	stream, errs := checks.RunStream(ctx, modules, s.reportProgress)
+	out := make(chan checks.CheckResult)
+	go func() {
+		defer close(out)
+		for r := range stream {
+			result := []checks.CheckResult{s.scoped(r)}
+			s.rate(result)
+			select {
+			case out <- result[0]:
+			case <-ctx.Done():
+			}
+		}
+	}()
+	return out, errs
+}
+
+// runModules runs modules through checks.RunModules, reporting progress and,
+// when verbose, each module as it finishes, any module failures and any
+// results checks.ValidateResults flags. Results come back sorted with
+// severity overrides and the environment policy applied.
+func (s *AWSScanner) runModules(ctx context.Context, modules []checks.Check, verbose bool) []checks.CheckResult {
+	results, err := checks.RunModules(ctx, modules, func(service string, done, total int) {
+		if verbose {
+			fmt.Printf("  Ran %s (%d/%d)\n", service, done, total)
+		}
+		s.reportProgress(service, done, total)
+	})
+	if err != nil && verbose {
+		fmt.Printf("    Warning: %v\n", strings.ReplaceAll(err.Error(), "\n", "\n    Warning: "))
+	}
+	if verbose {
+		for _, issue := range checks.ValidateResults(results) {
+			fmt.Printf("    Warning: malformed result: %v\n", issue)
+		}
+	}
+
+	s.rate(results)
+	return results
+}
+
+// modulesForFramework returns the check modules ScanServices would run for
+// framework, in run order, narrowed by the resource filter
+func (s *AWSScanner) modulesForFramework(framework string) []checks.Check {
+	return checks.ApplyResourceFilter(s.frameworkModules(framework), s.resourceFilter)
+}
+
+func (s *AWSScanner) frameworkModules(framework string) []checks.Check {
+	pciModules := []checks.Check{
+		checks.NewPCIDSSChecks(s.clients.IAM, s.clients.EC2, s.clients.S3, s.clients.CloudTrail, s.clients.ConfigService),
+		checks.NewIAMChecks(s.clients.IAM),
+		checks.NewS3Checks(s.clients.S3),
+		checks.NewEC2Checks(s.clients.EC2),
+		checks.NewCloudTrailChecks(s.clients.CloudTrail),
+	}
+	cmmcModules := []checks.Check{
+		checks.NewAWSCMMCLevel1Checks(s.clients.IAM, s.clients.S3, s.clients.EC2, s.clients.CloudTrail),
+	}
+
+	switch strings.ToLower(framework) {
+	case "pci", "pci-dss":
+		return pciModules
+	case "cmmc":
+		return cmmcModules
+	case "cis", "cis-aws":
+		return s.cisModules()
+	case "all":
+		modules := s.soc2Modules()
+		modules = append(modules, pciModules...)
+		modules = append(modules, cmmcModules...)
+		return append(modules, s.cisModules()...)
+	default:
+		return s.soc2Modules()
+	}
+}
+
+// EstimateCalls predicts the AWS API calls a scan of framework would make,
+// per check module, without running any checks. "all" runs some modules under
+// several frameworks, so they appear once per framework.
+func (s *AWSScanner) EstimateCalls(ctx context.Context, framework string) []checks.CallEstimate {
+	return checks.EstimateModules(ctx, s.modulesForFramework(framework))
+}
+
+// cisModules returns the check modules run for CIS AWS
+func (s *AWSScanner) cisModules() []checks.Check {
+	return []checks.Check{
+		checks.NewIAMChecks(s.clients.IAM),
+		checks.NewS3Checks(s.clients.S3),
+		checks.NewEC2Checks(s.clients.EC2),
+		checks.NewCloudTrailChecks(s.clients.CloudTrail),
+		checks.NewConfigChecks(s.clients.ConfigService),
+		checks.NewRDSChecks(s.clients.RDS),
+		checks.NewVPCChecks(s.clients.EC2),
+		checks.NewNetworkFirewallChecks(s.clients.NetworkFirewall, s.clients.EC2),
+		checks.NewLambdaChecks(s.clients.Lambda),
+		checks.NewECSChecks(s.clients.ECS),
+		checks.NewEKSChecks(s.clients.EKS),
+		checks.NewRoute53Checks(s.clients.Route53),
+		checks.NewAccessAnalyzerChecks(s.clients.AccessAnalyzer, s.clients.Config.Region),
+		checks.NewSecurityServicesChecks(s.clients.GuardDuty, s.clients.Macie, s.clients.SecurityHub, s.clients.Inspector2),
+		checks.NewMonitoringChecks(s.clients.CloudWatch, s.clients.SNS, s.clients.SecurityHub), // Add monitoring checks (CIS 4.16)
+		checks.NewCISManualChecks(),                                                            // Add manual CIS controls (Section 4)
+		// Section 10 - Additional Services
+		checks.NewSSMChecks(s.clients.SSM),                                       // CIS 10.1-10.3
+		checks.NewBeanstalkChecks(s.clients.Beanstalk),                           // CIS 10.4-10.6
+		checks.NewAPIGatewayChecks(s.clients.APIGateway, s.clients.APIGatewayV2), // CIS 10.7-10.9
+		checks.NewBackupVaultChecks(s.clients.Backup),                            // CIS 10.10-10.12
+		checks.NewMessagingChecks(s.clients.SNS, s.clients.SQS),                  // CIS 10.13-10.15
+		// Sections 11-18 - Extended Coverage for 100%
+		checks.NewOrganizationsAdvancedChecks(s.clients.Organizations, s.clients.CloudTrail), // CIS 11.1-11.4
+		checks.NewSecretsManagerChecks(s.clients.SecretsManager),                             // CIS 12.1-12.3
+		checks.NewECRChecks(s.clients.ECR),                                                   // CIS 13.1-13.3
+		checks.NewDynamoDBChecks(s.clients.DynamoDB),                                         // CIS 14.1-14.3
+		checks.NewCloudFormationChecks(s.clients.CloudFormation),                             // CIS 15.1-15.2
+		checks.NewACMChecks(s.clients.ACM),                                                   // CIS 16.1-16.2
+		checks.NewIAMExtendedChecks(s.clients.IAM),                                           // CIS 17.1-17.2
+		checks.NewAuroraChecks(s.clients.RDS),                                                // CIS 18.1
+		// Sections 19-22 - Data Analytics & ML Services (January 2026)
+		checks.NewSageMakerChecks(s.clients.SageMaker, s.clients.IAM),                           // CIS 19.1-19.7
+		checks.NewRedshiftChecks(s.clients.Redshift, s.clients.IAM, s.clients.S3, s.clients.EC2), // CIS 20.1-20.10
+		checks.NewRedshiftServerlessChecks(s.clients.RedshiftServerless),                         // CIS 20.1-20.2 (serverless)
+		checks.NewElastiCacheChecks(s.clients.ElastiCache, s.clients.EC2),                        // CIS 21.1-21.7
+		checks.NewOpenSearchChecks(s.clients.OpenSearch, s.clients.ACM),                          // CIS 22.1-22.9
+	}
+}
+
 func (s *AWSScanner) runCISChecks(ctx context.Context, verbose bool) []ScanResult {
 	var results []ScanResult
 	
@@ -209,135 +451,80 @@ func (s *AWSScanner) runCISChecks(ctx context.Context, verbose bool) []ScanResul
 	}
 	
 	// Run existing AWS check modules - they return results with Frameworks map
-	checkModules := []checks.Check{
-		checks.NewIAMChecks(s.iamClient),
-		checks.NewS3Checks(s.s3Client),
-		checks.NewEC2Checks(s.ec2Client),
-		checks.NewCloudTrailChecks(s.ctClient),
-		checks.NewConfigChecks(s.configClient),
-		checks.NewRDSChecks(s.rdsClient),
-		checks.NewVPCChecks(s.ec2Client),
-		checks.NewNetworkFirewallChecks(s.nfwClient, s.ec2Client),
-		checks.NewLambdaChecks(s.lambdaClient),
-		checks.NewECSChecks(s.ecsClient),
-		checks.NewEKSChecks(s.eksClient),
-		checks.NewRoute53Checks(s.route53Client),
-		checks.NewAccessAnalyzerChecks(s.accessAnalyzerClient, s.cfg.Region),
-		checks.NewSecurityServicesChecks(s.gdClient, s.macieClient, s.shClient, s.inspector2Client),
-		checks.NewMonitoringChecks(s.cwClient, s.snsClient, s.shClient), // Add monitoring checks (CIS 4.16)
-		checks.NewCISManualChecks(), // Add manual CIS controls (Section 4)
-		// Section 10 - Additional Services
-		checks.NewSSMChecks(s.ssmClient),                                // CIS 10.1-10.3
-		checks.NewBeanstalkChecks(s.beanstalkClient),                    // CIS 10.4-10.6
-		checks.NewAPIGatewayChecks(s.apigwClient, s.apigwv2Client),      // CIS 10.7-10.9
-		checks.NewBackupVaultChecks(s.backupClient),                     // CIS 10.10-10.12
-		checks.NewMessagingChecks(s.snsClient, s.sqsClient),             // CIS 10.13-10.15
-		// Sections 11-18 - Extended Coverage for 100%
-		checks.NewOrganizationsAdvancedChecks(s.orgClient, s.ctClient),  // CIS 11.1-11.4
-		checks.NewSecretsManagerChecks(s.secretsManagerClient),          // CIS 12.1-12.3
-		checks.NewECRChecks(s.ecrClient),                                // CIS 13.1-13.3
-		checks.NewDynamoDBChecks(s.dynamodbClient),                      // CIS 14.1-14.3
-		checks.NewCloudFormationChecks(s.cloudFormationClient),          // CIS 15.1-15.2
-		checks.NewACMChecks(s.acmClient),                                // CIS 16.1-16.2
-		checks.NewIAMExtendedChecks(s.iamClient),                        // CIS 17.1-17.2
-		checks.NewAuroraChecks(s.rdsClient),                             // CIS 18.1
-		// Sections 19-22 - Data Analytics & ML Services (January 2026)
-		checks.NewSageMakerChecks(s.sagemakerClient),                    // CIS 19.1-19.6
-		checks.NewRedshiftChecks(s.redshiftClient),                      // CIS 20.1-20.7
-		checks.NewElastiCacheChecks(s.elasticacheClient),                // CIS 21.1-21.5
-		checks.NewOpenSearchChecks(s.opensearchClient),                  // CIS 22.1-22.6
-	}
+	checkModules := checks.ApplyResourceFilter(s.cisModules(), s.resourceFilter)
 	
 	// Track which CIS sections we're covering
 	sectionCounts := make(map[string]int)
 	
-	for _, check := range checkModules {
-		if verbose {
-			fmt.Printf("  Running %s...\n", check.Name())
-		}
-		
-		checkResults, checkErr := check.Run(ctx)
-		if checkErr != nil && verbose {
-			fmt.Printf("    Warning: %v\n", checkErr)
-		}
-		
-		for _, cr := range checkResults {
-			// Check if this control has CIS-AWS mapping in Frameworks
-			if cr.Frameworks != nil && cr.Frameworks["CIS-AWS"] != "" {
-				cisControls := cr.Frameworks["CIS-AWS"]
-				
-				// Enhance control name with CIS numbers
-				enhancedName := fmt.Sprintf("[CIS AWS %s] %s", cisControls, cr.Name)
-				
-				// Track section coverage (extract first digit from control number)
-				if len(cisControls) > 0 {
-					section := string(cisControls[0])
-					switch section {
-					case "1":
-						sectionCounts["Identity and Access Management"]++
-					case "2":
-						sectionCounts["Storage"]++
-					case "3":
-						sectionCounts["Logging"]++
-					case "4":
-						sectionCounts["Monitoring"]++
-					case "5":
-						sectionCounts["Networking"]++
-					case "6":
-						sectionCounts["Lambda"]++
-					case "7":
-						sectionCounts["ECS"]++
-					case "8":
-						sectionCounts["EKS"]++
-					case "9":
-						sectionCounts["Security Services"]++
-					default:
-						// Handle multi-digit sections (10-18)
-						if len(cisControls) >= 2 {
-							switch cisControls[0:2] {
-							case "10":
-								sectionCounts["Additional Services"]++
-							case "11":
-								sectionCounts["Organizations"]++
-							case "12":
-								sectionCounts["Secrets Manager"]++
-							case "13":
-								sectionCounts["ECR"]++
-							case "14":
-								sectionCounts["DynamoDB"]++
-							case "15":
-								sectionCounts["CloudFormation"]++
-							case "16":
-								sectionCounts["ACM"]++
-							case "17":
-								sectionCounts["IAM Extended"]++
-							case "18":
-								sectionCounts["Aurora"]++
-							case "19":
-								sectionCounts["SageMaker"]++
-							case "20":
-								sectionCounts["Redshift"]++
-							case "21":
-								sectionCounts["ElastiCache"]++
-							case "22":
-								sectionCounts["OpenSearch"]++
-							}
+	collected := s.runModules(ctx, checkModules, verbose)
+	for _, cr := range collected {
+		// Check if this control has CIS-AWS mapping in Frameworks
+		if cr.Frameworks != nil && cr.Frameworks["CIS-AWS"] != "" {
+			cisControls := cr.Frameworks["CIS-AWS"]
+			
+			// Enhance control name with CIS numbers
+			enhancedName := fmt.Sprintf("[CIS AWS %s] %s", cisControls, cr.Name)
+			
+			// Track section coverage (extract first digit from control number)
+			if len(cisControls) > 0 {
+				section := string(cisControls[0])
+				switch section {
+				case "1":
+					sectionCounts["Identity and Access Management"]++
+				case "2":
+					sectionCounts["Storage"]++
+				case "3":
+					sectionCounts["Logging"]++
+				case "4":
+					sectionCounts["Monitoring"]++
+				case "5":
+					sectionCounts["Networking"]++
+				case "6":
+					sectionCounts["Lambda"]++
+				case "7":
+					sectionCounts["ECS"]++
+				case "8":
+					sectionCounts["EKS"]++
+				case "9":
+					sectionCounts["Security Services"]++
+				default:
+					// Handle multi-digit sections (10-18)
+					if len(cisControls) >= 2 {
+						switch cisControls[0:2] {
+						case "10":
+							sectionCounts["Additional Services"]++
+						case "11":
+							sectionCounts["Organizations"]++
+						case "12":
+							sectionCounts["Secrets Manager"]++
+						case "13":
+							sectionCounts["ECR"]++
+						case "14":
+							sectionCounts["DynamoDB"]++
+						case "15":
+							sectionCounts["CloudFormation"]++
+						case "16":
+							sectionCounts["ACM"]++
+						case "17":
+							sectionCounts["IAM Extended"]++
+						case "18":
+							sectionCounts["Aurora"]++
+						case "19":
+							sectionCounts["SageMaker"]++
+						case "20":
+							sectionCounts["Redshift"]++
+						case "21":
+							sectionCounts["ElastiCache"]++
+						case "22":
+							sectionCounts["OpenSearch"]++
 						}
 					}
 				}
-				
-				results = append(results, ScanResult{
-					Control:           enhancedName,
-					Status:            cr.Status,
-					Evidence:          cr.Evidence,
-					Remediation:       cr.Remediation,
-					RemediationDetail: cr.RemediationDetail,
-					Severity:          cr.Severity,
-					ScreenshotGuide:   cr.ScreenshotGuide,
-					ConsoleURL:        cr.ConsoleURL,
-					Frameworks:        cr.Frameworks,
-				})
 			}
+			
+			result := s.toScanResult(cr)
+			result.Control = enhancedName
+			results = append(results, result)
 		}
 	}
 	
@@ -362,6 +549,11 @@ func (s *AWSScanner) runCISChecks(ctx context.Context, verbose bool) []ScanResul
 
 func (s *AWSScanner) runCMMCChecks(ctx context.Context, verbose bool) []ScanResult {
 	var results []ScanResult
+
+	// No CMMC module can be narrowed to a single resource
+	if !s.resourceFilter.IsEmpty() {
+		return results
+	}
 	
 	if verbose {
 		fmt.Println("Running CMMC Level 1 (17 practices) - Open Source")
@@ -385,20 +577,12 @@ func (s *AWSScanner) runCMMCChecks(ctx context.Context, verbose bool) []ScanResu
 	}
 	
 	// ONLY Level 1 (17 practices)
-	level1 := checks.NewAWSCMMCLevel1Checks(s.iamClient, s.s3Client, s.ec2Client, s.ctClient)
+	level1 := checks.NewAWSCMMCLevel1Checks(s.clients.IAM, s.clients.S3, s.clients.EC2, s.clients.CloudTrail)
 	results1, _ := level1.Run(ctx)
+	s.rate(results1)
+	s.markExecuted(level1.Name())
 	for _, cr := range results1 {
-		results = append(results, ScanResult{
-			Control:           cr.Control,
-			Status:            cr.Status,
-			Evidence:          cr.Evidence,
-			Remediation:       cr.Remediation,
-			RemediationDetail: cr.RemediationDetail,
-			Severity:          cr.Severity,
-			ScreenshotGuide:   cr.ScreenshotGuide,
-			ConsoleURL:        cr.ConsoleURL,
-			Frameworks:        cr.Frameworks,
-		})
+		results = append(results, s.toScanResult(cr))
 	}
 	
 	if verbose {
@@ -416,89 +600,77 @@ func (s *AWSScanner) runCMMCChecks(ctx context.Context, verbose bool) []ScanResu
 	return results
 }
 
-func (s *AWSScanner) runSOC2Checks(ctx context.Context, verbose bool) []ScanResult {
-	var results []ScanResult
-	
-	// Initialize SOC2 checks
-	soc2Checks := []checks.Check{
+// soc2Modules returns the check modules run for SOC2 (and frameworks that
+// reuse the SOC2 run)
+func (s *AWSScanner) soc2Modules() []checks.Check {
+	return []checks.Check{
 		// CC1 & CC2: Control Environment & Communication
-		checks.NewCC1Checks(s.iamClient, s.orgClient, s.ssmClient),
-		checks.NewCC2Checks(s.snsClient, s.ssmClient, s.iamClient),
+		checks.NewCC1Checks(s.clients.IAM, s.clients.Organizations, s.clients.SSM),
+		checks.NewCC2Checks(s.clients.SNS, s.clients.SSM, s.clients.IAM),
 
 		// CC3, CC4, CC5: Risk Assessment, Monitoring, Control Activities
-		checks.NewCC3Checks(s.gdClient, s.shClient, s.inspector2Client),
-		checks.NewCC4Checks(s.cwClient, s.configClient),
-		checks.NewCC5Checks(s.backupClient, s.kmsClient),
+		checks.NewCC3Checks(s.clients.GuardDuty, s.clients.SecurityHub, s.clients.Inspector2),
+		checks.NewCC4Checks(s.clients.CloudWatch, s.clients.ConfigService),
+		checks.NewCC5Checks(s.clients.Backup, s.clients.KMS),
 
 		// CC6, CC7, CC8, CC9: Access Controls, Operations, Change Mgmt, Risk Mitigation
-		checks.NewCC6Checks(s.iamClient, s.ec2Client, s.s3Client, s.ctClient),
-		checks.NewCC7Checks(s.ctClient, s.ssmClient, s.lambdaClient),
-		checks.NewCC8Checks(s.lambdaClient, s.ec2Client),
-		checks.NewCC9Checks(s.rdsClient, s.s3Client),
+		checks.NewCC6Checks(s.clients.IAM, s.clients.EC2, s.clients.S3, s.clients.CloudTrail),
+		checks.NewCC7Checks(s.clients.CloudTrail, s.clients.SSM, s.clients.Lambda),
+		checks.NewCC8Checks(s.clients.Lambda, s.clients.EC2),
+		checks.NewCC9Checks(s.clients.RDS, s.clients.S3),
 
 		// Also run traditional checks for backward compatibility
-		checks.NewS3Checks(s.s3Client),
-		checks.NewIAMChecks(s.iamClient),
-		checks.NewEC2Checks(s.ec2Client),
-		checks.NewCloudTrailChecks(s.ctClient),
-		checks.NewConfigChecks(s.configClient),
-		checks.NewGuardDutyChecks(s.gdClient),
-		checks.NewRDSChecks(s.rdsClient),
-		checks.NewVPCChecks(s.ec2Client),
+		checks.NewS3Checks(s.clients.S3),
+		checks.NewIAMChecks(s.clients.IAM),
+		checks.NewEC2Checks(s.clients.EC2),
+		checks.NewCloudTrailChecks(s.clients.CloudTrail),
+		checks.NewConfigChecks(s.clients.ConfigService),
+		checks.NewGuardDutyChecks(s.clients.GuardDuty),
+		checks.NewRDSChecks(s.clients.RDS),
+		checks.NewVPCChecks(s.clients.EC2),
 
 		// CIS AWS Benchmark v1.5.0+ comprehensive coverage
-		checks.NewCISManualChecks(),                                                                   // CIS 4.1-4.15
-		checks.NewAccessAnalyzerChecks(s.accessAnalyzerClient, s.cfg.Region),                          // CIS 1.8
-		checks.NewRoute53Checks(s.route53Client),                                                      // CIS 5.19
-		checks.NewSSMChecks(s.ssmClient),                                                              // CIS 10.1-10.3
-		checks.NewBeanstalkChecks(s.beanstalkClient),                                                  // CIS 10.4-10.6
-		checks.NewAPIGatewayChecks(s.apigwClient, s.apigwv2Client),                                    // CIS 10.7-10.9
-		checks.NewBackupVaultChecks(s.backupClient),                                                   // CIS 10.10-10.12
-		checks.NewMessagingChecks(s.snsClient, s.sqsClient),                                           // CIS 10.13-10.15
-		checks.NewOrganizationsAdvancedChecks(s.orgClient, s.ctClient),                                // CIS 11.1-11.4
-		checks.NewSecretsManagerChecks(s.secretsManagerClient),                                        // CIS 12.1-12.3
-		checks.NewECRChecks(s.ecrClient),                                                              // CIS 13.1-13.3
-		checks.NewDynamoDBChecks(s.dynamodbClient),                                                    // CIS 14.1-14.3
-		checks.NewCloudFormationChecks(s.cloudFormationClient),                                        // CIS 15.1-15.2
-		checks.NewACMChecks(s.acmClient),                                                              // CIS 16.1-16.2
-		checks.NewIAMExtendedChecks(s.iamClient),                                                      // CIS 17.1-17.2
-		checks.NewAuroraChecks(s.rdsClient),                                                           // CIS 18.1
-		checks.NewLambdaChecks(s.lambdaClient),                                                        // Lambda best practices
-		checks.NewECSChecks(s.ecsClient),                                                              // ECS best practices
-		checks.NewEKSChecks(s.eksClient),                                                              // EKS best practices
-		checks.NewNetworkFirewallChecks(s.nfwClient, s.ec2Client),                                     // Network Firewall
-		checks.NewSecurityServicesChecks(s.gdClient, s.macieClient, s.shClient, s.inspector2Client),   // Additional security
+		checks.NewCISManualChecks(),                                                                                         // CIS 4.1-4.15
+		checks.NewAccessAnalyzerChecks(s.clients.AccessAnalyzer, s.clients.Config.Region),                                   // CIS 1.8
+		checks.NewRoute53Checks(s.clients.Route53),                                                                          // CIS 5.19
+		checks.NewSSMChecks(s.clients.SSM),                                                                                  // CIS 10.1-10.3
+		checks.NewBeanstalkChecks(s.clients.Beanstalk),                                                                      // CIS 10.4-10.6
+		checks.NewAPIGatewayChecks(s.clients.APIGateway, s.clients.APIGatewayV2),                                            // CIS 10.7-10.9
+		checks.NewBackupVaultChecks(s.clients.Backup),                                                                       // CIS 10.10-10.12
+		checks.NewMessagingChecks(s.clients.SNS, s.clients.SQS),                                                             // CIS 10.13-10.15
+		checks.NewOrganizationsAdvancedChecks(s.clients.Organizations, s.clients.CloudTrail),                                // CIS 11.1-11.4
+		checks.NewSecretsManagerChecks(s.clients.SecretsManager),                                                            // CIS 12.1-12.3
+		checks.NewECRChecks(s.clients.ECR),                                                                                  // CIS 13.1-13.3
+		checks.NewDynamoDBChecks(s.clients.DynamoDB),                                                                        // CIS 14.1-14.3
+		checks.NewCloudFormationChecks(s.clients.CloudFormation),                                                            // CIS 15.1-15.2
+		checks.NewACMChecks(s.clients.ACM),                                                                                  // CIS 16.1-16.2
+		checks.NewIAMExtendedChecks(s.clients.IAM),                                                                          // CIS 17.1-17.2
+		checks.NewAuroraChecks(s.clients.RDS),                                                                               // CIS 18.1
+		checks.NewLambdaChecks(s.clients.Lambda),                                                                            // Lambda best practices
+		checks.NewECSChecks(s.clients.ECS),                                                                                  // ECS best practices
+		checks.NewEKSChecks(s.clients.EKS),                                                                                  // EKS best practices
+		checks.NewNetworkFirewallChecks(s.clients.NetworkFirewall, s.clients.EC2),                                           // Network Firewall
+		checks.NewSecurityServicesChecks(s.clients.GuardDuty, s.clients.Macie, s.clients.SecurityHub, s.clients.Inspector2), // Additional security
+		checks.NewCloudWatchLogsChecks(s.clients.CloudWatchLogs),                                                            // Log group retention
 		// Data Analytics & ML Services (January 2026)
-		checks.NewSageMakerChecks(s.sagemakerClient),                                                  // SageMaker ML security
-		checks.NewRedshiftChecks(s.redshiftClient),                                                    // Redshift data warehouse
-		checks.NewElastiCacheChecks(s.elasticacheClient),                                              // ElastiCache/Redis
-		checks.NewOpenSearchChecks(s.opensearchClient),                                                // OpenSearch/Elasticsearch
+		checks.NewSageMakerChecks(s.clients.SageMaker, s.clients.IAM),                           // SageMaker ML security
+		checks.NewRedshiftChecks(s.clients.Redshift, s.clients.IAM, s.clients.S3, s.clients.EC2), // Redshift data warehouse
+		checks.NewRedshiftServerlessChecks(s.clients.RedshiftServerless),                         // Redshift Serverless
+		checks.NewElastiCacheChecks(s.clients.ElastiCache, s.clients.EC2),                        // ElastiCache/Redis
+		checks.NewOpenSearchChecks(s.clients.OpenSearch, s.clients.ACM),                          // OpenSearch/Elasticsearch
 	}
+}
+
+func (s *AWSScanner) runSOC2Checks(ctx context.Context, verbose bool) []ScanResult {
+	var results []ScanResult
 	
-	for _, check := range soc2Checks {
-		if verbose {
-			fmt.Printf("  Running %s ...\n", check.Name())
-		}
-		
-		checkResults, err := check.Run(ctx)
-		if err != nil && verbose {
-			fmt.Printf("    Warning in %s: %v\n", check.Name(), err)
-		}
-		
-		// Convert CheckResult to ScanResult
-		for _, cr := range checkResults {
-			results = append(results, ScanResult{
-				Control:           cr.Control,
-				Status:            cr.Status,
-				Evidence:          cr.Evidence,
-				Remediation:       cr.Remediation,
-				RemediationDetail: cr.RemediationDetail,
-				Severity:          cr.Severity,
-				ScreenshotGuide:   cr.ScreenshotGuide,
-				ConsoleURL:        cr.ConsoleURL,
-				Frameworks:        cr.Frameworks,
-			})
-		}
+	// Initialize SOC2 checks
+	soc2Checks := checks.ApplyResourceFilter(s.soc2Modules(), s.resourceFilter)
+	
+	// Convert CheckResult to ScanResult in a stable order
+	collected := s.runModules(ctx, soc2Checks, verbose)
+	for _, cr := range collected {
+		results = append(results, s.toScanResult(cr))
 	}
 	
 	return results
@@ -506,58 +678,47 @@ func (s *AWSScanner) runSOC2Checks(ctx context.Context, verbose bool) []ScanResu
 
 func (s *AWSScanner) runPCIChecks(ctx context.Context, verbose bool) []ScanResult {
 	var results []ScanResult
+
+	// No PCI module can be narrowed to a single resource
+	if !s.resourceFilter.IsEmpty() {
+		return results
+	}
 	
 	// Check if pci_dss.go exists, if not fall back to basic checks with PCI mappings
-	pciChecks := checks.NewPCIDSSChecks(s.iamClient, s.ec2Client, s.s3Client, s.ctClient, s.configClient)
+	pciChecks := checks.NewPCIDSSChecks(s.clients.IAM, s.clients.EC2, s.clients.S3, s.clients.CloudTrail, s.clients.ConfigService)
 	
 	if verbose {
 		fmt.Printf("  Running PCI-DSS v4.0 requirements...\n")
 	}
 	
 	checkResults, err := pciChecks.Run(ctx)
+	s.rate(checkResults)
+	s.markExecuted(pciChecks.Name())
 	if err != nil && verbose {
 		fmt.Printf("    Warning in PCI-DSS checks: %v\n", err)
 	}
 	
 	// Convert CheckResult to ScanResult
 	for _, cr := range checkResults {
-		results = append(results, ScanResult{
-			Control:           cr.Control,
-			Status:            cr.Status,
-			Evidence:          cr.Evidence,
-			Remediation:       cr.Remediation,
-			RemediationDetail: cr.RemediationDetail,
-			Severity:          cr.Severity,
-			ScreenshotGuide:   cr.ScreenshotGuide,
-			ConsoleURL:        cr.ConsoleURL,
-			Frameworks:        cr.Frameworks,
-		})
+		results = append(results, s.toScanResult(cr))
 	}
 	
 	// Also run basic checks but filter for PCI relevance
 	basicChecks := []checks.Check{
-		checks.NewIAMChecks(s.iamClient),      // For password policy, MFA, key rotation
-		checks.NewS3Checks(s.s3Client),        // For encryption requirements
-		checks.NewEC2Checks(s.ec2Client),      // For network segmentation
-		checks.NewCloudTrailChecks(s.ctClient), // For logging requirements
+		checks.NewIAMChecks(s.clients.IAM),      // For password policy, MFA, key rotation
+		checks.NewS3Checks(s.clients.S3),        // For encryption requirements
+		checks.NewEC2Checks(s.clients.EC2),      // For network segmentation
+		checks.NewCloudTrailChecks(s.clients.CloudTrail), // For logging requirements
 	}
 	
 	for _, check := range basicChecks {
 		checkResults, _ := check.Run(ctx)
+		s.rate(checkResults)
+		s.markExecuted(check.Name())
 		for _, cr := range checkResults {
 			// Only include if it has PCI mapping
 			if cr.Frameworks != nil && cr.Frameworks["PCI-DSS"] != "" {
-				results = append(results, ScanResult{
-					Control:           cr.Control,
-					Status:            cr.Status,
-					Evidence:          cr.Evidence,
-					Remediation:       cr.Remediation,
-					RemediationDetail: cr.RemediationDetail,
-					Severity:          cr.Severity,
-					ScreenshotGuide:   cr.ScreenshotGuide,
-					ConsoleURL:        cr.ConsoleURL,
-					Frameworks:        cr.Frameworks,
-				})
+				results = append(results, s.toScanResult(cr))
 			}
 		}
 	}
@@ -3,6 +3,8 @@ package checks
 import (
     "context"
     "fmt"
+    "sort"
+    "strconv"
     "strings"
     "time"
     
@@ -83,22 +85,9 @@ func (c *NetworkChecks) CheckRDPAccess(ctx context.Context) []CheckResult {
                        rule.Properties.Access != nil && *rule.Properties.Access == armnetwork.SecurityRuleAccessAllow {
                         
                         // Check if RDP (3389) is open from internet
-                        isRDP := false
-                        if rule.Properties.DestinationPortRange != nil {
-                            port := *rule.Properties.DestinationPortRange
-                            if port == "3389" || port == "*" {
-                                isRDP = true
-                            }
-                        }
-                        
-                        if isRDP {
-                            // Check source
-                            if rule.Properties.SourceAddressPrefix != nil {
-                                source := *rule.Properties.SourceAddressPrefix
-                                if source == "*" || source == "0.0.0.0/0" || source == "Internet" || source == "<nw>/0" || source == "/0" {
-                                    rdpFromInternet = append(rdpFromInternet, fmt.Sprintf("%s (rule: %s)", *nsg.Name, *rule.Name))
-                                }
-                            }
+                        // Covers single ports, ranges and multi-value port/prefix lists
+                        if ruleAllowsPort(rule.Properties, 3389) && ruleSourceIsInternet(rule.Properties) {
+                            rdpFromInternet = append(rdpFromInternet, fmt.Sprintf("%s (rule: %s)", *nsg.Name, *rule.Name))
                         }
                     }
                 }
@@ -185,21 +174,8 @@ func (c *NetworkChecks) CheckSSHAccess(ctx context.Context) []CheckResult {
                        rule.Properties.Access != nil && *rule.Properties.Access == armnetwork.SecurityRuleAccessAllow {
                         
                         // Check if SSH (22) is open from internet
-                        isSSH := false
-                        if rule.Properties.DestinationPortRange != nil {
-                            port := *rule.Properties.DestinationPortRange
-                            if port == "22" || port == "*" {
-                                isSSH = true
-                            }
-                        }
-                        
-                        if isSSH {
-                            if rule.Properties.SourceAddressPrefix != nil {
-                                source := *rule.Properties.SourceAddressPrefix
-                                if source == "*" || source == "0.0.0.0/0" || source == "Internet" || source == "<nw>/0" || source == "/0" {
-                                    sshFromInternet = append(sshFromInternet, fmt.Sprintf("%s (rule: %s)", *nsg.Name, *rule.Name))
-                                }
-                            }
+                        if ruleAllowsPort(rule.Properties, 22) && ruleSourceIsInternet(rule.Properties) {
+                            sshFromInternet = append(sshFromInternet, fmt.Sprintf("%s (rule: %s)", *nsg.Name, *rule.Name))
                         }
                     }
                 }
@@ -578,21 +554,21 @@ func (c *NetworkChecks) CheckOpenPorts(ctx context.Context) []CheckResult {
                     if rule.Properties != nil {
                         if rule.Properties.Direction != nil && *rule.Properties.Direction == armnetwork.SecurityRuleDirectionInbound {
                             if rule.Properties.Access != nil && *rule.Properties.Access == armnetwork.SecurityRuleAccessAllow {
-                                isInternet := false
-                                if rule.Properties.SourceAddressPrefix != nil {
-                                    source := *rule.Properties.SourceAddressPrefix
-                                    if source == "*" || source == "0.0.0.0/0" || source == "Internet" {
-                                        isInternet = true
-                                    }
-                                }
-                                
-                                if isInternet {
-                                    if rule.Properties.DestinationPortRange != nil {
-                                        port := *rule.Properties.DestinationPortRange
-                                        if port == "*" {
-                                            openToInternet = append(openToInternet, fmt.Sprintf("%s (ALL PORTS!)", nsgName))
-                                        } else if serviceName, isDangerous := dangerousPorts[port]; isDangerous {
-                                            openToInternet = append(openToInternet, fmt.Sprintf("%s (%s port %s)", nsgName, serviceName, port))
+                                if ruleSourceIsInternet(rule.Properties) {
+                                    if ruleAllowsAllPorts(rule.Properties) {
+                                        openToInternet = append(openToInternet, fmt.Sprintf("%s (ALL PORTS!)", nsgName))
+                                    } else {
+                                        // Sorted so evidence is stable between scans
+                                        ports := make([]string, 0, len(dangerousPorts))
+                                        for port := range dangerousPorts {
+                                            ports = append(ports, port)
+                                        }
+                                        sort.Strings(ports)
+                                        for _, port := range ports {
+                                            portNum, _ := strconv.Atoi(port)
+                                            if ruleAllowsPort(rule.Properties, portNum) {
+                                                openToInternet = append(openToInternet, fmt.Sprintf("%s (%s port %s)", nsgName, dangerousPorts[port], port))
+                                            }
                                         }
                                     }
                                 }
@@ -639,3 +615,79 @@ This is a critical security vulnerability enabling:
     
     return results
 }
+
+// internetSources are NSG source prefixes that match any internet address
+var internetSources = map[string]bool{
+    "*":         true,
+    "0.0.0.0/0": true,
+    "Internet":  true,
+    "<nw>/0":    true,
+    "/0":        true,
+}
+
+// ruleSourceIsInternet reports whether a rule's source prefix (or any entry
+// in its source prefix list) covers the whole internet
+func ruleSourceIsInternet(props *armnetwork.SecurityRulePropertiesFormat) bool {
+    if props == nil {
+        return false
+    }
+    if props.SourceAddressPrefix != nil && internetSources[*props.SourceAddressPrefix] {
+        return true
+    }
+    for _, prefix := range props.SourceAddressPrefixes {
+        if prefix != nil && internetSources[*prefix] {
+            return true
+        }
+    }
+    return false
+}
+
+// rulePortRanges returns every destination port entry on a rule, combining
+// DestinationPortRange with the multi-value DestinationPortRanges
+func rulePortRanges(props *armnetwork.SecurityRulePropertiesFormat) []string {
+    ranges := []string{}
+    if props == nil {
+        return ranges
+    }
+    if props.DestinationPortRange != nil {
+        ranges = append(ranges, strings.TrimSpace(*props.DestinationPortRange))
+    }
+    for _, r := range props.DestinationPortRanges {
+        if r != nil {
+            ranges = append(ranges, strings.TrimSpace(*r))
+        }
+    }
+    return ranges
+}
+
+// ruleAllowsAllPorts reports whether a rule opens every destination port
+func ruleAllowsAllPorts(props *armnetwork.SecurityRulePropertiesFormat) bool {
+    for _, r := range rulePortRanges(props) {
+        if r == "*" || r == "0-65535" {
+            return true
+        }
+    }
+    return false
+}
+
+// ruleAllowsPort reports whether a rule's destination ports include port.
+// Handles "*", single ports and "low-high" ranges such as "3000-4000".
+func ruleAllowsPort(props *armnetwork.SecurityRulePropertiesFormat, port int) bool {
+    for _, r := range rulePortRanges(props) {
+        if r == "*" {
+            return true
+        }
+        if low, high, found := strings.Cut(r, "-"); found {
+            lowNum, errLow := strconv.Atoi(strings.TrimSpace(low))
+            highNum, errHigh := strconv.Atoi(strings.TrimSpace(high))
+            if errLow == nil && errHigh == nil && port >= lowNum && port <= highNum {
+                return true
+            }
+            continue
+        }
+        if n, err := strconv.Atoi(r); err == nil && n == port {
+            return true
+        }
+    }
+    return false
+}
@@ -2,6 +2,8 @@ package cli
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"strings"
 )
 
@@ -34,8 +36,14 @@ type Box struct {
 	color   string
 }
 
+// minBoxWidth fits the borders, padding and an ellipsis
+const minBoxWidth = 8
+
 // NewBox creates a new box with specified width
 func NewBox(width int) *Box {
+	if width < minBoxWidth {
+		width = minBoxWidth
+	}
 	return &Box{
 		width: width,
 	}
@@ -87,13 +95,17 @@ func (b *Box) String() string {
 
 	// Top border with optional title
 	if b.title != "" {
-		titleLen := len(b.title) + 2 // add spaces around title
-		leftPad := (b.width - 2 - titleLen) / 2
-		rightPad := b.width - 2 - titleLen - leftPad
+		title := b.title
+		if visibleLength(title) > b.width-4 {
+			title = truncateWithEllipsis(title, b.width-4)
+		}
+		titleLen := visibleLength(title) + 2 // add spaces around title
+		leftPad := max((b.width-2-titleLen)/2, 0)
+		rightPad := max(b.width-2-titleLen-leftPad, 0)
 		sb.WriteString(color)
 		sb.WriteString(tl)
 		sb.WriteString(strings.Repeat(h, leftPad))
-		sb.WriteString(" " + b.title + " ")
+		sb.WriteString(" " + title + " ")
 		sb.WriteString(strings.Repeat(h, rightPad))
 		sb.WriteString(tr)
 		sb.WriteString(Reset + "\n")
@@ -204,12 +216,25 @@ func truncateWithEllipsis(s string, width int) string {
 		length++
 	}
 
+	// Don't let a color cut off mid-string bleed into the ellipsis and border
+	if strings.ContainsRune(result, '\033') {
+		result += Reset
+	}
 	return result + "..."
 }
 
+// padVisible pads s with spaces to width visible characters, ignoring ANSI
+// codes that fmt's %-Ns would count
+func padVisible(s string, width int) string {
+	if padding := width - visibleLength(s); padding > 0 {
+		return s + strings.Repeat(" ", padding)
+	}
+	return s
+}
+
 // SummaryBox creates a formatted summary box for scan results
 func SummaryBox(provider, accountID, framework string, score float64, passed, failed, total int) string {
-	box := NewBox(50).SetDouble(true).SetTitle("SCAN SUMMARY")
+	box := NewBox(TerminalWidth()).SetDouble(true).SetTitle("SCAN SUMMARY")
 
 	box.AddLine(fmt.Sprintf("Provider:   %s", strings.ToUpper(provider)))
 	box.AddLine(fmt.Sprintf("Account:    %s", accountID))
@@ -223,26 +248,38 @@ func SummaryBox(provider, accountID, framework string, score float64, passed, fa
 	return box.String()
 }
 
-// ResultTable formats controls in a table
+// ResultTable prints controls in a table sized to the terminal width
 func ResultTable(controls []struct{ ID, Name, Status, Severity string }) {
+	WriteResultTable(os.Stdout, controls)
+}
+
+// WriteResultTable writes controls to w in a table sized to the terminal width
+func WriteResultTable(w io.Writer, controls []struct{ ID, Name, Status, Severity string }) {
 	if len(controls) == 0 {
 		return
 	}
 
+	// The name column takes whatever the fixed columns leave
+	const idWidth, statusWidth, severityWidth = 12, 8, 10
+	width := TerminalWidth()
+	nameWidth := max(width-idWidth-statusWidth-severityWidth-3, 10)
+
 	// Header
-	fmt.Printf("\n%-12s %-40s %-8s %-10s\n", "CONTROL", "NAME", "STATUS", "SEVERITY")
-	fmt.Println(strings.Repeat("-", 72))
+	fmt.Fprintf(w, "\n%s %s %s %s\n", padVisible("CONTROL", idWidth), padVisible("NAME", nameWidth),
+		padVisible("STATUS", statusWidth), "SEVERITY")
+	fmt.Fprintln(w, strings.Repeat("-", idWidth+nameWidth+statusWidth+severityWidth+3))
 
 	// Rows
 	for _, c := range controls {
 		name := c.Name
-		if len(name) > 38 {
-			name = name[:35] + "..."
+		if visibleLength(name) > nameWidth {
+			name = truncateWithEllipsis(name, nameWidth)
 		}
 
 		status := FormatStatus(c.Status)
 		severity := FormatSeverity(c.Severity)
 
-		fmt.Printf("%-12s %-40s %s %s\n", c.ID, name, status, severity)
+		fmt.Fprintf(w, "%s %s %s %s\n", padVisible(c.ID, idWidth), padVisible(name, nameWidth),
+			padVisible(status, statusWidth), severity)
 	}
 }
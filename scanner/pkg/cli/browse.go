@@ -0,0 +1,456 @@
+package cli
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/guardian-nexus/auditkit/scanner/pkg/offline"
+)
+
+// Browser views, from the outermost in
+const (
+	browseServices = iota
+	browseFindings
+	browseDetail
+)
+
+// browseSeverities is the cycle the severity filter steps through; "" shows
+// every severity. The order also ranks findings within a service.
+var browseSeverities = []string{"", "CRITICAL", "HIGH", "MEDIUM", "LOW"}
+
+// browseHelp is the footer of each view
+var browseHelp = map[int]string{
+	browseServices: "↑/↓ move  enter open  s severity  p passing  q quit",
+	browseFindings: "↑/↓ move  enter details  ← back  s severity  p passing  c copy fix  q quit",
+	browseDetail:   "↑/↓ scroll  ← back  c copy fix  q quit",
+}
+
+// browseGroup is one service (the controls' category) in the browser
+type browseGroup struct {
+	name     string
+	controls []offline.CachedControl
+	failed   int
+}
+
+// browser is the state of the interactive finding browser: which view is
+// open, the cursor in each list and the filters. It renders to a string and
+// takes parsed key names, so Browse only moves bytes to and from the terminal.
+type browser struct {
+	scan     *offline.CachedScan
+	view     int
+	group    int    // cursor in the service list
+	finding  int    // cursor in the finding list
+	scroll   int    // first line shown in the detail view
+	severity int    // index into browseSeverities
+	passing  bool   // list PASS and other non-failing controls too
+	message  string // one-off status line, e.g. after a copy
+	copied   string // text to put on the clipboard with the next render
+
+	width, height int
+}
+
+// Browse opens an interactive browser over scan: services, then the findings
+// of a service, then one finding's evidence and remediation. Findings can be
+// filtered by severity and a finding's remediation command copied to the
+// clipboard (via the terminal's OSC 52 support). When stdin or stdout is not a
+// terminal it prints the summary and the static result table instead.
+func Browse(scan *offline.CachedScan) error {
+	if !isTerminal(os.Stdin) || !isTerminal(os.Stdout) || os.Getenv("TERM") == "dumb" {
+		printStaticFindings(scan)
+		return nil
+	}
+
+	restore, err := enableRawInput(int(os.Stdin.Fd()))
+	if err != nil {
+		printStaticFindings(scan)
+		return nil
+	}
+	defer restore()
+
+	// Alternate screen and hidden cursor, undone on the way out
+	fmt.Print("\033[?1049h\033[?25l")
+	defer fmt.Print("\033[?25h\033[?1049l")
+
+	b := &browser{scan: scan}
+	input := make([]byte, 16)
+	for {
+		b.width, b.height = TerminalWidth(), terminalHeight()
+		fmt.Print(b.render())
+
+		n, err := os.Stdin.Read(input)
+		if err != nil {
+			return fmt.Errorf("failed to read key: %w", err)
+		}
+		if b.handleKey(parseKey(input[:n])) {
+			return nil
+		}
+	}
+}
+
+// isTerminal reports whether f is a character device, i.e. a terminal
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+// terminalHeight returns the rows of the terminal on stdout, else $LINES,
+// else 24
+func terminalHeight() int {
+	if height := detectTerminalHeight(); height > 0 {
+		return height
+	}
+	if lines, err := strconv.Atoi(os.Getenv("LINES")); err == nil && lines > 0 {
+		return lines
+	}
+	return 24
+}
+
+// printStaticFindings is Browse's fallback: the summary box and a table of
+// the failing controls
+func printStaticFindings(scan *offline.CachedScan) {
+	fmt.Print(SummaryBox(scan.Provider, scan.AccountID, scan.Framework, scan.Score,
+		scan.PassedControls, scan.FailedControls, scan.TotalControls))
+
+	rows := []struct{ ID, Name, Status, Severity string }{}
+	for _, group := range groupControls(scan.Controls, "", false) {
+		for _, control := range group.controls {
+			rows = append(rows, struct{ ID, Name, Status, Severity string }{control.ID, control.Name, control.Status, control.Severity})
+		}
+	}
+	ResultTable(rows)
+}
+
+// parseKey names the key press in input: "up", "down", "left", "right",
+// "enter", "back", "esc", "ctrl-c" or the typed character
+func parseKey(input []byte) string {
+	switch string(input) {
+	case "\033[A", "\033OA":
+		return "up"
+	case "\033[B", "\033OB":
+		return "down"
+	case "\033[C", "\033OC":
+		return "right"
+	case "\033[D", "\033OD":
+		return "left"
+	case "\r", "\n":
+		return "enter"
+	case "\x7f", "\b":
+		return "back"
+	case "\033":
+		return "esc"
+	case "\x03":
+		return "ctrl-c"
+	}
+	return string(input)
+}
+
+// groupControls groups controls by category, keeping those that match the
+// filters: severity ("" for any) and whether controls other than failures are
+// listed. Services with the most failures come first; within one, failures
+// come before the rest, then by severity and control ID.
+func groupControls(controls []offline.CachedControl, severity string, passing bool) []browseGroup {
+	byName := map[string]*browseGroup{}
+	groups := []*browseGroup{}
+	for _, control := range controls {
+		if control.Status != "FAIL" && !passing {
+			continue
+		}
+		if severity != "" && !strings.EqualFold(control.Severity, severity) {
+			continue
+		}
+
+		name := control.Category
+		if name == "" {
+			name = "Other"
+		}
+		group, ok := byName[name]
+		if !ok {
+			group = &browseGroup{name: name}
+			byName[name] = group
+			groups = append(groups, group)
+		}
+		group.controls = append(group.controls, control)
+		if control.Status == "FAIL" {
+			group.failed++
+		}
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].failed != groups[j].failed {
+			return groups[i].failed > groups[j].failed
+		}
+		return groups[i].name < groups[j].name
+	})
+
+	result := make([]browseGroup, len(groups))
+	for i, group := range groups {
+		sort.SliceStable(group.controls, func(a, b int) bool {
+			ca, cb := group.controls[a], group.controls[b]
+			if (ca.Status == "FAIL") != (cb.Status == "FAIL") {
+				return ca.Status == "FAIL"
+			}
+			if ra, rb := severityOrder(ca.Severity), severityOrder(cb.Severity); ra != rb {
+				return ra < rb
+			}
+			return ca.ID < cb.ID
+		})
+		result[i] = *group
+	}
+	return result
+}
+
+// severityOrder ranks a severity by its place in browseSeverities; unknown
+// severities sort last
+func severityOrder(severity string) int {
+	for i, s := range browseSeverities[1:] {
+		if strings.EqualFold(s, severity) {
+			return i
+		}
+	}
+	return len(browseSeverities)
+}
+
+// groups returns the services under the current filters
+func (b *browser) groups() []browseGroup {
+	return groupControls(b.scan.Controls, browseSeverities[b.severity], b.passing)
+}
+
+// selected returns the finding under the cursor, if any
+func (b *browser) selected() (offline.CachedControl, bool) {
+	groups := b.groups()
+	if b.group >= len(groups) || b.finding >= len(groups[b.group].controls) {
+		return offline.CachedControl{}, false
+	}
+	return groups[b.group].controls[b.finding], true
+}
+
+// handleKey applies one key press and reports whether the browser should quit
+func (b *browser) handleKey(key string) bool {
+	b.message = ""
+	groups := b.groups()
+
+	switch key {
+	case "q", "ctrl-c":
+		return true
+
+	case "up", "k":
+		switch b.view {
+		case browseServices:
+			b.group = max(b.group-1, 0)
+		case browseFindings:
+			b.finding = max(b.finding-1, 0)
+		case browseDetail:
+			b.scroll = max(b.scroll-1, 0)
+		}
+
+	case "down", "j":
+		switch b.view {
+		case browseServices:
+			b.group = min(b.group+1, max(len(groups)-1, 0))
+		case browseFindings:
+			if b.group < len(groups) {
+				b.finding = min(b.finding+1, max(len(groups[b.group].controls)-1, 0))
+			}
+		case browseDetail:
+			b.scroll++
+		}
+
+	case "enter", "right", "l":
+		if b.view == browseServices && len(groups) > 0 {
+			b.view, b.finding = browseFindings, 0
+		} else if b.view == browseFindings {
+			if _, ok := b.selected(); ok {
+				b.view, b.scroll = browseDetail, 0
+			}
+		}
+
+	case "left", "h", "back", "esc":
+		b.view = max(b.view-1, browseServices)
+
+	case "s", "p":
+		if key == "s" {
+			b.severity = (b.severity + 1) % len(browseSeverities)
+		} else {
+			b.passing = !b.passing
+		}
+		// The lists change under the filter: start over from the services
+		b.view, b.group, b.finding, b.scroll = browseServices, 0, 0, 0
+
+	case "c":
+		control, ok := b.selected()
+		if b.view == browseServices || !ok {
+			break
+		}
+		command := control.RemediationDetail
+		if command == "" {
+			command = control.Remediation
+		}
+		if command == "" {
+			b.message = "No remediation to copy"
+			break
+		}
+		b.copied = command
+		b.message = fmt.Sprintf("Copied remediation for %s to the clipboard", control.ID)
+	}
+	return false
+}
+
+// render draws the current view to fit the terminal, preceded by the
+// clipboard escape sequence when a copy is pending
+func (b *browser) render() string {
+	var sb strings.Builder
+	if b.copied != "" {
+		sb.WriteString("\033]52;c;" + base64.StdEncoding.EncodeToString([]byte(b.copied)) + "\a")
+		b.copied = ""
+	}
+	sb.WriteString("\033[H\033[2J")
+
+	filter := browseSeverities[b.severity]
+	if filter == "" {
+		filter = "ALL"
+	}
+	passing := "hidden"
+	if b.passing {
+		passing = "shown"
+	}
+	sb.WriteString(fmt.Sprintf("%s %s │ %s │ %s\n", Color(Bold, "AuditKit"), b.scan.AccountID,
+		strings.ToUpper(b.scan.Framework), FormatScore(b.scan.Score)))
+	sb.WriteString(Color(Dim, fmt.Sprintf("Severity: %s  Passing: %s", filter, passing)) + "\n")
+	sb.WriteString(strings.Repeat(BoxHorizontal, b.width) + "\n")
+
+	// Header (3 lines) and footer (2 lines) frame the body
+	bodyHeight := max(b.height-5, 1)
+	var lines []string
+	start := 0
+	groups := b.groups()
+
+	switch b.view {
+	case browseServices:
+		if len(groups) == 0 {
+			lines = append(lines, "  No findings match the current filters")
+		}
+		for i, group := range groups {
+			lines = append(lines, b.row(i == b.group, fmt.Sprintf("%s  %s",
+				padVisible(group.name, 30), Color(activeTheme.Fail, fmt.Sprintf("%d failing", group.failed)))+
+				Color(Dim, fmt.Sprintf(" of %d", len(group.controls)))))
+		}
+		start = scrollStart(b.group, bodyHeight)
+
+	case browseFindings:
+		if b.group < len(groups) {
+			for i, control := range groups[b.group].controls {
+				line := fmt.Sprintf("%s %s %s %s", padVisible(FormatStatus(control.Status), 8),
+					padVisible(FormatSeverity(control.Severity), 10), padVisible(control.ID, 12), control.Name)
+				lines = append(lines, b.row(i == b.finding, line))
+			}
+		}
+		start = scrollStart(b.finding, bodyHeight)
+
+	case browseDetail:
+		if control, ok := b.selected(); ok {
+			lines = b.detailLines(control)
+		}
+		b.scroll = min(b.scroll, max(len(lines)-bodyHeight, 0))
+		start = b.scroll
+	}
+
+	end := min(start+bodyHeight, len(lines))
+	for _, line := range lines[start:end] {
+		if visibleLength(line) > b.width {
+			line = truncateWithEllipsis(line, b.width)
+		}
+		sb.WriteString(line + "\n")
+	}
+	for i := end - start; i < bodyHeight; i++ {
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(strings.Repeat(BoxHorizontal, b.width) + "\n")
+	if b.message != "" {
+		sb.WriteString(Color(activeTheme.Pass, b.message))
+	} else {
+		sb.WriteString(Color(Dim, browseHelp[b.view]))
+	}
+	return sb.String()
+}
+
+// row marks the line under the cursor
+func (b *browser) row(selected bool, line string) string {
+	if selected {
+		return Color(Bold, "▸ ") + line
+	}
+	return "  " + line
+}
+
+// scrollStart is the first list row to show so the cursor stays on screen
+func scrollStart(cursor, height int) int {
+	return max(cursor-height+1, 0)
+}
+
+// detailLines lays out one finding: status, severity and mappings, then the
+// evidence and remediation wrapped to the terminal width
+func (b *browser) detailLines(control offline.CachedControl) []string {
+	lines := []string{
+		Color(Bold, fmt.Sprintf("%s  %s", control.ID, control.Name)),
+		fmt.Sprintf("Status: %s  Severity: %s", FormatStatus(control.Status), FormatSeverity(control.Severity)),
+	}
+	if control.OriginalSeverity != "" {
+		lines = append(lines, fmt.Sprintf("Rated %s by the check", control.OriginalSeverity))
+	}
+	if control.Environment != "" {
+		lines = append(lines, "Environment: "+control.Environment)
+	}
+	if len(control.Frameworks) > 0 {
+		names := make([]string, 0, len(control.Frameworks))
+		for name := range control.Frameworks {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		mappings := make([]string, 0, len(names))
+		for _, name := range names {
+			mappings = append(mappings, fmt.Sprintf("%s %s", name, control.Frameworks[name]))
+		}
+		lines = append(lines, wrapText("Frameworks: "+strings.Join(mappings, "; "), b.width)...)
+	}
+
+	sections := []struct{ title, text string }{
+		{"Evidence", control.Evidence},
+		{"Remediation", control.Remediation},
+		{"Command", control.RemediationDetail},
+		{"Screenshot", control.ScreenshotGuide},
+		{"Console", control.ConsoleURL},
+	}
+	for _, section := range sections {
+		if section.text == "" {
+			continue
+		}
+		lines = append(lines, "", Color(Bold, section.title))
+		lines = append(lines, wrapText(section.text, b.width)...)
+	}
+	return lines
+}
+
+// wrapText breaks text into lines of at most width columns at spaces,
+// keeping its own line breaks. Words longer than width are left whole.
+func wrapText(text string, width int) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			if line != "" && visibleLength(line)+1+visibleLength(word) > width {
+				lines = append(lines, line)
+				line = ""
+			}
+			if line != "" {
+				line += " "
+			}
+			line += word
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
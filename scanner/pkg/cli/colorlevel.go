@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ColorLevel is how many colors the terminal can display
+type ColorLevel int
+
+const (
+	ColorNone ColorLevel = iota // no color: plain [PASS]/[FAIL] markers
+	Color16                     // basic ANSI colors
+	Color256                    // xterm 256-color palette
+	ColorTrue                   // 24-bit truecolor
+)
+
+// DetectColorLevel reads the terminal's color support from COLORTERM and
+// TERM. It does not consider NO_COLOR or whether stdout is a terminal; use
+// CurrentColorLevel for the level output is actually rendered at.
+func DetectColorLevel() ColorLevel {
+	term := strings.ToLower(os.Getenv("TERM"))
+	if term == "dumb" {
+		return ColorNone
+	}
+
+	switch strings.ToLower(os.Getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return ColorTrue
+	}
+	if strings.Contains(term, "256color") {
+		return Color256
+	}
+	return Color16
+}
+
+// CurrentColorLevel is the level Color renders at: ColorNone whenever
+// IsColorEnabled is false, otherwise the detected terminal capability. Color
+// forced on with SetColorEnabled renders at least the basic colors.
+func CurrentColorLevel() ColorLevel {
+	if !IsColorEnabled() {
+		return ColorNone
+	}
+	return max(DetectColorLevel(), Color16)
+}
+
+// color256 and colorTrue re-express the basic foreground colors with
+// palette entries that render more consistently than the terminal's own
+// 16-color scheme
+var (
+	color256 = strings.NewReplacer(
+		Red, "\033[38;5;160m",
+		Green, "\033[38;5;34m",
+		Yellow, "\033[38;5;178m",
+		Blue, "\033[38;5;33m",
+		Magenta, "\033[38;5;127m",
+		Cyan, "\033[38;5;37m",
+		BrightRed, "\033[38;5;196m",
+		BrightGreen, "\033[38;5;46m",
+		BrightYellow, "\033[38;5;226m",
+		BrightBlue, "\033[38;5;75m",
+		BrightCyan, "\033[38;5;51m",
+	)
+	colorTrue = strings.NewReplacer(
+		Red, "\033[38;2;215;58;73m",
+		Green, "\033[38;2;40;167;69m",
+		Yellow, "\033[38;2;219;171;9m",
+		Blue, "\033[38;2;3;102;214m",
+		Magenta, "\033[38;2;163;53;163m",
+		Cyan, "\033[38;2;27;161;179m",
+		BrightRed, "\033[38;2;255;69;58m",
+		BrightGreen, "\033[38;2;48;209;88m",
+		BrightYellow, "\033[38;2;255;214;10m",
+		BrightBlue, "\033[38;2;100;170;255m",
+		BrightCyan, "\033[38;2;100;210;255m",
+	)
+)
+
+// upgradeColor rewrites basic ANSI foreground codes in color for level
+func upgradeColor(color string, level ColorLevel) string {
+	switch level {
+	case ColorTrue:
+		return colorTrue.Replace(color)
+	case Color256:
+		return color256.Replace(color)
+	default:
+		return color
+	}
+}
+
+// scoreGradient returns a color sliding from red at 0% through amber to green
+// at 100%, so the score reads at a glance without the 60/80/90 steps
+func scoreGradient(score float64, level ColorLevel) string {
+	if score < 0 {
+		score = 0
+	} else if score > 100 {
+		score = 100
+	}
+
+	// red (215,58,73) -> amber (219,171,9) -> green (40,167,69)
+	var r, g, b float64
+	if score < 50 {
+		t := score / 50
+		r, g, b = 215+(219-215)*t, 58+(171-58)*t, 73+(9-73)*t
+	} else {
+		t := (score - 50) / 50
+		r, g, b = 219+(40-219)*t, 171+(167-171)*t, 9+(69-9)*t
+	}
+
+	if level == ColorTrue {
+		return fmt.Sprintf("\033[38;2;%d;%d;%dm", int(r), int(g), int(b))
+	}
+	// Nearest entry in the 6x6x6 color cube
+	cube := func(v float64) int { return int(v/255*5 + 0.5) }
+	return fmt.Sprintf("\033[38;5;%dm", 16+36*cube(r)+6*cube(g)+cube(b))
+}
@@ -4,7 +4,10 @@ package cli
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 )
 
 // ANSI color codes
@@ -35,80 +38,208 @@ const (
 	BgYellow   = "\033[43m"
 )
 
-// IsColorEnabled checks if color output should be enabled
+// Values of colorOverride
+const (
+	colorAuto int32 = iota // detect once from the environment
+	colorForcedOn
+	colorForcedOff
+)
+
+var (
+	// colorOverride is set by SetColorEnabled and wins over detection
+	colorOverride atomic.Int32
+
+	colorDetectOnce sync.Once
+	colorDetected   bool
+)
+
+// IsColorEnabled checks if color output should be enabled. It is the master
+// switch: when false, Color and the status helpers print plain text markers.
+// The environment is read once, so output does not change mid-run, unless
+// SetColorEnabled overrides it.
 func IsColorEnabled() bool {
-	// Disable colors if NO_COLOR env is set or not a terminal
-	if os.Getenv("NO_COLOR") != "" {
+	switch colorOverride.Load() {
+	case colorForcedOn:
+		return true
+	case colorForcedOff:
+		return false
+	}
+	colorDetectOnce.Do(func() { colorDetected = detectColor() })
+	return colorDetected
+}
+
+// SetColorEnabled forces color output on or off, overriding NO_COLOR, TERM
+// and terminal detection, e.g. for deterministic test output. It is safe to
+// call while other goroutines render.
+func SetColorEnabled(enabled bool) {
+	if enabled {
+		colorOverride.Store(colorForcedOn)
+	} else {
+		colorOverride.Store(colorForcedOff)
+	}
+}
+
+// detectColor reports whether stdout wants color: not disabled by NO_COLOR,
+// not a dumb terminal, and a terminal rather than a pipe or file
+func detectColor() bool {
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	fi, err := os.Stdout.Stat()
+	if err != nil {
 		return false
 	}
-	// Check if stdout is a terminal
-	fi, _ := os.Stdout.Stat()
 	return (fi.Mode() & os.ModeCharDevice) != 0
 }
 
-// Color wraps text with color codes
+// Color wraps text with color codes, upgraded to the 256-color or truecolor
+// palette when the terminal supports it. An empty color, or color disabled,
+// leaves text unchanged.
 func Color(color, text string) string {
-	if !IsColorEnabled() {
+	level := CurrentColorLevel()
+	if color == "" || level == ColorNone {
 		return text
 	}
-	return color + text + Reset
+	return upgradeColor(color, level) + text + Reset
 }
 
-// Pass returns green-colored "[PASS]"
+// Pass returns "[PASS]" in the theme's pass color
 func Pass() string {
-	return Color(Green, "[PASS]")
+	return Color(activeTheme.Pass, "[PASS]")
 }
 
-// Fail returns red-colored "[FAIL]"
+// Fail returns "[FAIL]" in the theme's fail color
 func Fail() string {
-	return Color(Red, "[FAIL]")
+	return Color(activeTheme.Fail, "[FAIL]")
 }
 
-// Warn returns yellow-colored "[WARN]"
+// Warn returns "[WARN]" in the theme's warning color
 func Warn() string {
-	return Color(Yellow, "[WARN]")
+	return Color(activeTheme.Warn, "[WARN]")
 }
 
-// Info returns cyan-colored "[INFO]"
+// Info returns "[INFO]" in the theme's info color
 func Info() string {
-	return Color(Cyan, "[INFO]")
+	return Color(activeTheme.Info, "[INFO]")
 }
 
-// Critical returns bright red bold "[CRITICAL]"
+// Critical returns "[CRITICAL]" in the theme's critical color
 func Critical() string {
-	return Color(Bold+BrightRed, "[CRITICAL]")
+	return Color(activeTheme.Critical, "[CRITICAL]")
 }
 
-// High returns yellow "[HIGH]"
+// High returns "[HIGH]" in the theme's high severity color
 func High() string {
-	return Color(Yellow, "[HIGH]")
+	return Color(activeTheme.High, "[HIGH]")
 }
 
-// Medium returns blue "[MEDIUM]"
+// Medium returns "[MEDIUM]" in the theme's medium severity color
 func Medium() string {
-	return Color(Blue, "[MEDIUM]")
+	return Color(activeTheme.Medium, "[MEDIUM]")
 }
 
-// Low returns dim "[LOW]"
+// Low returns "[LOW]" in the theme's low severity color
 func Low() string {
-	return Color(Dim, "[LOW]")
+	return Color(activeTheme.Low, "[LOW]")
+}
+
+// ScoreThresholds are the lowest scores that get the excellent, good and fair
+// colors. Scores below Fair get the poor color.
+type ScoreThresholds struct {
+	Excellent float64
+	Good      float64
+	Fair      float64
+}
+
+// DefaultScoreThresholds are the 90/80/60 breakpoints used unless
+// SetScoreThresholds changes them
+var DefaultScoreThresholds = ScoreThresholds{Excellent: 90, Good: 80, Fair: 60}
+
+// scoreThresholds are the breakpoints in use
+var scoreThresholds = DefaultScoreThresholds
+
+// SetScoreThresholds changes the score color breakpoints. They must be
+// between 0 and 100 and descend from Excellent to Fair.
+func SetScoreThresholds(t ScoreThresholds) error {
+	if t.Fair < 0 || t.Excellent > 100 || t.Good < t.Fair || t.Excellent < t.Good {
+		return fmt.Errorf("invalid score thresholds %g/%g/%g (want 100 >= excellent >= good >= fair >= 0)", t.Excellent, t.Good, t.Fair)
+	}
+	scoreThresholds = t
+	return nil
+}
+
+// ParseScoreThresholds parses "excellent,good,fair", e.g. "95,85,70"
+func ParseScoreThresholds(value string) (ScoreThresholds, error) {
+	parts := strings.Split(value, ",")
+	if len(parts) != 3 {
+		return ScoreThresholds{}, fmt.Errorf("invalid score thresholds %q (want excellent,good,fair, e.g. 95,85,70)", value)
+	}
+	values := make([]float64, len(parts))
+	for i, part := range parts {
+		v, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return ScoreThresholds{}, fmt.Errorf("invalid score threshold %q: %w", part, err)
+		}
+		values[i] = v
+	}
+	return ScoreThresholds{Excellent: values[0], Good: values[1], Fair: values[2]}, nil
+}
+
+// Score bands, from the configured score thresholds
+const (
+	ScoreBandExcellent = "excellent"
+	ScoreBandGood      = "good"
+	ScoreBandFair      = "fair"
+	ScoreBandPoor      = "poor"
+)
+
+// ScoreBand returns the band a compliance score falls in under the configured
+// score thresholds, for renderers that pick their own colors per band
+func ScoreBand(score float64) string {
+	if score >= scoreThresholds.Excellent {
+		return ScoreBandExcellent
+	} else if score >= scoreThresholds.Good {
+		return ScoreBandGood
+	} else if score >= scoreThresholds.Fair {
+		return ScoreBandFair
+	}
+	return ScoreBandPoor
 }
 
-// ScoreColor returns appropriate color for a compliance score
+// ScoreColor returns the active theme's color for a compliance score, banded
+// by the configured score thresholds
 func ScoreColor(score float64) string {
-	if score >= 90 {
-		return BrightGreen
-	} else if score >= 80 {
-		return Green
-	} else if score >= 60 {
-		return Yellow
+	switch ScoreBand(score) {
+	case ScoreBandExcellent:
+		return activeTheme.ScoreExcellent
+	case ScoreBandGood:
+		return activeTheme.ScoreGood
+	case ScoreBandFair:
+		return activeTheme.ScoreFair
 	}
-	return Red
+	return activeTheme.ScorePoor
 }
 
-// FormatScore formats a compliance score with color
+// scoreGradientEnabled is whether FormatScore uses the continuous gradient, set by
+// SetScoreGradient
+var scoreGradientEnabled bool
+
+// SetScoreGradient makes FormatScore color scores along a red-to-green
+// gradient on 256-color and truecolor terminals instead of the theme's banded
+// colors. Custom score thresholds and uncolored themes keep the bands.
+func SetScoreGradient(enabled bool) {
+	scoreGradientEnabled = enabled
+}
+
+// FormatScore formats a compliance score in the active theme's color for its
+// band, or along the gradient if SetScoreGradient enabled it
 func FormatScore(score float64) string {
-	return Color(ScoreColor(score), fmt.Sprintf("%.1f%%", score))
+	text := fmt.Sprintf("%.1f%%", score)
+	level := CurrentColorLevel()
+	if scoreGradientEnabled && level >= Color256 && activeTheme.ScorePoor != "" && scoreThresholds == DefaultScoreThresholds {
+		return scoreGradient(score, level) + text + Reset
+	}
+	return Color(ScoreColor(score), text)
 }
 
 // FormatStatus returns formatted status with appropriate color
@@ -0,0 +1,49 @@
+package cli
+
+import "testing"
+
+func TestCustomScoreThresholdsMoveBands(t *testing.T) {
+	defer SetScoreThresholds(DefaultScoreThresholds)
+
+	if got := ScoreBand(88); got != ScoreBandGood {
+		t.Fatalf("88%% with the default thresholds is %s, want %s", got, ScoreBandGood)
+	}
+	if err := SetScoreThresholds(ScoreThresholds{Excellent: 85, Good: 75, Fair: 50}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		score float64
+		band  string
+		color string
+	}{
+		{88, ScoreBandExcellent, activeTheme.ScoreExcellent},
+		{80, ScoreBandGood, activeTheme.ScoreGood},
+		{55, ScoreBandFair, activeTheme.ScoreFair},
+		{49.9, ScoreBandPoor, activeTheme.ScorePoor},
+	} {
+		if got := ScoreBand(tc.score); got != tc.band {
+			t.Errorf("ScoreBand(%g) = %s, want %s", tc.score, got, tc.band)
+		}
+		if got := ScoreColor(tc.score); got != tc.color {
+			t.Errorf("ScoreColor(%g) = %q, want the theme's %s color %q", tc.score, got, tc.band, tc.color)
+		}
+	}
+}
+
+func TestSetScoreThresholdsRejectsInvalidBreakpoints(t *testing.T) {
+	defer SetScoreThresholds(DefaultScoreThresholds)
+
+	for _, thresholds := range []ScoreThresholds{
+		{Excellent: 101, Good: 80, Fair: 60},
+		{Excellent: 90, Good: 80, Fair: -1},
+		{Excellent: 80, Good: 90, Fair: 60},
+	} {
+		if err := SetScoreThresholds(thresholds); err == nil {
+			t.Errorf("SetScoreThresholds(%+v) accepted invalid breakpoints", thresholds)
+		}
+	}
+	if scoreThresholds != DefaultScoreThresholds {
+		t.Errorf("rejected thresholds changed the breakpoints to %+v", scoreThresholds)
+	}
+}
@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/guardian-nexus/auditkit/scanner/pkg/offline"
+)
+
+// ScanDelta renders a one-line comparison of current against the previous
+// scan of the same account, e.g.
+//
+//	Since last scan (2026-01-02 15:04): ▲ +3 newly failing, ▼ -5 fixed, score 72.0% → 78.0% ▲
+//
+// Arrows are colored by whether the change is good (pass color) or bad (fail
+// color). When the two scans ran different check catalogs, the score change
+// those checks account for is called out, e.g. "(-2.5 due to 4 new checks)",
+// so an upgrade is not read as a change in posture. Returns "" when there is
+// no previous scan.
+func ScanDelta(current, previous *offline.CachedScan) string {
+	if current == nil || previous == nil {
+		return ""
+	}
+	diff := offline.DiffScans(current, previous)
+
+	parts := []string{
+		countDelta(len(diff.NewFailures), "+", "newly failing", activeTheme.Fail),
+		countDelta(len(diff.Resolved), "-", "fixed", activeTheme.Pass),
+	}
+
+	score := fmt.Sprintf("score %.1f%% → %.1f%%", previous.Score, current.Score)
+	switch {
+	case diff.ScoreDelta > 0:
+		score += " " + Color(activeTheme.Pass, "▲")
+	case diff.ScoreDelta < 0:
+		score += " " + Color(activeTheme.Fail, "▼")
+	}
+	if change := diff.CatalogChange(); change != "" {
+		score += fmt.Sprintf(" (%+.1f due to %s)", diff.CatalogScoreDelta, change)
+	}
+	parts = append(parts, score)
+
+	return fmt.Sprintf("  Since last scan (%s): %s\n",
+		previous.Timestamp.Local().Format("2006-01-02 15:04"), strings.Join(parts, ", "))
+}
+
+// countDelta formats a change count, with a colored arrow when it is nonzero:
+// up for new failures, down for fixes
+func countDelta(count int, sign, label, color string) string {
+	if count == 0 {
+		return fmt.Sprintf("0 %s", label)
+	}
+
+	arrow := "▲"
+	if sign == "-" {
+		arrow = "▼"
+	}
+	return fmt.Sprintf("%s %s%d %s", Color(color, arrow), sign, count, label)
+}
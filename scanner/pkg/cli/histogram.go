@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/guardian-nexus/auditkit/scanner/pkg/aws/checks"
+)
+
+// histogramSeverities are the histogram rows, most severe first, with the
+// theme color each bar is drawn in
+var histogramSeverities = []struct {
+	name  string
+	color func(Theme) string
+}{
+	{"CRITICAL", func(t Theme) string { return t.Critical }},
+	{"HIGH", func(t Theme) string { return t.High }},
+	{"MEDIUM", func(t Theme) string { return t.Medium }},
+	{"LOW", func(t Theme) string { return t.Low }},
+}
+
+// SeverityHistogram renders failing results as a horizontal bar chart of
+// counts by severity, one row per severity, scaled so the largest bar fills
+// the terminal width. Severities with no findings get a row with an empty
+// bar; any finding gets at least one block so small counts stay visible.
+func SeverityHistogram(results []checks.CheckResult) string {
+	counts := map[string]int{}
+	largest := 0
+	for _, result := range results {
+		if result.Status != "FAIL" {
+			continue
+		}
+		severity := strings.ToUpper(result.Severity)
+		counts[severity]++
+		largest = max(largest, counts[severity])
+	}
+
+	const indent, labelWidth = 2, 9
+	countWidth := len(fmt.Sprint(largest))
+	barWidth := max(TerminalWidth()-indent-labelWidth-countWidth-1, 1)
+
+	var b strings.Builder
+	for _, row := range histogramSeverities {
+		count := counts[row.name]
+
+		length := 0
+		if count > 0 {
+			length = max(count*barWidth/largest, 1)
+		}
+
+		bar := Color(row.color(activeTheme), strings.Repeat("█", length))
+		if count == 0 {
+			bar = Color(Dim, "·")
+		}
+		fmt.Fprintf(&b, "%s%-*s%*d %s\n", strings.Repeat(" ", indent), labelWidth, row.name, countWidth, count, bar)
+	}
+	return b.String()
+}
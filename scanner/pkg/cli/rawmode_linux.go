@@ -0,0 +1,9 @@
+package cli
+
+import "golang.org/x/sys/unix"
+
+// termios ioctl requests used by enableRawInput
+const (
+	ioctlGetTermios = unix.TCGETS
+	ioctlSetTermios = unix.TCSETS
+)
@@ -0,0 +1,11 @@
+//go:build !(linux || darwin || dragonfly || freebsd || netbsd || openbsd)
+
+package cli
+
+import "errors"
+
+// enableRawInput is not supported on this platform; Browse falls back to the
+// static table
+func enableRawInput(fd int) (func(), error) {
+	return nil, errors.New("raw terminal input is not supported on this platform")
+}
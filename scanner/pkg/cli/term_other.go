@@ -0,0 +1,15 @@
+//go:build !unix
+
+package cli
+
+// detectTerminalWidth is not supported on this platform; callers fall back to
+// $COLUMNS or DefaultWidth
+func detectTerminalWidth() int {
+	return 0
+}
+
+// detectTerminalHeight is not supported on this platform; callers fall back
+// to $LINES or a default
+func detectTerminalHeight() int {
+	return 0
+}
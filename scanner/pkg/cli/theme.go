@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Theme is the palette used for status, severity and score colors. Each field
+// is an ANSI sequence; an empty field prints the text uncolored.
+type Theme struct {
+	Name string
+
+	Pass string
+	Fail string
+	Warn string
+	Info string
+
+	Critical string
+	High     string
+	Medium   string
+	Low      string
+
+	// Scores of 90+, 80+, 60+ and below 60
+	ScoreExcellent string
+	ScoreGood      string
+	ScoreFair      string
+	ScorePoor      string
+}
+
+// DarkTheme is the default palette, tuned for dark terminal backgrounds
+var DarkTheme = Theme{
+	Name:           "dark",
+	Pass:           Green,
+	Fail:           Red,
+	Warn:           Yellow,
+	Info:           Cyan,
+	Critical:       Bold + BrightRed,
+	High:           Yellow,
+	Medium:         Blue,
+	Low:            Dim,
+	ScoreExcellent: BrightGreen,
+	ScoreGood:      Green,
+	ScoreFair:      Yellow,
+	ScorePoor:      Red,
+}
+
+// LightTheme avoids yellow, bright and dim colors, which wash out on light
+// terminal backgrounds
+var LightTheme = Theme{
+	Name:           "light",
+	Pass:           Green,
+	Fail:           Red,
+	Warn:           Magenta,
+	Info:           Blue,
+	Critical:       Bold + Red,
+	High:           Magenta,
+	Medium:         Blue,
+	Low:            "",
+	ScoreExcellent: Bold + Green,
+	ScoreGood:      Green,
+	ScoreFair:      Magenta,
+	ScorePoor:      Red,
+}
+
+// HighContrastTheme uses bold colors and a background for critical findings
+var HighContrastTheme = Theme{
+	Name:           "high-contrast",
+	Pass:           Bold + BrightGreen,
+	Fail:           Bold + BrightRed,
+	Warn:           Bold + BrightYellow,
+	Info:           Bold + BrightCyan,
+	Critical:       Bold + White + BgRed,
+	High:           Bold + BrightYellow,
+	Medium:         Bold + BrightBlue,
+	Low:            Bold,
+	ScoreExcellent: Bold + BrightGreen,
+	ScoreGood:      Bold + BrightGreen,
+	ScoreFair:      Bold + BrightYellow,
+	ScorePoor:      Bold + BrightRed,
+}
+
+// MonoTheme uses no color, only bold for failures and critical findings
+var MonoTheme = Theme{
+	Name:     "mono",
+	Fail:     Bold,
+	Critical: Bold,
+	High:     Bold,
+}
+
+// themes are the palettes selectable by name
+var themes = []Theme{DarkTheme, LightTheme, HighContrastTheme, MonoTheme}
+
+// activeTheme is the palette in use, chosen by AUDITKIT_THEME or SetTheme
+var activeTheme = themeFromEnv()
+
+func themeFromEnv() Theme {
+	if theme, ok := lookupTheme(os.Getenv("AUDITKIT_THEME")); ok {
+		return theme
+	}
+	return DarkTheme
+}
+
+func lookupTheme(name string) (Theme, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, theme := range themes {
+		if theme.Name == name {
+			return theme, true
+		}
+	}
+	return Theme{}, false
+}
+
+// SetTheme selects the palette by name: dark, light, high-contrast or mono
+func SetTheme(name string) error {
+	theme, ok := lookupTheme(name)
+	if !ok {
+		return fmt.Errorf("unknown theme %q (use %s)", name, strings.Join(ThemeNames(), ", "))
+	}
+	activeTheme = theme
+	return nil
+}
+
+// ActiveTheme returns the palette in use
+func ActiveTheme() Theme {
+	return activeTheme
+}
+
+// ThemeNames returns the names SetTheme accepts
+func ThemeNames() []string {
+	names := make([]string, 0, len(themes))
+	for _, theme := range themes {
+		names = append(names, theme.Name)
+	}
+	return names
+}
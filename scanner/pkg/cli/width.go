@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"os"
+	"strconv"
+)
+
+// DefaultWidth is used when the terminal width cannot be detected (pipes, CI)
+const DefaultWidth = 80
+
+var (
+	// widthOverride is set by the --width flag; 0 means detect
+	widthOverride int
+
+	// minWidth and maxWidth clamp the detected or overridden width
+	minWidth = 40
+	maxWidth = 100
+)
+
+// SetWidth forces the output width used by boxes and tables. 0 restores
+// detection. The value is still clamped to the configured bounds.
+func SetWidth(width int) {
+	widthOverride = width
+}
+
+// SetWidthBounds configures the min/max width boxes and tables may use
+func SetWidthBounds(min, max int) {
+	if min > 0 {
+		minWidth = min
+	}
+	if max >= minWidth {
+		maxWidth = max
+	}
+}
+
+// TerminalWidth returns the width to render boxes and tables at: the --width
+// override, else the width of the terminal on stdout, else $COLUMNS, else
+// DefaultWidth, clamped to the configured bounds
+func TerminalWidth() int {
+	width := widthOverride
+	if width <= 0 {
+		width = detectTerminalWidth()
+	}
+	if width <= 0 {
+		if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil {
+			width = columns
+		}
+	}
+	if width <= 0 {
+		width = DefaultWidth
+	}
+
+	if width < minWidth {
+		width = minWidth
+	}
+	if width > maxWidth {
+		width = maxWidth
+	}
+	return width
+}
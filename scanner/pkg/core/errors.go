@@ -0,0 +1,46 @@
+package core
+
+import "errors"
+
+// Kinds of scan failure, shared by every provider and the offline cache so
+// callers can react with errors.Is: retry on ErrThrottled, warn and continue
+// on ErrServiceUnavailable, fix credentials on ErrAccessDenied, rescan on
+// ErrStaleCache.
+var (
+	ErrAccessDenied       = errors.New("access denied")
+	ErrThrottled          = errors.New("request throttled")
+	ErrServiceUnavailable = errors.New("service unavailable")
+	ErrStaleCache         = errors.New("cached scan is stale")
+)
+
+// ScanError is a provider failure classified as one of the kinds above. It
+// matches its Kind with errors.Is and still unwraps to the provider's own
+// error, so SDK error types remain reachable with errors.As.
+type ScanError struct {
+	Kind error // ErrAccessDenied, ErrThrottled or ErrServiceUnavailable
+	Err  error
+}
+
+func (e *ScanError) Error() string {
+	return e.Kind.Error() + ": " + e.Err.Error()
+}
+
+func (e *ScanError) Unwrap() []error {
+	return []error{e.Kind, e.Err}
+}
+
+// ErrorKindName names the kind of err for counting in ScanMetadata.CheckErrors:
+// "access_denied", "throttled", "service_unavailable", or "other" for errors
+// of no known kind
+func ErrorKindName(err error) string {
+	switch {
+	case errors.Is(err, ErrAccessDenied):
+		return "access_denied"
+	case errors.Is(err, ErrThrottled):
+		return "throttled"
+	case errors.Is(err, ErrServiceUnavailable):
+		return "service_unavailable"
+	default:
+		return "other"
+	}
+}
@@ -0,0 +1,22 @@
+package core
+
+import "strings"
+
+// Identity is who and what a scan ran against, as detected from the cloud
+// provider's own credentials rather than user-supplied flags
+type Identity struct {
+	Provider  string `json:"provider"`
+	AccountID string `json:"account_id"`
+	Principal string `json:"principal,omitempty"` // e.g. STS caller ARN
+	Partition string `json:"partition,omitempty"` // aws, aws-us-gov, aws-cn
+}
+
+// PartitionFromARN returns the partition segment of an ARN ("aws" for
+// "arn:aws:iam::123456789012:root"), or "" if arn is not an ARN
+func PartitionFromARN(arn string) string {
+	parts := strings.SplitN(arn, ":", 3)
+	if len(parts) < 3 || parts[0] != "arn" {
+		return ""
+	}
+	return parts[1]
+}
@@ -0,0 +1,198 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ScanMetadata records who ran a scan, against what, and how long it took.
+// It travels with cached scans and is emitted by the report writers so
+// auditors can answer "who ran this and against what".
+type ScanMetadata struct {
+	ToolVersion       string         `json:"tool_version"`
+	ScanProfile       string         `json:"scan_profile,omitempty"` // "quick" or "deep"; a quick scan skipped per-resource checks
+	StartTime         time.Time      `json:"start_time"`
+	EndTime           time.Time      `json:"end_time"`
+	DurationSeconds   float64        `json:"duration_seconds"`
+	Services          []string       `json:"services"`
+	Checks            []CheckRef     `json:"checks,omitempty"`          // the check catalog the scan ran, to attribute score changes to added or removed checks
+	CallerIdentity    string         `json:"caller_identity,omitempty"` // e.g. STS caller ARN
+	Identity          *Identity      `json:"identity,omitempty"`
+	Aborted           bool           `json:"aborted,omitempty"`            // scan stopped early; results are partial
+	AbortReason       string         `json:"abort_reason,omitempty"`       // why the scan stopped early
+	CompletedServices []string       `json:"completed_services,omitempty"` // on an aborted scan, the services that ran to completion
+	Stale             bool           `json:"stale,omitempty"`              // results come from the offline cache because the live scan failed
+	StaleReason       string         `json:"stale_reason,omitempty"`       // why the live scan failed
+	CacheAgeSeconds   float64        `json:"cache_age_seconds,omitempty"`  // on a stale scan, how old the cached results are
+	ServiceTimings    []Timing       `json:"service_timings,omitempty"`    // run time per check module, slowest first
+	SlowestChecks     []Timing       `json:"slowest_checks,omitempty"`     // the SlowestChecksLimit slowest checks
+	DescribeCache     *CacheStats    `json:"describe_cache,omitempty"`     // in-scan memoization of repeated describe calls
+	CheckErrors       map[string]int `json:"check_errors,omitempty"`       // checks that errored instead of producing a result, by ErrorKindName
+}
+
+// CheckRef names one check in a scan's catalog
+type CheckRef struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Timing is how long one check or check module took to run
+type Timing struct {
+	Name    string  `json:"name"`
+	Seconds float64 `json:"seconds"`
+}
+
+// CacheStats counts lookups answered from a cache (hits) and those that had
+// to call the provider (misses)
+type CacheStats struct {
+	Hits   int64 `json:"hits"`
+	Misses int64 `json:"misses"`
+}
+
+// SlowestChecksLimit is how many checks SetTimings keeps in SlowestChecks
+const SlowestChecksLimit = 10
+
+// SortTimings orders timings slowest first, then by name
+func SortTimings(timings []Timing) {
+	sort.Slice(timings, func(i, j int) bool {
+		if timings[i].Seconds != timings[j].Seconds {
+			return timings[i].Seconds > timings[j].Seconds
+		}
+		return timings[i].Name < timings[j].Name
+	})
+}
+
+// SetTimings records per-service run times and the slowest checks
+func (m *ScanMetadata) SetTimings(services, checks []Timing) {
+	m.ServiceTimings = append([]Timing{}, services...)
+	SortTimings(m.ServiceTimings)
+
+	slowest := append([]Timing{}, checks...)
+	SortTimings(slowest)
+	if len(slowest) > SlowestChecksLimit {
+		slowest = slowest[:SlowestChecksLimit]
+	}
+	m.SlowestChecks = slowest
+}
+
+// SetDescribeCacheStats records how well in-scan memoization of describe
+// calls worked. Nothing is recorded if no memoized call was made.
+func (m *ScanMetadata) SetDescribeCacheStats(hits, misses int64) {
+	if hits+misses == 0 {
+		m.DescribeCache = nil
+		return
+	}
+	m.DescribeCache = &CacheStats{Hits: hits, Misses: misses}
+}
+
+// SetCheckErrors records how many checks errored, by ErrorKindName. Nothing
+// is recorded if none did.
+func (m *ScanMetadata) SetCheckErrors(counts map[string]int) {
+	m.CheckErrors = nil
+	for kind, n := range counts {
+		if n <= 0 {
+			continue
+		}
+		if m.CheckErrors == nil {
+			m.CheckErrors = map[string]int{}
+		}
+		m.CheckErrors[kind] = n
+	}
+}
+
+// CheckErrorCount is how many checks errored, of any kind
+func (m *ScanMetadata) CheckErrorCount() int {
+	total := 0
+	for _, n := range m.CheckErrors {
+		total += n
+	}
+	return total
+}
+
+// NewScanMetadata starts the scan clock
+func NewScanMetadata(toolVersion string) *ScanMetadata {
+	return &ScanMetadata{
+		ToolVersion: toolVersion,
+		StartTime:   time.Now(),
+		Services:    []string{},
+	}
+}
+
+// Finish stops the scan clock and records the services that ran (sorted)
+func (m *ScanMetadata) Finish(services []string) {
+	m.EndTime = time.Now()
+	m.DurationSeconds = m.EndTime.Sub(m.StartTime).Seconds()
+
+	m.Services = append([]string{}, services...)
+	sort.Strings(m.Services)
+}
+
+// SetChecks records the checks the scan ran (sorted by ID). Comparing the
+// catalogs of two scans tells a score change caused by a new tool version
+// adding or removing checks from one caused by remediation.
+func (m *ScanMetadata) SetChecks(checks []CheckRef) {
+	m.Checks = append([]CheckRef{}, checks...)
+	sort.Slice(m.Checks, func(i, j int) bool {
+		return m.Checks[i].ID < m.Checks[j].ID
+	})
+}
+
+// SetIdentity records the detected identity, including its principal as
+// CallerIdentity
+func (m *ScanMetadata) SetIdentity(id Identity) {
+	m.Identity = &id
+	m.CallerIdentity = id.Principal
+}
+
+// Abort marks the scan as stopped early, so reports flag its results as
+// incomplete
+func (m *ScanMetadata) Abort(reason string) {
+	m.Aborted = true
+	m.AbortReason = reason
+}
+
+// SetCompletedServices records which services ran to completion before an
+// aborted scan stopped (sorted)
+func (m *ScanMetadata) SetCompletedServices(services []string) {
+	m.CompletedServices = append([]string{}, services...)
+	sort.Strings(m.CompletedServices)
+}
+
+// MarkStale flags the results as served from the offline cache, cacheAge
+// old, because the live scan failed with reason
+func (m *ScanMetadata) MarkStale(reason string, cacheAge time.Duration) {
+	m.Stale = true
+	m.StaleReason = reason
+	m.CacheAgeSeconds = cacheAge.Seconds()
+}
+
+// CacheAge returns how old the cached results of a stale scan are
+func (m *ScanMetadata) CacheAge() time.Duration {
+	return time.Duration(m.CacheAgeSeconds * float64(time.Second))
+}
+
+// Duration returns the scan duration
+func (m *ScanMetadata) Duration() time.Duration {
+	return m.EndTime.Sub(m.StartTime)
+}
+
+// Summary returns a one-line description for report headers
+func (m *ScanMetadata) Summary() string {
+	identity := m.CallerIdentity
+	if identity == "" {
+		identity = "unknown identity"
+	}
+	summary := fmt.Sprintf("AuditKit %s | Run by %s | Duration %s | %d services",
+		m.ToolVersion, identity, m.Duration().Round(time.Second), len(m.Services))
+	if m.ScanProfile == "quick" {
+		summary += " | QUICK PROFILE: per-resource checks skipped"
+	}
+	if m.Aborted {
+		summary += " | INCOMPLETE: " + m.AbortReason
+	}
+	if m.Stale {
+		summary += fmt.Sprintf(" | STALE/OFFLINE: cached results %s old", m.CacheAge().Round(time.Minute))
+	}
+	return summary
+}
@@ -0,0 +1,66 @@
+package evidence
+
+import "strings"
+
+// Checklist is the structured form of a finding's free-text ScreenshotGuide:
+// the console navigation steps, the value the screenshot must show, and a
+// deep link to start from. Reports render it as a step-by-step checklist.
+type Checklist struct {
+	Steps      []string `json:"steps"`
+	Expected   string   `json:"expected,omitempty"`
+	ConsoleURL string   `json:"console_url,omitempty"`
+}
+
+// ParseGuide converts a ScreenshotGuide string such as
+// "Redshift Console → Clusters → Select cluster → Screenshot showing 'Encrypted: Yes'"
+// into a Checklist. Returns nil when the guide is empty.
+func ParseGuide(guide, consoleURL string) *Checklist {
+	guide = strings.TrimSpace(guide)
+	if guide == "" {
+		return nil
+	}
+
+	checklist := &Checklist{
+		Steps:      []string{},
+		ConsoleURL: consoleURL,
+	}
+
+	// Bullet lines after the path ("- No rules with ...") describe what the
+	// screenshot must show
+	lines := strings.Split(guide, "\n")
+	path := strings.TrimSpace(lines[0])
+	expected := []string{}
+	for _, line := range lines[1:] {
+		line = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "-•*"))
+		if line != "" {
+			expected = append(expected, line)
+		}
+	}
+
+	path = strings.ReplaceAll(path, "->", "→")
+	for _, segment := range strings.Split(path, "→") {
+		segment = strings.TrimSpace(segment)
+		if segment == "" {
+			continue
+		}
+
+		lower := strings.ToLower(segment)
+		if strings.HasPrefix(lower, "screenshot") {
+			// "Screenshot showing 'X'" -> expected value X
+			value := segment[len("screenshot"):]
+			value = strings.TrimSpace(value)
+			if strings.HasPrefix(strings.ToLower(value), "showing") {
+				value = strings.TrimSpace(value[len("showing"):])
+			}
+			value = strings.Trim(strings.TrimSpace(strings.TrimSuffix(value, ":")), "'\"")
+			if value != "" {
+				expected = append([]string{value}, expected...)
+			}
+			continue
+		}
+		checklist.Steps = append(checklist.Steps, segment)
+	}
+
+	checklist.Expected = strings.Join(expected, "; ")
+	return checklist
+}
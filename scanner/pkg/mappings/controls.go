@@ -0,0 +1,138 @@
+package mappings
+
+import "strings"
+
+// ControlInfo describes a framework control so reports can say what a control
+// ID means and why it matters, not just which check failed
+type ControlInfo struct {
+	ID          string
+	Title       string
+	Description string
+	Rationale   string
+}
+
+// LookupControl returns the catalog entry for a finding's Control ID. SOC2
+// criteria ("CC6.3") and CMMC practices ("AC.L1-3.1.1") match exactly; PCI
+// IDs ("PCI-8.3.1") fall back to their top-level requirement. CIS
+// recommendations are too numerous to catalog and are not found.
+func LookupControl(id string) (ControlInfo, bool) {
+	id = strings.Trim(strings.TrimSpace(id), "[]")
+
+	if info, ok := controlCatalog[id]; ok {
+		return info, true
+	}
+
+	if strings.HasPrefix(id, "PCI-") {
+		requirement, _, _ := strings.Cut(strings.TrimPrefix(id, "PCI-"), ".")
+		if info, ok := pciRequirements[requirement]; ok {
+			info.ID = id
+			return info, true
+		}
+	}
+
+	return ControlInfo{}, false
+}
+
+// controlCatalog holds the SOC2 Trust Services Criteria and CMMC Level 1
+// practices, keyed by the ID checks put in CheckResult.Control
+var controlCatalog = buildControlCatalog([]ControlInfo{
+	// SOC2 CC1 - Control Environment
+	{"CC1.1", "Integrity and Ethical Values", "The organization demonstrates a commitment to integrity and ethical values.", "Controls only work when leadership sets and enforces expectations for how people behave."},
+	{"CC1.2", "Board Oversight", "The board exercises oversight of the development and performance of internal control.", "Independent oversight catches control failures management has reason to overlook."},
+	{"CC1.3", "Structures, Reporting Lines and Authority", "Management establishes structures, reporting lines and appropriate authorities and responsibilities.", "Clear ownership means every control has someone accountable for running it."},
+	{"CC1.4", "Competence", "The organization attracts, develops and retains competent individuals.", "Controls operated by people without the right skills fail silently."},
+	{"CC1.5", "Accountability", "The organization holds individuals accountable for their internal control responsibilities.", "Without accountability, control duties lapse as soon as they become inconvenient."},
+
+	// SOC2 CC2 - Communication and Information
+	{"CC2.1", "Quality Information", "The organization obtains or generates relevant, quality information to support internal control.", "Decisions about risk are only as good as the information, such as logs and inventories, behind them."},
+	{"CC2.2", "Internal Communication", "The organization internally communicates information, including control objectives and responsibilities.", "People cannot follow security policies they have never been told about."},
+	{"CC2.3", "External Communication", "The organization communicates with external parties about matters affecting internal control.", "Customers and partners need a channel to report and hear about security issues."},
+
+	// SOC2 CC3 - Risk Assessment
+	{"CC3.1", "Risk Objectives", "The organization specifies objectives clearly enough to identify and assess related risks.", "Risks can only be measured against stated objectives."},
+	{"CC3.2", "Risk Identification and Analysis", "The organization identifies and analyzes risks to achieving its objectives.", "Unidentified risks go untreated until they become incidents."},
+	{"CC3.3", "Fraud Risk", "The organization considers the potential for fraud in assessing risks.", "Insiders with excessive access are a common source of loss."},
+	{"CC3.4", "Change Impact", "The organization identifies and assesses changes that could significantly affect internal control.", "New services and architectures introduce risks the existing controls were not designed for."},
+
+	// SOC2 CC4 - Monitoring Activities
+	{"CC4.1", "Ongoing Evaluations", "The organization performs ongoing or separate evaluations to confirm controls are present and functioning.", "Controls drift over time; continuous monitoring catches the drift before an auditor or attacker does."},
+	{"CC4.2", "Deficiency Communication", "The organization evaluates and communicates control deficiencies in a timely manner.", "A known deficiency that nobody acts on is still an open exposure."},
+
+	// SOC2 CC5 - Control Activities
+	{"CC5.1", "Control Selection", "The organization selects and develops control activities that mitigate risks to acceptable levels.", "Risks identified in assessment must map to concrete controls."},
+	{"CC5.2", "Technology Controls", "The organization selects and develops general control activities over technology.", "Cloud configuration is itself a control; misconfiguration undoes the policies on paper."},
+	{"CC5.3", "Policies and Procedures", "The organization deploys control activities through policies and procedures.", "Procedures turn policy into repeatable practice."},
+
+	// SOC2 CC6 - Logical and Physical Access Controls
+	{"CC6.1", "Logical Access Security", "The organization implements logical access security software, infrastructure and architectures over protected information assets.", "Network and identity boundaries are the first line of defense against unauthorized access."},
+	{"CC6.2", "User Registration and Authorization", "Prior to issuing credentials, the organization registers and authorizes new users and removes access when no longer needed.", "Stale or unapproved accounts are a common path for attackers."},
+	{"CC6.3", "Role-Based Access and Data Protection", "The organization authorizes, modifies or removes access based on roles and least privilege, and protects data at rest.", "Least privilege and encryption limit what an attacker gains from any single compromised identity or disk."},
+	{"CC6.4", "Restricted Physical and Transport Access", "The organization restricts access to facilities and protected assets, including data in transit.", "Unencrypted traffic can be read or altered by anyone on the path."},
+	{"CC6.5", "Asset Disposal", "The organization discontinues logical and physical protections over assets only after data has been removed.", "Leftover snapshots and decommissioned resources can still hold sensitive data."},
+	{"CC6.6", "External Threat Protection", "The organization implements controls to prevent or detect threats from sources outside its system boundaries.", "Internet-facing services and weak authentication are where most breaches begin."},
+	{"CC6.7", "Data Transmission and Movement", "The organization restricts the transmission, movement and removal of information to authorized users and processes.", "Data that can leave the environment unchecked can be exfiltrated unnoticed."},
+	{"CC6.8", "Malicious Software Prevention", "The organization implements controls to prevent or detect unauthorized or malicious software.", "Unpatched or unmonitored hosts let malware establish persistence."},
+
+	// SOC2 CC7 - System Operations
+	{"CC7.1", "Configuration and Vulnerability Detection", "The organization uses detection and monitoring procedures to identify configuration changes and new vulnerabilities.", "Audit logs and configuration monitoring are how changes and intrusions are noticed and investigated."},
+	{"CC7.2", "Anomaly Monitoring", "The organization monitors system components for anomalies indicative of malicious acts, natural disasters and errors.", "Threats that are not monitored for are only discovered after the damage is done."},
+	{"CC7.3", "Security Event Evaluation", "The organization evaluates security events to determine whether they could cause a failure to meet its objectives.", "Alerts only help if someone triages them."},
+	{"CC7.4", "Incident Response", "The organization responds to identified security incidents by executing a defined incident response program.", "A rehearsed response shortens the time an attacker has inside the environment."},
+	{"CC7.5", "Incident Recovery", "The organization identifies, develops and implements activities to recover from security incidents.", "Recovery depends on systems being patched and restorable to a known good state."},
+
+	// SOC2 CC8 / CC9
+	{"CC8.1", "Change Management", "The organization authorizes, designs, develops, tests, approves and implements changes to infrastructure and software.", "Unreviewed changes are a leading cause of both outages and security gaps."},
+	{"CC9.1", "Business Disruption Risk", "The organization identifies and develops risk mitigation activities for potential business disruptions.", "Backups and redundancy decide whether an incident is an inconvenience or an outage."},
+	{"CC9.2", "Vendor and Partner Risk", "The organization assesses and manages risks associated with vendors and business partners.", "Third parties with access to systems or data extend the attack surface."},
+
+	// SOC2 Availability and Confidentiality
+	{"A1.1", "Capacity Management", "The organization maintains and monitors processing capacity to meet its availability commitments.", "Unplanned maintenance and exhausted capacity cause avoidable downtime."},
+	{"A1.2", "Backup and Recovery Infrastructure", "The organization authorizes, designs and maintains environmental protections, backups and recovery infrastructure.", "Data that is not backed up and retained cannot be recovered after deletion, corruption or ransomware."},
+	{"A1.3", "Recovery Testing", "The organization tests recovery plan procedures supporting system recovery.", "Untested backups frequently fail when they are needed."},
+	{"C1.1", "Confidential Information Identification", "The organization identifies and maintains confidential information to meet its confidentiality objectives.", "Data cannot be protected appropriately until it is known where it lives."},
+	{"C1.2", "Confidential Information Disposal", "The organization disposes of confidential information to meet its confidentiality objectives.", "Retaining data longer than needed increases the impact of any breach."},
+
+	// CMMC Level 1 practices
+	{"AC.L1-3.1.1", "Authorized Access Control", "Limit system access to authorized users, processes acting on behalf of authorized users, and devices.", "Federal contract information must only be reachable by identities the organization has approved."},
+	{"AC.L1-3.1.2", "Transaction and Function Control", "Limit system access to the types of transactions and functions authorized users are permitted to execute.", "Least privilege keeps a compromised account from doing more than its job requires."},
+	{"AC.L1-3.1.20", "External Connections", "Verify and control or limit connections to and use of external systems.", "Unmanaged external connections bypass the organization's protections."},
+	{"AC.L1-3.1.22", "Control Public Information", "Control information posted or processed on publicly accessible systems.", "Contract information on public systems is disclosed to anyone who looks."},
+	{"IA.L1-3.5.1", "Identification", "Identify system users, processes acting on behalf of users, and devices.", "Actions can only be attributed and audited when every actor has a unique identity."},
+	{"IA.L1-3.5.2", "Authentication", "Authenticate the identities of users, processes or devices before allowing access.", "Strong authentication stops stolen or guessed credentials from granting access."},
+	{"MP.L1-3.8.3", "Media Disposal", "Sanitize or destroy system media containing federal contract information before disposal or reuse.", "Discarded storage can still hold recoverable contract information."},
+	{"PE.L1-3.10.1", "Limit Physical Access", "Limit physical access to systems, equipment and operating environments to authorized individuals.", "Physical access can defeat logical controls."},
+	{"PE.L1-3.10.3", "Escort Visitors", "Escort visitors and monitor visitor activity.", "Unescorted visitors can access systems unobserved."},
+	{"PE.L1-3.10.4", "Physical Access Logs", "Maintain audit logs of physical access.", "Access logs allow physical incidents to be investigated."},
+	{"PE.L1-3.10.5", "Manage Physical Access Devices", "Control and manage physical access devices.", "Lost or uncontrolled keys and badges grant access to anyone who holds them."},
+	{"SC.L1-3.13.1", "Boundary Protection", "Monitor, control and protect communications at the external and key internal boundaries of systems.", "Boundary controls keep untrusted networks from reaching internal systems directly."},
+	{"SC.L1-3.13.5", "Public-Access System Separation", "Implement subnetworks for publicly accessible system components, separated from internal networks.", "Separating public components limits how far a compromise of one can spread."},
+	{"SI.L1-3.14.1", "Flaw Remediation", "Identify, report and correct system flaws in a timely manner.", "Known vulnerabilities are the easiest way into a system."},
+	{"SI.L1-3.14.2", "Malicious Code Protection", "Provide protection from malicious code at appropriate locations within systems.", "Malware defenses stop common attacks before they spread."},
+	{"SI.L1-3.14.4", "Update Malicious Code Protection", "Update malicious code protection mechanisms when new releases are available.", "Outdated signatures miss current threats."},
+	{"SI.L1-3.14.5", "System and File Scanning", "Perform periodic scans of systems and real-time scans of files from external sources.", "Scanning finds malicious code that slipped past other defenses."},
+})
+
+// pciRequirements are the twelve PCI DSS v4.0 principal requirements, keyed by
+// requirement number
+var pciRequirements = map[string]ControlInfo{
+	"1":  {"1", "Network Security Controls", "Install and maintain network security controls.", "Firewalls and security groups keep the cardholder data environment unreachable from untrusted networks."},
+	"2":  {"2", "Secure Configurations", "Apply secure configurations to all system components.", "Vendor defaults and unnecessary services are well known to attackers."},
+	"3":  {"3", "Protect Stored Account Data", "Protect stored account data.", "Encrypted cardholder data is useless to someone who steals the storage."},
+	"4":  {"4", "Encrypt Transmission", "Protect cardholder data with strong cryptography during transmission over open, public networks.", "Cardholder data sent in cleartext can be intercepted."},
+	"5":  {"5", "Anti-Malware", "Protect all systems and networks from malicious software.", "Malware is a common way card data is skimmed from systems."},
+	"6":  {"6", "Secure Systems and Software", "Develop and maintain secure systems and software.", "Unpatched systems and insecure code expose cardholder data to known attacks."},
+	"7":  {"7", "Restrict Access by Need to Know", "Restrict access to system components and cardholder data by business need to know.", "Every unnecessary permission is another way card data can be reached."},
+	"8":  {"8", "Identify and Authenticate Users", "Identify users and authenticate access to system components.", "Shared and weakly authenticated accounts make access impossible to attribute or control."},
+	"9":  {"9", "Restrict Physical Access", "Restrict physical access to cardholder data.", "Physical access to media or systems bypasses logical controls."},
+	"10": {"10", "Log and Monitor Access", "Log and monitor all access to system components and cardholder data.", "Logs are essential for detecting and investigating a compromise of card data."},
+	"11": {"11", "Test Security Regularly", "Test security of systems and networks regularly.", "Regular testing finds weaknesses before attackers do."},
+	"12": {"12", "Information Security Policy", "Support information security with organizational policies and programs.", "Policy and governance keep the technical controls funded, owned and maintained."},
+}
+
+func buildControlCatalog(controls []ControlInfo) map[string]ControlInfo {
+	catalog := make(map[string]ControlInfo, len(controls))
+	for _, control := range controls {
+		catalog[control.ID] = control
+	}
+	return catalog
+}
@@ -0,0 +1,99 @@
+package mappings
+
+import (
+	"sort"
+	"strings"
+)
+
+// gapFrameworkKeys maps a -framework value to the key checks use in their
+// Frameworks map. Only frameworks whose control IDs appear directly in check
+// results can be gap-analysed; crosswalk-derived ones (800-53, ISO) cannot.
+var gapFrameworkKeys = map[string]string{
+	"soc2":     "SOC2",
+	"pci":      "PCI-DSS",
+	"pci-dss":  "PCI-DSS",
+	"hipaa":    "HIPAA",
+	"cmmc":     "CMMC",
+	"gdpr":     "GDPR",
+	"nist-csf": "NIST-CSF",
+	"csf":      "NIST-CSF",
+}
+
+// SupportsGapAnalysis reports whether NotAssessed can be computed for framework
+func SupportsGapAnalysis(framework string) bool {
+	_, ok := gapFrameworkKeys[strings.ToLower(framework)]
+	return ok
+}
+
+// FrameworkControls returns every control the crosswalk defines for framework,
+// sorted. Returns nil for frameworks without a catalog.
+func (c *Crosswalk) FrameworkControls(framework string) []string {
+	var catalog map[string][]string
+	framework = strings.ToLower(framework)
+
+	switch gapFrameworkKeys[framework] {
+	case "SOC2":
+		catalog = c.SOC2ToCIS
+	case "PCI-DSS":
+		catalog = c.PCIToCIS
+	case "HIPAA":
+		catalog = c.HIPAAToCIS
+	case "CMMC":
+		catalog = c.CMMCToCIS
+	case "GDPR":
+		catalog = c.GDPRToCIS
+	case "NIST-CSF":
+		catalog = c.NISTCSFToCIS
+	default:
+		return nil
+	}
+
+	controls := []string{}
+	for id := range catalog {
+		// The open-source scanner covers CMMC Level 1 only
+		if framework == "cmmc" && !strings.Contains(id, ".L1-") {
+			continue
+		}
+		controls = append(controls, strings.TrimPrefix(id, "PCI-"))
+	}
+	sort.Strings(controls)
+	return controls
+}
+
+// NotAssessed returns the framework's controls that no check result maps to,
+// sorted. frameworks holds the Frameworks map of each result and controlIDs
+// their Control IDs. A result for a sub-requirement such as
+// "164.312(a)(2)(iv)" counts as assessing its parent "164.312(a)(2)".
+func (c *Crosswalk) NotAssessed(framework string, controlIDs []string, frameworks []map[string]string) []string {
+	key, ok := gapFrameworkKeys[strings.ToLower(framework)]
+	if !ok {
+		return nil
+	}
+
+	assessed := []string{}
+	for _, id := range controlIDs {
+		assessed = append(assessed, strings.TrimPrefix(strings.TrimSpace(id), "PCI-"))
+	}
+	for _, fw := range frameworks {
+		for _, id := range strings.Split(fw[key], ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				assessed = append(assessed, strings.TrimPrefix(id, "PCI-"))
+			}
+		}
+	}
+
+	gaps := []string{}
+	for _, control := range c.FrameworkControls(framework) {
+		covered := false
+		for _, id := range assessed {
+			if id == control || strings.HasPrefix(id, control+"(") {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			gaps = append(gaps, control)
+		}
+	}
+	return gaps
+}
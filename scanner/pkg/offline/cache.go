@@ -3,24 +3,50 @@ package offline
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
+
+	"github.com/guardian-nexus/auditkit/scanner/pkg/core"
 )
 
+// DefaultMaxCacheAge is how old a cached scan can be before offline mode refuses it
+const DefaultMaxCacheAge = 24 * time.Hour
+
+// ErrStaleCache is returned when a cached scan is older than the allowed max age.
+// Callers can detect it with errors.Is. It is core.ErrStaleCache, so callers
+// that only import core match it too.
+var ErrStaleCache = core.ErrStaleCache
+
 // CachedScan represents a cached scan result
 type CachedScan struct {
-	Timestamp       time.Time         `json:"timestamp"`
-	Provider        string            `json:"provider"`
-	Framework       string            `json:"framework"`
-	AccountID       string            `json:"account_id"`
-	Score           float64           `json:"score"`
-	TotalControls   int               `json:"total_controls"`
-	PassedControls  int               `json:"passed_controls"`
-	FailedControls  int               `json:"failed_controls"`
-	Controls        []CachedControl   `json:"controls"`
-	Recommendations []string          `json:"recommendations"`
-	Version         string            `json:"version"`
+	Timestamp       time.Time          `json:"timestamp"`
+	Provider        string             `json:"provider"`
+	Framework       string             `json:"framework"`
+	AccountID       string             `json:"account_id"`
+	Score           float64            `json:"score"`
+	TotalControls   int                `json:"total_controls"`
+	PassedControls  int                `json:"passed_controls"`
+	FailedControls  int                `json:"failed_controls"`
+	Controls        []CachedControl    `json:"controls"`
+	Recommendations []string           `json:"recommendations"`
+	Version         string             `json:"version"`
+	SkippedChecks   []string           `json:"skipped_checks,omitempty"` // Checks disabled by configuration
+	Metadata        *core.ScanMetadata `json:"metadata,omitempty"`
+}
+
+// SetIdentity makes the scan's provider and account match the identity the
+// scanner detected, so cache filenames always name the account actually
+// scanned. Empty identity fields leave the scan unchanged.
+func (scan *CachedScan) SetIdentity(id core.Identity) {
+	if id.Provider != "" {
+		scan.Provider = id.Provider
+	}
+	if id.AccountID != "" {
+		scan.AccountID = id.AccountID
+	}
 }
 
 // CachedControl represents a cached control result
@@ -28,11 +54,17 @@ type CachedControl struct {
 	ID                string            `json:"id"`
 	Name              string            `json:"name"`
 	Category          string            `json:"category"`
+	Region            string            `json:"region,omitempty"`
 	Severity          string            `json:"severity,omitempty"`
+	OriginalSeverity  string            `json:"original_severity,omitempty"`
+	Environment       string            `json:"environment,omitempty"`
+	FindingID         string            `json:"finding_id,omitempty"`
 	Status            string            `json:"status"`
 	Evidence          string            `json:"evidence"`
+	AffectedResources []string          `json:"affected_resources,omitempty"`
 	Remediation       string            `json:"remediation,omitempty"`
 	RemediationDetail string            `json:"remediation_detail,omitempty"`
+	RequiresRecreate  bool              `json:"requires_recreate,omitempty"`
 	Priority          string            `json:"priority,omitempty"`
 	Impact            string            `json:"impact,omitempty"`
 	ScreenshotGuide   string            `json:"screenshot_guide,omitempty"`
@@ -40,9 +72,16 @@ type CachedControl struct {
 	Frameworks        map[string]string `json:"frameworks,omitempty"`
 }
 
-// Cache manages offline scan data
+// Cache manages offline scan data. With a passphrase set, scan files are
+// encrypted at rest; the index keeps only the summary fields in cleartext.
+//
+// A Cache is safe for concurrent use. Save, Clear and ClearOlderThan hold a
+// lock on the cache directory (flock on Unix), so parallel scans, in this
+// process or another, do not interleave writes to the latest-* files or the
+// index.
 type Cache struct {
-	basePath string
+	basePath   string
+	passphrase string
 }
 
 // NewCache creates a new cache manager
@@ -57,7 +96,28 @@ func NewCache() (*Cache, error) {
 		return nil, fmt.Errorf("failed to create cache directory: %w", err)
 	}
 
-	return &Cache{basePath: basePath}, nil
+	return &Cache{basePath: basePath, passphrase: os.Getenv(PassphraseEnv)}, nil
+}
+
+// SetPassphrase enables encryption of saved scans with a key derived from
+// passphrase, or disables it when passphrase is empty. Encrypted files
+// already in the cache need the passphrase to load.
+func (c *Cache) SetPassphrase(passphrase string) {
+	c.passphrase = passphrase
+}
+
+// IsEncrypted reports whether Save encrypts scans
+func (c *Cache) IsEncrypted() bool {
+	return c.passphrase != ""
+}
+
+// lockFilename is the lock file taken around cache writes. It is never
+// removed, so every writer locks the same inode.
+const lockFilename = ".lock"
+
+// lock takes the cache directory lock; call the returned func to release it
+func (c *Cache) lock() (unlock func(), err error) {
+	return lockFile(filepath.Join(c.basePath, lockFilename))
 }
 
 // GetCachePath returns the path to the cache directory
@@ -67,6 +127,12 @@ func (c *Cache) GetCachePath() string {
 
 // Save stores a scan result to cache
 func (c *Cache) Save(scan CachedScan) error {
+	unlock, err := c.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	filename := c.getScanFilename(scan.Provider, scan.AccountID, scan.Framework, scan.Timestamp)
 	scanPath := filepath.Join(c.basePath, filename)
 
@@ -74,18 +140,55 @@ func (c *Cache) Save(scan CachedScan) error {
 	if err != nil {
 		return fmt.Errorf("failed to marshal scan data: %w", err)
 	}
+	if c.passphrase != "" {
+		if data, err = sealScan(data, c.passphrase); err != nil {
+			return fmt.Errorf("failed to encrypt scan data: %w", err)
+		}
+	}
 
-	if err := os.WriteFile(scanPath, data, 0644); err != nil {
+	if err := writeFileAtomic(scanPath, data); err != nil {
 		return fmt.Errorf("failed to write cache file: %w", err)
 	}
 
 	// Also update the "latest" symlink/copy
 	latestPath := filepath.Join(c.basePath, c.getLatestFilename(scan.Provider, scan.AccountID, scan.Framework))
-	if err := os.WriteFile(latestPath, data, 0644); err != nil {
+	if err := writeFileAtomic(latestPath, data); err != nil {
 		return fmt.Errorf("failed to write latest cache file: %w", err)
 	}
 
-	return nil
+	return c.addToIndex(newIndexEntry(filename, scan, int64(len(data))))
+}
+
+// renameFile moves a fully written temporary file into place. Tests swap it
+// to make a write fail at its last step.
+var renameFile = os.Rename
+
+// writeFileAtomic writes data to a temporary file next to path and renames it
+// into place, so an interrupted write never leaves a truncated file behind and
+// readers see either the previous contents or the new ones
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath) // no-op once the rename succeeds
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpPath, 0644); err != nil {
+		return err
+	}
+	return renameFile(tmpPath, path)
 }
 
 // LoadLatest loads the most recent scan for a provider/account/framework
@@ -114,6 +217,9 @@ func (c *Cache) loadFromFile(filePath string) (*CachedScan, error) {
 		}
 		return nil, fmt.Errorf("failed to read cache file: %w", err)
 	}
+	if data, err = openScan(data, c.passphrase); err != nil {
+		return nil, err
+	}
 
 	var scan CachedScan
 	if err := json.Unmarshal(data, &scan); err != nil {
@@ -123,17 +229,20 @@ func (c *Cache) loadFromFile(filePath string) (*CachedScan, error) {
 	return &scan, nil
 }
 
-// ListScans returns all cached scans matching the criteria
+// ListScans returns all cached scans matching the criteria. The cache index
+// picks the matching files, so only those are parsed.
 func (c *Cache) ListScans(provider, accountID, framework string) ([]CachedScan, error) {
-	pattern := fmt.Sprintf("scan-%s-%s-%s-*.json", provider, accountID, framework)
-	matches, err := filepath.Glob(filepath.Join(c.basePath, pattern))
+	index, err := c.loadIndex()
 	if err != nil {
-		return nil, fmt.Errorf("failed to list cache files: %w", err)
+		return nil, err
 	}
 
 	scans := []CachedScan{}
-	for _, match := range matches {
-		scan, err := c.loadFromFile(match)
+	for _, entry := range index {
+		if entry.Provider != provider || entry.AccountID != accountID || entry.Framework != framework {
+			continue
+		}
+		scan, err := c.loadFromFile(filepath.Join(c.basePath, entry.Filename))
 		if err != nil {
 			continue
 		}
@@ -150,61 +259,118 @@ func (c *Cache) HasCachedScan(provider, accountID, framework string) bool {
 	return err == nil
 }
 
-// GetCacheInfo returns information about cached data
-func (c *Cache) GetCacheInfo() (map[string]interface{}, error) {
+// CacheInfo describes the cache directory and the scans in it
+type CacheInfo struct {
+	Path       string          `json:"cache_path"`
+	TotalFiles int             `json:"total_files"`
+	Encrypted  bool            `json:"encrypted"` // scans saved from now on are encrypted
+	Scans      []CacheScanInfo `json:"scans"`
+}
+
+// CacheScanInfo summarizes one cached scan file
+type CacheScanInfo struct {
+	Filename  string    `json:"filename"`
+	Provider  string    `json:"provider"`
+	Framework string    `json:"framework"`
+	AccountID string    `json:"account_id"`
+	Timestamp time.Time `json:"timestamp"`
+	Score     float64   `json:"score"`
+	Size      int64     `json:"size"`
+}
+
+// WriteJSON writes the cache info as indented JSON
+func (info CacheInfo) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(info)
+}
+
+// Info returns information about cached data, read from the cache index
+// rather than from each scan file. Scans are listed oldest first.
+func (c *Cache) Info() (CacheInfo, error) {
 	entries, err := os.ReadDir(c.basePath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read cache directory: %w", err)
+		return CacheInfo{}, fmt.Errorf("failed to read cache directory: %w", err)
 	}
 
-	info := map[string]interface{}{
-		"cache_path":  c.basePath,
-		"total_files": len(entries),
-		"scans":       []map[string]interface{}{},
+	index, err := c.loadIndex()
+	if err != nil {
+		return CacheInfo{}, err
 	}
 
-	scans := []map[string]interface{}{}
+	scans := []CacheScanInfo{}
+	for _, entry := range index {
+		scans = append(scans, CacheScanInfo{
+			Filename:  entry.Filename,
+			Provider:  entry.Provider,
+			Framework: entry.Framework,
+			AccountID: entry.AccountID,
+			Timestamp: entry.Timestamp,
+			Score:     entry.Score,
+			Size:      entry.Size,
+		})
+	}
+
+	files := 0
 	for _, entry := range entries {
-		if entry.IsDir() {
-			continue
-		}
-		if filepath.Ext(entry.Name()) != ".json" {
-			continue
-		}
-		if entry.Name()[:6] == "latest" {
-			continue
+		// Skip the lock file and any in-flight temp files
+		if !entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
+			files++
 		}
+	}
 
-		fileInfo, _ := entry.Info()
-		scan, err := c.loadFromFile(filepath.Join(c.basePath, entry.Name()))
-		if err != nil {
-			continue
-		}
+	return CacheInfo{
+		Path:       c.basePath,
+		TotalFiles: files,
+		Encrypted:  c.IsEncrypted(),
+		Scans:      scans,
+	}, nil
+}
+
+// GetCacheInfo returns information about cached data as an untyped map.
+//
+// Deprecated: Use Info, which returns a CacheInfo with stable field names.
+func (c *Cache) GetCacheInfo() (map[string]interface{}, error) {
+	info, err := c.Info()
+	if err != nil {
+		return nil, err
+	}
 
+	scans := []map[string]interface{}{}
+	for _, scan := range info.Scans {
 		scans = append(scans, map[string]interface{}{
-			"filename":  entry.Name(),
+			"filename":  scan.Filename,
 			"provider":  scan.Provider,
 			"framework": scan.Framework,
 			"account":   scan.AccountID,
 			"timestamp": scan.Timestamp,
 			"score":     scan.Score,
-			"size":      fileInfo.Size(),
+			"size":      scan.Size,
 		})
 	}
 
-	info["scans"] = scans
-	return info, nil
+	return map[string]interface{}{
+		"cache_path":  info.Path,
+		"total_files": info.TotalFiles,
+		"scans":       scans,
+	}, nil
 }
 
 // Clear removes all cached scans
 func (c *Cache) Clear() error {
+	unlock, err := c.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	entries, err := os.ReadDir(c.basePath)
 	if err != nil {
 		return fmt.Errorf("failed to read cache directory: %w", err)
 	}
 
 	for _, entry := range entries {
-		if entry.IsDir() {
+		if entry.IsDir() || entry.Name() == lockFilename {
 			continue
 		}
 		if err := os.Remove(filepath.Join(c.basePath, entry.Name())); err != nil {
@@ -217,6 +383,12 @@ func (c *Cache) Clear() error {
 
 // ClearOlderThan removes cached scans older than the specified duration
 func (c *Cache) ClearOlderThan(duration time.Duration) (int, error) {
+	unlock, err := c.lock()
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
 	entries, err := os.ReadDir(c.basePath)
 	if err != nil {
 		return 0, fmt.Errorf("failed to read cache directory: %w", err)
@@ -229,7 +401,7 @@ func (c *Cache) ClearOlderThan(duration time.Duration) (int, error) {
 		if entry.IsDir() {
 			continue
 		}
-		if entry.Name()[:6] == "latest" {
+		if !isScanFile(entry.Name()) {
 			continue
 		}
 
@@ -248,12 +420,16 @@ func (c *Cache) ClearOlderThan(duration time.Duration) (int, error) {
 	return removed, nil
 }
 
+// scanTimestampFormat is how scan filenames, and report paths expanded with
+// ExpandReportPath, write the scan time
+const scanTimestampFormat = "20060102-150405"
+
 func (c *Cache) getScanFilename(provider, accountID, framework string, timestamp time.Time) string {
 	return fmt.Sprintf("scan-%s-%s-%s-%s.json",
 		provider,
 		accountID,
 		framework,
-		timestamp.Format("20060102-150405"))
+		timestamp.Format(scanTimestampFormat))
 }
 
 func (c *Cache) getLatestFilename(provider, accountID, framework string) string {
@@ -283,3 +459,18 @@ func GetOfflineScanAge(provider, accountID, framework string) (time.Duration, er
 
 	return time.Since(scan.Timestamp), nil
 }
+
+// CheckFreshness returns an error wrapping ErrStaleCache if the scan is older
+// than maxAge. A maxAge of zero or less disables the check.
+func CheckFreshness(scan *CachedScan, maxAge time.Duration) error {
+	if maxAge <= 0 {
+		return nil
+	}
+
+	age := time.Since(scan.Timestamp)
+	if age > maxAge {
+		return fmt.Errorf("%w: scan from %s is %s old (max age %s)",
+			ErrStaleCache, scan.Timestamp.Format(time.RFC3339), age.Round(time.Minute), maxAge)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package offline
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestConcurrentSaveAndList runs parallel saves and listings against one
+// cache; run it with -race. Every save must end up in the index.
+func TestConcurrentSaveAndList(t *testing.T) {
+	c := testCache(t)
+	start := time.Date(2025, time.March, 4, 15, 30, 0, 0, time.UTC)
+	const saves = 20
+
+	var wg sync.WaitGroup
+	for i := 0; i < saves; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			scan := CachedScan{Timestamp: start.Add(time.Duration(i) * time.Second), Provider: "aws", AccountID: "123456789012", Framework: "soc2"}
+			if err := c.Save(scan); err != nil {
+				t.Errorf("Save %d: %v", i, err)
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			if _, err := c.ListScans("aws", "123456789012", "soc2"); err != nil {
+				t.Errorf("ListScans: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	scans, err := c.ListScans("aws", "123456789012", "soc2")
+	if err != nil {
+		t.Fatalf("ListScans: %v", err)
+	}
+	if len(scans) != saves {
+		t.Errorf("got %d scans, want %d", len(scans), saves)
+	}
+	if index := readIndex(t, c); len(index) != saves {
+		t.Errorf("index has %d entries, want %d", len(index), saves)
+	}
+}
@@ -0,0 +1,196 @@
+package offline
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// ScanDiff describes how a scan changed relative to an earlier one
+type ScanDiff struct {
+	ScoreDelta  float64         // current score minus previous score
+	NewFailures []CachedControl // failing now, not failing before
+	Resolved    []CachedControl // failing before, not failing now
+
+	// AddedChecks and RemovedChecks are the check IDs in only one scan's
+	// catalog, typically because the tool was upgraded in between.
+	// CatalogScoreDelta is the part of ScoreDelta they account for; the rest
+	// is change in posture. All three are empty when either scan predates
+	// recording its catalog.
+	AddedChecks       []string
+	RemovedChecks     []string
+	CatalogScoreDelta float64
+}
+
+// PostureScoreDelta is the score change not explained by checks added to or
+// removed from the catalog, rounded to hundredths of a point so the float
+// noise of the subtraction does not read as a change
+func (d ScanDiff) PostureScoreDelta() float64 {
+	return math.Round((d.ScoreDelta-d.CatalogScoreDelta)*100) / 100
+}
+
+// Improved reports whether posture got better: a higher score once changes
+// to the check catalog are discounted, or the same score with more failures
+// resolved than introduced
+func (d ScanDiff) Improved() bool {
+	if delta := d.PostureScoreDelta(); delta != 0 {
+		return delta > 0
+	}
+	return len(d.Resolved) > len(d.NewFailures)
+}
+
+// CatalogChange describes the catalog change, e.g. "3 new checks and 1
+// removed check", or "" when both scans ran the same checks
+func (d ScanDiff) CatalogChange() string {
+	parts := []string{}
+	if n := len(d.AddedChecks); n > 0 {
+		parts = append(parts, countChecks(n, "new"))
+	}
+	if n := len(d.RemovedChecks); n > 0 {
+		parts = append(parts, countChecks(n, "removed"))
+	}
+	return strings.Join(parts, " and ")
+}
+
+func countChecks(n int, kind string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s check", kind)
+	}
+	return fmt.Sprintf("%d %s checks", n, kind)
+}
+
+// DiffScans compares two scans of the same account. Controls are matched by
+// FindingID when both scans carry finding IDs, else by ID and name (scans
+// cached before finding IDs were recorded).
+func DiffScans(current, previous *CachedScan) ScanDiff {
+	diff := ScanDiff{
+		NewFailures: []CachedControl{},
+		Resolved:    []CachedControl{},
+	}
+	if current == nil || previous == nil {
+		return diff
+	}
+	diff.ScoreDelta = current.Score - previous.Score
+
+	byFinding := hasFindingIDs(current) && hasFindingIDs(previous)
+	failedBefore := failingControls(previous, byFinding)
+	failedNow := failingControls(current, byFinding)
+
+	for _, control := range current.Controls {
+		key := controlKey(control, byFinding)
+		if control.Status == "FAIL" && !failedBefore[key] {
+			diff.NewFailures = append(diff.NewFailures, control)
+		}
+	}
+	for _, control := range previous.Controls {
+		key := controlKey(control, byFinding)
+		if control.Status == "FAIL" && !failedNow[key] {
+			diff.Resolved = append(diff.Resolved, control)
+		}
+	}
+
+	diffCatalogs(current, previous, &diff)
+	return diff
+}
+
+// diffCatalogs records the checks only one scan ran and how much of the score
+// change they account for. A control is attributed to a check by name, as
+// checks report their manifest name; controls of checks both scans ran, or
+// whose check is unknown, count as comparable. The catalog's share is the
+// difference between each scan's score and its score over comparable
+// controls only.
+func diffCatalogs(current, previous *CachedScan, diff *ScanDiff) {
+	if current.Metadata == nil || previous.Metadata == nil ||
+		len(current.Metadata.Checks) == 0 || len(previous.Metadata.Checks) == 0 {
+		return
+	}
+
+	now := map[string]string{}
+	for _, check := range current.Metadata.Checks {
+		now[check.ID] = check.Name
+	}
+	before := map[string]string{}
+	for _, check := range previous.Metadata.Checks {
+		before[check.ID] = check.Name
+	}
+
+	addedNames := map[string]bool{}
+	for id, name := range now {
+		if _, ok := before[id]; !ok {
+			diff.AddedChecks = append(diff.AddedChecks, id)
+			addedNames[name] = true
+		}
+	}
+	removedNames := map[string]bool{}
+	for id, name := range before {
+		if _, ok := now[id]; !ok {
+			diff.RemovedChecks = append(diff.RemovedChecks, id)
+			removedNames[name] = true
+		}
+	}
+	if len(diff.AddedChecks) == 0 && len(diff.RemovedChecks) == 0 {
+		return
+	}
+	sort.Strings(diff.AddedChecks)
+	sort.Strings(diff.RemovedChecks)
+
+	// A name a check in both catalogs also reports cannot be attributed
+	for id, name := range now {
+		if _, ok := before[id]; ok {
+			delete(addedNames, name)
+			delete(removedNames, name)
+		}
+	}
+
+	diff.CatalogScoreDelta = (automatedScore(current, nil) - automatedScore(current, addedNames)) -
+		(automatedScore(previous, nil) - automatedScore(previous, removedNames))
+}
+
+// automatedScore is the share of PASS among PASS and FAIL controls, leaving
+// out controls named in exclude
+func automatedScore(scan *CachedScan, exclude map[string]bool) float64 {
+	passed, failed := 0, 0
+	for _, control := range scan.Controls {
+		if exclude[control.Name] {
+			continue
+		}
+		switch control.Status {
+		case "PASS":
+			passed++
+		case "FAIL":
+			failed++
+		}
+	}
+	if passed+failed == 0 {
+		return 0
+	}
+	return float64(passed) / float64(passed+failed) * 100
+}
+
+func failingControls(scan *CachedScan, byFinding bool) map[string]bool {
+	failing := map[string]bool{}
+	for _, control := range scan.Controls {
+		if control.Status == "FAIL" {
+			failing[controlKey(control, byFinding)] = true
+		}
+	}
+	return failing
+}
+
+func controlKey(control CachedControl, byFinding bool) string {
+	if byFinding {
+		return control.FindingID
+	}
+	return control.ID + "|" + control.Name
+}
+
+// hasFindingIDs reports whether every failing control of scan has a FindingID
+func hasFindingIDs(scan *CachedScan) bool {
+	for _, control := range scan.Controls {
+		if control.Status == "FAIL" && control.FindingID == "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,106 @@
+package offline
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// PassphraseEnv names the environment variable holding the cache passphrase.
+// When it is set, NewCache encrypts every scan it saves.
+const PassphraseEnv = "AUDITKIT_CACHE_PASSPHRASE"
+
+// ErrCacheEncrypted is returned when an encrypted cache file is read without
+// a passphrase. Callers can detect it with errors.Is.
+var ErrCacheEncrypted = errors.New("cached scan is encrypted")
+
+// encryptedFormat marks a cache file written by sealScan
+const encryptedFormat = "auditkit-aes-gcm-scrypt-v1"
+
+// scrypt parameters recommended for interactive use (about 100ms per key)
+const (
+	scryptN      = 1 << 15
+	scryptR      = 8
+	scryptP      = 1
+	scryptKeyLen = 32
+	saltLen      = 16
+)
+
+// encryptedFile is the on-disk envelope of an encrypted cache file. Each file
+// has its own salt, so a key is derived per file.
+type encryptedFile struct {
+	Format     string `json:"format"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// sealScan encrypts marshaled scan data with AES-256-GCM under a key derived
+// from passphrase with scrypt
+func sealScan(data []byte, passphrase string) ([]byte, error) {
+	salt := make([]byte, saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	gcm, err := newCacheCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	return json.MarshalIndent(encryptedFile{
+		Format:     encryptedFormat,
+		Salt:       salt,
+		Nonce:      nonce,
+		Ciphertext: gcm.Seal(nil, nonce, data, []byte(encryptedFormat)),
+	}, "", "  ")
+}
+
+// openScan returns the plaintext scan data in a cache file. Unencrypted files
+// are returned unchanged, so caches written before encryption was enabled
+// keep loading.
+func openScan(data []byte, passphrase string) ([]byte, error) {
+	if !bytes.Contains(data, []byte(encryptedFormat)) {
+		return data, nil
+	}
+
+	var file encryptedFile
+	if err := json.Unmarshal(data, &file); err != nil || file.Format != encryptedFormat {
+		return data, nil
+	}
+	if passphrase == "" {
+		return nil, fmt.Errorf("%w: set %s to read it", ErrCacheEncrypted, PassphraseEnv)
+	}
+
+	gcm, err := newCacheCipher(passphrase, file.Salt)
+	if err != nil {
+		return nil, err
+	}
+	plain, err := gcm.Open(nil, file.Nonce, file.Ciphertext, []byte(encryptedFormat))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt cache file (wrong passphrase?): %w", err)
+	}
+	return plain, nil
+}
+
+func newCacheCipher(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, scryptKeyLen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive cache key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cache cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
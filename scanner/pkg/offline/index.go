@@ -0,0 +1,178 @@
+package offline
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// indexFilename is the cache index kept alongside the scan files
+const indexFilename = "index.json"
+
+// IndexEntry summarizes one cached scan file so listings do not have to parse
+// every scan
+type IndexEntry struct {
+	Filename  string    `json:"filename"`
+	Provider  string    `json:"provider"`
+	AccountID string    `json:"account_id"`
+	Framework string    `json:"framework"`
+	Timestamp time.Time `json:"timestamp"`
+	Score     float64   `json:"score"`
+	Size      int64     `json:"size"`
+}
+
+// cacheIndex is the on-disk format of index.json
+type cacheIndex struct {
+	Scans []IndexEntry `json:"scans"`
+
+	// Unreadable lists the scan files that could not be parsed when the index
+	// was rebuilt, so the index still accounts for every file on disk and is
+	// not rebuilt again on every call
+	Unreadable []string `json:"unreadable,omitempty"`
+}
+
+// isScanFile reports whether name is a timestamped scan file, as opposed to a
+// latest-* copy or the index itself
+func isScanFile(name string) bool {
+	return strings.HasPrefix(name, "scan-") && filepath.Ext(name) == ".json"
+}
+
+// loadIndex returns the index entries, rebuilding index.json from the scan
+// files when it is missing, unreadable or does not list exactly the scan files
+// in the cache directory
+func (c *Cache) loadIndex() ([]IndexEntry, error) {
+	index, err := c.currentIndex()
+	if err != nil {
+		return nil, err
+	}
+	return index.Scans, nil
+}
+
+// currentIndex is loadIndex, returning the unreadable files as well
+func (c *Cache) currentIndex() (cacheIndex, error) {
+	entries, err := os.ReadDir(c.basePath)
+	if err != nil {
+		return cacheIndex{}, fmt.Errorf("failed to read cache directory: %w", err)
+	}
+	onDisk := map[string]bool{}
+	for _, entry := range entries {
+		if !entry.IsDir() && isScanFile(entry.Name()) {
+			onDisk[entry.Name()] = true
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(c.basePath, indexFilename))
+	if err == nil {
+		var index cacheIndex
+		if json.Unmarshal(data, &index) == nil && indexMatches(index, onDisk) {
+			return index, nil
+		}
+	}
+
+	return c.rebuildIndex(onDisk)
+}
+
+// indexMatches reports whether index lists exactly the files in onDisk, each
+// either as a scan or as unreadable
+func indexMatches(index cacheIndex, onDisk map[string]bool) bool {
+	if len(index.Scans)+len(index.Unreadable) != len(onDisk) {
+		return false
+	}
+	listed := map[string]bool{}
+	for _, entry := range index.Scans {
+		listed[entry.Filename] = true
+	}
+	for _, name := range index.Unreadable {
+		listed[name] = true
+	}
+	for name := range onDisk {
+		if !listed[name] {
+			return false
+		}
+	}
+	return true
+}
+
+// rebuildIndex parses every scan file in files and rewrites index.json. Files
+// that fail to parse are left out of the scans, as ListScans always has, and
+// recorded as unreadable.
+func (c *Cache) rebuildIndex(files map[string]bool) (cacheIndex, error) {
+	index := cacheIndex{Scans: []IndexEntry{}}
+	for name := range files {
+		path := filepath.Join(c.basePath, name)
+		scan, err := c.loadFromFile(path)
+		if err != nil {
+			index.Unreadable = append(index.Unreadable, name)
+			continue
+		}
+		fileInfo, err := os.Stat(path)
+		if err != nil {
+			index.Unreadable = append(index.Unreadable, name)
+			continue
+		}
+		index.Scans = append(index.Scans, newIndexEntry(name, *scan, fileInfo.Size()))
+	}
+
+	if err := c.writeIndex(index); err != nil {
+		return cacheIndex{}, err
+	}
+	return index, nil
+}
+
+// addToIndex records a newly saved scan file in index.json
+func (c *Cache) addToIndex(entry IndexEntry) error {
+	index, err := c.currentIndex()
+	if err != nil {
+		return err
+	}
+
+	updated := cacheIndex{Scans: []IndexEntry{entry}}
+	for _, existing := range index.Scans {
+		if existing.Filename != entry.Filename {
+			updated.Scans = append(updated.Scans, existing)
+		}
+	}
+	for _, name := range index.Unreadable {
+		if name != entry.Filename {
+			updated.Unreadable = append(updated.Unreadable, name)
+		}
+	}
+	return c.writeIndex(updated)
+}
+
+// writeIndex writes index to index.json, scans oldest first
+func (c *Cache) writeIndex(index cacheIndex) error {
+	scans := index.Scans
+	sort.Strings(index.Unreadable)
+	sort.Slice(scans, func(i, j int) bool {
+		if !scans[i].Timestamp.Equal(scans[j].Timestamp) {
+			return scans[i].Timestamp.Before(scans[j].Timestamp)
+		}
+		return scans[i].Filename < scans[j].Filename
+	})
+
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache index: %w", err)
+	}
+	if err := writeFileAtomic(filepath.Join(c.basePath, indexFilename), data); err != nil {
+		return fmt.Errorf("failed to write cache index: %w", err)
+	}
+	return nil
+}
+
+func newIndexEntry(filename string, scan CachedScan, size int64) IndexEntry {
+	return IndexEntry{
+		Filename:  filename,
+		Provider:  scan.Provider,
+		AccountID: scan.AccountID,
+		Framework: scan.Framework,
+		Timestamp: scan.Timestamp,
+		Score:     scan.Score,
+		Size:      size,
+	}
+}
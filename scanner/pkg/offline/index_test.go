@@ -0,0 +1,255 @@
+package offline
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testCache returns a cache in a fresh temporary directory holding one scan
+// per timestamp for the same account and framework
+func testCache(t *testing.T, timestamps ...time.Time) *Cache {
+	t.Helper()
+	c := &Cache{basePath: t.TempDir()}
+	for _, ts := range timestamps {
+		if err := c.Save(CachedScan{Timestamp: ts, Provider: "aws", AccountID: "123456789012", Framework: "soc2", Score: 80}); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+	}
+	return c
+}
+
+// readIndex parses index.json, failing the test if it is missing or invalid
+func readIndex(t *testing.T, c *Cache) []IndexEntry {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(c.basePath, indexFilename))
+	if err != nil {
+		t.Fatalf("reading index: %v", err)
+	}
+	var index cacheIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		t.Fatalf("index is not valid JSON: %v", err)
+	}
+	return index.Scans
+}
+
+func TestListScansRebuildsDivergedIndex(t *testing.T) {
+	first := time.Date(2025, time.March, 4, 15, 30, 0, 0, time.UTC)
+	second := first.Add(time.Hour)
+
+	tests := []struct {
+		name    string
+		diverge func(t *testing.T, c *Cache)
+		want    int
+	}{
+		{
+			name: "index missing",
+			diverge: func(t *testing.T, c *Cache) {
+				if err := os.Remove(filepath.Join(c.basePath, indexFilename)); err != nil {
+					t.Fatal(err)
+				}
+			},
+			want: 2,
+		},
+		{
+			name: "index corrupt",
+			diverge: func(t *testing.T, c *Cache) {
+				if err := os.WriteFile(filepath.Join(c.basePath, indexFilename), []byte(`{"scans": [`), 0644); err != nil {
+					t.Fatal(err)
+				}
+			},
+			want: 2,
+		},
+		{
+			name: "index lists a deleted scan",
+			diverge: func(t *testing.T, c *Cache) {
+				name := c.getScanFilename("aws", "123456789012", "soc2", first)
+				if err := os.Remove(filepath.Join(c.basePath, name)); err != nil {
+					t.Fatal(err)
+				}
+			},
+			want: 1,
+		},
+		{
+			name: "scan missing from index",
+			diverge: func(t *testing.T, c *Cache) {
+				if err := c.writeIndex(cacheIndex{Scans: readIndex(t, c)[:1]}); err != nil {
+					t.Fatal(err)
+				}
+			},
+			want: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testCache(t, first, second)
+			tt.diverge(t, c)
+
+			scans, err := c.ListScans("aws", "123456789012", "soc2")
+			if err != nil {
+				t.Fatalf("ListScans: %v", err)
+			}
+			if len(scans) != tt.want {
+				t.Errorf("got %d scans, want %d", len(scans), tt.want)
+			}
+
+			index := readIndex(t, c)
+			if len(index) != tt.want {
+				t.Fatalf("rebuilt index has %d entries, want %d", len(index), tt.want)
+			}
+			for _, entry := range index {
+				if _, err := os.Stat(filepath.Join(c.basePath, entry.Filename)); err != nil {
+					t.Errorf("index lists %s: %v", entry.Filename, err)
+				}
+			}
+		})
+	}
+}
+
+func TestSaveWritesIndexAtomically(t *testing.T) {
+	start := time.Date(2025, time.March, 4, 15, 30, 0, 0, time.UTC)
+	c := testCache(t, start, start.Add(time.Hour), start.Add(2*time.Hour))
+
+	entries, err := os.ReadDir(c.basePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if strings.Contains(entry.Name(), ".tmp-") {
+			t.Errorf("leftover temporary file %s", entry.Name())
+		}
+	}
+
+	index := readIndex(t, c)
+	if len(index) != 3 {
+		t.Fatalf("index has %d entries, want 3", len(index))
+	}
+	for i, entry := range index {
+		if want := start.Add(time.Duration(i) * time.Hour); !entry.Timestamp.Equal(want) {
+			t.Errorf("entry %d timestamp %v, want %v (oldest first)", i, entry.Timestamp, want)
+		}
+		if entry.Size == 0 {
+			t.Errorf("entry %d has no size", i)
+		}
+	}
+}
+
+func TestRebuiltIndexRecordsUnreadableFiles(t *testing.T) {
+	start := time.Date(2025, time.March, 4, 15, 30, 0, 0, time.UTC)
+	c := testCache(t, start, start.Add(time.Hour))
+
+	corrupt := c.getScanFilename("aws", "123456789012", "soc2", start.Add(2*time.Hour))
+	if err := os.WriteFile(filepath.Join(c.basePath, corrupt), []byte(`{"timestamp": `), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	scans, err := c.ListScans("aws", "123456789012", "soc2")
+	if err != nil {
+		t.Fatalf("ListScans: %v", err)
+	}
+	if len(scans) != 2 {
+		t.Errorf("got %d scans, want the 2 readable ones", len(scans))
+	}
+
+	data, err := os.ReadFile(filepath.Join(c.basePath, indexFilename))
+	if err != nil {
+		t.Fatalf("rebuilt index not written: %v", err)
+	}
+	var index cacheIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		t.Fatalf("index is not valid JSON: %v", err)
+	}
+	if len(index.Scans) != 2 || len(index.Unreadable) != 1 || index.Unreadable[0] != corrupt {
+		t.Errorf("index lists %d scans and unreadable %v, want 2 and [%s]", len(index.Scans), index.Unreadable, corrupt)
+	}
+
+	// The index accounts for every file, so it is used as is from now on
+	entries, err := os.ReadDir(c.basePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	onDisk := map[string]bool{}
+	for _, entry := range entries {
+		if isScanFile(entry.Name()) {
+			onDisk[entry.Name()] = true
+		}
+	}
+	if !indexMatches(index, onDisk) {
+		t.Error("index with the unreadable file recorded does not match the cache directory")
+	}
+
+	// Saving another scan keeps the unreadable file recorded
+	if err := c.Save(CachedScan{Timestamp: start.Add(3 * time.Hour), Provider: "aws", AccountID: "123456789012", Framework: "soc2"}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if got := readIndex(t, c); len(got) != 3 {
+		t.Errorf("index has %d scans after Save, want 3", len(got))
+	}
+}
+
+func TestFailedWriteKeepsPreviousFiles(t *testing.T) {
+	start := time.Date(2025, time.March, 4, 15, 30, 0, 0, time.UTC)
+	c := testCache(t, start)
+	latestPath := filepath.Join(c.basePath, c.getLatestFilename("aws", "123456789012", "soc2"))
+	indexPath := filepath.Join(c.basePath, indexFilename)
+	latestBefore, err := os.ReadFile(latestPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	indexBefore, err := os.ReadFile(indexPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A crash mid-write leaves a truncated temporary file behind
+	partial := filepath.Join(c.basePath, "."+filepath.Base(latestPath)+".tmp-123")
+	if err := os.WriteFile(partial, latestBefore[:len(latestBefore)/2], 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// A write that fails before it lands
+	renameFile = func(oldpath, newpath string) error {
+		return &os.LinkError{Op: "rename", Old: oldpath, New: newpath, Err: os.ErrPermission}
+	}
+	err = c.Save(CachedScan{Timestamp: start.Add(time.Hour), Provider: "aws", AccountID: "123456789012", Framework: "soc2", Score: 40})
+	renameFile = os.Rename
+	if err == nil {
+		t.Fatal("Save succeeded with every rename failing")
+	}
+
+	if latest, err := os.ReadFile(latestPath); err != nil || string(latest) != string(latestBefore) {
+		t.Errorf("latest file changed by a failed write (err %v)", err)
+	}
+	if index, err := os.ReadFile(indexPath); err != nil || string(index) != string(indexBefore) {
+		t.Errorf("index changed by a failed write (err %v)", err)
+	}
+
+	latest, err := c.LoadLatest("aws", "123456789012", "soc2")
+	if err != nil {
+		t.Fatalf("previous latest scan no longer loads: %v", err)
+	}
+	if !latest.Timestamp.Equal(start) || latest.Score != 80 {
+		t.Errorf("LoadLatest = scan at %v scoring %v, want the previous scan at %v scoring 80", latest.Timestamp, latest.Score, start)
+	}
+	scans, err := c.ListScans("aws", "123456789012", "soc2")
+	if err != nil {
+		t.Fatalf("ListScans: %v", err)
+	}
+	if len(scans) != 1 {
+		t.Errorf("got %d scans, want only the previous one", len(scans))
+	}
+
+	entries, err := os.ReadDir(c.basePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if strings.Contains(entry.Name(), ".tmp-") && filepath.Join(c.basePath, entry.Name()) != partial {
+			t.Errorf("failed write left temporary file %s", entry.Name())
+		}
+	}
+}
@@ -0,0 +1,15 @@
+//go:build !unix
+
+package offline
+
+import "sync"
+
+// cacheMu stands in for flock where it is unavailable. It serializes writers
+// within this process only.
+var cacheMu sync.Mutex
+
+// lockFile serializes cache writers in this process; path is unused
+func lockFile(path string) (unlock func(), err error) {
+	cacheMu.Lock()
+	return cacheMu.Unlock, nil
+}
@@ -0,0 +1,77 @@
+package offline
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// pathToken matches a {token} in a report path template
+var pathToken = regexp.MustCompile(`\{([A-Za-z_]+)\}`)
+
+// IsPathTemplate reports whether path contains {token}s for ExpandReportPath
+func IsPathTemplate(path string) bool {
+	return pathToken.MatchString(path)
+}
+
+// ExpandReportPath fills a report path template from the scan it reports on
+// and creates the directories the path needs, so batch scans across accounts
+// write an organized tree of reports, e.g.
+//
+//	reports/{provider}/{account}/{framework}-{timestamp}.json
+//
+// The tokens are the fields cache filenames are built from: {provider},
+// {account}, {framework} and {timestamp}, formatted as in cache filenames
+// (20060102-150405). Values are made safe as a single path element, so
+// an account cannot add directories of its own. Unknown tokens are an error,
+// so a typo does not end up in the filename.
+func ExpandReportPath(template string, scan CachedScan) (string, error) {
+	values := map[string]string{
+		"provider":  scan.Provider,
+		"account":   scan.AccountID,
+		"framework": scan.Framework,
+		"timestamp": scan.Timestamp.Format(scanTimestampFormat),
+	}
+
+	var unknown []string
+	path := pathToken.ReplaceAllStringFunc(template, func(token string) string {
+		name := strings.Trim(token, "{}")
+		value, ok := values[name]
+		if !ok {
+			unknown = append(unknown, token)
+			return token
+		}
+		return pathElement(value)
+	})
+	if len(unknown) > 0 {
+		return "", fmt.Errorf("unknown report path token %s (use {provider}, {account}, {framework} or {timestamp})", strings.Join(unknown, ", "))
+	}
+
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return "", fmt.Errorf("failed to create report directory %s: %w", dir, err)
+		}
+	}
+	return path, nil
+}
+
+// pathElement makes value safe as one path element: separators and other
+// characters filenames cannot hold become "_", and an empty value is "unknown"
+func pathElement(value string) string {
+	value = strings.TrimSpace(value)
+	if value == "" || value == "." || value == ".." {
+		return "unknown"
+	}
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', '*', '?', '"', '<', '>', '|':
+			return '_'
+		}
+		if r < ' ' {
+			return '_'
+		}
+		return r
+	}, value)
+}
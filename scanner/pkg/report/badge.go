@@ -0,0 +1,91 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/guardian-nexus/auditkit/scanner/pkg/cli"
+	"github.com/guardian-nexus/auditkit/scanner/pkg/offline"
+)
+
+// badgeColors are the shields.io colors for each score band (see
+// cli.ScoreBand), so the badge agrees with the terminal summary
+var badgeColors = map[string]string{
+	cli.ScoreBandExcellent: "#4c1",
+	cli.ScoreBandGood:      "#97ca00",
+	cli.ScoreBandFair:      "#dfb317",
+	cli.ScoreBandPoor:      "#e05d44",
+}
+
+// BadgeLabel is the left-hand text of the score badge
+const BadgeLabel = "AuditKit"
+
+// badgeTemplate is a shields.io "flat" badge: label on grey, value on the
+// score color. Arguments: total width, label width, value width, color, label
+// center, value center, label, value (text positions are in tenths of a
+// pixel, as shields.io renders them).
+const badgeTemplate = `<svg xmlns="http://www.w3.org/2000/svg" width="%[1]d" height="20" role="img" aria-label="%[7]s: %[8]s">
+  <title>%[7]s: %[8]s</title>
+  <linearGradient id="s" x2="0" y2="100%%">
+    <stop offset="0" stop-color="#bbb" stop-opacity=".1"/>
+    <stop offset="1" stop-opacity=".1"/>
+  </linearGradient>
+  <clipPath id="r"><rect width="%[1]d" height="20" rx="3" fill="#fff"/></clipPath>
+  <g clip-path="url(#r)">
+    <rect width="%[2]d" height="20" fill="#555"/>
+    <rect x="%[2]d" width="%[3]d" height="20" fill="%[4]s"/>
+    <rect width="%[1]d" height="20" fill="url(#s)"/>
+  </g>
+  <g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="110">
+    <text x="%[5]d" y="150" fill="#010101" fill-opacity=".3" transform="scale(.1)">%[7]s</text>
+    <text x="%[5]d" y="140" transform="scale(.1)">%[7]s</text>
+    <text x="%[6]d" y="150" fill="#010101" fill-opacity=".3" transform="scale(.1)">%[8]s</text>
+    <text x="%[6]d" y="140" transform="scale(.1)">%[8]s</text>
+  </g>
+</svg>
+`
+
+// badgeTextWidth approximates the rendered width of 11px Verdana text, with
+// the 5px padding either side
+func badgeTextWidth(text string) int {
+	return len([]rune(text))*7 + 10
+}
+
+// WriteBadge writes a shields.io-style SVG badge for the scan's score, e.g.
+// "AuditKit | 82%", colored by the score's band
+func WriteBadge(w io.Writer, scan offline.CachedScan) error {
+	value := fmt.Sprintf("%.0f%%", scan.Score)
+	labelWidth := badgeTextWidth(BadgeLabel)
+	valueWidth := badgeTextWidth(value)
+
+	_, err := fmt.Fprintf(w, badgeTemplate,
+		labelWidth+valueWidth, labelWidth, valueWidth, badgeColors[cli.ScoreBand(scan.Score)],
+		labelWidth*5, labelWidth*10+valueWidth*5, BadgeLabel, value)
+	if err != nil {
+		return fmt.Errorf("failed to write badge: %w", err)
+	}
+	return nil
+}
+
+// BadgeHandler serves the badge for the scan load returns, loading it on
+// every request so the badge follows the latest cached scan
+func BadgeHandler(load func() (*offline.CachedScan, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scan, err := load()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+
+		var buf bytes.Buffer
+		if err := WriteBadge(&buf, *scan); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "image/svg+xml")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Write(buf.Bytes())
+	})
+}
@@ -7,20 +7,28 @@ import (
 	"os"
 	"strings"
 	"time"
+
+	"github.com/guardian-nexus/auditkit/scanner/pkg/mappings"
 )
 
-// Generate unique report ID from timestamp + license
-func generateReportIDHTML() string {
+// Generate unique report ID from the scan timestamp + license
+func generateReportIDHTML(scanTime time.Time) string {
 	licenseKey := os.Getenv("AUDITKIT_PRO_LICENSE")
 	if licenseKey == "" {
 		licenseKey = "unlicensed"
 	}
-	data := time.Now().String() + licenseKey
+	data := scanTime.UTC().String() + licenseKey
 	hash := sha256.Sum256([]byte(data))
 	return strings.ToUpper(hex.EncodeToString(hash[:8]))
 }
 
 func GenerateHTML(result ComplianceResult) string {
+	return GenerateHTMLWithOptions(result, DefaultOptions("html"))
+}
+
+// GenerateHTMLWithOptions renders the HTML report. Scores and tab counts use
+// every control; opts only decides whether passing controls are listed.
+func GenerateHTMLWithOptions(result ComplianceResult, opts Options) string {
 	// Count automated vs manual checks
 	automated := 0
 	manual := 0
@@ -51,11 +59,19 @@ func GenerateHTML(result ComplianceResult) string {
 	if len(licenseKey) >= 8 {
 		lastEight = licenseKey[len(licenseKey)-8:]
 	}
-	reportID := generateReportIDHTML()
-	timestamp := time.Now().Format("2006-01-02 15:04:05")
+	scanTime := reportTime(result)
+	reportID := generateReportIDHTML(scanTime)
+	timestamp := scanTime.Format("2006-01-02 15:04:05")
+
+	passedHTML := generatePassedControlsHTML(result)
+	if !opts.IncludePassing {
+		passedHTML = fmt.Sprintf(`<div class="control-card">
+                    <div class="control-title">%d passing controls omitted from this report.</div>
+                </div>`, countByStatus(result.Controls, "PASS"))
+	}
 
 	// Generate the disclaimer banner HTML
-	disclaimerHTML := fmt.Sprintf(`
+	disclaimerHTML := generateStaleScanHTML(result) + generateIncompleteScanHTML(result) + fmt.Sprintf(`
         <div class="disclaimer-banner">
             <h3>⚠️ Important: Automated Technical Checks Only</h3>
             <p>This report shows <strong>%d automated technical checks</strong> out of <strong>%d total controls</strong>. 
@@ -514,6 +530,22 @@ func GenerateHTML(result ComplianceResult) string {
             margin-top: 10px;
         }
         
+        .control-rationale {
+            margin-top: 10px;
+            color: #57606a;
+            font-size: 0.9em;
+            line-height: 1.6;
+        }
+
+        .control-effort {
+            margin-top: 10px;
+            padding: 8px 12px;
+            background: #fff8c5;
+            border-left: 3px solid #d4a72c;
+            border-radius: 4px;
+            font-size: 0.9em;
+        }
+        
         .control-evidence {
             margin-top: 15px;
             padding: 15px;
@@ -601,7 +633,7 @@ func GenerateHTML(result ComplianceResult) string {
     <div class="container">
         <div class="header">
             <h1>%s</h1>
-            <div class="subtitle">Generated %s | Account: %s</div>
+            <div class="subtitle">Generated %s | Account: %s</div>%s
         </div>
         
         <div class="score-card">
@@ -677,8 +709,9 @@ func GenerateHTML(result ComplianceResult) string {
 </html>`,
 		getFrameworkLabel(result.Framework),
 		getFrameworkLabel(result.Framework),
-		result.Timestamp.Format("January 2, 2006 at 3:04 PM"),
+		opts.FormatTime(result.Timestamp),
 		result.AccountID,
+		generateMetadataHTML(result),
 		getScoreClass(result.Score),
 		result.Score,
 		result.TotalControls,
@@ -691,9 +724,9 @@ func GenerateHTML(result ComplianceResult) string {
 		countByStatus(result.Controls, "PASS"),
 		countByStatus(result.Controls, "INFO"),
 		generateFailedControlsHTML(result),
-		generatePassedControlsHTML(result),
+		passedHTML,
 		generateInfoControlsHTML(result),
-		footerHTML,
+		generateNotAssessedHTML(result)+generateSkippedChecksHTML(result)+generateSlowestChecksHTML(result)+footerHTML,
 	)
 }
 
@@ -815,6 +848,15 @@ func generateFailedControlsHTML(result ComplianceResult) string {
 				control.Evidence,
 			)
 
+			if info, ok := mappings.LookupControl(control.ID); ok {
+				html += fmt.Sprintf(`
+                    <div class="control-rationale">
+                        <strong>%s %s:</strong> %s <em>Why it matters:</em> %s
+                    </div>`,
+					control.ID, info.Title, info.Description, info.Rationale,
+				)
+			}
+
 			if control.Remediation != "" {
 				html += fmt.Sprintf(`
                     <div class="control-fix">$ %s</div>`,
@@ -822,34 +864,13 @@ func generateFailedControlsHTML(result ComplianceResult) string {
 				)
 			}
 
-			if control.ScreenshotGuide != "" || control.ConsoleURL != "" {
-				html += `<div class="control-evidence">
-                        <div class="evidence-title">
-                            <span>Evidence Collection Guide</span>
-                        </div>`
-
-				if control.ScreenshotGuide != "" {
-					html += `<ul class="evidence-steps">`
-					steps := strings.Split(control.ScreenshotGuide, "\n")
-					for _, step := range steps {
-						step = strings.TrimSpace(step)
-						if len(step) > 0 {
-							html += fmt.Sprintf(`<li>%s</li>`, step)
-						}
-					}
-					html += `</ul>`
-				}
-
-				if control.ConsoleURL != "" {
-					html += fmt.Sprintf(`
-                        <a href="%s" target="_blank" class="console-link">Open Console →</a>`,
-						control.ConsoleURL,
-					)
-				}
-
-				html += `</div>`
+			if control.RequiresRecreate {
+				html += `
+                    <div class="control-effort">Heavy lift: fixing this requires recreating the resource</div>`
 			}
 
+			html += evidenceGuideHTML(control, "Evidence Collection Guide", "Open Console →")
+
 			html += `</div>`
 		}
 	}
@@ -877,13 +898,20 @@ func generatePassedControlsHTML(result ComplianceResult) string {
                         <div class="control-title">%d. [%s] %s</div>
                         <span class="control-badge badge-pass">PASS</span>
                     </div>
-                    <div class="control-issue">%s</div>
-                </div>`,
+                    <div class="control-issue">
+                        <strong>Why this passed:</strong> %s
+                    </div>`,
 				passedCount,
 				control.ID,
 				control.Name,
 				control.Evidence,
 			)
+
+			// The same guide that documents a fix shows an auditor where to
+			// confirm the compliant setting
+			html += evidenceGuideHTML(control, "Verify Compliance", "View in Console →")
+
+			html += `</div>`
 		}
 	}
 
@@ -896,6 +924,50 @@ func generatePassedControlsHTML(result ComplianceResult) string {
 	return html
 }
 
+// evidenceGuideHTML renders a control's screenshot steps and console link
+// under title, or "" when the control has neither
+func evidenceGuideHTML(control ControlResult, title, linkText string) string {
+	if control.ScreenshotGuide == "" && control.ConsoleURL == "" {
+		return ""
+	}
+
+	html := fmt.Sprintf(`<div class="control-evidence">
+                        <div class="evidence-title">
+                            <span>%s</span>
+                        </div>`, title)
+
+	if control.EvidenceSteps != nil && len(control.EvidenceSteps.Steps) > 0 {
+		// Step-by-step screenshot checklist
+		html += `<ol class="evidence-steps">`
+		for _, step := range control.EvidenceSteps.Steps {
+			html += fmt.Sprintf(`<li>%s</li>`, step)
+		}
+		if control.EvidenceSteps.Expected != "" {
+			html += fmt.Sprintf(`<li>Screenshot showing: <strong>%s</strong></li>`, control.EvidenceSteps.Expected)
+		}
+		html += `</ol>`
+	} else if control.ScreenshotGuide != "" {
+		html += `<ul class="evidence-steps">`
+		steps := strings.Split(control.ScreenshotGuide, "\n")
+		for _, step := range steps {
+			step = strings.TrimSpace(step)
+			if len(step) > 0 {
+				html += fmt.Sprintf(`<li>%s</li>`, step)
+			}
+		}
+		html += `</ul>`
+	}
+
+	if control.ConsoleURL != "" {
+		html += fmt.Sprintf(`
+                        <a href="%s" target="_blank" class="console-link">%s</a>`,
+			control.ConsoleURL, linkText,
+		)
+	}
+
+	return html + `</div>`
+}
+
 func generateInfoControlsHTML(result ComplianceResult) string {
 	html := ""
 
@@ -919,33 +991,7 @@ func generateInfoControlsHTML(result ComplianceResult) string {
 				control.Evidence,
 			)
 
-			if control.ScreenshotGuide != "" || control.ConsoleURL != "" {
-				html += `<div class="control-evidence">
-                        <div class="evidence-title">
-                            <span>Evidence Collection Guide</span>
-                        </div>`
-
-				if control.ScreenshotGuide != "" {
-					html += `<ul class="evidence-steps">`
-					steps := strings.Split(control.ScreenshotGuide, "\n")
-					for _, step := range steps {
-						step = strings.TrimSpace(step)
-						if len(step) > 0 {
-							html += fmt.Sprintf(`<li>%s</li>`, step)
-						}
-					}
-					html += `</ul>`
-				}
-
-				if control.ConsoleURL != "" {
-					html += fmt.Sprintf(`
-                        <a href="%s" target="_blank" class="console-link">View in Console →</a>`,
-						control.ConsoleURL,
-					)
-				}
-
-				html += `</div>`
-			}
+			html += evidenceGuideHTML(control, "Evidence Collection Guide", "View in Console →")
 
 			html += `</div>`
 		}
@@ -960,6 +1006,126 @@ func generateInfoControlsHTML(result ComplianceResult) string {
 	return html
 }
 
+// generateMetadataHTML renders the scan metadata line under the report title.
+// Empty for results without metadata (e.g. older cached scans).
+func generateMetadataHTML(result ComplianceResult) string {
+	if result.Metadata == nil {
+		return ""
+	}
+	return fmt.Sprintf(`
+            <div class="subtitle">%s</div>
+            <div class="subtitle">Services: %s</div>`,
+		result.Metadata.Summary(), strings.Join(result.Metadata.Services, ", "))
+}
+
+// generateIncompleteScanHTML warns that the scan stopped early, so a partial
+// report is not mistaken for a full assessment. Empty for complete scans.
+func generateIncompleteScanHTML(result ComplianceResult) string {
+	if result.Metadata == nil || !result.Metadata.Aborted {
+		return ""
+	}
+	completed := ""
+	if len(result.Metadata.CompletedServices) > 0 {
+		completed = fmt.Sprintf(`
+            <p>Services that completed: %s.</p>`, strings.Join(result.Metadata.CompletedServices, ", "))
+	}
+	return fmt.Sprintf(`
+        <div class="disclaimer-banner">
+            <h3>⚠️ Incomplete Scan</h3>
+            <p>This scan was <strong>aborted early</strong>: %s.
+               Checks after that point did not run, so this report is <strong>not a full assessment</strong>.</p>%s
+        </div>`, result.Metadata.AbortReason, completed)
+}
+
+// generateStaleScanHTML warns that the results were loaded from the offline
+// cache after the live scan failed, so an old scan is not mistaken for the
+// account's current state. Empty for live scans.
+func generateStaleScanHTML(result ComplianceResult) string {
+	if result.Metadata == nil || !result.Metadata.Stale {
+		return ""
+	}
+	return fmt.Sprintf(`
+        <div class="disclaimer-banner">
+            <h3>⚠️ STALE/OFFLINE: Cached Results</h3>
+            <p>The live scan failed (%s), so this report shows the <strong>cached scan from %s</strong>,
+               <strong>%s old</strong>. It does not reflect changes made since then.</p>
+        </div>`, result.Metadata.StaleReason, result.Timestamp.Format("2006-01-02 15:04"),
+		result.Metadata.CacheAge().Round(time.Minute))
+}
+
+// generateNotAssessedHTML lists framework controls with no automated check so
+// auditors can scope manual testing. Empty when there are no gaps.
+func generateNotAssessedHTML(result ComplianceResult) string {
+	if len(result.NotAssessed) == 0 {
+		return ""
+	}
+
+	items := ""
+	for _, id := range result.NotAssessed {
+		items += fmt.Sprintf(`
+                <div class="control-card">
+                    <div class="control-title">%s</div>
+                </div>`, id)
+	}
+
+	return fmt.Sprintf(`
+        <div class="controls-section">
+            <h2>Not Assessed (%d)</h2>
+            <p>These %s controls are not covered by any automated check and must be assessed manually.</p>
+            %s
+        </div>
+    `, len(result.NotAssessed), getFrameworkLabel(result.Framework), items)
+}
+
+// generateSkippedChecksHTML lists checks disabled by configuration so a
+// reader can see what was deliberately not evaluated. Empty when none were.
+func generateSkippedChecksHTML(result ComplianceResult) string {
+	if len(result.SkippedChecks) == 0 {
+		return ""
+	}
+
+	items := ""
+	for _, id := range result.SkippedChecks {
+		items += fmt.Sprintf(`
+                <div class="control-card">
+                    <div class="control-title">%s</div>
+                </div>`, id)
+	}
+
+	return fmt.Sprintf(`
+        <div class="controls-section">
+            <h2>Skipped Checks (%d)</h2>
+            <p>These checks were disabled in the checks configuration and did not run.</p>
+            %s
+        </div>
+    `, len(result.SkippedChecks), items)
+}
+
+// generateSlowestChecksHTML lists the checks that took longest, to guide
+// tuning concurrency or narrowing -services. Empty without timing data.
+func generateSlowestChecksHTML(result ComplianceResult) string {
+	if result.Metadata == nil || len(result.Metadata.SlowestChecks) == 0 {
+		return ""
+	}
+
+	items := ""
+	for _, timing := range result.Metadata.SlowestChecks {
+		items += fmt.Sprintf(`
+                <div class="control-card">
+                    <div class="control-title">%s</div>
+                    <div class="control-evidence">%.2fs</div>
+                </div>`, timing.Name, timing.Seconds)
+	}
+
+	return fmt.Sprintf(`
+        <div class="controls-section">
+            <h2>Slowest Checks</h2>
+            <p>The checks that took longest to run in this scan.</p>
+            %s
+        </div>
+    `, items)
+}
+
 func countByStatus(controls []ControlResult, status string) int {
 	count := 0
 	for _, control := range controls {
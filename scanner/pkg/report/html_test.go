@@ -0,0 +1,20 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGenerateHTMLIsDeterministic(t *testing.T) {
+	first := GenerateHTML(FromCachedScan(testScan()))
+	time.Sleep(1100 * time.Millisecond)
+	second := GenerateHTML(FromCachedScan(testScan()))
+
+	if first != second {
+		t.Fatal("rendering the same scan twice produced different HTML")
+	}
+	if !strings.Contains(first, "2025-03-04 15:30:00") {
+		t.Error("HTML report is not stamped with the scan timestamp")
+	}
+}
@@ -0,0 +1,48 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/guardian-nexus/auditkit/scanner/pkg/aws/checks"
+)
+
+// ndjsonResult is one NDJSON line: the CheckResult with its FindingID
+type ndjsonResult struct {
+	checks.CheckResult
+	FindingID string `json:"finding_id"`
+}
+
+// WriteNDJSON writes results as newline-delimited JSON, one CheckResult per
+// line with its finding_id, for log pipelines that stream-process findings.
+// Each line is a complete JSON object; newlines inside fields are escaped by
+// the encoder.
+func WriteNDJSON(w io.Writer, results []checks.CheckResult) error {
+	encoder := json.NewEncoder(w)
+	for _, result := range results {
+		if err := encoder.Encode(ndjsonResult{result, result.FindingID()}); err != nil {
+			return fmt.Errorf("failed to write NDJSON result %s: %w", result.Control, err)
+		}
+	}
+	return nil
+}
+
+// StreamNDJSON writes results from a RunStream channel as they arrive, one per
+// line, and returns once the channel is closed. After a write error the rest
+// of the stream is drained so the producer is not left blocked, and the first
+// error is returned.
+func StreamNDJSON(w io.Writer, stream <-chan checks.CheckResult) error {
+	encoder := json.NewEncoder(w)
+
+	var writeErr error
+	for result := range stream {
+		if writeErr != nil {
+			continue
+		}
+		if err := encoder.Encode(ndjsonResult{result, result.FindingID()}); err != nil {
+			writeErr = fmt.Errorf("failed to write NDJSON result %s: %w", result.Control, err)
+		}
+	}
+	return writeErr
+}
@@ -0,0 +1,77 @@
+package report
+
+import (
+	"strings"
+	"time"
+)
+
+// Options controls what the report writers include
+type Options struct {
+	// IncludePassing keeps PASS controls in the output. Scores and totals are
+	// always computed from the full result set either way.
+	IncludePassing bool
+
+	// Location is the timezone report timestamps are rendered in, so teams in
+	// different zones read the same times. nil means UTC.
+	Location *time.Location
+}
+
+// In converts t to the report timezone
+func (o Options) In(t time.Time) time.Time {
+	if o.Location == nil {
+		return t.UTC()
+	}
+	return t.In(o.Location)
+}
+
+// FormatTime renders t in the report timezone as ISO 8601 (RFC 3339), e.g.
+// "2026-03-01T14:05:00Z" or "2026-03-01T09:05:00-05:00"
+func (o Options) FormatTime(t time.Time) string {
+	return o.In(t).Format(time.RFC3339)
+}
+
+// DefaultOptions returns the defaults for an output format. Machine-readable
+// and file reports include everything as evidence of coverage; the terminal
+// view shows failures only to keep large accounts readable.
+func DefaultOptions(format string) Options {
+	switch strings.ToLower(format) {
+	case "text", "":
+		return Options{IncludePassing: false}
+	default:
+		return Options{IncludePassing: true}
+	}
+}
+
+// FilterByStatus returns the controls whose status matches one of statuses
+// (case-insensitive). With no statuses the input is returned unchanged.
+func FilterByStatus(controls []ControlResult, statuses ...string) []ControlResult {
+	if len(statuses) == 0 {
+		return controls
+	}
+
+	filtered := []ControlResult{}
+	for _, control := range controls {
+		for _, status := range statuses {
+			if strings.EqualFold(control.Status, status) {
+				filtered = append(filtered, control)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+// Filter applies the options to a control list
+func (o Options) Filter(controls []ControlResult) []ControlResult {
+	if o.IncludePassing {
+		return controls
+	}
+
+	filtered := []ControlResult{}
+	for _, control := range controls {
+		if control.Status != "PASS" {
+			filtered = append(filtered, control)
+		}
+	}
+	return filtered
+}
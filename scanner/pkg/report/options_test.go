@@ -0,0 +1,50 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+	_ "time/tzdata" // the zones below must load on systems without zoneinfo
+)
+
+func TestFormatTimeRendersOneScanInEachZone(t *testing.T) {
+	scan := FromCachedScan(testScan())
+
+	cases := []struct {
+		zone string
+		want string
+	}{
+		{"", "2025-03-04T15:30:00Z"},
+		{"America/New_York", "2025-03-04T10:30:00-05:00"},
+		{"Asia/Tokyo", "2025-03-05T00:30:00+09:00"},
+	}
+
+	for _, tc := range cases {
+		opts := DefaultOptions("html")
+		if tc.zone != "" {
+			location, err := time.LoadLocation(tc.zone)
+			if err != nil {
+				t.Fatalf("load %s: %v", tc.zone, err)
+			}
+			opts.Location = location
+		}
+
+		got := opts.FormatTime(scan.Timestamp)
+		if got != tc.want {
+			t.Errorf("%s: FormatTime = %q, want %q", tc.zone, got, tc.want)
+		}
+
+		// Every zone names the same instant
+		parsed, err := time.Parse(time.RFC3339, got)
+		if err != nil {
+			t.Fatalf("%s: %q is not RFC 3339: %v", tc.zone, got, err)
+		}
+		if !parsed.Equal(scan.Timestamp) {
+			t.Errorf("%s: %q is %v, not the scan time %v", tc.zone, got, parsed, scan.Timestamp)
+		}
+
+		if html := GenerateHTMLWithOptions(scan, opts); !strings.Contains(html, tc.want) {
+			t.Errorf("%s: HTML report does not show the scan time as %q", tc.zone, tc.want)
+		}
+	}
+}
@@ -4,10 +4,15 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"time"
 
+	"github.com/guardian-nexus/auditkit/scanner/pkg/core"
+	"github.com/guardian-nexus/auditkit/scanner/pkg/evidence"
+	"github.com/guardian-nexus/auditkit/scanner/pkg/mappings"
+	"github.com/guardian-nexus/auditkit/scanner/pkg/offline"
 	"github.com/jung-kurt/gofpdf"
 )
 
@@ -22,33 +27,97 @@ type ComplianceResult struct {
 	FailedControls  int
 	Controls        []ControlResult
 	Recommendations []string
+	NotAssessed     []string // Framework controls no automated check covers
+	SkippedChecks   []string // Checks disabled by configuration
+	Metadata        *core.ScanMetadata
 }
 
 type ControlResult struct {
-	ID              string
-	Name            string
-	Category        string
-	Severity        string
-	Status          string
-	Evidence        string
-	Remediation     string
-	ScreenshotGuide string
-	ConsoleURL      string
-	Frameworks      map[string]string
+	ID               string
+	Name             string
+	Category         string
+	Severity         string
+	Status           string
+	Evidence         string
+	Remediation      string
+	RequiresRecreate bool // Fix means rebuilding the resource, not a config toggle
+	ScreenshotGuide  string
+	EvidenceSteps    *evidence.Checklist
+	ConsoleURL       string
+	Frameworks       map[string]string
 }
 
-// Generate unique report ID from timestamp + license
-func generateReportID() string {
+// Generate unique report ID from the scan timestamp + license
+func generateReportID(scanTime time.Time) string {
 	licenseKey := os.Getenv("AUDITKIT_PRO_LICENSE")
 	if licenseKey == "" {
 		licenseKey = "unlicensed"
 	}
-	data := time.Now().String() + licenseKey
+	data := scanTime.UTC().String() + licenseKey
 	hash := sha256.Sum256([]byte(data))
 	return strings.ToUpper(hex.EncodeToString(hash[:8]))
 }
 
+// reportTime is the time a report is stamped with: the scan's own timestamp,
+// so rendering the same scan twice gives identical output. Results built
+// without one fall back to the current time.
+func reportTime(result ComplianceResult) time.Time {
+	if result.Timestamp.IsZero() {
+		return time.Now()
+	}
+	return result.Timestamp
+}
+
 func GeneratePDF(result ComplianceResult, outputPath string) error {
+	return buildPDF(result).OutputFileAndClose(outputPath)
+}
+
+// WritePDF writes the PDF report for a cached scan to w: cover page with
+// score, account, framework and date, the executive summary, findings with
+// remediation, and the evidence guide. It renders the same ComplianceResult
+// model as GeneratePDF and the HTML report.
+func WritePDF(w io.Writer, scan offline.CachedScan) error {
+	return buildPDF(FromCachedScan(scan)).Output(w)
+}
+
+// FromCachedScan converts a cached scan into the report data model
+func FromCachedScan(scan offline.CachedScan) ComplianceResult {
+	result := ComplianceResult{
+		Timestamp:       scan.Timestamp,
+		Provider:        scan.Provider,
+		AccountID:       scan.AccountID,
+		Framework:       scan.Framework,
+		Score:           scan.Score,
+		TotalControls:   scan.TotalControls,
+		PassedControls:  scan.PassedControls,
+		FailedControls:  scan.FailedControls,
+		Recommendations: scan.Recommendations,
+		SkippedChecks:   scan.SkippedChecks,
+		Metadata:        scan.Metadata,
+	}
+
+	for _, control := range scan.Controls {
+		result.Controls = append(result.Controls, ControlResult{
+			ID:               control.ID,
+			Name:             control.Name,
+			Category:         control.Category,
+			Severity:         control.Severity,
+			Status:           control.Status,
+			Evidence:         control.Evidence,
+			Remediation:      control.Remediation,
+			RequiresRecreate: control.RequiresRecreate,
+			ScreenshotGuide:  control.ScreenshotGuide,
+			EvidenceSteps:    evidence.ParseGuide(control.ScreenshotGuide, control.ConsoleURL),
+			ConsoleURL:       control.ConsoleURL,
+			Frameworks:       control.Frameworks,
+		})
+	}
+
+	return result
+}
+
+// buildPDF lays out every section of the report
+func buildPDF(result ComplianceResult) *gofpdf.Fpdf {
 	pdf := gofpdf.New("P", "mm", "A4", "")
 	pdf.SetMargins(15, 15, 15)
 
@@ -58,8 +127,14 @@ func GeneratePDF(result ComplianceResult, outputPath string) error {
 	if len(licenseKey) >= 8 {
 		lastEight = licenseKey[len(licenseKey)-8:]
 	}
-	reportID := generateReportID()
-	timestamp := time.Now().Format("2006-01-02 15:04:05")
+	scanTime := reportTime(result)
+	reportID := generateReportID(scanTime)
+	timestamp := scanTime.Format("2006-01-02 15:04:05")
+
+	// Pin the document dates too so the file depends only on the scan
+	pdf.SetCreationDate(scanTime)
+	pdf.SetModificationDate(scanTime)
+	pdf.SetCatalogSort(true)
 
 	// Footer on every page WITH WATERMARK
 	pdf.SetFooterFunc(func() {
@@ -107,7 +182,7 @@ func GeneratePDF(result ComplianceResult, outputPath string) error {
 	// Evidence Checklist
 	generateEvidenceChecklist(pdf, result)
 
-	return pdf.OutputFileAndClose(outputPath)
+	return pdf
 }
 
 func generateCoverPage(pdf *gofpdf.Fpdf, result ComplianceResult) {
@@ -190,6 +265,9 @@ func generateCoverPage(pdf *gofpdf.Fpdf, result ComplianceResult) {
 	pdf.SetTextColor(108, 117, 125)
 	pdf.CellFormat(0, 5, fmt.Sprintf("Generated: %s", result.Timestamp.Format("January 2, 2006 at 3:04 PM")), "", 1, "C", false, 0, "")
 	pdf.CellFormat(0, 5, fmt.Sprintf("Provider: %s | Account: %s", strings.ToUpper(result.Provider), result.AccountID), "", 1, "C", false, 0, "")
+	if result.Metadata != nil {
+		pdf.CellFormat(0, 5, result.Metadata.Summary(), "", 1, "C", false, 0, "")
+	}
 }
 
 func generateComplianceDisclaimer(pdf *gofpdf.Fpdf, result ComplianceResult) {
@@ -516,6 +594,14 @@ func generateControlCard(pdf *gofpdf.Fpdf, control ControlResult, number int, fr
 	pdf.MultiCell(170, 5, fmt.Sprintf("Issue: %s", control.Evidence), "", "L", false)
 	pdf.Ln(2)
 
+	if info, ok := mappings.LookupControl(control.ID); ok {
+		pdf.SetFont("Arial", "I", 9)
+		pdf.SetTextColor(87, 96, 106)
+		pdf.SetX(20)
+		pdf.MultiCell(170, 4, fmt.Sprintf("%s %s - why it matters: %s", control.ID, info.Title, info.Rationale), "", "L", false)
+		pdf.Ln(2)
+	}
+
 	if control.Remediation != "" {
 		pdf.SetFont("Courier", "", 9)
 		pdf.SetTextColor(0, 0, 0)
@@ -653,6 +739,14 @@ func generateEvidenceCard(pdf *gofpdf.Fpdf, control ControlResult, number int) {
 
 	pdf.CellFormat(0, 6, fmt.Sprintf("%d. %s - %s", number, cleanID, cleanName), "", 1, "L", false, 0, "")
 
+	// A passed control's evidence is the positive finding an auditor files
+	if control.Status == "PASS" && control.Evidence != "" {
+		pdf.SetFont("Arial", "", 9)
+		pdf.SetTextColor(40, 167, 69)
+		pdf.SetX(20)
+		pdf.MultiCell(170, 4, fmt.Sprintf("Why this passed: %s", strings.ReplaceAll(control.Evidence, "→", "->")), "", "L", false)
+	}
+
 	if control.ConsoleURL != "" {
 		pdf.SetFont("Arial", "", 9)
 		pdf.SetTextColor(3, 102, 214)
@@ -0,0 +1,48 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/guardian-nexus/auditkit/scanner/pkg/offline"
+)
+
+// testScan is a small fixed scan shared by the report tests
+func testScan() offline.CachedScan {
+	return offline.CachedScan{
+		Timestamp:      time.Date(2025, time.March, 4, 15, 30, 0, 0, time.UTC),
+		Provider:       "aws",
+		Framework:      "soc2",
+		AccountID:      "123456789012",
+		Score:          50,
+		TotalControls:  2,
+		PassedControls: 1,
+		FailedControls: 1,
+		Controls: []offline.CachedControl{
+			{ID: "CC6.1", Name: "S3 Block Public Access", Category: "Security", Severity: "HIGH", Status: "FAIL", Evidence: "1 bucket allows public access: logs", Remediation: "Enable Block Public Access"},
+			{ID: "CC6.3", Name: "EBS Encryption", Category: "Security", Status: "PASS", Evidence: "All 3 volumes are encrypted"},
+		},
+		Recommendations: []string{"Enable Block Public Access"},
+		Version:         "test",
+	}
+}
+
+func TestWritePDFIsDeterministic(t *testing.T) {
+	var first, second bytes.Buffer
+	if err := WritePDF(&first, testScan()); err != nil {
+		t.Fatalf("WritePDF: %v", err)
+	}
+	time.Sleep(1100 * time.Millisecond)
+	if err := WritePDF(&second, testScan()); err != nil {
+		t.Fatalf("WritePDF: %v", err)
+	}
+
+	if !bytes.Equal(first.Bytes(), second.Bytes()) {
+		t.Fatal("rendering the same scan twice produced different PDFs")
+	}
+	if !strings.Contains(first.String(), "D:20250304153000") {
+		t.Error("PDF creation date is not the scan timestamp")
+	}
+}
@@ -0,0 +1,119 @@
+package report
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/guardian-nexus/auditkit/scanner/pkg/aws/checks"
+	"github.com/guardian-nexus/auditkit/scanner/pkg/evidence"
+)
+
+// runbookSeverities is the order runbook sections are written in. Failures
+// with any other severity go in a final "Unrated" section.
+var runbookSeverities = []string{"CRITICAL", "HIGH", "MEDIUM", "LOW"}
+
+// WriteRunbook writes a Markdown remediation runbook for the failing results:
+// one section per severity, most severe first, and one subsection per failing
+// control with its evidence, the remediation commands and the console steps
+// for capturing proof of the fix. PASS, INFO and other non-failing results
+// are left out.
+func WriteRunbook(w io.Writer, results []checks.CheckResult) error {
+	failing := checks.Query(results).WithStatus("FAIL").OrderBySeverity().Results()
+
+	groups := map[string][]checks.CheckResult{}
+	for _, r := range failing {
+		severity := strings.ToUpper(r.Severity)
+		if _, ok := severityRank[severity]; !ok {
+			severity = ""
+		}
+		groups[severity] = append(groups[severity], r)
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "# Remediation Runbook\n\n")
+	if len(failing) == 0 {
+		fmt.Fprintf(bw, "No failing controls. Nothing to remediate.\n")
+	} else {
+		fmt.Fprintf(bw, "%s to remediate, most severe first.\n", plural(len(failing), "failing control", "failing controls"))
+	}
+
+	number := 0
+	for _, severity := range append(runbookSeverities, "") {
+		group := groups[severity]
+		if len(group) == 0 {
+			continue
+		}
+
+		title := severity
+		if title == "" {
+			title = "UNRATED"
+		}
+		fmt.Fprintf(bw, "\n## %s (%d)\n", title, len(group))
+
+		for _, r := range group {
+			number++
+			writeRunbookEntry(bw, number, r)
+		}
+	}
+
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("failed to write runbook: %w", err)
+	}
+	return nil
+}
+
+// writeRunbookEntry writes one failing control as a numbered subsection
+func writeRunbookEntry(w io.Writer, number int, r checks.CheckResult) {
+	fmt.Fprintf(w, "\n### %d. %s: %s\n", number, r.Control, r.Name)
+
+	details := []string{}
+	if r.Service != "" {
+		details = append(details, fmt.Sprintf("- **Service:** %s", r.Service))
+	}
+	if r.Priority.TimeToFix != "" {
+		details = append(details, fmt.Sprintf("- **Time to fix:** %s", r.Priority.TimeToFix))
+	}
+	if r.RequiresRecreate {
+		details = append(details, "- **Requires recreate:** yes, the resource must be rebuilt")
+	}
+	if len(details) > 0 {
+		fmt.Fprintf(w, "\n%s\n", strings.Join(details, "\n"))
+	}
+
+	if finding := strings.TrimSpace(r.Evidence); finding != "" {
+		fmt.Fprintf(w, "\n**Finding**\n\n> %s\n", strings.ReplaceAll(finding, "\n", "\n> "))
+	}
+
+	remediation := strings.TrimSpace(r.Remediation)
+	detail := strings.TrimSpace(r.RemediationDetail)
+	if remediation != "" || detail != "" {
+		fmt.Fprintf(w, "\n**Fix**\n")
+		if remediation != "" {
+			fmt.Fprintf(w, "\n%s\n", remediation)
+		}
+		if detail != "" {
+			fmt.Fprintf(w, "\n```sh\n%s\n```\n", detail)
+		}
+	}
+
+	steps := r.EvidenceSteps
+	if steps == nil {
+		steps = evidence.ParseGuide(r.ScreenshotGuide, r.ConsoleURL)
+	}
+	if steps != nil && (len(steps.Steps) > 0 || steps.Expected != "") {
+		fmt.Fprintf(w, "\n**Verify**\n\n")
+		if steps.ConsoleURL != "" {
+			fmt.Fprintf(w, "Start from %s\n\n", steps.ConsoleURL)
+		}
+		for i, step := range steps.Steps {
+			fmt.Fprintf(w, "%d. %s\n", i+1, step)
+		}
+		if steps.Expected != "" {
+			fmt.Fprintf(w, "%d. Screenshot showing: %s\n", len(steps.Steps)+1, steps.Expected)
+		}
+	} else if r.ConsoleURL != "" {
+		fmt.Fprintf(w, "\n**Verify** in the console: %s\n", r.ConsoleURL)
+	}
+}
@@ -0,0 +1,14 @@
+package report
+
+import _ "embed"
+
+//go:embed schema.json
+var jsonSchema []byte
+
+// JSONSchema returns the JSON Schema (draft 2020-12) for the document written
+// by -format json, so downstream tooling can validate reports and catch field
+// renames. Keep schema.json in step with the JSON tags of the scan command's
+// ComplianceResult and ControlResult.
+func JSONSchema() []byte {
+	return append([]byte(nil), jsonSchema...)
+}
@@ -0,0 +1,142 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/guardian-nexus/auditkit/scanner/pkg/cli"
+	"github.com/guardian-nexus/auditkit/scanner/pkg/offline"
+)
+
+// ScanWriter renders a scan to w in one format. WritePDF and WriteBadge are
+// ScanWriters, as are WriteJSON, HTMLWriter and TableWriter.
+type ScanWriter func(w io.Writer, scan offline.CachedScan) error
+
+// Sink is a destination for a finished scan: the terminal, a report file, a
+// webhook. Sinks receive the scan already built, so one scan can be written
+// to several of them without re-running any check.
+type Sink interface {
+	Write(scan offline.CachedScan) error
+}
+
+// SinkFunc adapts a function to a Sink
+type SinkFunc func(scan offline.CachedScan) error
+
+func (f SinkFunc) Write(scan offline.CachedScan) error {
+	return f(scan)
+}
+
+// MultiSink writes the scan to every sink in order, e.g. a table to the
+// terminal, JSON to a file and a webhook notification. Unlike io.MultiWriter
+// a failing sink does not stop the rest: each sink gets the scan, and their
+// errors are joined into the returned error.
+func MultiSink(sinks ...Sink) Sink {
+	return SinkFunc(func(scan offline.CachedScan) error {
+		var failures []error
+		for _, sink := range sinks {
+			if err := sink.Write(scan); err != nil {
+				failures = append(failures, err)
+			}
+		}
+		return errors.Join(failures...)
+	})
+}
+
+// WriterSink writes the scan to w with write, e.g.
+// WriterSink(os.Stdout, TableWriter(DefaultOptions("text")))
+func WriterSink(w io.Writer, write ScanWriter) Sink {
+	return SinkFunc(func(scan offline.CachedScan) error {
+		return write(w, scan)
+	})
+}
+
+// FileSink writes the scan with write to the file at path, creating or
+// truncating it
+func FileSink(path string, write ScanWriter) Sink {
+	return SinkFunc(func(scan offline.CachedScan) error {
+		file, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("failed to create report %s: %w", path, err)
+		}
+		if err := write(file, scan); err != nil {
+			file.Close()
+			return fmt.Errorf("failed to write report %s: %w", path, err)
+		}
+		if err := file.Close(); err != nil {
+			return fmt.Errorf("failed to write report %s: %w", path, err)
+		}
+		return nil
+	})
+}
+
+// WebhookSink POSTs the scan as JSON to url. A nil client uses
+// http.DefaultClient; responses outside 2xx are errors.
+func WebhookSink(url string, client *http.Client) Sink {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return SinkFunc(func(scan offline.CachedScan) error {
+		var body bytes.Buffer
+		if err := json.NewEncoder(&body).Encode(scan); err != nil {
+			return fmt.Errorf("failed to encode scan for webhook: %w", err)
+		}
+
+		resp, err := client.Post(url, "application/json", &body)
+		if err != nil {
+			return fmt.Errorf("failed to post scan to webhook: %w", err)
+		}
+		defer resp.Body.Close()
+		io.Copy(io.Discard, resp.Body)
+
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return fmt.Errorf("webhook returned %s", resp.Status)
+		}
+		return nil
+	})
+}
+
+// WriteJSON writes the scan as indented JSON, the layout of the JSON report
+// and the offline cache
+func WriteJSON(w io.Writer, scan offline.CachedScan) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(scan); err != nil {
+		return fmt.Errorf("failed to write JSON report: %w", err)
+	}
+	return nil
+}
+
+// HTMLWriter returns a ScanWriter for the HTML report rendered with opts
+func HTMLWriter(opts Options) ScanWriter {
+	return func(w io.Writer, scan offline.CachedScan) error {
+		if _, err := io.WriteString(w, GenerateHTMLWithOptions(FromCachedScan(scan), opts)); err != nil {
+			return fmt.Errorf("failed to write HTML report: %w", err)
+		}
+		return nil
+	}
+}
+
+// TableWriter returns a ScanWriter for the terminal view: the summary box
+// and a table of the controls opts keeps
+func TableWriter(opts Options) ScanWriter {
+	return func(w io.Writer, scan offline.CachedScan) error {
+		if _, err := fmt.Fprintln(w, cli.SummaryBox(scan.Provider, scan.AccountID, scan.Framework,
+			scan.Score, scan.PassedControls, scan.FailedControls, scan.TotalControls)); err != nil {
+			return fmt.Errorf("failed to write table: %w", err)
+		}
+
+		rows := []struct{ ID, Name, Status, Severity string }{}
+		for _, control := range opts.Filter(FromCachedScan(scan).Controls) {
+			rows = append(rows, struct{ ID, Name, Status, Severity string }{
+				control.ID, control.Name, control.Status, control.Severity,
+			})
+		}
+		cli.WriteResultTable(w, rows)
+		return nil
+	}
+}
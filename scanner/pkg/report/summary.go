@@ -0,0 +1,231 @@
+package report
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/guardian-nexus/auditkit/scanner/pkg/offline"
+)
+
+// severityRank orders findings from most to least risky
+var severityRank = map[string]int{
+	"CRITICAL": 4,
+	"HIGH":     3,
+	"MEDIUM":   2,
+	"LOW":      1,
+}
+
+// ExecutiveSummary writes a short plain-English paragraph about a scan that
+// can be pasted into an email: the score, how many critical findings there
+// are, the three riskiest findings and, when previous is given, whether
+// posture improved since then, separating score changes caused by checks
+// added or removed between tool versions. A scan that found no resources
+// ends with its EmptyScanNote.
+func ExecutiveSummary(scan offline.CachedScan, previous *offline.CachedScan) string {
+	var b strings.Builder
+
+	framework := strings.ToUpper(scan.Framework)
+	if framework == "" || framework == "ALL" {
+		framework = "multi-framework"
+	}
+	automated := scan.PassedControls + scan.FailedControls
+	fmt.Fprintf(&b, "The %s %s scan of account %s on %s passed %.0f%% of automated checks (%d of %d).",
+		strings.ToUpper(scan.Provider), framework, scan.AccountID,
+		scan.Timestamp.Format("January 2, 2006"), scan.Score, scan.PassedControls, automated)
+
+	failing := []offline.CachedControl{}
+	critical := 0
+	for _, control := range scan.Controls {
+		if control.Status != "FAIL" {
+			continue
+		}
+		failing = append(failing, control)
+		if controlSeverity(control) == "CRITICAL" {
+			critical++
+		}
+	}
+
+	switch {
+	case len(failing) == 0:
+		b.WriteString(" No automated check failed.")
+	case critical == 0:
+		fmt.Fprintf(&b, " None of the %s is critical.", plural(len(failing), "failing check", "failing checks"))
+	case critical == 1:
+		b.WriteString(" One critical finding needs immediate attention.")
+	default:
+		fmt.Fprintf(&b, " %d critical findings need immediate attention.", critical)
+	}
+
+	if len(failing) > 0 {
+		sort.SliceStable(failing, func(i, j int) bool {
+			return severityRank[controlSeverity(failing[i])] > severityRank[controlSeverity(failing[j])]
+		})
+		if len(failing) > 3 {
+			failing = failing[:3]
+		}
+
+		names := []string{}
+		for _, control := range failing {
+			name := control.Name
+			if name == "" {
+				name = control.ID
+			}
+			if severity := controlSeverity(control); severity != "" {
+				name = fmt.Sprintf("%s (%s)", name, strings.ToLower(severity))
+			}
+			names = append(names, name)
+		}
+
+		if len(names) == 1 {
+			fmt.Fprintf(&b, " The riskiest issue is %s.", names[0])
+		} else {
+			fmt.Fprintf(&b, " The riskiest issues are %s.", joinWithAnd(names))
+		}
+	}
+
+	if previous != nil {
+		diff := offline.DiffScans(&scan, previous)
+		since := previous.Timestamp.Format("January 2")
+
+		// Rounded as printed, so a change that reads "0.0 points" is no change
+		delta := math.Round(diff.PostureScoreDelta()*10) / 10
+		switch {
+		case delta > 0:
+			fmt.Fprintf(&b, " Posture has improved since the %s scan, up %.1f points", since, delta)
+		case delta < 0:
+			fmt.Fprintf(&b, " Posture has slipped since the %s scan, down %.1f points", since, -delta)
+		case diff.CatalogChange() != "":
+			fmt.Fprintf(&b, " Posture is unchanged since the %s scan", since)
+		default:
+			fmt.Fprintf(&b, " The score is unchanged since the %s scan", since)
+		}
+
+		switch {
+		case len(diff.Resolved) > 0 && len(diff.NewFailures) > 0:
+			fmt.Fprintf(&b, ", with %s resolved and %s.",
+				plural(len(diff.Resolved), "issue", "issues"), plural(len(diff.NewFailures), "new failure", "new failures"))
+		case len(diff.Resolved) > 0:
+			fmt.Fprintf(&b, ", with %s resolved and nothing new failing.", plural(len(diff.Resolved), "issue", "issues"))
+		case len(diff.NewFailures) > 0:
+			fmt.Fprintf(&b, ", with %s.", plural(len(diff.NewFailures), "new failure", "new failures"))
+		default:
+			b.WriteString(".")
+		}
+
+		if change := diff.CatalogChange(); change != "" {
+			fmt.Fprintf(&b, " A further %+.1f points are due to %s in this version of AuditKit rather than changes to the account.",
+				diff.CatalogScoreDelta, change)
+		}
+	}
+
+	if note := EmptyScanNote(scan); note != "" {
+		b.WriteString(" " + note)
+	}
+
+	return b.String()
+}
+
+var (
+	// nothingFoundEvidence is how checks report a service with nothing to
+	// evaluate, e.g. "No Redshift clusters found"
+	nothingFoundEvidence = regexp.MustCompile(`^No .*\b(found|to check)\b`)
+
+	// resourceCountEvidence is the count checks lead with when they evaluated
+	// resources, e.g. "All 4 tables ..." or "3 clusters without ..."
+	resourceCountEvidence = regexp.MustCompile(`^(?:All )?(\d+)(?:/\d+)? `)
+)
+
+// EmptyScanNote explains a scan that found no resources anywhere, so its
+// passing checks are not mistaken for a hardened account, or returns "" when
+// the scan saw resources. It tells an empty account, where every service
+// check reported nothing found, from one the scan could not read, where
+// checks errored (see core.ScanMetadata.CheckErrors) or reported errors.
+//
+// A scan saw resources if a check listed affected resources or counted the
+// resources it evaluated; checks reporting "No X found" saw none. Account
+// settings such as the password policy are not resources.
+func EmptyScanNote(scan offline.CachedScan) string {
+	nothingFound := 0
+	errored := 0
+	for _, control := range scan.Controls {
+		if len(control.AffectedResources) > 0 {
+			return ""
+		}
+		if match := resourceCountEvidence.FindStringSubmatch(control.Evidence); match != nil {
+			if n, _ := strconv.Atoi(match[1]); n > 0 {
+				return ""
+			}
+		}
+		switch {
+		case control.Status == "ERROR":
+			errored++
+		case nothingFoundEvidence.MatchString(control.Evidence):
+			nothingFound++
+		}
+	}
+
+	kinds := []string{}
+	if scan.Metadata != nil {
+		errored += scan.Metadata.CheckErrorCount()
+		for _, kind := range []string{"access_denied", "throttled", "service_unavailable", "other"} {
+			if n := scan.Metadata.CheckErrors[kind]; n > 0 {
+				kinds = append(kinds, fmt.Sprintf("%d %s", n, strings.ReplaceAll(kind, "_", " ")))
+			}
+		}
+	}
+
+	switch {
+	case errored > 0:
+		reason := ""
+		if len(kinds) > 0 {
+			reason = " (" + strings.Join(kinds, ", ") + ")"
+		}
+		return fmt.Sprintf("No resources were found, but %s could not read the account%s, so the scan may have been unable to see them rather than the account being empty. Check the scan's permissions and rescan before relying on this score.",
+			plural(errored, "check", "checks"), reason)
+	case nothingFound > 0:
+		return "No resources were found in any scanned service. The account appears to be empty: passing checks mean there was nothing to evaluate, not that resources are configured securely."
+	default:
+		return ""
+	}
+}
+
+// controlSeverity returns the control's severity, falling back to the
+// severity named in its priority (e.g. "CRITICAL - ...")
+func controlSeverity(control offline.CachedControl) string {
+	severity := strings.ToUpper(control.Severity)
+	if _, ok := severityRank[severity]; ok {
+		return severity
+	}
+	priority := strings.ToUpper(control.Priority)
+	for _, candidate := range []string{"CRITICAL", "HIGH", "MEDIUM", "LOW"} {
+		if strings.Contains(priority, candidate) {
+			return candidate
+		}
+	}
+	return ""
+}
+
+func plural(n int, singular, pluralForm string) string {
+	if n == 1 {
+		return "1 " + singular
+	}
+	return fmt.Sprintf("%d %s", n, pluralForm)
+}
+
+// joinWithAnd joins items as "a, b, and c" ("a and b" for two)
+func joinWithAnd(items []string) string {
+	switch len(items) {
+	case 0:
+		return ""
+	case 1:
+		return items[0]
+	case 2:
+		return items[0] + " and " + items[1]
+	default:
+		return strings.Join(items[:len(items)-1], ", ") + ", and " + items[len(items)-1]
+	}
+}